@@ -0,0 +1,179 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+)
+
+type (
+	// LoadBalancingResolver is the way the agents discover the gateway collectors.
+	// +kubebuilder:validation:Enum=dns;k8s
+	LoadBalancingResolver string
+
+	// TracesRoutingKey is the key the agents route the spans to the gateway collectors by.
+	// +kubebuilder:validation:Enum=traceID;service
+	TracesRoutingKey string
+
+	// MetricsRoutingKey is the key the agents route the data points to the gateway collectors by.
+	// +kubebuilder:validation:Enum=service;resource;metric;streamID
+	MetricsRoutingKey string
+)
+
+const (
+	// LoadBalancingResolverDNS resolves the gateway collectors through the headless Service of the gateway.
+	LoadBalancingResolverDNS LoadBalancingResolver = "dns"
+
+	// LoadBalancingResolverK8s watches the endpoints of the headless Service of the gateway from the Kubernetes API.
+	LoadBalancingResolverK8s LoadBalancingResolver = "k8s"
+
+	// TracesRoutingKeyTraceID sends all the spans of a trace to the same gateway collector.
+	TracesRoutingKeyTraceID TracesRoutingKey = "traceID"
+
+	// TracesRoutingKeyService sends all the spans of a service to the same gateway collector.
+	TracesRoutingKeyService TracesRoutingKey = "service"
+
+	// MetricsRoutingKeyService sends all the data points of a service to the same gateway collector.
+	MetricsRoutingKeyService MetricsRoutingKey = "service"
+
+	// MetricsRoutingKeyResource sends all the data points of a resource to the same gateway collector.
+	MetricsRoutingKeyResource MetricsRoutingKey = "resource"
+
+	// MetricsRoutingKeyMetric sends all the data points of a metric to the same gateway collector.
+	MetricsRoutingKeyMetric MetricsRoutingKey = "metric"
+
+	// MetricsRoutingKeyStreamID sends all the data points of a stream to the same gateway collector.
+	MetricsRoutingKeyStreamID MetricsRoutingKey = "streamID"
+)
+
+const (
+	// CollectorTopologyConditionReady indicates whether all the tiers of the CollectorTopology are ready.
+	CollectorTopologyConditionReady = "Ready"
+)
+
+// CollectorTopologySpec defines the desired state of CollectorTopology.
+type CollectorTopologySpec struct {
+	// Agent is the tier running on every node as a DaemonSet. Its pipelines export to the gateway tier through the
+	// loadbalancing exporter, which the operator adds to the configuration.
+	// +required
+	Agent CollectorTierSpec `json:"agent"`
+
+	// Gateway is the tier running as a StatefulSet, processing the telemetry which must reach a single collector, such
+	// as the spans of a trace for tail sampling.
+	// +required
+	Gateway CollectorTierSpec `json:"gateway"`
+
+	// LoadBalancing configures how the agents route the telemetry to the gateway collectors.
+	// +optional
+	LoadBalancing LoadBalancingSpec `json:"loadBalancing,omitempty"`
+}
+
+// CollectorTierSpec defines the collectors of a tier of a CollectorTopology.
+type CollectorTierSpec struct {
+	// OpenTelemetryCommonFields are fields that are on all OpenTelemetry CRD workloads.
+	v1beta1.OpenTelemetryCommonFields `json:",inline"`
+
+	// Config is the raw JSON to be used as the collector's configuration. Refer to the OpenTelemetry Collector documentation for details.
+	// The empty objects e.g. batch: should be written as batch: {} otherwise they won't work with kustomize or kubectl edit.
+	// +required
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Config v1beta1.Config `json:"config"`
+}
+
+// LoadBalancingSpec defines how the agents route the telemetry to the gateway collectors.
+type LoadBalancingSpec struct {
+	// Resolver is the way the agents discover the gateway collectors. The dns resolver queries the headless Service of
+	// the gateway, the k8s resolver watches its endpoints, which picks up scaling faster but requires the operator to
+	// grant the agents access to the EndpointSlices of the namespace. Defaults to dns.
+	// +optional
+	Resolver LoadBalancingResolver `json:"resolver,omitempty"`
+
+	// Port is the port of the OTLP gRPC receiver of the gateway collectors. Defaults to 4317.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port int32 `json:"port,omitempty"`
+
+	// TracesRoutingKey is the key the agents route the spans by. It must be traceID when the gateway tail samples the
+	// traces. Defaults to traceID.
+	// +optional
+	TracesRoutingKey TracesRoutingKey `json:"tracesRoutingKey,omitempty"`
+
+	// MetricsRoutingKey is the key the agents route the data points by. Defaults to service.
+	// +optional
+	MetricsRoutingKey MetricsRoutingKey `json:"metricsRoutingKey,omitempty"`
+
+	// Protocol holds the settings of the OTLP exporter sending to the gateway collectors, such as tls or compression.
+	// Defaults to an insecure connection.
+	// +optional
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Protocol *v1beta1.AnyConfig `json:"protocol,omitempty"`
+}
+
+// CollectorTopologyStatus defines the observed state of CollectorTopology.
+type CollectorTopologyStatus struct {
+	// Agent is the status of the agent tier.
+	// +optional
+	Agent CollectorTierStatus `json:"agent,omitempty"`
+
+	// Gateway is the status of the gateway tier.
+	// +optional
+	Gateway CollectorTierStatus `json:"gateway,omitempty"`
+
+	// ObservedGeneration is the most recent generation observed for this CollectorTopology.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions represents the latest available observations of the CollectorTopology's current state.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// CollectorTierStatus defines the observed state of a tier of a CollectorTopology.
+type CollectorTierStatus struct {
+	// Collector is the name of the OpenTelemetryCollector of the tier.
+	// +optional
+	Collector string `json:"collector,omitempty"`
+
+	// StatusReplicas is the number of ready pods of the tier over the number of its pods.
+	// +optional
+	StatusReplicas string `json:"statusReplicas,omitempty"`
+
+	// Ready indicates whether all the pods of the tier are ready.
+	// +optional
+	Ready bool `json:"ready,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=otelcoltopology;otelcoltopologies
+// +kubebuilder:printcolumn:name="Agent",type="string",JSONPath=".status.agent.statusReplicas",description="Ready agent pods"
+// +kubebuilder:printcolumn:name="Gateway",type="string",JSONPath=".status.gateway.statusReplicas",description="Ready gateway pods"
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +operator-sdk:csv:customresourcedefinitions:displayName="Collector Topology"
+// +operator-sdk:csv:customresourcedefinitions:resources={{OpenTelemetryCollector,v1beta1}}
+
+// CollectorTopology is the Schema for the collectortopologies API. It deploys an agent tier load balancing the
+// telemetry to a gateway tier.
+type CollectorTopology struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CollectorTopologySpec   `json:"spec,omitempty"`
+	Status CollectorTopologyStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// CollectorTopologyList contains a list of CollectorTopology.
+type CollectorTopologyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CollectorTopology `json:"items"`
+}
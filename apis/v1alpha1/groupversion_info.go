@@ -36,6 +36,8 @@ func addKnownTypes(s *runtime.Scheme) error {
 		&TargetAllocatorList{},
 		&ClusterObservability{},
 		&ClusterObservabilityList{},
+		&CollectorTopology{},
+		&CollectorTopologyList{},
 	)
 	metav1.AddToGroupVersion(s, GroupVersion)
 	return nil
@@ -5,7 +5,7 @@ package v1alpha1
 
 type (
 	// OpAMPBridgeCapability represents capability supported by OpAMP Bridge.
-	// +kubebuilder:validation:Enum=AcceptsRemoteConfig;ReportsEffectiveConfig;ReportsOwnTraces;ReportsOwnMetrics;ReportsOwnLogs;AcceptsOpAMPConnectionSettings;AcceptsOtherConnectionSettings;AcceptsRestartCommand;ReportsHealth;ReportsRemoteConfig
+	// +kubebuilder:validation:Enum=AcceptsRemoteConfig;ReportsEffectiveConfig;ReportsOwnTraces;ReportsOwnMetrics;ReportsOwnLogs;AcceptsOpAMPConnectionSettings;AcceptsOtherConnectionSettings;AcceptsRestartCommand;ReportsHealth;ReportsRemoteConfig;AcceptsPackages;ReportsPackageStatuses
 	OpAMPBridgeCapability string
 )
 
@@ -21,4 +21,6 @@ const (
 	OpAMPBridgeCapabilityAcceptsRestartCommand          OpAMPBridgeCapability = "AcceptsRestartCommand"
 	OpAMPBridgeCapabilityReportsHealth                  OpAMPBridgeCapability = "ReportsHealth"
 	OpAMPBridgeCapabilityReportsRemoteConfig            OpAMPBridgeCapability = "ReportsRemoteConfig"
+	OpAMPBridgeCapabilityAcceptsPackages                OpAMPBridgeCapability = "AcceptsPackages"
+	OpAMPBridgeCapabilityReportsPackageStatuses         OpAMPBridgeCapability = "ReportsPackageStatuses"
 )
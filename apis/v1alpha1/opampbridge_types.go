@@ -29,6 +29,46 @@ type OpAMPBridgeSpec struct {
 	// ComponentsAllowed is a list of allowed OpenTelemetry components for each pipeline type (receiver, processor, etc.)
 	// +optional
 	ComponentsAllowed map[string][]string `json:"componentsAllowed,omitempty"`
+	// ResourcesAllowed lists the kinds besides OpenTelemetryCollector (Instrumentation, TargetAllocator) the
+	// OpAMP Bridge accepts remote config for, each with the spec fields the remote config may change.
+	// An empty list allows every field of the kind.
+	// +optional
+	ResourcesAllowed map[string][]string `json:"resourcesAllowed,omitempty"`
+	// AtomicRemoteConfig applies each remote config all at once or not at all. Every entry is validated with a
+	// dry-run before any is applied, and the applied entries are reverted if a later one fails.
+	// +optional
+	AtomicRemoteConfig bool `json:"atomicRemoteConfig,omitempty"`
+	// Packages maps the OpAMP packages the bridge accepts, with the AcceptsPackages capability, to the image of
+	// the managed collectors labeled with opentelemetry.io/opamp-package set to the package name.
+	// +optional
+	Packages map[string]OpAMPBridgePackage `json:"packages,omitempty"`
+	// Audit records the remote config applies, deletes and restarts made by the bridge, and serves them on the
+	// /audit endpoint of the health server.
+	// +optional
+	Audit *OpAMPBridgeAudit `json:"audit,omitempty"`
+	// SignatureVerification, when set, only applies a remote config when its entries match a manifest signed by one
+	// of the trust roots. The manifest is carried in the opamp-bridge.manifest entry, and its signature in the
+	// opamp-bridge.manifest.sig entry.
+	// +optional
+	SignatureVerification *OpAMPBridgeSignatureVerification `json:"signatureVerification,omitempty"`
+	// Multiplexing, when set, represents each managed OpenTelemetryCollector as its own OpAMP agent, with its own
+	// connection, instance UID, description, health and remote config, instead of a single aggregated agent.
+	// +optional
+	Multiplexing *OpAMPBridgeMultiplexing `json:"multiplexing,omitempty"`
+	// DriftDetection, when set, watches the managed OpenTelemetryCollectors for changes made outside of OpAMP, and
+	// reports them through the effective config and a drift health component.
+	// +optional
+	DriftDetection *OpAMPBridgeDriftDetection `json:"driftDetection,omitempty"`
+	// LeaderElection, when set, elects through a Lease the replica holding the OpAMP connection and shares the instance
+	// UID of the bridge between the replicas through a Secret, allowing more than one replica. The Service of the
+	// bridge then only selects the pod of the leader, which it labels.
+	// +optional
+	LeaderElection *OpAMPBridgeLeaderElection `json:"leaderElection,omitempty"`
+	// TelemetryForwarding, when set, forwards the telemetry connection settings received from the OpAMP server to the
+	// managed OpenTelemetryCollectors, as the destinations of their internal telemetry. It requires the
+	// AcceptsOtherConnectionSettings capability.
+	// +optional
+	TelemetryForwarding *OpAMPBridgeTelemetryForwarding `json:"telemetryForwarding,omitempty"`
 	// Description allows the customization of the non identifying attributes for the OpAMP Bridge.
 	// +optional
 	Description *AgentDescription `json:"description,omitempty"`
@@ -38,9 +78,8 @@ type OpAMPBridgeSpec struct {
 	// NodeSelector to schedule OpAMPBridge pods.
 	// +optional
 	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
-	// Replicas is the number of pod instances for the OpAMPBridge.
+	// Replicas is the number of pod instances for the OpAMPBridge. More than one replica requires LeaderElection.
 	// +optional
-	// +kubebuilder:validation:Maximum=1
 	Replicas *int32 `json:"replicas,omitempty"`
 	// SecurityContext will be set as the container security context.
 	// +optional
@@ -120,6 +159,95 @@ type OpAMPBridgeStatus struct {
 	Version string `json:"version,omitempty"`
 }
 
+// OpAMPBridgePackage describes the collector image an OpAMP package is rolled out to.
+type OpAMPBridgePackage struct {
+	// Image is the image repository of the package, without tag or digest. The version offered by the OpAMP
+	// server is the image tag, the image digest, or both as <tag>@<digest>.
+	Image string `json:"image" yaml:"image"`
+	// DigestsAllowed, when set, only accepts versions pinned to one of these image digests.
+	// +optional
+	// +listType=set
+	DigestsAllowed []string `json:"digestsAllowed,omitempty" yaml:"digestsAllowed,omitempty"`
+}
+
+// OpAMPBridgeAudit describes where the audit log of the OpAMP Bridge is kept.
+type OpAMPBridgeAudit struct {
+	// Storage is where the records are persisted: a ConfigMap in the namespace of the bridge, or a file.
+	// +kubebuilder:validation:Enum=configmap;file
+	Storage string `json:"storage" yaml:"storage"`
+	// ConfigMap is the name of the ConfigMap of the configmap storage. Defaults to <bridge name>-audit.
+	// +optional
+	ConfigMap string `json:"configMap,omitempty" yaml:"configMap,omitempty"`
+	// Path is the file of the file storage, usually on a mounted volume.
+	// +optional
+	Path string `json:"path,omitempty" yaml:"path,omitempty"`
+	// MaxRecords bounds the number of records kept, the oldest ones are dropped first. Defaults to 100.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=1000
+	MaxRecords int `json:"maxRecords,omitempty" yaml:"maxRecords,omitempty"`
+	// EmitLogs sends each record as an OTLP log to the own logs destination supplied by the OpAMP server. It
+	// requires the ReportsOwnLogs capability.
+	// +optional
+	EmitLogs bool `json:"emitLogs,omitempty" yaml:"emitLogs,omitempty"`
+}
+
+// OpAMPBridgeSignatureVerification describes the trust roots the signatures of remote config manifests are verified
+// with.
+type OpAMPBridgeSignatureVerification struct {
+	// TrustRoots are the PEM encoded public keys or x509 certificates the signatures are verified with. ECDSA,
+	// Ed25519 and RSA keys are supported.
+	// +kubebuilder:validation:MinItems=1
+	TrustRoots []string `json:"trustRoots" yaml:"trustRoots"`
+}
+
+// OpAMPBridgeMultiplexing describes how the OpAMP connections of the managed collectors are opened in multiplexing
+// mode.
+type OpAMPBridgeMultiplexing struct {
+	// MaxConnections bounds the number of OpAMP connections. Collectors past the limit are connected once others are
+	// removed. Defaults to 100.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MaxConnections int `json:"maxConnections,omitempty"`
+	// SyncInterval is how often the managed collectors are listed, to connect the new ones and disconnect the
+	// removed ones. Defaults to 30s.
+	// +optional
+	SyncInterval *metav1.Duration `json:"syncInterval,omitempty"`
+}
+
+// OpAMPBridgeDriftDetection describes what the bridge does with a managed collector changed outside of OpAMP.
+type OpAMPBridgeDriftDetection struct {
+	// Policy is what the bridge does with a drifted collector: report it, enforce the last remote config on it
+	// again, or adopt the change and report it as the effective config. Defaults to report.
+	// +optional
+	// +kubebuilder:validation:Enum=report;enforce;adopt
+	Policy string `json:"policy,omitempty" yaml:"policy,omitempty"`
+}
+
+// OpAMPBridgeLeaderElection describes the Lease electing the replica of the bridge holding the OpAMP connection, and
+// the Secret holding the instance UID of the bridge.
+type OpAMPBridgeLeaderElection struct {
+	// LeaseName is the name of the Lease. Defaults to <bridge name>-leader.
+	// +optional
+	LeaseName string `json:"leaseName,omitempty" yaml:"leaseName,omitempty"`
+	// IdentitySecret is the name of the Secret holding the instance UID. Defaults to <bridge name>-identity.
+	// +optional
+	IdentitySecret string `json:"identitySecret,omitempty" yaml:"identitySecret,omitempty"`
+}
+
+// OpAMPBridgeTelemetryForwarding describes which telemetry connection settings the bridge forwards to the managed
+// collectors.
+type OpAMPBridgeTelemetryForwarding struct {
+	// Signals lists the signals whose destinations are forwarded. Defaults to metrics, traces and logs.
+	// +optional
+	// +listType=set
+	Signals []OpAMPBridgeTelemetrySignal `json:"signals,omitempty" yaml:"signals,omitempty"`
+}
+
+// OpAMPBridgeTelemetrySignal is a signal of the internal telemetry of a collector.
+// +kubebuilder:validation:Enum=metrics;traces;logs
+type OpAMPBridgeTelemetrySignal string
+
 type AgentDescription struct {
 	// NonIdentifyingAttributes are a map of key-value pairs that may be specified to provide
 	// extra information about the agent to the OpAMP server.
@@ -35,6 +35,13 @@ type MetricSpec struct {
 
 // AutoscalerSpec defines the OpenTelemetryCollector's pod autoscaling specification.
 type AutoscalerSpec struct {
+	// Type is the kind of autoscaler managing the collector's replicas: hpa for a HorizontalPodAutoscaler, or keda
+	// for a KEDA ScaledObject. Defaults to hpa.
+	// +optional
+	Type AutoscalerType `json:"type,omitempty"`
+	// Keda configures the triggers of the KEDA ScaledObject, when the type is keda.
+	// +optional
+	Keda *KedaAutoscalerSpec `json:"keda,omitempty"`
 	// MinReplicas sets a lower bound to the autoscaling feature.  Set this if you are using autoscaling. It must be at least 1
 	// +optional
 	// +kubebuilder:validation:Minimum=1
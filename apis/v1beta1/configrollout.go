@@ -0,0 +1,55 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ConfigRolloutSpec defines how configuration changes are rolled out to the collector pods.
+type ConfigRolloutSpec struct {
+	// CanaryReplicas is the number of pods running a new configuration while it's analyzed.
+	// In deployment mode, the canary pods are run by a separate Deployment, in addition to the collector's replicas.
+	// In statefulset mode, the pods with the highest ordinals are updated first.
+	// +optional
+	// +kubebuilder:default:=1
+	// +kubebuilder:validation:Minimum=1
+	CanaryReplicas int32 `json:"canaryReplicas,omitempty"`
+	// AnalysisDuration is how long the canary pods have to stay healthy before the configuration is promoted to every pod.
+	// Canary pods that aren't ready by then cause the configuration to be rolled back.
+	// +optional
+	// +kubebuilder:default:="5m"
+	AnalysisDuration metav1.Duration `json:"analysisDuration,omitempty"`
+	// MaxExportFailurePercent is the share of the telemetry the exporters of the canary pods may fail to send, as reported
+	// by the otelcol_exporter_send_failed_* metrics, before the configuration is rolled back.
+	// +optional
+	// +kubebuilder:default:=5
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	MaxExportFailurePercent int32 `json:"maxExportFailurePercent,omitempty"`
+}
+
+// ConfigRolloutStatus tracks the progress of configuration rollouts.
+type ConfigRolloutStatus struct {
+	// Stable is the last configuration promoted to every pod.
+	// +optional
+	Stable *ConfigVersion `json:"stable,omitempty"`
+	// Canary is the configuration being analyzed on the canary pods.
+	// +optional
+	Canary *ConfigVersion `json:"canary,omitempty"`
+	// CanaryStartTime is when the analysis of the Canary configuration started.
+	// +optional
+	CanaryStartTime *metav1.Time `json:"canaryStartTime,omitempty"`
+	// Failed is the last configuration rolled back. It's not rolled out again until the configuration changes.
+	// +optional
+	Failed *ConfigVersion `json:"failed,omitempty"`
+}
+
+// ConfigVersion identifies a version of the collector configuration.
+type ConfigVersion struct {
+	// ConfigMap is the name of the ConfigMap holding this version.
+	ConfigMap string `json:"configMap"`
+	// Hash is the hash of the configuration, as set on the pods' opentelemetry-operator-config/sha256 annotation.
+	Hash string `json:"hash"`
+}
@@ -0,0 +1,22 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	v1 "k8s.io/api/core/v1"
+)
+
+// ConfigSource references a fragment of the collector's configuration. Exactly one of the references must be set.
+// +kubebuilder:validation:XValidation:rule="has(self.configMapKeyRef) != has(self.secretKeyRef)",message="exactly one of configMapKeyRef or secretKeyRef must be set"
+type ConfigSource struct {
+	// ConfigMapKeyRef selects a key of a ConfigMap in the collector's namespace holding a configuration fragment.
+	// +optional
+	ConfigMapKeyRef *v1.ConfigMapKeySelector `json:"configMapKeyRef,omitempty"`
+	// SecretKeyRef selects a key of a Secret in the collector's namespace holding a configuration fragment.
+	// The Secret must have the opentelemetry.io/config-fragment label. The collector reads the fragment from the
+	// mounted Secret, so that its content isn't stored in the collector's ConfigMap. The fragment must not configure
+	// receivers or connectors, nor set a list that is set by another source of the configuration.
+	// +optional
+	SecretKeyRef *v1.SecretKeySelector `json:"secretKeyRef,omitempty"`
+}
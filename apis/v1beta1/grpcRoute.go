@@ -0,0 +1,22 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+// GRPCRouteConfig represents the gRPC route configuration for the Gateway API.
+type GRPCRouteConfig struct {
+	// Enabled indicates whether the gRPC route configuration is enabled.
+	Enabled bool `json:"enabled" yaml:"enabled"`
+
+	// Gateway specifies the name of the Gateway resource to associate with the gRPC route.
+	Gateway string `json:"gateway" yaml:"gateway"`
+
+	// GatewayNamespace specifies the namespace of the Gateway resource.
+	// Default is the same namespace as the collector.
+	GatewayNamespace string `json:"gatewayNamespace,omitempty" yaml:"gatewayNamespace,omitempty"`
+
+	// Hostnames specifies the hostnames for the gRPC route.
+	// Multiple hostnames can be specified to match requests with any of the given hostnames.
+	// If empty, the route matches requests with any hostname.
+	Hostnames []string `json:"hostnames,omitempty" yaml:"hostnames,omitempty"`
+}
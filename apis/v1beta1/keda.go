@@ -0,0 +1,139 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+type (
+	// AutoscalerType represents the kind of autoscaler managing the collector's replicas.
+	// +kubebuilder:validation:Enum=hpa;keda
+	AutoscalerType string
+)
+
+const (
+	// AutoscalerTypeHPA renders a HorizontalPodAutoscaler.
+	AutoscalerTypeHPA AutoscalerType = "hpa"
+
+	// AutoscalerTypeKeda renders a KEDA ScaledObject, which requires KEDA to be installed in the cluster.
+	AutoscalerTypeKeda AutoscalerType = "keda"
+)
+
+// KedaAutoscalerSpec defines the KEDA ScaledObject scaling the collector. The triggers are combined with the
+// targetCPUUtilization and targetMemoryUtilization of the autoscaler, the collector is scaled on the trigger
+// requiring the most replicas.
+type KedaAutoscalerSpec struct {
+	// PollingInterval is the interval in seconds at which KEDA checks the triggers. Defaults to 30 seconds.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	PollingInterval *int32 `json:"pollingInterval,omitempty"`
+
+	// Prometheus is the Prometheus server scraping the collector's own metrics, which the exporterQueueSize and
+	// refusedSpans triggers query.
+	// +optional
+	Prometheus *KedaPrometheusSpec `json:"prometheus,omitempty"`
+
+	// ExporterQueueSize scales the collector on the number of batches waiting in the sending queues of its
+	// exporters, from the otelcol_exporter_queue_size metric. The target is the average queue size per replica.
+	// +optional
+	ExporterQueueSize *KedaMetricTarget `json:"exporterQueueSize,omitempty"`
+
+	// RefusedSpans scales the collector on the spans its receivers refuse, from the otelcol_receiver_refused_spans
+	// metric. The target is the average rate of refused spans per second and per replica.
+	// +optional
+	RefusedSpans *KedaMetricTarget `json:"refusedSpans,omitempty"`
+
+	// KafkaLag scales the collector on the lag of the consumer group of its kafka receiver.
+	// +optional
+	KafkaLag *KedaKafkaLagTrigger `json:"kafkaLag,omitempty"`
+
+	// Triggers are additional KEDA triggers, added as they are to the ScaledObject.
+	// See https://keda.sh/docs/latest/scalers/ for the available scalers.
+	// +optional
+	// +listType=atomic
+	Triggers []KedaTrigger `json:"triggers,omitempty"`
+}
+
+// KedaPrometheusSpec defines the Prometheus server queried by the KEDA triggers on the collector's own metrics.
+type KedaPrometheusSpec struct {
+	// ServerAddress is the URL of the Prometheus server, such as http://prometheus.monitoring.svc:9090.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	ServerAddress string `json:"serverAddress"`
+
+	// AuthenticationRef references the KEDA TriggerAuthentication used to query the Prometheus server.
+	// +optional
+	AuthenticationRef *KedaAuthenticationRef `json:"authenticationRef,omitempty"`
+}
+
+// KedaMetricTarget defines the target value of a KEDA trigger.
+type KedaMetricTarget struct {
+	// TargetValue is the average value of the metric per replica the collector is scaled to.
+	// +required
+	TargetValue resource.Quantity `json:"targetValue"`
+}
+
+// KedaKafkaLagTrigger defines a KEDA trigger on the lag of a Kafka consumer group.
+type KedaKafkaLagTrigger struct {
+	// BootstrapServers is the comma-separated list of the Kafka brokers.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	BootstrapServers string `json:"bootstrapServers"`
+
+	// ConsumerGroup is the consumer group of the kafka receiver.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	ConsumerGroup string `json:"consumerGroup"`
+
+	// Topic is the topic the kafka receiver consumes. Defaults to all the topics of the consumer group.
+	// +optional
+	Topic string `json:"topic,omitempty"`
+
+	// LagThreshold is the average lag per replica the collector is scaled to.
+	// +required
+	// +kubebuilder:validation:Minimum=1
+	LagThreshold int64 `json:"lagThreshold"`
+
+	// AuthenticationRef references the KEDA TriggerAuthentication used to connect to the Kafka brokers.
+	// +optional
+	AuthenticationRef *KedaAuthenticationRef `json:"authenticationRef,omitempty"`
+}
+
+// KedaTrigger defines a KEDA trigger.
+type KedaTrigger struct {
+	// Type is the type of the KEDA scaler, such as prometheus or kafka.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	Type string `json:"type"`
+
+	// Name is the name of the trigger.
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// Metadata is the configuration of the scaler.
+	// +optional
+	Metadata map[string]string `json:"metadata,omitempty"`
+
+	// MetricType is the type of the metric target: AverageValue, Value or Utilization.
+	// +optional
+	MetricType autoscalingv2.MetricTargetType `json:"metricType,omitempty"`
+
+	// AuthenticationRef references the KEDA TriggerAuthentication of the scaler.
+	// +optional
+	AuthenticationRef *KedaAuthenticationRef `json:"authenticationRef,omitempty"`
+}
+
+// KedaAuthenticationRef references a KEDA TriggerAuthentication or ClusterTriggerAuthentication.
+type KedaAuthenticationRef struct {
+	// Name is the name of the TriggerAuthentication.
+	// +required
+	Name string `json:"name"`
+
+	// Kind is the kind of the authentication, TriggerAuthentication by default.
+	// +optional
+	// +kubebuilder:validation:Enum=TriggerAuthentication;ClusterTriggerAuthentication
+	Kind string `json:"kind,omitempty"`
+}
@@ -10,4 +10,14 @@ type NetworkPolicy struct {
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Enable NetworkPolicy"
 	Enabled *bool `json:"enabled,omitempty"`
+
+	// DisableEgress stops restricting the egress traffic of the collector's pods, so that only their ingress traffic is
+	// restricted. By default, the egress traffic is restricted to the destinations derived from the collector's
+	// configuration, such as the endpoints of its exporters.
+	// A NetworkPolicy can't select host names: the destinations given by a host name, other than the services of the
+	// cluster, are only restricted to their port, to any IP address.
+	// The NetworkPolicy of the TargetAllocator always restricts its egress traffic.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Disable Egress Rules"
+	DisableEgress bool `json:"disableEgress,omitempty"`
 }
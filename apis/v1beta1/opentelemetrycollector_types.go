@@ -17,6 +17,7 @@ import (
 // +kubebuilder:printcolumn:name="Mode",type="string",JSONPath=".spec.mode",description="Deployment Mode"
 // +kubebuilder:printcolumn:name="Version",type="string",JSONPath=".status.version",description="OpenTelemetry Version"
 // +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.scale.statusReplicas"
+// +kubebuilder:printcolumn:name="Healthy",type="string",JSONPath=".status.conditions[?(@.type==\"ComponentsHealthy\")].status",description="Whether the components of the collector report no error"
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:printcolumn:name="Image",type="string",JSONPath=".status.image"
 // +kubebuilder:printcolumn:name="Management",type="string",JSONPath=".spec.managementState",description="Management State"
@@ -63,6 +64,10 @@ type OpenTelemetryCollectorStatus struct {
 	// ObservedGeneration is the most recent generation observed for this OpenTelemetryCollector. It corresponds to the OpenTelemetryCollector's generation, which is updated on mutation by the API Server.
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 
+	// ConfigRollout tracks the progress of configuration rollouts, when spec.configRollout is set.
+	// +optional
+	ConfigRollout *ConfigRolloutStatus `json:"configRollout,omitempty"`
+
 	// Conditions represents the latest available observations of the OpenTelemetryCollector's current state.
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
@@ -82,6 +87,9 @@ type OpenTelemetryCollectorSpec struct {
 	// for the workload.
 	// +optional
 	Autoscaler *AutoscalerSpec `json:"autoscaler,omitempty"`
+	// VerticalAutoscaler specifies the VerticalPodAutoscaler adjusting the resources of the collector container.
+	// +optional
+	VerticalAutoscaler *VerticalAutoscalerSpec `json:"verticalAutoscaler,omitempty"`
 	// TargetAllocator indicates a value which determines whether to spawn a target allocation resource or not.
 	// +optional
 	TargetAllocator TargetAllocatorEmbedded `json:"targetAllocator,omitempty"`
@@ -96,12 +104,24 @@ type OpenTelemetryCollectorSpec struct {
 	// +required
 	// +kubebuilder:pruning:PreserveUnknownFields
 	Config Config `json:"config"`
+	// ConfigFrom references fragments of the collector's configuration stored in ConfigMaps or Secrets of the
+	// collector's namespace. The fragments are merged into Config in order: maps are merged, component lists, such as
+	// the receivers of a pipeline, are combined, and setting a different value for the same key is an error.
+	// The collector is rolled out again when a fragment changes.
+	// This is not applicable to Sidecar mode.
+	// +optional
+	ConfigFrom []ConfigSource `json:"configFrom,omitempty"`
 	// ConfigVersions defines the number versions to keep for the collector config. Each config version is stored in a separate ConfigMap.
 	// Defaults to 3. The minimum value is 1.
 	// +optional
 	// +kubebuilder:default:=3
 	// +kubebuilder:validation:Minimum:=1
 	ConfigVersions int `json:"configVersions,omitempty"`
+	// ConfigRollout rolls configuration changes out to a canary subset of the pods first, and promotes them to every pod
+	// once the canary pods are healthy, or rolls them back to the last healthy configuration.
+	// This is only applicable to Deployment and StatefulSet modes.
+	// +optional
+	ConfigRollout *ConfigRolloutSpec `json:"configRollout,omitempty"`
 	// Ingress is used to specify how OpenTelemetry Collector is exposed. This
 	// functionality is only available if one of the valid modes is set.
 	// Valid modes are: deployment, daemonset and statefulset.
@@ -112,9 +132,37 @@ type OpenTelemetryCollectorSpec struct {
 	// Valid modes are: deployment, daemonset and statefulset.
 	// +optional
 	HttpRoute HttpRouteConfig `json:"httpRoute,omitempty"`
+	// GRPCRoute is used to specify how the gRPC receivers of the OpenTelemetry Collector are exposed via Gateway API GRPCRoute.
+	// This functionality is only available if one of the valid modes is set.
+	// Valid modes are: deployment, daemonset and statefulset.
+	// +optional
+	GRPCRoute GRPCRouteConfig `json:"grpcRoute,omitempty"`
+	// TLSRoute is used to specify how the OpenTelemetry Collector is exposed via Gateway API TLSRoute, passing the TLS
+	// connections through to the receivers.
+	// This functionality is only available if one of the valid modes is set.
+	// Valid modes are: deployment, daemonset and statefulset.
+	// +optional
+	TLSRoute TLSRouteConfig `json:"tlsRoute,omitempty"`
 	// NetworkPolicy defines the network policy to be applied to the OpenTelemetry Collector pods.
 	// +optional
 	NetworkPolicy NetworkPolicy `json:"networkPolicy,omitempty"`
+	// TLS requests a certificate for the receivers of the collector from cert-manager and configures the receivers
+	// serving TLS with it.
+	// This is not applicable to Sidecar mode.
+	// +optional
+	TLS *ReceiverTLSSpec `json:"tls,omitempty"`
+	// Persistence provisions storage for the directories of the file_storage extensions of the collector, and sets the
+	// fsGroup of the pods so that the collector can write to it.
+	// This is not applicable to Sidecar mode.
+	// +optional
+	Persistence *PersistenceSpec `json:"persistence,omitempty"`
+	// PodMetadataAttributes maps labels and annotations of the pod the collector is injected into to resource attributes.
+	// The values are mounted through the Downward API and read as strings by a resource processor added to every pipeline
+	// when the collector container starts. A running collector doesn't follow the changes made to the pod metadata
+	// afterward: they only reach it when its container restarts. Keys the pod doesn't have at injection are skipped.
+	// This is only applicable to Sidecar mode.
+	// +optional
+	PodMetadataAttributes *PodMetadataAttributes `json:"podMetadataAttributes,omitempty"`
 	// Liveness config for the OpenTelemetry Collector except the probe handler which is auto generated from the health extension of the collector.
 	// It is only effective when healthcheckextension is configured in the OpenTelemetry Collector pipeline.
 	// +optional
@@ -135,6 +183,13 @@ type OpenTelemetryCollectorSpec struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Observability"
 	Observability ObservabilitySpec `json:"observability,omitempty"`
 
+	// Monitoring defines the alerts and the Grafana dashboard of the collector's own metrics.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Monitoring"
+	Monitoring MonitoringSpec `json:"monitoring,omitempty"`
+
 	// ConfigMaps is a list of ConfigMaps in the same namespace as the OpenTelemetryCollector
 	// object, which shall be mounted into the Collector Pods.
 	// Each ConfigMap will be added to the Collector's Deployments as a volume named `configmap-<configmap-name>`.
@@ -420,6 +475,44 @@ type MetricsConfigSpec struct {
 	DisablePrometheusAnnotations bool `json:"disablePrometheusAnnotations,omitempty"`
 }
 
+// MonitoringSpec defines the alerts and the dashboard of the collector's own metrics.
+type MonitoringSpec struct {
+	// EnableAlerts specifies if a PrometheusRule alerting on the collector's own metrics should be created for the
+	// collector. The alerts rely on the metrics scraped through the ServiceMonitor or PodMonitor created with
+	// observability.metrics.enableMetrics.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	EnableAlerts bool `json:"enableAlerts,omitempty"`
+
+	// Dashboard defines the Grafana dashboard of the collector's own metrics.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	Dashboard *MetricsDashboardSpec `json:"dashboard,omitempty"`
+}
+
+// MetricsDashboardSpec defines the Grafana dashboard of a collector. The dashboard is a GrafanaDashboard when the
+// Grafana operator is installed, and a ConfigMap for the Grafana dashboard sidecar otherwise.
+type MetricsDashboardSpec struct {
+	// Enabled specifies if the dashboard should be created.
+	//
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+
+	// Labels are the labels of the ConfigMap of the dashboard, which the Grafana dashboard sidecar discovers the
+	// dashboards by. Defaults to grafana_dashboard: "1", the label of the Grafana Helm chart.
+	//
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// InstanceSelector selects the Grafana instances importing the GrafanaDashboard. Defaults to all the instances in
+	// the namespace of the collector.
+	//
+	// +optional
+	InstanceSelector *metav1.LabelSelector `json:"instanceSelector,omitempty"`
+}
+
 // ScaleSubresourceStatus defines the observed state of the OpenTelemetryCollector's
 // scale subresource.
 type ScaleSubresourceStatus struct {
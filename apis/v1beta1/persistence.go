@@ -0,0 +1,50 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// PersistenceSpec defines the storage provisioned for the directories of the file_storage extensions of the
+// collector, such as the persistent sending queues of the exporters.
+type PersistenceSpec struct {
+	// Enabled mounts a volume at the directory of every file_storage extension of the service. The volume depends on
+	// the mode: an emptyDir in the deployment mode, which survives the restarts of the collector container, a
+	// PersistentVolumeClaim from a volumeClaimTemplate in the statefulset mode, which survives the pods, and a
+	// hostPath in the daemonset mode, which survives the pods on the same node. This is not supported in the sidecar
+	// mode.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Enable Persistence"
+	Enabled bool `json:"enabled,omitempty"`
+
+	// Size is the size of the PersistentVolumeClaims in the statefulset mode, and the size limit of the emptyDir
+	// volumes in the deployment mode. Defaults to 1Gi for the PersistentVolumeClaims, and no limit for the emptyDir
+	// volumes.
+	// +optional
+	Size *resource.Quantity `json:"size,omitempty"`
+
+	// StorageClassName is the storage class of the PersistentVolumeClaims in the statefulset mode. Defaults to the
+	// default storage class of the cluster.
+	// +optional
+	StorageClassName *string `json:"storageClassName,omitempty"`
+
+	// HostPath is the directory of the nodes holding the storage of the collectors in the daemonset mode. Each
+	// extension stores its data in the <hostPath>/<namespace>/<name>/<extension> directory. Defaults to
+	// /var/lib/otelcol.
+	// +optional
+	HostPath string `json:"hostPath,omitempty"`
+
+	// ChownHostPath adds an init container handing the hostPath directories over to the user and group of the
+	// collector in the daemonset mode. Kubernetes creates them owned by root and doesn't apply the fsGroup to them.
+	// The init container runs as root, so the pods aren't admitted under the restricted Pod Security Standard.
+	// +optional
+	ChownHostPath bool `json:"chownHostPath,omitempty"`
+
+	// Fsync sets fsync in the file_storage extensions of the service which don't configure it, so that every write is
+	// flushed to the volume. This keeps the data when the node crashes, at the cost of the throughput of the
+	// extensions.
+	// +optional
+	Fsync bool `json:"fsync,omitempty"`
+}
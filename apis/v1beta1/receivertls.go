@@ -0,0 +1,63 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type (
+	// TLSIssuerKind is the kind of the cert-manager issuer signing the certificate of the receivers.
+	// +kubebuilder:validation:Enum=Issuer;ClusterIssuer
+	TLSIssuerKind string
+)
+
+const (
+	// TLSIssuerKindIssuer references an Issuer of the collector's namespace.
+	TLSIssuerKindIssuer TLSIssuerKind = "Issuer"
+
+	// TLSIssuerKindClusterIssuer references a ClusterIssuer.
+	TLSIssuerKindClusterIssuer TLSIssuerKind = "ClusterIssuer"
+)
+
+// ReceiverTLSSpec defines the certificate cert-manager issues for the receivers of the collector.
+type ReceiverTLSSpec struct {
+	// Enabled requests a certificate covering the DNS names of the collector Service from cert-manager, and configures
+	// the receivers serving TLS (otlp, jaeger and zipkin) with it. Receivers which have a certificate configured are
+	// left as they are. This requires cert-manager to be installed in the cluster, and is not supported in the sidecar
+	// mode.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Enable Receiver TLS"
+	Enabled bool `json:"enabled,omitempty"`
+
+	// IssuerRef references the cert-manager issuer signing the certificate. When it isn't set, the operator creates a
+	// self-signed CA for the collector and an Issuer for it.
+	// +optional
+	IssuerRef *TLSIssuerReference `json:"issuerRef,omitempty"`
+
+	// ClientAuth requires the clients of the receivers to present a certificate signed by the CA of the issuer.
+	// +optional
+	ClientAuth bool `json:"clientAuth,omitempty"`
+
+	// DNSNames are additional DNS names the certificate covers, such as the host names of an Ingress.
+	// +optional
+	// +listType=atomic
+	DNSNames []string `json:"dnsNames,omitempty"`
+
+	// ReloadInterval is the interval at which the receivers reload the certificate, so that the certificates renewed
+	// by cert-manager are used without restarting the collector. Defaults to 1h.
+	// +optional
+	ReloadInterval *metav1.Duration `json:"reloadInterval,omitempty"`
+}
+
+// TLSIssuerReference references a cert-manager Issuer or ClusterIssuer.
+type TLSIssuerReference struct {
+	// Name is the name of the issuer.
+	// +required
+	Name string `json:"name"`
+
+	// Kind is the kind of the issuer. Defaults to Issuer.
+	// +optional
+	Kind TLSIssuerKind `json:"kind,omitempty"`
+}
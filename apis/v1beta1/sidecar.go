@@ -0,0 +1,20 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+// PodMetadataAttributes maps labels and annotations of the pod a sidecar is injected into to resource attributes.
+// The selected keys are projected into the sidecar through a Downward API volume, and are read by a resource processor
+// the operator adds to every pipeline when the collector container starts. The values are always read as strings, and
+// keys the pod doesn't have when the sidecar is injected are skipped.
+// A running collector doesn't pick up the changes made to the pod metadata afterward: they only reach the collector when
+// its container restarts, and the operator doesn't restart it for them.
+type PodMetadataAttributes struct {
+	// Labels maps pod label keys to the name of the resource attribute they should be exposed as.
+	// For instance, `app.kubernetes.io/version: service.version`.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+	// Annotations maps pod annotation keys to the name of the resource attribute they should be exposed as.
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
@@ -0,0 +1,20 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+// TLSRouteConfig represents the TLS passthrough route configuration for the Gateway API.
+type TLSRouteConfig struct {
+	// Enabled indicates whether the TLS route configuration is enabled.
+	Enabled bool `json:"enabled" yaml:"enabled"`
+
+	// Gateway specifies the name of the Gateway resource to associate with the TLS route.
+	Gateway string `json:"gateway" yaml:"gateway"`
+
+	// GatewayNamespace specifies the namespace of the Gateway resource.
+	// Default is the same namespace as the collector.
+	GatewayNamespace string `json:"gatewayNamespace,omitempty" yaml:"gatewayNamespace,omitempty"`
+
+	// Hostnames specifies the SNI hostnames for the TLS route. At least one hostname is required.
+	Hostnames []string `json:"hostnames,omitempty" yaml:"hostnames,omitempty"`
+}
@@ -0,0 +1,82 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+type (
+	// VerticalAutoscalerUpdateMode is the way the VerticalPodAutoscaler applies its recommendations.
+	// +kubebuilder:validation:Enum=Off;Initial;Recreate;Auto
+	VerticalAutoscalerUpdateMode string
+)
+
+const (
+	// VerticalAutoscalerUpdateModeOff only computes the recommendations, without applying them.
+	VerticalAutoscalerUpdateModeOff VerticalAutoscalerUpdateMode = "Off"
+
+	// VerticalAutoscalerUpdateModeInitial applies the recommendations when the pods are created.
+	VerticalAutoscalerUpdateModeInitial VerticalAutoscalerUpdateMode = "Initial"
+
+	// VerticalAutoscalerUpdateModeRecreate applies the recommendations by evicting the pods.
+	VerticalAutoscalerUpdateModeRecreate VerticalAutoscalerUpdateMode = "Recreate"
+
+	// VerticalAutoscalerUpdateModeAuto applies the recommendations with the best method the cluster supports.
+	VerticalAutoscalerUpdateModeAuto VerticalAutoscalerUpdateMode = "Auto"
+)
+
+// VerticalAutoscalerSpec defines the VerticalPodAutoscaler adjusting the resources of the collector container.
+type VerticalAutoscalerSpec struct {
+	// Enabled creates a VerticalPodAutoscaler for the collector. This requires the VerticalPodAutoscaler to be
+	// installed in the cluster, and is only supported in the deployment and daemonset modes.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Enable VerticalPodAutoscaler"
+	Enabled bool `json:"enabled,omitempty"`
+
+	// UpdateMode is the way the recommendations are applied to the pods. Defaults to Auto.
+	// +optional
+	UpdateMode VerticalAutoscalerUpdateMode `json:"updateMode,omitempty"`
+
+	// ControlledResources are the resources of the collector container the VerticalPodAutoscaler adjusts.
+	// Defaults to cpu and memory.
+	// +optional
+	// +listType=atomic
+	ControlledResources []corev1.ResourceName `json:"controlledResources,omitempty"`
+
+	// MinAllowed is the lower bound of the recommended resources.
+	// +optional
+	MinAllowed corev1.ResourceList `json:"minAllowed,omitempty"`
+
+	// MaxAllowed is the upper bound of the recommended resources.
+	// +optional
+	MaxAllowed corev1.ResourceList `json:"maxAllowed,omitempty"`
+
+	// MemoryLimiter tunes the memory_limiter processor of the collector to the memory limit of its container.
+	// +optional
+	MemoryLimiter MemoryLimiterTuning `json:"memoryLimiter,omitempty"`
+}
+
+// MemoryLimiterTuning defines the memory_limiter processor the operator puts at the head of every pipeline when the
+// VerticalPodAutoscaler is enabled. Its limits are percentages of the memory limit of the collector container, so that
+// they follow the limits set by the VerticalPodAutoscaler.
+type MemoryLimiterTuning struct {
+	// Disabled leaves the memory_limiter processors of the configuration as they are.
+	// +optional
+	Disabled bool `json:"disabled,omitempty"`
+
+	// LimitPercentage is the maximum amount of memory the collector uses, in percent of the container's memory
+	// limit. Defaults to 80.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	LimitPercentage *int32 `json:"limitPercentage,omitempty"`
+
+	// SpikeLimitPercentage is the expected maximum increase of the memory usage between two checks, in percent of
+	// the container's memory limit. Defaults to 20.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	SpikeLimitPercentage *int32 `json:"spikeLimitPercentage,omitempty"`
+}
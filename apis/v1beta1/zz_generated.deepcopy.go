@@ -36,6 +36,11 @@ func (in *ApacheHttpd) DeepCopy() *ApacheHttpd {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AutoscalerSpec) DeepCopyInto(out *AutoscalerSpec) {
 	*out = *in
+	if in.Keda != nil {
+		in, out := &in.Keda, &out.Keda
+		*out = new(KedaAutoscalerSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.MinReplicas != nil {
 		in, out := &in.MinReplicas, &out.MinReplicas
 		*out = new(int32)
@@ -206,6 +211,96 @@ func (in *ConfigMapsSpec) DeepCopy() *ConfigMapsSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigRolloutSpec) DeepCopyInto(out *ConfigRolloutSpec) {
+	*out = *in
+	out.AnalysisDuration = in.AnalysisDuration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigRolloutSpec.
+func (in *ConfigRolloutSpec) DeepCopy() *ConfigRolloutSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigRolloutSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigRolloutStatus) DeepCopyInto(out *ConfigRolloutStatus) {
+	*out = *in
+	if in.Stable != nil {
+		in, out := &in.Stable, &out.Stable
+		*out = new(ConfigVersion)
+		**out = **in
+	}
+	if in.Canary != nil {
+		in, out := &in.Canary, &out.Canary
+		*out = new(ConfigVersion)
+		**out = **in
+	}
+	if in.CanaryStartTime != nil {
+		in, out := &in.CanaryStartTime, &out.CanaryStartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Failed != nil {
+		in, out := &in.Failed, &out.Failed
+		*out = new(ConfigVersion)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigRolloutStatus.
+func (in *ConfigRolloutStatus) DeepCopy() *ConfigRolloutStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigRolloutStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigSource) DeepCopyInto(out *ConfigSource) {
+	*out = *in
+	if in.ConfigMapKeyRef != nil {
+		in, out := &in.ConfigMapKeyRef, &out.ConfigMapKeyRef
+		*out = new(v1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecretKeyRef != nil {
+		in, out := &in.SecretKeyRef, &out.SecretKeyRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigSource.
+func (in *ConfigSource) DeepCopy() *ConfigSource {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigVersion) DeepCopyInto(out *ConfigVersion) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigVersion.
+func (in *ConfigVersion) DeepCopy() *ConfigVersion {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigVersion)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DotNet) DeepCopyInto(out *DotNet) {
 	*out = *in
@@ -301,6 +396,26 @@ func (in *Extensions) DeepCopy() *Extensions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GRPCRouteConfig) DeepCopyInto(out *GRPCRouteConfig) {
+	*out = *in
+	if in.Hostnames != nil {
+		in, out := &in.Hostnames, &out.Hostnames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GRPCRouteConfig.
+func (in *GRPCRouteConfig) DeepCopy() *GRPCRouteConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(GRPCRouteConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Go) DeepCopyInto(out *Go) {
 	*out = *in
@@ -523,6 +638,176 @@ func (in *K8sMetadataConfig) DeepCopy() *K8sMetadataConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KedaAuthenticationRef) DeepCopyInto(out *KedaAuthenticationRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KedaAuthenticationRef.
+func (in *KedaAuthenticationRef) DeepCopy() *KedaAuthenticationRef {
+	if in == nil {
+		return nil
+	}
+	out := new(KedaAuthenticationRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KedaAutoscalerSpec) DeepCopyInto(out *KedaAutoscalerSpec) {
+	*out = *in
+	if in.PollingInterval != nil {
+		in, out := &in.PollingInterval, &out.PollingInterval
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Prometheus != nil {
+		in, out := &in.Prometheus, &out.Prometheus
+		*out = new(KedaPrometheusSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExporterQueueSize != nil {
+		in, out := &in.ExporterQueueSize, &out.ExporterQueueSize
+		*out = new(KedaMetricTarget)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RefusedSpans != nil {
+		in, out := &in.RefusedSpans, &out.RefusedSpans
+		*out = new(KedaMetricTarget)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.KafkaLag != nil {
+		in, out := &in.KafkaLag, &out.KafkaLag
+		*out = new(KedaKafkaLagTrigger)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Triggers != nil {
+		in, out := &in.Triggers, &out.Triggers
+		*out = make([]KedaTrigger, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KedaAutoscalerSpec.
+func (in *KedaAutoscalerSpec) DeepCopy() *KedaAutoscalerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KedaAutoscalerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KedaKafkaLagTrigger) DeepCopyInto(out *KedaKafkaLagTrigger) {
+	*out = *in
+	if in.AuthenticationRef != nil {
+		in, out := &in.AuthenticationRef, &out.AuthenticationRef
+		*out = new(KedaAuthenticationRef)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KedaKafkaLagTrigger.
+func (in *KedaKafkaLagTrigger) DeepCopy() *KedaKafkaLagTrigger {
+	if in == nil {
+		return nil
+	}
+	out := new(KedaKafkaLagTrigger)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KedaMetricTarget) DeepCopyInto(out *KedaMetricTarget) {
+	*out = *in
+	out.TargetValue = in.TargetValue.DeepCopy()
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KedaMetricTarget.
+func (in *KedaMetricTarget) DeepCopy() *KedaMetricTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(KedaMetricTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KedaPrometheusSpec) DeepCopyInto(out *KedaPrometheusSpec) {
+	*out = *in
+	if in.AuthenticationRef != nil {
+		in, out := &in.AuthenticationRef, &out.AuthenticationRef
+		*out = new(KedaAuthenticationRef)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KedaPrometheusSpec.
+func (in *KedaPrometheusSpec) DeepCopy() *KedaPrometheusSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KedaPrometheusSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KedaTrigger) DeepCopyInto(out *KedaTrigger) {
+	*out = *in
+	if in.Metadata != nil {
+		in, out := &in.Metadata, &out.Metadata
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.AuthenticationRef != nil {
+		in, out := &in.AuthenticationRef, &out.AuthenticationRef
+		*out = new(KedaAuthenticationRef)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KedaTrigger.
+func (in *KedaTrigger) DeepCopy() *KedaTrigger {
+	if in == nil {
+		return nil
+	}
+	out := new(KedaTrigger)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MemoryLimiterTuning) DeepCopyInto(out *MemoryLimiterTuning) {
+	*out = *in
+	if in.LimitPercentage != nil {
+		in, out := &in.LimitPercentage, &out.LimitPercentage
+		*out = new(int32)
+		**out = **in
+	}
+	if in.SpikeLimitPercentage != nil {
+		in, out := &in.SpikeLimitPercentage, &out.SpikeLimitPercentage
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MemoryLimiterTuning.
+func (in *MemoryLimiterTuning) DeepCopy() *MemoryLimiterTuning {
+	if in == nil {
+		return nil
+	}
+	out := new(MemoryLimiterTuning)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MetricSpec) DeepCopyInto(out *MetricSpec) {
 	*out = *in
@@ -565,6 +850,53 @@ func (in *MetricsConfigSpec) DeepCopy() *MetricsConfigSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetricsDashboardSpec) DeepCopyInto(out *MetricsDashboardSpec) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.InstanceSelector != nil {
+		in, out := &in.InstanceSelector, &out.InstanceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricsDashboardSpec.
+func (in *MetricsDashboardSpec) DeepCopy() *MetricsDashboardSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MetricsDashboardSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MonitoringSpec) DeepCopyInto(out *MonitoringSpec) {
+	*out = *in
+	if in.Dashboard != nil {
+		in, out := &in.Dashboard, &out.Dashboard
+		*out = new(MetricsDashboardSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonitoringSpec.
+func (in *MonitoringSpec) DeepCopy() *MonitoringSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MonitoringSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NetworkPolicy) DeepCopyInto(out *NetworkPolicy) {
 	*out = *in
@@ -717,11 +1049,45 @@ func (in *OpenTelemetryCollectorSpec) DeepCopyInto(out *OpenTelemetryCollectorSp
 		*out = new(AutoscalerSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.VerticalAutoscaler != nil {
+		in, out := &in.VerticalAutoscaler, &out.VerticalAutoscaler
+		*out = new(VerticalAutoscalerSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	in.TargetAllocator.DeepCopyInto(&out.TargetAllocator)
 	in.Config.DeepCopyInto(&out.Config)
+	if in.ConfigFrom != nil {
+		in, out := &in.ConfigFrom, &out.ConfigFrom
+		*out = make([]ConfigSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ConfigRollout != nil {
+		in, out := &in.ConfigRollout, &out.ConfigRollout
+		*out = new(ConfigRolloutSpec)
+		**out = **in
+	}
 	in.Ingress.DeepCopyInto(&out.Ingress)
 	in.HttpRoute.DeepCopyInto(&out.HttpRoute)
+	in.GRPCRoute.DeepCopyInto(&out.GRPCRoute)
+	in.TLSRoute.DeepCopyInto(&out.TLSRoute)
 	in.NetworkPolicy.DeepCopyInto(&out.NetworkPolicy)
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(ReceiverTLSSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Persistence != nil {
+		in, out := &in.Persistence, &out.Persistence
+		*out = new(PersistenceSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodMetadataAttributes != nil {
+		in, out := &in.PodMetadataAttributes, &out.PodMetadataAttributes
+		*out = new(PodMetadataAttributes)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.LivenessProbe != nil {
 		in, out := &in.LivenessProbe, &out.LivenessProbe
 		*out = new(Probe)
@@ -738,6 +1104,7 @@ func (in *OpenTelemetryCollectorSpec) DeepCopyInto(out *OpenTelemetryCollectorSp
 		(*in).DeepCopyInto(*out)
 	}
 	in.Observability.DeepCopyInto(&out.Observability)
+	in.Monitoring.DeepCopyInto(&out.Monitoring)
 	if in.ConfigMaps != nil {
 		in, out := &in.ConfigMaps, &out.ConfigMaps
 		*out = make([]ConfigMapsSpec, len(*in))
@@ -766,6 +1133,11 @@ func (in *OpenTelemetryCollectorSpec) DeepCopy() *OpenTelemetryCollectorSpec {
 func (in *OpenTelemetryCollectorStatus) DeepCopyInto(out *OpenTelemetryCollectorStatus) {
 	*out = *in
 	out.Scale = in.Scale
+	if in.ConfigRollout != nil {
+		in, out := &in.ConfigRollout, &out.ConfigRollout
+		*out = new(ConfigRolloutStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]metav1.Condition, len(*in))
@@ -963,6 +1335,31 @@ func (in *OpenTelemetryCommonFields) DeepCopy() *OpenTelemetryCommonFields {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PersistenceSpec) DeepCopyInto(out *PersistenceSpec) {
+	*out = *in
+	if in.Size != nil {
+		in, out := &in.Size, &out.Size
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.StorageClassName != nil {
+		in, out := &in.StorageClassName, &out.StorageClassName
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PersistenceSpec.
+func (in *PersistenceSpec) DeepCopy() *PersistenceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PersistenceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Pipeline) DeepCopyInto(out *Pipeline) {
 	*out = *in
@@ -1018,6 +1415,35 @@ func (in *PodDisruptionBudgetSpec) DeepCopy() *PodDisruptionBudgetSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodMetadataAttributes) DeepCopyInto(out *PodMetadataAttributes) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodMetadataAttributes.
+func (in *PodMetadataAttributes) DeepCopy() *PodMetadataAttributes {
+	if in == nil {
+		return nil
+	}
+	out := new(PodMetadataAttributes)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PortsSpec) DeepCopyInto(out *PortsSpec) {
 	*out = *in
@@ -1095,6 +1521,36 @@ func (in *Python) DeepCopy() *Python {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReceiverTLSSpec) DeepCopyInto(out *ReceiverTLSSpec) {
+	*out = *in
+	if in.IssuerRef != nil {
+		in, out := &in.IssuerRef, &out.IssuerRef
+		*out = new(TLSIssuerReference)
+		**out = **in
+	}
+	if in.DNSNames != nil {
+		in, out := &in.DNSNames, &out.DNSNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ReloadInterval != nil {
+		in, out := &in.ReloadInterval, &out.ReloadInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReceiverTLSSpec.
+func (in *ReceiverTLSSpec) DeepCopy() *ReceiverTLSSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ReceiverTLSSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Resource) DeepCopyInto(out *Resource) {
 	*out = *in
@@ -1274,6 +1730,41 @@ func (in *TLS) DeepCopy() *TLS {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TLSIssuerReference) DeepCopyInto(out *TLSIssuerReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TLSIssuerReference.
+func (in *TLSIssuerReference) DeepCopy() *TLSIssuerReference {
+	if in == nil {
+		return nil
+	}
+	out := new(TLSIssuerReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TLSRouteConfig) DeepCopyInto(out *TLSRouteConfig) {
+	*out = *in
+	if in.Hostnames != nil {
+		in, out := &in.Hostnames, &out.Hostnames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TLSRouteConfig.
+func (in *TLSRouteConfig) DeepCopy() *TLSRouteConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(TLSRouteConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TargetAllocatorEmbedded) DeepCopyInto(out *TargetAllocatorEmbedded) {
 	*out = *in
@@ -1506,3 +1997,38 @@ func (in *TargetAllocatorTLS) DeepCopy() *TargetAllocatorTLS {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VerticalAutoscalerSpec) DeepCopyInto(out *VerticalAutoscalerSpec) {
+	*out = *in
+	if in.ControlledResources != nil {
+		in, out := &in.ControlledResources, &out.ControlledResources
+		*out = make([]v1.ResourceName, len(*in))
+		copy(*out, *in)
+	}
+	if in.MinAllowed != nil {
+		in, out := &in.MinAllowed, &out.MinAllowed
+		*out = make(v1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.MaxAllowed != nil {
+		in, out := &in.MaxAllowed, &out.MaxAllowed
+		*out = make(v1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	in.MemoryLimiter.DeepCopyInto(&out.MemoryLimiter)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VerticalAutoscalerSpec.
+func (in *VerticalAutoscalerSpec) DeepCopy() *VerticalAutoscalerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VerticalAutoscalerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -9,8 +9,12 @@ import (
 	"crypto/tls"
 	"errors"
 	"fmt"
+	"maps"
 	"net/url"
+	"slices"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -20,10 +24,12 @@ import (
 	"github.com/open-telemetry/opamp-go/protobufs"
 	"k8s.io/utils/clock"
 
+	"github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/internal/audit"
 	"github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/internal/config"
 	"github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/internal/metrics"
 	"github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/internal/operator"
 	"github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/internal/proxy"
+	"github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/internal/signature"
 )
 
 type Agent struct {
@@ -33,6 +39,13 @@ type Agent struct {
 	clock       clock.Clock
 	startTime   uint64
 	lastHash    []byte
+	// lastRemoteConfig is the last remote config accepted, applied again by the resync command.
+	lastRemoteConfig *protobufs.AgentRemoteConfig
+	// manifestVersion is the version of the last verified remote config manifest, which must increase.
+	manifestVersion uint64
+	// manifestDigest is the digest of the last verified remote config manifest, which may only be verified again with
+	// the same version.
+	manifestDigest string
 
 	instanceId         uuid.UUID
 	agentDescription   *protobufs.AgentDescription
@@ -41,10 +54,38 @@ type Agent struct {
 	opampClient         client.OpAMPClient
 	proxy               proxy.Server
 	metricReporter      *metrics.MetricReporter
+	auditLog            *audit.Log
+	instanceIdStore     InstanceIdStore
+	manifestStore       ManifestVersionStore
+	verifier            *signature.Verifier
 	config              *config.Config
 	applier             operator.ConfigApplier
 	remoteConfigEnabled bool
 
+	// packagesMu guards the package statuses, updated on messages from the server and on heartbeats.
+	packagesMu sync.Mutex
+	packages   *packagesStateProvider
+
+	// applyMu serializes the changes made to the managed resources by remote configs, packages, resyncs, drift
+	// checks and forwarded telemetry settings, which run on different goroutines.
+	applyMu sync.Mutex
+	// forwardedTelemetry holds the telemetry destinations forwarded to the managed collectors, guarded by applyMu.
+	forwardedTelemetry operator.TelemetrySettings
+	// driftMu guards specHashes and drifted.
+	driftMu sync.Mutex
+	// specHashes holds the spec hash of each managed collector as last changed through OpAMP, by remote config entry
+	// name.
+	specHashes map[string]string
+	// drifted holds the remote config entry names of the collectors that drifted from their spec hash.
+	drifted map[string]bool
+
+	// commandRunning is set while a restart or a flush of the collectors runs in the background.
+	commandRunning atomic.Bool
+	// commands tracks the commands running in the background, which commandsCtx cancels on shutdown.
+	commands       sync.WaitGroup
+	commandsCtx    context.Context
+	cancelCommands context.CancelFunc
+
 	done   chan struct{}
 	ticker *time.Ticker
 }
@@ -54,21 +95,35 @@ func NewAgent(logger logr.Logger, applier operator.ConfigApplier, cfg *config.Co
 	if cfg.HeartbeatInterval > 0 {
 		t = time.NewTicker(cfg.HeartbeatInterval)
 	}
+	commandsCtx, cancelCommands := context.WithCancel(context.Background())
 	agent := &Agent{
 		config:              cfg,
 		applier:             applier,
 		proxy:               p,
 		logger:              logger,
 		appliedKeys:         map[string]bool{},
+		packages:            &packagesStateProvider{},
+		specHashes:          map[string]string{},
+		drifted:             map[string]bool{},
 		instanceId:          cfg.GetInstanceId(),
 		agentDescription:    cfg.GetDescription(),
 		remoteConfigEnabled: cfg.RemoteConfigEnabled(),
 		opampClient:         opampClient,
 		clock:               clock.RealClock{},
+		commandsCtx:         commandsCtx,
+		cancelCommands:      cancelCommands,
 		done:                make(chan struct{}, 1),
 		ticker:              t,
 	}
 
+	if cfg.SignatureVerificationEnabled() {
+		verifier, err := cfg.GetSignatureVerifier()
+		if err != nil {
+			agent.logger.Error(err, "invalid signature verification trust roots, every remote config will be rejected")
+		}
+		agent.verifier = verifier
+	}
+
 	agent.logger.V(3).Info("Agent created",
 		"instanceId", agent.instanceId.String(),
 		"agentType", cfg.GetAgentType(),
@@ -104,6 +159,9 @@ func (agent *Agent) getHealth() *protobufs.ComponentHealth {
 		}
 	}
 	agent.addProxyHealth(componentHealth)
+	if _, ok := agent.driftDetector(); ok {
+		agent.addDriftHealth(componentHealth, statusTime)
+	}
 	return componentHealth
 }
 
@@ -199,9 +257,18 @@ func (agent *Agent) Start() error {
 	}
 	agent.startTime = startTime
 
+	if err = agent.loadInstanceId(); err != nil {
+		return err
+	}
+	if err = agent.loadManifestVersion(); err != nil {
+		return err
+	}
 	if err = agent.rebuildAppliedKeys(); err != nil {
 		return fmt.Errorf("failed to rebuild applied keys from cluster state: %w", err)
 	}
+	// the collectors are compared against their spec as last changed through OpAMP, before the bridge started
+	agent.loadDriftBaselines()
+	agent.restoreTelemetry()
 
 	settings := types.StartSettings{
 		OpAMPServerURL: agent.config.Endpoint,
@@ -216,8 +283,10 @@ func (agent *Agent) Start() error {
 			OnMessage:              agent.onMessage,
 			OnCommand:              agent.onCommand,
 		},
-		RemoteConfigStatus:    agent.remoteConfigStatus,
-		PackagesStateProvider: nil,
+		RemoteConfigStatus: agent.remoteConfigStatus,
+	}
+	if agent.config.PackagesEnabled() {
+		settings.PackagesStateProvider = agent.packages
 	}
 
 	if agent.config.Proxy != nil && agent.config.Proxy.URL != "" {
@@ -264,6 +333,12 @@ func (agent *Agent) Start() error {
 	if err != nil {
 		return err
 	}
+	if agent.commandsEnabled() {
+		err = agent.opampClient.SetCustomCapabilities(&protobufs.CustomCapabilities{Capabilities: []string{CommandsCapability}})
+		if err != nil {
+			return err
+		}
+	}
 
 	agent.logger.V(3).Info("Starting OpAMP client...")
 
@@ -319,6 +394,11 @@ func (agent *Agent) runHeartbeat() {
 				agent.logger.Error(err, "failed to heartbeat")
 				return
 			}
+			if agent.config.PackagesEnabled() {
+				if err = agent.updatePackageStatuses(); err != nil {
+					agent.logger.Error(err, "failed to update package statuses")
+				}
+			}
 		case <-agent.done:
 			agent.ticker.Stop()
 			agent.logger.Info("stopping heartbeating")
@@ -332,6 +412,36 @@ func (agent *Agent) UpdateHealth() error {
 	return agent.opampClient.SetHealth(agent.getHealth())
 }
 
+// InstanceIdStore persists the instance UID of the agent, so that it survives restarts and failovers.
+type InstanceIdStore interface {
+	// LoadOrSave returns the persisted instance UID, or persists and returns instanceId when there's none yet.
+	LoadOrSave(ctx context.Context, instanceId uuid.UUID) (uuid.UUID, error)
+	Save(instanceId uuid.UUID) error
+}
+
+// SetInstanceIdStore sets the store the instance UID is loaded from when the agent starts, and a new instance UID
+// assigned by the server is persisted to. Nothing is loaded nor persisted when it's nil.
+func (agent *Agent) SetInstanceIdStore(store InstanceIdStore) {
+	agent.instanceIdStore = store
+}
+
+// loadInstanceId replaces the instance UID of the agent with the persisted one, such as the one of the previous leader.
+// It's loaded when the agent starts rather than when the bridge starts, so that a standby elected after a new instance
+// UID was assigned to the previous leader connects with it.
+func (agent *Agent) loadInstanceId() error {
+	if agent.instanceIdStore == nil {
+		return nil
+	}
+	instanceId, err := agent.instanceIdStore.LoadOrSave(context.Background(), agent.instanceId)
+	if err != nil {
+		return fmt.Errorf("failed to load the instance UID: %w", err)
+	}
+	agent.instanceId = instanceId
+	agent.config.SetInstanceId(instanceId)
+	agent.agentDescription = agent.config.GetDescription()
+	return nil
+}
+
 // updateAgentIdentity receives a new instancedId from the remote server and updates the agent's instanceID field.
 // The meter will be reinitialized by the onMessage function.
 func (agent *Agent) updateAgentIdentity(instanceId uuid.UUID) {
@@ -339,6 +449,11 @@ func (agent *Agent) updateAgentIdentity(instanceId uuid.UUID) {
 		"old instanceId", agent.instanceId.String(),
 		"new instanceid", instanceId.String())
 	agent.instanceId = instanceId
+	if agent.instanceIdStore != nil {
+		if err := agent.instanceIdStore.Save(instanceId); err != nil {
+			agent.logger.Error(err, "failed to persist the new instance UID")
+		}
+	}
 }
 
 // getEffectiveConfig is called when a remote server needs to learn of the current effective configuration of each
@@ -423,22 +538,55 @@ func (agent *Agent) rebuildAppliedKeys() error {
 //
 // For every key in the received remote configuration, the agent attempts to apply it via the configured
 // applier. If an entry fails to apply, the agent continues to the next entry. The agent stores the
-// received configuration hash regardless of application status, as per the OpAMP spec.
+// received configuration hash regardless of application status, as per the OpAMP spec. Every apply and delete is
+// recorded in the audit log, if any. With signature verification, the remote configuration is rejected as a whole
+// unless it matches a manifest signed by a trust root.
 //
 // INVARIANT: The caller must verify that config isn't nil _and_ the configuration has changed between calls.
 func (agent *Agent) applyRemoteConfig(config *protobufs.AgentRemoteConfig) (*protobufs.RemoteConfigStatus, error) {
+	if agent.config.SignatureVerificationEnabled() {
+		verified, err := agent.verifyRemoteConfig(config)
+		if err != nil {
+			return agent.rejectRemoteConfig(config, err)
+		}
+		config = verified
+	}
+	agent.lastRemoteConfig = config
+	before := agent.auditSnapshot()
+	if agent.config.AtomicRemoteConfig {
+		// the changes all share the outcome of the remote config
+		var changes []auditChange
+		for _, key := range slices.Sorted(maps.Keys(config.Config.GetConfigMap())) {
+			changes = append(changes, auditChange{action: audit.ActionApply, key: key})
+		}
+		for _, key := range slices.Sorted(maps.Keys(agent.appliedKeys)) {
+			if _, ok := config.Config.GetConfigMap()[key]; !ok {
+				changes = append(changes, auditChange{action: audit.ActionDelete, key: key})
+			}
+		}
+		status, err := agent.applyRemoteConfigAtomically(config)
+		for i := range changes {
+			changes[i].err = err
+		}
+		agent.recordChanges(config.GetConfigHash(), before, changes)
+		agent.rebaselineChanges(changes)
+		return status, err
+	}
 	var errs []error
+	var changes []auditChange
 	// Apply changes from the received config map
 	for key, file := range config.Config.GetConfigMap() {
-		if key == "" {
-			errs = append(errs, errors.New("remote config entry has empty name"))
-			continue
-		}
-		if len(file.Body) == 0 {
-			errs = append(errs, fmt.Errorf("remote config entry %q has empty body", key))
-			continue
+		var err error
+		switch {
+		case key == "":
+			err = errors.New("remote config entry has empty name")
+		case len(file.Body) == 0:
+			err = fmt.Errorf("remote config entry %q has empty body", key)
+		default:
+			err = agent.applier.Apply(key, file)
 		}
-		if err := agent.applier.Apply(key, file); err != nil {
+		changes = append(changes, auditChange{action: audit.ActionApply, key: key, err: err})
+		if err != nil {
 			errs = append(errs, err)
 			continue
 		}
@@ -447,12 +595,16 @@ func (agent *Agent) applyRemoteConfig(config *protobufs.AgentRemoteConfig) (*pro
 	// Check if anything was deleted
 	for key := range agent.appliedKeys {
 		if _, ok := config.Config.GetConfigMap()[key]; !ok {
-			if err := agent.applier.Delete(key); err != nil {
+			err := agent.applier.Delete(key)
+			changes = append(changes, auditChange{action: audit.ActionDelete, key: key, err: err})
+			if err != nil {
 				errs = append(errs, err)
 			}
 		}
 	}
 	agent.lastHash = config.GetConfigHash()
+	agent.recordChanges(agent.lastHash, before, changes)
+	agent.rebaselineChanges(changes)
 	multiErr := errors.Join(errs...)
 	if multiErr != nil {
 		return &protobufs.RemoteConfigStatus{
@@ -467,10 +619,108 @@ func (agent *Agent) applyRemoteConfig(config *protobufs.AgentRemoteConfig) (*pro
 	}, nil
 }
 
+// applyRemoteConfigAtomically applies a remote configuration all at once, or not at all. Every entry is first
+// validated with a dry-run, and nothing is applied unless they all pass. Entries are then applied, and the resources
+// of dropped keys deleted, one at a time. If one of them fails, the ones already applied or deleted are reverted to
+// their previous state. The agent stores the received configuration hash regardless of application status, as per the
+// OpAMP spec.
+//
+// INVARIANT: The caller must verify that config isn't nil _and_ the configuration has changed between calls.
+func (agent *Agent) applyRemoteConfigAtomically(config *protobufs.AgentRemoteConfig) (*protobufs.RemoteConfigStatus, error) {
+	agent.lastHash = config.GetConfigHash()
+	applier, ok := agent.applier.(operator.TransactionalConfigApplier)
+	if !ok {
+		return agent.failedRemoteConfigStatus(errors.New("the remote config can't be applied atomically by this bridge"))
+	}
+
+	configMap := config.Config.GetConfigMap()
+	keys := slices.Sorted(maps.Keys(configMap))
+	var errs []error
+	for _, key := range keys {
+		file := configMap[key]
+		switch {
+		case key == "":
+			errs = append(errs, errors.New("remote config entry has empty name"))
+		case len(file.Body) == 0:
+			errs = append(errs, fmt.Errorf("remote config entry %q has empty body", key))
+		default:
+			if err := applier.Validate(key, file); err != nil {
+				errs = append(errs, fmt.Errorf("remote config entry %q is invalid: %w", key, err))
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return agent.failedRemoteConfigStatus(errors.Join(errs...))
+	}
+
+	var deletedKeys []string
+	for key := range agent.appliedKeys {
+		if _, ok := configMap[key]; !ok {
+			deletedKeys = append(deletedKeys, key)
+		}
+	}
+	slices.Sort(deletedKeys)
+
+	var changedKeys []string
+	var restores []func() error
+	rollback := func(err error) (*protobufs.RemoteConfigStatus, error) {
+		errs := []error{err}
+		for i := len(restores) - 1; i >= 0; i-- {
+			if restoreErr := restores[i](); restoreErr != nil {
+				errs = append(errs, fmt.Errorf("failed to revert remote config entry %q: %w", changedKeys[i], restoreErr))
+			}
+		}
+		agent.logger.Info("Reverted remote config entries", "keys", changedKeys)
+		return agent.failedRemoteConfigStatus(errors.Join(errs...))
+	}
+	change := func(key string, apply func() error) error {
+		restore, err := applier.Snapshot(key)
+		if err != nil {
+			return fmt.Errorf("failed to snapshot remote config entry %q: %w", key, err)
+		}
+		// a failed apply may still have changed the resource, so it's reverted as well
+		changedKeys = append(changedKeys, key)
+		restores = append(restores, restore)
+		return apply()
+	}
+	for _, key := range keys {
+		if err := change(key, func() error { return applier.Apply(key, configMap[key]) }); err != nil {
+			return rollback(fmt.Errorf("failed to apply remote config entry %q: %w", key, err))
+		}
+	}
+	for _, key := range deletedKeys {
+		if err := change(key, func() error { return applier.Delete(key) }); err != nil {
+			return rollback(fmt.Errorf("failed to delete remote config entry %q: %w", key, err))
+		}
+	}
+
+	for _, key := range keys {
+		agent.appliedKeys[key] = true
+	}
+	for _, key := range deletedKeys {
+		delete(agent.appliedKeys, key)
+	}
+	return &protobufs.RemoteConfigStatus{
+		LastRemoteConfigHash: agent.lastHash,
+		Status:               protobufs.RemoteConfigStatuses_RemoteConfigStatuses_APPLIED,
+	}, nil
+}
+
+// failedRemoteConfigStatus returns the status of a remote configuration that failed to apply with err.
+func (agent *Agent) failedRemoteConfigStatus(err error) (*protobufs.RemoteConfigStatus, error) {
+	return &protobufs.RemoteConfigStatus{
+		LastRemoteConfigHash: agent.lastHash,
+		Status:               protobufs.RemoteConfigStatuses_RemoteConfigStatuses_FAILED,
+		ErrorMessage:         err.Error(),
+	}, err
+}
+
 // Shutdown will stop the OpAMP client gracefully.
 func (agent *Agent) Shutdown() {
 	agent.logger.V(3).Info("Agent shutting down...")
 	close(agent.done)
+	agent.cancelCommands()
+	agent.commands.Wait()
 	if agent.opampClient != nil {
 		err := agent.opampClient.Stop(context.Background())
 		if err != nil {
@@ -483,12 +733,13 @@ func (agent *Agent) Shutdown() {
 }
 
 // onCommand is called when the OpAMP server sends a ServerToAgentCommand.
-// Only CommandType_Restart is handled; all other command types return an error to the server.
-func (agent *Agent) onCommand(ctx context.Context, command *protobufs.ServerToAgentCommand) error {
+// Only CommandType_Restart is handled, with a staged restart of every managed collector run in the background and
+// reported with a result custom message; all other command types return an error to the server. Commands scoped to a
+// collector are sent as custom messages, see onCustomCommand.
+func (agent *Agent) onCommand(_ context.Context, command *protobufs.ServerToAgentCommand) error {
 	switch command.GetType() {
 	case protobufs.CommandType_CommandType_Restart:
-		agent.logger.Info("Received restart command, triggering collector restart")
-		if err := agent.applier.Restart(ctx); err != nil {
+		if err := agent.runInBackground(RestartCommandType, "", agent.restart); err != nil {
 			agent.logger.Error(err, "Failed to restart collector on command")
 			return err
 		}
@@ -507,7 +758,9 @@ func (agent *Agent) onMessage(ctx context.Context, msg *types.MessageData) {
 	// If we received remote configuration, and it's not the same as the previously applied one
 	if agent.remoteConfigEnabled && msg.RemoteConfig != nil && !bytes.Equal(agent.lastHash, msg.RemoteConfig.GetConfigHash()) {
 		var err error
+		agent.applyMu.Lock()
 		status, err := agent.applyRemoteConfig(msg.RemoteConfig)
+		agent.applyMu.Unlock()
 		if err != nil {
 			agent.logger.Error(err, "failed to apply remote config")
 		}
@@ -522,6 +775,19 @@ func (agent *Agent) onMessage(ctx context.Context, msg *types.MessageData) {
 		}
 	}
 
+	if msg.CustomMessage != nil && msg.CustomMessage.GetCapability() == CommandsCapability {
+		agent.onCustomCommand(ctx, msg.CustomMessage)
+	}
+
+	if agent.config.PackagesEnabled() && msg.PackagesAvailable != nil {
+		agent.applyMu.Lock()
+		err := agent.applyPackages(msg.PackagesAvailable)
+		agent.applyMu.Unlock()
+		if err != nil {
+			agent.logger.Error(err, "failed to set package statuses")
+		}
+	}
+
 	// The instance id is updated prior to the meter initialization so that the new meter will report using the updated
 	// instanceId.
 	if msg.AgentIdentification != nil {
@@ -533,9 +799,18 @@ func (agent *Agent) onMessage(ctx context.Context, msg *types.MessageData) {
 		agent.updateAgentIdentity(uid)
 	}
 
-	if msg.OwnMetricsConnSettings != nil {
+	// The own metrics of an agent representing a collector are forwarded to the collector, rather than reported by the
+	// bridge.
+	forwardsOwnMetrics := agent.config.ForwardsOwnTelemetry() && agent.config.TelemetryForwarding.Forwards(config.TelemetrySignalMetrics)
+	if msg.OwnMetricsConnSettings != nil && !forwardsOwnMetrics {
 		agent.initMeter(msg.OwnMetricsConnSettings)
 	}
+
+	agent.forwardTelemetry(msg)
+
+	if msg.OwnLogsConnSettings != nil && agent.auditLog != nil && agent.config.AuditEnabled() && agent.config.Audit.EmitLogs {
+		agent.initAuditEmitter(msg.OwnLogsConnSettings)
+	}
 }
 
 // getCurrentTimeUnixNano returns the current time as a uint64, which the protocol expects.
@@ -28,6 +28,7 @@ import (
 	testingclock "k8s.io/utils/clock/testing"
 	runtimeClient "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
@@ -187,14 +188,23 @@ type mockOpampClient struct {
 	lastStatus          *protobufs.RemoteConfigStatus
 	lastHealth          *protobufs.ComponentHealth
 	lastEffectiveConfig *protobufs.EffectiveConfig
+	lastPackageStatuses *protobufs.PackageStatuses
+	lastCustomMessage   *protobufs.CustomMessage
+	customCapabilities  *protobufs.CustomCapabilities
 	settings            types.StartSettings
 }
 
-func (*mockOpampClient) SetCustomCapabilities(*protobufs.CustomCapabilities) error {
+func (m *mockOpampClient) SetCustomCapabilities(customCapabilities *protobufs.CustomCapabilities) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.customCapabilities = customCapabilities
 	return nil
 }
 
-func (*mockOpampClient) SendCustomMessage(*protobufs.CustomMessage) (messageSendingChannel chan struct{}, err error) {
+func (m *mockOpampClient) SendCustomMessage(message *protobufs.CustomMessage) (messageSendingChannel chan struct{}, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.lastCustomMessage = message
 	return nil, nil
 }
 
@@ -246,7 +256,10 @@ func (m *mockOpampClient) SetRemoteConfigStatus(status *protobufs.RemoteConfigSt
 	return nil
 }
 
-func (*mockOpampClient) SetPackageStatuses(*protobufs.PackageStatuses) error {
+func (m *mockOpampClient) SetPackageStatuses(statuses *protobufs.PackageStatuses) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.lastPackageStatuses = statuses
 	return nil
 }
 
@@ -262,6 +275,10 @@ func (*mockOpampClient) SetCapabilities(*protobufs.AgentCapabilities) error {
 }
 
 func getFakeApplier(t *testing.T, conf *config.Config, lists ...runtimeClient.ObjectList) *operator.Client {
+	return operator.NewClient("test-bridge", l, getFakeClientBuilder(t, lists...).Build(), conf.GetComponentsAllowed(), conf.GetResourcesAllowed())
+}
+
+func getFakeClientBuilder(t *testing.T, lists ...runtimeClient.ObjectList) *fake.ClientBuilder {
 	schemeBuilder := runtime.NewSchemeBuilder(func(s *runtime.Scheme) error {
 		s.AddKnownTypes(v1alpha1.GroupVersion, &v1alpha1.OpenTelemetryCollector{}, &v1alpha1.OpenTelemetryCollectorList{})
 		s.AddKnownTypes(v1beta1.GroupVersion, &v1beta1.OpenTelemetryCollector{}, &v1beta1.OpenTelemetryCollectorList{})
@@ -272,8 +289,7 @@ func getFakeApplier(t *testing.T, conf *config.Config, lists ...runtimeClient.Ob
 	scheme := runtime.NewScheme()
 	err := schemeBuilder.AddToScheme(scheme)
 	require.NoError(t, err, "Should be able to add custom types")
-	c := fake.NewClientBuilder().WithLists(lists...).WithScheme(scheme)
-	return operator.NewClient("test-bridge", l, c.Build(), conf.GetComponentsAllowed())
+	return fake.NewClientBuilder().WithLists(lists...).WithScheme(scheme)
 }
 
 type mockHealthApplier struct {
@@ -332,6 +348,27 @@ func (*recordingConfigApplier) GetHealth() (operator.Health, error) {
 	return operator.Health{Healthy: true, Children: map[string]operator.Health{}}, nil
 }
 
+type recordingPackageApplier struct {
+	recordingConfigApplier
+	images      map[string]string
+	rolledOut   bool
+	rolloutErr  error
+	rolloutCall int
+}
+
+func (r *recordingPackageApplier) ApplyPackage(name, image string) error {
+	if r.images == nil {
+		r.images = map[string]string{}
+	}
+	r.images[name] = image
+	return nil
+}
+
+func (r *recordingPackageApplier) PackageRollout(string, string) (bool, error) {
+	r.rolloutCall++
+	return r.rolledOut, r.rolloutErr
+}
+
 func TestAgent_UpdateHealth(t *testing.T) {
 	mockClient := &mockOpampClient{}
 	conf := config.NewConfig(logr.Discard())
@@ -384,6 +421,75 @@ func TestAgentApplyRemoteConfigRejectsEmptyBody(t *testing.T) {
 	assert.Empty(t, applier.applied)
 }
 
+func TestAgentApplyRemoteConfigAtomicallyValidatesEveryEntry(t *testing.T) {
+	conf := config.NewConfig(logr.Discard())
+	conf.AtomicRemoteConfig = true
+	applier := getFakeApplier(t, conf)
+	agent := NewAgent(logr.Discard(), applier, conf, &mockOpampClient{}, newMockProxy(nil, nil, nil))
+	data, err := getMessageDataFromConfigFile(map[string]string{
+		testCollectorKey:  collectorBasicFile,
+		otherCollectorKey: collectorInvalidFile,
+	})
+	require.NoError(t, err)
+
+	status, err := agent.applyRemoteConfig(data.RemoteConfig)
+
+	require.Error(t, err)
+	require.Equal(t, protobufs.RemoteConfigStatuses_RemoteConfigStatuses_FAILED, status.Status)
+	assert.Equal(t, data.RemoteConfig.GetConfigHash(), status.LastRemoteConfigHash)
+	assert.Contains(t, status.ErrorMessage, fmt.Sprintf("remote config entry %q is invalid", otherCollectorKey))
+	assert.NotContains(t, status.ErrorMessage, testCollectorKey)
+	instance, err := applier.GetInstance(testCollectorName, testNamespace)
+	require.NoError(t, err)
+	assert.Nil(t, instance, "no entry should be applied when one of them is invalid")
+	assert.Empty(t, agent.appliedKeys)
+}
+
+func TestAgentApplyRemoteConfigAtomicallyRevertsAppliedEntries(t *testing.T) {
+	conf := config.NewConfig(logr.Discard())
+	conf.AtomicRemoteConfig = true
+	fakeClient := getFakeClientBuilder(t).WithInterceptorFuncs(interceptor.Funcs{
+		Create: func(ctx context.Context, c runtimeClient.WithWatch, obj runtimeClient.Object, opts ...runtimeClient.CreateOption) error {
+			createOptions := &runtimeClient.CreateOptions{}
+			createOptions.ApplyOptions(opts)
+			// the other collector passes the dry-run, but fails to be created
+			if obj.GetName() == otherCollectorName && len(createOptions.DryRun) == 0 {
+				return errors.New("admission webhook denied the request")
+			}
+			return c.Create(ctx, obj, opts...)
+		},
+	}).Build()
+	applier := operator.NewClient("test-bridge", l, fakeClient, nil, nil)
+	agent := NewAgent(logr.Discard(), applier, conf, &mockOpampClient{}, newMockProxy(nil, nil, nil))
+
+	data, err := getMessageDataFromConfigFile(map[string]string{testCollectorKey: collectorBasicFile})
+	require.NoError(t, err)
+	status, err := agent.applyRemoteConfig(data.RemoteConfig)
+	require.NoError(t, err)
+	require.Equal(t, protobufs.RemoteConfigStatuses_RemoteConfigStatuses_APPLIED, status.Status)
+
+	data, err = getMessageDataFromConfigFile(map[string]string{
+		testCollectorKey:  collectorUpdatedFile,
+		otherCollectorKey: collectorBasicFile,
+	})
+	require.NoError(t, err)
+	status, err = agent.applyRemoteConfig(data.RemoteConfig)
+
+	require.Error(t, err)
+	require.Equal(t, protobufs.RemoteConfigStatuses_RemoteConfigStatuses_FAILED, status.Status)
+	assert.Contains(t, status.ErrorMessage, fmt.Sprintf("failed to apply remote config entry %q", otherCollectorKey))
+	assert.Contains(t, status.ErrorMessage, "admission webhook denied the request")
+	instance, err := applier.GetInstance(testCollectorName, testNamespace)
+	require.NoError(t, err)
+	require.NotNil(t, instance)
+	assert.Empty(t, instance.Spec.Config.Service.Pipelines["traces"].Processors, "the applied entry should be reverted")
+	assert.Nil(t, instance.Spec.Replicas)
+	instance, err = applier.GetInstance(otherCollectorName, testNamespace)
+	require.NoError(t, err)
+	assert.Nil(t, instance)
+	assert.Equal(t, map[string]bool{testCollectorKey: true}, agent.appliedKeys)
+}
+
 func TestAgent_getHealthFromApplierHealth(t *testing.T) {
 	fakeClock := testingclock.NewFakeClock(time.Now())
 	startTime, err := timeToUnixNanoUnsigned(fakeClock.Now())
@@ -1337,6 +1443,58 @@ func Test_CanUpdateIdentity(t *testing.T) {
 	assert.Equal(t, newId, parsedUUID)
 }
 
+type recordingInstanceIdStore struct {
+	persisted uuid.UUID
+	saved     []uuid.UUID
+}
+
+func (r *recordingInstanceIdStore) LoadOrSave(_ context.Context, instanceId uuid.UUID) (uuid.UUID, error) {
+	if r.persisted == uuid.Nil {
+		r.persisted = instanceId
+	}
+	return r.persisted, nil
+}
+
+func (r *recordingInstanceIdStore) Save(instanceId uuid.UUID) error {
+	r.saved = append(r.saved, instanceId)
+	return nil
+}
+
+func TestAgent_PersistsUpdatedIdentity(t *testing.T) {
+	conf := config.NewConfig(logr.Discard())
+	agent := NewAgent(logr.Discard(), &recordingConfigApplier{}, conf, &mockOpampClient{}, newMockProxy(nil, nil, nil))
+	store := &recordingInstanceIdStore{}
+	agent.SetInstanceIdStore(store)
+
+	newId, err := uuid.NewV7()
+	require.NoError(t, err)
+	agent.onMessage(context.Background(), &types.MessageData{
+		AgentIdentification: &protobufs.AgentIdentification{
+			NewInstanceUid: newId[:],
+		},
+	})
+	assert.Equal(t, newId, agent.instanceId)
+	assert.Equal(t, []uuid.UUID{newId}, store.saved)
+}
+
+func TestAgent_StartLoadsPersistedIdentity(t *testing.T) {
+	conf := config.NewConfig(logr.Discard())
+	mockClient := &mockOpampClient{}
+	agent := NewAgent(logr.Discard(), &recordingConfigApplier{}, conf, mockClient, newMockProxy(nil, nil, nil))
+	persisted := uuid.New()
+	agent.SetInstanceIdStore(&recordingInstanceIdStore{persisted: persisted})
+
+	require.NoError(t, agent.Start())
+	t.Cleanup(agent.Shutdown)
+
+	assert.Equal(t, persisted, agent.instanceId)
+	assert.Equal(t, types.InstanceUid(persisted), mockClient.settings.InstanceUid, "the agent connects with the persisted instance UID")
+	assert.Contains(t, agent.agentDescription.GetIdentifyingAttributes(), &protobufs.KeyValue{
+		Key:   "service.instance.id",
+		Value: &protobufs.AnyValue{Value: &protobufs.AnyValue_StringValue{StringValue: persisted.String()}},
+	})
+}
+
 func TestAgent_ListensForUpdates(t *testing.T) {
 	synctest.Test(t, func(t *testing.T) {
 		mockClient := &mockOpampClient{}
@@ -1403,20 +1561,38 @@ func TestAgent_onCommand_Restart(t *testing.T) {
 	})
 
 	require.NoError(t, err)
+	agent.commands.Wait()
 	assert.Equal(t, 1, applier.restartCalled, "Restart should be called exactly once")
 }
 
 func TestAgent_onCommand_RestartError(t *testing.T) {
-	restartErr := errors.New("rollout failed")
-	applier := &recordingConfigApplier{restartErr: restartErr}
-	agent := NewAgent(logr.Discard(), applier, config.NewConfig(logr.Discard()), &mockOpampClient{}, newMockProxy(nil, nil, nil))
+	applier := &recordingInstanceRestarter{}
+	applier.restartErr = errors.New("rollout failed")
+	mockClient := &mockOpampClient{}
+	agent := getCommandsAgent(applier, mockClient)
 
 	err := agent.onCommand(context.Background(), &protobufs.ServerToAgentCommand{
 		Type: protobufs.CommandType_CommandType_Restart,
 	})
 
-	require.ErrorIs(t, err, restartErr)
+	// the restart runs in the background, and its error is reported with a result custom message
+	require.NoError(t, err)
+	agent.commands.Wait()
 	assert.Equal(t, 1, applier.restartCalled)
+	assert.Equal(t, commandResult{Command: RestartCommandType, Error: "rollout failed"}, getCommandResult(t, mockClient))
+}
+
+func TestAgent_onCommand_RestartAlreadyRunning(t *testing.T) {
+	applier := &recordingConfigApplier{}
+	agent := NewAgent(logr.Discard(), applier, config.NewConfig(logr.Discard()), &mockOpampClient{}, newMockProxy(nil, nil, nil))
+	agent.commandRunning.Store(true)
+
+	err := agent.onCommand(context.Background(), &protobufs.ServerToAgentCommand{
+		Type: protobufs.CommandType_CommandType_Restart,
+	})
+
+	require.EqualError(t, err, "a restart or a flush of the collectors is already running")
+	assert.Equal(t, 0, applier.restartCalled)
 }
 
 func TestAgent_onCommand_UnknownType(t *testing.T) {
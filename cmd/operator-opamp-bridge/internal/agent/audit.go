@@ -0,0 +1,114 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"encoding/hex"
+	"net/url"
+
+	"github.com/open-telemetry/opamp-go/protobufs"
+
+	"github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/internal/audit"
+)
+
+// auditChange is a change made to the resource of a remote config entry, recorded in the audit log.
+type auditChange struct {
+	action audit.Action
+	key    string
+	err    error
+	// actor is who made the change, the OpAMP server when it's empty.
+	actor string
+}
+
+// SetAuditLog sets the log the changes made by the agent are recorded to. Nothing is recorded when it's nil.
+func (agent *Agent) SetAuditLog(log *audit.Log) {
+	agent.auditLog = log
+}
+
+// auditSnapshot returns the effective config of each managed resource by remote config entry name, which the changes
+// recorded in the audit log are diffed against. It's nil when there's no audit log.
+func (agent *Agent) auditSnapshot() map[string]string {
+	if agent.auditLog == nil {
+		return nil
+	}
+	instances, err := agent.applier.ListInstances()
+	if err != nil {
+		agent.logger.Error(err, "failed to list instances for the audit log")
+		return nil
+	}
+	snapshot := map[string]string{}
+	for _, instance := range instances {
+		if instance.GetDeletionTimestamp() != nil {
+			continue
+		}
+		for key, file := range instance.GetConfigMap() {
+			snapshot[key] = audit.Redact(string(file.Body))
+		}
+	}
+	return snapshot
+}
+
+// recordChanges adds the changes made by a remote config to the audit log, each with the diff of the effective config
+// of its resource against the snapshot taken before the change. Changes without a snapshot, such as restarts, have no
+// diff.
+func (agent *Agent) recordChanges(configHash []byte, before map[string]string, changes []auditChange) {
+	if agent.auditLog == nil || len(changes) == 0 {
+		return
+	}
+	var after map[string]string
+	if before != nil {
+		after = agent.auditSnapshot()
+	}
+	for _, change := range changes {
+		record := audit.Record{
+			Action:     change.action,
+			Resource:   change.key,
+			ConfigHash: hex.EncodeToString(configHash),
+			Actor:      change.actor,
+			Outcome:    audit.OutcomeSuccess,
+		}
+		if record.Actor == "" {
+			record.Actor = agent.serverActor()
+		}
+		if before != nil && after != nil {
+			record.Diff = audit.Diff(before[change.key], after[change.key])
+		}
+		if change.err != nil {
+			record.Outcome = audit.OutcomeFailure
+			record.Error = change.err.Error()
+		}
+		agent.auditLog.Add(record)
+	}
+}
+
+// serverActor returns the actor of the changes requested by the OpAMP server: its endpoint, without the credentials
+// and the query it may hold.
+func (agent *Agent) serverActor() string {
+	endpoint, err := url.Parse(agent.config.Endpoint)
+	if err != nil {
+		return "opamp-server"
+	}
+	endpoint.User = nil
+	endpoint.RawQuery = ""
+	return endpoint.String()
+}
+
+// bridgeActor returns the actor of the changes the bridge makes on its own, such as enforcing its drift policy.
+func (agent *Agent) bridgeActor() string {
+	if agent.config.Name == "" {
+		return "opamp-bridge"
+	}
+	return "opamp-bridge/" + agent.config.Name
+}
+
+// initAuditEmitter sends the records of the audit log as OTLP logs to the destination supplied by the server, shutting
+// down any previously running emitter.
+func (agent *Agent) initAuditEmitter(settings *protobufs.TelemetryConnectionSettings) {
+	emitter, err := audit.NewLogEmitter(settings, agent.config.GetAgentType(), agent.config.GetAgentVersion(), agent.instanceId)
+	if err != nil {
+		agent.logger.Error(err, "failed to create audit log emitter")
+		return
+	}
+	agent.auditLog.SetEmitter(emitter)
+}
@@ -0,0 +1,203 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/open-telemetry/opamp-go/protobufs"
+
+	"github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/internal/audit"
+	"github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/internal/operator"
+)
+
+const (
+	// CommandsCapability is the OpAMP custom capability of the commands the bridge accepts besides the restart command
+	// of the protocol. The data of a command message is the remote config entry name of the collector it's scoped
+	// to, or empty for every managed collector.
+	CommandsCapability = "io.opentelemetry.operator.opampbridge.commands"
+
+	// RestartCommandType restarts the workload of a collector, or a staged restart of every managed collector.
+	RestartCommandType = "restart"
+	// FlushCommandType flushes the sending queues of a collector, or of every managed collector, by terminating its
+	// pods one at a time.
+	FlushCommandType = "flush"
+	// ResyncCommandType applies the last remote config entry of a collector, or the whole last remote config, again.
+	ResyncCommandType = "resync"
+	// CommandResultType is the type of the message the bridge replies to a command with.
+	CommandResultType = "result"
+)
+
+// commandResult is the data of the message the bridge replies to a command with.
+type commandResult struct {
+	Command string `json:"command"`
+	Key     string `json:"key,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+// commandsEnabled returns whether the bridge advertises the CommandsCapability.
+func (agent *Agent) commandsEnabled() bool {
+	capabilities := agent.config.GetCapabilities()
+	return agent.remoteConfigEnabled || capabilities&protobufs.AgentCapabilities_AgentCapabilities_AcceptsRestartCommand != 0
+}
+
+// onCustomCommand runs a command sent by the server as a custom message of the CommandsCapability, and replies with
+// its result. Restarts and flushes wait for rollouts, so they run in the background and reply once done.
+func (agent *Agent) onCustomCommand(ctx context.Context, message *protobufs.CustomMessage) {
+	key := string(message.GetData())
+	var err error
+	switch message.GetType() {
+	case RestartCommandType, FlushCommandType:
+		capabilities := agent.config.GetCapabilities()
+		if capabilities&protobufs.AgentCapabilities_AgentCapabilities_AcceptsRestartCommand == 0 {
+			err = fmt.Errorf("the bridge doesn't accept %s commands", message.GetType())
+			break
+		}
+		run := agent.restart
+		if message.GetType() == FlushCommandType {
+			run = agent.flush
+		}
+		if err = agent.runInBackground(message.GetType(), key, run); err == nil {
+			return
+		}
+	case ResyncCommandType:
+		err = agent.resync(ctx, key)
+	default:
+		err = fmt.Errorf("unsupported command type: %s", message.GetType())
+	}
+	if err != nil {
+		agent.logger.Error(err, "failed to run command", "type", message.GetType(), "key", key)
+	}
+	agent.sendCommandResult(message.GetType(), key, err)
+}
+
+// runInBackground runs a restart or a flush of the collectors on its own goroutine, bounded by the lifetime of the
+// agent, and replies with its result once done. Only one runs at a time, as both take the workloads down.
+func (agent *Agent) runInBackground(command, key string, run func(ctx context.Context, key string) error) error {
+	if !agent.commandRunning.CompareAndSwap(false, true) {
+		return errors.New("a restart or a flush of the collectors is already running")
+	}
+	agent.commands.Add(1)
+	go func() {
+		defer agent.commands.Done()
+		err := run(agent.commandsCtx, key)
+		agent.commandRunning.Store(false)
+		if err != nil {
+			agent.logger.Error(err, "failed to run command", "type", command, "key", key)
+		}
+		agent.sendCommandResult(command, key, err)
+	}()
+	return nil
+}
+
+// sendCommandResult replies to a command with its result, when the bridge advertises the CommandsCapability.
+func (agent *Agent) sendCommandResult(command, key string, err error) {
+	if !agent.commandsEnabled() {
+		return
+	}
+	result := commandResult{Command: command, Key: key}
+	if err != nil {
+		result.Error = err.Error()
+	}
+	data, err := json.Marshal(result)
+	if err != nil {
+		agent.logger.Error(err, "failed to marshal command result")
+		return
+	}
+	if _, err = agent.opampClient.SendCustomMessage(&protobufs.CustomMessage{
+		Capability: CommandsCapability,
+		Type:       CommandResultType,
+		Data:       data,
+	}); err != nil {
+		agent.logger.Error(err, "failed to send command result")
+	}
+}
+
+// restart triggers a staged restart of the workloads of the managed collectors, or only of the collector identified
+// by key when it isn't empty. The restart is bounded by the configured restart timeout, and recorded in the audit
+// log, if any.
+func (agent *Agent) restart(ctx context.Context, key string) error {
+	err := agent.restartInstances(ctx, key)
+	agent.recordChanges(nil, nil, []auditChange{{action: audit.ActionRestart, key: key, err: err}})
+	return err
+}
+
+func (agent *Agent) restartInstances(ctx context.Context, key string) error {
+	ctx, cancel := context.WithTimeout(ctx, agent.config.GetRestartTimeout())
+	defer cancel()
+	if key == "" {
+		agent.logger.Info("Received restart command, triggering a staged restart of the collectors")
+		return agent.applier.Restart(ctx)
+	}
+	restarter, ok := agent.applier.(operator.InstanceRestarter)
+	if !ok {
+		return errors.New("collectors can't be restarted one at a time by this bridge")
+	}
+	agent.logger.Info("Received restart command, triggering collector restart", "key", key)
+	return restarter.RestartInstance(ctx, key)
+}
+
+// flush terminates the pods of the managed collectors one at a time, or only the pods of the collector identified by
+// key when it isn't empty, so that the collectors drain their sending queues on shutdown. Like a restart, the flush is
+// bounded by the configured restart timeout, and recorded in the audit log, if any.
+func (agent *Agent) flush(ctx context.Context, key string) error {
+	err := agent.flushQueues(ctx, key)
+	agent.recordChanges(nil, nil, []auditChange{{action: audit.ActionFlush, key: key, err: err}})
+	return err
+}
+
+func (agent *Agent) flushQueues(ctx context.Context, key string) error {
+	flusher, ok := agent.applier.(operator.QueueFlusher)
+	if !ok {
+		return errors.New("the sending queues of the collectors can't be flushed by this bridge")
+	}
+	ctx, cancel := context.WithTimeout(ctx, agent.config.GetRestartTimeout())
+	defer cancel()
+	agent.logger.Info("Received flush command, terminating the collector pods one at a time", "key", key)
+	return flusher.FlushQueues(ctx, key)
+}
+
+// resync applies the entry of the last remote config for the resource identified by key again, or every entry when
+// key is empty, reverting the changes made to the resources outside of OpAMP.
+func (agent *Agent) resync(ctx context.Context, key string) error {
+	if !agent.remoteConfigEnabled {
+		return errors.New("the bridge doesn't accept remote config")
+	}
+	agent.applyMu.Lock()
+	defer agent.applyMu.Unlock()
+	if agent.lastRemoteConfig == nil {
+		return errors.New("no remote config was received yet")
+	}
+	configMap := agent.lastRemoteConfig.GetConfig().GetConfigMap()
+	keys := slices.Sorted(maps.Keys(configMap))
+	if key != "" {
+		if _, ok := configMap[key]; !ok {
+			return fmt.Errorf("the last remote config has no entry %q", key)
+		}
+		keys = []string{key}
+	}
+
+	before := agent.auditSnapshot()
+	var errs []error
+	var changes []auditChange
+	for _, key := range keys {
+		err := agent.applier.Apply(key, configMap[key])
+		changes = append(changes, auditChange{action: audit.ActionResync, key: key, err: err})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to resync remote config entry %q: %w", key, err))
+		}
+	}
+	agent.recordChanges(agent.lastRemoteConfig.GetConfigHash(), before, changes)
+	agent.rebaselineChanges(changes)
+	agent.logger.Info("Resynced remote config entries", "keys", keys)
+	if err := agent.opampClient.UpdateEffectiveConfig(ctx); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,210 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/open-telemetry/opamp-go/client/types"
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/internal/config"
+)
+
+type recordingInstanceRestarter struct {
+	recordingConfigApplier
+	restarted  []string
+	flushed    []string
+	hasTimeout bool
+	err        error
+}
+
+func (r *recordingInstanceRestarter) RestartInstance(ctx context.Context, key string) error {
+	_, r.hasTimeout = ctx.Deadline()
+	r.restarted = append(r.restarted, key)
+	return r.err
+}
+
+func (r *recordingInstanceRestarter) FlushQueues(ctx context.Context, key string) error {
+	_, r.hasTimeout = ctx.Deadline()
+	r.flushed = append(r.flushed, key)
+	return r.err
+}
+
+func getCommandsAgent(applier *recordingInstanceRestarter, mockClient *mockOpampClient) *Agent {
+	conf := config.NewConfig(logr.Discard())
+	conf.Capabilities = map[config.Capability]bool{
+		config.AcceptsRemoteConfig:   true,
+		config.AcceptsRestartCommand: true,
+	}
+	return NewAgent(logr.Discard(), applier, conf, mockClient, newMockProxy(nil, nil, nil))
+}
+
+func commandMessage(commandType, key string) *types.MessageData {
+	return &types.MessageData{CustomMessage: &protobufs.CustomMessage{
+		Capability: CommandsCapability,
+		Type:       commandType,
+		Data:       []byte(key),
+	}}
+}
+
+func getCommandResult(t *testing.T, mockClient *mockOpampClient) commandResult {
+	require.NotNil(t, mockClient.lastCustomMessage)
+	assert.Equal(t, CommandsCapability, mockClient.lastCustomMessage.Capability)
+	assert.Equal(t, CommandResultType, mockClient.lastCustomMessage.Type)
+	var result commandResult
+	require.NoError(t, json.Unmarshal(mockClient.lastCustomMessage.Data, &result))
+	return result
+}
+
+func TestAgent_Start_SetsCommandsCapability(t *testing.T) {
+	mockClient := &mockOpampClient{}
+	agent := getCommandsAgent(&recordingInstanceRestarter{}, mockClient)
+
+	require.NoError(t, agent.Start())
+	defer agent.Shutdown()
+
+	require.NotNil(t, mockClient.customCapabilities)
+	assert.Equal(t, []string{CommandsCapability}, mockClient.customCapabilities.Capabilities)
+}
+
+func TestAgent_onMessage_RestartCommand(t *testing.T) {
+	applier := &recordingInstanceRestarter{}
+	mockClient := &mockOpampClient{}
+	agent := getCommandsAgent(applier, mockClient)
+
+	agent.onMessage(context.Background(), commandMessage(RestartCommandType, testCollectorKey))
+	agent.commands.Wait()
+
+	assert.Equal(t, []string{testCollectorKey}, applier.restarted)
+	assert.True(t, applier.hasTimeout, "the restart must be bounded by the restart timeout")
+	assert.Equal(t, 0, applier.restartCalled)
+	assert.Equal(t, commandResult{Command: RestartCommandType, Key: testCollectorKey}, getCommandResult(t, mockClient))
+
+	// without a key, every collector is restarted
+	agent.onMessage(context.Background(), commandMessage(RestartCommandType, ""))
+	agent.commands.Wait()
+	assert.Equal(t, 1, applier.restartCalled)
+	assert.Equal(t, commandResult{Command: RestartCommandType}, getCommandResult(t, mockClient))
+}
+
+func TestAgent_onMessage_RestartCommandError(t *testing.T) {
+	applier := &recordingInstanceRestarter{err: errors.New("timed out waiting for the rollout")}
+	mockClient := &mockOpampClient{}
+	agent := getCommandsAgent(applier, mockClient)
+
+	agent.onMessage(context.Background(), commandMessage(RestartCommandType, testCollectorKey))
+	agent.commands.Wait()
+
+	assert.Equal(t, commandResult{
+		Command: RestartCommandType,
+		Key:     testCollectorKey,
+		Error:   "timed out waiting for the rollout",
+	}, getCommandResult(t, mockClient))
+}
+
+func TestAgent_onMessage_RestartCommandAlreadyRunning(t *testing.T) {
+	applier := &recordingInstanceRestarter{}
+	mockClient := &mockOpampClient{}
+	agent := getCommandsAgent(applier, mockClient)
+	agent.commandRunning.Store(true)
+
+	agent.onMessage(context.Background(), commandMessage(FlushCommandType, testCollectorKey))
+
+	assert.Empty(t, applier.flushed)
+	assert.Equal(t, commandResult{
+		Command: FlushCommandType,
+		Key:     testCollectorKey,
+		Error:   "a restart or a flush of the collectors is already running",
+	}, getCommandResult(t, mockClient))
+}
+
+func TestAgent_onMessage_FlushCommand(t *testing.T) {
+	applier := &recordingInstanceRestarter{}
+	mockClient := &mockOpampClient{}
+	agent := getCommandsAgent(applier, mockClient)
+
+	agent.onMessage(context.Background(), commandMessage(FlushCommandType, testCollectorKey))
+	agent.commands.Wait()
+
+	assert.Equal(t, []string{testCollectorKey}, applier.flushed)
+	assert.True(t, applier.hasTimeout, "the flush must be bounded by the restart timeout")
+	assert.Empty(t, applier.restarted)
+	assert.Equal(t, commandResult{Command: FlushCommandType, Key: testCollectorKey}, getCommandResult(t, mockClient))
+
+	agent.config.Capabilities[config.AcceptsRestartCommand] = false
+	agent.onMessage(context.Background(), commandMessage(FlushCommandType, ""))
+	assert.Equal(t, []string{testCollectorKey}, applier.flushed)
+	assert.Equal(t, "the bridge doesn't accept flush commands", getCommandResult(t, mockClient).Error)
+}
+
+func TestAgent_onMessage_FlushCommandUnsupported(t *testing.T) {
+	mockClient := &mockOpampClient{}
+	conf := config.NewConfig(logr.Discard())
+	conf.Capabilities = map[config.Capability]bool{config.AcceptsRestartCommand: true}
+	agent := NewAgent(logr.Discard(), &recordingConfigApplier{}, conf, mockClient, newMockProxy(nil, nil, nil))
+
+	agent.onMessage(context.Background(), commandMessage(FlushCommandType, ""))
+	agent.commands.Wait()
+
+	assert.Equal(t, "the sending queues of the collectors can't be flushed by this bridge", getCommandResult(t, mockClient).Error)
+}
+
+func TestAgent_onMessage_RestartCommandNotAccepted(t *testing.T) {
+	applier := &recordingInstanceRestarter{}
+	mockClient := &mockOpampClient{}
+	agent := getCommandsAgent(applier, mockClient)
+	agent.config.Capabilities[config.AcceptsRestartCommand] = false
+
+	agent.onMessage(context.Background(), commandMessage(RestartCommandType, testCollectorKey))
+
+	assert.Empty(t, applier.restarted)
+	assert.Equal(t, "the bridge doesn't accept restart commands", getCommandResult(t, mockClient).Error)
+}
+
+func TestAgent_onMessage_ResyncCommand(t *testing.T) {
+	applier := &recordingInstanceRestarter{}
+	mockClient := &mockOpampClient{}
+	agent := getCommandsAgent(applier, mockClient)
+	require.NoError(t, agent.Start())
+	defer agent.Shutdown()
+
+	agent.onMessage(context.Background(), commandMessage(ResyncCommandType, ""))
+	assert.Equal(t, "no remote config was received yet", getCommandResult(t, mockClient).Error)
+
+	remoteConfig := &protobufs.AgentRemoteConfig{
+		Config: &protobufs.AgentConfigMap{ConfigMap: map[string]*protobufs.AgentConfigFile{
+			testCollectorKey:  {Body: []byte("first")},
+			otherCollectorKey: {Body: []byte("second")},
+		}},
+		ConfigHash: []byte("hash"),
+	}
+	agent.onMessage(context.Background(), &types.MessageData{RemoteConfig: remoteConfig})
+	applier.applied = nil
+
+	agent.onMessage(context.Background(), commandMessage(ResyncCommandType, otherCollectorKey))
+	assert.Equal(t, map[string][]byte{otherCollectorKey: []byte("second")}, applier.applied)
+	assert.Equal(t, commandResult{Command: ResyncCommandType, Key: otherCollectorKey}, getCommandResult(t, mockClient))
+
+	agent.onMessage(context.Background(), commandMessage(ResyncCommandType, ""))
+	assert.Equal(t, map[string][]byte{testCollectorKey: []byte("first"), otherCollectorKey: []byte("second")}, applier.applied)
+
+	agent.onMessage(context.Background(), commandMessage(ResyncCommandType, thirdCollectorKey))
+	assert.Equal(t, `the last remote config has no entry "other/third"`, getCommandResult(t, mockClient).Error)
+}
+
+func TestAgent_onMessage_UnsupportedCommand(t *testing.T) {
+	mockClient := &mockOpampClient{}
+	agent := getCommandsAgent(&recordingInstanceRestarter{}, mockClient)
+
+	agent.onMessage(context.Background(), commandMessage("upgrade", ""))
+
+	assert.Equal(t, "unsupported command type: upgrade", getCommandResult(t, mockClient).Error)
+}
@@ -0,0 +1,274 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/open-telemetry/opamp-go/protobufs"
+
+	"github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/internal/audit"
+	"github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/internal/operator"
+)
+
+// driftComponent is the name of the health component listing the drifted collectors.
+const driftComponent = "drift"
+
+// driftDetector returns the applier as a DriftDetector, when drift detection is enabled and the applier supports it.
+func (agent *Agent) driftDetector() (operator.DriftDetector, bool) {
+	if !agent.config.DriftDetectionEnabled() {
+		return nil, false
+	}
+	detector, ok := agent.applier.(operator.DriftDetector)
+	return detector, ok
+}
+
+// CheckDrift compares hash, the spec hash of the collector identified by key, against its hash as last changed through
+// OpAMP, and handles a difference according to the drift policy. It's called by the collector watcher on every change
+// of a managed collector, with an empty hash when the collector is deleted.
+func (agent *Agent) CheckDrift(key, hash string) {
+	if _, ok := agent.driftDetector(); !ok {
+		return
+	}
+	agent.applyMu.Lock()
+	defer agent.applyMu.Unlock()
+
+	exists := hash != ""
+	agent.driftMu.Lock()
+	baseline, known := agent.specHashes[key]
+	wasDrifted := agent.drifted[key]
+	switch {
+	case !exists:
+		delete(agent.specHashes, key)
+		delete(agent.drifted, key)
+	case !known:
+		// the collector was labeled outside of OpAMP, its current spec is the reference
+		agent.specHashes[key] = hash
+	case hash == baseline:
+		delete(agent.drifted, key)
+	}
+	agent.driftMu.Unlock()
+
+	if exists && !known {
+		agent.saveDriftBaselines(map[string]string{key: hash})
+	}
+	if exists && known && hash != baseline {
+		agent.handleDrift(key, hash)
+	} else if !wasDrifted {
+		return
+	}
+	agent.reportDrift()
+}
+
+// handleDrift applies the drift policy to the collector identified by key, whose spec hash changed to hash outside of
+// OpAMP.
+func (agent *Agent) handleDrift(key, hash string) {
+	policy := agent.config.DriftDetection.GetPolicy()
+	agent.logger.Info("Collector drifted from the last applied remote config", "key", key, "policy", policy)
+	switch policy {
+	case config.DriftPolicyEnforce:
+		file, ok := agent.lastRemoteConfig.GetConfig().GetConfigMap()[key]
+		if !ok {
+			agent.logger.Info("The last remote config has no entry for the drifted collector, reporting it instead", "key", key)
+			agent.setDrifted(key)
+			return
+		}
+		before := agent.auditSnapshot()
+		err := agent.applier.Apply(key, file)
+		agent.recordChanges(agent.lastRemoteConfig.GetConfigHash(), before, []auditChange{{action: audit.ActionEnforce, key: key, err: err, actor: agent.bridgeActor()}})
+		if err != nil {
+			agent.logger.Error(err, "failed to enforce the last remote config on a drifted collector", "key", key)
+			agent.setDrifted(key)
+			return
+		}
+		agent.rebaselineDrift(key)
+	case config.DriftPolicyAdopt:
+		agent.driftMu.Lock()
+		agent.specHashes[key] = hash
+		delete(agent.drifted, key)
+		agent.driftMu.Unlock()
+		agent.saveDriftBaselines(map[string]string{key: hash})
+		agent.recordChanges(nil, nil, []auditChange{{action: audit.ActionAdopt, key: key, actor: agent.driftActor(key)}})
+	default:
+		agent.setDrifted(key)
+	}
+}
+
+// driftActor returns the field manager that last changed the spec of the collector identified by key, which made the
+// change adopted by the drift policy.
+func (agent *Agent) driftActor(key string) string {
+	detector, ok := agent.driftDetector()
+	if !ok {
+		return ""
+	}
+	manager, err := detector.SpecManager(key)
+	if err != nil {
+		agent.logger.Error(err, "failed to get the manager of the drifted collector", "key", key)
+	}
+	if manager == "" {
+		return "unknown"
+	}
+	return manager
+}
+
+func (agent *Agent) setDrifted(key string) {
+	agent.driftMu.Lock()
+	defer agent.driftMu.Unlock()
+	agent.drifted[key] = true
+}
+
+// loadDriftBaselines restores the spec hashes of the collectors as last changed through OpAMP, as saved on the
+// collectors, so that the changes made while the bridge wasn't running are handled as drifts. A collector without a
+// saved hash is taken to be in sync with the remote config.
+func (agent *Agent) loadDriftBaselines() {
+	detector, ok := agent.driftDetector()
+	if !ok {
+		return
+	}
+	agent.applyMu.Lock()
+	defer agent.applyMu.Unlock()
+
+	hashes, err := detector.SpecHashes()
+	if err != nil {
+		agent.logger.Error(err, "failed to get the spec hashes of the collectors")
+		return
+	}
+	baselines, err := detector.SpecBaselines()
+	if err != nil {
+		agent.logger.Error(err, "failed to get the saved spec hashes of the collectors")
+		return
+	}
+	unsaved := map[string]string{}
+	agent.driftMu.Lock()
+	for key, hash := range hashes {
+		baseline, ok := baselines[key]
+		if !ok {
+			baseline = hash
+			unsaved[key] = hash
+		}
+		agent.specHashes[key] = baseline
+	}
+	agent.driftMu.Unlock()
+	agent.saveDriftBaselines(unsaved)
+
+	for _, key := range slices.Sorted(maps.Keys(hashes)) {
+		if baseline, ok := baselines[key]; ok && baseline != hashes[key] {
+			agent.handleDrift(key, hashes[key])
+		}
+	}
+}
+
+// rebaselineDrift records the current spec hash of the collectors identified by keys as their hash as last changed
+// through OpAMP, and clears their drift. Without keys, every collector but the drifted ones is rebaselined.
+func (agent *Agent) rebaselineDrift(keys ...string) {
+	detector, ok := agent.driftDetector()
+	if !ok {
+		return
+	}
+	hashes, err := detector.SpecHashes()
+	if err != nil {
+		agent.logger.Error(err, "failed to get the spec hashes of the collectors")
+		return
+	}
+	changed := map[string]string{}
+	agent.driftMu.Lock()
+	if len(keys) == 0 {
+		for key := range agent.specHashes {
+			if _, ok := hashes[key]; !ok {
+				delete(agent.specHashes, key)
+				delete(agent.drifted, key)
+			}
+		}
+		for key, hash := range hashes {
+			if !agent.drifted[key] && agent.specHashes[key] != hash {
+				agent.specHashes[key] = hash
+				changed[key] = hash
+			}
+		}
+	}
+	for _, key := range keys {
+		delete(agent.drifted, key)
+		if hash, ok := hashes[key]; ok {
+			if agent.specHashes[key] != hash {
+				agent.specHashes[key] = hash
+				changed[key] = hash
+			}
+		} else {
+			delete(agent.specHashes, key)
+		}
+	}
+	agent.driftMu.Unlock()
+	agent.saveDriftBaselines(changed)
+}
+
+// saveDriftBaselines saves the spec hashes of the collectors as last changed through OpAMP on the collectors, so that
+// they survive a restart of the bridge.
+func (agent *Agent) saveDriftBaselines(baselines map[string]string) {
+	detector, ok := agent.driftDetector()
+	if !ok {
+		return
+	}
+	for _, key := range slices.Sorted(maps.Keys(baselines)) {
+		if err := detector.SaveSpecBaseline(key, baselines[key]); err != nil {
+			agent.logger.Error(err, "failed to save the spec hash of the collector", "key", key)
+		}
+	}
+}
+
+// rebaselineChanges rebaselines the collectors changed successfully through OpAMP.
+func (agent *Agent) rebaselineChanges(changes []auditChange) {
+	var keys []string
+	for _, change := range changes {
+		if change.err == nil && change.key != "" {
+			keys = append(keys, change.key)
+		}
+	}
+	if len(keys) > 0 {
+		agent.rebaselineDrift(keys...)
+	}
+}
+
+// driftedKeys returns the remote config entry names of the drifted collectors, sorted.
+func (agent *Agent) driftedKeys() []string {
+	agent.driftMu.Lock()
+	defer agent.driftMu.Unlock()
+	return slices.Sorted(maps.Keys(agent.drifted))
+}
+
+// reportDrift sends the health and effective config of the collectors to the server after a drift changed.
+func (agent *Agent) reportDrift() {
+	if err := agent.opampClient.SetHealth(agent.getHealth()); err != nil {
+		agent.logger.Error(err, "failed to report drift health")
+	}
+	if err := agent.opampClient.UpdateEffectiveConfig(context.Background()); err != nil {
+		agent.logger.Error(err, "failed to report drifted effective config")
+	}
+}
+
+// addDriftHealth adds a component to the root health listing the collectors that drifted from the last applied remote
+// config.
+func (agent *Agent) addDriftHealth(rootHealth *protobufs.ComponentHealth, statusTime uint64) {
+	drifted := agent.driftedKeys()
+	driftHealth := &protobufs.ComponentHealth{
+		Healthy:            len(drifted) == 0,
+		Status:             "no drift",
+		StatusTimeUnixNano: statusTime,
+		ComponentHealthMap: map[string]*protobufs.ComponentHealth{},
+	}
+	if len(drifted) > 0 {
+		driftHealth.Status = fmt.Sprintf("%d collector(s) drifted from the last applied remote config", len(drifted))
+	}
+	for _, key := range drifted {
+		driftHealth.ComponentHealthMap[key] = &protobufs.ComponentHealth{
+			Healthy:            false,
+			Status:             "drifted from the last applied remote config",
+			StatusTimeUnixNano: statusTime,
+		}
+	}
+	rootHealth.ComponentHealthMap[driftComponent] = driftHealth
+}
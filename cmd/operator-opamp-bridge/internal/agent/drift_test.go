@@ -0,0 +1,201 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"context"
+	"maps"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/open-telemetry/opamp-go/client/types"
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/internal/audit"
+	"github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/internal/config"
+)
+
+// driftApplier is a recordingConfigApplier whose collectors' spec hashes change with every apply.
+type driftApplier struct {
+	recordingConfigApplier
+	hashes    map[string]string
+	baselines map[string]string
+	manager   string
+}
+
+func (d *driftApplier) Apply(name string, configFile *protobufs.AgentConfigFile) error {
+	d.hashes[name] = "applied-" + string(configFile.Body)
+	return d.recordingConfigApplier.Apply(name, configFile)
+}
+
+func (d *driftApplier) SpecHashes() (map[string]string, error) {
+	return maps.Clone(d.hashes), nil
+}
+
+func (d *driftApplier) SpecBaselines() (map[string]string, error) {
+	return maps.Clone(d.baselines), nil
+}
+
+func (d *driftApplier) SaveSpecBaseline(key, hash string) error {
+	if d.baselines == nil {
+		d.baselines = map[string]string{}
+	}
+	d.baselines[key] = hash
+	return nil
+}
+
+func (d *driftApplier) SpecManager(string) (string, error) {
+	return d.manager, nil
+}
+
+func getDriftAgent(t *testing.T, policy config.DriftPolicy, applier *driftApplier, mockClient *mockOpampClient) *Agent {
+	conf := config.NewConfig(logr.Discard())
+	conf.Capabilities = map[config.Capability]bool{
+		config.AcceptsRemoteConfig:    true,
+		config.ReportsEffectiveConfig: true,
+		config.ReportsHealth:          true,
+	}
+	conf.DriftDetection = &config.DriftDetectionConfig{Policy: policy}
+	agent := NewAgent(logr.Discard(), applier, conf, mockClient, newMockProxy(nil, nil, nil))
+	require.NoError(t, agent.Start())
+	t.Cleanup(agent.Shutdown)
+	return agent
+}
+
+func driftHealth(t *testing.T, mockClient *mockOpampClient) *protobufs.ComponentHealth {
+	mockClient.mu.Lock()
+	defer mockClient.mu.Unlock()
+	require.NotNil(t, mockClient.lastHealth)
+	health, ok := mockClient.lastHealth.ComponentHealthMap[driftComponent]
+	require.True(t, ok, "the health must have a drift component")
+	return health
+}
+
+func TestAgent_CheckDrift_Report(t *testing.T) {
+	applier := &driftApplier{hashes: map[string]string{testCollectorKey: "original"}}
+	mockClient := &mockOpampClient{}
+	agent := getDriftAgent(t, config.DriftPolicyReport, applier, mockClient)
+	assert.Equal(t, map[string]string{testCollectorKey: "original"}, applier.baselines, "a collector without a saved hash is taken to be in sync")
+
+	// an unchanged collector isn't reported
+	agent.CheckDrift(testCollectorKey, applier.hashes[testCollectorKey])
+	assert.Empty(t, agent.driftedKeys())
+
+	applier.hashes[testCollectorKey] = "edited"
+	agent.CheckDrift(testCollectorKey, applier.hashes[testCollectorKey])
+	assert.Equal(t, []string{testCollectorKey}, agent.driftedKeys())
+	assert.Empty(t, applier.applied)
+	health := driftHealth(t, mockClient)
+	assert.False(t, health.Healthy)
+	assert.Equal(t, "1 collector(s) drifted from the last applied remote config", health.Status)
+	assert.Contains(t, health.ComponentHealthMap, testCollectorKey)
+	assert.NotNil(t, mockClient.lastEffectiveConfig)
+
+	// reverting the change clears the drift
+	applier.hashes[testCollectorKey] = "original"
+	agent.CheckDrift(testCollectorKey, applier.hashes[testCollectorKey])
+	assert.Empty(t, agent.driftedKeys())
+	health = driftHealth(t, mockClient)
+	assert.True(t, health.Healthy)
+	assert.Equal(t, "no drift", health.Status)
+}
+
+func TestAgent_CheckDrift_Enforce(t *testing.T) {
+	applier := &driftApplier{hashes: map[string]string{}}
+	mockClient := &mockOpampClient{}
+	agent := getDriftAgent(t, config.DriftPolicyEnforce, applier, mockClient)
+
+	remoteConfig := &protobufs.AgentRemoteConfig{
+		Config: &protobufs.AgentConfigMap{ConfigMap: map[string]*protobufs.AgentConfigFile{
+			testCollectorKey: {Body: []byte("first")},
+		}},
+		ConfigHash: []byte("hash"),
+	}
+	agent.onMessage(context.Background(), &types.MessageData{RemoteConfig: remoteConfig})
+	require.Equal(t, "applied-first", applier.hashes[testCollectorKey])
+	applier.applied = nil
+
+	// the remote config is applied again to the drifted collector
+	applier.hashes[testCollectorKey] = "edited"
+	agent.CheckDrift(testCollectorKey, applier.hashes[testCollectorKey])
+	assert.Equal(t, map[string][]byte{testCollectorKey: []byte("first")}, applier.applied)
+	assert.Equal(t, "applied-first", applier.hashes[testCollectorKey])
+	assert.Equal(t, "applied-first", applier.baselines[testCollectorKey])
+	assert.Empty(t, agent.driftedKeys())
+
+	// a collector the remote config has no entry for is reported instead
+	applier.hashes[otherCollectorKey] = "original"
+	agent.CheckDrift(otherCollectorKey, applier.hashes[otherCollectorKey])
+	applier.hashes[otherCollectorKey] = "edited"
+	agent.CheckDrift(otherCollectorKey, applier.hashes[otherCollectorKey])
+	assert.Equal(t, []string{otherCollectorKey}, agent.driftedKeys())
+
+	// a deleted collector is forgotten
+	delete(applier.hashes, otherCollectorKey)
+	agent.CheckDrift(otherCollectorKey, applier.hashes[otherCollectorKey])
+	assert.Empty(t, agent.driftedKeys())
+}
+
+func TestAgent_CheckDrift_Adopt(t *testing.T) {
+	applier := &driftApplier{hashes: map[string]string{testCollectorKey: "original"}, manager: "kubectl-edit"}
+	mockClient := &mockOpampClient{}
+	agent := getDriftAgent(t, config.DriftPolicyAdopt, applier, mockClient)
+	auditLog := newTestAuditLog(t)
+	agent.SetAuditLog(auditLog)
+
+	applier.hashes[testCollectorKey] = "edited"
+	agent.CheckDrift(testCollectorKey, applier.hashes[testCollectorKey])
+	assert.Empty(t, applier.applied)
+	records := auditLog.Records()
+	require.Len(t, records, 1)
+	assert.Equal(t, audit.ActionAdopt, records[0].Action)
+	assert.Equal(t, "kubectl-edit", records[0].Actor, "the adopted change is attributed to its field manager")
+	assert.Empty(t, agent.driftedKeys())
+	assert.NotNil(t, mockClient.lastEffectiveConfig)
+
+	// the change is the new reference
+	agent.CheckDrift(testCollectorKey, applier.hashes[testCollectorKey])
+	assert.Empty(t, agent.driftedKeys())
+	assert.Equal(t, "edited", agent.specHashes[testCollectorKey])
+	assert.Equal(t, "edited", applier.baselines[testCollectorKey])
+}
+
+func TestAgent_Start_DetectsDriftWhileStopped(t *testing.T) {
+	applier := &driftApplier{
+		hashes:    map[string]string{testCollectorKey: "edited", otherCollectorKey: "original"},
+		baselines: map[string]string{testCollectorKey: "original"},
+	}
+	mockClient := &mockOpampClient{}
+	agent := getDriftAgent(t, config.DriftPolicyEnforce, applier, mockClient)
+
+	// the remote config isn't known yet, so the drifted collector is reported
+	assert.Equal(t, []string{testCollectorKey}, agent.driftedKeys())
+	assert.Equal(t, "original", agent.specHashes[testCollectorKey])
+	assert.Equal(t, "original", applier.baselines[otherCollectorKey])
+	assert.Empty(t, applier.applied)
+
+	// until the remote config is applied again
+	remoteConfig := &protobufs.AgentRemoteConfig{
+		Config: &protobufs.AgentConfigMap{ConfigMap: map[string]*protobufs.AgentConfigFile{
+			testCollectorKey: {Body: []byte("first")},
+		}},
+		ConfigHash: []byte("hash"),
+	}
+	agent.onMessage(context.Background(), &types.MessageData{RemoteConfig: remoteConfig})
+	assert.Empty(t, agent.driftedKeys())
+	assert.Equal(t, "applied-first", applier.baselines[testCollectorKey])
+}
+
+func TestAgent_CheckDrift_Disabled(t *testing.T) {
+	applier := &driftApplier{hashes: map[string]string{testCollectorKey: "original"}}
+	mockClient := &mockOpampClient{}
+	agent := NewAgent(logr.Discard(), applier, config.NewConfig(logr.Discard()), mockClient, newMockProxy(nil, nil, nil))
+
+	applier.hashes[testCollectorKey] = "edited"
+	agent.CheckDrift(testCollectorKey, applier.hashes[testCollectorKey])
+	assert.Empty(t, agent.driftedKeys())
+	assert.Nil(t, mockClient.lastHealth)
+}
@@ -0,0 +1,187 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+
+	"github.com/open-telemetry/opamp-go/client/types"
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/internal/operator"
+)
+
+var errPackagesNotSynced = errors.New("packages are rolled out to collector images, not synced by the bridge")
+
+var _ types.PackagesStateProvider = &packagesStateProvider{}
+
+// packagesStateProvider holds the package statuses last reported by the bridge. The bridge rolls packages out to
+// collector images itself instead of downloading them with a PackagesSyncer, so the local package state is never
+// used.
+type packagesStateProvider struct {
+	statuses *protobufs.PackageStatuses
+}
+
+func (p *packagesStateProvider) AllPackagesHash() ([]byte, error) {
+	return p.statuses.GetServerProvidedAllPackagesHash(), nil
+}
+
+func (*packagesStateProvider) SetAllPackagesHash([]byte) error {
+	return errPackagesNotSynced
+}
+
+func (*packagesStateProvider) Packages() ([]string, error) {
+	return nil, nil
+}
+
+func (*packagesStateProvider) PackageState(string) (types.PackageState, error) {
+	return types.PackageState{}, nil
+}
+
+func (*packagesStateProvider) SetPackageState(string, types.PackageState) error {
+	return errPackagesNotSynced
+}
+
+func (*packagesStateProvider) CreatePackage(string, protobufs.PackageType) error {
+	return errPackagesNotSynced
+}
+
+func (*packagesStateProvider) FileContentHash(string) ([]byte, error) {
+	return nil, nil
+}
+
+func (*packagesStateProvider) UpdateContent(context.Context, string, io.Reader, []byte, []byte) error {
+	return errPackagesNotSynced
+}
+
+func (*packagesStateProvider) DeletePackage(string) error {
+	return errPackagesNotSynced
+}
+
+func (p *packagesStateProvider) LastReportedStatuses() (*protobufs.PackageStatuses, error) {
+	return p.statuses, nil
+}
+
+func (p *packagesStateProvider) SetLastReportedStatuses(statuses *protobufs.PackageStatuses) error {
+	p.statuses = statuses
+	return nil
+}
+
+// applyPackages rolls the packages offered by the server out to the images of the collectors labeled with their
+// names. A package is reported as installing until its rollout completes, see updatePackageStatuses. Packages the
+// bridge isn't configured for, or versions that aren't allowed, are reported as failed.
+func (agent *Agent) applyPackages(available *protobufs.PackagesAvailable) error {
+	agent.packagesMu.Lock()
+	defer agent.packagesMu.Unlock()
+
+	previous := agent.packages.statuses
+	if previous != nil && bytes.Equal(previous.GetServerProvidedAllPackagesHash(), available.GetAllPackagesHash()) {
+		return nil
+	}
+	applier, canApply := agent.applier.(operator.PackageApplier)
+
+	statuses := &protobufs.PackageStatuses{
+		Packages: map[string]*protobufs.PackageStatus{},
+		// the server hash must be set for the statuses to be sent
+		ServerProvidedAllPackagesHash: append([]byte{}, available.GetAllPackagesHash()...),
+	}
+	for _, name := range slices.Sorted(maps.Keys(available.GetPackages())) {
+		offered := available.GetPackages()[name]
+		status := &protobufs.PackageStatus{
+			Name:                 name,
+			ServerOfferedVersion: offered.GetVersion(),
+			ServerOfferedHash:    offered.GetHash(),
+			Status:               protobufs.PackageStatusEnum_PackageStatusEnum_Installing,
+		}
+		if current, ok := previous.GetPackages()[name]; ok {
+			status.AgentHasVersion = current.GetAgentHasVersion()
+			status.AgentHasHash = current.GetAgentHasHash()
+		}
+		statuses.Packages[name] = status
+
+		if status.GetAgentHasVersion() == status.GetServerOfferedVersion() && bytes.Equal(status.GetAgentHasHash(), status.GetServerOfferedHash()) {
+			status.Status = protobufs.PackageStatusEnum_PackageStatusEnum_Installed
+			continue
+		}
+		image, err := agent.packageImage(name, offered.GetVersion())
+		if err == nil && !canApply {
+			err = errors.New("packages can't be rolled out by this bridge")
+		}
+		if err == nil {
+			err = applier.ApplyPackage(name, image)
+		}
+		if err != nil {
+			agent.logger.Error(err, "failed to apply package", "package", name, "version", offered.GetVersion())
+			status.Status = protobufs.PackageStatusEnum_PackageStatusEnum_InstallFailed
+			status.ErrorMessage = err.Error()
+			continue
+		}
+		agent.logger.Info("Rolling out package", "package", name, "image", image)
+		// the image of the collectors was changed through OpAMP, so it isn't a drift
+		agent.rebaselineDrift()
+	}
+	return agent.setPackageStatuses(statuses)
+}
+
+// updatePackageStatuses checks the rollout of the packages being installed, and reports the ones whose rollout
+// completed or failed.
+func (agent *Agent) updatePackageStatuses() error {
+	agent.packagesMu.Lock()
+	defer agent.packagesMu.Unlock()
+
+	applier, ok := agent.applier.(operator.PackageApplier)
+	if !ok || agent.packages.statuses == nil {
+		return nil
+	}
+	statuses := proto.Clone(agent.packages.statuses).(*protobufs.PackageStatuses)
+	for name, status := range statuses.GetPackages() {
+		if status.GetStatus() != protobufs.PackageStatusEnum_PackageStatusEnum_Installing {
+			continue
+		}
+		image, err := agent.packageImage(name, status.GetServerOfferedVersion())
+		done := false
+		if err == nil {
+			done, err = applier.PackageRollout(name, image)
+		}
+		switch {
+		case err != nil:
+			agent.logger.Error(err, "package rollout failed", "package", name)
+			status.Status = protobufs.PackageStatusEnum_PackageStatusEnum_InstallFailed
+			status.ErrorMessage = err.Error()
+		case done:
+			agent.logger.Info("Package rolled out", "package", name, "image", image)
+			status.Status = protobufs.PackageStatusEnum_PackageStatusEnum_Installed
+			status.AgentHasVersion = status.GetServerOfferedVersion()
+			status.AgentHasHash = status.GetServerOfferedHash()
+		}
+	}
+	if proto.Equal(statuses, agent.packages.statuses) {
+		return nil
+	}
+	return agent.setPackageStatuses(statuses)
+}
+
+// packageImage returns the collector image of the given version of a configured package.
+func (agent *Agent) packageImage(name, version string) (string, error) {
+	pkg, ok := agent.config.Packages[name]
+	if !ok {
+		return "", fmt.Errorf("package %q is not configured in the bridge", name)
+	}
+	return pkg.GetImage(version)
+}
+
+// setPackageStatuses records the package statuses and reports them to the server.
+func (agent *Agent) setPackageStatuses(statuses *protobufs.PackageStatuses) error {
+	if err := agent.packages.SetLastReportedStatuses(statuses); err != nil {
+		return err
+	}
+	return agent.opampClient.SetPackageStatuses(statuses)
+}
@@ -0,0 +1,104 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/internal/config"
+)
+
+func getPackagesAgent(applier *recordingPackageApplier, mockClient *mockOpampClient) *Agent {
+	conf := config.NewConfig(logr.Discard())
+	conf.Packages = map[string]config.PackageConfig{
+		"collector": {Image: "ghcr.io/open-telemetry/opentelemetry-collector-releases/opentelemetry-collector-k8s"},
+	}
+	return NewAgent(logr.Discard(), applier, conf, mockClient, newMockProxy(nil, nil, nil))
+}
+
+func packagesAvailable(hash, version string) *protobufs.PackagesAvailable {
+	return &protobufs.PackagesAvailable{
+		AllPackagesHash: []byte(hash),
+		Packages: map[string]*protobufs.PackageAvailable{
+			"collector": {Type: protobufs.PackageType_PackageType_TopLevel, Version: version, Hash: []byte(version)},
+			"unknown":   {Type: protobufs.PackageType_PackageType_TopLevel, Version: version, Hash: []byte(version)},
+		},
+	}
+}
+
+func TestAgent_applyPackages(t *testing.T) {
+	applier := &recordingPackageApplier{}
+	mockClient := &mockOpampClient{}
+	agent := getPackagesAgent(applier, mockClient)
+
+	require.NoError(t, agent.applyPackages(packagesAvailable("v1", "0.120.0")))
+
+	assert.Equal(t, map[string]string{
+		"collector": "ghcr.io/open-telemetry/opentelemetry-collector-releases/opentelemetry-collector-k8s:0.120.0",
+	}, applier.images)
+	statuses := mockClient.lastPackageStatuses
+	require.NotNil(t, statuses)
+	assert.Equal(t, []byte("v1"), statuses.ServerProvidedAllPackagesHash)
+	assert.Equal(t, protobufs.PackageStatusEnum_PackageStatusEnum_Installing, statuses.Packages["collector"].Status)
+	assert.Equal(t, "0.120.0", statuses.Packages["collector"].ServerOfferedVersion)
+	assert.Empty(t, statuses.Packages["collector"].AgentHasVersion)
+	assert.Equal(t, protobufs.PackageStatusEnum_PackageStatusEnum_InstallFailed, statuses.Packages["unknown"].Status)
+	assert.Contains(t, statuses.Packages["unknown"].ErrorMessage, `package "unknown" is not configured in the bridge`)
+
+	// the rollout is in progress
+	require.NoError(t, agent.updatePackageStatuses())
+	assert.Equal(t, 1, applier.rolloutCall)
+	assert.Same(t, statuses, mockClient.lastPackageStatuses)
+
+	applier.rolledOut = true
+	require.NoError(t, agent.updatePackageStatuses())
+	statuses = mockClient.lastPackageStatuses
+	assert.Equal(t, protobufs.PackageStatusEnum_PackageStatusEnum_Installed, statuses.Packages["collector"].Status)
+	assert.Equal(t, "0.120.0", statuses.Packages["collector"].AgentHasVersion)
+	assert.Equal(t, []byte("0.120.0"), statuses.Packages["collector"].AgentHasHash)
+
+	// the same packages are offered again
+	applier.images = nil
+	require.NoError(t, agent.applyPackages(packagesAvailable("v1", "0.120.0")))
+	assert.Nil(t, applier.images)
+	require.NoError(t, agent.applyPackages(packagesAvailable("v2", "0.120.0")))
+	assert.Nil(t, applier.images)
+	assert.Equal(t, protobufs.PackageStatusEnum_PackageStatusEnum_Installed, mockClient.lastPackageStatuses.Packages["collector"].Status)
+}
+
+func TestAgent_updatePackageStatusesReportsFailedRollout(t *testing.T) {
+	applier := &recordingPackageApplier{rolloutErr: errors.New("pod testnamespace/collector-0 failed to pull image")}
+	mockClient := &mockOpampClient{}
+	agent := getPackagesAgent(applier, mockClient)
+
+	require.NoError(t, agent.applyPackages(packagesAvailable("v1", "0.120.0@sha256:abc")))
+	require.NoError(t, agent.updatePackageStatuses())
+
+	status := mockClient.lastPackageStatuses.Packages["collector"]
+	assert.Equal(t, protobufs.PackageStatusEnum_PackageStatusEnum_InstallFailed, status.Status)
+	assert.Equal(t, "pod testnamespace/collector-0 failed to pull image", status.ErrorMessage)
+	assert.Empty(t, status.AgentHasVersion)
+}
+
+func TestAgent_applyPackagesRejectsDisallowedDigest(t *testing.T) {
+	applier := &recordingPackageApplier{}
+	mockClient := &mockOpampClient{}
+	agent := getPackagesAgent(applier, mockClient)
+	pkg := agent.config.Packages["collector"]
+	pkg.DigestsAllowed = []string{"sha256:abc"}
+	agent.config.Packages["collector"] = pkg
+
+	require.NoError(t, agent.applyPackages(packagesAvailable("v1", "0.120.0@sha256:def")))
+
+	assert.Nil(t, applier.images)
+	status := mockClient.lastPackageStatuses.Packages["collector"]
+	assert.Equal(t, protobufs.PackageStatusEnum_PackageStatusEnum_InstallFailed, status.Status)
+	assert.Contains(t, status.ErrorMessage, "isn't pinned to an allowed digest")
+}
@@ -0,0 +1,135 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/open-telemetry/opamp-go/protobufs"
+
+	"github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/internal/audit"
+	"github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/internal/signature"
+)
+
+// manifestSignatureEntry names the remote config entry holding the signature of the manifest.
+const manifestSignatureEntry = signature.ManifestEntry + signature.EntrySuffix
+
+// ManifestVersionStore persists the version and the digest of the last verified remote config manifest, so that an
+// older manifest can't be replayed after a restart or a failover.
+type ManifestVersionStore interface {
+	// LoadManifestVersion returns the persisted version and digest, zero and empty when none was persisted yet.
+	LoadManifestVersion(ctx context.Context) (uint64, string, error)
+	SaveManifestVersion(ctx context.Context, version uint64, digest string) error
+}
+
+// SetManifestVersionStore sets the store the last verified manifest is loaded from when the agent starts, and
+// persisted to before a remote config is accepted. The manifest is only kept in memory when it's nil.
+func (agent *Agent) SetManifestVersionStore(store ManifestVersionStore) {
+	agent.manifestStore = store
+}
+
+// loadManifestVersion loads the last verified manifest, before any remote config is received.
+func (agent *Agent) loadManifestVersion() error {
+	if agent.manifestStore == nil {
+		return nil
+	}
+	version, digest, err := agent.manifestStore.LoadManifestVersion(context.Background())
+	if err != nil {
+		return fmt.Errorf("failed to load the remote config manifest version: %w", err)
+	}
+	agent.manifestVersion, agent.manifestDigest = version, digest
+	return nil
+}
+
+// verifyRemoteConfig checks that the remote config carries a manifest signed by one of the configured trust roots,
+// newer than the last verified one and not expired, and that its entries are exactly the ones listed in the manifest
+// with matching digests. As the manifest lists the whole set of entries, a remote config without a signed manifest is
+// rejected rather than deleting the entries it lacks. The last verified manifest itself is accepted again, as the
+// server sends it again when the bridge restarts, and is persisted before the remote config is accepted. It returns the
+// remote config without the manifest entries, or an error naming every mismatched entry.
+func (agent *Agent) verifyRemoteConfig(config *protobufs.AgentRemoteConfig) (*protobufs.AgentRemoteConfig, error) {
+	if agent.verifier == nil {
+		return nil, errors.New("remote config signatures can't be verified: no valid trust root is configured")
+	}
+	configMap := config.GetConfig().GetConfigMap()
+	manifestFile, ok := configMap[signature.ManifestEntry]
+	if !ok {
+		return nil, fmt.Errorf("remote config has no %q entry", signature.ManifestEntry)
+	}
+	sig, ok := configMap[manifestSignatureEntry]
+	if !ok {
+		return nil, fmt.Errorf("remote config manifest is not signed: it has no %q entry", manifestSignatureEntry)
+	}
+	if err := agent.verifier.Verify(manifestFile.GetBody(), sig.GetBody()); err != nil {
+		return nil, fmt.Errorf("remote config manifest has an invalid signature: %w", err)
+	}
+	manifest, err := signature.ParseManifest(manifestFile.GetBody())
+	if err != nil {
+		return nil, err
+	}
+	digest := signature.Digest(manifestFile.GetBody())
+	if manifest.Version < agent.manifestVersion || (manifest.Version == agent.manifestVersion && digest != agent.manifestDigest) {
+		return nil, fmt.Errorf("remote config manifest version %d is not newer than the applied version %d", manifest.Version, agent.manifestVersion)
+	}
+	if manifest.Expires != nil && agent.clock.Now().After(*manifest.Expires) {
+		return nil, fmt.Errorf("remote config manifest expired at %s", manifest.Expires)
+	}
+	verified := map[string]*protobufs.AgentConfigFile{}
+	var errs []error
+	for _, key := range slices.Sorted(maps.Keys(configMap)) {
+		if isManifestEntry(key) {
+			continue
+		}
+		digest, ok := manifest.Entries[key]
+		if !ok {
+			errs = append(errs, fmt.Errorf("remote config entry %q is not listed in the manifest", key))
+			continue
+		}
+		if digest != signature.Digest(configMap[key].GetBody()) {
+			errs = append(errs, fmt.Errorf("remote config entry %q doesn't match its digest in the manifest", key))
+			continue
+		}
+		verified[key] = configMap[key]
+	}
+	for _, key := range slices.Sorted(maps.Keys(manifest.Entries)) {
+		if _, ok := configMap[key]; !ok {
+			errs = append(errs, fmt.Errorf("remote config entry %q listed in the manifest is missing", key))
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	if agent.manifestStore != nil && (manifest.Version != agent.manifestVersion || digest != agent.manifestDigest) {
+		if err = agent.manifestStore.SaveManifestVersion(context.Background(), manifest.Version, digest); err != nil {
+			return nil, fmt.Errorf("failed to persist the remote config manifest version: %w", err)
+		}
+	}
+	agent.manifestVersion, agent.manifestDigest = manifest.Version, digest
+	return &protobufs.AgentRemoteConfig{
+		Config:     &protobufs.AgentConfigMap{ConfigMap: verified},
+		ConfigHash: config.GetConfigHash(),
+	}, nil
+}
+
+// rejectRemoteConfig reports a remote config that failed verification, without applying any of its entries.
+func (agent *Agent) rejectRemoteConfig(config *protobufs.AgentRemoteConfig, err error) (*protobufs.RemoteConfigStatus, error) {
+	agent.lastHash = config.GetConfigHash()
+	var changes []auditChange
+	for _, key := range slices.Sorted(maps.Keys(config.GetConfig().GetConfigMap())) {
+		if !isManifestEntry(key) {
+			changes = append(changes, auditChange{action: audit.ActionApply, key: key, err: err})
+		}
+	}
+	agent.recordChanges(agent.lastHash, nil, changes)
+	return agent.failedRemoteConfigStatus(fmt.Errorf("remote config rejected: %w", err))
+}
+
+// isManifestEntry returns whether key names the manifest or its signature rather than a resource.
+func isManifestEntry(key string) bool {
+	return key == signature.ManifestEntry || key == manifestSignatureEntry
+}
@@ -0,0 +1,318 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"maps"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/internal/audit"
+	"github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/internal/operator"
+	"github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/internal/signature"
+)
+
+func getSigningAgent(t *testing.T) (*Agent, *operator.Client, ed25519.PrivateKey) {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(publicKey)
+	require.NoError(t, err)
+	conf := config.NewConfig(logr.Discard())
+	conf.SignatureVerification = &config.SignatureVerificationConfig{
+		TrustRoots: []string{string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))},
+	}
+	applier := getFakeApplier(t, conf)
+	return NewAgent(logr.Discard(), applier, conf, &mockOpampClient{}, newMockProxy(nil, nil, nil)), applier, privateKey
+}
+
+// signRemoteConfig adds a manifest of version listing the entries of the remote config, signed with key.
+func signRemoteConfig(t *testing.T, remoteConfig *protobufs.AgentRemoteConfig, key ed25519.PrivateKey, version uint64) {
+	manifest := &signature.Manifest{Version: version, Entries: map[string]string{}}
+	for name, file := range remoteConfig.GetConfig().GetConfigMap() {
+		manifest.Entries[name] = signature.Digest(file.GetBody())
+	}
+	signManifest(t, remoteConfig, key, manifest)
+}
+
+func signManifest(t *testing.T, remoteConfig *protobufs.AgentRemoteConfig, key ed25519.PrivateKey, manifest *signature.Manifest) {
+	body, err := json.Marshal(manifest)
+	require.NoError(t, err)
+	configMap := remoteConfig.GetConfig().GetConfigMap()
+	configMap[signature.ManifestEntry] = &protobufs.AgentConfigFile{Body: body, ContentType: "application/json"}
+	configMap[signature.ManifestEntry+signature.EntrySuffix] = &protobufs.AgentConfigFile{
+		Body:        []byte(base64.StdEncoding.EncodeToString(ed25519.Sign(key, body))),
+		ContentType: "text/plain",
+	}
+}
+
+func TestAgent_applyRemoteConfig_SignedEntries(t *testing.T) {
+	agent, _, key := getSigningAgent(t)
+	data, err := getMessageDataFromConfigFile(map[string]string{testCollectorKey: collectorBasicFile})
+	require.NoError(t, err)
+	signRemoteConfig(t, data.RemoteConfig, key, 1)
+
+	status, err := agent.applyRemoteConfig(data.RemoteConfig)
+
+	require.NoError(t, err)
+	assert.Equal(t, protobufs.RemoteConfigStatuses_RemoteConfigStatuses_APPLIED, status.Status)
+	assert.Equal(t, map[string]bool{testCollectorKey: true}, agent.appliedKeys, "the manifest entries aren't applied")
+	assert.Equal(t, []string{testCollectorKey}, slices.Sorted(maps.Keys(agent.lastRemoteConfig.GetConfig().GetConfigMap())))
+	assert.Equal(t, data.RemoteConfig.GetConfigHash(), agent.lastRemoteConfig.GetConfigHash())
+	assert.Equal(t, uint64(1), agent.manifestVersion)
+}
+
+func TestAgent_applyRemoteConfig_SignedRemovals(t *testing.T) {
+	agent, applier, key := getSigningAgent(t)
+	data, err := getMessageDataFromConfigFile(map[string]string{testCollectorKey: collectorBasicFile})
+	require.NoError(t, err)
+	signRemoteConfig(t, data.RemoteConfig, key, 1)
+	_, err = agent.applyRemoteConfig(data.RemoteConfig)
+	require.NoError(t, err)
+
+	// an unsigned empty remote config doesn't delete the collector
+	empty := &protobufs.AgentRemoteConfig{Config: &protobufs.AgentConfigMap{ConfigMap: map[string]*protobufs.AgentConfigFile{}}, ConfigHash: []byte("empty")}
+	status, err := agent.applyRemoteConfig(empty)
+
+	require.Error(t, err)
+	assert.Equal(t, protobufs.RemoteConfigStatuses_RemoteConfigStatuses_FAILED, status.Status)
+	assert.Contains(t, status.ErrorMessage, fmt.Sprintf("remote config has no %q entry", signature.ManifestEntry))
+	assert.Equal(t, map[string]bool{testCollectorKey: true}, agent.appliedKeys)
+	instance, err := applier.GetInstance(testCollectorName, testNamespace)
+	require.NoError(t, err)
+	assert.NotNil(t, instance)
+
+	// a signed empty remote config does
+	empty.ConfigHash = []byte("signed empty")
+	signRemoteConfig(t, empty, key, 2)
+	status, err = agent.applyRemoteConfig(empty)
+
+	require.NoError(t, err)
+	assert.Equal(t, protobufs.RemoteConfigStatuses_RemoteConfigStatuses_APPLIED, status.Status)
+	instance, err = applier.GetInstance(testCollectorName, testNamespace)
+	require.NoError(t, err)
+	assert.Nil(t, instance)
+}
+
+// memoryManifestStore is a ManifestVersionStore shared by the agents of a test, as if they were restarted.
+type memoryManifestStore struct {
+	version uint64
+	digest  string
+}
+
+func (s *memoryManifestStore) LoadManifestVersion(context.Context) (uint64, string, error) {
+	return s.version, s.digest, nil
+}
+
+func (s *memoryManifestStore) SaveManifestVersion(_ context.Context, version uint64, digest string) error {
+	s.version, s.digest = version, digest
+	return nil
+}
+
+func TestAgent_applyRemoteConfig_RejectsManifestsReplayedAfterRestart(t *testing.T) {
+	store := &memoryManifestStore{}
+	agent, _, key := getSigningAgent(t)
+	agent.SetManifestVersionStore(store)
+	first, err := getMessageDataFromConfigFile(map[string]string{testCollectorKey: collectorBasicFile})
+	require.NoError(t, err)
+	signRemoteConfig(t, first.RemoteConfig, key, 1)
+	second, err := getMessageDataFromConfigFile(map[string]string{testCollectorKey: collectorUpdatedFile})
+	require.NoError(t, err)
+	signRemoteConfig(t, second.RemoteConfig, key, 2)
+	_, err = agent.applyRemoteConfig(second.RemoteConfig)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), store.version)
+
+	// the restarted agent shares the trust roots and the store
+	restarted := NewAgent(logr.Discard(), agent.applier, agent.config, &mockOpampClient{}, newMockProxy(nil, nil, nil))
+	restarted.SetManifestVersionStore(store)
+	require.NoError(t, restarted.loadManifestVersion())
+
+	status, err := restarted.applyRemoteConfig(first.RemoteConfig)
+	require.Error(t, err)
+	assert.Contains(t, status.ErrorMessage, "remote config manifest version 1 is not newer than the applied version 2")
+
+	// the server sends the last manifest again on reconnection
+	status, err = restarted.applyRemoteConfig(second.RemoteConfig)
+	require.NoError(t, err)
+	assert.Equal(t, protobufs.RemoteConfigStatuses_RemoteConfigStatuses_APPLIED, status.Status)
+
+	// another manifest with the same version isn't
+	other, err := getMessageDataFromConfigFile(map[string]string{testCollectorKey: collectorBasicFile})
+	require.NoError(t, err)
+	other.RemoteConfig.ConfigHash = []byte("other")
+	signRemoteConfig(t, other.RemoteConfig, key, 2)
+	status, err = restarted.applyRemoteConfig(other.RemoteConfig)
+	require.Error(t, err)
+	assert.Contains(t, status.ErrorMessage, "remote config manifest version 2 is not newer than the applied version 2")
+}
+
+func TestAgent_applyRemoteConfig_RejectsReplayedManifests(t *testing.T) {
+	agent, _, key := getSigningAgent(t)
+	first, err := getMessageDataFromConfigFile(map[string]string{testCollectorKey: collectorBasicFile})
+	require.NoError(t, err)
+	signRemoteConfig(t, first.RemoteConfig, key, 1)
+	second, err := getMessageDataFromConfigFile(map[string]string{testCollectorKey: collectorUpdatedFile})
+	require.NoError(t, err)
+	signRemoteConfig(t, second.RemoteConfig, key, 2)
+	_, err = agent.applyRemoteConfig(second.RemoteConfig)
+	require.NoError(t, err)
+
+	status, err := agent.applyRemoteConfig(first.RemoteConfig)
+
+	require.Error(t, err)
+	assert.Equal(t, protobufs.RemoteConfigStatuses_RemoteConfigStatuses_FAILED, status.Status)
+	assert.Contains(t, status.ErrorMessage, "remote config manifest version 1 is not newer than the applied version 2")
+	assert.Equal(t, second.RemoteConfig.GetConfigHash(), agent.lastRemoteConfig.GetConfigHash())
+	assert.Equal(t, uint64(2), agent.manifestVersion)
+}
+
+func TestAgent_applyRemoteConfig_RejectsUnverifiedEntries(t *testing.T) {
+	tests := []struct {
+		name    string
+		prepare func(remoteConfig *protobufs.AgentRemoteConfig, key ed25519.PrivateKey)
+		wantErr []string
+	}{
+		{
+			name:    "no manifest",
+			prepare: func(*protobufs.AgentRemoteConfig, ed25519.PrivateKey) {},
+			wantErr: []string{fmt.Sprintf("remote config has no %q entry", signature.ManifestEntry)},
+		},
+		{
+			name: "unsigned manifest",
+			prepare: func(remoteConfig *protobufs.AgentRemoteConfig, key ed25519.PrivateKey) {
+				signRemoteConfig(t, remoteConfig, key, 1)
+				delete(remoteConfig.GetConfig().GetConfigMap(), signature.ManifestEntry+signature.EntrySuffix)
+			},
+			wantErr: []string{"remote config manifest is not signed"},
+		},
+		{
+			name: "invalid signature",
+			prepare: func(remoteConfig *protobufs.AgentRemoteConfig, key ed25519.PrivateKey) {
+				signRemoteConfig(t, remoteConfig, key, 1)
+				manifest := remoteConfig.GetConfig().GetConfigMap()[signature.ManifestEntry]
+				manifest.Body = append(manifest.Body, ' ')
+			},
+			wantErr: []string{"remote config manifest has an invalid signature: the signature doesn't match any valid trust root"},
+		},
+		{
+			name: "untrusted key",
+			prepare: func(remoteConfig *protobufs.AgentRemoteConfig, _ ed25519.PrivateKey) {
+				_, otherKey, err := ed25519.GenerateKey(rand.Reader)
+				require.NoError(t, err)
+				signRemoteConfig(t, remoteConfig, otherKey, 1)
+			},
+			wantErr: []string{"remote config manifest has an invalid signature"},
+		},
+		{
+			name: "entry not listed",
+			prepare: func(remoteConfig *protobufs.AgentRemoteConfig, key ed25519.PrivateKey) {
+				signManifest(t, remoteConfig, key, &signature.Manifest{Version: 1, Entries: map[string]string{
+					testCollectorKey: signature.Digest(remoteConfig.GetConfig().GetConfigMap()[testCollectorKey].GetBody()),
+				}})
+			},
+			wantErr: []string{fmt.Sprintf("remote config entry %q is not listed in the manifest", otherCollectorKey)},
+		},
+		{
+			name: "tampered entry",
+			prepare: func(remoteConfig *protobufs.AgentRemoteConfig, key ed25519.PrivateKey) {
+				signRemoteConfig(t, remoteConfig, key, 1)
+				configMap := remoteConfig.GetConfig().GetConfigMap()
+				configMap[otherCollectorKey].Body = append([]byte("# tampered\n"), configMap[otherCollectorKey].Body...)
+			},
+			wantErr: []string{fmt.Sprintf("remote config entry %q doesn't match its digest in the manifest", otherCollectorKey)},
+		},
+		{
+			name: "renamed entry",
+			prepare: func(remoteConfig *protobufs.AgentRemoteConfig, key ed25519.PrivateKey) {
+				signRemoteConfig(t, remoteConfig, key, 1)
+				configMap := remoteConfig.GetConfig().GetConfigMap()
+				configMap["testnamespace/renamed"] = configMap[otherCollectorKey]
+				delete(configMap, otherCollectorKey)
+			},
+			wantErr: []string{
+				`remote config entry "testnamespace/renamed" is not listed in the manifest`,
+				fmt.Sprintf("remote config entry %q listed in the manifest is missing", otherCollectorKey),
+			},
+		},
+		{
+			name: "missing entry",
+			prepare: func(remoteConfig *protobufs.AgentRemoteConfig, key ed25519.PrivateKey) {
+				remoteConfig.GetConfig().GetConfigMap()["testnamespace/removed"] = &protobufs.AgentConfigFile{Body: []byte("removed")}
+				signRemoteConfig(t, remoteConfig, key, 1)
+				delete(remoteConfig.GetConfig().GetConfigMap(), "testnamespace/removed")
+			},
+			wantErr: []string{`remote config entry "testnamespace/removed" listed in the manifest is missing`},
+		},
+		{
+			name: "expired manifest",
+			prepare: func(remoteConfig *protobufs.AgentRemoteConfig, key ed25519.PrivateKey) {
+				expires := time.Now().Add(-time.Minute)
+				manifest := &signature.Manifest{Version: 1, Expires: &expires, Entries: map[string]string{}}
+				for name, file := range remoteConfig.GetConfig().GetConfigMap() {
+					manifest.Entries[name] = signature.Digest(file.GetBody())
+				}
+				signManifest(t, remoteConfig, key, manifest)
+			},
+			wantErr: []string{"remote config manifest expired at"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			agent, _, key := getSigningAgent(t)
+			auditLog := newTestAuditLog(t)
+			agent.SetAuditLog(auditLog)
+			data, err := getMessageDataFromConfigFile(map[string]string{
+				testCollectorKey:  collectorBasicFile,
+				otherCollectorKey: collectorBasicFile,
+			})
+			require.NoError(t, err)
+			tt.prepare(data.RemoteConfig, key)
+
+			status, err := agent.applyRemoteConfig(data.RemoteConfig)
+
+			require.Error(t, err)
+			assert.Equal(t, protobufs.RemoteConfigStatuses_RemoteConfigStatuses_FAILED, status.Status)
+			assert.Equal(t, data.RemoteConfig.GetConfigHash(), status.LastRemoteConfigHash)
+			assert.Contains(t, status.ErrorMessage, "remote config rejected")
+			for _, wantErr := range tt.wantErr {
+				assert.Contains(t, status.ErrorMessage, wantErr)
+			}
+			assert.Empty(t, agent.appliedKeys, "no entry is applied when one of them isn't verified")
+			assert.Nil(t, agent.lastRemoteConfig)
+			assert.Zero(t, agent.manifestVersion)
+			records := auditLog.Records()
+			require.Len(t, records, 2)
+			assert.Equal(t, audit.OutcomeFailure, records[0].Outcome)
+		})
+	}
+}
+
+func TestAgent_applyRemoteConfig_RejectsWithoutValidTrustRoots(t *testing.T) {
+	conf := config.NewConfig(logr.Discard())
+	conf.SignatureVerification = &config.SignatureVerificationConfig{TrustRoots: []string{"not a key"}}
+	agent := NewAgent(logr.Discard(), &recordingConfigApplier{}, conf, &mockOpampClient{}, newMockProxy(nil, nil, nil))
+	data, err := getMessageDataFromConfigFile(map[string]string{testCollectorKey: collectorBasicFile})
+	require.NoError(t, err)
+
+	status, err := agent.applyRemoteConfig(data.RemoteConfig)
+
+	require.Error(t, err)
+	assert.Equal(t, protobufs.RemoteConfigStatuses_RemoteConfigStatuses_FAILED, status.Status)
+	assert.Contains(t, status.ErrorMessage, "no valid trust root is configured")
+}
@@ -0,0 +1,107 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"github.com/open-telemetry/opamp-go/client/types"
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/internal/operator"
+)
+
+// telemetryForwarder returns the applier as a TelemetryForwarder, when telemetry forwarding is enabled and the applier
+// supports it.
+func (agent *Agent) telemetryForwarder() (operator.TelemetryForwarder, bool) {
+	if !agent.config.TelemetryForwardingEnabled() {
+		return nil, false
+	}
+	forwarder, ok := agent.applier.(operator.TelemetryForwarder)
+	return forwarder, ok
+}
+
+// restoreTelemetry restores the telemetry destinations forwarded to the managed collectors before the bridge started,
+// so that the collectors keep them until the OpAMP server offers them again.
+func (agent *Agent) restoreTelemetry() {
+	forwarder, ok := agent.telemetryForwarder()
+	if !ok {
+		return
+	}
+	agent.applyMu.Lock()
+	defer agent.applyMu.Unlock()
+
+	settings, err := forwarder.RestoreTelemetry()
+	if err != nil {
+		agent.logger.Error(err, "failed to restore the forwarded telemetry settings")
+		return
+	}
+	agent.forwardedTelemetry = settings
+}
+
+// forwardTelemetry updates the telemetry destinations of the managed collectors with the connection settings of msg,
+// and forwards them to the collectors when they changed. The other connections named after a forwarded signal are
+// forwarded, as well as the own telemetry connections of an agent representing a collector, which the other
+// connections take precedence over. The other connections are offered as a whole, so a signal missing from them is
+// withdrawn unless an own connection is forwarded for it, and so is a signal offered with an empty destination.
+func (agent *Agent) forwardTelemetry(msg *types.MessageData) {
+	forwarder, ok := agent.telemetryForwarder()
+	if !ok {
+		return
+	}
+	agent.applyMu.Lock()
+	defer agent.applyMu.Unlock()
+
+	settings := agent.forwardedTelemetry
+	changed := false
+	update := func(current **protobufs.TelemetryConnectionSettings, own *protobufs.TelemetryConnectionSettings, signal config.TelemetrySignal) {
+		if !agent.config.TelemetryForwarding.Forwards(signal) {
+			return
+		}
+		var received *protobufs.TelemetryConnectionSettings
+		offered := msg.OtherConnSettings != nil
+		if offered {
+			received = otherTelemetryConnection(msg.OtherConnSettings[string(signal)])
+		}
+		if received == nil && own != nil && agent.config.ForwardsOwnTelemetry() {
+			received, offered = own, true
+		}
+		if !offered {
+			return
+		}
+		if received.GetDestinationEndpoint() == "" {
+			received = nil
+		}
+		if !proto.Equal(*current, received) {
+			*current = received
+			changed = true
+		}
+	}
+	update(&settings.Metrics, msg.OwnMetricsConnSettings, config.TelemetrySignalMetrics)
+	update(&settings.Traces, msg.OwnTracesConnSettings, config.TelemetrySignalTraces)
+	update(&settings.Logs, msg.OwnLogsConnSettings, config.TelemetrySignalLogs)
+	if !changed {
+		return
+	}
+
+	agent.forwardedTelemetry = settings
+	agent.logger.Info("Forwarding telemetry settings to the managed collectors")
+	if err := forwarder.ForwardTelemetry(settings); err != nil {
+		agent.logger.Error(err, "failed to forward telemetry settings")
+	}
+	// the collectors were changed through OpAMP
+	agent.rebaselineDrift()
+}
+
+// otherTelemetryConnection returns the telemetry connection settings of an other connection, if any.
+func otherTelemetryConnection(settings *protobufs.OtherConnectionSettings) *protobufs.TelemetryConnectionSettings {
+	if settings == nil {
+		return nil
+	}
+	return &protobufs.TelemetryConnectionSettings{
+		DestinationEndpoint: settings.GetDestinationEndpoint(),
+		Headers:             settings.GetHeaders(),
+		Certificate:         settings.GetCertificate(),
+	}
+}
@@ -0,0 +1,182 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/open-telemetry/opamp-go/client/types"
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/internal/operator"
+)
+
+// forwardingApplier is a recordingConfigApplier recording the telemetry settings forwarded to it, and restoring the
+// saved ones.
+type forwardingApplier struct {
+	recordingConfigApplier
+	forwarded []operator.TelemetrySettings
+	saved     operator.TelemetrySettings
+}
+
+func (f *forwardingApplier) ForwardTelemetry(settings operator.TelemetrySettings) error {
+	f.forwarded = append(f.forwarded, settings)
+	return nil
+}
+
+func (f *forwardingApplier) RestoreTelemetry() (operator.TelemetrySettings, error) {
+	return f.saved, nil
+}
+
+func getForwardingAgent(forwarding *config.TelemetryForwardingConfig, collectorAgent bool) (*Agent, *forwardingApplier) {
+	conf := config.NewConfig(logr.Discard())
+	conf.Capabilities = map[config.Capability]bool{
+		config.AcceptsOtherConnectionSettings: true,
+	}
+	conf.TelemetryForwarding = forwarding
+	if collectorAgent {
+		conf = config.NewCollectorAgentConfig(conf, testNamespace, testCollectorName)
+	}
+	applier := &forwardingApplier{}
+	return NewAgent(logr.Discard(), applier, conf, &mockOpampClient{}, newMockProxy(nil, nil, nil)), applier
+}
+
+func telemetryDestination(endpoint string) *protobufs.TelemetryConnectionSettings {
+	return &protobufs.TelemetryConnectionSettings{DestinationEndpoint: endpoint}
+}
+
+func TestAgent_ForwardsOtherConnections(t *testing.T) {
+	agent, applier := getForwardingAgent(&config.TelemetryForwardingConfig{}, false)
+
+	msg := &types.MessageData{
+		OwnLogsConnSettings: telemetryDestination("https://bridge.example.com/v1/logs"),
+		OtherConnSettings: map[string]*protobufs.OtherConnectionSettings{
+			"traces":   {DestinationEndpoint: "https://collectors.example.com/v1/traces"},
+			"database": {DestinationEndpoint: "postgres://db.example.com"},
+		},
+	}
+	agent.onMessage(context.Background(), msg)
+	require.Len(t, applier.forwarded, 1)
+	forwarded := applier.forwarded[0]
+	assert.Equal(t, "https://collectors.example.com/v1/traces", forwarded.Traces.GetDestinationEndpoint())
+	assert.Nil(t, forwarded.Logs, "the own telemetry of the aggregated agent is the bridge's")
+	assert.Nil(t, forwarded.Metrics)
+
+	// unchanged settings aren't forwarded again
+	agent.onMessage(context.Background(), msg)
+	assert.Len(t, applier.forwarded, 1)
+
+	// settings without other connections leave them unchanged
+	agent.onMessage(context.Background(), &types.MessageData{
+		OwnLogsConnSettings: telemetryDestination("https://other.example.com/v1/logs"),
+	})
+	assert.Len(t, applier.forwarded, 1)
+
+	// the other connections are offered as a whole, withdrawing the signals missing from them
+	agent.onMessage(context.Background(), &types.MessageData{
+		OtherConnSettings: map[string]*protobufs.OtherConnectionSettings{
+			"metrics": {DestinationEndpoint: "https://collectors.example.com/v1/metrics"},
+		},
+	})
+	require.Len(t, applier.forwarded, 2)
+	forwarded = applier.forwarded[1]
+	assert.Equal(t, "https://collectors.example.com/v1/metrics", forwarded.Metrics.GetDestinationEndpoint())
+	assert.Nil(t, forwarded.Traces)
+
+	agent.onMessage(context.Background(), &types.MessageData{
+		OtherConnSettings: map[string]*protobufs.OtherConnectionSettings{},
+	})
+	require.Len(t, applier.forwarded, 3)
+	assert.True(t, applier.forwarded[2].IsEmpty())
+}
+
+func TestAgent_WithdrawsOwnTelemetryOfCollectorAgent(t *testing.T) {
+	agent, applier := getForwardingAgent(&config.TelemetryForwardingConfig{}, true)
+
+	agent.onMessage(context.Background(), &types.MessageData{
+		OwnMetricsConnSettings: telemetryDestination("https://telemetry.example.com/v1/metrics"),
+		OwnTracesConnSettings:  telemetryDestination("https://telemetry.example.com/v1/traces"),
+	})
+	require.Len(t, applier.forwarded, 1)
+
+	// an own connection with an empty destination withdraws the signal, the others being unchanged
+	agent.onMessage(context.Background(), &types.MessageData{
+		OwnMetricsConnSettings: telemetryDestination(""),
+	})
+	require.Len(t, applier.forwarded, 2)
+	assert.Nil(t, applier.forwarded[1].Metrics)
+	assert.Equal(t, "https://telemetry.example.com/v1/traces", applier.forwarded[1].Traces.GetDestinationEndpoint())
+}
+
+func TestAgent_StartRestoresTelemetry(t *testing.T) {
+	agent, applier := getForwardingAgent(&config.TelemetryForwardingConfig{}, false)
+	applier.saved = operator.TelemetrySettings{Traces: telemetryDestination("https://collectors.example.com/v1/traces")}
+	require.NoError(t, agent.Start())
+	t.Cleanup(agent.Shutdown)
+
+	// the restored settings aren't forwarded again
+	offered := map[string]*protobufs.OtherConnectionSettings{
+		"traces": {DestinationEndpoint: "https://collectors.example.com/v1/traces"},
+	}
+	agent.onMessage(context.Background(), &types.MessageData{OtherConnSettings: offered})
+	assert.Empty(t, applier.forwarded)
+
+	// but are withdrawn when the server no longer offers them
+	agent.onMessage(context.Background(), &types.MessageData{
+		OtherConnSettings: map[string]*protobufs.OtherConnectionSettings{},
+	})
+	require.Len(t, applier.forwarded, 1)
+	assert.True(t, applier.forwarded[0].IsEmpty())
+}
+
+func TestAgent_ForwardsOwnTelemetryOfCollectorAgent(t *testing.T) {
+	agent, applier := getForwardingAgent(&config.TelemetryForwardingConfig{}, true)
+
+	agent.onMessage(context.Background(), &types.MessageData{
+		OwnMetricsConnSettings: telemetryDestination("https://telemetry.example.com/v1/metrics"),
+		OwnLogsConnSettings:    telemetryDestination("https://telemetry.example.com/v1/logs"),
+		OtherConnSettings: map[string]*protobufs.OtherConnectionSettings{
+			"logs": {DestinationEndpoint: "https://other.example.com/v1/logs"},
+		},
+	})
+	require.Len(t, applier.forwarded, 1)
+	forwarded := applier.forwarded[0]
+	assert.Equal(t, "https://telemetry.example.com/v1/metrics", forwarded.Metrics.GetDestinationEndpoint())
+	assert.Equal(t, "https://other.example.com/v1/logs", forwarded.Logs.GetDestinationEndpoint(), "the other connections take precedence")
+	assert.Nil(t, agent.metricReporter, "the own metrics of the collector aren't reported by the bridge")
+}
+
+func TestAgent_ForwardsConfiguredSignals(t *testing.T) {
+	agent, applier := getForwardingAgent(&config.TelemetryForwardingConfig{
+		Signals: []config.TelemetrySignal{config.TelemetrySignalLogs},
+	}, true)
+
+	agent.onMessage(context.Background(), &types.MessageData{
+		OwnTracesConnSettings: telemetryDestination("https://telemetry.example.com/v1/traces"),
+	})
+	assert.Empty(t, applier.forwarded)
+
+	agent.onMessage(context.Background(), &types.MessageData{
+		OwnLogsConnSettings: telemetryDestination("https://telemetry.example.com/v1/logs"),
+	})
+	require.Len(t, applier.forwarded, 1)
+	assert.Nil(t, applier.forwarded[0].Traces)
+	assert.Equal(t, "https://telemetry.example.com/v1/logs", applier.forwarded[0].Logs.GetDestinationEndpoint())
+}
+
+func TestAgent_TelemetryForwardingDisabled(t *testing.T) {
+	agent, applier := getForwardingAgent(nil, true)
+
+	agent.onMessage(context.Background(), &types.MessageData{
+		OtherConnSettings: map[string]*protobufs.OtherConnectionSettings{
+			"traces": {DestinationEndpoint: "https://collectors.example.com/v1/traces"},
+		},
+	})
+	assert.Empty(t, applier.forwarded)
+}
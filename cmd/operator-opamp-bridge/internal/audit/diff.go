@@ -0,0 +1,110 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package audit
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"sigs.k8s.io/yaml"
+)
+
+// redacted replaces the sensitive values of the configs recorded in the audit log.
+const redacted = "<redacted>"
+
+// sensitiveKey matches the keys of the config values holding credentials, such as the password of a basic auth
+// extension, the token of an exporter or an Authorization header. Keys of files holding credentials, such as
+// ca_file, are paths and not matched.
+var sensitiveKey = regexp.MustCompile(`(?i)(password|passwd|secret|token|api[_-]?key|credential|authorization|private[_-]?key|bearer)`)
+
+// Redact returns the YAML config of a resource with the values of its sensitive keys, and the values of the
+// environment variables with sensitive names, replaced, so that the audit log never holds credentials. A config that
+// isn't valid YAML is redacted entirely.
+func Redact(config string) string {
+	if config == "" {
+		return ""
+	}
+	var parsed any
+	if err := yaml.Unmarshal([]byte(config), &parsed); err != nil {
+		return redacted
+	}
+	out, err := yaml.Marshal(redactValue(parsed))
+	if err != nil {
+		return redacted
+	}
+	return string(out)
+}
+
+func redactValue(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		// an environment variable, or a header, given as a name and a value
+		if name, ok := v["name"].(string); ok && isSensitive(name) {
+			if _, ok = v["value"]; ok {
+				v["value"] = redacted
+			}
+		}
+		for key, item := range v {
+			if isSensitive(key) {
+				v[key] = redactScalars(item)
+			} else {
+				v[key] = redactValue(item)
+			}
+		}
+		return v
+	case []any:
+		for i, item := range v {
+			v[i] = redactValue(item)
+		}
+		return v
+	default:
+		return value
+	}
+}
+
+// redactScalars replaces the value of a sensitive key, or its items when it's a list. A map, such as the config of
+// the bearertokenauth extension or a secretKeyRef, is redacted like any other.
+func redactScalars(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		return redactValue(v)
+	case []any:
+		for i, item := range v {
+			v[i] = redactScalars(item)
+		}
+		return v
+	case nil:
+		return nil
+	default:
+		return redacted
+	}
+}
+
+func isSensitive(key string) bool {
+	lower := strings.ToLower(key)
+	if strings.HasSuffix(lower, "file") || strings.HasSuffix(lower, "_path") {
+		return false
+	}
+	return sensitiveKey.MatchString(key)
+}
+
+// Diff returns the unified diff of a resource spec before and after a change, both as YAML. It's empty when the spec
+// didn't change.
+func Diff(before, after string) string {
+	if before == after {
+		return ""
+	}
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(before),
+		B:        difflib.SplitLines(after),
+		FromFile: "previous",
+		ToFile:   "current",
+		Context:  3,
+	})
+	if err != nil {
+		return ""
+	}
+	return diff
+}
@@ -0,0 +1,194 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+)
+
+// Path is the path of the audit log endpoint on the health server.
+const Path = "/audit"
+
+const (
+	// DefaultMaxRecords is the number of records kept when no bound is configured.
+	DefaultMaxRecords = 100
+
+	// maxDiffSize bounds the diff kept in a record, so that a single record never fills a ConfigMap.
+	maxDiffSize = 8 * 1024
+)
+
+type Action string
+
+const (
+	ActionApply   Action = "apply"
+	ActionDelete  Action = "delete"
+	ActionRestart Action = "restart"
+	ActionResync  Action = "resync"
+	ActionFlush   Action = "flush"
+	ActionEnforce Action = "enforce"
+	ActionAdopt   Action = "adopt"
+)
+
+type Outcome string
+
+const (
+	OutcomeSuccess Outcome = "success"
+	OutcomeFailure Outcome = "failure"
+)
+
+// Record is a change made by the bridge to the resource identified by a remote config entry name.
+type Record struct {
+	Time   time.Time `json:"time"`
+	Action Action    `json:"action"`
+	// Resource is the remote config entry name of the resource, empty for a restart of every collector.
+	Resource string `json:"resource,omitempty"`
+	// ConfigHash is the hash of the remote config that made the change, hex encoded.
+	ConfigHash string `json:"configHash,omitempty"`
+	// Actor is who made the change: the OpAMP server that sent the remote config or the command, the bridge enforcing
+	// its drift policy, or the field manager that changed a collector adopted by the drift policy.
+	Actor string `json:"actor,omitempty"`
+	// Diff is the unified diff of the resource spec before and after the change, with its credentials redacted.
+	Diff    string  `json:"diff,omitempty"`
+	Outcome Outcome `json:"outcome"`
+	Error   string  `json:"error,omitempty"`
+}
+
+// Store persists the records of a Log.
+type Store interface {
+	// Load returns the stored records, oldest first.
+	Load(ctx context.Context) ([]Record, error)
+	// Save replaces the stored records.
+	Save(ctx context.Context, records []Record) error
+}
+
+// Emitter sends records to an external destination, such as an OTLP endpoint.
+type Emitter interface {
+	Emit(ctx context.Context, record Record)
+	Shutdown()
+}
+
+// Log is a bounded log of the changes made by the bridge. It keeps the latest records in memory, persists them to a
+// Store, and serves them read-only over HTTP.
+type Log struct {
+	logger     logr.Logger
+	store      Store
+	maxRecords int
+
+	mu      sync.Mutex
+	records []Record
+	emitter Emitter
+}
+
+// NewLog returns a Log keeping up to maxRecords records, loaded from the store.
+func NewLog(logger logr.Logger, store Store, maxRecords int) (*Log, error) {
+	if maxRecords <= 0 {
+		maxRecords = DefaultMaxRecords
+	}
+	records, err := store.Load(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	if len(records) > maxRecords {
+		records = records[len(records)-maxRecords:]
+	}
+	return &Log{
+		logger:     logger,
+		store:      store,
+		maxRecords: maxRecords,
+		records:    records,
+	}, nil
+}
+
+// Add records a change. The oldest records are dropped once the log is full. Failures to persist or emit the record
+// are logged, so that auditing never blocks a change.
+func (l *Log) Add(record Record) {
+	if record.Time.IsZero() {
+		record.Time = time.Now().UTC()
+	}
+	if len(record.Diff) > maxDiffSize {
+		record.Diff = record.Diff[:maxDiffSize] + "\n... (truncated)"
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.records = append(l.records, record)
+	if len(l.records) > l.maxRecords {
+		l.records = slices.Clone(l.records[len(l.records)-l.maxRecords:])
+	}
+	if err := l.store.Save(context.Background(), l.records); err != nil {
+		l.logger.Error(err, "failed to persist audit log")
+	}
+	if l.emitter != nil {
+		l.emitter.Emit(context.Background(), record)
+	}
+}
+
+// Records returns the records, oldest first.
+func (l *Log) Records() []Record {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return slices.Clone(l.records)
+}
+
+// SetEmitter sets the emitter the following records are sent to, shutting down the previous one.
+func (l *Log) SetEmitter(emitter Emitter) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.emitter != nil {
+		l.emitter.Shutdown()
+	}
+	l.emitter = emitter
+}
+
+// Shutdown shuts down the emitter of the log.
+func (l *Log) Shutdown() {
+	l.SetEmitter(nil)
+}
+
+// ServeHTTP serves the records as JSON, newest first. The resource query parameter only returns the records of a
+// resource, and limit bounds the number of records returned.
+func (l *Log) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	limit := 0
+	if value := r.URL.Query().Get("limit"); value != "" {
+		var err error
+		if limit, err = strconv.Atoi(value); err != nil || limit < 0 {
+			http.Error(w, "invalid limit", http.StatusBadRequest)
+			return
+		}
+	}
+	resource := r.URL.Query().Get("resource")
+
+	records := l.Records()
+	slices.Reverse(records)
+	result := []Record{}
+	for _, record := range records {
+		if resource != "" && record.Resource != resource {
+			continue
+		}
+		if limit > 0 && len(result) == limit {
+			break
+		}
+		result = append(result, record)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(struct {
+		Records []Record `json:"records"`
+	}{Records: result}); err != nil {
+		l.logger.Error(err, "failed to write audit log response")
+	}
+}
@@ -0,0 +1,225 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type memoryStore struct {
+	records []Record
+	saveErr error
+}
+
+func (s *memoryStore) Load(context.Context) ([]Record, error) {
+	return s.records, nil
+}
+
+func (s *memoryStore) Save(_ context.Context, records []Record) error {
+	s.records = records
+	return s.saveErr
+}
+
+type recordingEmitter struct {
+	emitted  []Record
+	shutdown bool
+}
+
+func (e *recordingEmitter) Emit(_ context.Context, record Record) {
+	e.emitted = append(e.emitted, record)
+}
+
+func (e *recordingEmitter) Shutdown() {
+	e.shutdown = true
+}
+
+func TestLog_Add(t *testing.T) {
+	store := &memoryStore{}
+	log, err := NewLog(logr.Discard(), store, 2)
+	require.NoError(t, err)
+	emitter := &recordingEmitter{}
+	log.SetEmitter(emitter)
+
+	log.Add(Record{Action: ActionApply, Resource: "default/first", Outcome: OutcomeSuccess})
+	log.Add(Record{Action: ActionApply, Resource: "default/second", Outcome: OutcomeSuccess})
+	log.Add(Record{Action: ActionDelete, Resource: "default/third", Outcome: OutcomeFailure, Error: "denied"})
+
+	records := log.Records()
+	require.Len(t, records, 2, "the oldest record should be dropped")
+	assert.Equal(t, "default/second", records[0].Resource)
+	assert.Equal(t, "default/third", records[1].Resource)
+	assert.False(t, records[1].Time.IsZero())
+	assert.Equal(t, records, store.records)
+	assert.Len(t, emitter.emitted, 3)
+
+	log.Shutdown()
+	assert.True(t, emitter.shutdown)
+}
+
+func TestLog_AddTruncatesDiff(t *testing.T) {
+	log, err := NewLog(logr.Discard(), &memoryStore{}, 0)
+	require.NoError(t, err)
+
+	log.Add(Record{Action: ActionApply, Diff: strings.Repeat("+", maxDiffSize+1)})
+
+	assert.True(t, strings.HasSuffix(log.Records()[0].Diff, "... (truncated)"))
+}
+
+func TestLog_AddIgnoresStoreErrors(t *testing.T) {
+	log, err := NewLog(logr.Discard(), &memoryStore{saveErr: errors.New("conflict")}, 0)
+	require.NoError(t, err)
+
+	log.Add(Record{Action: ActionRestart})
+
+	assert.Len(t, log.Records(), 1)
+}
+
+func TestNewLog_LoadsStoredRecords(t *testing.T) {
+	store := &memoryStore{records: []Record{
+		{Action: ActionApply, Resource: "default/first"},
+		{Action: ActionApply, Resource: "default/second"},
+		{Action: ActionApply, Resource: "default/third"},
+	}}
+
+	log, err := NewLog(logr.Discard(), store, 2)
+	require.NoError(t, err)
+
+	records := log.Records()
+	require.Len(t, records, 2)
+	assert.Equal(t, "default/second", records[0].Resource)
+}
+
+func TestLog_ServeHTTP(t *testing.T) {
+	log, err := NewLog(logr.Discard(), &memoryStore{}, 0)
+	require.NoError(t, err)
+	log.Add(Record{Action: ActionApply, Resource: "default/first", Outcome: OutcomeSuccess})
+	log.Add(Record{Action: ActionApply, Resource: "default/second", Outcome: OutcomeSuccess})
+	log.Add(Record{Action: ActionDelete, Resource: "default/first", Outcome: OutcomeSuccess})
+
+	tests := []struct {
+		name       string
+		method     string
+		query      string
+		wantStatus int
+		wantTypes  []Action
+	}{
+		{
+			name:       "newest first",
+			method:     http.MethodGet,
+			wantStatus: http.StatusOK,
+			wantTypes:  []Action{ActionDelete, ActionApply, ActionApply},
+		},
+		{
+			name:       "by resource",
+			method:     http.MethodGet,
+			query:      "?resource=default/first",
+			wantStatus: http.StatusOK,
+			wantTypes:  []Action{ActionDelete, ActionApply},
+		},
+		{
+			name:       "limit",
+			method:     http.MethodGet,
+			query:      "?limit=1",
+			wantStatus: http.StatusOK,
+			wantTypes:  []Action{ActionDelete},
+		},
+		{
+			name:       "no match",
+			method:     http.MethodGet,
+			query:      "?resource=default/other",
+			wantStatus: http.StatusOK,
+			wantTypes:  []Action{},
+		},
+		{
+			name:       "invalid limit",
+			method:     http.MethodGet,
+			query:      "?limit=-1",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "read only",
+			method:     http.MethodDelete,
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			log.ServeHTTP(recorder, httptest.NewRequest(tt.method, Path+tt.query, http.NoBody))
+
+			require.Equal(t, tt.wantStatus, recorder.Code)
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			var response struct {
+				Records []Record `json:"records"`
+			}
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+			actions := []Action{}
+			for _, record := range response.Records {
+				actions = append(actions, record.Action)
+			}
+			assert.Equal(t, tt.wantTypes, actions)
+		})
+	}
+}
+
+func TestDiff(t *testing.T) {
+	assert.Empty(t, Diff("replicas: 1\n", "replicas: 1\n"))
+
+	diff := Diff("mode: deployment\nreplicas: 1\n", "mode: deployment\nreplicas: 3\n")
+	assert.Contains(t, diff, "--- previous")
+	assert.Contains(t, diff, "+++ current")
+	assert.Contains(t, diff, "-replicas: 1")
+	assert.Contains(t, diff, "+replicas: 3")
+}
+
+func TestRedact(t *testing.T) {
+	config := `spec:
+  config:
+    exporters:
+      otlphttp:
+        endpoint: https://otlp.example.com
+        headers:
+          Authorization: Bearer abcd
+    extensions:
+      basicauth:
+        client_auth:
+          password: hunter2
+          username: collector
+      bearertokenauth:
+        token: abcd
+        filename: /var/run/token
+  env:
+  - name: API_TOKEN
+    value: abcd
+  - name: LOG_LEVEL
+    value: debug
+  - name: DD_API_KEY
+    valueFrom:
+      secretKeyRef:
+        key: key
+        name: datadog
+`
+	redactedConfig := Redact(config)
+	assert.NotContains(t, redactedConfig, "abcd")
+	assert.NotContains(t, redactedConfig, "hunter2")
+	for _, kept := range []string{"https://otlp.example.com", "username: collector", "filename: /var/run/token", "value: debug", "name: datadog"} {
+		assert.Contains(t, redactedConfig, kept)
+	}
+	assert.Equal(t, redactedConfig, Redact(redactedConfig), "redacting is idempotent")
+
+	assert.Empty(t, Redact(""))
+	assert.Equal(t, "<redacted>", Redact("password: [unterminated"))
+}
@@ -0,0 +1,102 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package audit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/google/uuid"
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp"
+	otellog "go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	otelresource "go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+)
+
+var _ Emitter = &LogEmitter{}
+
+// LogEmitter sends records as OTLP/HTTP logs to the own logs destination supplied by the server.
+type LogEmitter struct {
+	provider *sdklog.LoggerProvider
+	logger   otellog.Logger
+}
+
+// NewLogEmitter creates an OTLP/HTTP client to the destination address supplied by the server.
+func NewLogEmitter(dest *protobufs.TelemetryConnectionSettings, agentType, agentVersion string, instanceId uuid.UUID) (*LogEmitter, error) {
+	if dest.DestinationEndpoint == "" {
+		return nil, errors.New("log destination must specify DestinationEndpoint")
+	}
+
+	u, err := url.Parse(dest.DestinationEndpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid DestinationEndpoint: %w", err)
+	}
+
+	headers := map[string]string{}
+	for _, header := range dest.Headers.GetHeaders() {
+		headers[header.GetKey()] = header.GetValue()
+	}
+	exporter, err := otlploghttp.New(context.Background(),
+		otlploghttp.WithEndpoint(u.Host),
+		otlploghttp.WithURLPath(u.Path),
+		otlploghttp.WithHeaders(headers),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize otlp log http client: %w", err)
+	}
+
+	resource, err := otelresource.New(context.Background(),
+		otelresource.WithAttributes(
+			semconv.ServiceNameKey.String(agentType),
+			semconv.ServiceVersionKey.String(agentVersion),
+			semconv.ServiceInstanceIDKey.String(instanceId.String()),
+		),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	provider := sdklog.NewLoggerProvider(
+		sdklog.WithResource(resource),
+		sdklog.WithProcessor(sdklog.NewBatchProcessor(exporter)),
+	)
+	return &LogEmitter{
+		provider: provider,
+		logger:   provider.Logger("opamp.audit"),
+	}, nil
+}
+
+// Emit sends the record as a log whose body is the action, and whose attributes are the other fields of the record.
+func (e *LogEmitter) Emit(ctx context.Context, record Record) {
+	logRecord := otellog.Record{}
+	logRecord.SetEventName("opamp.audit")
+	logRecord.SetTimestamp(record.Time)
+	logRecord.SetBody(attribute.StringValue(string(record.Action)))
+	logRecord.SetSeverity(otellog.SeverityInfo)
+	if record.Outcome == OutcomeFailure {
+		logRecord.SetSeverity(otellog.SeverityError)
+	}
+	logRecord.AddAttributes(
+		attribute.String("opamp.audit.action", string(record.Action)),
+		attribute.String("opamp.audit.resource", record.Resource),
+		attribute.String("opamp.audit.config_hash", record.ConfigHash),
+		attribute.String("opamp.audit.outcome", string(record.Outcome)),
+	)
+	if record.Diff != "" {
+		logRecord.AddAttributes(attribute.String("opamp.audit.diff", record.Diff))
+	}
+	if record.Error != "" {
+		logRecord.AddAttributes(attribute.String("opamp.audit.error", record.Error))
+	}
+	e.logger.Emit(ctx, logRecord)
+}
+
+func (e *LogEmitter) Shutdown() {
+	_ = e.provider.Shutdown(context.Background())
+}
@@ -0,0 +1,151 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ConfigMapKey is the key of the records in the ConfigMap of a ConfigMapStore.
+const ConfigMapKey = "records.json"
+
+// maxConfigMapDataSize bounds the records stored in a ConfigMap, below the 1 MiB limit of Kubernetes objects, leaving
+// room for the metadata of the ConfigMap.
+const maxConfigMapDataSize = 1000 * 1024
+
+var (
+	_ Store = &FileStore{}
+	_ Store = &ConfigMapStore{}
+)
+
+// FileStore stores the records as a JSON array in a file.
+type FileStore struct {
+	path string
+}
+
+func NewFileStore(path string) *FileStore {
+	return &FileStore{path: path}
+}
+
+func (s *FileStore) Load(context.Context) ([]Record, error) {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read audit log %s: %w", s.path, err)
+	}
+	return unmarshalRecords(data)
+}
+
+// Save writes the records to a temporary file first, then renames it, so that the log is never left half written.
+func (s *FileStore) Save(_ context.Context, records []Record) error {
+	data, err := json.Marshal(records)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*")
+	if err != nil {
+		return fmt.Errorf("failed to write audit log %s: %w", s.path, err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err = tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write audit log %s: %w", s.path, err)
+	}
+	if err = tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write audit log %s: %w", s.path, err)
+	}
+	return os.Rename(tmp.Name(), s.path)
+}
+
+// ConfigMapStore stores the records as a JSON array in a ConfigMap, created if it doesn't exist.
+type ConfigMapStore struct {
+	k8sClient client.Client
+	key       client.ObjectKey
+}
+
+func NewConfigMapStore(k8sClient client.Client, namespace, name string) *ConfigMapStore {
+	return &ConfigMapStore{k8sClient: k8sClient, key: client.ObjectKey{Namespace: namespace, Name: name}}
+}
+
+func (s *ConfigMapStore) Load(ctx context.Context) ([]Record, error) {
+	configMap := &corev1.ConfigMap{}
+	err := s.k8sClient.Get(ctx, s.key, configMap)
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to get audit log ConfigMap %s: %w", s.key, err)
+	}
+	return unmarshalRecords([]byte(configMap.Data[ConfigMapKey]))
+}
+
+// Save drops the oldest records until the others fit in the ConfigMap.
+func (s *ConfigMapStore) Save(ctx context.Context, records []Record) error {
+	data, err := marshalBoundedRecords(records, maxConfigMapDataSize)
+	if err != nil {
+		return err
+	}
+	configMap := &corev1.ConfigMap{}
+	err = s.k8sClient.Get(ctx, s.key, configMap)
+	if apierrors.IsNotFound(err) {
+		configMap = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      s.key.Name,
+				Namespace: s.key.Namespace,
+				Labels:    map[string]string{"app.kubernetes.io/managed-by": "opentelemetry-opamp-bridge"},
+			},
+			Data: map[string]string{ConfigMapKey: string(data)},
+		}
+		return s.k8sClient.Create(ctx, configMap)
+	} else if err != nil {
+		return fmt.Errorf("failed to get audit log ConfigMap %s: %w", s.key, err)
+	}
+	if configMap.Data == nil {
+		configMap.Data = map[string]string{}
+	}
+	configMap.Data[ConfigMapKey] = string(data)
+	return s.k8sClient.Update(ctx, configMap)
+}
+
+// marshalBoundedRecords returns the JSON array of the newest records whose size doesn't exceed maxSize.
+func marshalBoundedRecords(records []Record, maxSize int) ([]byte, error) {
+	sizes := make([]int, len(records))
+	// the brackets of the array
+	size := 2
+	for i, record := range records {
+		data, err := json.Marshal(record)
+		if err != nil {
+			return nil, err
+		}
+		// the record and its separating comma
+		sizes[i] = len(data) + 1
+		size += sizes[i]
+	}
+	first := 0
+	for ; first < len(records) && size > maxSize; first++ {
+		size -= sizes[first]
+	}
+	return json.Marshal(records[first:])
+}
+
+func unmarshalRecords(data []byte) ([]Record, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	var records []Record
+	if err := json.Unmarshal(data, &records); err != nil {
+		return nil, fmt.Errorf("invalid audit log: %w", err)
+	}
+	return records, nil
+}
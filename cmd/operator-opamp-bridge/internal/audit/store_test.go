@@ -0,0 +1,80 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package audit
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var testRecords = []Record{
+	{Time: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), Action: ActionApply, Resource: "default/collector", ConfigHash: "abcd", Diff: "+replicas: 3\n", Outcome: OutcomeSuccess},
+	{Time: time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC), Action: ActionRestart, Outcome: OutcomeFailure, Error: "timed out"},
+}
+
+func TestFileStore(t *testing.T) {
+	store := NewFileStore(filepath.Join(t.TempDir(), "audit.json"))
+
+	records, err := store.Load(t.Context())
+	require.NoError(t, err)
+	assert.Empty(t, records, "a missing file is an empty log")
+
+	require.NoError(t, store.Save(t.Context(), testRecords))
+	records, err = store.Load(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, testRecords, records)
+}
+
+func TestConfigMapStore(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+	store := NewConfigMapStore(k8sClient, "opentelemetry", "bridge-audit")
+
+	records, err := store.Load(t.Context())
+	require.NoError(t, err)
+	assert.Empty(t, records, "a missing ConfigMap is an empty log")
+
+	require.NoError(t, store.Save(t.Context(), testRecords[:1]))
+	require.NoError(t, store.Save(t.Context(), testRecords))
+	records, err = store.Load(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, testRecords, records)
+
+	configMap := &corev1.ConfigMap{}
+	require.NoError(t, k8sClient.Get(t.Context(), client.ObjectKey{Namespace: "opentelemetry", Name: "bridge-audit"}, configMap))
+	assert.Contains(t, configMap.Data[ConfigMapKey], `"action":"restart"`)
+}
+
+func TestConfigMapStore_DropsOldestRecordsAboveSizeLimit(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+	store := NewConfigMapStore(k8sClient, "opentelemetry", "bridge-audit")
+
+	records := make([]Record, 200)
+	for i := range records {
+		records[i] = Record{Action: ActionApply, Resource: fmt.Sprintf("default/collector-%d", i), Diff: strings.Repeat("x", maxDiffSize), Outcome: OutcomeSuccess}
+	}
+	require.NoError(t, store.Save(t.Context(), records))
+
+	configMap := &corev1.ConfigMap{}
+	require.NoError(t, k8sClient.Get(t.Context(), client.ObjectKey{Namespace: "opentelemetry", Name: "bridge-audit"}, configMap))
+	assert.LessOrEqual(t, len(configMap.Data[ConfigMapKey]), maxConfigMapDataSize)
+	stored, err := store.Load(t.Context())
+	require.NoError(t, err)
+	require.NotEmpty(t, stored)
+	assert.Less(t, len(stored), len(records))
+	assert.Equal(t, records[len(records)-len(stored):], stored, "the newest records are kept")
+}
@@ -0,0 +1,93 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/open-telemetry/opamp-go/protobufs"
+)
+
+type AuditStorage string
+
+const (
+	AuditStorageConfigMap AuditStorage = "configmap"
+	AuditStorageFile      AuditStorage = "file"
+)
+
+// maxAuditRecords bounds the maxRecords of the audit log, which is kept in memory and rewritten on every change.
+const maxAuditRecords = 1000
+
+// AuditConfig configures the log of the changes the bridge makes to the resources it manages.
+type AuditConfig struct {
+	// Storage is where the records are persisted, configmap or file.
+	Storage AuditStorage `yaml:"storage"`
+	// ConfigMap is the name of the ConfigMap of the configmap storage. Defaults to `<bridge name>-audit`.
+	ConfigMap string `yaml:"configMap,omitempty"`
+	// Namespace is the namespace of the ConfigMap of the configmap storage. Defaults to the namespace of the bridge.
+	Namespace string `yaml:"namespace,omitempty"`
+	// Path is the file of the file storage.
+	Path string `yaml:"path,omitempty"`
+	// MaxRecords bounds the number of records kept, the oldest ones are dropped first. Defaults to 100, and can't exceed
+	// 1000. The configmap storage also drops the oldest records to stay below the size limit of a ConfigMap.
+	MaxRecords int `yaml:"maxRecords,omitempty"`
+	// EmitLogs sends each record as an OTLP log to the own logs destination supplied by the server.
+	EmitLogs bool `yaml:"emitLogs,omitempty"`
+}
+
+// GetConfigMap returns the name of the ConfigMap of the configmap storage.
+func (a *AuditConfig) GetConfigMap(bridgeName string) string {
+	if a.ConfigMap != "" {
+		return a.ConfigMap
+	}
+	if bridgeName == "" {
+		bridgeName = "opamp-bridge"
+	}
+	return bridgeName + "-audit"
+}
+
+// GetNamespace returns the namespace of the ConfigMap of the configmap storage.
+func (a *AuditConfig) GetNamespace() string {
+	if a.Namespace != "" {
+		return a.Namespace
+	}
+	return os.Getenv("OTELCOL_NAMESPACE")
+}
+
+// AuditEnabled returns whether the bridge records the changes it makes.
+func (c *Config) AuditEnabled() bool {
+	return c.Audit != nil
+}
+
+func (c *Config) validateAudit() error {
+	if c.Audit == nil {
+		return nil
+	}
+	switch c.Audit.Storage {
+	case AuditStorageConfigMap:
+		if c.Audit.GetNamespace() == "" {
+			return errors.New("audit namespace is required with the configmap storage")
+		}
+	case AuditStorageFile:
+		if strings.TrimSpace(c.Audit.Path) == "" {
+			return errors.New("audit path is required with the file storage")
+		}
+	default:
+		return fmt.Errorf("invalid audit storage %q: must be %q or %q", c.Audit.Storage, AuditStorageConfigMap, AuditStorageFile)
+	}
+	if c.Audit.MaxRecords < 0 {
+		return errors.New("audit maxRecords must not be negative")
+	}
+	if c.Audit.MaxRecords > maxAuditRecords {
+		return fmt.Errorf("audit maxRecords must not exceed %d", maxAuditRecords)
+	}
+	capabilities := c.GetCapabilities()
+	if c.Audit.EmitLogs && capabilities&protobufs.AgentCapabilities_AgentCapabilities_ReportsOwnLogs == 0 {
+		return fmt.Errorf("audit emitLogs requires the %s capability", ReportsOwnLogs)
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateAudit(t *testing.T) {
+	tests := []struct {
+		name         string
+		capabilities map[Capability]bool
+		audit        *AuditConfig
+		wantErr      string
+	}{
+		{
+			name: "audit disabled",
+		},
+		{
+			name:  "configmap storage",
+			audit: &AuditConfig{Storage: AuditStorageConfigMap, Namespace: "opentelemetry"},
+		},
+		{
+			name:    "configmap storage without namespace",
+			audit:   &AuditConfig{Storage: AuditStorageConfigMap},
+			wantErr: "audit namespace is required with the configmap storage",
+		},
+		{
+			name:  "file storage",
+			audit: &AuditConfig{Storage: AuditStorageFile, Path: "/var/lib/opamp-bridge/audit.json"},
+		},
+		{
+			name:    "file storage without path",
+			audit:   &AuditConfig{Storage: AuditStorageFile},
+			wantErr: "audit path is required with the file storage",
+		},
+		{
+			name:    "unknown storage",
+			audit:   &AuditConfig{Storage: "database"},
+			wantErr: `invalid audit storage "database"`,
+		},
+		{
+			name:    "negative max records",
+			audit:   &AuditConfig{Storage: AuditStorageFile, Path: "audit.json", MaxRecords: -1},
+			wantErr: "audit maxRecords must not be negative",
+		},
+		{
+			name:    "too many max records",
+			audit:   &AuditConfig{Storage: AuditStorageFile, Path: "audit.json", MaxRecords: 1001},
+			wantErr: "audit maxRecords must not exceed 1000",
+		},
+		{
+			name:         "emit logs",
+			capabilities: map[Capability]bool{ReportsOwnLogs: true},
+			audit:        &AuditConfig{Storage: AuditStorageFile, Path: "audit.json", EmitLogs: true},
+		},
+		{
+			name:    "emit logs without own logs",
+			audit:   &AuditConfig{Storage: AuditStorageFile, Path: "audit.json", EmitLogs: true},
+			wantErr: "audit emitLogs requires the ReportsOwnLogs capability",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := NewConfig(logr.Discard())
+			cfg.Capabilities = tt.capabilities
+			cfg.Audit = tt.audit
+			err := cfg.Validate()
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestAuditConfigDefaults(t *testing.T) {
+	t.Setenv("OTELCOL_NAMESPACE", "opentelemetry")
+	audit := &AuditConfig{Storage: AuditStorageConfigMap}
+	assert.Equal(t, "my-bridge-audit", audit.GetConfigMap("my-bridge"))
+	assert.Equal(t, "opamp-bridge-audit", audit.GetConfigMap(""))
+	assert.Equal(t, "opentelemetry", audit.GetNamespace())
+
+	audit = &AuditConfig{Storage: AuditStorageConfigMap, ConfigMap: "history", Namespace: "audit"}
+	assert.Equal(t, "history", audit.GetConfigMap("my-bridge"))
+	assert.Equal(t, "audit", audit.GetNamespace())
+}
@@ -11,6 +11,7 @@ import (
 	"net/url"
 	"os"
 	"runtime"
+	"slices"
 	"strings"
 	"time"
 
@@ -43,6 +44,9 @@ const (
 	standaloneMode     = "standalone"
 )
 
+// supportedResourceKinds are the kinds, besides OpenTelemetryCollector, the bridge can accept remote config for.
+var supportedResourceKinds = []string{"Instrumentation", "TargetAllocator"}
+
 var (
 	hostname, _   = os.Hostname()
 	schemeBuilder = k8sruntime.NewSchemeBuilder(registerKnownTypes)
@@ -50,6 +54,8 @@ var (
 
 func registerKnownTypes(s *k8sruntime.Scheme) error {
 	s.AddKnownTypes(v1alpha1.GroupVersion, &v1alpha1.OpenTelemetryCollector{}, &v1alpha1.OpenTelemetryCollectorList{})
+	s.AddKnownTypes(v1alpha1.GroupVersion, &v1alpha1.Instrumentation{}, &v1alpha1.InstrumentationList{})
+	s.AddKnownTypes(v1alpha1.GroupVersion, &v1alpha1.TargetAllocator{}, &v1alpha1.TargetAllocatorList{})
 	s.AddKnownTypes(v1beta1.GroupVersion, &v1beta1.OpenTelemetryCollector{}, &v1beta1.OpenTelemetryCollectorList{})
 	metav1.AddToGroupVersion(s, v1alpha1.GroupVersion)
 	metav1.AddToGroupVersion(s, v1beta1.GroupVersion)
@@ -88,6 +94,44 @@ type Config struct {
 	ClusterConfig      *rest.Config `yaml:"-"`
 	RootLogger         logr.Logger  `yaml:"-"`
 	instanceId         uuid.UUID    `yaml:"-"`
+	// collector is the remote config entry name of the collector an agent represents in multiplexing mode.
+	collector string `yaml:"-"`
+
+	// ResourcesAllowed lists the kinds besides OpenTelemetryCollector (Instrumentation, TargetAllocator) the bridge
+	// accepts remote config for, each with the spec fields the remote config may change. An empty list allows every
+	// field of the kind.
+	ResourcesAllowed map[string][]string `yaml:"resourcesAllowed,omitempty"`
+
+	// AtomicRemoteConfig applies each remote config all at once or not at all: every entry is validated with a
+	// dry-run before any is applied, and the applied ones are reverted if a later one fails.
+	AtomicRemoteConfig bool `yaml:"atomicRemoteConfig,omitempty"`
+
+	// Packages maps the names of the packages offered by the OpAMP server to collector images, when the bridge has
+	// the AcceptsPackages capability.
+	Packages map[string]PackageConfig `yaml:"packages,omitempty"`
+
+	// RestartTimeout bounds a staged restart of collector workloads, in response to a restart command. Defaults to
+	// 5 minutes.
+	RestartTimeout time.Duration `yaml:"restartTimeout,omitempty"`
+
+	// Audit records the changes the bridge makes to the resources it manages, when set.
+	Audit *AuditConfig `yaml:"audit,omitempty"`
+
+	// SignatureVerification, when set, only applies remote configs matching a manifest signed by one of its trust roots.
+	SignatureVerification *SignatureVerificationConfig `yaml:"signatureVerification,omitempty"`
+
+	// Multiplexing, when set, represents each managed collector as its own OpAMP agent.
+	Multiplexing *MultiplexingConfig `yaml:"multiplexing,omitempty"`
+
+	// DriftDetection, when set, watches the managed collectors for changes made outside of OpAMP.
+	DriftDetection *DriftDetectionConfig `yaml:"driftDetection,omitempty"`
+
+	// TelemetryForwarding, when set, forwards the telemetry connection settings received from the server to the
+	// managed collectors.
+	TelemetryForwarding *TelemetryForwardingConfig `yaml:"telemetryForwarding,omitempty"`
+
+	// LeaderElection, when set, elects the replica of the bridge that holds the OpAMP connection.
+	LeaderElection *LeaderElectionConfig `yaml:"leaderElection,omitempty"`
 
 	// ComponentsAllowed is a list of allowed OpenTelemetry components for each pipeline type (receiver, processor, etc.)
 	ComponentsAllowed map[string][]string            `yaml:"componentsAllowed,omitempty"`
@@ -189,6 +233,26 @@ func (c *Config) GetComponentsAllowed() map[string]map[string]bool {
 	return m
 }
 
+// GetResourcesAllowed returns the allowed spec fields of each kind in ResourcesAllowed.
+func (c *Config) GetResourcesAllowed() map[string]map[string]bool {
+	m := make(map[string]map[string]bool, len(c.ResourcesAllowed))
+	for kind, fields := range c.ResourcesAllowed {
+		m[kind] = make(map[string]bool, len(fields))
+		for _, field := range fields {
+			m[kind][field] = true
+		}
+	}
+	return m
+}
+
+// GetRestartTimeout returns the timeout of a staged restart of collector workloads.
+func (c *Config) GetRestartTimeout() time.Duration {
+	if c.RestartTimeout <= 0 {
+		return defaultRestartTimeout
+	}
+	return c.RestartTimeout
+}
+
 func (c *Config) GetCapabilities() protobufs.AgentCapabilities {
 	var capabilities int32
 	for capability, enabled := range c.Capabilities {
@@ -213,6 +277,9 @@ func (c *Config) GetAgentScheme() string {
 }
 
 func (c *Config) GetAgentType() string {
+	if c.collector != "" {
+		return collectorAgentType
+	}
 	if c.Name != opampBridgeName && c.Mode == standaloneMode {
 		return c.Name
 	}
@@ -227,6 +294,11 @@ func (c *Config) GetInstanceId() uuid.UUID {
 	return c.instanceId
 }
 
+// SetInstanceId sets the instance UID of the bridge, such as the one persisted by a previous leader.
+func (c *Config) SetInstanceId(instanceId uuid.UUID) {
+	c.instanceId = instanceId
+}
+
 func (c *Config) GetDescription() *protobufs.AgentDescription {
 	return &protobufs.AgentDescription{
 		IdentifyingAttributes: []*protobufs.KeyValue{
@@ -260,19 +332,23 @@ func NewStandaloneAgentConfig(base *Config, agent StandaloneAgentConfig) *Config
 	maps.Copy(capabilities, base.Capabilities)
 
 	return &Config{
-		KubeConfigFilePath: base.KubeConfigFilePath,
-		ListenAddr:         base.ListenAddr,
-		HealthListenAddr:   base.HealthListenAddr,
-		ClusterConfig:      base.ClusterConfig,
-		RootLogger:         base.RootLogger,
-		instanceId:         uuid.NewSHA1(uuid.NameSpaceURL, fmt.Appendf(nil, "%s/%s/%s/%s", agent.Namespace, agent.WorkloadRef.Kind, agent.WorkloadRef.Name, agent.Type)),
-		ComponentsAllowed:  cloneStringSliceMap(base.ComponentsAllowed),
-		Endpoint:           base.Endpoint,
-		Proxy:              cloneProxyConfig(base.Proxy),
-		Headers:            headers,
-		Capabilities:       capabilities,
-		HeartbeatInterval:  base.HeartbeatInterval,
-		Name:               agent.WorkloadRef.Name,
+		KubeConfigFilePath:    base.KubeConfigFilePath,
+		ListenAddr:            base.ListenAddr,
+		HealthListenAddr:      base.HealthListenAddr,
+		ClusterConfig:         base.ClusterConfig,
+		RootLogger:            base.RootLogger,
+		instanceId:            uuid.NewSHA1(uuid.NameSpaceURL, fmt.Appendf(nil, "%s/%s/%s/%s", agent.Namespace, agent.WorkloadRef.Kind, agent.WorkloadRef.Name, agent.Type)),
+		ComponentsAllowed:     cloneStringSliceMap(base.ComponentsAllowed),
+		ResourcesAllowed:      cloneStringSliceMap(base.ResourcesAllowed),
+		Endpoint:              base.Endpoint,
+		Proxy:                 cloneProxyConfig(base.Proxy),
+		Headers:               headers,
+		Capabilities:          capabilities,
+		HeartbeatInterval:     base.HeartbeatInterval,
+		RestartTimeout:        base.RestartTimeout,
+		Audit:                 base.Audit,
+		SignatureVerification: base.SignatureVerification,
+		Name:                  agent.WorkloadRef.Name,
 		AgentDescription: AgentDescription{
 			NonIdentifyingAttributes: nonIdentifyingAttributes,
 		},
@@ -315,6 +391,32 @@ func (c *Config) Validate() error {
 	if err := c.validateProxyConfig(); err != nil {
 		return err
 	}
+	if err := c.validatePackages(); err != nil {
+		return err
+	}
+	if err := c.validateAudit(); err != nil {
+		return err
+	}
+	if err := c.validateSignatureVerification(); err != nil {
+		return err
+	}
+	if err := c.validateMultiplexing(); err != nil {
+		return err
+	}
+	if err := c.validateDriftDetection(); err != nil {
+		return err
+	}
+	if err := c.validateTelemetryForwarding(); err != nil {
+		return err
+	}
+	if err := c.validateLeaderElection(); err != nil {
+		return err
+	}
+	for kind := range c.ResourcesAllowed {
+		if !slices.Contains(supportedResourceKinds, kind) {
+			return fmt.Errorf("resourcesAllowed has unsupported kind %q: must be one of %v", kind, supportedResourceKinds)
+		}
+	}
 	switch c.Mode {
 	case operatorMode, standaloneMode:
 	default:
@@ -323,6 +425,9 @@ func (c *Config) Validate() error {
 	if !c.IsStandaloneMode() {
 		return nil
 	}
+	if c.AtomicRemoteConfig {
+		return errors.New("atomicRemoteConfig is not supported in standalone mode")
+	}
 	if len(c.Standalone.Agents) == 0 {
 		return errors.New("standalone mode requires at least one configured agent")
 	}
@@ -671,6 +671,42 @@ func TestValidateProxyConfig(t *testing.T) {
 	})
 }
 
+func TestValidateResourcesAllowed(t *testing.T) {
+	t.Run("accepts instrumentations and target allocators", func(t *testing.T) {
+		cfg := NewConfig(logr.Discard())
+		cfg.ResourcesAllowed = map[string][]string{
+			"Instrumentation": {"sampler", "exporter"},
+			"TargetAllocator": {},
+		}
+
+		require.NoError(t, cfg.Validate())
+		assert.Equal(t, map[string]map[string]bool{
+			"Instrumentation": {"sampler": true, "exporter": true},
+			"TargetAllocator": {},
+		}, cfg.GetResourcesAllowed())
+	})
+
+	t.Run("rejects unsupported kinds", func(t *testing.T) {
+		cfg := NewConfig(logr.Discard())
+		cfg.ResourcesAllowed = map[string][]string{"OpAMPBridge": {}}
+
+		err := cfg.Validate()
+		require.Error(t, err)
+		assert.ErrorContains(t, err, `resourcesAllowed has unsupported kind "OpAMPBridge"`)
+	})
+}
+
+func TestValidateAtomicRemoteConfig(t *testing.T) {
+	cfg := NewConfig(logr.Discard())
+	cfg.AtomicRemoteConfig = true
+	require.NoError(t, cfg.Validate())
+
+	cfg.Mode = standaloneMode
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "atomicRemoteConfig is not supported in standalone mode")
+}
+
 func TestNewStandaloneAgentConfigUsesWorkloadRefNameAsHostName(t *testing.T) {
 	cfg := NewConfig(logr.Discard())
 	cfg.Mode = standaloneMode
@@ -761,3 +797,11 @@ func TestNewStandaloneAgentConfigMergesAgentDescription(t *testing.T) {
 		Value: &protobufs.AnyValue_StringValue{StringValue: "user-supplied-workload-name"},
 	}})
 }
+
+func TestGetRestartTimeout(t *testing.T) {
+	cfg := NewConfig(logr.Discard())
+	assert.Equal(t, defaultRestartTimeout, cfg.GetRestartTimeout(), "use default value")
+
+	cfg.RestartTimeout = time.Minute
+	assert.Equal(t, time.Minute, cfg.GetRestartTimeout())
+}
@@ -0,0 +1,59 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"errors"
+	"fmt"
+)
+
+type DriftPolicy string
+
+const (
+	// DriftPolicyReport only reports the drifted collectors.
+	DriftPolicyReport DriftPolicy = "report"
+	// DriftPolicyEnforce applies the entry of the last remote config to a drifted collector again.
+	DriftPolicyEnforce DriftPolicy = "enforce"
+	// DriftPolicyAdopt keeps the change made to a drifted collector, and reports it to the server as its effective
+	// config.
+	DriftPolicyAdopt DriftPolicy = "adopt"
+)
+
+// DriftDetectionConfig configures the detection of the changes made to the managed collectors outside of OpAMP.
+type DriftDetectionConfig struct {
+	// Policy is what the bridge does with a drifted collector: report, enforce or adopt. Defaults to report.
+	Policy DriftPolicy `yaml:"policy,omitempty"`
+}
+
+// GetPolicy returns what the bridge does with a drifted collector.
+func (d *DriftDetectionConfig) GetPolicy() DriftPolicy {
+	if d.Policy == "" {
+		return DriftPolicyReport
+	}
+	return d.Policy
+}
+
+// DriftDetectionEnabled returns whether the managed collectors are watched for changes made outside of OpAMP.
+func (c *Config) DriftDetectionEnabled() bool {
+	return c.DriftDetection != nil
+}
+
+func (c *Config) validateDriftDetection() error {
+	if !c.DriftDetectionEnabled() {
+		return nil
+	}
+	if c.IsStandaloneMode() {
+		return errors.New("driftDetection is not supported in standalone mode")
+	}
+	switch policy := c.DriftDetection.GetPolicy(); policy {
+	case DriftPolicyReport, DriftPolicyAdopt:
+	case DriftPolicyEnforce:
+		if !c.RemoteConfigEnabled() {
+			return fmt.Errorf("driftDetection policy %s requires the %s capability", policy, AcceptsRemoteConfig)
+		}
+	default:
+		return fmt.Errorf("invalid driftDetection policy %q, must be one of %s, %s or %s", policy, DriftPolicyReport, DriftPolicyEnforce, DriftPolicyAdopt)
+	}
+	return nil
+}
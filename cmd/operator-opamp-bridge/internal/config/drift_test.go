@@ -0,0 +1,76 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateDriftDetection(t *testing.T) {
+	tests := []struct {
+		name           string
+		mode           string
+		capabilities   map[Capability]bool
+		driftDetection *DriftDetectionConfig
+		wantErr        string
+	}{
+		{
+			name: "drift detection disabled",
+		},
+		{
+			name:           "default policy",
+			driftDetection: &DriftDetectionConfig{},
+		},
+		{
+			name:           "adopt",
+			driftDetection: &DriftDetectionConfig{Policy: DriftPolicyAdopt},
+		},
+		{
+			name:           "enforce",
+			capabilities:   map[Capability]bool{AcceptsRemoteConfig: true},
+			driftDetection: &DriftDetectionConfig{Policy: DriftPolicyEnforce},
+		},
+		{
+			name:           "enforce without remote config",
+			driftDetection: &DriftDetectionConfig{Policy: DriftPolicyEnforce},
+			wantErr:        "driftDetection policy enforce requires the AcceptsRemoteConfig capability",
+		},
+		{
+			name:           "invalid policy",
+			driftDetection: &DriftDetectionConfig{Policy: "ignore"},
+			wantErr:        `invalid driftDetection policy "ignore", must be one of report, enforce or adopt`,
+		},
+		{
+			name:           "standalone mode",
+			mode:           standaloneMode,
+			driftDetection: &DriftDetectionConfig{},
+			wantErr:        "driftDetection is not supported in standalone mode",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := NewConfig(logr.Discard())
+			cfg.Capabilities = tt.capabilities
+			cfg.DriftDetection = tt.driftDetection
+			if tt.mode != "" {
+				cfg.Mode = tt.mode
+				cfg.Standalone.Agents = []StandaloneAgentConfig{{}}
+			}
+			err := cfg.Validate()
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestDriftDetectionPolicy(t *testing.T) {
+	assert.Equal(t, DriftPolicyReport, (&DriftDetectionConfig{}).GetPolicy())
+	assert.Equal(t, DriftPolicyAdopt, (&DriftDetectionConfig{Policy: DriftPolicyAdopt}).GetPolicy())
+}
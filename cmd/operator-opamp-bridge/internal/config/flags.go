@@ -28,6 +28,7 @@ const (
 	nameFlagName              = "name"
 	modeFlagName              = "mode"
 	defaultHeartbeatInterval  = 30 * time.Second
+	defaultRestartTimeout     = 5 * time.Minute
 	defaultMode               = operatorMode
 )
 
@@ -0,0 +1,125 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"k8s.io/client-go/tools/leaderelection"
+)
+
+const (
+	defaultLeaseDuration = 15 * time.Second
+	defaultRenewDeadline = 10 * time.Second
+	defaultRetryPeriod   = 2 * time.Second
+)
+
+// LeaderElectionConfig configures the election of the replica of the bridge that holds the OpAMP connection, and the
+// Secret its instance UID is persisted in.
+type LeaderElectionConfig struct {
+	// LeaseName is the name of the Lease the replicas campaign for. Defaults to `<bridge name>-leader`.
+	LeaseName string `yaml:"leaseName,omitempty"`
+	// IdentitySecret is the name of the Secret the instance UID is persisted in. Defaults to `<bridge name>-identity`.
+	IdentitySecret string `yaml:"identitySecret,omitempty"`
+	// Namespace is the namespace of the Lease and the Secret. Defaults to the namespace of the bridge.
+	Namespace string `yaml:"namespace,omitempty"`
+	// LeaseDuration is how long standbys wait before taking over a lease that isn't renewed. Defaults to 15 seconds.
+	LeaseDuration time.Duration `yaml:"leaseDuration,omitempty"`
+	// RenewDeadline is how long the leader retries renewing the lease before giving up the leadership. Defaults to
+	// 10 seconds.
+	RenewDeadline time.Duration `yaml:"renewDeadline,omitempty"`
+	// RetryPeriod is how often the replicas try to acquire or renew the lease. Defaults to 2 seconds.
+	RetryPeriod time.Duration `yaml:"retryPeriod,omitempty"`
+}
+
+// GetLeaseName returns the name of the Lease the replicas campaign for.
+func (l *LeaderElectionConfig) GetLeaseName(bridgeName string) string {
+	if l.LeaseName != "" {
+		return l.LeaseName
+	}
+	if bridgeName == "" {
+		bridgeName = "opamp-bridge"
+	}
+	return bridgeName + "-leader"
+}
+
+// GetIdentitySecret returns the name of the Secret the instance UID is persisted in.
+func (l *LeaderElectionConfig) GetIdentitySecret(bridgeName string) string {
+	if l.IdentitySecret != "" {
+		return l.IdentitySecret
+	}
+	if bridgeName == "" {
+		bridgeName = "opamp-bridge"
+	}
+	return bridgeName + "-identity"
+}
+
+// GetNamespace returns the namespace of the Lease and the Secret.
+func (l *LeaderElectionConfig) GetNamespace() string {
+	if l.Namespace != "" {
+		return l.Namespace
+	}
+	return os.Getenv("OTELCOL_NAMESPACE")
+}
+
+// GetLeaseDuration returns how long standbys wait before taking over a lease that isn't renewed.
+func (l *LeaderElectionConfig) GetLeaseDuration() time.Duration {
+	if l.LeaseDuration <= 0 {
+		return defaultLeaseDuration
+	}
+	return l.LeaseDuration
+}
+
+// GetRenewDeadline returns how long the leader retries renewing the lease before giving up the leadership.
+func (l *LeaderElectionConfig) GetRenewDeadline() time.Duration {
+	if l.RenewDeadline <= 0 {
+		return defaultRenewDeadline
+	}
+	return l.RenewDeadline
+}
+
+// GetRetryPeriod returns how often the replicas try to acquire or renew the lease.
+func (l *LeaderElectionConfig) GetRetryPeriod() time.Duration {
+	if l.RetryPeriod <= 0 {
+		return defaultRetryPeriod
+	}
+	return l.RetryPeriod
+}
+
+// GetIdentitySecretKey returns the namespace and the name of the Secret the instance UID is persisted in. Without
+// leader election, the Secret only persists the versions of the verified remote config manifests.
+func (c *Config) GetIdentitySecretKey() (string, string) {
+	leaderElection := c.LeaderElection
+	if leaderElection == nil {
+		leaderElection = &LeaderElectionConfig{}
+	}
+	return leaderElection.GetNamespace(), leaderElection.GetIdentitySecret(c.Name)
+}
+
+// LeaderElectionEnabled returns whether the replicas of the bridge elect the one holding the OpAMP connection.
+func (c *Config) LeaderElectionEnabled() bool {
+	return c.LeaderElection != nil
+}
+
+func (c *Config) validateLeaderElection() error {
+	if !c.LeaderElectionEnabled() {
+		return nil
+	}
+	if c.LeaderElection.GetNamespace() == "" {
+		return errors.New("leaderElection namespace is required")
+	}
+	if c.LeaderElection.LeaseDuration < 0 || c.LeaderElection.RenewDeadline < 0 || c.LeaderElection.RetryPeriod < 0 {
+		return errors.New("leaderElection durations must not be negative")
+	}
+	if c.LeaderElection.GetRenewDeadline() >= c.LeaderElection.GetLeaseDuration() {
+		return errors.New("leaderElection renewDeadline must be shorter than leaseDuration")
+	}
+	if float64(c.LeaderElection.GetRenewDeadline()) <= leaderelection.JitterFactor*float64(c.LeaderElection.GetRetryPeriod()) {
+		return fmt.Errorf("leaderElection renewDeadline must be longer than %v times retryPeriod", leaderelection.JitterFactor)
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateLeaderElection(t *testing.T) {
+	tests := []struct {
+		name           string
+		leaderElection *LeaderElectionConfig
+		wantErr        string
+	}{
+		{
+			name: "leader election disabled",
+		},
+		{
+			name:           "defaults",
+			leaderElection: &LeaderElectionConfig{Namespace: "opentelemetry"},
+		},
+		{
+			name:           "no namespace",
+			leaderElection: &LeaderElectionConfig{},
+			wantErr:        "leaderElection namespace is required",
+		},
+		{
+			name:           "negative duration",
+			leaderElection: &LeaderElectionConfig{Namespace: "opentelemetry", RetryPeriod: -time.Second},
+			wantErr:        "leaderElection durations must not be negative",
+		},
+		{
+			name:           "renew deadline longer than lease duration",
+			leaderElection: &LeaderElectionConfig{Namespace: "opentelemetry", RenewDeadline: time.Minute},
+			wantErr:        "leaderElection renewDeadline must be shorter than leaseDuration",
+		},
+		{
+			name:           "retry period too long",
+			leaderElection: &LeaderElectionConfig{Namespace: "opentelemetry", RetryPeriod: 9 * time.Second},
+			wantErr:        "leaderElection renewDeadline must be longer than 1.2 times retryPeriod",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("OTELCOL_NAMESPACE", "")
+			cfg := NewConfig(logr.Discard())
+			cfg.LeaderElection = tt.leaderElection
+			err := cfg.Validate()
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestLeaderElectionDefaults(t *testing.T) {
+	t.Setenv("OTELCOL_NAMESPACE", "opentelemetry")
+	leaderElection := &LeaderElectionConfig{}
+	assert.Equal(t, "my-bridge-leader", leaderElection.GetLeaseName("my-bridge"))
+	assert.Equal(t, "opamp-bridge-leader", leaderElection.GetLeaseName(""))
+	assert.Equal(t, "my-bridge-identity", leaderElection.GetIdentitySecret("my-bridge"))
+	assert.Equal(t, "opentelemetry", leaderElection.GetNamespace())
+	assert.Equal(t, 15*time.Second, leaderElection.GetLeaseDuration())
+	assert.Equal(t, 10*time.Second, leaderElection.GetRenewDeadline())
+	assert.Equal(t, 2*time.Second, leaderElection.GetRetryPeriod())
+
+	leaderElection = &LeaderElectionConfig{LeaseName: "lease", IdentitySecret: "identity", Namespace: "bridge"}
+	assert.Equal(t, "lease", leaderElection.GetLeaseName("my-bridge"))
+	assert.Equal(t, "identity", leaderElection.GetIdentitySecret("my-bridge"))
+	assert.Equal(t, "bridge", leaderElection.GetNamespace())
+}
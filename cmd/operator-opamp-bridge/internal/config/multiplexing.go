@@ -0,0 +1,117 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// collectorAgentType is the agent type of the OpAMP agents representing a collector in multiplexing mode.
+	collectorAgentType = "io.opentelemetry.collector"
+
+	defaultMaxConnections = 100
+	defaultSyncInterval   = 30 * time.Second
+)
+
+// MultiplexingConfig configures the multiplexing mode, where the bridge opens one OpAMP connection per managed
+// collector instead of reporting them all as a single agent.
+type MultiplexingConfig struct {
+	// MaxConnections bounds the number of OpAMP connections. Collectors past the limit are connected once others are
+	// removed. Defaults to 100.
+	MaxConnections int `yaml:"maxConnections,omitempty"`
+	// SyncInterval is how often the managed collectors are listed, to connect the new ones and disconnect the
+	// removed ones. Defaults to 30 seconds.
+	SyncInterval time.Duration `yaml:"syncInterval,omitempty"`
+}
+
+// MultiplexingEnabled returns whether each managed collector is represented by its own OpAMP agent.
+func (c *Config) MultiplexingEnabled() bool {
+	return c.Multiplexing != nil
+}
+
+// GetMaxConnections returns the maximum number of OpAMP connections in multiplexing mode.
+func (c *Config) GetMaxConnections() int {
+	if c.Multiplexing.MaxConnections <= 0 {
+		return defaultMaxConnections
+	}
+	return c.Multiplexing.MaxConnections
+}
+
+// GetSyncInterval returns how often the managed collectors are listed in multiplexing mode.
+func (c *Config) GetSyncInterval() time.Duration {
+	if c.Multiplexing.SyncInterval <= 0 {
+		return defaultSyncInterval
+	}
+	return c.Multiplexing.SyncInterval
+}
+
+// NewCollectorAgentConfig returns the config of the OpAMP agent representing a managed collector in multiplexing
+// mode. Its instance UID is derived from the bridge name and the collector, so that it's stable across restarts.
+func NewCollectorAgentConfig(base *Config, namespace, name string) *Config {
+	nonIdentifyingAttributes := map[string]string{}
+	maps.Copy(nonIdentifyingAttributes, base.AgentDescription.NonIdentifyingAttributes)
+	nonIdentifyingAttributes["k8s.namespace.name"] = namespace
+	nonIdentifyingAttributes["opentelemetry.io/collector.name"] = name
+	nonIdentifyingAttributes["host.name"] = name
+
+	headers := Headers{}
+	maps.Copy(headers, base.Headers)
+
+	capabilities := map[Capability]bool{}
+	maps.Copy(capabilities, base.Capabilities)
+
+	return &Config{
+		KubeConfigFilePath:    base.KubeConfigFilePath,
+		ListenAddr:            base.ListenAddr,
+		HealthListenAddr:      base.HealthListenAddr,
+		ClusterConfig:         base.ClusterConfig,
+		RootLogger:            base.RootLogger,
+		instanceId:            uuid.NewSHA1(uuid.NameSpaceURL, fmt.Appendf(nil, "%s/%s/%s", base.Name, namespace, name)),
+		collector:             namespace + "/" + name,
+		ComponentsAllowed:     cloneStringSliceMap(base.ComponentsAllowed),
+		ResourcesAllowed:      cloneStringSliceMap(base.ResourcesAllowed),
+		AtomicRemoteConfig:    base.AtomicRemoteConfig,
+		Endpoint:              base.Endpoint,
+		TLS:                   base.TLS,
+		Proxy:                 cloneProxyConfig(base.Proxy),
+		Headers:               headers,
+		Capabilities:          capabilities,
+		HeartbeatInterval:     base.HeartbeatInterval,
+		RestartTimeout:        base.RestartTimeout,
+		Audit:                 base.Audit,
+		SignatureVerification: base.SignatureVerification,
+		DriftDetection:        base.DriftDetection,
+		TelemetryForwarding:   base.TelemetryForwarding,
+		Name:                  base.Name,
+		AgentDescription: AgentDescription{
+			NonIdentifyingAttributes: nonIdentifyingAttributes,
+		},
+		Mode: base.Mode,
+	}
+}
+
+func (c *Config) validateMultiplexing() error {
+	if !c.MultiplexingEnabled() {
+		return nil
+	}
+	if c.IsStandaloneMode() {
+		return errors.New("multiplexing is not supported in standalone mode")
+	}
+	if c.PackagesEnabled() {
+		return fmt.Errorf("multiplexing is not supported with the %s capability", AcceptsPackages)
+	}
+	if c.Multiplexing.MaxConnections < 0 {
+		return errors.New("multiplexing maxConnections must not be negative")
+	}
+	if c.Multiplexing.SyncInterval < 0 {
+		return errors.New("multiplexing syncInterval must not be negative")
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestValidateMultiplexing(t *testing.T) {
+	tests := []struct {
+		name         string
+		mode         string
+		capabilities map[Capability]bool
+		multiplexing *MultiplexingConfig
+		packages     map[string]PackageConfig
+		wantErr      string
+	}{
+		{
+			name: "multiplexing disabled",
+		},
+		{
+			name:         "valid",
+			multiplexing: &MultiplexingConfig{MaxConnections: 10, SyncInterval: time.Minute},
+		},
+		{
+			name:         "standalone mode",
+			mode:         standaloneMode,
+			multiplexing: &MultiplexingConfig{},
+			wantErr:      "multiplexing is not supported in standalone mode",
+		},
+		{
+			name:         "packages",
+			capabilities: map[Capability]bool{AcceptsPackages: true, ReportsPackageStatuses: true},
+			packages:     map[string]PackageConfig{"collector": {Image: testPackageImage}},
+			multiplexing: &MultiplexingConfig{},
+			wantErr:      "multiplexing is not supported with the AcceptsPackages capability",
+		},
+		{
+			name:         "negative max connections",
+			multiplexing: &MultiplexingConfig{MaxConnections: -1},
+			wantErr:      "multiplexing maxConnections must not be negative",
+		},
+		{
+			name:         "negative sync interval",
+			multiplexing: &MultiplexingConfig{SyncInterval: -time.Second},
+			wantErr:      "multiplexing syncInterval must not be negative",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := NewConfig(logr.Discard())
+			cfg.Capabilities = tt.capabilities
+			cfg.Packages = tt.packages
+			cfg.Multiplexing = tt.multiplexing
+			if tt.mode != "" {
+				cfg.Mode = tt.mode
+				cfg.Standalone.Agents = []StandaloneAgentConfig{{}}
+			}
+			err := cfg.Validate()
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestMultiplexingDefaults(t *testing.T) {
+	cfg := NewConfig(logr.Discard())
+	cfg.Multiplexing = &MultiplexingConfig{}
+	assert.Equal(t, 100, cfg.GetMaxConnections())
+	assert.Equal(t, 30*time.Second, cfg.GetSyncInterval())
+
+	cfg.Multiplexing = &MultiplexingConfig{MaxConnections: 5, SyncInterval: time.Minute}
+	assert.Equal(t, 5, cfg.GetMaxConnections())
+	assert.Equal(t, time.Minute, cfg.GetSyncInterval())
+
+	require.NoError(t, yaml.Unmarshal([]byte("multiplexing:\n  maxConnections: 20\n  syncInterval: 1m0s\n"), cfg))
+	assert.Equal(t, 20, cfg.GetMaxConnections())
+	assert.Equal(t, time.Minute, cfg.GetSyncInterval())
+}
+
+func TestNewCollectorAgentConfig(t *testing.T) {
+	cfg := NewConfig(logr.Discard())
+	cfg.Name = "bridge"
+	cfg.Headers = Headers{"authorization": "token"}
+	cfg.Capabilities = map[Capability]bool{AcceptsRemoteConfig: true}
+	cfg.AgentDescription.NonIdentifyingAttributes = map[string]string{"environment": "test"}
+
+	agentCfg := NewCollectorAgentConfig(cfg, "default", "collector")
+
+	assert.Equal(t, collectorAgentType, agentCfg.GetAgentType())
+	assert.Equal(t, agentType, cfg.GetAgentType())
+	assert.Equal(t, NewCollectorAgentConfig(cfg, "default", "collector").GetInstanceId(), agentCfg.GetInstanceId(), "the instance UID is stable")
+	assert.NotEqual(t, NewCollectorAgentConfig(cfg, "default", "other").GetInstanceId(), agentCfg.GetInstanceId())
+	assert.NotEqual(t, cfg.GetInstanceId(), agentCfg.GetInstanceId())
+
+	desc := agentCfg.GetDescription()
+	for key, value := range map[string]string{
+		"k8s.namespace.name":              "default",
+		"opentelemetry.io/collector.name": "collector",
+		"host.name":                       "collector",
+		"environment":                     "test",
+		bridgeAttributeKey:                operatorMode,
+	} {
+		assert.Contains(t, desc.NonIdentifyingAttributes, &protobufs.KeyValue{Key: key, Value: &protobufs.AnyValue{
+			Value: &protobufs.AnyValue_StringValue{StringValue: value},
+		}})
+	}
+
+	agentCfg.Headers["authorization"] = "changed"
+	agentCfg.Capabilities[AcceptsRemoteConfig] = false
+	assert.Equal(t, "token", cfg.Headers["authorization"])
+	assert.True(t, cfg.Capabilities[AcceptsRemoteConfig])
+}
@@ -0,0 +1,84 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/open-telemetry/opamp-go/protobufs"
+)
+
+// PackageConfig maps an OpAMP package to the image of the collectors it's rolled out to. The version offered by the
+// server is the image tag, the image digest, or both as `<tag>@<digest>`.
+type PackageConfig struct {
+	// Image is the image repository of the package, without tag or digest.
+	Image string `yaml:"image"`
+	// DigestsAllowed, when set, only accepts versions pinned to one of these image digests.
+	DigestsAllowed []string `yaml:"digestsAllowed,omitempty"`
+}
+
+// GetImage returns the image of the given version of the package.
+func (p PackageConfig) GetImage(version string) (string, error) {
+	tag, digest, pinned := strings.Cut(version, "@")
+	if !pinned && strings.Contains(version, ":") {
+		tag, digest = "", version
+	}
+	if tag == "" && digest == "" {
+		return "", errors.New("the package version is empty")
+	}
+	if len(p.DigestsAllowed) > 0 && !slices.Contains(p.DigestsAllowed, digest) {
+		return "", fmt.Errorf("the package version %q isn't pinned to an allowed digest", version)
+	}
+
+	image := p.Image
+	if tag != "" {
+		image += ":" + tag
+	}
+	if digest != "" {
+		image += "@" + digest
+	}
+	return image, nil
+}
+
+// PackagesEnabled returns whether the bridge accepts packages.
+func (c *Config) PackagesEnabled() bool {
+	capabilities := c.GetCapabilities()
+	return capabilities&protobufs.AgentCapabilities_AgentCapabilities_AcceptsPackages != 0
+}
+
+func (c *Config) validatePackages() error {
+	capabilities := c.GetCapabilities()
+	acceptsPackages := capabilities&protobufs.AgentCapabilities_AgentCapabilities_AcceptsPackages != 0
+	reportsPackageStatuses := capabilities&protobufs.AgentCapabilities_AgentCapabilities_ReportsPackageStatuses != 0
+	if acceptsPackages != reportsPackageStatuses {
+		return fmt.Errorf("the %s and %s capabilities must be enabled together", AcceptsPackages, ReportsPackageStatuses)
+	}
+	if !acceptsPackages {
+		return nil
+	}
+	if c.IsStandaloneMode() {
+		return fmt.Errorf("the %s capability is not supported in standalone mode", AcceptsPackages)
+	}
+	if len(c.Packages) == 0 {
+		return fmt.Errorf("the %s capability requires at least one package", AcceptsPackages)
+	}
+	for name, pkg := range c.Packages {
+		if strings.TrimSpace(pkg.Image) == "" {
+			return fmt.Errorf("package %q image is required", name)
+		}
+		// a registry port is the only colon allowed in the repository
+		if strings.Contains(pkg.Image, "@") || strings.Contains(pkg.Image[strings.LastIndex(pkg.Image, "/")+1:], ":") {
+			return fmt.Errorf("package %q image %q must not have a tag or digest", name, pkg.Image)
+		}
+		for _, digest := range pkg.DigestsAllowed {
+			if algorithm, hash, ok := strings.Cut(digest, ":"); !ok || algorithm == "" || hash == "" {
+				return fmt.Errorf("package %q has an invalid digest %q", name, digest)
+			}
+		}
+	}
+	return nil
+}
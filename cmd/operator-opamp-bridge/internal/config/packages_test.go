@@ -0,0 +1,141 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testPackageImage = "registry.example.com:5000/otel/collector"
+
+func TestPackageConfig_GetImage(t *testing.T) {
+	tests := []struct {
+		name           string
+		digestsAllowed []string
+		version        string
+		want           string
+		wantErr        string
+	}{
+		{
+			name:    "tag",
+			version: "0.120.0",
+			want:    testPackageImage + ":0.120.0",
+		},
+		{
+			name:    "digest",
+			version: "sha256:abc",
+			want:    testPackageImage + "@sha256:abc",
+		},
+		{
+			name:    "tag and digest",
+			version: "0.120.0@sha256:abc",
+			want:    testPackageImage + ":0.120.0@sha256:abc",
+		},
+		{
+			name:           "allowed digest",
+			digestsAllowed: []string{"sha256:abc"},
+			version:        "0.120.0@sha256:abc",
+			want:           testPackageImage + ":0.120.0@sha256:abc",
+		},
+		{
+			name:           "disallowed digest",
+			digestsAllowed: []string{"sha256:abc"},
+			version:        "sha256:def",
+			wantErr:        `the package version "sha256:def" isn't pinned to an allowed digest`,
+		},
+		{
+			name:           "tag without digest",
+			digestsAllowed: []string{"sha256:abc"},
+			version:        "0.120.0",
+			wantErr:        `the package version "0.120.0" isn't pinned to an allowed digest`,
+		},
+		{
+			name:    "empty",
+			version: "",
+			wantErr: "the package version is empty",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pkg := PackageConfig{Image: testPackageImage, DigestsAllowed: tt.digestsAllowed}
+			got, err := pkg.GetImage(tt.version)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestValidatePackages(t *testing.T) {
+	tests := []struct {
+		name         string
+		capabilities map[Capability]bool
+		mode         string
+		packages     map[string]PackageConfig
+		wantErr      string
+	}{
+		{
+			name:         "packages disabled",
+			capabilities: map[Capability]bool{},
+		},
+		{
+			name:         "valid",
+			capabilities: map[Capability]bool{AcceptsPackages: true, ReportsPackageStatuses: true},
+			packages:     map[string]PackageConfig{"collector": {Image: testPackageImage, DigestsAllowed: []string{"sha256:abc"}}},
+		},
+		{
+			name:         "status reporting disabled",
+			capabilities: map[Capability]bool{AcceptsPackages: true},
+			packages:     map[string]PackageConfig{"collector": {Image: testPackageImage}},
+			wantErr:      "the AcceptsPackages and ReportsPackageStatuses capabilities must be enabled together",
+		},
+		{
+			name:         "standalone mode",
+			capabilities: map[Capability]bool{AcceptsPackages: true, ReportsPackageStatuses: true},
+			mode:         standaloneMode,
+			packages:     map[string]PackageConfig{"collector": {Image: testPackageImage}},
+			wantErr:      "the AcceptsPackages capability is not supported in standalone mode",
+		},
+		{
+			name:         "no packages",
+			capabilities: map[Capability]bool{AcceptsPackages: true, ReportsPackageStatuses: true},
+			wantErr:      "the AcceptsPackages capability requires at least one package",
+		},
+		{
+			name:         "image with tag",
+			capabilities: map[Capability]bool{AcceptsPackages: true, ReportsPackageStatuses: true},
+			packages:     map[string]PackageConfig{"collector": {Image: testPackageImage + ":0.120.0"}},
+			wantErr:      `package "collector" image "` + testPackageImage + `:0.120.0" must not have a tag or digest`,
+		},
+		{
+			name:         "invalid digest",
+			capabilities: map[Capability]bool{AcceptsPackages: true, ReportsPackageStatuses: true},
+			packages:     map[string]PackageConfig{"collector": {Image: testPackageImage, DigestsAllowed: []string{"abc"}}},
+			wantErr:      `package "collector" has an invalid digest "abc"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := NewConfig(logr.Discard())
+			cfg.Capabilities = tt.capabilities
+			cfg.Packages = tt.packages
+			if tt.mode != "" {
+				cfg.Mode = tt.mode
+			}
+			err := cfg.Validate()
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,41 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/internal/signature"
+)
+
+// SignatureVerificationConfig configures the verification of the signatures of the remote config entries.
+type SignatureVerificationConfig struct {
+	// TrustRoots are the PEM encoded public keys or x509 certificates the signatures are verified with.
+	TrustRoots []string `yaml:"trustRoots"`
+}
+
+// SignatureVerificationEnabled returns whether remote configs must match a manifest signed by a trust root to be applied.
+func (c *Config) SignatureVerificationEnabled() bool {
+	return c.SignatureVerification != nil
+}
+
+// GetSignatureVerifier returns the verifier of the signatures of the remote config manifests.
+func (c *Config) GetSignatureVerifier() (*signature.Verifier, error) {
+	return signature.NewVerifier(c.SignatureVerification.TrustRoots)
+}
+
+func (c *Config) validateSignatureVerification() error {
+	if !c.SignatureVerificationEnabled() {
+		return nil
+	}
+	if _, err := c.GetSignatureVerifier(); err != nil {
+		return fmt.Errorf("invalid signatureVerification: %w", err)
+	}
+	// the version of the last verified manifest is persisted in the identity Secret
+	if namespace, _ := c.GetIdentitySecretKey(); namespace == "" {
+		return errors.New("invalid signatureVerification: the namespace of the identity Secret is required, set leaderElection.namespace or the OTELCOL_NAMESPACE environment variable")
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateSignatureVerification(t *testing.T) {
+	publicKey, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(publicKey)
+	require.NoError(t, err)
+	trustRoot := string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+
+	tests := []struct {
+		name         string
+		verification *SignatureVerificationConfig
+		namespace    string
+		wantErr      string
+	}{
+		{
+			name: "verification disabled",
+		},
+		{
+			name:         "valid trust root",
+			verification: &SignatureVerificationConfig{TrustRoots: []string{trustRoot}},
+			namespace:    "opentelemetry",
+		},
+		{
+			name:         "no identity Secret namespace",
+			verification: &SignatureVerificationConfig{TrustRoots: []string{trustRoot}},
+			wantErr:      "invalid signatureVerification: the namespace of the identity Secret is required, set leaderElection.namespace or the OTELCOL_NAMESPACE environment variable",
+		},
+		{
+			name:         "no trust roots",
+			verification: &SignatureVerificationConfig{},
+			wantErr:      "invalid signatureVerification: at least one trust root is required",
+		},
+		{
+			name:         "invalid trust root",
+			verification: &SignatureVerificationConfig{TrustRoots: []string{"not a key"}},
+			wantErr:      "invalid signatureVerification: trust root 0 has no PEM encoded public key or certificate",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("OTELCOL_NAMESPACE", tt.namespace)
+			cfg := NewConfig(logr.Discard())
+			cfg.SignatureVerification = tt.verification
+			err := cfg.Validate()
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.verification != nil, cfg.SignatureVerificationEnabled())
+		})
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/open-telemetry/opamp-go/protobufs"
+)
+
+type TelemetrySignal string
+
+const (
+	TelemetrySignalMetrics TelemetrySignal = "metrics"
+	TelemetrySignalTraces  TelemetrySignal = "traces"
+	TelemetrySignalLogs    TelemetrySignal = "logs"
+)
+
+var telemetrySignals = []TelemetrySignal{TelemetrySignalMetrics, TelemetrySignalTraces, TelemetrySignalLogs}
+
+// TelemetryForwardingConfig configures the forwarding of the telemetry connection settings received from the server to
+// the managed collectors, as the destinations of their internal telemetry.
+type TelemetryForwardingConfig struct {
+	// Signals lists the signals whose destinations are forwarded. Defaults to metrics, traces and logs.
+	Signals []TelemetrySignal `yaml:"signals,omitempty"`
+}
+
+// Forwards returns whether the destination of signal is forwarded.
+func (t *TelemetryForwardingConfig) Forwards(signal TelemetrySignal) bool {
+	return len(t.Signals) == 0 || slices.Contains(t.Signals, signal)
+}
+
+// TelemetryForwardingEnabled returns whether the telemetry connection settings received from the server are forwarded
+// to the managed collectors.
+func (c *Config) TelemetryForwardingEnabled() bool {
+	return c.TelemetryForwarding != nil
+}
+
+// ForwardsOwnTelemetry returns whether the own telemetry connection settings of the agent are the ones of a managed
+// collector, which is the case of the agents representing each collector in multiplexing mode.
+func (c *Config) ForwardsOwnTelemetry() bool {
+	return c.TelemetryForwardingEnabled() && c.collector != ""
+}
+
+func (c *Config) validateTelemetryForwarding() error {
+	if !c.TelemetryForwardingEnabled() {
+		return nil
+	}
+	if c.IsStandaloneMode() {
+		return errors.New("telemetryForwarding is not supported in standalone mode")
+	}
+	if c.GetCapabilities()&protobufs.AgentCapabilities_AgentCapabilities_AcceptsOtherConnectionSettings == 0 {
+		return fmt.Errorf("telemetryForwarding requires the %s capability", AcceptsOtherConnectionSettings)
+	}
+	for _, signal := range c.TelemetryForwarding.Signals {
+		if !slices.Contains(telemetrySignals, signal) {
+			return fmt.Errorf("invalid telemetryForwarding signal %q, must be one of %s, %s or %s", signal, TelemetrySignalMetrics, TelemetrySignalTraces, TelemetrySignalLogs)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateTelemetryForwarding(t *testing.T) {
+	acceptsOtherConnections := map[Capability]bool{AcceptsOtherConnectionSettings: true}
+	tests := []struct {
+		name                string
+		mode                string
+		capabilities        map[Capability]bool
+		telemetryForwarding *TelemetryForwardingConfig
+		wantErr             string
+	}{
+		{
+			name: "telemetry forwarding disabled",
+		},
+		{
+			name:                "all signals",
+			capabilities:        acceptsOtherConnections,
+			telemetryForwarding: &TelemetryForwardingConfig{},
+		},
+		{
+			name:                "some signals",
+			capabilities:        acceptsOtherConnections,
+			telemetryForwarding: &TelemetryForwardingConfig{Signals: []TelemetrySignal{TelemetrySignalMetrics, TelemetrySignalLogs}},
+		},
+		{
+			name:                "invalid signal",
+			capabilities:        acceptsOtherConnections,
+			telemetryForwarding: &TelemetryForwardingConfig{Signals: []TelemetrySignal{"profiles"}},
+			wantErr:             `invalid telemetryForwarding signal "profiles", must be one of metrics, traces or logs`,
+		},
+		{
+			name:                "without AcceptsOtherConnectionSettings",
+			telemetryForwarding: &TelemetryForwardingConfig{},
+			wantErr:             "telemetryForwarding requires the AcceptsOtherConnectionSettings capability",
+		},
+		{
+			name:                "standalone mode",
+			mode:                standaloneMode,
+			capabilities:        acceptsOtherConnections,
+			telemetryForwarding: &TelemetryForwardingConfig{},
+			wantErr:             "telemetryForwarding is not supported in standalone mode",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := NewConfig(logr.Discard())
+			cfg.Capabilities = tt.capabilities
+			cfg.TelemetryForwarding = tt.telemetryForwarding
+			if tt.mode != "" {
+				cfg.Mode = tt.mode
+				cfg.Standalone.Agents = []StandaloneAgentConfig{{}}
+			}
+			err := cfg.Validate()
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestTelemetryForwardingSignals(t *testing.T) {
+	all := &TelemetryForwardingConfig{}
+	assert.True(t, all.Forwards(TelemetrySignalTraces))
+
+	metricsOnly := &TelemetryForwardingConfig{Signals: []TelemetrySignal{TelemetrySignalMetrics}}
+	assert.True(t, metricsOnly.Forwards(TelemetrySignalMetrics))
+	assert.False(t, metricsOnly.Forwards(TelemetrySignalLogs))
+}
+
+func TestForwardsOwnTelemetry(t *testing.T) {
+	cfg := NewConfig(logr.Discard())
+	assert.False(t, NewCollectorAgentConfig(cfg, "default", "collector").ForwardsOwnTelemetry())
+
+	cfg.TelemetryForwarding = &TelemetryForwardingConfig{}
+	assert.False(t, cfg.ForwardsOwnTelemetry(), "the aggregated agent's own telemetry is the bridge's")
+	assert.True(t, NewCollectorAgentConfig(cfg, "default", "collector").ForwardsOwnTelemetry())
+}
@@ -18,6 +18,7 @@ const Path = "/healthz"
 
 type Server struct {
 	logger logr.Logger
+	mux    *http.ServeMux
 	server *http.Server
 	addr   string
 }
@@ -30,6 +31,7 @@ func NewServer(logger logr.Logger, listenAddr string) *Server {
 
 	return &Server{
 		logger: logger,
+		mux:    mux,
 		server: &http.Server{
 			Addr:              listenAddr,
 			Handler:           mux,
@@ -38,6 +40,11 @@ func NewServer(logger logr.Logger, listenAddr string) *Server {
 	}
 }
 
+// Handle registers a read-only endpoint served next to the health endpoint, such as the audit log.
+func (s *Server) Handle(pattern string, handler http.Handler) {
+	s.mux.Handle(pattern, handler)
+}
+
 func (s *Server) Start(ctx context.Context) error {
 	listener, err := (&net.ListenConfig{}).Listen(ctx, "tcp", s.server.Addr)
 	if err != nil {
@@ -0,0 +1,81 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package leader
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+
+	"github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/internal/config"
+)
+
+// Elector elects the replica of the bridge holding the OpAMP connection with a Lease. The Lease is released when the
+// leader shuts down, so that a standby takes over right away.
+type Elector struct {
+	log      logr.Logger
+	identity string
+	config   leaderelection.LeaderElectionConfig
+}
+
+// NewElector returns an elector campaigning for the Lease of the bridge as identity, which must be unique to the
+// replica.
+func NewElector(log logr.Logger, clientset kubernetes.Interface, cfg *config.Config, identity string) (*Elector, error) {
+	lock := &resourcelock.LeaseLock{
+		LeaseMeta: metav1.ObjectMeta{
+			Name:      cfg.LeaderElection.GetLeaseName(cfg.Name),
+			Namespace: cfg.LeaderElection.GetNamespace(),
+		},
+		Client:     clientset.CoordinationV1(),
+		LockConfig: resourcelock.ResourceLockConfig{Identity: identity},
+	}
+	elector := &Elector{
+		log:      log,
+		identity: identity,
+		config: leaderelection.LeaderElectionConfig{
+			Lock:            lock,
+			LeaseDuration:   cfg.LeaderElection.GetLeaseDuration(),
+			RenewDeadline:   cfg.LeaderElection.GetRenewDeadline(),
+			RetryPeriod:     cfg.LeaderElection.GetRetryPeriod(),
+			ReleaseOnCancel: true,
+			Name:            lock.LeaseMeta.Name,
+		},
+	}
+	// the callbacks are set on Run, the config is validated right away
+	if _, err := elector.newLeaderElector(func(context.Context) {}); err != nil {
+		return nil, err
+	}
+	return elector, nil
+}
+
+// Run campaigns for the Lease until ctx is done or the leadership is lost, calling onStartedLeading once elected.
+func (e *Elector) Run(ctx context.Context, onStartedLeading func(context.Context)) {
+	elector, err := e.newLeaderElector(onStartedLeading)
+	if err != nil {
+		e.log.Error(err, "failed to create leader elector")
+		return
+	}
+	e.log.Info("Campaigning for leadership", "lease", e.config.Name, "identity", e.identity)
+	elector.Run(ctx)
+}
+
+func (e *Elector) newLeaderElector(onStartedLeading func(context.Context)) (*leaderelection.LeaderElector, error) {
+	cfg := e.config
+	cfg.Callbacks = leaderelection.LeaderCallbacks{
+		OnStartedLeading: onStartedLeading,
+		OnStoppedLeading: func() {
+			e.log.Info("Stopped leading", "lease", e.config.Name)
+		},
+		OnNewLeader: func(identity string) {
+			if identity != e.identity {
+				e.log.Info("New leader elected, waiting as a standby", "leader", identity)
+			}
+		},
+	}
+	return leaderelection.NewLeaderElector(cfg)
+}
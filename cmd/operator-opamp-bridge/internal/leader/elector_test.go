@@ -0,0 +1,89 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package leader
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/internal/config"
+)
+
+func testLeaderElectionConfig() *config.Config {
+	cfg := config.NewConfig(logr.Discard())
+	cfg.Name = "bridge"
+	cfg.LeaderElection = &config.LeaderElectionConfig{
+		Namespace:     "opentelemetry",
+		LeaseDuration: time.Second,
+		RenewDeadline: 500 * time.Millisecond,
+		RetryPeriod:   100 * time.Millisecond,
+	}
+	return cfg
+}
+
+// runElector runs an elector until ctx is done. It returns a channel closed once the elector is elected, and another
+// closed once it stops.
+func runElector(ctx context.Context, t *testing.T, elector *Elector) (elected, stopped chan struct{}) {
+	elected, stopped = make(chan struct{}), make(chan struct{})
+	go func() {
+		defer close(stopped)
+		elector.Run(ctx, func(context.Context) { close(elected) })
+	}()
+	t.Cleanup(func() { <-stopped })
+	return elected, stopped
+}
+
+func TestElector_FailsOver(t *testing.T) {
+	clientset := fake.NewClientset()
+	cfg := testLeaderElectionConfig()
+	first, err := NewElector(logr.Discard(), clientset, cfg, "first")
+	require.NoError(t, err)
+	second, err := NewElector(logr.Discard(), clientset, cfg, "second")
+	require.NoError(t, err)
+
+	firstCtx, cancelFirst := context.WithCancel(t.Context())
+	defer cancelFirst()
+	firstElected, firstStopped := runElector(firstCtx, t, first)
+	select {
+	case <-firstElected:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the first replica wasn't elected")
+	}
+
+	secondCtx, cancelSecond := context.WithCancel(t.Context())
+	defer cancelSecond()
+	secondElected, _ := runElector(secondCtx, t, second)
+	select {
+	case <-secondElected:
+		t.Fatal("the second replica was elected while the first one leads")
+	case <-time.After(300 * time.Millisecond):
+	}
+
+	// the lease is released on shutdown, so the standby takes over before it expires
+	cancelFirst()
+	<-firstStopped
+	select {
+	case <-secondElected:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the second replica didn't take over")
+	}
+
+	lease, err := clientset.CoordinationV1().Leases("opentelemetry").Get(t.Context(), "bridge-leader", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "second", *lease.Spec.HolderIdentity)
+}
+
+func TestNewElector_InvalidConfig(t *testing.T) {
+	cfg := testLeaderElectionConfig()
+	cfg.LeaderElection.RenewDeadline = 2 * time.Second
+	_, err := NewElector(logr.Discard(), fake.NewClientset(), cfg, "first")
+	assert.Error(t, err)
+}
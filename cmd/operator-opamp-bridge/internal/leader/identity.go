@@ -0,0 +1,165 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package leader
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// InstanceUIDKey is the key of the instance UID in the Secret of a SecretIdentityStore.
+	InstanceUIDKey = "instanceUid"
+	// ManifestVersionKey is the key of the version of the last verified remote config manifest in the Secret of a
+	// SecretIdentityStore, suffixed with the agent name for the agents of standalone mode and multiplexing.
+	ManifestVersionKey = "manifestVersion"
+	// ManifestDigestKey is the key of the digest of the last verified remote config manifest in the Secret of a
+	// SecretIdentityStore, suffixed like ManifestVersionKey.
+	ManifestDigestKey = "manifestDigest"
+)
+
+// SecretIdentityStore persists the instance UID of the bridge in a Secret, so that every replica connects to the
+// OpAMP server as the same agent, and the identity survives failovers. The Secret also holds the last verified remote
+// config manifest of each agent, see ManifestVersionStore.
+type SecretIdentityStore struct {
+	k8sClient client.Client
+	key       client.ObjectKey
+}
+
+func NewSecretIdentityStore(k8sClient client.Client, namespace, name string) *SecretIdentityStore {
+	return &SecretIdentityStore{k8sClient: k8sClient, key: client.ObjectKey{Namespace: namespace, Name: name}}
+}
+
+// LoadOrSave returns the persisted instance UID. When there's none yet, instanceId is persisted and returned instead.
+func (s *SecretIdentityStore) LoadOrSave(ctx context.Context, instanceId uuid.UUID) (uuid.UUID, error) {
+	secret := &corev1.Secret{}
+	err := s.k8sClient.Get(ctx, s.key, secret)
+	if apierrors.IsNotFound(err) {
+		err = s.k8sClient.Create(ctx, s.newSecret(map[string][]byte{InstanceUIDKey: []byte(instanceId.String())}))
+		if apierrors.IsAlreadyExists(err) {
+			// another replica persisted its instance UID first
+			return s.LoadOrSave(ctx, instanceId)
+		} else if err != nil {
+			return uuid.Nil, fmt.Errorf("failed to create identity Secret %s: %w", s.key, err)
+		}
+		return instanceId, nil
+	} else if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to get identity Secret %s: %w", s.key, err)
+	}
+	if _, ok := secret.Data[InstanceUIDKey]; !ok {
+		// the Secret was created to persist a manifest version
+		if err = s.update(ctx, map[string][]byte{InstanceUIDKey: []byte(instanceId.String())}); err != nil {
+			return uuid.Nil, err
+		}
+		return instanceId, nil
+	}
+	persisted, err := uuid.ParseBytes(secret.Data[InstanceUIDKey])
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("invalid instance UID in identity Secret %s: %w", s.key, err)
+	}
+	return persisted, nil
+}
+
+// Save persists instanceId, such as a new instance UID assigned by the OpAMP server.
+func (s *SecretIdentityStore) Save(instanceId uuid.UUID) error {
+	return s.update(context.Background(), map[string][]byte{InstanceUIDKey: []byte(instanceId.String())})
+}
+
+// ManifestVersionStore returns the store of the last remote config manifest verified by the agent named agentName.
+// The agent of the bridge itself has an empty name.
+func (s *SecretIdentityStore) ManifestVersionStore(agentName string) *SecretManifestVersionStore {
+	suffix := ""
+	if agentName != "" {
+		// the keys of a Secret can't contain slashes
+		suffix = "." + strings.ReplaceAll(agentName, "/", ".")
+	}
+	return &SecretManifestVersionStore{
+		identity:   s,
+		versionKey: ManifestVersionKey + suffix,
+		digestKey:  ManifestDigestKey + suffix,
+	}
+}
+
+// update sets the keys of data in the Secret, creating it if it doesn't exist.
+func (s *SecretIdentityStore) update(ctx context.Context, data map[string][]byte) error {
+	secret := &corev1.Secret{}
+	err := s.k8sClient.Get(ctx, s.key, secret)
+	if apierrors.IsNotFound(err) {
+		err = s.k8sClient.Create(ctx, s.newSecret(data))
+		if apierrors.IsAlreadyExists(err) {
+			// another replica created it first
+			return s.update(ctx, data)
+		} else if err != nil {
+			return fmt.Errorf("failed to create identity Secret %s: %w", s.key, err)
+		}
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("failed to get identity Secret %s: %w", s.key, err)
+	}
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+	maps.Copy(secret.Data, data)
+	if err = s.k8sClient.Update(ctx, secret); err != nil {
+		return fmt.Errorf("failed to update identity Secret %s: %w", s.key, err)
+	}
+	return nil
+}
+
+func (s *SecretIdentityStore) newSecret(data map[string][]byte) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      s.key.Name,
+			Namespace: s.key.Namespace,
+			Labels:    map[string]string{"app.kubernetes.io/managed-by": "opentelemetry-opamp-bridge"},
+		},
+		Data: maps.Clone(data),
+	}
+}
+
+// SecretManifestVersionStore persists the version and the digest of the last remote config manifest verified by an
+// agent in the identity Secret, so that an older manifest can't be replayed after a restart or a failover.
+type SecretManifestVersionStore struct {
+	identity   *SecretIdentityStore
+	versionKey string
+	digestKey  string
+}
+
+// LoadManifestVersion returns the persisted version and digest of the last verified manifest, zero and empty when
+// none was verified yet.
+func (s *SecretManifestVersionStore) LoadManifestVersion(ctx context.Context) (uint64, string, error) {
+	secret := &corev1.Secret{}
+	err := s.identity.k8sClient.Get(ctx, s.identity.key, secret)
+	if apierrors.IsNotFound(err) {
+		return 0, "", nil
+	} else if err != nil {
+		return 0, "", fmt.Errorf("failed to get identity Secret %s: %w", s.identity.key, err)
+	}
+	rawVersion, ok := secret.Data[s.versionKey]
+	if !ok {
+		return 0, "", nil
+	}
+	version, err := strconv.ParseUint(string(rawVersion), 10, 64)
+	if err != nil {
+		return 0, "", fmt.Errorf("invalid manifest version in identity Secret %s: %w", s.identity.key, err)
+	}
+	return version, string(secret.Data[s.digestKey]), nil
+}
+
+// SaveManifestVersion persists the version and the digest of the last verified manifest.
+func (s *SecretManifestVersionStore) SaveManifestVersion(ctx context.Context, version uint64, digest string) error {
+	return s.identity.update(ctx, map[string][]byte{
+		s.versionKey: []byte(strconv.FormatUint(version, 10)),
+		s.digestKey:  []byte(digest),
+	})
+}
@@ -0,0 +1,92 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package leader
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestSecretIdentityStore(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+	store := NewSecretIdentityStore(k8sClient, "opentelemetry", "bridge-identity")
+
+	// the first replica persists its instance UID
+	first := uuid.New()
+	instanceId, err := store.LoadOrSave(t.Context(), first)
+	require.NoError(t, err)
+	assert.Equal(t, first, instanceId)
+
+	secret := &corev1.Secret{}
+	require.NoError(t, k8sClient.Get(t.Context(), client.ObjectKey{Namespace: "opentelemetry", Name: "bridge-identity"}, secret))
+	assert.Equal(t, first.String(), string(secret.Data[InstanceUIDKey]))
+
+	// the other replicas share it
+	instanceId, err = store.LoadOrSave(t.Context(), uuid.New())
+	require.NoError(t, err)
+	assert.Equal(t, first, instanceId)
+
+	// a new instance UID assigned by the server is persisted
+	assigned := uuid.New()
+	require.NoError(t, store.Save(assigned))
+	instanceId, err = store.LoadOrSave(t.Context(), uuid.New())
+	require.NoError(t, err)
+	assert.Equal(t, assigned, instanceId)
+
+	require.NoError(t, k8sClient.Get(t.Context(), client.ObjectKeyFromObject(secret), secret))
+	secret.Data[InstanceUIDKey] = []byte("invalid")
+	require.NoError(t, k8sClient.Update(t.Context(), secret))
+	_, err = store.LoadOrSave(t.Context(), uuid.New())
+	assert.ErrorContains(t, err, "invalid instance UID in identity Secret opentelemetry/bridge-identity")
+}
+
+func TestSecretManifestVersionStore(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+	identity := NewSecretIdentityStore(k8sClient, "opentelemetry", "bridge-identity")
+	bridge := identity.ManifestVersionStore("")
+	collector := identity.ManifestVersionStore("default/collector")
+
+	// nothing is persisted before the first manifest is verified
+	version, digest, err := bridge.LoadManifestVersion(t.Context())
+	require.NoError(t, err)
+	assert.Zero(t, version)
+	assert.Empty(t, digest)
+
+	require.NoError(t, bridge.SaveManifestVersion(t.Context(), 42, "3f7c"))
+	require.NoError(t, collector.SaveManifestVersion(t.Context(), 7, "e9a1"))
+
+	version, digest, err = bridge.LoadManifestVersion(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, uint64(42), version)
+	assert.Equal(t, "3f7c", digest)
+	version, digest, err = collector.LoadManifestVersion(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, uint64(7), version)
+	assert.Equal(t, "e9a1", digest)
+
+	secret := &corev1.Secret{}
+	require.NoError(t, k8sClient.Get(t.Context(), client.ObjectKey{Namespace: "opentelemetry", Name: "bridge-identity"}, secret))
+	assert.Equal(t, "42", string(secret.Data[ManifestVersionKey]))
+	assert.Equal(t, "7", string(secret.Data[ManifestVersionKey+".default.collector"]))
+
+	// the instance UID is added to the Secret created for the manifest versions
+	first := uuid.New()
+	instanceId, err := identity.LoadOrSave(t.Context(), first)
+	require.NoError(t, err)
+	assert.Equal(t, first, instanceId)
+	version, _, err = bridge.LoadManifestVersion(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, uint64(42), version)
+}
@@ -0,0 +1,51 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package leader
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// LabelKey labels the pod of the leader of the bridge replicas, which the Service of the bridge selects, so that the
+// OpAMP connections of the collectors reach the replica running the OpAMP proxy.
+const LabelKey = "opentelemetry.io/opamp-bridge-leader"
+
+// PodLabeler labels the pod of the replica with LabelKey while it leads.
+type PodLabeler struct {
+	k8sClient client.Client
+	key       client.ObjectKey
+}
+
+func NewPodLabeler(k8sClient client.Client, namespace, podName string) *PodLabeler {
+	return &PodLabeler{k8sClient: k8sClient, key: client.ObjectKey{Namespace: namespace, Name: podName}}
+}
+
+// SetLeader labels the pod of the replica as the leader, or removes the label.
+func (p *PodLabeler) SetLeader(ctx context.Context, leader bool) error {
+	pod := &corev1.Pod{}
+	if err := p.k8sClient.Get(ctx, p.key, pod); err != nil {
+		return fmt.Errorf("failed to get pod %s: %w", p.key, err)
+	}
+	_, labeled := pod.Labels[LabelKey]
+	if labeled == leader {
+		return nil
+	}
+	patch := client.MergeFrom(pod.DeepCopy())
+	if leader {
+		if pod.Labels == nil {
+			pod.Labels = map[string]string{}
+		}
+		pod.Labels[LabelKey] = "true"
+	} else {
+		delete(pod.Labels, LabelKey)
+	}
+	if err := p.k8sClient.Patch(ctx, pod, patch); err != nil {
+		return fmt.Errorf("failed to label pod %s: %w", p.key, err)
+	}
+	return nil
+}
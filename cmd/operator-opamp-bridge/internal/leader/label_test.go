@@ -0,0 +1,42 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package leader
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestPodLabeler(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+		Name:      "bridge-0",
+		Namespace: "opentelemetry",
+		Labels:    map[string]string{"app.kubernetes.io/component": "opentelemetry-opamp-bridge"},
+	}}
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(pod).Build()
+	labeler := NewPodLabeler(k8sClient, "opentelemetry", "bridge-0")
+
+	require.NoError(t, labeler.SetLeader(t.Context(), true))
+	require.NoError(t, k8sClient.Get(t.Context(), client.ObjectKeyFromObject(pod), pod))
+	assert.Equal(t, map[string]string{
+		"app.kubernetes.io/component": "opentelemetry-opamp-bridge",
+		LabelKey:                      "true",
+	}, pod.Labels)
+
+	require.NoError(t, labeler.SetLeader(t.Context(), false))
+	require.NoError(t, k8sClient.Get(t.Context(), client.ObjectKeyFromObject(pod), pod))
+	assert.Equal(t, map[string]string{"app.kubernetes.io/component": "opentelemetry-opamp-bridge"}, pod.Labels)
+
+	missing := NewPodLabeler(k8sClient, "opentelemetry", "missing")
+	assert.ErrorContains(t, missing.SetLeader(t.Context(), false), "failed to get pod opentelemetry/missing")
+}
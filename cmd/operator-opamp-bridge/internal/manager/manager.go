@@ -27,6 +27,25 @@ type KubernetesClient interface {
 	Start(context.Context) error
 }
 
+// AgentMultiplexer connects an OpAMP agent for each managed collector, in place of the runtimes' single agent.
+type AgentMultiplexer interface {
+	Start(context.Context) error
+	Shutdown()
+}
+
+// LeaderElector campaigns for the leadership of the bridge replicas, so that only one of them runs the OpAMP agents.
+type LeaderElector interface {
+	// Run blocks until ctx is done or the leadership is lost, calling onStartedLeading once the replica is elected.
+	// The context passed to onStartedLeading is canceled when the leadership is lost.
+	Run(ctx context.Context, onStartedLeading func(context.Context))
+}
+
+// LeaderLabeler labels the pod of the leader, so that its Service routes the OpAMP connections of the collectors to the
+// OpAMP proxy of the leader rather than to standbys.
+type LeaderLabeler interface {
+	SetLeader(ctx context.Context, leader bool) error
+}
+
 type Option func(*Manager)
 
 type Manager struct {
@@ -35,10 +54,20 @@ type Manager struct {
 	healthServer            *healthcheck.Server
 	opampProxy              *proxy.OpAMPProxy
 	kubernetesClient        KubernetesClient
+	agentMultiplexer        AgentMultiplexer
 	permissionReviewClient  PermissionReviewClient
 	listRequiredPermissions func() ([]Permission, error)
-	cancelKubernetesClient  context.CancelFunc
-	shutdownOnce            sync.Once
+	leaderElector           LeaderElector
+	leaderLabeler           LeaderLabeler
+	cancelElection          context.CancelFunc
+	leadershipLost          chan struct{}
+	// mu guards the components started by startLeading, which runs on the leader election goroutine.
+	mu                     sync.Mutex
+	leading                bool
+	stopped                bool
+	cancelKubernetesClient context.CancelFunc
+	multiplexerStarted     bool
+	shutdownOnce           sync.Once
 }
 
 func WithLogger(log logr.Logger) Option {
@@ -71,6 +100,24 @@ func WithKubernetesClient(kubernetesClient KubernetesClient) Option {
 	}
 }
 
+func WithAgentMultiplexer(agentMultiplexer AgentMultiplexer) Option {
+	return func(m *Manager) {
+		m.agentMultiplexer = agentMultiplexer
+	}
+}
+
+func WithLeaderElector(leaderElector LeaderElector) Option {
+	return func(m *Manager) {
+		m.leaderElector = leaderElector
+	}
+}
+
+func WithLeaderLabeler(leaderLabeler LeaderLabeler) Option {
+	return func(m *Manager) {
+		m.leaderLabeler = leaderLabeler
+	}
+}
+
 func WithPermissionReviewClient(permissionReviewClient PermissionReviewClient) Option {
 	return func(m *Manager) {
 		m.permissionReviewClient = permissionReviewClient
@@ -84,7 +131,9 @@ func WithRequiredPermissions(listRequiredPermissions func() ([]Permission, error
 }
 
 func New(options ...Option) (*Manager, error) {
-	manager := &Manager{}
+	manager := &Manager{
+		leadershipLost: make(chan struct{}),
+	}
 	for _, option := range options {
 		option(manager)
 	}
@@ -106,6 +155,8 @@ func (m *Manager) validate() error {
 	return nil
 }
 
+// Start checks the permissions of the bridge and starts it. With a leader elector, only the health server is started
+// right away, and the rest of the bridge once the replica is elected.
 func (m *Manager) Start(ctx context.Context) error {
 	if m.listRequiredPermissions != nil {
 		requiredPermissions, err := m.listRequiredPermissions()
@@ -116,9 +167,60 @@ func (m *Manager) Start(ctx context.Context) error {
 			return err
 		}
 	}
+	if m.leaderElector == nil {
+		if err := m.startLeading(ctx); err != nil {
+			m.Shutdown(ctx)
+			return err
+		}
+		return m.startHealthServer(ctx)
+	}
+
+	// the label is left over when the replica led before it restarted
+	if m.leaderLabeler != nil {
+		if err := m.leaderLabeler.SetLeader(ctx, false); err != nil {
+			return err
+		}
+	}
+	// standbys serve the health endpoint while they wait to be elected
+	if err := m.startHealthServer(ctx); err != nil {
+		return err
+	}
+	electionCtx, cancelElection := context.WithCancel(ctx)
+	m.cancelElection = cancelElection
+	go func() {
+		defer close(m.leadershipLost)
+		m.leaderElector.Run(electionCtx, func(leaderCtx context.Context) {
+			m.log.Info("Elected leader, starting the OpAMP bridge")
+			if err := m.startLeading(leaderCtx); err != nil {
+				m.log.Error(err, "failed to start the OpAMP bridge as leader")
+				// giving up the leadership stops the replica, which is restarted as a standby
+				cancelElection()
+			}
+		})
+	}()
+	return nil
+}
+
+// LeadershipLost is closed once the replica stops campaigning for the leadership, because it lost it or was shut
+// down. It's never closed without a leader elector.
+func (m *Manager) LeadershipLost() <-chan struct{} {
+	if m.leaderElector == nil {
+		return nil
+	}
+	return m.leadershipLost
+}
+
+// startLeading starts the OpAMP agents, the Kubernetes client, the agent multiplexer and the OpAMP proxy, then labels
+// the pod of the replica as the leader.
+func (m *Manager) startLeading(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.stopped {
+		return errors.New("the OpAMP bridge is shut down")
+	}
+	m.leading = true
 	for _, runtime := range m.runtimes {
 		if err := runtime.OpAMPAgent.Start(); err != nil {
-			m.Shutdown(ctx)
 			return err
 		}
 	}
@@ -126,40 +228,72 @@ func (m *Manager) Start(ctx context.Context) error {
 		kubernetesClientCtx, cancelKubernetesClient := context.WithCancel(ctx)
 		m.cancelKubernetesClient = cancelKubernetesClient
 		if err := m.kubernetesClient.Start(kubernetesClientCtx); err != nil {
-			cancelKubernetesClient()
-			m.cancelKubernetesClient = nil
-			m.Shutdown(ctx)
 			return err
 		}
 	}
-	if m.opampProxy != nil {
-		if err := m.opampProxy.Start(); err != nil {
-			m.Shutdown(ctx)
+	// the multiplexer lists the collectors through the kubernetes client, so it starts once the client has synced
+	if m.agentMultiplexer != nil {
+		if err := m.agentMultiplexer.Start(ctx); err != nil {
 			return err
 		}
+		m.multiplexerStarted = true
 	}
-	if m.healthServer != nil {
-		if err := m.healthServer.Start(ctx); err != nil {
-			m.Shutdown(ctx)
+	if m.opampProxy != nil {
+		if err := m.opampProxy.Start(); err != nil {
 			return err
 		}
 	}
+	if m.leaderLabeler != nil {
+		return m.leaderLabeler.SetLeader(ctx, true)
+	}
+	return nil
+}
+
+func (m *Manager) startHealthServer(ctx context.Context) error {
+	if m.healthServer == nil {
+		return nil
+	}
+	if err := m.healthServer.Start(ctx); err != nil {
+		m.Shutdown(ctx)
+		return err
+	}
 	return nil
 }
 
 func (m *Manager) Shutdown(ctx context.Context) {
 	m.shutdownOnce.Do(func() {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		m.stopped = true
+		if m.leading && m.leaderLabeler != nil {
+			if err := m.leaderLabeler.SetLeader(ctx, false); err != nil {
+				m.log.Error(err, "failed to remove the leader label")
+			}
+		}
 		if m.cancelKubernetesClient != nil {
 			m.cancelKubernetesClient()
 		}
-		for _, runtime := range m.runtimes {
-			runtime.OpAMPAgent.Shutdown()
+		if m.leading {
+			for _, runtime := range m.runtimes {
+				runtime.OpAMPAgent.Shutdown()
+			}
 		}
-		if m.opampProxy != nil {
+		if m.multiplexerStarted {
+			m.agentMultiplexer.Shutdown()
+		}
+		if m.leading && m.opampProxy != nil {
 			if err := m.opampProxy.Stop(ctx); err != nil {
 				m.log.Error(err, "failed to stop OpAMP proxy")
 			}
 		}
+		if m.cancelElection != nil {
+			m.cancelElection()
+			// the lease is released once the election stops, so that a standby takes over right away
+			select {
+			case <-m.leadershipLost:
+			case <-ctx.Done():
+			}
+		}
 		if m.healthServer != nil {
 			if err := m.healthServer.Stop(ctx); err != nil {
 				m.log.Error(err, "failed to stop health listener")
@@ -6,8 +6,10 @@ package manager
 import (
 	"context"
 	"errors"
+	"net/http"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/open-telemetry/opamp-go/client/types"
@@ -16,6 +18,7 @@ import (
 
 	opampagent "github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/internal/agent"
 	"github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/internal/healthcheck"
 	"github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/internal/operator"
 	"github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/internal/proxy"
 )
@@ -43,6 +46,75 @@ func TestManager_StartStartsRuntimesBeforeKubernetesClient(t *testing.T) {
 	require.True(t, kubernetesClient.started)
 }
 
+func TestManager_StartStartsAgentMultiplexerAfterKubernetesClient(t *testing.T) {
+	kubernetesClient := &startRecordingKubernetesClient{}
+	multiplexer := &recordingAgentMultiplexer{kubernetesClient: kubernetesClient}
+	manager, err := New(
+		WithLogger(logr.Discard()),
+		WithKubernetesClient(kubernetesClient),
+		WithAgentMultiplexer(multiplexer),
+	)
+	require.NoError(t, err)
+
+	require.NoError(t, manager.Start(context.Background()))
+	require.True(t, multiplexer.started)
+	manager.Shutdown(t.Context())
+	manager.Shutdown(t.Context())
+	require.Equal(t, 1, multiplexer.shutdowns)
+}
+
+func TestManager_StartStartsAgentsOnceElected(t *testing.T) {
+	cfg := config.NewConfig(logr.Discard())
+	cfg.Endpoint = "ws://example.test/v1/opamp"
+	opampClient := &recordingOpAMPClient{}
+	agent := opampagent.NewAgent(logr.Discard(), healthyApplier{}, cfg, opampClient, proxy.NoopServer{})
+	elector := &fakeLeaderElector{elect: make(chan struct{}), lose: make(chan struct{})}
+	healthServer := healthcheck.NewServer(logr.Discard(), "127.0.0.1:0")
+	labeler := &recordingLeaderLabeler{}
+	manager, err := New(
+		WithLogger(logr.Discard()),
+		WithRuntimes([]Runtime{{Name: "test", OpAMPAgent: agent, Client: opampClient}}),
+		WithHealthServer(healthServer),
+		WithLeaderElector(elector),
+		WithLeaderLabeler(labeler),
+	)
+	require.NoError(t, err)
+	require.NoError(t, manager.Start(context.Background()))
+	t.Cleanup(func() {
+		manager.Shutdown(context.Background())
+	})
+
+	// a standby serves the health endpoint, but doesn't connect to the OpAMP server
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, "http://"+healthServer.Addr()+healthcheck.Path, http.NoBody)
+	require.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.False(t, opampClient.Started())
+	require.Equal(t, []bool{false}, labeler.Labels(), "a label left over by a previous leader is removed")
+
+	close(elector.elect)
+	require.Eventually(t, opampClient.Started, time.Second, 10*time.Millisecond)
+	require.Eventually(t, func() bool { return len(labeler.Labels()) == 2 }, time.Second, 10*time.Millisecond)
+	require.Equal(t, []bool{false, true}, labeler.Labels(), "the leader is labeled once started")
+
+	close(elector.lose)
+	select {
+	case <-manager.LeadershipLost():
+	case <-time.After(time.Second):
+		t.Fatal("leadership loss wasn't reported")
+	}
+	manager.Shutdown(context.Background())
+	require.Equal(t, []bool{false, true, false}, labeler.Labels(), "the label is removed on shutdown")
+}
+
+func TestManager_LeadershipLostWithoutLeaderElector(t *testing.T) {
+	manager, err := New(WithLogger(logr.Discard()))
+	require.NoError(t, err)
+	require.Nil(t, manager.LeadershipLost())
+}
+
 func TestManager_NewRequiresPermissionReviewClientWithRequiredPermissions(t *testing.T) {
 	manager, err := New(
 		WithRequiredPermissions(func() ([]Permission, error) {
@@ -76,6 +148,72 @@ func (c *orderCheckingKubernetesClient) Start(context.Context) error {
 	return nil
 }
 
+type startRecordingKubernetesClient struct {
+	started bool
+}
+
+func (c *startRecordingKubernetesClient) Start(context.Context) error {
+	c.started = true
+	return nil
+}
+
+type recordingAgentMultiplexer struct {
+	kubernetesClient *startRecordingKubernetesClient
+	started          bool
+	shutdowns        int
+}
+
+func (m *recordingAgentMultiplexer) Start(context.Context) error {
+	if !m.kubernetesClient.started {
+		return errors.New("agent multiplexer started before kubernetes client")
+	}
+	m.started = true
+	return nil
+}
+
+func (m *recordingAgentMultiplexer) Shutdown() {
+	m.shutdowns++
+}
+
+// fakeLeaderElector elects the replica once elect is closed, and loses the leadership once lose is closed.
+type fakeLeaderElector struct {
+	elect chan struct{}
+	lose  chan struct{}
+}
+
+func (e *fakeLeaderElector) Run(ctx context.Context, onStartedLeading func(context.Context)) {
+	select {
+	case <-e.elect:
+	case <-ctx.Done():
+		return
+	}
+	leaderCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go onStartedLeading(leaderCtx)
+	select {
+	case <-e.lose:
+	case <-ctx.Done():
+	}
+}
+
+type recordingLeaderLabeler struct {
+	mu     sync.Mutex
+	labels []bool
+}
+
+func (l *recordingLeaderLabeler) SetLeader(_ context.Context, leader bool) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.labels = append(l.labels, leader)
+	return nil
+}
+
+func (l *recordingLeaderLabeler) Labels() []bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]bool(nil), l.labels...)
+}
+
 type healthyApplier struct{}
 
 func (healthyApplier) Apply(string, *protobufs.AgentConfigFile) error {
@@ -0,0 +1,187 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package multiplex
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	opampclient "github.com/open-telemetry/opamp-go/client"
+
+	opampagent "github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/internal/agent"
+	"github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/internal/audit"
+	"github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/internal/operator"
+	"github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/internal/proxy"
+)
+
+// CollectorApplier lists the managed collectors, and applies the remote config of each one on its own.
+type CollectorApplier interface {
+	ListCollectorKeys() ([]string, error)
+	ScopedApplier(key string) operator.ConfigApplier
+}
+
+// Multiplexer represents each collector managed by the bridge as its own OpAMP agent, with its own connection,
+// instance UID, description, health and remote config. The collectors are listed on an interval: agents are connected
+// for the new ones, up to a connection limit, and disconnected for the removed ones. Collectors past the limit, or
+// whose agent failed to start, are connected on a later sync.
+type Multiplexer struct {
+	logger    logr.Logger
+	config    *config.Config
+	applier   CollectorApplier
+	auditLog  *audit.Log
+	newClient func(cfg *config.Config) opampclient.OpAMPClient
+	// manifestStores returns the store of the last verified remote config manifest of the agent of a collector.
+	manifestStores func(key string) opampagent.ManifestVersionStore
+
+	mu     sync.Mutex
+	agents map[string]*opampagent.Agent
+	done   chan struct{}
+	wg     sync.WaitGroup
+}
+
+func NewMultiplexer(logger logr.Logger, cfg *config.Config, applier CollectorApplier, auditLog *audit.Log) *Multiplexer {
+	return &Multiplexer{
+		logger:    logger,
+		config:    cfg,
+		applier:   applier,
+		auditLog:  auditLog,
+		newClient: (*config.Config).CreateClient,
+		agents:    map[string]*opampagent.Agent{},
+		done:      make(chan struct{}),
+	}
+}
+
+// SetManifestVersionStores sets the function returning the store each agent persists its last verified remote config
+// manifest to. The manifests are only kept in memory when it's nil.
+func (m *Multiplexer) SetManifestVersionStores(stores func(key string) opampagent.ManifestVersionStore) {
+	m.manifestStores = stores
+}
+
+// Start connects the agents of the managed collectors, and keeps them in sync with the collectors until ctx is done
+// or the multiplexer is shut down.
+func (m *Multiplexer) Start(ctx context.Context) error {
+	if err := m.sync(); err != nil {
+		return err
+	}
+	m.wg.Add(1)
+	go func() {
+		defer m.wg.Done()
+		ticker := time.NewTicker(m.config.GetSyncInterval())
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if err := m.sync(); err != nil {
+					m.logger.Error(err, "failed to sync collector agents")
+				}
+			case <-ctx.Done():
+				return
+			case <-m.done:
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+// Shutdown stops syncing and disconnects every agent.
+func (m *Multiplexer) Shutdown() {
+	close(m.done)
+	m.wg.Wait()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for key, agent := range m.agents {
+		agent.Shutdown()
+		delete(m.agents, key)
+	}
+}
+
+// Connected returns the remote config entry names of the collectors whose agent is connected.
+func (m *Multiplexer) Connected() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	keys := make([]string, 0, len(m.agents))
+	for key := range m.agents {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// CheckDrift checks the collector identified by key and its spec hash for drift with its agent, if it's connected.
+func (m *Multiplexer) CheckDrift(key, hash string) {
+	m.mu.Lock()
+	agent, ok := m.agents[key]
+	m.mu.Unlock()
+	if ok {
+		agent.CheckDrift(key, hash)
+	}
+}
+
+// sync disconnects the agents of the removed collectors, then connects agents for the new ones within the connection
+// limit.
+func (m *Multiplexer) sync() error {
+	keys, err := m.applier.ListCollectorKeys()
+	if err != nil {
+		return fmt.Errorf("failed to list managed collectors: %w", err)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	present := map[string]bool{}
+	for _, key := range keys {
+		present[key] = true
+	}
+	for key, agent := range m.agents {
+		if !present[key] {
+			m.logger.Info("Disconnecting the agent of a removed collector", "collector", key)
+			agent.Shutdown()
+			delete(m.agents, key)
+		}
+	}
+
+	waiting := 0
+	for _, key := range keys {
+		if _, ok := m.agents[key]; ok {
+			continue
+		}
+		if len(m.agents) >= m.config.GetMaxConnections() {
+			waiting++
+			continue
+		}
+		agent, err := m.connect(key)
+		if err != nil {
+			m.logger.Error(err, "failed to connect the agent of a collector, retrying on the next sync", "collector", key)
+			continue
+		}
+		m.agents[key] = agent
+	}
+	if waiting > 0 {
+		m.logger.Info("OpAMP connection limit reached, collectors are waiting for a connection",
+			"maxConnections", m.config.GetMaxConnections(), "waiting", waiting)
+	}
+	return nil
+}
+
+func (m *Multiplexer) connect(key string) (*opampagent.Agent, error) {
+	namespace, name, ok := strings.Cut(key, "/")
+	if !ok {
+		return nil, fmt.Errorf("invalid collector key %q", key)
+	}
+	cfg := config.NewCollectorAgentConfig(m.config, namespace, name)
+	agent := opampagent.NewAgent(m.logger.WithName(key), m.applier.ScopedApplier(key), cfg, m.newClient(cfg), proxy.NoopServer{})
+	agent.SetAuditLog(m.auditLog)
+	if m.manifestStores != nil {
+		agent.SetManifestVersionStore(m.manifestStores(key))
+	}
+	if err := agent.Start(); err != nil {
+		return nil, err
+	}
+	m.logger.Info("Connected the agent of a collector", "collector", key, "instanceId", cfg.GetInstanceId().String())
+	return agent, nil
+}
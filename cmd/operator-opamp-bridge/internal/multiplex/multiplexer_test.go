@@ -0,0 +1,175 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package multiplex
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/google/uuid"
+	opampclient "github.com/open-telemetry/opamp-go/client"
+	"github.com/open-telemetry/opamp-go/client/types"
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/internal/operator"
+)
+
+// fakeClient implements the OpAMP client methods used to start and stop an agent.
+type fakeClient struct {
+	opampclient.OpAMPClient
+	instanceUid types.InstanceUid
+	description *protobufs.AgentDescription
+	stopped     bool
+	startErr    error
+}
+
+func (c *fakeClient) SetAgentDescription(description *protobufs.AgentDescription) error {
+	c.description = description
+	return nil
+}
+
+func (*fakeClient) SetHealth(*protobufs.ComponentHealth) error {
+	return nil
+}
+
+func (*fakeClient) SetCapabilities(*protobufs.AgentCapabilities) error {
+	return nil
+}
+
+func (*fakeClient) SetCustomCapabilities(*protobufs.CustomCapabilities) error {
+	return nil
+}
+
+func (c *fakeClient) Start(_ context.Context, settings types.StartSettings) error {
+	c.instanceUid = settings.InstanceUid
+	return c.startErr
+}
+
+func (c *fakeClient) Stop(context.Context) error {
+	c.stopped = true
+	return nil
+}
+
+type fakeApplier struct {
+	operator.ConfigApplier
+	key string
+}
+
+func (*fakeApplier) ListInstances() ([]operator.CollectorInstance, error) {
+	return nil, nil
+}
+
+func (a *fakeApplier) GetHealth() (operator.Health, error) {
+	return operator.Health{Healthy: true, Status: a.key}, nil
+}
+
+type fakeCollectorApplier struct {
+	keys    []string
+	listErr error
+}
+
+func (a *fakeCollectorApplier) ListCollectorKeys() ([]string, error) {
+	return a.keys, a.listErr
+}
+
+func (*fakeCollectorApplier) ScopedApplier(key string) operator.ConfigApplier {
+	return &fakeApplier{key: key}
+}
+
+func newTestMultiplexer(applier *fakeCollectorApplier, maxConnections int) (*Multiplexer, map[string]*fakeClient) {
+	cfg := config.NewConfig(logr.Discard())
+	cfg.Name = "bridge"
+	cfg.HeartbeatInterval = 0
+	cfg.Multiplexing = &config.MultiplexingConfig{MaxConnections: maxConnections}
+	multiplexer := NewMultiplexer(logr.Discard(), cfg, applier, nil)
+	var mu sync.Mutex
+	clients := map[string]*fakeClient{}
+	multiplexer.newClient = func(cfg *config.Config) opampclient.OpAMPClient {
+		mu.Lock()
+		defer mu.Unlock()
+		client := &fakeClient{}
+		clients[cfg.GetInstanceId().String()] = client
+		return client
+	}
+	return multiplexer, clients
+}
+
+func TestMultiplexer_ConnectsAgentPerCollector(t *testing.T) {
+	applier := &fakeCollectorApplier{keys: []string{"default/first", "default/second"}}
+	multiplexer, clients := newTestMultiplexer(applier, 0)
+
+	require.NoError(t, multiplexer.Start(t.Context()))
+	defer multiplexer.Shutdown()
+
+	assert.ElementsMatch(t, []string{"default/first", "default/second"}, multiplexer.Connected())
+	require.Len(t, clients, 2, "each collector has its own connection and instance UID")
+	for instanceId, client := range clients {
+		assert.Equal(t, instanceId, uuid.UUID(client.instanceUid).String())
+		assert.Contains(t, client.description.IdentifyingAttributes, &protobufs.KeyValue{Key: "service.name", Value: &protobufs.AnyValue{
+			Value: &protobufs.AnyValue_StringValue{StringValue: "io.opentelemetry.collector"},
+		}})
+	}
+}
+
+func TestMultiplexer_DisconnectsRemovedCollectors(t *testing.T) {
+	applier := &fakeCollectorApplier{keys: []string{"default/first", "default/second"}}
+	multiplexer, clients := newTestMultiplexer(applier, 0)
+	require.NoError(t, multiplexer.Start(t.Context()))
+	defer multiplexer.Shutdown()
+	removed := clients[config.NewCollectorAgentConfig(multiplexer.config, "default", "second").GetInstanceId().String()]
+	require.NotNil(t, removed)
+
+	applier.keys = []string{"default/first"}
+	require.NoError(t, multiplexer.sync())
+
+	assert.Equal(t, []string{"default/first"}, multiplexer.Connected())
+	assert.True(t, removed.stopped)
+
+	applier.keys = []string{"default/first", "default/second"}
+	require.NoError(t, multiplexer.sync())
+	assert.Len(t, multiplexer.Connected(), 2, "a collector added back is connected again")
+}
+
+func TestMultiplexer_ConnectionLimit(t *testing.T) {
+	applier := &fakeCollectorApplier{keys: []string{"default/first", "default/second", "default/third"}}
+	multiplexer, _ := newTestMultiplexer(applier, 2)
+	require.NoError(t, multiplexer.Start(t.Context()))
+	defer multiplexer.Shutdown()
+
+	assert.ElementsMatch(t, []string{"default/first", "default/second"}, multiplexer.Connected())
+
+	applier.keys = []string{"default/second", "default/third"}
+	require.NoError(t, multiplexer.sync())
+	assert.ElementsMatch(t, []string{"default/second", "default/third"}, multiplexer.Connected(), "a waiting collector is connected once a connection is freed")
+}
+
+func TestMultiplexer_RetriesFailedConnections(t *testing.T) {
+	applier := &fakeCollectorApplier{keys: []string{"default/first"}}
+	multiplexer, _ := newTestMultiplexer(applier, 0)
+	newClient := multiplexer.newClient
+	multiplexer.newClient = func(*config.Config) opampclient.OpAMPClient {
+		return &fakeClient{startErr: errors.New("connection refused")}
+	}
+	require.NoError(t, multiplexer.Start(t.Context()))
+	defer multiplexer.Shutdown()
+	assert.Empty(t, multiplexer.Connected())
+
+	multiplexer.newClient = newClient
+	require.NoError(t, multiplexer.sync())
+	assert.Equal(t, []string{"default/first"}, multiplexer.Connected())
+}
+
+func TestMultiplexer_StartFailsWhenCollectorsCantBeListed(t *testing.T) {
+	multiplexer, _ := newTestMultiplexer(&fakeCollectorApplier{listErr: errors.New("forbidden")}, 0)
+
+	err := multiplexer.Start(t.Context())
+
+	assert.ErrorContains(t, err, "failed to list managed collectors: forbidden")
+}
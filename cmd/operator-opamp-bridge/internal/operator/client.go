@@ -16,6 +16,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/yaml"
 
@@ -30,6 +31,9 @@ const (
 	ResourceIdentifierValue = "operator-opamp-bridge"
 	ReportingLabelKey       = "opentelemetry.io/opamp-reporting"
 	ManagedLabelKey         = "opentelemetry.io/opamp-managed"
+
+	// restartPollInterval is how often the rollout of a restarted workload is checked.
+	restartPollInterval = 2 * time.Second
 )
 
 type ConfigApplier interface {
@@ -47,30 +51,59 @@ type ConfigApplier interface {
 	// an OpAMP ServerToAgentCommand with type CommandType_Restart.
 	Restart(ctx context.Context) error
 
-	// ListInstances retrieves all collector instances managed by the bridge.
+	// ListInstances retrieves all collector instances, and other resources, managed by the bridge.
 	ListInstances() ([]CollectorInstance, error)
 
 	// GetHealth retrieves the health of resources managed by the bridge.
 	GetHealth() (Health, error)
 }
 
+// InstanceRestarter is a ConfigApplier that can restart the workload of a single collector, in response to an OpAMP
+// custom command scoped to it.
+type InstanceRestarter interface {
+	ConfigApplier
+
+	// RestartInstance triggers a rolling restart of the workload of the collector identified by an OpAMP remote
+	// config entry name, and waits for its rollout.
+	RestartInstance(ctx context.Context, key string) error
+}
+
+// QueueFlusher is a ConfigApplier that can flush the sending queues of the collectors, in response to an OpAMP custom
+// command.
+type QueueFlusher interface {
+	ConfigApplier
+
+	// FlushQueues terminates the pods of the collector identified by an OpAMP remote config entry name, or of every
+	// managed collector when key is empty, one at a time, so that each collector drains its sending queues on
+	// shutdown. It waits for the workload to be available again before terminating the next pod.
+	FlushQueues(ctx context.Context, key string) error
+}
+
 type Client struct {
 	log               logr.Logger
 	componentsAllowed map[string]map[string]bool
+	resourcesAllowed  map[string]map[string]bool
 	k8sClient         client.Client
 	close             chan bool
 	name              string
+	telemetry         *forwardedTelemetry
 }
 
-var _ ConfigApplier = &Client{}
+var (
+	_ ConfigApplier     = &Client{}
+	_ InstanceRestarter = &Client{}
+	_ QueueFlusher      = &Client{}
+)
 
-func NewClient(name string, log logr.Logger, c client.Client, componentsAllowed map[string]map[string]bool) *Client {
+func NewClient(name string, log logr.Logger, c client.Client, componentsAllowed, resourcesAllowed map[string]map[string]bool) *Client {
 	return &Client{
 		log:               log,
 		componentsAllowed: componentsAllowed,
+		resourcesAllowed:  resourcesAllowed,
 		k8sClient:         c,
 		close:             make(chan bool, 1),
 		name:              name,
+		telemetry:         newForwardedTelemetry(),
 	}
 }
 
@@ -85,6 +118,9 @@ func (c Client) Apply(key string, configmap *protobufs.AgentConfigFile) error {
 	if len(configmap.Body) == 0 {
 		return errors.NewBadRequest("invalid config to apply: config is empty")
 	}
+	if resource.kind != "" {
+		return c.applyResource(resource, configmap)
+	}
 
 	var collector v1beta1.OpenTelemetryCollector
 	err = yaml.Unmarshal(configmap.Body, &collector)
@@ -114,7 +150,14 @@ func (c Client) Apply(key string, configmap *protobufs.AgentConfigFile) error {
 	}
 
 	if instance == nil {
-		return c.create(ctx, name, namespace, updatedCollector)
+		if err := c.create(ctx, name, namespace, updatedCollector); err != nil {
+			return err
+		}
+		// the Secret of the telemetry destinations is owned by the collector, which has to exist first
+		return c.forwardTelemetry(key)
+	}
+	if err := c.injectTelemetry(ctx, instance, updatedCollector); err != nil {
+		return err
 	}
 	return c.update(ctx, instance, updatedCollector)
 }
@@ -156,20 +199,21 @@ func (c Client) validateLabels(collector *v1beta1.OpenTelemetryCollector) error
 	if collector == nil {
 		return nil
 	}
+	return c.validateResourceLabels("a collector", collector.GetLabels())
+}
 
-	resourceLabels := collector.GetLabels()
-
-	// If either the received collector resource has labels indicating it should only report and is not managed,
-	// disallow applying the new collector config
+func (c Client) validateResourceLabels(description string, resourceLabels map[string]string) error {
+	// If either the received resource has labels indicating it should only report and is not managed,
+	// disallow applying the new config
 	if labelSetContainsLabel(resourceLabels, ReportingLabelKey, "true") {
-		return errors.NewBadRequest(fmt.Sprintf("cannot modify a collector with `%s: true`", ReportingLabelKey))
+		return errors.NewBadRequest(fmt.Sprintf("cannot modify %s with `%s: true`", description, ReportingLabelKey))
 	}
 
-	// If either the collector doesn't have the managed label set to true, it should disallow applying the new collector
+	// If either the resource doesn't have the managed label set to true, it should disallow applying the new
 	// config
 	if !labelSetContainsLabel(resourceLabels, ManagedLabelKey, "true") &&
 		!labelSetContainsLabel(resourceLabels, ManagedLabelKey, c.name) {
-		return errors.NewBadRequest(fmt.Sprintf("cannot modify a collector that doesn't have `%s: true | <bridge-name>` set", ManagedLabelKey))
+		return errors.NewBadRequest(fmt.Sprintf("cannot modify %s that doesn't have `%s: true | <bridge-name>` set", description, ManagedLabelKey))
 	}
 
 	return nil
@@ -210,6 +254,9 @@ func (c Client) Delete(key string) error {
 	if err != nil {
 		return err
 	}
+	if resource.kind != "" {
+		return c.deleteResource(resource)
+	}
 	ctx := context.Background()
 	result := v1beta1.OpenTelemetryCollector{}
 	err = c.k8sClient.Get(ctx, client.ObjectKey{
@@ -225,9 +272,10 @@ func (c Client) Delete(key string) error {
 	return c.k8sClient.Delete(ctx, &result)
 }
 
-// Restart triggers a rolling restart of all managed collector workloads.
-// Sidecar mode collectors are skipped (they have no standalone workload).
-// All other collectors are restarted via rollout.TriggerRollout; errors are joined and returned.
+// Restart triggers a staged rolling restart of all managed collector workloads: each workload is restarted, and its
+// rollout awaited, before the next one. Sidecar mode collectors are skipped (they have no standalone workload).
+// Workloads that fail to restart are skipped and their errors joined and returned, while a rollout that doesn't
+// complete, or ctx being done, stops the restart of the remaining ones.
 func (c Client) Restart(ctx context.Context) error {
 	collectors, err := c.listOpenTelemetryCollectors()
 	if err != nil {
@@ -235,32 +283,163 @@ func (c Client) Restart(ctx context.Context) error {
 	}
 	var errs []error
 	for i := range collectors {
-		col := &collectors[i]
-		mode := strings.ToLower(string(col.Col.Spec.Mode))
-		if mode == "sidecar" {
-			c.log.Info("Skipping restart for sidecar mode collector - no standalone workload",
-				"name", col.GetName(), "namespace", col.GetNamespace())
-			continue
-		}
-		workloadName := naming.Collector(col.GetName())
-		if err := rollout.TriggerRollout(ctx, c.k8sClient, col.GetNamespace(), mode, workloadName); err != nil {
+		restarted, err := c.restartCollector(ctx, &collectors[i].Col)
+		if err != nil {
 			errs = append(errs, err)
-		} else {
-			c.log.Info("Triggered workload rollout restart", "mode", mode, "name", workloadName, "namespace", col.GetNamespace())
+			if restarted {
+				break
+			}
 		}
 	}
 	return stderrors.Join(errs...)
 }
 
-// ListInstances returns all collectors that are visible to OpAMP as effective config entries.
+// RestartInstance triggers a rolling restart of the workload of the managed collector identified by an OpAMP
+// remote config entry name, and waits for its rollout.
+func (c Client) RestartInstance(ctx context.Context, key string) error {
+	collector, err := c.workloadCollector(key, "restart")
+	if err != nil {
+		return err
+	}
+	_, err = c.restartCollector(ctx, collector)
+	return err
+}
+
+// workloadCollector returns the managed collector identified by an OpAMP remote config entry name, if it has a
+// workload the action can be run on.
+func (c Client) workloadCollector(key, action string) (*v1beta1.OpenTelemetryCollector, error) {
+	resource, err := kubeResourceFromKey(key)
+	if err != nil {
+		return nil, err
+	}
+	if resource.kind != "" {
+		return nil, fmt.Errorf("%s has no workload to %s", key, action)
+	}
+	collector, err := c.GetInstance(resource.name, resource.namespace)
+	if err != nil {
+		return nil, err
+	}
+	if collector == nil {
+		return nil, fmt.Errorf("collector %s not found", key)
+	}
+	if err = c.validateLabels(collector); err != nil {
+		return nil, err
+	}
+	if strings.EqualFold(string(collector.Spec.Mode), string(v1beta1.ModeSidecar)) {
+		return nil, fmt.Errorf("collector %s is in sidecar mode and has no workload to %s", key, action)
+	}
+	return collector, nil
+}
+
+// restartCollector restarts the workload of a collector and waits for its rollout. Sidecar collectors are skipped.
+// It reports whether the restart was triggered, so that a failed rollout can be told apart from a failed restart.
+func (c Client) restartCollector(ctx context.Context, col *v1beta1.OpenTelemetryCollector) (bool, error) {
+	mode := strings.ToLower(string(col.Spec.Mode))
+	if mode == "sidecar" {
+		c.log.Info("Skipping restart for sidecar mode collector - no standalone workload",
+			"name", col.GetName(), "namespace", col.GetNamespace())
+		return false, nil
+	}
+	workloadName := naming.Collector(col.GetName())
+	if err := rollout.TriggerRollout(ctx, c.k8sClient, col.GetNamespace(), mode, workloadName); err != nil {
+		return false, err
+	}
+	c.log.Info("Triggered workload rollout restart", "mode", mode, "name", workloadName, "namespace", col.GetNamespace())
+	if err := rollout.WaitForRollout(ctx, c.k8sClient, col.GetNamespace(), mode, workloadName, restartPollInterval); err != nil {
+		return true, err
+	}
+	c.log.Info("Workload rollout restart completed", "mode", mode, "name", workloadName, "namespace", col.GetNamespace())
+	return true, nil
+}
+
+// FlushQueues terminates the pods of the collector identified by key, or of every managed collector when key is empty,
+// one at a time, so that each collector drains its sending queues on shutdown. Sidecar mode collectors are skipped
+// (their pods are the pods of the applications). Like Restart, a pod that doesn't come back, or ctx being done, stops
+// the flush of the remaining ones.
+func (c Client) FlushQueues(ctx context.Context, key string) error {
+	if key != "" {
+		collector, err := c.workloadCollector(key, "flush")
+		if err != nil {
+			return err
+		}
+		return c.flushCollector(ctx, CRDInstance{Col: *collector})
+	}
+	collectors, err := c.listOpenTelemetryCollectors()
+	if err != nil {
+		return fmt.Errorf("failed to list collectors for flush: %w", err)
+	}
+	for _, col := range collectors {
+		if err = c.flushCollector(ctx, col); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// flushCollector terminates the pods of a collector one at a time, waiting for each pod to be gone and for the
+// workload to be available again before the next one. Sidecar collectors are skipped.
+func (c Client) flushCollector(ctx context.Context, col CRDInstance) error {
+	mode := strings.ToLower(string(col.Col.Spec.Mode))
+	if mode == "sidecar" {
+		c.log.Info("Skipping queue flush for sidecar mode collector - no standalone workload",
+			"name", col.Col.GetName(), "namespace", col.Col.GetNamespace())
+		return nil
+	}
+	pods, err := c.getCollectorPods(col.selectorLabels(), col.Col.GetNamespace())
+	if err != nil {
+		return fmt.Errorf("failed to list the pods of collector %s/%s: %w", col.Col.GetNamespace(), col.Col.GetName(), err)
+	}
+	workloadName := naming.Collector(col.Col.GetName())
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		// the pod gets its termination grace period, during which the collector drains its sending queues
+		if err = c.k8sClient.Delete(ctx, pod); err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete pod %s/%s: %w", pod.GetNamespace(), pod.GetName(), err)
+		}
+		c.log.Info("Deleted collector pod to flush its queues", "name", pod.GetName(), "namespace", pod.GetNamespace())
+		if err = c.waitForPodDeletion(ctx, pod); err != nil {
+			return err
+		}
+		if err = rollout.WaitForRollout(ctx, c.k8sClient, col.Col.GetNamespace(), mode, workloadName, restartPollInterval); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// waitForPodDeletion polls the pod until it's gone, or replaced by a pod of the same name, as StatefulSets do.
+func (c Client) waitForPodDeletion(ctx context.Context, pod *v1.Pod) error {
+	err := wait.PollUntilContextCancel(ctx, restartPollInterval, true, func(ctx context.Context) (bool, error) {
+		current := &v1.Pod{}
+		err := c.k8sClient.Get(ctx, client.ObjectKeyFromObject(pod), current)
+		if errors.IsNotFound(err) {
+			return true, nil
+		}
+		return err == nil && current.GetUID() != pod.GetUID(), err
+	})
+	if err != nil && wait.Interrupted(err) {
+		return fmt.Errorf("timed out waiting for the termination of pod %s/%s: %w", pod.GetNamespace(), pod.GetName(), err)
+	}
+	return err
+}
+
+// ListInstances returns all collectors, and resources of the allowed kinds, that are visible to OpAMP as effective
+// config entries.
 func (c Client) ListInstances() ([]CollectorInstance, error) {
 	collectors, err := c.listOpenTelemetryCollectors()
 	if err != nil {
 		return nil, err
 	}
-	result := make([]CollectorInstance, len(collectors))
+	resources, err := c.listResources()
+	if err != nil {
+		return nil, err
+	}
+	result := make([]CollectorInstance, 0, len(collectors)+len(resources))
 	for i := range collectors {
-		result[i] = collectors[i]
+		result = append(result, collectors[i])
+	}
+	for i := range resources {
+		result = append(result, resources[i])
 	}
 	return result, nil
 }
@@ -273,20 +452,11 @@ func (c Client) GetHealth() (Health, error) {
 	}
 	healthMap := map[string]Health{}
 	for _, col := range collectors {
-		podMap, err := c.generateCollectorHealth(col.selectorLabels(), col.GetNamespace())
+		health, err := c.collectorHealth(col)
 		if err != nil {
 			return Health{}, err
 		}
-		isPoolHealthy := true
-		for _, pod := range podMap {
-			isPoolHealthy = isPoolHealthy && pod.Healthy
-		}
-		healthMap[NewKubeResourceKey(col.GetNamespace(), col.GetName()).String()] = Health{
-			StartTime: col.Col.GetCreationTimestamp().Time,
-			Status:    col.Col.Status.Scale.StatusReplicas,
-			Children:  podMap,
-			Healthy:   isPoolHealthy,
-		}
+		healthMap[NewKubeResourceKey(col.GetNamespace(), col.GetName()).String()] = health
 	}
 	return Health{
 		Healthy:  true,
@@ -294,6 +464,24 @@ func (c Client) GetHealth() (Health, error) {
 	}, nil
 }
 
+// collectorHealth reports the health of a collector, with the health of its pods as children.
+func (c Client) collectorHealth(col CRDInstance) (Health, error) {
+	podMap, err := c.generateCollectorHealth(col.selectorLabels(), col.GetNamespace())
+	if err != nil {
+		return Health{}, err
+	}
+	isPoolHealthy := true
+	for _, pod := range podMap {
+		isPoolHealthy = isPoolHealthy && pod.Healthy
+	}
+	return Health{
+		StartTime: col.Col.GetCreationTimestamp().Time,
+		Status:    col.Col.Status.Scale.StatusReplicas,
+		Children:  podMap,
+		Healthy:   isPoolHealthy,
+	}, nil
+}
+
 // generateCollectorHealth reports pod health for one collector selected by labels within a namespace.
 func (c Client) generateCollectorHealth(selectorLabels map[string]string, namespace string) (map[string]Health, error) {
 	pods, err := c.getCollectorPods(selectorLabels, namespace)
@@ -327,12 +515,10 @@ func (c Client) generateCollectorHealth(selectorLabels map[string]string, namesp
 func (c Client) listOpenTelemetryCollectors() ([]CRDInstance, error) {
 	ctx := context.Background()
 
-	labelSelector := labels.NewSelector()
-	requirement, err := labels.NewRequirement(ManagedLabelKey, selection.In, []string{c.name, "true"})
+	managedCollectorLabelSelector, err := c.managedLabelSelector()
 	if err != nil {
 		return nil, err
 	}
-	managedCollectorLabelSelector := client.MatchingLabelsSelector{Selector: labelSelector.Add(*requirement)}
 
 	managedCollectors := v1beta1.OpenTelemetryCollectorList{}
 	err = c.k8sClient.List(ctx, &managedCollectors, managedCollectorLabelSelector)
@@ -361,6 +547,24 @@ func (c Client) listOpenTelemetryCollectors() ([]CRDInstance, error) {
 	return result, nil
 }
 
+// managedLabelSelector selects the resources managed by this bridge.
+func (c Client) managedLabelSelector() (client.MatchingLabelsSelector, error) {
+	selector, err := managedSelector(c.name)
+	if err != nil {
+		return client.MatchingLabelsSelector{}, err
+	}
+	return client.MatchingLabelsSelector{Selector: selector}, nil
+}
+
+// managedSelector selects the resources managed by the bridge with the given name.
+func managedSelector(bridgeName string) (labels.Selector, error) {
+	requirement, err := labels.NewRequirement(ManagedLabelKey, selection.In, []string{bridgeName, "true"})
+	if err != nil {
+		return nil, err
+	}
+	return labels.NewSelector().Add(*requirement), nil
+}
+
 func (c Client) GetInstance(name, namespace string) (*v1beta1.OpenTelemetryCollector, error) {
 	ctx := context.Background()
 	result := v1beta1.OpenTelemetryCollector{}
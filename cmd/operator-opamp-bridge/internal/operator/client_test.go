@@ -37,8 +37,11 @@ const (
 func getFakeClient(t *testing.T, lists ...client.ObjectList) client.WithWatch {
 	schemeBuilder := runtime.NewSchemeBuilder(func(s *runtime.Scheme) error {
 		s.AddKnownTypes(v1alpha1.GroupVersion, &v1alpha1.OpenTelemetryCollector{}, &v1alpha1.OpenTelemetryCollectorList{})
+		s.AddKnownTypes(v1alpha1.GroupVersion, &v1alpha1.Instrumentation{}, &v1alpha1.InstrumentationList{})
+		s.AddKnownTypes(v1alpha1.GroupVersion, &v1alpha1.TargetAllocator{}, &v1alpha1.TargetAllocatorList{})
 		s.AddKnownTypes(v1beta1.GroupVersion, &v1beta1.OpenTelemetryCollector{}, &v1beta1.OpenTelemetryCollectorList{})
 		s.AddKnownTypes(v1.SchemeGroupVersion, &v1.Pod{}, &v1.PodList{})
+		s.AddKnownTypes(v1.SchemeGroupVersion, &v1.Secret{}, &v1.SecretList{})
 		metav1.AddToGroupVersion(s, v1alpha1.GroupVersion)
 		return appsv1.AddToScheme(s)
 	})
@@ -147,7 +150,7 @@ func TestClient_Apply(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			fakeClient := getFakeClient(t)
-			c := NewClient(bridgeName, clientLogger, fakeClient, componentsAllowed)
+			c := NewClient(bridgeName, clientLogger, fakeClient, componentsAllowed, nil)
 			var colConfig []byte
 			var err error
 			if tt.args.file != "" {
@@ -173,7 +176,7 @@ func TestClient_ApplyUpdate(t *testing.T) {
 	name := "test"
 	namespace := "testing"
 	fakeClient := getFakeClient(t)
-	c := NewClient(bridgeName, clientLogger, fakeClient, nil)
+	c := NewClient(bridgeName, clientLogger, fakeClient, nil, nil)
 
 	// Load reporting-only collector
 	reportingColConfig, err := loadConfig("testdata/reporting-collector.yaml")
@@ -262,7 +265,7 @@ func TestClient_Delete(t *testing.T) {
 	name := "test"
 	namespace := "testing"
 	fakeClient := getFakeClient(t)
-	c := NewClient(bridgeName, clientLogger, fakeClient, nil)
+	c := NewClient(bridgeName, clientLogger, fakeClient, nil, nil)
 	colConfig, err := loadConfig("testdata/collector.yaml")
 	require.NoError(t, err, "Should be no error on loading test configuration")
 	configmap := &protobufs.AgentConfigFile{
@@ -291,6 +294,113 @@ func TestClient_Delete(t *testing.T) {
 	require.Empty(t, allInstances, "Should be empty after deletion")
 }
 
+func TestClient_ApplyResource(t *testing.T) {
+	name := "test"
+	namespace := "testing"
+	key := newKindedKubeResourceKey(InstrumentationResource, namespace, name).String()
+	instrumentationConfig, err := loadConfig("testdata/instrumentation.yaml")
+	require.NoError(t, err, "Should be no error on loading test configuration")
+
+	t.Run("kind not allowed", func(t *testing.T) {
+		c := NewClient(bridgeName, clientLogger, getFakeClient(t), nil, nil)
+		err := c.Apply(key, &protobufs.AgentConfigFile{Body: instrumentationConfig, ContentType: "yaml"})
+		assert.ErrorContains(t, err, "remote config for the kind Instrumentation is not allowed")
+	})
+
+	t.Run("reporting-only", func(t *testing.T) {
+		fakeClient := getFakeClient(t)
+		require.NoError(t, fakeClient.Create(context.Background(), &v1alpha1.Instrumentation{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: map[string]string{ReportingLabelKey: "true"}},
+		}))
+		c := NewClient(bridgeName, clientLogger, fakeClient, nil, map[string]map[string]bool{InstrumentationResource: {}})
+		err := c.Apply(key, &protobufs.AgentConfigFile{Body: instrumentationConfig, ContentType: "yaml"})
+		assert.ErrorContains(t, err, "cannot modify an instrumentation with `opentelemetry.io/opamp-reporting: true`")
+	})
+
+	fakeClient := getFakeClient(t)
+	c := NewClient(bridgeName, clientLogger, fakeClient, nil, map[string]map[string]bool{
+		InstrumentationResource: {"sampler": true, "exporter": true},
+	})
+
+	// Create the instrumentation
+	err = c.Apply(key, &protobufs.AgentConfigFile{Body: instrumentationConfig, ContentType: "yaml"})
+	require.NoError(t, err, "Should apply base config")
+	instrumentation := &v1alpha1.Instrumentation{}
+	require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKey{Name: name, Namespace: namespace}, instrumentation))
+	assert.Equal(t, ResourceIdentifierValue, instrumentation.Labels[ResourceIdentifierKey])
+	assert.Equal(t, v1alpha1.ParentBasedTraceIDRatio, instrumentation.Spec.Sampler.Type)
+	assert.Equal(t, "http://otel-collector:4318", instrumentation.Spec.Exporter.Endpoint)
+
+	// Fields set outside the bridge are kept by updates
+	instrumentation.Spec.Java.Image = "java:1.0"
+	require.NoError(t, fakeClient.Update(context.Background(), instrumentation))
+	err = c.Apply(key, &protobufs.AgentConfigFile{Body: []byte("spec:\n  sampler:\n    type: always_on\n"), ContentType: "yaml"})
+	require.NoError(t, err, "Should update the sampler")
+	require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKey{Name: name, Namespace: namespace}, instrumentation))
+	assert.Equal(t, v1alpha1.AlwaysOn, instrumentation.Spec.Sampler.Type)
+	assert.Equal(t, "http://otel-collector:4318", instrumentation.Spec.Exporter.Endpoint)
+	assert.Equal(t, "java:1.0", instrumentation.Spec.Java.Image)
+
+	// Fields that aren't allowed can't be changed
+	err = c.Apply(key, &protobufs.AgentConfigFile{Body: []byte("spec:\n  java:\n    image: java:2.0\n"), ContentType: "yaml"})
+	assert.ErrorContains(t, err, "Items in config are not allowed: [spec.java]")
+	err = c.Apply(key, &protobufs.AgentConfigFile{Body: []byte("spec:\n  sampelr: {}\n"), ContentType: "yaml"})
+	assert.ErrorContains(t, err, "unknown field in Instrumentation config: spec.sampelr")
+
+	// The instrumentation is reported in the effective config, which can be applied back as is
+	instances, err := c.ListInstances()
+	require.NoError(t, err)
+	require.Len(t, instances, 1)
+	assert.True(t, instances[0].IsManaged())
+	effectiveConfig := instances[0].GetConfigMap()
+	require.Contains(t, effectiveConfig, key)
+	err = c.Apply(key, &protobufs.AgentConfigFile{Body: effectiveConfig[key].Body, ContentType: "yaml"})
+	require.NoError(t, err, "Should apply the effective config")
+
+	// Delete it
+	require.NoError(t, c.Delete(key))
+	instances, err = c.ListInstances()
+	require.NoError(t, err)
+	assert.Empty(t, instances)
+}
+
+func TestClient_Snapshot(t *testing.T) {
+	name := "test"
+	namespace := "testing"
+	key := NewKubeResourceKey(namespace, name).String()
+	fakeClient := getFakeClient(t)
+	c := NewClient(bridgeName, clientLogger, fakeClient, nil, nil)
+	colConfig, err := loadConfig("testdata/collector.yaml")
+	require.NoError(t, err, "Should be no error on loading test configuration")
+	configmap := &protobufs.AgentConfigFile{Body: colConfig, ContentType: "yaml"}
+
+	// A dry-run doesn't create the collector
+	require.NoError(t, c.Validate(key, configmap))
+	instance, err := c.GetInstance(name, namespace)
+	require.NoError(t, err)
+	require.Nil(t, instance)
+
+	// Restoring a snapshot of a missing collector deletes it
+	restore, err := c.Snapshot(key)
+	require.NoError(t, err)
+	require.NoError(t, c.Apply(key, configmap))
+	require.NoError(t, restore())
+	instance, err = c.GetInstance(name, namespace)
+	require.NoError(t, err)
+	require.Nil(t, instance)
+
+	// Restoring a snapshot of a deleted collector recreates it
+	require.NoError(t, c.Apply(key, configmap))
+	restore, err = c.Snapshot(key)
+	require.NoError(t, err)
+	require.NoError(t, c.Delete(key))
+	require.NoError(t, restore())
+	instance, err = c.GetInstance(name, namespace)
+	require.NoError(t, err)
+	require.NotNil(t, instance)
+	assert.Contains(t, instance.Spec.Config.Processors.Object, "batch")
+}
+
 func loadConfig(file string) ([]byte, error) {
 	yamlFile, err := os.ReadFile(file)
 	if err != nil {
@@ -366,7 +476,7 @@ func TestClient_getCollectorPods(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			fakeClient := getFakeClient(t, mockPodList)
-			c := NewClient(bridgeName, clientLogger, fakeClient, nil)
+			c := NewClient(bridgeName, clientLogger, fakeClient, nil, nil)
 			got, err := c.getCollectorPods(tt.args.selector, tt.args.namespace)
 			if !tt.wantErr(t, err, fmt.Sprintf("getCollectorPods(%v)", tt.args.selector)) {
 				return
@@ -394,13 +504,16 @@ func managedCollector(name string, mode v1beta1.Mode) *v1beta1.OpenTelemetryColl
 func TestClient_Restart_Deployment(t *testing.T) {
 	col := managedCollector("test-col", v1beta1.ModeDeployment)
 	workloadName := naming.Collector(col.Name)
-	deploy := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: workloadName, Namespace: "default"}}
+	deploy := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: workloadName, Namespace: "default"},
+		Status:     appsv1.DeploymentStatus{Replicas: 1, UpdatedReplicas: 1, AvailableReplicas: 1},
+	}
 
 	fakeClient := getFakeClient(t)
 	require.NoError(t, fakeClient.Create(context.Background(), col))
 	require.NoError(t, fakeClient.Create(context.Background(), deploy))
 
-	c := NewClient(bridgeName, clientLogger, fakeClient, nil)
+	c := NewClient(bridgeName, clientLogger, fakeClient, nil, nil)
 	before := time.Now().Truncate(time.Second)
 	require.NoError(t, c.Restart(context.Background()))
 
@@ -422,7 +535,7 @@ func TestClient_Restart_DaemonSet(t *testing.T) {
 	require.NoError(t, fakeClient.Create(context.Background(), col))
 	require.NoError(t, fakeClient.Create(context.Background(), ds))
 
-	c := NewClient(bridgeName, clientLogger, fakeClient, nil)
+	c := NewClient(bridgeName, clientLogger, fakeClient, nil, nil)
 	require.NoError(t, c.Restart(context.Background()))
 
 	result := &appsv1.DaemonSet{}
@@ -433,13 +546,16 @@ func TestClient_Restart_DaemonSet(t *testing.T) {
 func TestClient_Restart_StatefulSet(t *testing.T) {
 	col := managedCollector("test-col", v1beta1.ModeStatefulSet)
 	workloadName := naming.Collector(col.Name)
-	sts := &appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Name: workloadName, Namespace: "default"}}
+	sts := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: workloadName, Namespace: "default"},
+		Status:     appsv1.StatefulSetStatus{UpdatedReplicas: 1, ReadyReplicas: 1},
+	}
 
 	fakeClient := getFakeClient(t)
 	require.NoError(t, fakeClient.Create(context.Background(), col))
 	require.NoError(t, fakeClient.Create(context.Background(), sts))
 
-	c := NewClient(bridgeName, clientLogger, fakeClient, nil)
+	c := NewClient(bridgeName, clientLogger, fakeClient, nil, nil)
 	require.NoError(t, c.Restart(context.Background()))
 
 	result := &appsv1.StatefulSet{}
@@ -453,13 +569,13 @@ func TestClient_Restart_SidecarSkipped(t *testing.T) {
 	fakeClient := getFakeClient(t)
 	require.NoError(t, fakeClient.Create(context.Background(), col))
 
-	c := NewClient(bridgeName, clientLogger, fakeClient, nil)
+	c := NewClient(bridgeName, clientLogger, fakeClient, nil, nil)
 	require.NoError(t, c.Restart(context.Background()))
 }
 
 func TestClient_Restart_NoCollectors(t *testing.T) {
 	fakeClient := getFakeClient(t)
-	c := NewClient(bridgeName, clientLogger, fakeClient, nil)
+	c := NewClient(bridgeName, clientLogger, fakeClient, nil, nil)
 	require.NoError(t, c.Restart(context.Background()))
 }
 
@@ -468,14 +584,17 @@ func TestClient_Restart_PartialFailure(t *testing.T) {
 	col1 := managedCollector("col-ok", v1beta1.ModeDeployment)
 	col2 := managedCollector("col-missing", v1beta1.ModeDeployment)
 	workload1 := naming.Collector(col1.Name)
-	deploy := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: workload1, Namespace: "default"}}
+	deploy := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: workload1, Namespace: "default"},
+		Status:     appsv1.DeploymentStatus{Replicas: 1, UpdatedReplicas: 1, AvailableReplicas: 1},
+	}
 
 	fakeClient := getFakeClient(t)
 	require.NoError(t, fakeClient.Create(context.Background(), col1))
 	require.NoError(t, fakeClient.Create(context.Background(), col2))
 	require.NoError(t, fakeClient.Create(context.Background(), deploy))
 
-	c := NewClient(bridgeName, clientLogger, fakeClient, nil)
+	c := NewClient(bridgeName, clientLogger, fakeClient, nil, nil)
 	err := c.Restart(context.Background())
 	require.Error(t, err, "partial failure must be reported")
 
@@ -484,3 +603,192 @@ func TestClient_Restart_PartialFailure(t *testing.T) {
 	require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKey{Name: workload1, Namespace: "default"}, result))
 	assert.NotEmpty(t, result.Spec.Template.Annotations[rollout.RestartAnnotation])
 }
+
+func TestClient_Restart_StopsOnIncompleteRollout(t *testing.T) {
+	stuck := managedCollector("a-stuck", v1beta1.ModeDeployment)
+	next := managedCollector("b-next", v1beta1.ModeDeployment)
+	fakeClient := getFakeClient(t)
+	require.NoError(t, fakeClient.Create(context.Background(), stuck))
+	require.NoError(t, fakeClient.Create(context.Background(), next))
+	// the first workload never becomes available
+	require.NoError(t, fakeClient.Create(context.Background(), &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: naming.Collector(stuck.Name), Namespace: "default"},
+	}))
+	require.NoError(t, fakeClient.Create(context.Background(), &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: naming.Collector(next.Name), Namespace: "default"},
+		Status:     appsv1.DeploymentStatus{Replicas: 1, UpdatedReplicas: 1, AvailableReplicas: 1},
+	}))
+
+	c := NewClient(bridgeName, clientLogger, fakeClient, nil, nil)
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	err := c.Restart(ctx)
+	assert.ErrorContains(t, err, "timed out waiting for the rollout of deployment default/a-stuck-collector")
+
+	result := &appsv1.Deployment{}
+	require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKey{Name: naming.Collector(next.Name), Namespace: "default"}, result))
+	assert.Empty(t, result.Spec.Template.Annotations[rollout.RestartAnnotation], "the next workload must not be restarted")
+}
+
+func TestClient_RestartInstance(t *testing.T) {
+	col := managedCollector("test-col", v1beta1.ModeDeployment)
+	other := managedCollector("other-col", v1beta1.ModeDeployment)
+	sidecar := managedCollector("test-sidecar", v1beta1.ModeSidecar)
+	reporting := managedCollector("reporting-col", v1beta1.ModeDeployment)
+	reporting.Labels = map[string]string{ReportingLabelKey: "true"}
+
+	fakeClient := getFakeClient(t)
+	for _, obj := range []client.Object{col, other, sidecar, reporting} {
+		require.NoError(t, fakeClient.Create(context.Background(), obj))
+	}
+	for _, name := range []string{col.Name, other.Name} {
+		require.NoError(t, fakeClient.Create(context.Background(), &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: naming.Collector(name), Namespace: "default"},
+			Status:     appsv1.DeploymentStatus{Replicas: 1, UpdatedReplicas: 1, AvailableReplicas: 1},
+		}))
+	}
+
+	c := NewClient(bridgeName, clientLogger, fakeClient, nil, nil)
+	require.NoError(t, c.RestartInstance(context.Background(), "default/test-col"))
+
+	for name, restarted := range map[string]bool{col.Name: true, other.Name: false} {
+		result := &appsv1.Deployment{}
+		require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKey{Name: naming.Collector(name), Namespace: "default"}, result))
+		assert.Equal(t, restarted, result.Spec.Template.Annotations[rollout.RestartAnnotation] != "", name)
+	}
+
+	assert.EqualError(t, c.RestartInstance(context.Background(), "default/test-sidecar"), "collector default/test-sidecar is in sidecar mode and has no workload to restart")
+	assert.EqualError(t, c.RestartInstance(context.Background(), "default/missing"), "collector default/missing not found")
+	assert.ErrorContains(t, c.RestartInstance(context.Background(), "default/reporting-col"), "cannot modify a collector with `opentelemetry.io/opamp-reporting: true`")
+	assert.EqualError(t, c.RestartInstance(context.Background(), "Instrumentation/default/test"), "Instrumentation/default/test has no workload to restart")
+}
+
+func TestClient_FlushQueues(t *testing.T) {
+	col := managedCollector("test-col", v1beta1.ModeDeployment)
+	other := managedCollector("other-col", v1beta1.ModeDeployment)
+	sidecar := managedCollector("test-sidecar", v1beta1.ModeSidecar)
+
+	fakeClient := getFakeClient(t)
+	for _, obj := range []client.Object{col, other, sidecar} {
+		require.NoError(t, fakeClient.Create(context.Background(), obj))
+	}
+	replicas := int32(2)
+	for _, c := range []*v1beta1.OpenTelemetryCollector{col, other} {
+		require.NoError(t, fakeClient.Create(context.Background(), &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: naming.Collector(c.Name), Namespace: "default"},
+			Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+			Status:     appsv1.DeploymentStatus{Replicas: 2, UpdatedReplicas: 2, AvailableReplicas: 2},
+		}))
+		for _, suffix := range []string{"a", "b"} {
+			require.NoError(t, fakeClient.Create(context.Background(), &v1.Pod{ObjectMeta: metav1.ObjectMeta{
+				Name:      naming.Collector(c.Name) + "-" + suffix,
+				Namespace: "default",
+				Labels:    newCRDInstance(*c, true).selectorLabels(),
+			}}))
+		}
+	}
+
+	c := NewClient(bridgeName, clientLogger, fakeClient, nil, nil)
+	require.NoError(t, c.FlushQueues(context.Background(), "default/test-col"))
+
+	pods := &v1.PodList{}
+	require.NoError(t, fakeClient.List(context.Background(), pods))
+	var remaining []string
+	for _, pod := range pods.Items {
+		remaining = append(remaining, pod.Name)
+	}
+	assert.ElementsMatch(t, []string{"other-col-collector-a", "other-col-collector-b"}, remaining)
+
+	require.NoError(t, c.FlushQueues(context.Background(), ""))
+	require.NoError(t, fakeClient.List(context.Background(), pods))
+	assert.Empty(t, pods.Items)
+
+	assert.EqualError(t, c.FlushQueues(context.Background(), "default/test-sidecar"), "collector default/test-sidecar is in sidecar mode and has no workload to flush")
+	assert.EqualError(t, c.FlushQueues(context.Background(), "Instrumentation/default/test"), "Instrumentation/default/test has no workload to flush")
+}
+
+// packageCollector creates a managed collector labeled with the package it's rolled out from.
+func packageCollector(name, pkg string, mode v1beta1.Mode) *v1beta1.OpenTelemetryCollector {
+	col := managedCollector(name, mode)
+	col.Labels[PackageLabelKey] = pkg
+	col.Spec.Image = "otel/collector:0.119.0"
+	return col
+}
+
+func TestClient_ApplyPackage(t *testing.T) {
+	fakeClient := getFakeClient(t)
+	for _, col := range []*v1beta1.OpenTelemetryCollector{
+		packageCollector("first", "collector", v1beta1.ModeDeployment),
+		packageCollector("second", "collector", v1beta1.ModeDaemonSet),
+		packageCollector("other", "contrib", v1beta1.ModeDeployment),
+	} {
+		require.NoError(t, fakeClient.Create(context.Background(), col))
+	}
+	c := NewClient(bridgeName, clientLogger, fakeClient, nil, nil)
+
+	require.NoError(t, c.ApplyPackage("collector", "otel/collector:0.120.0"))
+
+	for name, image := range map[string]string{
+		"first":  "otel/collector:0.120.0",
+		"second": "otel/collector:0.120.0",
+		"other":  "otel/collector:0.119.0",
+	} {
+		instance, err := c.GetInstance(name, "default")
+		require.NoError(t, err)
+		assert.Equal(t, image, instance.Spec.Image, name)
+	}
+
+	err := c.ApplyPackage("unknown", "otel/collector:0.120.0")
+	assert.EqualError(t, err, "no managed collector is labeled with opentelemetry.io/opamp-package=unknown")
+}
+
+func TestClient_PackageRollout(t *testing.T) {
+	const image = "otel/collector:0.120.0"
+	col := packageCollector("test-col", "collector", v1beta1.ModeDeployment)
+	col.Spec.Image = image
+	sidecar := packageCollector("test-sidecar", "collector", v1beta1.ModeSidecar)
+	deploy := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: naming.Collector(col.Name), Namespace: "default"},
+		Spec: appsv1.DeploymentSpec{
+			Template: v1.PodTemplateSpec{Spec: v1.PodSpec{Containers: []v1.Container{{Name: naming.Container(), Image: "otel/collector:0.119.0"}}}},
+		},
+		Status: appsv1.DeploymentStatus{Replicas: 1, UpdatedReplicas: 1, AvailableReplicas: 1},
+	}
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-col-collector-abc",
+			Namespace: "default",
+			Labels:    newCRDInstance(*col, true).selectorLabels(),
+		},
+		Spec: v1.PodSpec{Containers: []v1.Container{{Name: naming.Container(), Image: image}}},
+		Status: v1.PodStatus{ContainerStatuses: []v1.ContainerStatus{{
+			Name: naming.Container(),
+			State: v1.ContainerState{Waiting: &v1.ContainerStateWaiting{
+				Reason:  "ImagePullBackOff",
+				Message: `Back-off pulling image "otel/collector:0.120.0"`,
+			}},
+		}}},
+	}
+
+	fakeClient := getFakeClient(t)
+	require.NoError(t, fakeClient.Create(context.Background(), col))
+	require.NoError(t, fakeClient.Create(context.Background(), sidecar))
+	require.NoError(t, fakeClient.Create(context.Background(), deploy))
+	c := NewClient(bridgeName, clientLogger, fakeClient, nil, nil)
+
+	// the workload still runs the previous image
+	done, err := c.PackageRollout("collector", image)
+	require.NoError(t, err)
+	assert.False(t, done)
+
+	deploy.Spec.Template.Spec.Containers[0].Image = image
+	require.NoError(t, fakeClient.Update(context.Background(), deploy))
+	done, err = c.PackageRollout("collector", image)
+	require.NoError(t, err)
+	assert.True(t, done)
+
+	require.NoError(t, fakeClient.Create(context.Background(), pod))
+	done, err = c.PackageRollout("collector", image)
+	assert.False(t, done)
+	assert.EqualError(t, err, `pod default/test-col-collector-abc failed to pull image otel/collector:0.120.0: Back-off pulling image "otel/collector:0.120.0"`)
+}
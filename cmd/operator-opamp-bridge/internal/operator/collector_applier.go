@@ -0,0 +1,143 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package operator
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/open-telemetry/opamp-go/protobufs"
+)
+
+// ListCollectorKeys returns the remote config entry names of the collectors managed by this bridge, sorted. Collectors
+// being deleted are left out.
+func (c Client) ListCollectorKeys() ([]string, error) {
+	collectors, err := c.listOpenTelemetryCollectors()
+	if err != nil {
+		return nil, err
+	}
+	var keys []string
+	for _, col := range collectors {
+		if col.IsManaged() && col.GetDeletionTimestamp() == nil {
+			keys = append(keys, NewKubeResourceKey(col.GetNamespace(), col.GetName()).String())
+		}
+	}
+	slices.Sort(keys)
+	return keys, nil
+}
+
+// ScopedApplier returns a ConfigApplier limited to the managed collector identified by a remote config entry name, for
+// the OpAMP agent representing that collector on its own.
+func (c Client) ScopedApplier(key string) ConfigApplier {
+	return &collectorApplier{
+		client: c,
+		key:    key,
+	}
+}
+
+// collectorApplier applies the remote config of one collector. It can't create or delete collectors, which are
+// managed outside of its OpAMP connection.
+type collectorApplier struct {
+	client Client
+	key    string
+}
+
+var (
+	_ TransactionalConfigApplier = &collectorApplier{}
+	_ InstanceRestarter          = &collectorApplier{}
+	_ QueueFlusher               = &collectorApplier{}
+)
+
+func (s *collectorApplier) checkKey(key string) error {
+	if key != s.key {
+		return fmt.Errorf("the agent of collector %s doesn't manage remote config entry %q", s.key, key)
+	}
+	return nil
+}
+
+func (s *collectorApplier) Apply(key string, configFile *protobufs.AgentConfigFile) error {
+	if err := s.checkKey(key); err != nil {
+		return err
+	}
+	return s.client.Apply(key, configFile)
+}
+
+func (s *collectorApplier) Delete(key string) error {
+	return fmt.Errorf("the agent of collector %s can't delete remote config entry %q", s.key, key)
+}
+
+// Restart restarts the workload of the collector and waits for its rollout.
+func (s *collectorApplier) Restart(ctx context.Context) error {
+	return s.client.RestartInstance(ctx, s.key)
+}
+
+func (s *collectorApplier) RestartInstance(ctx context.Context, key string) error {
+	if err := s.checkKey(key); err != nil {
+		return err
+	}
+	return s.client.RestartInstance(ctx, key)
+}
+
+// FlushQueues flushes the sending queues of the collector.
+func (s *collectorApplier) FlushQueues(ctx context.Context, key string) error {
+	if key == "" {
+		key = s.key
+	}
+	if err := s.checkKey(key); err != nil {
+		return err
+	}
+	return s.client.FlushQueues(ctx, key)
+}
+
+func (s *collectorApplier) Validate(key string, configFile *protobufs.AgentConfigFile) error {
+	if err := s.checkKey(key); err != nil {
+		return err
+	}
+	return s.client.Validate(key, configFile)
+}
+
+func (s *collectorApplier) Snapshot(key string) (func() error, error) {
+	if err := s.checkKey(key); err != nil {
+		return nil, err
+	}
+	return s.client.Snapshot(key)
+}
+
+// ListInstances returns the collector, or nothing once it's deleted.
+func (s *collectorApplier) ListInstances() ([]CollectorInstance, error) {
+	col, err := s.getCollector()
+	if err != nil || col == nil {
+		return []CollectorInstance{}, err
+	}
+	return []CollectorInstance{*col}, nil
+}
+
+// GetHealth reports the health of the collector's pods.
+func (s *collectorApplier) GetHealth() (Health, error) {
+	col, err := s.getCollector()
+	if err != nil {
+		return Health{}, err
+	}
+	if col == nil {
+		return Health{
+			Status:   fmt.Sprintf("collector %s not found", s.key),
+			Children: map[string]Health{},
+		}, nil
+	}
+	return s.client.collectorHealth(*col)
+}
+
+func (s *collectorApplier) getCollector() (*CRDInstance, error) {
+	collectors, err := s.client.listOpenTelemetryCollectors()
+	if err != nil {
+		return nil, err
+	}
+	for _, col := range collectors {
+		if col.IsManaged() && NewKubeResourceKey(col.GetNamespace(), col.GetName()).String() == s.key {
+			return &col, nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,63 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package operator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+)
+
+func TestClient_ListCollectorKeys(t *testing.T) {
+	fakeClient := getFakeClient(t)
+	for _, name := range []string{"second", "first"} {
+		require.NoError(t, fakeClient.Create(context.Background(), managedCollector(name, v1beta1.ModeDeployment)))
+	}
+	unmanaged := managedCollector("unmanaged", v1beta1.ModeDeployment)
+	unmanaged.Labels = map[string]string{}
+	require.NoError(t, fakeClient.Create(context.Background(), unmanaged))
+
+	c := NewClient(bridgeName, clientLogger, fakeClient, nil, nil)
+	keys, err := c.ListCollectorKeys()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"default/first", "default/second"}, keys)
+}
+
+func TestClient_ScopedApplier(t *testing.T) {
+	fakeClient := getFakeClient(t)
+	for _, name := range []string{"test-col", "other-col"} {
+		require.NoError(t, fakeClient.Create(context.Background(), managedCollector(name, v1beta1.ModeDeployment)))
+	}
+	c := NewClient(bridgeName, clientLogger, fakeClient, nil, nil)
+	applier := c.ScopedApplier("default/test-col")
+
+	instances, err := applier.ListInstances()
+	require.NoError(t, err)
+	require.Len(t, instances, 1)
+	assert.Equal(t, "test-col", instances[0].GetName())
+
+	configFile := &protobufs.AgentConfigFile{Body: []byte("{}"), ContentType: "yaml"}
+	assert.EqualError(t, applier.Apply("default/other-col", configFile), `the agent of collector default/test-col doesn't manage remote config entry "default/other-col"`)
+	assert.EqualError(t, applier.Delete("default/test-col"), `the agent of collector default/test-col can't delete remote config entry "default/test-col"`)
+	transactional, ok := applier.(TransactionalConfigApplier)
+	require.True(t, ok)
+	_, err = transactional.Snapshot("default/other-col")
+	assert.Error(t, err)
+	flusher, ok := applier.(QueueFlusher)
+	require.True(t, ok)
+	assert.Error(t, flusher.FlushQueues(context.Background(), "default/other-col"))
+
+	missing := c.ScopedApplier("default/missing")
+	instances, err = missing.ListInstances()
+	require.NoError(t, err)
+	assert.Empty(t, instances)
+	health, err := missing.GetHealth()
+	require.NoError(t, err)
+	assert.Equal(t, "collector default/missing not found", health.Status)
+}
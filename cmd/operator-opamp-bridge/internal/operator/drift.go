@@ -0,0 +1,173 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package operator
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+)
+
+// SpecBaselineAnnotation holds the hash of the spec of a managed collector as last changed through OpAMP, so that the
+// changes made while the bridge isn't running are detected as drifts.
+const SpecBaselineAnnotation = "opentelemetry.io/opamp-spec-baseline"
+
+// DriftDetector is a ConfigApplier that can tell when the collectors it manages were changed outside of OpAMP.
+type DriftDetector interface {
+	ConfigApplier
+
+	// SpecHashes returns the hash of the spec of each managed collector by remote config entry name. A change of
+	// hash between two calls means the collector was changed.
+	SpecHashes() (map[string]string, error)
+
+	// SpecBaselines returns the hash of the spec of each managed collector as last changed through OpAMP, as saved by
+	// SaveSpecBaseline, by remote config entry name. Collectors without a saved hash are left out.
+	SpecBaselines() (map[string]string, error)
+
+	// SaveSpecBaseline saves hash as the hash of the spec of the collector identified by an OpAMP remote config entry
+	// name as last changed through OpAMP.
+	SaveSpecBaseline(key, hash string) error
+
+	// SpecManager returns the field manager that last changed the spec of the collector identified by an OpAMP
+	// remote config entry name, empty when it isn't known.
+	SpecManager(key string) (string, error)
+}
+
+var (
+	_ DriftDetector = &Client{}
+	_ DriftDetector = &collectorApplier{}
+)
+
+func (c Client) SpecHashes() (map[string]string, error) {
+	collectors, err := c.listOpenTelemetryCollectors()
+	if err != nil {
+		return nil, err
+	}
+	hashes := map[string]string{}
+	for _, col := range collectors {
+		if !col.IsManaged() || col.GetDeletionTimestamp() != nil {
+			continue
+		}
+		hash, err := specHash(&col.Col)
+		if err != nil {
+			return nil, err
+		}
+		hashes[NewKubeResourceKey(col.GetNamespace(), col.GetName()).String()] = hash
+	}
+	return hashes, nil
+}
+
+func (s *collectorApplier) SpecHashes() (map[string]string, error) {
+	col, err := s.getCollector()
+	if err != nil || col == nil || col.GetDeletionTimestamp() != nil {
+		return map[string]string{}, err
+	}
+	hash, err := specHash(&col.Col)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]string{s.key: hash}, nil
+}
+
+func (c Client) SpecBaselines() (map[string]string, error) {
+	collectors, err := c.listOpenTelemetryCollectors()
+	if err != nil {
+		return nil, err
+	}
+	baselines := map[string]string{}
+	for _, col := range collectors {
+		if baseline, ok := col.Col.GetAnnotations()[SpecBaselineAnnotation]; ok && col.IsManaged() {
+			baselines[NewKubeResourceKey(col.GetNamespace(), col.GetName()).String()] = baseline
+		}
+	}
+	return baselines, nil
+}
+
+func (s *collectorApplier) SpecBaselines() (map[string]string, error) {
+	col, err := s.getCollector()
+	if err != nil || col == nil {
+		return map[string]string{}, err
+	}
+	if baseline, ok := col.Col.GetAnnotations()[SpecBaselineAnnotation]; ok {
+		return map[string]string{s.key: baseline}, nil
+	}
+	return map[string]string{}, nil
+}
+
+func (c Client) SaveSpecBaseline(key, hash string) error {
+	resource, err := kubeResourceFromKey(key)
+	if err != nil || resource.kind != "" {
+		return err
+	}
+	collector, err := c.GetInstance(resource.name, resource.namespace)
+	if err != nil || collector == nil || collector.GetAnnotations()[SpecBaselineAnnotation] == hash {
+		return err
+	}
+	patch := client.MergeFrom(collector.DeepCopy())
+	if collector.Annotations == nil {
+		collector.Annotations = map[string]string{}
+	}
+	collector.Annotations[SpecBaselineAnnotation] = hash
+	return c.k8sClient.Patch(context.Background(), collector, patch)
+}
+
+func (s *collectorApplier) SaveSpecBaseline(key, hash string) error {
+	if err := s.checkKey(key); err != nil {
+		return err
+	}
+	return s.client.SaveSpecBaseline(key, hash)
+}
+
+func (c Client) SpecManager(key string) (string, error) {
+	resource, err := kubeResourceFromKey(key)
+	if err != nil || resource.kind != "" {
+		return "", err
+	}
+	collector, err := c.GetInstance(resource.name, resource.namespace)
+	if err != nil || collector == nil {
+		return "", err
+	}
+	return lastSpecManager(collector.GetManagedFields()), nil
+}
+
+func (s *collectorApplier) SpecManager(key string) (string, error) {
+	if err := s.checkKey(key); err != nil {
+		return "", err
+	}
+	return s.client.SpecManager(key)
+}
+
+// lastSpecManager returns the manager of the latest change of the managed fields of an object, ignoring the changes
+// of its subresources, such as its status.
+func lastSpecManager(entries []metav1.ManagedFieldsEntry) string {
+	var manager string
+	var last time.Time
+	for _, entry := range entries {
+		if entry.Subresource != "" || entry.Time == nil {
+			continue
+		}
+		if manager == "" || entry.Time.After(last) {
+			manager = entry.Manager
+			last = entry.Time.Time
+		}
+	}
+	return manager
+}
+
+// specHash returns the SHA-256 digest of the spec of the collector, hex encoded.
+func specHash(collector *v1beta1.OpenTelemetryCollector) (string, error) {
+	spec, err := json.Marshal(collector.Spec)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(spec)
+	return hex.EncodeToString(sum[:]), nil
+}
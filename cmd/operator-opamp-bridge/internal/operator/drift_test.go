@@ -0,0 +1,129 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package operator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	toolscache "k8s.io/client-go/tools/cache"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+)
+
+func TestClient_SpecHashes(t *testing.T) {
+	fakeClient := getFakeClient(t)
+	col := managedCollector("test-col", v1beta1.ModeDeployment)
+	reporting := managedCollector("reporting-col", v1beta1.ModeDeployment)
+	reporting.Labels = map[string]string{ReportingLabelKey: "true"}
+	for _, obj := range []*v1beta1.OpenTelemetryCollector{col, reporting} {
+		require.NoError(t, fakeClient.Create(context.Background(), obj))
+	}
+	c := NewClient(bridgeName, clientLogger, fakeClient, nil, nil)
+
+	hashes, err := c.SpecHashes()
+	require.NoError(t, err)
+	require.Len(t, hashes, 1)
+	hash := hashes["default/test-col"]
+	assert.NotEmpty(t, hash)
+
+	// metadata and status changes aren't drifts
+	col.Annotations = map[string]string{"edited": "true"}
+	col.Status.Version = "0.120.0"
+	require.NoError(t, fakeClient.Update(context.Background(), col))
+	hashes, err = c.SpecHashes()
+	require.NoError(t, err)
+	assert.Equal(t, hash, hashes["default/test-col"])
+
+	col.Spec.Image = "otel/collector:0.120.0"
+	require.NoError(t, fakeClient.Update(context.Background(), col))
+	hashes, err = c.SpecHashes()
+	require.NoError(t, err)
+	assert.NotEqual(t, hash, hashes["default/test-col"])
+
+	scoped, ok := c.ScopedApplier("default/test-col").(DriftDetector)
+	require.True(t, ok)
+	scopedHashes, err := scoped.SpecHashes()
+	require.NoError(t, err)
+	assert.Equal(t, hashes, scopedHashes)
+
+	missing, ok := c.ScopedApplier("default/missing").(DriftDetector)
+	require.True(t, ok)
+	scopedHashes, err = missing.SpecHashes()
+	require.NoError(t, err)
+	assert.Empty(t, scopedHashes)
+}
+
+func TestLastSpecManager(t *testing.T) {
+	entries := []metav1.ManagedFieldsEntry{
+		{Manager: "opamp-bridge", Operation: metav1.ManagedFieldsOperationUpdate, Time: &metav1.Time{Time: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}},
+		{Manager: "kubectl-edit", Operation: metav1.ManagedFieldsOperationUpdate, Time: &metav1.Time{Time: time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)}},
+		{Manager: "opentelemetry-operator", Operation: metav1.ManagedFieldsOperationUpdate, Subresource: "status", Time: &metav1.Time{Time: time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC)}},
+	}
+	assert.Equal(t, "kubectl-edit", lastSpecManager(entries), "the latest change of the spec is the one of kubectl, not the status update")
+	assert.Empty(t, lastSpecManager(nil))
+
+	c := NewClient(bridgeName, clientLogger, getFakeClient(t), nil, nil)
+	manager, err := c.SpecManager("default/missing")
+	require.NoError(t, err)
+	assert.Empty(t, manager)
+}
+
+func TestClient_SpecBaselines(t *testing.T) {
+	fakeClient := getFakeClient(t)
+	col := managedCollector("test-col", v1beta1.ModeDeployment)
+	other := managedCollector("other-col", v1beta1.ModeDeployment)
+	for _, obj := range []*v1beta1.OpenTelemetryCollector{col, other} {
+		require.NoError(t, fakeClient.Create(context.Background(), obj))
+	}
+	c := NewClient(bridgeName, clientLogger, fakeClient, nil, nil)
+
+	baselines, err := c.SpecBaselines()
+	require.NoError(t, err)
+	assert.Empty(t, baselines, "nothing is saved before the collectors are changed through OpAMP")
+
+	require.NoError(t, c.SaveSpecBaseline("default/test-col", "baseline"))
+	baselines, err = c.SpecBaselines()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"default/test-col": "baseline"}, baselines)
+
+	// saving the baseline doesn't change the spec
+	hashes, err := c.SpecHashes()
+	require.NoError(t, err)
+	require.NoError(t, c.SaveSpecBaseline("default/test-col", "other baseline"))
+	updatedHashes, err := c.SpecHashes()
+	require.NoError(t, err)
+	assert.Equal(t, hashes, updatedHashes)
+
+	scoped, ok := c.ScopedApplier("default/test-col").(DriftDetector)
+	require.True(t, ok)
+	baselines, err = scoped.SpecBaselines()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"default/test-col": "other baseline"}, baselines)
+	require.Error(t, scoped.SaveSpecBaseline("default/other-col", "baseline"), "a scoped applier only saves its own collector")
+
+	require.NoError(t, c.SaveSpecBaseline("default/missing", "baseline"), "a deleted collector is ignored")
+}
+
+func TestCollectorWatcher_notifyChange(t *testing.T) {
+	changes := map[string]string{}
+	watcher := NewCollectorWatcher(clientLogger, nil, nil, bridgeName, func(key, hash string) { changes[key] = hash })
+	col := managedCollector("test-col", v1beta1.ModeDeployment)
+	hash, err := specHash(col)
+	require.NoError(t, err)
+
+	watcher.notifyChange(col, false)
+	assert.Equal(t, map[string]string{"default/test-col": hash}, changes)
+
+	watcher.notifyChange(toolscache.DeletedFinalStateUnknown{Key: "default/test-col", Obj: col}, true)
+	assert.Equal(t, map[string]string{"default/test-col": ""}, changes, "a deleted collector has no hash")
+
+	col.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+	watcher.notifyChange(col, false)
+	assert.Equal(t, map[string]string{"default/test-col": ""}, changes, "a collector being deleted has no hash")
+}
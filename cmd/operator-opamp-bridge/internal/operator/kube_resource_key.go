@@ -9,7 +9,10 @@ import (
 	"strings"
 )
 
+// KubeResourceKey identifies a resource managed by the bridge. Collectors are identified by namespace/name, other
+// managed kinds by kind/namespace/name.
 type KubeResourceKey struct {
+	kind      string
 	name      string
 	namespace string
 }
@@ -18,15 +21,30 @@ func NewKubeResourceKey(namespace, name string) KubeResourceKey {
 	return KubeResourceKey{name: name, namespace: namespace}
 }
 
+// newKindedKubeResourceKey returns the key of a resource of one of the managedResources kinds.
+func newKindedKubeResourceKey(kind, namespace, name string) KubeResourceKey {
+	return KubeResourceKey{kind: kind, name: name, namespace: namespace}
+}
+
 func kubeResourceFromKey(key string) (KubeResourceKey, error) {
 	s := strings.Split(key, "/")
-	// We expect map keys to be of the form namespace/name.
-	if len(s) != 2 {
+	// We expect map keys to be of the form namespace/name, or kind/namespace/name for kinds other than collectors.
+	switch len(s) {
+	case 2:
+		return NewKubeResourceKey(s[0], s[1]), nil
+	case 3:
+		if _, ok := managedResources[s[0]]; !ok {
+			return KubeResourceKey{}, fmt.Errorf("invalid key: unsupported kind %q", s[0])
+		}
+		return newKindedKubeResourceKey(s[0], s[1], s[2]), nil
+	default:
 		return KubeResourceKey{}, errors.New("invalid key")
 	}
-	return NewKubeResourceKey(s[0], s[1]), nil
 }
 
 func (k KubeResourceKey) String() string {
+	if k.kind != "" {
+		return fmt.Sprintf("%s/%s/%s", k.kind, k.namespace, k.name)
+	}
 	return fmt.Sprintf("%s/%s", k.namespace, k.name)
 }
@@ -39,6 +39,18 @@ func TestKubeResourceFromKey(t *testing.T) {
 			want:    KubeResourceKey{},
 			wantErr: assert.Error,
 		},
+		{
+			name: "kind",
+			args: args{
+				key: "Instrumentation/namespace/good",
+			},
+			want: KubeResourceKey{
+				kind:      InstrumentationResource,
+				name:      "good",
+				namespace: "namespace",
+			},
+			wantErr: assert.NoError,
+		},
 		{
 			name: "too many slashes",
 			args: args{
@@ -62,4 +74,5 @@ func TestKubeResourceFromKey(t *testing.T) {
 func TestKubeResourceKeyString(t *testing.T) {
 	key := NewKubeResourceKey("namespace", "good")
 	assert.Equal(t, "namespace/good", key.String())
+	assert.Equal(t, "TargetAllocator/namespace/good", newKindedKubeResourceKey(TargetAllocatorResource, "namespace", "good").String())
 }
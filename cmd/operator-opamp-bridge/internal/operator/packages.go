@@ -0,0 +1,136 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package operator
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/internal/rollout"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+)
+
+// PackageLabelKey is the label naming the OpAMP package a managed collector's image is rolled out from.
+const PackageLabelKey = "opentelemetry.io/opamp-package"
+
+// imagePullFailures are the waiting reasons of a container whose image can't be pulled.
+var imagePullFailures = map[string]bool{
+	"ErrImagePull":     true,
+	"ImagePullBackOff": true,
+	"InvalidImageName": true,
+}
+
+// PackageApplier is a ConfigApplier that can roll the collectors of an OpAMP package out to a new image.
+type PackageApplier interface {
+	ConfigApplier
+
+	// ApplyPackage sets the image of the collectors of the package.
+	ApplyPackage(name, image string) error
+
+	// PackageRollout reports whether the workloads of the collectors of the package finished rolling out the image.
+	// It returns an error when the rollout failed.
+	PackageRollout(name, image string) (bool, error)
+}
+
+var _ PackageApplier = &Client{}
+
+// ApplyPackage sets the image of the managed collectors labeled with the package name.
+func (c Client) ApplyPackage(name, image string) error {
+	collectors, err := c.listPackageCollectors(name)
+	if err != nil {
+		return err
+	}
+	if len(collectors) == 0 {
+		return fmt.Errorf("no managed collector is labeled with %s=%s", PackageLabelKey, name)
+	}
+	ctx := context.Background()
+	for i := range collectors {
+		if collectors[i].Spec.Image == image {
+			continue
+		}
+		collectors[i].Spec.Image = image
+		c.log.Info("Updating collector image", "package", name, "name", collectors[i].GetName(), "namespace", collectors[i].GetNamespace())
+		if err = c.k8sClient.Update(ctx, &collectors[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PackageRollout checks that the workload of every collector of the package runs the image, its rollout is
+// complete, and none of its pods fails to pull the image. Sidecar collectors have no workload and are skipped.
+func (c Client) PackageRollout(name, image string) (bool, error) {
+	collectors, err := c.listPackageCollectors(name)
+	if err != nil {
+		return false, err
+	}
+	ctx := context.Background()
+	done := true
+	for i := range collectors {
+		col := newCRDInstance(collectors[i], true)
+		mode := string(col.Col.Spec.Mode)
+		if strings.EqualFold(mode, string(v1beta1.ModeSidecar)) {
+			continue
+		}
+		template, complete, err := rollout.Status(ctx, c.k8sClient, col.GetNamespace(), mode, naming.Collector(col.GetName()))
+		if err != nil {
+			return false, err
+		}
+		if !hasContainerImage(template.Spec.Containers, image) {
+			done = false
+			continue
+		}
+		pods, err := c.getCollectorPods(col.selectorLabels(), col.GetNamespace())
+		if err != nil {
+			return false, err
+		}
+		for _, pod := range pods.Items {
+			// the image in the container status may be normalized, the pod spec has it as set on the collector
+			if !hasContainerImage(pod.Spec.Containers, image) {
+				continue
+			}
+			for _, status := range pod.Status.ContainerStatuses {
+				if status.Name == naming.Container() && status.State.Waiting != nil && imagePullFailures[status.State.Waiting.Reason] {
+					return false, fmt.Errorf("pod %s/%s failed to pull image %s: %s", pod.GetNamespace(), pod.GetName(), image, status.State.Waiting.Message)
+				}
+			}
+		}
+		done = done && complete
+	}
+	return done, nil
+}
+
+// listPackageCollectors returns the collectors managed by this bridge labeled with the package name.
+func (c Client) listPackageCollectors(name string) ([]v1beta1.OpenTelemetryCollector, error) {
+	managedSelector, err := c.managedLabelSelector()
+	if err != nil {
+		return nil, err
+	}
+	requirement, err := labels.NewRequirement(PackageLabelKey, selection.Equals, []string{name})
+	if err != nil {
+		return nil, err
+	}
+	// list options each replace the label selector, so both requirements go in one selector
+	managedSelector.Selector = managedSelector.Selector.Add(*requirement)
+	collectors := v1beta1.OpenTelemetryCollectorList{}
+	if err = c.k8sClient.List(context.Background(), &collectors, managedSelector); err != nil {
+		return nil, err
+	}
+	return collectors.Items, nil
+}
+
+func hasContainerImage(containers []v1.Container, image string) bool {
+	for _, container := range containers {
+		if container.Name == naming.Container() {
+			return container.Image == image
+		}
+	}
+	return false
+}
@@ -0,0 +1,57 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package operator
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+var _ CollectorInstance = ResourceInstance{}
+
+// ResourceInstance wraps a resource of one of the managedResources kinds, such as an Instrumentation, to report it
+// in the effective config next to the collectors.
+type ResourceInstance struct {
+	Object  client.Object
+	kind    string
+	managed bool
+}
+
+func newResourceInstance(kind string, object client.Object, managed bool) ResourceInstance {
+	return ResourceInstance{Object: object, kind: kind, managed: managed}
+}
+
+func (r ResourceInstance) GetName() string {
+	return r.Object.GetName()
+}
+
+func (r ResourceInstance) GetNamespace() string {
+	return r.Object.GetNamespace()
+}
+
+func (r ResourceInstance) GetDeletionTimestamp() *metav1.Time {
+	return r.Object.GetDeletionTimestamp()
+}
+
+// IsManaged reports whether the bridge applies remote config to this resource, as opposed to it only being
+// reporting-only.
+func (r ResourceInstance) IsManaged() bool {
+	return r.managed
+}
+
+// GetConfigMap serializes the resource into the OpAMP effective config map using kind/namespace/name as the key.
+func (r ResourceInstance) GetConfigMap() map[string]ConfigFile {
+	key := newKindedKubeResourceKey(r.kind, r.GetNamespace(), r.GetName()).String()
+	marshaled, err := yaml.Marshal(r.Object)
+	if err != nil {
+		return map[string]ConfigFile{key: {}}
+	}
+	return map[string]ConfigFile{
+		key: {
+			Body:        marshaled,
+			ContentType: "yaml",
+		},
+	}
+}
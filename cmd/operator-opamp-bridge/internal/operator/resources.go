@@ -0,0 +1,213 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package operator
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/open-telemetry/opamp-go/protobufs"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+const (
+	InstrumentationResource = "Instrumentation"
+	TargetAllocatorResource = "TargetAllocator"
+)
+
+// managedResource describes a kind, besides OpenTelemetryCollector, the bridge can apply remote config to.
+type managedResource struct {
+	// description names the kind in error messages.
+	description string
+	newObject   func() client.Object
+	newList     func() client.ObjectList
+}
+
+var managedResources = map[string]managedResource{
+	InstrumentationResource: {
+		description: "an instrumentation",
+		newObject:   func() client.Object { return &v1alpha1.Instrumentation{} },
+		newList:     func() client.ObjectList { return &v1alpha1.InstrumentationList{} },
+	},
+	TargetAllocatorResource: {
+		description: "a target allocator",
+		newObject:   func() client.Object { return &v1alpha1.TargetAllocator{} },
+		newList:     func() client.ObjectList { return &v1alpha1.TargetAllocatorList{} },
+	},
+}
+
+// applyResource applies a remote config entry to a resource of one of the managedResources kinds. Only the spec
+// fields set by the remote config are changed, every other field of an existing resource is kept.
+func (c Client) applyResource(resource KubeResourceKey, configmap *protobufs.AgentConfigFile) error {
+	kind := managedResources[resource.kind]
+	if _, ok := c.resourcesAllowed[resource.kind]; !ok {
+		return errors.NewBadRequest(fmt.Sprintf("remote config for the kind %s is not allowed", resource.kind))
+	}
+
+	desired := kind.newObject()
+	if err := yaml.Unmarshal(configmap.Body, desired); err != nil {
+		return errors.NewBadRequest(fmt.Sprintf("failed to unmarshal config into %s: %v", resource.kind, err))
+	}
+	// the typed object can't tell the fields the remote config sets from the ones it leaves out
+	var raw map[string]any
+	if err := yaml.Unmarshal(configmap.Body, &raw); err != nil {
+		return errors.NewBadRequest(fmt.Sprintf("failed to unmarshal config into %s: %v", resource.kind, err))
+	}
+	rawSpec, _ := raw["spec"].(map[string]any)
+
+	ctx := context.Background()
+	current := kind.newObject()
+	exists := true
+	err := c.k8sClient.Get(ctx, client.ObjectKey{Namespace: resource.namespace, Name: resource.name}, current)
+	if errors.IsNotFound(err) {
+		exists = false
+	} else if err != nil {
+		return err
+	}
+
+	// the labels of an existing resource are kept, so only a new resource must carry the managed label itself
+	resourceLabels := current.GetLabels()
+	if !exists {
+		resourceLabels = desired.GetLabels()
+	}
+	if err = c.validateResourceLabels(kind.description, resourceLabels); err != nil {
+		return err
+	}
+
+	currentObject, err := runtime.DefaultUnstructuredConverter.ToUnstructured(current)
+	if err != nil {
+		return err
+	}
+	desiredObject, err := runtime.DefaultUnstructuredConverter.ToUnstructured(desired)
+	if err != nil {
+		return err
+	}
+	currentSpec, _ := currentObject["spec"].(map[string]any)
+	if currentSpec == nil {
+		currentSpec = map[string]any{}
+	}
+	desiredSpec, _ := desiredObject["spec"].(map[string]any)
+	if err = c.validateFields(resource.kind, rawSpec, currentSpec, desiredSpec); err != nil {
+		return err
+	}
+	for field := range rawSpec {
+		currentSpec[field] = desiredSpec[field]
+	}
+	currentObject["spec"] = currentSpec
+	if err = runtime.DefaultUnstructuredConverter.FromUnstructured(currentObject, current); err != nil {
+		return errors.NewBadRequest(fmt.Sprintf("invalid %s config: %v", kind.description, err))
+	}
+
+	if exists {
+		c.log.Info("Updating resource", "kind", resource.kind)
+		return c.k8sClient.Update(ctx, current)
+	}
+
+	current.SetName(resource.name)
+	current.SetNamespace(resource.namespace)
+	resourceLabels = maps.Clone(resourceLabels)
+	resourceLabels[ResourceIdentifierKey] = ResourceIdentifierValue
+	current.SetLabels(resourceLabels)
+	current.GetObjectKind().SetGroupVersionKind(v1alpha1.GroupVersion.WithKind(resource.kind))
+
+	c.log.Info("Creating resource", "kind", resource.kind)
+	return c.k8sClient.Create(ctx, current)
+}
+
+// validateFields rejects remote config changing spec fields that aren't allowed for the kind. A field that's set to
+// its current value is accepted, so the effective config reported for a resource can be sent back as is.
+func (c Client) validateFields(kind string, rawSpec, currentSpec, desiredSpec map[string]any) error {
+	fieldsAllowed := c.resourcesAllowed[kind]
+
+	var invalidFields []string
+	for field := range rawSpec {
+		if _, ok := desiredSpec[field]; !ok {
+			return errors.NewBadRequest(fmt.Sprintf("unknown field in %s config: spec.%s", kind, field))
+		}
+		if len(fieldsAllowed) == 0 || fieldsAllowed[field] {
+			continue
+		}
+		if !apiequality.Semantic.DeepEqual(currentSpec[field], desiredSpec[field]) {
+			invalidFields = append(invalidFields, fmt.Sprintf("spec.%s", field))
+		}
+	}
+
+	if len(invalidFields) > 0 {
+		slices.Sort(invalidFields)
+		return errors.NewBadRequest(fmt.Sprintf("Items in config are not allowed: %v", invalidFields))
+	}
+
+	return nil
+}
+
+func (c Client) deleteResource(resource KubeResourceKey) error {
+	if _, ok := c.resourcesAllowed[resource.kind]; !ok {
+		return errors.NewBadRequest(fmt.Sprintf("remote config for the kind %s is not allowed", resource.kind))
+	}
+	ctx := context.Background()
+	result := managedResources[resource.kind].newObject()
+	err := c.k8sClient.Get(ctx, client.ObjectKey{
+		Namespace: resource.namespace,
+		Name:      resource.name,
+	}, result)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	return c.k8sClient.Delete(ctx, result)
+}
+
+// listResources returns the resources of the allowed kinds managed by this bridge plus the reporting-only ones.
+func (c Client) listResources() ([]ResourceInstance, error) {
+	ctx := context.Background()
+	managedSelector, err := c.managedLabelSelector()
+	if err != nil {
+		return nil, err
+	}
+
+	var result []ResourceInstance
+	for _, kind := range slices.Sorted(maps.Keys(c.resourcesAllowed)) {
+		resource, ok := managedResources[kind]
+		if !ok {
+			continue
+		}
+		for _, listOption := range []struct {
+			option  client.ListOption
+			managed bool
+		}{
+			{option: managedSelector, managed: true},
+			{option: client.MatchingLabels{ReportingLabelKey: "true"}, managed: false},
+		} {
+			list := resource.newList()
+			if err = c.k8sClient.List(ctx, list, listOption.option); err != nil {
+				return nil, err
+			}
+			items, err := meta.ExtractList(list)
+			if err != nil {
+				return nil, err
+			}
+			for _, item := range items {
+				object, ok := item.(client.Object)
+				if !ok {
+					continue
+				}
+				object.SetManagedFields(nil)
+				object.GetObjectKind().SetGroupVersionKind(v1alpha1.GroupVersion.WithKind(kind))
+				result = append(result, newResourceInstance(kind, object, listOption.managed))
+			}
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,480 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package operator
+
+import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"google.golang.org/protobuf/encoding/protojson"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/otelconfig"
+)
+
+const (
+	// telemetrySecretSuffix is appended to the name of a collector to name the Secret holding the credentials of its
+	// telemetry destinations.
+	telemetrySecretSuffix = "-opamp-telemetry"
+	// telemetrySettingsKey is the key of that Secret holding the destinations themselves, so that they're injected
+	// again after the bridge restarts.
+	telemetrySettingsKey = "settings"
+	// telemetryEnvPrefix prefixes the environment variables referencing the headers of the telemetry destinations.
+	telemetryEnvPrefix = "OPAMP_TELEMETRY_"
+	// telemetryVolumeName is the name of the volume mounting the certificates of the telemetry destinations.
+	telemetryVolumeName = "opamp-telemetry"
+	// telemetryMountPath is where the certificates of the telemetry destinations are mounted.
+	telemetryMountPath = "/etc/opamp-telemetry"
+)
+
+// TelemetrySettings are the destinations of the internal telemetry of managed collectors, supplied by the OpAMP
+// server. A signal without a destination is left as configured by the collector's own config.
+type TelemetrySettings struct {
+	Metrics *protobufs.TelemetryConnectionSettings
+	Traces  *protobufs.TelemetryConnectionSettings
+	Logs    *protobufs.TelemetryConnectionSettings
+}
+
+// IsEmpty returns whether none of the signals has a destination.
+func (s TelemetrySettings) IsEmpty() bool {
+	return s.Metrics == nil && s.Traces == nil && s.Logs == nil
+}
+
+// signals returns the destination of each signal by the signal's name.
+func (s *TelemetrySettings) signals() map[string]**protobufs.TelemetryConnectionSettings {
+	return map[string]**protobufs.TelemetryConnectionSettings{
+		"metrics": &s.Metrics,
+		"traces":  &s.Traces,
+		"logs":    &s.Logs,
+	}
+}
+
+// marshalTelemetrySettings encodes settings as a JSON object holding the destination of each signal having one.
+func marshalTelemetrySettings(settings TelemetrySettings) ([]byte, error) {
+	encoded := map[string]json.RawMessage{}
+	for signal, destination := range settings.signals() {
+		if *destination == nil {
+			continue
+		}
+		data, err := protojson.Marshal(*destination)
+		if err != nil {
+			return nil, err
+		}
+		encoded[signal] = data
+	}
+	return json.Marshal(encoded)
+}
+
+// unmarshalTelemetrySettings decodes settings encoded by marshalTelemetrySettings.
+func unmarshalTelemetrySettings(data []byte) (TelemetrySettings, error) {
+	var encoded map[string]json.RawMessage
+	if err := json.Unmarshal(data, &encoded); err != nil {
+		return TelemetrySettings{}, err
+	}
+	settings := TelemetrySettings{}
+	for signal, destination := range settings.signals() {
+		data, ok := encoded[signal]
+		if !ok {
+			continue
+		}
+		*destination = &protobufs.TelemetryConnectionSettings{}
+		if err := protojson.Unmarshal(data, *destination); err != nil {
+			return TelemetrySettings{}, fmt.Errorf("invalid %s destination: %w", signal, err)
+		}
+	}
+	return settings, nil
+}
+
+// TelemetryForwarder is a ConfigApplier that can send the internal telemetry of the managed collectors to destinations
+// supplied by the OpAMP server.
+type TelemetryForwarder interface {
+	ConfigApplier
+
+	// ForwardTelemetry sets the destinations of the internal telemetry of the managed collectors, and updates every
+	// managed collector with them. Collectors applied afterward get them too. Signals without a destination are
+	// withdrawn from the collectors they were forwarded to.
+	ForwardTelemetry(settings TelemetrySettings) error
+
+	// RestoreTelemetry sets the destinations forwarded before the bridge restarted, as saved with the managed
+	// collectors, so that the collectors applied before they're forwarded again keep them, and returns them.
+	RestoreTelemetry() (TelemetrySettings, error)
+}
+
+var (
+	_ TelemetryForwarder = &Client{}
+	_ TelemetryForwarder = &collectorApplier{}
+)
+
+// forwardedTelemetry holds the telemetry destinations of the managed collectors, shared between a Client and its
+// scoped appliers. The destinations under the empty key apply to every collector without its own.
+type forwardedTelemetry struct {
+	mu       sync.Mutex
+	settings map[string]TelemetrySettings
+}
+
+func newForwardedTelemetry() *forwardedTelemetry {
+	return &forwardedTelemetry{settings: map[string]TelemetrySettings{}}
+}
+
+func (f *forwardedTelemetry) set(key string, settings TelemetrySettings) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.settings[key] = settings
+}
+
+func (f *forwardedTelemetry) get(key string) (TelemetrySettings, bool) {
+	if f == nil {
+		return TelemetrySettings{}, false
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if settings, ok := f.settings[key]; ok {
+		return settings, true
+	}
+	settings, ok := f.settings[""]
+	return settings, ok
+}
+
+// ForwardTelemetry sets the telemetry destinations of every managed collector. Collectors that fail to update are
+// skipped, and their errors joined and returned.
+func (c Client) ForwardTelemetry(settings TelemetrySettings) error {
+	c.telemetry.set("", settings)
+	keys, err := c.ListCollectorKeys()
+	if err != nil {
+		return fmt.Errorf("failed to list collectors to forward telemetry to: %w", err)
+	}
+	var errs []error
+	for _, key := range keys {
+		if err := c.forwardTelemetry(key); err != nil {
+			errs = append(errs, fmt.Errorf("failed to forward telemetry to collector %s: %w", key, err))
+		}
+	}
+	return stderrors.Join(errs...)
+}
+
+// ForwardTelemetry sets the telemetry destinations of the collector.
+func (s *collectorApplier) ForwardTelemetry(settings TelemetrySettings) error {
+	s.client.telemetry.set(s.key, settings)
+	return s.client.forwardTelemetry(s.key)
+}
+
+// RestoreTelemetry sets the telemetry destinations of every managed collector to the ones saved with the first
+// collector having some, since they were forwarded to all of them.
+func (c Client) RestoreTelemetry() (TelemetrySettings, error) {
+	keys, err := c.ListCollectorKeys()
+	if err != nil {
+		return TelemetrySettings{}, fmt.Errorf("failed to list collectors to restore telemetry from: %w", err)
+	}
+	slices.Sort(keys)
+	for _, key := range keys {
+		settings, err := c.savedTelemetry(key)
+		if err != nil {
+			return TelemetrySettings{}, fmt.Errorf("failed to restore telemetry of collector %s: %w", key, err)
+		}
+		if !settings.IsEmpty() {
+			c.telemetry.set("", settings)
+			return settings, nil
+		}
+	}
+	return TelemetrySettings{}, nil
+}
+
+// RestoreTelemetry sets the telemetry destinations of the collector to the ones saved with it.
+func (s *collectorApplier) RestoreTelemetry() (TelemetrySettings, error) {
+	settings, err := s.client.savedTelemetry(s.key)
+	if err != nil || settings.IsEmpty() {
+		return TelemetrySettings{}, err
+	}
+	s.client.telemetry.set(s.key, settings)
+	return settings, nil
+}
+
+// savedTelemetry returns the telemetry destinations last injected into the collector identified by a remote config
+// entry name, as saved in its Secret. They're empty if it has none.
+func (c Client) savedTelemetry(key string) (TelemetrySettings, error) {
+	resource, err := kubeResourceFromKey(key)
+	if err != nil {
+		return TelemetrySettings{}, err
+	}
+	secret := &v1.Secret{}
+	err = c.k8sClient.Get(context.Background(), client.ObjectKey{
+		Namespace: resource.namespace,
+		Name:      resource.name + telemetrySecretSuffix,
+	}, secret)
+	if errors.IsNotFound(err) {
+		return TelemetrySettings{}, nil
+	}
+	if err != nil {
+		return TelemetrySettings{}, err
+	}
+	data, ok := secret.Data[telemetrySettingsKey]
+	if !ok {
+		return TelemetrySettings{}, nil
+	}
+	return unmarshalTelemetrySettings(data)
+}
+
+// forwardTelemetry updates the collector identified by a remote config entry name with its telemetry destinations.
+// The destinations previously injected into it for signals that no longer have one are removed.
+func (c Client) forwardTelemetry(key string) error {
+	if _, ok := c.telemetry.get(key); !ok {
+		return nil
+	}
+	resource, err := kubeResourceFromKey(key)
+	if err != nil {
+		return err
+	}
+	instance, err := c.GetInstance(resource.name, resource.namespace)
+	if err != nil || instance == nil {
+		return err
+	}
+	previous, err := c.savedTelemetry(key)
+	if err != nil {
+		return err
+	}
+	updated := instance.DeepCopy()
+	removeTelemetry(updated, previous)
+	if err := c.injectTelemetry(context.Background(), instance, updated); err != nil {
+		return err
+	}
+	if equality.Semantic.DeepEqual(instance.Spec, updated.Spec) {
+		return nil
+	}
+	c.log.Info("Forwarding telemetry settings to collector", "name", instance.Name, "namespace", instance.Namespace)
+	return c.k8sClient.Update(context.Background(), updated)
+}
+
+// injectTelemetry directs the internal telemetry of collector to the destinations of the existing collector owner, if
+// any. The OTLP exporters of the telemetry config are replaced by ones sending to the destinations. Their headers are
+// stored in a Secret owned by owner and referenced through environment variables, and their certificates are mounted
+// from that Secret, which holds the destinations too.
+func (c Client) injectTelemetry(ctx context.Context, owner, collector *v1beta1.OpenTelemetryCollector) error {
+	settings, ok := c.telemetry.get(NewKubeResourceKey(owner.Namespace, owner.Name).String())
+	if !ok {
+		return nil
+	}
+	secret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      owner.Name + telemetrySecretSuffix,
+			Namespace: owner.Namespace,
+			Labels:    map[string]string{ResourceIdentifierKey: ResourceIdentifierValue},
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: v1beta1.GroupVersion.String(),
+				Kind:       CollectorResource,
+				Name:       owner.Name,
+				UID:        owner.UID,
+			}},
+		},
+		Data: map[string][]byte{},
+	}
+	if settings.IsEmpty() {
+		// the withdrawn destinations mustn't be restored
+		return c.saveTelemetrySecret(ctx, secret)
+	}
+	injector := &telemetryInjector{secret: secret}
+
+	service := &collector.Spec.Config.Service
+	if settings.Metrics != nil {
+		// the default Prometheus reader is only added by the operator when there are no readers
+		if _, err := otelconfig.ServiceApplyDefaults(service, c.log); err != nil {
+			return err
+		}
+	}
+	if service.Telemetry == nil {
+		service.Telemetry = &v1beta1.AnyConfig{Object: map[string]any{}}
+	}
+	if service.Telemetry.Object == nil {
+		service.Telemetry.Object = map[string]any{}
+	}
+	telemetry := service.Telemetry.Object
+	if settings.Metrics != nil {
+		exporter := injector.otlpExporter("metrics", settings.Metrics)
+		replaceOTLPEntries(telemetry, "metrics", "readers", "periodic", exporter)
+	}
+	if settings.Traces != nil {
+		exporter := injector.otlpExporter("traces", settings.Traces)
+		replaceOTLPEntries(telemetry, "traces", "processors", "batch", exporter)
+	}
+	if settings.Logs != nil {
+		exporter := injector.otlpExporter("logs", settings.Logs)
+		replaceOTLPEntries(telemetry, "logs", "processors", "batch", exporter)
+	}
+
+	removeTelemetryCredentials(collector)
+	collector.Spec.Env = append(collector.Spec.Env, injector.env...)
+	if injector.mountCertificates {
+		collector.Spec.Volumes = append(collector.Spec.Volumes, v1.Volume{
+			Name:         telemetryVolumeName,
+			VolumeSource: v1.VolumeSource{Secret: &v1.SecretVolumeSource{SecretName: secret.Name}},
+		})
+		collector.Spec.VolumeMounts = append(collector.Spec.VolumeMounts, v1.VolumeMount{
+			Name:      telemetryVolumeName,
+			MountPath: telemetryMountPath,
+			ReadOnly:  true,
+		})
+	}
+
+	data, err := marshalTelemetrySettings(settings)
+	if err != nil {
+		return err
+	}
+	secret.Data[telemetrySettingsKey] = data
+	return c.saveTelemetrySecret(ctx, secret)
+}
+
+// removeTelemetry removes the destinations previously injected into collector. The signals without one are left
+// without their OTLP exporters, which the injected ones replaced.
+func removeTelemetry(collector *v1beta1.OpenTelemetryCollector, previous TelemetrySettings) {
+	if telemetry := collector.Spec.Config.Service.Telemetry; telemetry != nil && telemetry.Object != nil {
+		if previous.Metrics != nil {
+			removeOTLPEntries(telemetry.Object, "metrics", "readers")
+		}
+		if previous.Traces != nil {
+			removeOTLPEntries(telemetry.Object, "traces", "processors")
+		}
+		if previous.Logs != nil {
+			removeOTLPEntries(telemetry.Object, "logs", "processors")
+		}
+	}
+	removeTelemetryCredentials(collector)
+}
+
+// removeTelemetryCredentials removes the environment variables and the volume referencing the Secret of the telemetry
+// destinations from collector.
+func removeTelemetryCredentials(collector *v1beta1.OpenTelemetryCollector) {
+	collector.Spec.Env = slices.DeleteFunc(collector.Spec.Env, func(env v1.EnvVar) bool {
+		return strings.HasPrefix(env.Name, telemetryEnvPrefix)
+	})
+	collector.Spec.Volumes = slices.DeleteFunc(collector.Spec.Volumes, func(volume v1.Volume) bool {
+		return volume.Name == telemetryVolumeName
+	})
+	collector.Spec.VolumeMounts = slices.DeleteFunc(collector.Spec.VolumeMounts, func(mount v1.VolumeMount) bool {
+		return mount.Name == telemetryVolumeName
+	})
+}
+
+// saveTelemetrySecret creates the Secret of the telemetry destinations, or replaces its data. A Secret without data
+// isn't created.
+func (c Client) saveTelemetrySecret(ctx context.Context, secret *v1.Secret) error {
+	existing := &v1.Secret{}
+	err := c.k8sClient.Get(ctx, client.ObjectKeyFromObject(secret), existing)
+	if errors.IsNotFound(err) {
+		if len(secret.Data) == 0 {
+			return nil
+		}
+		return c.k8sClient.Create(ctx, secret)
+	}
+	if err != nil {
+		return err
+	}
+	if equality.Semantic.DeepEqual(existing.Data, secret.Data) {
+		return nil
+	}
+	existing.Data = secret.Data
+	return c.k8sClient.Update(ctx, existing)
+}
+
+// telemetryInjector builds the OTLP exporters of the telemetry destinations of a collector, collecting their
+// credentials in its Secret.
+type telemetryInjector struct {
+	secret            *v1.Secret
+	env               []v1.EnvVar
+	mountCertificates bool
+}
+
+// otlpExporter returns the OTLP/HTTP exporter of a signal of the collector's telemetry config sending to settings.
+func (t *telemetryInjector) otlpExporter(signal string, settings *protobufs.TelemetryConnectionSettings) map[string]any {
+	exporter := map[string]any{
+		"protocol": "http/protobuf",
+		"endpoint": settings.GetDestinationEndpoint(),
+	}
+	var headers []any
+	for i, header := range settings.GetHeaders().GetHeaders() {
+		secretKey := fmt.Sprintf("%s-header-%d", signal, i)
+		envName := fmt.Sprintf("%s%s_HEADER_%d", telemetryEnvPrefix, strings.ToUpper(signal), i)
+		t.secret.Data[secretKey] = []byte(header.GetValue())
+		t.env = append(t.env, v1.EnvVar{
+			Name: envName,
+			ValueFrom: &v1.EnvVarSource{SecretKeyRef: &v1.SecretKeySelector{
+				LocalObjectReference: v1.LocalObjectReference{Name: t.secret.Name},
+				Key:                  secretKey,
+			}},
+		})
+		headers = append(headers, map[string]any{
+			"name":  header.GetKey(),
+			"value": fmt.Sprintf("${env:%s}", envName),
+		})
+	}
+	if len(headers) > 0 {
+		exporter["headers"] = headers
+	}
+	certificate := settings.GetCertificate()
+	for field, file := range map[string]struct {
+		name string
+		data []byte
+	}{
+		"certificate":        {signal + "-ca.crt", certificate.GetCaCert()},
+		"client_certificate": {signal + "-tls.crt", certificate.GetCert()},
+		"client_key":         {signal + "-tls.key", certificate.GetPrivateKey()},
+	} {
+		if len(file.data) == 0 {
+			continue
+		}
+		t.secret.Data[file.name] = file.data
+		t.mountCertificates = true
+		exporter[field] = path.Join(telemetryMountPath, file.name)
+	}
+	return exporter
+}
+
+// replaceOTLPEntries replaces the entries of telemetry.<signal>.<list> exporting through OTLP with a single one of the
+// given kind, exporting through exporter.
+func replaceOTLPEntries(telemetry map[string]any, signal, list, kind string, exporter map[string]any) {
+	removeOTLPEntries(telemetry, signal, list)
+	signalConfig, ok := telemetry[signal].(map[string]any)
+	if !ok {
+		signalConfig = map[string]any{}
+		telemetry[signal] = signalConfig
+	}
+	entries, _ := signalConfig[list].([]any)
+	signalConfig[list] = append(entries, map[string]any{
+		kind: map[string]any{
+			"exporter": map[string]any{"otlp": exporter},
+		},
+	})
+}
+
+// removeOTLPEntries removes the entries of telemetry.<signal>.<list> exporting through OTLP.
+func removeOTLPEntries(telemetry map[string]any, signal, list string) {
+	signalConfig := asMap(telemetry[signal])
+	entries, ok := signalConfig[list].([]any)
+	if !ok {
+		return
+	}
+	signalConfig[list] = slices.DeleteFunc(slices.Clone(entries), func(entry any) bool {
+		for _, kindConfig := range asMap(entry) {
+			if _, ok := asMap(asMap(kindConfig)["exporter"])["otlp"]; ok {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+func asMap(value any) map[string]any {
+	m, _ := value.(map[string]any)
+	return m
+}
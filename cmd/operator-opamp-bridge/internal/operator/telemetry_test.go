@@ -0,0 +1,236 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package operator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	v1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+)
+
+func metricsDestination(endpoint string) *protobufs.TelemetryConnectionSettings {
+	return &protobufs.TelemetryConnectionSettings{
+		DestinationEndpoint: endpoint,
+		Headers: &protobufs.Headers{Headers: []*protobufs.Header{
+			{Key: "Authorization", Value: "Bearer secret-token"},
+		}},
+		Certificate: &protobufs.TLSCertificate{CaCert: []byte("ca")},
+	}
+}
+
+// telemetryEntries returns the entries of telemetry.<signal>.<list> of the collector.
+func telemetryEntries(t *testing.T, col *v1beta1.OpenTelemetryCollector, signal, list string) []any {
+	require.NotNil(t, col.Spec.Config.Service.Telemetry)
+	signalConfig, ok := col.Spec.Config.Service.Telemetry.Object[signal].(map[string]any)
+	require.True(t, ok, "the telemetry config must have %s", signal)
+	entries, ok := signalConfig[list].([]any)
+	require.True(t, ok, "the %s telemetry config must have %s", signal, list)
+	return entries
+}
+
+func TestClient_ForwardTelemetry(t *testing.T) {
+	fakeClient := getFakeClient(t)
+	require.NoError(t, fakeClient.Create(context.Background(), managedCollector("test-col", v1beta1.ModeDeployment)))
+	c := NewClient(bridgeName, clientLogger, fakeClient, nil, nil)
+
+	settings := TelemetrySettings{
+		Metrics: metricsDestination("https://telemetry.example.com/v1/metrics"),
+		Traces:  &protobufs.TelemetryConnectionSettings{DestinationEndpoint: "https://telemetry.example.com/v1/traces"},
+	}
+	require.NoError(t, c.ForwardTelemetry(settings))
+
+	col, err := c.GetInstance("test-col", "default")
+	require.NoError(t, err)
+	readers := telemetryEntries(t, col, "metrics", "readers")
+	require.Len(t, readers, 2, "the default Prometheus reader must be kept")
+	assert.Contains(t, readers[0], "pull")
+	assert.Equal(t, map[string]any{
+		"periodic": map[string]any{
+			"exporter": map[string]any{
+				"otlp": map[string]any{
+					"protocol":    "http/protobuf",
+					"endpoint":    "https://telemetry.example.com/v1/metrics",
+					"certificate": "/etc/opamp-telemetry/metrics-ca.crt",
+					"headers": []any{map[string]any{
+						"name":  "Authorization",
+						"value": "${env:OPAMP_TELEMETRY_METRICS_HEADER_0}",
+					}},
+				},
+			},
+		},
+	}, readers[1])
+	processors := telemetryEntries(t, col, "traces", "processors")
+	require.Len(t, processors, 1)
+	assert.Equal(t, map[string]any{
+		"batch": map[string]any{
+			"exporter": map[string]any{
+				"otlp": map[string]any{
+					"protocol": "http/protobuf",
+					"endpoint": "https://telemetry.example.com/v1/traces",
+				},
+			},
+		},
+	}, processors[0])
+	assert.NotContains(t, col.Spec.Config.Service.Telemetry.Object, "logs")
+
+	// the credentials are stored in a Secret owned by the collector
+	require.Len(t, col.Spec.Env, 1)
+	assert.Equal(t, "OPAMP_TELEMETRY_METRICS_HEADER_0", col.Spec.Env[0].Name)
+	assert.Equal(t, &v1.SecretKeySelector{
+		LocalObjectReference: v1.LocalObjectReference{Name: "test-col-opamp-telemetry"},
+		Key:                  "metrics-header-0",
+	}, col.Spec.Env[0].ValueFrom.SecretKeyRef)
+	require.Len(t, col.Spec.Volumes, 1)
+	assert.Equal(t, "test-col-opamp-telemetry", col.Spec.Volumes[0].Secret.SecretName)
+	require.Len(t, col.Spec.VolumeMounts, 1)
+	assert.Equal(t, "/etc/opamp-telemetry", col.Spec.VolumeMounts[0].MountPath)
+	secret := &v1.Secret{}
+	require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKey{Namespace: "default", Name: "test-col-opamp-telemetry"}, secret))
+	assert.Equal(t, []byte("Bearer secret-token"), secret.Data["metrics-header-0"])
+	assert.Equal(t, []byte("ca"), secret.Data["metrics-ca.crt"])
+	require.Len(t, secret.OwnerReferences, 1)
+	assert.Equal(t, "test-col", secret.OwnerReferences[0].Name)
+	// along with the destinations
+	saved, err := unmarshalTelemetrySettings(secret.Data["settings"])
+	require.NoError(t, err)
+	assert.True(t, proto.Equal(settings.Metrics, saved.Metrics))
+	assert.True(t, proto.Equal(settings.Traces, saved.Traces))
+	assert.Nil(t, saved.Logs)
+
+	// new destinations replace the previous ones
+	settings.Metrics = &protobufs.TelemetryConnectionSettings{DestinationEndpoint: "https://other.example.com/v1/metrics"}
+	require.NoError(t, c.ForwardTelemetry(settings))
+	col, err = c.GetInstance("test-col", "default")
+	require.NoError(t, err)
+	readers = telemetryEntries(t, col, "metrics", "readers")
+	require.Len(t, readers, 2)
+	assert.Equal(t, "https://other.example.com/v1/metrics", readers[1].(map[string]any)["periodic"].(map[string]any)["exporter"].(map[string]any)["otlp"].(map[string]any)["endpoint"])
+	assert.Empty(t, col.Spec.Env)
+	assert.Empty(t, col.Spec.Volumes)
+	assert.Empty(t, col.Spec.VolumeMounts)
+}
+
+func TestClient_ForwardTelemetryWithdrawsDestinations(t *testing.T) {
+	fakeClient := getFakeClient(t)
+	require.NoError(t, fakeClient.Create(context.Background(), managedCollector("test-col", v1beta1.ModeDeployment)))
+	c := NewClient(bridgeName, clientLogger, fakeClient, nil, nil)
+
+	require.NoError(t, c.ForwardTelemetry(TelemetrySettings{
+		Metrics: metricsDestination("https://telemetry.example.com/v1/metrics"),
+		Traces:  &protobufs.TelemetryConnectionSettings{DestinationEndpoint: "https://telemetry.example.com/v1/traces"},
+	}))
+
+	// the withdrawn traces destination is removed
+	require.NoError(t, c.ForwardTelemetry(TelemetrySettings{
+		Metrics: metricsDestination("https://telemetry.example.com/v1/metrics"),
+	}))
+	col, err := c.GetInstance("test-col", "default")
+	require.NoError(t, err)
+	assert.Len(t, telemetryEntries(t, col, "metrics", "readers"), 2)
+	assert.Empty(t, telemetryEntries(t, col, "traces", "processors"))
+	assert.Len(t, col.Spec.Env, 1)
+
+	// as well as the credentials of the withdrawn metrics destination
+	require.NoError(t, c.ForwardTelemetry(TelemetrySettings{}))
+	col, err = c.GetInstance("test-col", "default")
+	require.NoError(t, err)
+	readers := telemetryEntries(t, col, "metrics", "readers")
+	require.Len(t, readers, 1)
+	assert.Contains(t, readers[0], "pull")
+	assert.Empty(t, col.Spec.Env)
+	assert.Empty(t, col.Spec.Volumes)
+	assert.Empty(t, col.Spec.VolumeMounts)
+
+	// and they aren't restored
+	secret := &v1.Secret{}
+	require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKey{Namespace: "default", Name: "test-col-opamp-telemetry"}, secret))
+	assert.Empty(t, secret.Data)
+	restored, err := NewClient(bridgeName, clientLogger, fakeClient, nil, nil).RestoreTelemetry()
+	require.NoError(t, err)
+	assert.True(t, restored.IsEmpty())
+}
+
+func TestClient_RestoreTelemetry(t *testing.T) {
+	fakeClient := getFakeClient(t)
+	c := NewClient(bridgeName, clientLogger, fakeClient, nil, nil)
+	settings := TelemetrySettings{Metrics: metricsDestination("https://telemetry.example.com/v1/metrics")}
+	require.NoError(t, c.ForwardTelemetry(settings))
+	colConfig, err := loadConfig("testdata/collector.yaml")
+	require.NoError(t, err)
+	configFile := &protobufs.AgentConfigFile{Body: colConfig, ContentType: "yaml"}
+	key := NewKubeResourceKey("default", "test").String()
+	require.NoError(t, c.Apply(key, configFile))
+
+	// after a restart, the collector keeps its destinations when applied again
+	restarted := NewClient(bridgeName, clientLogger, fakeClient, nil, nil)
+	restored, err := restarted.RestoreTelemetry()
+	require.NoError(t, err)
+	assert.True(t, proto.Equal(settings.Metrics, restored.Metrics))
+	require.NoError(t, restarted.Apply(key, configFile))
+	col, err := restarted.GetInstance("test", "default")
+	require.NoError(t, err)
+	assert.Len(t, telemetryEntries(t, col, "metrics", "readers"), 2)
+	assert.Len(t, col.Spec.Env, 1)
+
+	// the destinations of a collector are restored by its scoped applier
+	forwarder, ok := NewClient(bridgeName, clientLogger, fakeClient, nil, nil).ScopedApplier(key).(TelemetryForwarder)
+	require.True(t, ok)
+	restored, err = forwarder.RestoreTelemetry()
+	require.NoError(t, err)
+	assert.True(t, proto.Equal(settings.Metrics, restored.Metrics))
+}
+
+func TestClient_ApplyForwardsTelemetry(t *testing.T) {
+	fakeClient := getFakeClient(t)
+	c := NewClient(bridgeName, clientLogger, fakeClient, nil, nil)
+	require.NoError(t, c.ForwardTelemetry(TelemetrySettings{Metrics: metricsDestination("https://telemetry.example.com/v1/metrics")}))
+
+	colConfig, err := loadConfig("testdata/collector.yaml")
+	require.NoError(t, err)
+	configFile := &protobufs.AgentConfigFile{Body: colConfig, ContentType: "yaml"}
+	key := NewKubeResourceKey("default", "test").String()
+
+	// a created collector gets the destinations
+	require.NoError(t, c.Apply(key, configFile))
+	col, err := c.GetInstance("test", "default")
+	require.NoError(t, err)
+	assert.Len(t, telemetryEntries(t, col, "metrics", "readers"), 2)
+	assert.Len(t, col.Spec.Env, 1)
+
+	// and keeps them when updated
+	require.NoError(t, c.Apply(key, configFile))
+	col, err = c.GetInstance("test", "default")
+	require.NoError(t, err)
+	assert.Len(t, telemetryEntries(t, col, "metrics", "readers"), 2)
+	assert.Len(t, col.Spec.Env, 1)
+}
+
+func TestCollectorApplier_ForwardTelemetry(t *testing.T) {
+	fakeClient := getFakeClient(t)
+	for _, name := range []string{"test-col", "other-col"} {
+		require.NoError(t, fakeClient.Create(context.Background(), managedCollector(name, v1beta1.ModeDeployment)))
+	}
+	c := NewClient(bridgeName, clientLogger, fakeClient, nil, nil)
+	forwarder, ok := c.ScopedApplier("default/test-col").(TelemetryForwarder)
+	require.True(t, ok)
+
+	require.NoError(t, forwarder.ForwardTelemetry(TelemetrySettings{
+		Logs: &protobufs.TelemetryConnectionSettings{DestinationEndpoint: "https://telemetry.example.com/v1/logs"},
+	}))
+
+	col, err := c.GetInstance("test-col", "default")
+	require.NoError(t, err)
+	assert.Len(t, telemetryEntries(t, col, "logs", "processors"), 1)
+	other, err := c.GetInstance("other-col", "default")
+	require.NoError(t, err)
+	assert.Nil(t, other.Spec.Config.Service.Telemetry, "the destinations of a collector must not be forwarded to others")
+}
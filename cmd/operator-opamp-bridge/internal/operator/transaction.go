@@ -0,0 +1,102 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package operator
+
+import (
+	"context"
+
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+)
+
+// TransactionalConfigApplier is a ConfigApplier that can check remote config entries without applying them, and
+// revert the entries it applied, so that a remote config can be applied atomically.
+type TransactionalConfigApplier interface {
+	ConfigApplier
+
+	// Validate checks that the remote config entry would be applied, without applying it.
+	Validate(key string, configmap *protobufs.AgentConfigFile) error
+
+	// Snapshot records the current state of the resource identified by a remote config entry name, and returns a
+	// function restoring it.
+	Snapshot(key string) (func() error, error)
+}
+
+var _ TransactionalConfigApplier = &Client{}
+
+// Validate applies the remote config entry with a dry-run, so the API server runs its validating and mutating
+// webhooks on it without persisting it.
+func (c Client) Validate(key string, configmap *protobufs.AgentConfigFile) error {
+	dryRun := c
+	dryRun.k8sClient = client.NewDryRunClient(c.k8sClient)
+	return dryRun.Apply(key, configmap)
+}
+
+// Snapshot records the spec of the resource identified by key. The returned function restores that spec, recreates
+// the resource if it was deleted meanwhile, or deletes it if it didn't exist when the snapshot was taken.
+func (c Client) Snapshot(key string) (func() error, error) {
+	resource, err := kubeResourceFromKey(key)
+	if err != nil {
+		return nil, err
+	}
+	ctx := context.Background()
+	objectKey := client.ObjectKey{Namespace: resource.namespace, Name: resource.name}
+	snapshot := newObjectForKey(resource)
+	err = c.k8sClient.Get(ctx, objectKey, snapshot)
+	if errors.IsNotFound(err) {
+		return func() error {
+			return c.Delete(key)
+		}, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	return func() error {
+		current := newObjectForKey(resource)
+		err := c.k8sClient.Get(ctx, objectKey, current)
+		if errors.IsNotFound(err) {
+			recreated := snapshot.DeepCopyObject().(client.Object)
+			recreated.SetResourceVersion("")
+			recreated.SetUID("")
+			recreated.SetCreationTimestamp(metav1.Time{})
+			recreated.SetManagedFields(nil)
+			c.log.Info("Recreating deleted resource", "key", key)
+			return c.k8sClient.Create(ctx, recreated)
+		} else if err != nil {
+			return err
+		}
+		if err = copySpec(current, snapshot); err != nil {
+			return err
+		}
+		c.log.Info("Reverting resource", "key", key)
+		return c.k8sClient.Update(ctx, current)
+	}, nil
+}
+
+// newObjectForKey returns an empty object of the kind of the resource identified by key.
+func newObjectForKey(resource KubeResourceKey) client.Object {
+	if resource.kind == "" {
+		return &v1beta1.OpenTelemetryCollector{}
+	}
+	return managedResources[resource.kind].newObject()
+}
+
+// copySpec sets the spec of dst to the spec of src, which must be of the same kind.
+func copySpec(dst, src client.Object) error {
+	srcObject, err := runtime.DefaultUnstructuredConverter.ToUnstructured(src)
+	if err != nil {
+		return err
+	}
+	dstObject, err := runtime.DefaultUnstructuredConverter.ToUnstructured(dst)
+	if err != nil {
+		return err
+	}
+	dstObject["spec"] = srcObject["spec"]
+	return runtime.DefaultUnstructuredConverter.FromUnstructured(dstObject, dst)
+}
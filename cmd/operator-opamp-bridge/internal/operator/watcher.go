@@ -0,0 +1,108 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package operator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/rest"
+	toolscache "k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+)
+
+// CollectorWatcher watches the collectors managed by the bridge, and calls onChange with the remote config entry name
+// and the spec hash of each one added, updated or deleted, as seen by the informer. The hash is empty for a deleted
+// collector.
+type CollectorWatcher struct {
+	log        logr.Logger
+	restCfg    *rest.Config
+	scheme     *runtime.Scheme
+	bridgeName string
+	onChange   func(key, hash string)
+}
+
+func NewCollectorWatcher(log logr.Logger, restCfg *rest.Config, scheme *runtime.Scheme, bridgeName string, onChange func(key, hash string)) *CollectorWatcher {
+	return &CollectorWatcher{
+		log:        log,
+		restCfg:    restCfg,
+		scheme:     scheme,
+		bridgeName: bridgeName,
+		onChange:   onChange,
+	}
+}
+
+// Start creates an informer cache for the managed collectors, and waits for it to sync.
+func (w *CollectorWatcher) Start(ctx context.Context) error {
+	selector, err := managedSelector(w.bridgeName)
+	if err != nil {
+		return err
+	}
+	ca, err := cache.New(w.restCfg, cache.Options{
+		Scheme: w.scheme,
+		ByObject: map[client.Object]cache.ByObject{
+			&v1beta1.OpenTelemetryCollector{}: {Label: selector},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create collector cache: %w", err)
+	}
+
+	informer, err := ca.GetInformer(ctx, &v1beta1.OpenTelemetryCollector{})
+	if err != nil {
+		return fmt.Errorf("failed to get OpenTelemetryCollector informer: %w", err)
+	}
+	handler := toolscache.ResourceEventHandlerFuncs{
+		AddFunc:    func(obj any) { w.notifyChange(obj, false) },
+		UpdateFunc: func(_, newObj any) { w.notifyChange(newObj, false) },
+		DeleteFunc: func(obj any) { w.notifyChange(obj, true) },
+	}
+	if _, err = informer.AddEventHandler(handler); err != nil {
+		return fmt.Errorf("failed to add OpenTelemetryCollector event handler: %w", err)
+	}
+
+	go func() {
+		if err := ca.Start(ctx); err != nil {
+			if errors.Is(err, context.Canceled) {
+				return
+			}
+			w.log.Error(err, "collector cache stopped with error")
+		}
+	}()
+
+	if !ca.WaitForCacheSync(ctx) {
+		return errors.New("timed out waiting for OpenTelemetryCollector cache to sync")
+	}
+	w.log.Info("collector informer cache synced")
+	return nil
+}
+
+// notifyChange extracts a collector from an informer event, including delete tombstones, and calls onChange with its
+// remote config entry name and spec hash, empty when it's deleted or being deleted.
+func (w *CollectorWatcher) notifyChange(obj any, deleted bool) {
+	if tombstone, ok := obj.(toolscache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	col, ok := obj.(*v1beta1.OpenTelemetryCollector)
+	if !ok {
+		return
+	}
+	key := NewKubeResourceKey(col.GetNamespace(), col.GetName()).String()
+	if deleted || col.GetDeletionTimestamp() != nil {
+		w.onChange(key, "")
+		return
+	}
+	hash, err := specHash(col)
+	if err != nil {
+		w.log.Error(err, "failed to hash the spec of the collector", "key", key)
+		return
+	}
+	w.onChange(key, hash)
+}
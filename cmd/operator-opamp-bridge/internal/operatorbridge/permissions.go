@@ -3,11 +3,18 @@
 
 package operatorbridge
 
-import bridgemanager "github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/internal/manager"
+import (
+	"maps"
+	"slices"
+	"strings"
+
+	bridgemanager "github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/internal/manager"
+)
 
 // ListRequiredPermissions returns the Kubernetes permissions needed to run the bridge in operator mode.
-func ListRequiredPermissions() ([]bridgemanager.Permission, error) {
-	return []bridgemanager.Permission{
+// resourcesAllowed holds the kinds besides OpenTelemetryCollector the bridge accepts remote config for.
+func ListRequiredPermissions(resourcesAllowed map[string][]string) ([]bridgemanager.Permission, error) {
+	perms := []bridgemanager.Permission{
 		{Verb: "get", APIGroup: "opentelemetry.io", Resource: "opentelemetrycollectors"},
 		{Verb: "list", APIGroup: "opentelemetry.io", Resource: "opentelemetrycollectors"},
 		{Verb: "create", APIGroup: "opentelemetry.io", Resource: "opentelemetrycollectors"},
@@ -21,5 +28,13 @@ func ListRequiredPermissions() ([]bridgemanager.Permission, error) {
 		{Verb: "patch", APIGroup: "apps", Resource: "daemonsets"},
 		{Verb: "get", APIGroup: "apps", Resource: "statefulsets"},
 		{Verb: "patch", APIGroup: "apps", Resource: "statefulsets"},
-	}, nil
+	}
+	for _, kind := range slices.Sorted(maps.Keys(resourcesAllowed)) {
+		// the kinds are Instrumentation and TargetAllocator, whose resource names are their lowercase plurals
+		resource := strings.ToLower(kind) + "s"
+		for _, verb := range []string{"get", "list", "create", "update", "delete"} {
+			perms = append(perms, bridgemanager.Permission{Verb: verb, APIGroup: "opentelemetry.io", Resource: resource})
+		}
+	}
+	return perms, nil
 }
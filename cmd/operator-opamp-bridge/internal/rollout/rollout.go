@@ -10,6 +10,9 @@ import (
 	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -86,3 +89,62 @@ func patchStatefulSet(ctx context.Context, k8sClient client.Client, namespace, n
 	}
 	return nil
 }
+
+// Status returns the pod template of the named workload, and whether its rollout is complete, as checked by
+// `kubectl rollout status`: the workload controller observed its latest spec, and every replica is updated and
+// available. It returns an error when the rollout of a Deployment exceeded its progress deadline.
+func Status(ctx context.Context, k8sClient client.Client, namespace, workloadType, workloadName string) (corev1.PodTemplateSpec, bool, error) {
+	key := client.ObjectKey{Name: workloadName, Namespace: namespace}
+	switch strings.ToLower(workloadType) {
+	case "deployment":
+		deploy := &appsv1.Deployment{}
+		if err := k8sClient.Get(ctx, key, deploy); err != nil {
+			return corev1.PodTemplateSpec{}, false, fmt.Errorf("failed to get Deployment %s/%s: %w", namespace, workloadName, err)
+		}
+		for _, condition := range deploy.Status.Conditions {
+			if condition.Type == appsv1.DeploymentProgressing && condition.Reason == "ProgressDeadlineExceeded" {
+				return deploy.Spec.Template, false, fmt.Errorf("the rollout of Deployment %s/%s exceeded its progress deadline: %s", namespace, workloadName, condition.Message)
+			}
+		}
+		replicas := ptr.Deref(deploy.Spec.Replicas, 1)
+		done := deploy.Status.ObservedGeneration >= deploy.Generation &&
+			deploy.Status.UpdatedReplicas == replicas &&
+			deploy.Status.Replicas == replicas &&
+			deploy.Status.AvailableReplicas == replicas
+		return deploy.Spec.Template, done, nil
+	case "daemonset":
+		ds := &appsv1.DaemonSet{}
+		if err := k8sClient.Get(ctx, key, ds); err != nil {
+			return corev1.PodTemplateSpec{}, false, fmt.Errorf("failed to get DaemonSet %s/%s: %w", namespace, workloadName, err)
+		}
+		done := ds.Status.ObservedGeneration >= ds.Generation &&
+			ds.Status.UpdatedNumberScheduled == ds.Status.DesiredNumberScheduled &&
+			ds.Status.NumberAvailable == ds.Status.DesiredNumberScheduled
+		return ds.Spec.Template, done, nil
+	case "statefulset":
+		sts := &appsv1.StatefulSet{}
+		if err := k8sClient.Get(ctx, key, sts); err != nil {
+			return corev1.PodTemplateSpec{}, false, fmt.Errorf("failed to get StatefulSet %s/%s: %w", namespace, workloadName, err)
+		}
+		replicas := ptr.Deref(sts.Spec.Replicas, 1)
+		done := sts.Status.ObservedGeneration >= sts.Generation &&
+			sts.Status.UpdatedReplicas == replicas &&
+			sts.Status.ReadyReplicas == replicas
+		return sts.Spec.Template, done, nil
+	default:
+		return corev1.PodTemplateSpec{}, false, fmt.Errorf("unsupported workload type %q for rollout status", workloadType)
+	}
+}
+
+// WaitForRollout polls the rollout status of the named workload every interval until the rollout completes. It returns
+// an error when the rollout fails, or ctx is done before it completes.
+func WaitForRollout(ctx context.Context, k8sClient client.Client, namespace, workloadType, workloadName string, interval time.Duration) error {
+	err := wait.PollUntilContextCancel(ctx, interval, true, func(ctx context.Context) (bool, error) {
+		_, done, err := Status(ctx, k8sClient, namespace, workloadType, workloadName)
+		return done, err
+	})
+	if err != nil && wait.Interrupted(err) {
+		return fmt.Errorf("timed out waiting for the rollout of %s %s/%s: %w", workloadType, namespace, workloadName, err)
+	}
+	return err
+}
@@ -14,6 +14,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
@@ -117,3 +118,93 @@ func TestTriggerRollout_NotFound(t *testing.T) {
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "failed to get Deployment")
 }
+
+func TestStatus_Deployment(t *testing.T) {
+	deploy := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: testName, Namespace: testNamespace, Generation: 2},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: ptr.To(int32(2)),
+			Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "otc-container", Image: "collector:0.2.0"}}}},
+		},
+		Status: appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 3, UpdatedReplicas: 1, AvailableReplicas: 2},
+	}
+	k8s := fake.NewClientBuilder().WithScheme(newScheme(t)).WithObjects(deploy).Build()
+
+	template, done, err := rollout.Status(context.Background(), k8s, testNamespace, "Deployment", testName)
+	require.NoError(t, err)
+	assert.False(t, done, "old replicas are still running")
+	assert.Equal(t, "collector:0.2.0", template.Spec.Containers[0].Image)
+
+	deploy.Status = appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 2, UpdatedReplicas: 2, AvailableReplicas: 2}
+	k8s = fake.NewClientBuilder().WithScheme(newScheme(t)).WithObjects(deploy).Build()
+	_, done, err = rollout.Status(context.Background(), k8s, testNamespace, "deployment", testName)
+	require.NoError(t, err)
+	assert.True(t, done)
+}
+
+func TestStatus_DeploymentProgressDeadlineExceeded(t *testing.T) {
+	deploy := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: testName, Namespace: testNamespace},
+		Status: appsv1.DeploymentStatus{Conditions: []appsv1.DeploymentCondition{{
+			Type:    appsv1.DeploymentProgressing,
+			Status:  corev1.ConditionFalse,
+			Reason:  "ProgressDeadlineExceeded",
+			Message: `ReplicaSet "test-workload-123" has timed out progressing.`,
+		}}},
+	}
+	k8s := fake.NewClientBuilder().WithScheme(newScheme(t)).WithObjects(deploy).Build()
+
+	_, done, err := rollout.Status(context.Background(), k8s, testNamespace, "deployment", testName)
+	assert.False(t, done)
+	assert.ErrorContains(t, err, "exceeded its progress deadline")
+}
+
+func TestStatus_DaemonSet(t *testing.T) {
+	ds := &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{Name: testName, Namespace: testNamespace, Generation: 3},
+		Status:     appsv1.DaemonSetStatus{ObservedGeneration: 2, DesiredNumberScheduled: 2, UpdatedNumberScheduled: 2, NumberAvailable: 2},
+	}
+	k8s := fake.NewClientBuilder().WithScheme(newScheme(t)).WithObjects(ds).Build()
+
+	_, done, err := rollout.Status(context.Background(), k8s, testNamespace, "daemonset", testName)
+	require.NoError(t, err)
+	assert.False(t, done, "the latest spec isn't observed yet")
+}
+
+func TestStatus_StatefulSet(t *testing.T) {
+	sts := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: testName, Namespace: testNamespace},
+		Status:     appsv1.StatefulSetStatus{UpdatedReplicas: 1, ReadyReplicas: 1},
+	}
+	k8s := fake.NewClientBuilder().WithScheme(newScheme(t)).WithObjects(sts).Build()
+
+	_, done, err := rollout.Status(context.Background(), k8s, testNamespace, "statefulset", testName)
+	require.NoError(t, err)
+	assert.True(t, done)
+}
+
+func TestStatus_UnknownType(t *testing.T) {
+	k8s := fake.NewClientBuilder().WithScheme(newScheme(t)).Build()
+	_, _, err := rollout.Status(context.Background(), k8s, testNamespace, "cronjob", testName)
+	assert.ErrorContains(t, err, "unsupported workload type")
+}
+
+func TestWaitForRollout(t *testing.T) {
+	deploy := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: testName, Namespace: testNamespace},
+		Status:     appsv1.DeploymentStatus{Replicas: 1, UpdatedReplicas: 1, AvailableReplicas: 1},
+	}
+	k8s := fake.NewClientBuilder().WithScheme(newScheme(t)).WithObjects(deploy).Build()
+
+	require.NoError(t, rollout.WaitForRollout(context.Background(), k8s, testNamespace, "deployment", testName, time.Millisecond))
+}
+
+func TestWaitForRollout_Timeout(t *testing.T) {
+	deploy := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: testName, Namespace: testNamespace}}
+	k8s := fake.NewClientBuilder().WithScheme(newScheme(t)).WithObjects(deploy).Build()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	err := rollout.WaitForRollout(ctx, k8s, testNamespace, "deployment", testName, 10*time.Millisecond)
+	assert.ErrorContains(t, err, "timed out waiting for the rollout of deployment test-ns/test-workload")
+}
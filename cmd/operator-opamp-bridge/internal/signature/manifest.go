@@ -0,0 +1,47 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package signature
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ManifestEntry names the remote config entry holding the manifest. Its signature is held in the sibling entry named
+// after it with the EntrySuffix.
+const ManifestEntry = "opamp-bridge.manifest"
+
+// Manifest lists every entry of a signed remote config with the digest of its body, so that its signature binds the
+// entry names and the whole set of entries, including their removal. Version increases with each remote config, so
+// that an older one can't be replayed.
+type Manifest struct {
+	// Version of the remote config, which must be greater than the version of the previously applied one.
+	Version uint64 `json:"version"`
+	// Expires is the time after which the remote config must not be applied anymore, bounding replays across restarts.
+	Expires *time.Time `json:"expires,omitempty"`
+	// Entries maps the name of each entry to the hex encoded SHA-256 digest of its body.
+	Entries map[string]string `json:"entries"`
+}
+
+// ParseManifest parses a JSON encoded manifest.
+func ParseManifest(body []byte) (*Manifest, error) {
+	manifest := &Manifest{}
+	if err := json.Unmarshal(body, manifest); err != nil {
+		return nil, fmt.Errorf("the manifest is invalid: %w", err)
+	}
+	if manifest.Version == 0 {
+		return nil, errors.New("the manifest has no version")
+	}
+	return manifest, nil
+}
+
+// Digest returns the hex encoded SHA-256 digest of an entry body, as listed in a manifest.
+func Digest(body []byte) string {
+	digest := sha256.Sum256(body)
+	return hex.EncodeToString(digest[:])
+}
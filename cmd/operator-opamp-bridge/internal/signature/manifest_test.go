@@ -0,0 +1,58 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package signature
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseManifest(t *testing.T) {
+	expires := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	tests := []struct {
+		name    string
+		body    string
+		want    *Manifest
+		wantErr string
+	}{
+		{
+			name: "manifest",
+			body: `{"version": 3, "expires": "2026-01-02T03:04:05Z", "entries": {"default/my-collector": "abc"}}`,
+			want: &Manifest{Version: 3, Expires: &expires, Entries: map[string]string{"default/my-collector": "abc"}},
+		},
+		{
+			name: "no entries",
+			body: `{"version": 1, "entries": {}}`,
+			want: &Manifest{Version: 1, Entries: map[string]string{}},
+		},
+		{
+			name:    "no version",
+			body:    `{"entries": {}}`,
+			wantErr: "the manifest has no version",
+		},
+		{
+			name:    "not JSON",
+			body:    `version: 1`,
+			wantErr: "the manifest is invalid",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseManifest([]byte(tt.body))
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDigest(t *testing.T) {
+	assert.Equal(t, "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855", Digest(nil))
+}
@@ -0,0 +1,132 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package signature
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// EntrySuffix is appended to the name of the manifest entry to name the sibling entry holding its signature.
+const EntrySuffix = ".sig"
+
+// trustRoot is a public key the signatures are verified with, from a certificate when notAfter is set.
+type trustRoot struct {
+	publicKey crypto.PublicKey
+	notBefore time.Time
+	notAfter  time.Time
+}
+
+// Verifier verifies detached signatures of remote config manifests against a set of trust roots. A signature is the
+// base64 encoded signature of the SHA-256 digest of the manifest body, as made by `cosign sign-blob`, or of the body
+// itself for Ed25519 keys.
+type Verifier struct {
+	roots []trustRoot
+	now   func() time.Time
+}
+
+// NewVerifier returns a Verifier for the PEM encoded trust roots, each a public key or an x509 certificate. ECDSA,
+// Ed25519 and RSA keys are supported.
+func NewVerifier(trustRoots []string) (*Verifier, error) {
+	verifier := &Verifier{now: time.Now}
+	for i, trustRoot := range trustRoots {
+		rest := []byte(trustRoot)
+		found := false
+		for {
+			var block *pem.Block
+			block, rest = pem.Decode(rest)
+			if block == nil {
+				break
+			}
+			root, err := parseTrustRoot(block)
+			if err != nil {
+				return nil, fmt.Errorf("trust root %d: %w", i, err)
+			}
+			verifier.roots = append(verifier.roots, root)
+			found = true
+		}
+		if !found {
+			return nil, fmt.Errorf("trust root %d has no PEM encoded public key or certificate", i)
+		}
+	}
+	if len(verifier.roots) == 0 {
+		return nil, errors.New("at least one trust root is required")
+	}
+	return verifier, nil
+}
+
+func parseTrustRoot(block *pem.Block) (trustRoot, error) {
+	switch block.Type {
+	case "PUBLIC KEY":
+		publicKey, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return trustRoot{}, fmt.Errorf("invalid public key: %w", err)
+		}
+		return trustRoot{publicKey: publicKey}, checkKeyType(publicKey)
+	case "CERTIFICATE":
+		certificate, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return trustRoot{}, fmt.Errorf("invalid certificate: %w", err)
+		}
+		return trustRoot{
+			publicKey: certificate.PublicKey,
+			notBefore: certificate.NotBefore,
+			notAfter:  certificate.NotAfter,
+		}, checkKeyType(certificate.PublicKey)
+	default:
+		return trustRoot{}, fmt.Errorf("unsupported PEM block type %q", block.Type)
+	}
+}
+
+func checkKeyType(publicKey crypto.PublicKey) error {
+	switch publicKey.(type) {
+	case *ecdsa.PublicKey, ed25519.PublicKey, *rsa.PublicKey:
+		return nil
+	default:
+		return fmt.Errorf("unsupported public key type %T", publicKey)
+	}
+}
+
+// Verify checks that signature is a signature of body by one of the trust roots.
+func (v *Verifier) Verify(body, signature []byte) error {
+	decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(signature)))
+	if err != nil {
+		return fmt.Errorf("the signature isn't base64 encoded: %w", err)
+	}
+	digest := sha256.Sum256(body)
+	now := v.now()
+	for _, root := range v.roots {
+		if !root.notAfter.IsZero() && (now.Before(root.notBefore) || now.After(root.notAfter)) {
+			continue
+		}
+		if verify(root.publicKey, body, digest[:], decoded) {
+			return nil
+		}
+	}
+	return errors.New("the signature doesn't match any valid trust root")
+}
+
+func verify(publicKey crypto.PublicKey, body, digest, signature []byte) bool {
+	switch key := publicKey.(type) {
+	case *ecdsa.PublicKey:
+		return ecdsa.VerifyASN1(key, digest, signature)
+	case ed25519.PublicKey:
+		return ed25519.Verify(key, body, signature)
+	case *rsa.PublicKey:
+		return rsa.VerifyPKCS1v15(key, crypto.SHA256, digest, signature) == nil ||
+			rsa.VerifyPSS(key, crypto.SHA256, digest, signature, nil) == nil
+	default:
+		return false
+	}
+}
@@ -0,0 +1,179 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package signature
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testBody = []byte("receivers:\n  otlp: {}\n")
+
+func publicKeyPEM(t *testing.T, publicKey crypto.PublicKey) string {
+	der, err := x509.MarshalPKIXPublicKey(publicKey)
+	require.NoError(t, err)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+}
+
+func certificatePEM(t *testing.T, key *ecdsa.PrivateKey, notBefore, notAfter time.Time) string {
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "opamp-server"},
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
+
+func signECDSA(t *testing.T, key *ecdsa.PrivateKey, body []byte) []byte {
+	digest := sha256.Sum256(body)
+	signature, err := ecdsa.SignASN1(rand.Reader, key, digest[:])
+	require.NoError(t, err)
+	return []byte(base64.StdEncoding.EncodeToString(signature))
+}
+
+func TestVerifier_Verify(t *testing.T) {
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	ed25519Public, ed25519Private, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	digest := sha256.Sum256(testBody)
+	rsaSignature, err := rsa.SignPKCS1v15(rand.Reader, rsaKey, crypto.SHA256, digest[:])
+	require.NoError(t, err)
+	now := time.Now()
+
+	tests := []struct {
+		name       string
+		trustRoots []string
+		body       []byte
+		signature  []byte
+		wantErr    string
+	}{
+		{
+			name:       "ecdsa",
+			trustRoots: []string{publicKeyPEM(t, &ecdsaKey.PublicKey)},
+			body:       testBody,
+			signature:  signECDSA(t, ecdsaKey, testBody),
+		},
+		{
+			name:       "ed25519",
+			trustRoots: []string{publicKeyPEM(t, ed25519Public)},
+			body:       testBody,
+			signature:  []byte(base64.StdEncoding.EncodeToString(ed25519.Sign(ed25519Private, testBody)) + "\n"),
+		},
+		{
+			name:       "rsa",
+			trustRoots: []string{publicKeyPEM(t, &rsaKey.PublicKey)},
+			body:       testBody,
+			signature:  []byte(base64.StdEncoding.EncodeToString(rsaSignature)),
+		},
+		{
+			name:       "any trust root",
+			trustRoots: []string{publicKeyPEM(t, &otherKey.PublicKey), publicKeyPEM(t, &ecdsaKey.PublicKey)},
+			body:       testBody,
+			signature:  signECDSA(t, ecdsaKey, testBody),
+		},
+		{
+			name:       "certificate",
+			trustRoots: []string{certificatePEM(t, ecdsaKey, now.Add(-time.Hour), now.Add(time.Hour))},
+			body:       testBody,
+			signature:  signECDSA(t, ecdsaKey, testBody),
+		},
+		{
+			name:       "expired certificate",
+			trustRoots: []string{certificatePEM(t, ecdsaKey, now.Add(-2*time.Hour), now.Add(-time.Hour))},
+			body:       testBody,
+			signature:  signECDSA(t, ecdsaKey, testBody),
+			wantErr:    "the signature doesn't match any valid trust root",
+		},
+		{
+			name:       "untrusted key",
+			trustRoots: []string{publicKeyPEM(t, &otherKey.PublicKey)},
+			body:       testBody,
+			signature:  signECDSA(t, ecdsaKey, testBody),
+			wantErr:    "the signature doesn't match any valid trust root",
+		},
+		{
+			name:       "modified body",
+			trustRoots: []string{publicKeyPEM(t, &ecdsaKey.PublicKey)},
+			body:       []byte("receivers: {}\n"),
+			signature:  signECDSA(t, ecdsaKey, testBody),
+			wantErr:    "the signature doesn't match any valid trust root",
+		},
+		{
+			name:       "not base64",
+			trustRoots: []string{publicKeyPEM(t, &ecdsaKey.PublicKey)},
+			body:       testBody,
+			signature:  []byte("not a signature!"),
+			wantErr:    "the signature isn't base64 encoded",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verifier, err := NewVerifier(tt.trustRoots)
+			require.NoError(t, err)
+			err = verifier.Verify(tt.body, tt.signature)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestNewVerifier_InvalidTrustRoots(t *testing.T) {
+	tests := []struct {
+		name       string
+		trustRoots []string
+		wantErr    string
+	}{
+		{
+			name:    "none",
+			wantErr: "at least one trust root is required",
+		},
+		{
+			name:       "not PEM",
+			trustRoots: []string{"ssh-ed25519 AAAA"},
+			wantErr:    "trust root 0 has no PEM encoded public key or certificate",
+		},
+		{
+			name:       "private key",
+			trustRoots: []string{string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: []byte("key")}))},
+			wantErr:    `trust root 0: unsupported PEM block type "PRIVATE KEY"`,
+		},
+		{
+			name:       "invalid public key",
+			trustRoots: []string{string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: []byte("key")}))},
+			wantErr:    "trust root 0: invalid public key",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewVerifier(tt.trustRoots)
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
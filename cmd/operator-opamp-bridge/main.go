@@ -5,17 +5,23 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"os/signal"
 	"time"
 
 	"github.com/go-logr/logr"
+	"github.com/google/uuid"
+	"k8s.io/client-go/kubernetes"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	opampagent "github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/internal/agent"
+	"github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/internal/audit"
 	"github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/internal/config"
 	"github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/internal/healthcheck"
+	"github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/internal/leader"
 	bridgemanager "github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/internal/manager"
+	"github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/internal/multiplex"
 	"github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/internal/operator"
 	"github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/internal/operatorbridge"
 	"github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/internal/proxy"
@@ -42,11 +48,28 @@ func main() {
 	signalCtx, cancelSignal := signal.NotifyContext(context.Background(), os.Interrupt)
 	defer cancelSignal()
 
-	options := commonManagerOptions(l, cfg, kubeClient)
+	auditLog, auditErr := newAuditLog(l, cfg, kubeClient)
+	if auditErr != nil {
+		l.Error(auditErr, "Couldn't load the audit log")
+		os.Exit(1)
+	}
+
+	options := commonManagerOptions(l, cfg, kubeClient, auditLog)
+	if cfg.LeaderElectionEnabled() {
+		elector, err := newLeaderElector(l, cfg)
+		if err != nil {
+			l.Error(err, "Couldn't create the leader elector")
+			os.Exit(1)
+		}
+		options = append(options, bridgemanager.WithLeaderElector(elector))
+		if labeler := newLeaderLabeler(kubeClient); labeler != nil {
+			options = append(options, bridgemanager.WithLeaderLabeler(labeler))
+		}
+	}
 	if cfg.IsStandaloneMode() {
-		options = append(options, standaloneManagerOptions(l, cfg, kubeClient)...)
+		options = append(options, standaloneManagerOptions(l, cfg, kubeClient, auditLog)...)
 	} else {
-		options = append(options, operatorManagerOptions(l, cfg, kubeClient)...)
+		options = append(options, operatorManagerOptions(l, cfg, kubeClient, auditLog)...)
 	}
 	manager, err := bridgemanager.New(options...)
 	if err != nil {
@@ -57,21 +80,132 @@ func main() {
 		l.Error(err, "Cannot start OpAMP bridge")
 		os.Exit(1)
 	}
-	<-signalCtx.Done()
+	lostLeadership := false
+	select {
+	case <-signalCtx.Done():
+	case <-manager.LeadershipLost():
+		l.Info("Lost leadership, shutting down")
+		lostLeadership = true
+	}
 	shutdownCtx, cancelShutdown := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancelShutdown()
 	manager.Shutdown(shutdownCtx)
+	if auditLog != nil {
+		auditLog.Shutdown()
+	}
+	if lostLeadership {
+		// the replica is restarted as a standby
+		cancelShutdown()
+		os.Exit(1)
+	}
+}
+
+// newIdentityStore returns the store of the instance UID shared by the replicas of the bridge, and of the versions of
+// the remote config manifests verified by its agents.
+func newIdentityStore(cfg *config.Config, c client.Client) *leader.SecretIdentityStore {
+	namespace, name := cfg.GetIdentitySecretKey()
+	return leader.NewSecretIdentityStore(c, namespace, name)
 }
 
-func commonManagerOptions(log logr.Logger, cfg *config.Config, c client.Client) []bridgemanager.Option {
+// newLeaderElector returns the elector of the replica of the bridge holding the OpAMP connection. The replica is
+// identified by its pod name.
+func newLeaderElector(log logr.Logger, cfg *config.Config) (*leader.Elector, error) {
+	clientset, err := kubernetes.NewForConfig(cfg.GetRestConfig())
+	if err != nil {
+		return nil, err
+	}
+	hostname, err := os.Hostname()
+	if err != nil {
+		return nil, err
+	}
+	return leader.NewElector(log.WithName("leader-elector"), clientset, cfg, hostname+"_"+uuid.NewString())
+}
+
+// newLeaderLabeler returns the labeler of the pod of the replica while it leads, nil when the pod isn't known from the
+// POD_NAME and OTELCOL_NAMESPACE environment variables.
+func newLeaderLabeler(c client.Client) *leader.PodLabeler {
+	podName, namespace := os.Getenv("POD_NAME"), os.Getenv("OTELCOL_NAMESPACE")
+	if podName == "" || namespace == "" {
+		return nil
+	}
+	return leader.NewPodLabeler(c, namespace, podName)
+}
+
+// leaderElectionPermissions returns the Kubernetes permissions needed to elect the leader, share its instance UID and
+// label its pod.
+func leaderElectionPermissions(cfg *config.Config) []bridgemanager.Permission {
+	if !cfg.LeaderElectionEnabled() {
+		return nil
+	}
+	namespace := cfg.LeaderElection.GetNamespace()
+	var perms []bridgemanager.Permission
+	for _, resource := range []struct{ apiGroup, name string }{{"coordination.k8s.io", "leases"}, {"", "secrets"}} {
+		for _, verb := range []string{"get", "create", "update"} {
+			perms = append(perms, bridgemanager.Permission{Verb: verb, APIGroup: resource.apiGroup, Resource: resource.name, Namespace: namespace})
+		}
+	}
+	if os.Getenv("POD_NAME") != "" && os.Getenv("OTELCOL_NAMESPACE") != "" {
+		for _, verb := range []string{"get", "patch"} {
+			perms = append(perms, bridgemanager.Permission{Verb: verb, Resource: "pods", Namespace: os.Getenv("OTELCOL_NAMESPACE")})
+		}
+	}
+	return perms
+}
+
+// signaturePermissions returns the Kubernetes permissions needed to persist the versions of the verified remote config
+// manifests in the identity Secret.
+func signaturePermissions(cfg *config.Config) []bridgemanager.Permission {
+	if !cfg.SignatureVerificationEnabled() {
+		return nil
+	}
+	namespace, _ := cfg.GetIdentitySecretKey()
+	return []bridgemanager.Permission{
+		{Verb: "get", Resource: "secrets", Namespace: namespace},
+		{Verb: "create", Resource: "secrets", Namespace: namespace},
+		{Verb: "update", Resource: "secrets", Namespace: namespace},
+	}
+}
+
+// newAuditLog returns the audit log of the bridge, nil when it isn't configured.
+func newAuditLog(log logr.Logger, cfg *config.Config, c client.Client) (*audit.Log, error) {
+	if !cfg.AuditEnabled() {
+		return nil, nil
+	}
+	var store audit.Store
+	if cfg.Audit.Storage == config.AuditStorageFile {
+		store = audit.NewFileStore(cfg.Audit.Path)
+	} else {
+		store = audit.NewConfigMapStore(c, cfg.Audit.GetNamespace(), cfg.Audit.GetConfigMap(cfg.Name))
+	}
+	return audit.NewLog(log.WithName("audit"), store, cfg.Audit.MaxRecords)
+}
+
+// auditPermissions returns the Kubernetes permissions needed to persist the audit log in a ConfigMap.
+func auditPermissions(cfg *config.Config) []bridgemanager.Permission {
+	if !cfg.AuditEnabled() || cfg.Audit.Storage != config.AuditStorageConfigMap {
+		return nil
+	}
+	namespace := cfg.Audit.GetNamespace()
+	return []bridgemanager.Permission{
+		{Verb: "get", Resource: "configmaps", Namespace: namespace},
+		{Verb: "create", Resource: "configmaps", Namespace: namespace},
+		{Verb: "update", Resource: "configmaps", Namespace: namespace},
+	}
+}
+
+func commonManagerOptions(log logr.Logger, cfg *config.Config, c client.Client, auditLog *audit.Log) []bridgemanager.Option {
+	healthServer := healthcheck.NewServer(log.WithName("healthcheck"), cfg.HealthListenAddr)
+	if auditLog != nil {
+		healthServer.Handle(audit.Path, auditLog)
+	}
 	return []bridgemanager.Option{
 		bridgemanager.WithLogger(log),
-		bridgemanager.WithHealthServer(healthcheck.NewServer(log.WithName("healthcheck"), cfg.HealthListenAddr)),
+		bridgemanager.WithHealthServer(healthServer),
 		bridgemanager.WithPermissionReviewClient(c),
 	}
 }
 
-func standaloneManagerOptions(log logr.Logger, cfg *config.Config, c client.Client) []bridgemanager.Option {
+func standaloneManagerOptions(log logr.Logger, cfg *config.Config, c client.Client, auditLog *audit.Log) []bridgemanager.Option {
 	runtimes := make([]bridgemanager.Runtime, 0, len(cfg.Standalone.Agents))
 	standaloneClient := standalone.NewClient(log.WithName("client"), c, cfg.GetRestConfig(), func() {
 		for _, runtime := range runtimes {
@@ -85,6 +219,11 @@ func standaloneManagerOptions(log logr.Logger, cfg *config.Config, c client.Clie
 		opampClient := agentCfg.CreateClient()
 		applier := standaloneClient.ScopedApplier(configuredAgent)
 		opampAgent := opampagent.NewAgent(log.WithName(configuredAgent.WorkloadRef.Name), applier, agentCfg, opampClient, proxy.NoopServer{})
+		opampAgent.SetAuditLog(auditLog)
+		if cfg.SignatureVerificationEnabled() {
+			agentName := fmt.Sprintf("%s/%s/%s/%s", configuredAgent.Namespace, configuredAgent.WorkloadRef.Kind, configuredAgent.WorkloadRef.Name, configuredAgent.Type)
+			opampAgent.SetManifestVersionStore(newIdentityStore(cfg, c).ManifestVersionStore(agentName))
+		}
 		standaloneClient.RegisterHealthUpdater(configuredAgent, opampAgent.UpdateHealth)
 		runtimes = append(runtimes, bridgemanager.Runtime{
 			Name:       configuredAgent.WorkloadRef.Name,
@@ -96,19 +235,55 @@ func standaloneManagerOptions(log logr.Logger, cfg *config.Config, c client.Clie
 		bridgemanager.WithRuntimes(runtimes),
 		bridgemanager.WithKubernetesClient(standaloneClient),
 		bridgemanager.WithRequiredPermissions(func() ([]bridgemanager.Permission, error) {
-			return standalone.ListRequiredPermissions(cfg.Standalone.Agents, cfg.RemoteConfigEnabled())
+			perms, err := standalone.ListRequiredPermissions(cfg.Standalone.Agents, cfg.RemoteConfigEnabled())
+			perms = append(perms, leaderElectionPermissions(cfg)...)
+			perms = append(perms, signaturePermissions(cfg)...)
+			return append(perms, auditPermissions(cfg)...), err
 		}),
 	}
 }
 
-func operatorManagerOptions(log logr.Logger, cfg *config.Config, c client.Client) []bridgemanager.Option {
+func operatorManagerOptions(log logr.Logger, cfg *config.Config, c client.Client, auditLog *audit.Log) []bridgemanager.Option {
 	opampClient := cfg.CreateClient()
-	applier := operator.NewClient(cfg.Name, log.WithName("operator-client"), c, cfg.GetComponentsAllowed())
+	applier := operator.NewClient(cfg.Name, log.WithName("operator-client"), c, cfg.GetComponentsAllowed(), cfg.GetResourcesAllowed())
+	requiredPermissions := bridgemanager.WithRequiredPermissions(func() ([]bridgemanager.Permission, error) {
+		perms, err := operatorbridge.ListRequiredPermissions(cfg.ResourcesAllowed)
+		perms = append(perms, commandPermissions(cfg)...)
+		perms = append(perms, driftPermissions(cfg)...)
+		perms = append(perms, telemetryPermissions(cfg)...)
+		perms = append(perms, leaderElectionPermissions(cfg)...)
+		perms = append(perms, signaturePermissions(cfg)...)
+		return append(perms, auditPermissions(cfg)...), err
+	})
+	if cfg.MultiplexingEnabled() {
+		multiplexer := multiplex.NewMultiplexer(log.WithName("multiplexer"), cfg, applier, auditLog)
+		if cfg.SignatureVerificationEnabled() {
+			identityStore := newIdentityStore(cfg, c)
+			multiplexer.SetManifestVersionStores(func(key string) opampagent.ManifestVersionStore {
+				return identityStore.ManifestVersionStore(key)
+			})
+		}
+		options := []bridgemanager.Option{
+			requiredPermissions,
+			bridgemanager.WithAgentMultiplexer(multiplexer),
+		}
+		if cfg.DriftDetectionEnabled() {
+			options = append(options, bridgemanager.WithKubernetesClient(newCollectorWatcher(log, cfg, c, multiplexer.CheckDrift)))
+		}
+		return options
+	}
 	opampProxy := proxy.NewOpAMPProxy(log.WithName("server"), cfg.ListenAddr)
 	opampAgent := opampagent.NewAgent(log.WithName("agent"), applier, cfg, opampClient, opampProxy)
-	return []bridgemanager.Option{
+	opampAgent.SetAuditLog(auditLog)
+	if cfg.LeaderElectionEnabled() {
+		opampAgent.SetInstanceIdStore(newIdentityStore(cfg, c))
+	}
+	if cfg.SignatureVerificationEnabled() {
+		opampAgent.SetManifestVersionStore(newIdentityStore(cfg, c).ManifestVersionStore(""))
+	}
+	options := []bridgemanager.Option{
 		bridgemanager.WithOpAMPProxy(opampProxy),
-		bridgemanager.WithRequiredPermissions(operatorbridge.ListRequiredPermissions),
+		requiredPermissions,
 		bridgemanager.WithRuntimes([]bridgemanager.Runtime{
 			{
 				Name:       cfg.Name,
@@ -117,4 +292,49 @@ func operatorManagerOptions(log logr.Logger, cfg *config.Config, c client.Client
 			},
 		}),
 	}
+	if cfg.DriftDetectionEnabled() {
+		options = append(options, bridgemanager.WithKubernetesClient(newCollectorWatcher(log, cfg, c, opampAgent.CheckDrift)))
+	}
+	return options
+}
+
+// commandPermissions returns the Kubernetes permissions needed to flush the sending queues of the managed collectors
+// by terminating their pods.
+func commandPermissions(cfg *config.Config) []bridgemanager.Permission {
+	if !cfg.Capabilities[config.AcceptsRestartCommand] {
+		return nil
+	}
+	return []bridgemanager.Permission{
+		{Verb: "delete", Resource: "pods"},
+	}
+}
+
+// telemetryPermissions returns the Kubernetes permissions needed to store the credentials of the telemetry
+// destinations forwarded to the managed collectors.
+func telemetryPermissions(cfg *config.Config) []bridgemanager.Permission {
+	if !cfg.TelemetryForwardingEnabled() {
+		return nil
+	}
+	return []bridgemanager.Permission{
+		{Verb: "get", Resource: "secrets"},
+		{Verb: "create", Resource: "secrets"},
+		{Verb: "update", Resource: "secrets"},
+	}
+}
+
+// newCollectorWatcher returns a watcher of the managed collectors, which checks each changed one for drift.
+func newCollectorWatcher(log logr.Logger, cfg *config.Config, c client.Client, checkDrift func(key, hash string)) *operator.CollectorWatcher {
+	return operator.NewCollectorWatcher(log.WithName("collector-watcher"), cfg.GetRestConfig(), c.Scheme(), cfg.Name, checkDrift)
+}
+
+// driftPermissions returns the Kubernetes permissions needed to watch the managed collectors for drift, and to save
+// their spec hashes as last changed through OpAMP.
+func driftPermissions(cfg *config.Config) []bridgemanager.Permission {
+	if !cfg.DriftDetectionEnabled() {
+		return nil
+	}
+	return []bridgemanager.Permission{
+		{Verb: "watch", APIGroup: "opentelemetry.io", Resource: "opentelemetrycollectors"},
+		{Verb: "patch", APIGroup: "opentelemetry.io", Resource: "opentelemetrycollectors"},
+	}
 }
@@ -43,6 +43,7 @@ import (
 	operatorsetup "github.com/open-telemetry/opentelemetry-operator/internal/operator"
 	operatormetrics "github.com/open-telemetry/opentelemetry-operator/internal/operator-metrics"
 	"github.com/open-telemetry/opentelemetry-operator/internal/operatornetworkpolicy"
+	collectorStatus "github.com/open-telemetry/opentelemetry-operator/internal/status/collector"
 	"github.com/open-telemetry/opentelemetry-operator/internal/version"
 	wh "github.com/open-telemetry/opentelemetry-operator/internal/webhook"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
@@ -170,20 +171,38 @@ func runOperator(cfg config.Config, configFile string, opts zap.Options, feature
 
 	var collectorReconciler *controllers.OpenTelemetryCollectorReconciler
 	if result.Config.CollectorAvailability == collector.Available {
+		configFragmentSecrets, err := operatorsetup.NewConfigFragmentCache(mgr, result.Config)
+		if err != nil {
+			setupLog.Error(err, "failed to create the config fragment cache")
+			os.Exit(1)
+		}
+		rbacObjects, err := operatorsetup.NewRBACCache(mgr, result.Config)
+		if err != nil {
+			setupLog.Error(err, "failed to create the RBAC cache")
+			os.Exit(1)
+		}
 		collectorReconciler = controllers.NewReconciler(controllers.Params{
-			Client:   mgr.GetClient(),
-			Log:      ctrl.Log.WithName("controllers").WithName("OpenTelemetryCollector"),
-			Scheme:   mgr.GetScheme(),
-			Config:   result.Config,
-			Recorder: mgr.GetEventRecorder("opentelemetry-operator"),
-			Reviewer: result.Reviewer,
-			Version:  v,
+			Client:    mgr.GetClient(),
+			Log:       ctrl.Log.WithName("controllers").WithName("OpenTelemetryCollector"),
+			Scheme:    mgr.GetScheme(),
+			Config:    result.Config,
+			Recorder:  mgr.GetEventRecorder("opentelemetry-operator"),
+			Reviewer:  result.Reviewer,
+			Version:   v,
+			FIPSCheck: operatorsetup.NewFIPSCheck(ctx, result.Config, result.Autodetector),
+
+			ConfigFragmentSecrets: configFragmentSecrets,
+			RBACObjects:           rbacObjects,
 		})
 
 		if err := collectorReconciler.SetupWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "OpenTelemetryCollector")
 			os.Exit(1)
 		}
+		if err := mgr.Add(collectorStatus.NewComponentHealthChecker(mgr.GetClient(), ctrl.Log.WithName("component-health"))); err != nil {
+			setupLog.Error(err, "failed to add the component health checker to the controller manager")
+			os.Exit(1)
+		}
 	}
 
 	if result.Config.TargetAllocatorAvailability == targetallocator.Available {
@@ -228,6 +247,19 @@ func runOperator(cfg config.Config, configFile string, opts zap.Options, feature
 		setupLog.Info("ClusterObservability feature is disabled")
 	}
 
+	if featuregate.EnableCollectorTopology.IsEnabled() {
+		if err := controllers.NewCollectorTopologyReconciler(controllers.CollectorTopologyReconcilerParams{
+			Client:   mgr.GetClient(),
+			Log:      ctrl.Log.WithName("controllers").WithName("CollectorTopology"),
+			Scheme:   mgr.GetScheme(),
+			Config:   result.Config,
+			Recorder: mgr.GetEventRecorder("collector-topology"),
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "CollectorTopology")
+			os.Exit(1)
+		}
+	}
+
 	// Setup pod-webhook replica controller to maintain desired replica count.
 	// On OpenShift with OLM, this ensures replicas survive upgrades (OLM resets to CSV default).
 	if result.Config.OpenShiftRoutesAvailability == openshift.RoutesAvailable {
@@ -357,6 +389,19 @@ func enableOperatorNetworkPolicy(cfg config.Config, clientset kubernetes.Interfa
 		}))
 	}
 
+	// the operator scrapes the telemetry of the collector pods, including the canary pods of the configuration rollouts
+	policyOpts = append(policyOpts, operatornetworkpolicy.WithCollectorPodLabelSelector(&metav1.LabelSelector{
+		MatchLabels: map[string]string{
+			"app.kubernetes.io/managed-by": "opentelemetry-operator",
+			"app.kubernetes.io/part-of":    "opentelemetry",
+		},
+		MatchExpressions: []metav1.LabelSelectorRequirement{{
+			Key:      "app.kubernetes.io/component",
+			Operator: metav1.LabelSelectorOpIn,
+			Values:   []string{collectorManifests.ComponentOpenTelemetryCollector, collectorManifests.ComponentOpenTelemetryCollectorCanary},
+		}},
+	}))
+
 	// Always include the webhook port in the NetworkPolicy even when ENABLE_WEBHOOKS=false,
 	// because webhooks may run in a separate deployment that shares the same pod selector.
 	//nolint:gosec // disable G115
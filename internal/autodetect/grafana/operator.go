@@ -0,0 +1,19 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package grafana
+
+// Availability represents whether the GrafanaDashboard CRD of the Grafana operator is available.
+type Availability int
+
+const (
+	// NotAvailable represents the grafana.integreatly.org GrafanaDashboard CRD is not available.
+	NotAvailable Availability = iota
+
+	// Available represents the grafana.integreatly.org GrafanaDashboard CRD is available.
+	Available
+)
+
+func (p Availability) String() string {
+	return [...]string{"NotAvailable", "Available"}[p]
+}
@@ -0,0 +1,19 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package keda
+
+// Availability represents whether the KEDA ScaledObject CRD is available.
+type Availability int
+
+const (
+	// NotAvailable represents the keda.sh ScaledObject CRD is not available.
+	NotAvailable Availability = iota
+
+	// Available represents the keda.sh ScaledObject CRD is available.
+	Available
+)
+
+func (p Availability) String() string {
+	return [...]string{"NotAvailable", "Available"}[p]
+}
@@ -19,12 +19,15 @@ import (
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/collector"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/fips"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/gatewayapi"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/grafana"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/k8s"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/keda"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/opampbridge"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/openshift"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/prometheus"
 	autoRBAC "github.com/open-telemetry/opentelemetry-operator/internal/autodetect/rbac"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/targetallocator"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/vpa"
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
 	"github.com/open-telemetry/opentelemetry-operator/internal/rbac"
 )
@@ -36,6 +39,7 @@ type AutoDetect interface {
 	OpenShiftRoutesAvailability() (openshift.RoutesAvailability, error)
 	PrometheusCRsAvailability() (prometheus.Availability, error)
 	RBACPermissions(ctx context.Context) (autoRBAC.Availability, error)
+	RoleRBACPermissions(ctx context.Context) (autoRBAC.Availability, error)
 	CertManagerAvailability(ctx context.Context) (certmanager.Availability, error)
 	TargetAllocatorAvailability() (targetallocator.Availability, error)
 	CollectorAvailability() (collector.Availability, error)
@@ -43,6 +47,10 @@ type AutoDetect interface {
 	FIPSEnabled(ctx context.Context) bool
 	NativeSidecarSupport() (bool, error)
 	GatewayAPIsAvailability() (gatewayapi.ApiAvailability, error)
+	GatewayRouteAvailability(kind string) (gatewayapi.ApiAvailability, error)
+	KedaAvailability() (keda.Availability, error)
+	VPAAvailability() (vpa.Availability, error)
+	GrafanaDashboardAvailability() (grafana.Availability, error)
 }
 
 type k8sVersionDiscovery interface {
@@ -138,6 +146,18 @@ func (a *autoDetect) RBACPermissions(ctx context.Context) (autoRBAC.Availability
 	return autoRBAC.Available, nil
 }
 
+func (a *autoDetect) RoleRBACPermissions(ctx context.Context) (autoRBAC.Availability, error) {
+	w, err := autoRBAC.CheckRoleRBACPermissions(ctx, a.reviewer)
+	if err != nil {
+		return autoRBAC.NotAvailable, err
+	}
+	if w != nil {
+		return autoRBAC.NotAvailable, fmt.Errorf("missing permissions: %s", w)
+	}
+
+	return autoRBAC.Available, nil
+}
+
 func (a *autoDetect) CertManagerAvailability(ctx context.Context) (certmanager.Availability, error) {
 	apiList, err := a.dcl.ServerGroups()
 	if err != nil {
@@ -295,6 +315,133 @@ func (a *autoDetect) GatewayAPIsAvailability() (gatewayapi.ApiAvailability, erro
 	return gatewayapi.ApiNotAvailable, nil
 }
 
+// GatewayRouteAvailability checks if the given route kind is served in the v1 version of the Gateway API, which the
+// operator creates routes with. Only HTTPRoute is part of every installation of the Gateway API.
+func (a *autoDetect) GatewayRouteAvailability(kind string) (gatewayapi.ApiAvailability, error) {
+	apiList, err := a.dcl.ServerGroups()
+	if err != nil {
+		return gatewayapi.ApiNotAvailable, err
+	}
+
+	apiGroups := apiList.Groups
+	gatewayGroupIndex := slices.IndexFunc(apiGroups, func(group metav1.APIGroup) bool {
+		return group.Name == "gateway.networking.k8s.io"
+	})
+	if gatewayGroupIndex == -1 {
+		return gatewayapi.ApiNotAvailable, nil
+	}
+
+	const groupVersion = "gateway.networking.k8s.io/v1"
+	if !slices.ContainsFunc(apiGroups[gatewayGroupIndex].Versions, func(version metav1.GroupVersionForDiscovery) bool {
+		return version.GroupVersion == groupVersion
+	}) {
+		return gatewayapi.ApiNotAvailable, nil
+	}
+	resourceList, err := a.dcl.ServerResourcesForGroupVersion(groupVersion)
+	if err != nil {
+		return gatewayapi.ApiNotAvailable, err
+	}
+	if slices.ContainsFunc(resourceList.APIResources, func(resource metav1.APIResource) bool {
+		return resource.Kind == kind
+	}) {
+		return gatewayapi.ApiAvailable, nil
+	}
+	return gatewayapi.ApiNotAvailable, nil
+}
+
+// KedaAvailability checks if the KEDA ScaledObject CRD is available.
+func (a *autoDetect) KedaAvailability() (keda.Availability, error) {
+	apiList, err := a.dcl.ServerGroups()
+	if err != nil {
+		return keda.NotAvailable, err
+	}
+
+	apiGroups := apiList.Groups
+	kedaGroupIndex := slices.IndexFunc(apiGroups, func(group metav1.APIGroup) bool {
+		return group.Name == "keda.sh"
+	})
+	if kedaGroupIndex == -1 {
+		return keda.NotAvailable, nil
+	}
+
+	for _, groupVersion := range apiGroups[kedaGroupIndex].Versions {
+		resourceList, err := a.dcl.ServerResourcesForGroupVersion(groupVersion.GroupVersion)
+		if err != nil {
+			return keda.NotAvailable, err
+		}
+		index := slices.IndexFunc(resourceList.APIResources, func(resource metav1.APIResource) bool {
+			return resource.Kind == "ScaledObject"
+		})
+		if index >= 0 {
+			return keda.Available, nil
+		}
+	}
+
+	return keda.NotAvailable, nil
+}
+
+// VPAAvailability checks if the VerticalPodAutoscaler CRD is available.
+func (a *autoDetect) VPAAvailability() (vpa.Availability, error) {
+	apiList, err := a.dcl.ServerGroups()
+	if err != nil {
+		return vpa.NotAvailable, err
+	}
+
+	apiGroups := apiList.Groups
+	vpaGroupIndex := slices.IndexFunc(apiGroups, func(group metav1.APIGroup) bool {
+		return group.Name == "autoscaling.k8s.io"
+	})
+	if vpaGroupIndex == -1 {
+		return vpa.NotAvailable, nil
+	}
+
+	for _, groupVersion := range apiGroups[vpaGroupIndex].Versions {
+		resourceList, err := a.dcl.ServerResourcesForGroupVersion(groupVersion.GroupVersion)
+		if err != nil {
+			return vpa.NotAvailable, err
+		}
+		index := slices.IndexFunc(resourceList.APIResources, func(resource metav1.APIResource) bool {
+			return resource.Kind == "VerticalPodAutoscaler"
+		})
+		if index >= 0 {
+			return vpa.Available, nil
+		}
+	}
+
+	return vpa.NotAvailable, nil
+}
+
+// GrafanaDashboardAvailability checks if the GrafanaDashboard CRD of the Grafana operator is available.
+func (a *autoDetect) GrafanaDashboardAvailability() (grafana.Availability, error) {
+	apiList, err := a.dcl.ServerGroups()
+	if err != nil {
+		return grafana.NotAvailable, err
+	}
+
+	apiGroups := apiList.Groups
+	grafanaGroupIndex := slices.IndexFunc(apiGroups, func(group metav1.APIGroup) bool {
+		return group.Name == "grafana.integreatly.org"
+	})
+	if grafanaGroupIndex == -1 {
+		return grafana.NotAvailable, nil
+	}
+
+	for _, groupVersion := range apiGroups[grafanaGroupIndex].Versions {
+		resourceList, err := a.dcl.ServerResourcesForGroupVersion(groupVersion.GroupVersion)
+		if err != nil {
+			return grafana.NotAvailable, err
+		}
+		index := slices.IndexFunc(resourceList.APIResources, func(resource metav1.APIResource) bool {
+			return resource.Kind == "GrafanaDashboard"
+		})
+		if index >= 0 {
+			return grafana.Available, nil
+		}
+	}
+
+	return grafana.NotAvailable, nil
+}
+
 // ApplyAutoDetect attempts to automatically detect relevant information for this operator.
 func ApplyAutoDetect(autoDetect AutoDetect, c *config.Config, logger logr.Logger) error {
 	logger.V(2).Info("auto-detecting the configuration based on the environment")
@@ -320,6 +467,13 @@ func ApplyAutoDetect(autoDetect AutoDetect, c *config.Config, logger logr.Logger
 	c.CreateRBACPermissions = rAuto
 	logger.V(2).Info("create rbac permissions detected", "availability", rAuto)
 
+	rRoleAuto, err := autoDetect.RoleRBACPermissions(context.Background())
+	if err != nil {
+		logger.V(2).Info("the namespaced rbac permissions are not set for the operator", "reason", err)
+	}
+	c.CreateRoleRBACPermissions = rRoleAuto
+	logger.V(2).Info("create namespaced rbac permissions detected", "availability", rRoleAuto)
+
 	cmAvl, err := autoDetect.CertManagerAvailability(context.Background())
 	if err != nil {
 		logger.V(2).Info("the cert manager crd and permissions are not set for the operator", "reason", err)
@@ -363,5 +517,40 @@ func ApplyAutoDetect(autoDetect AutoDetect, c *config.Config, logger logr.Logger
 	c.GatewayAPIsAvailability = gapiAvl
 	logger.V(2).Info("determined Gateway API availability", "availability", gapiAvl)
 
+	grpcRouteAvl, err := autoDetect.GatewayRouteAvailability("GRPCRoute")
+	if err != nil {
+		return err
+	}
+	c.GRPCRouteAvailability = grpcRouteAvl
+	logger.V(2).Info("determined Gateway API GRPCRoute availability", "availability", grpcRouteAvl)
+
+	tlsRouteAvl, err := autoDetect.GatewayRouteAvailability("TLSRoute")
+	if err != nil {
+		return err
+	}
+	c.TLSRouteAvailability = tlsRouteAvl
+	logger.V(2).Info("determined Gateway API TLSRoute availability", "availability", tlsRouteAvl)
+
+	kedaAvl, err := autoDetect.KedaAvailability()
+	if err != nil {
+		return err
+	}
+	c.KedaAvailability = kedaAvl
+	logger.V(2).Info("determined KEDA availability", "availability", kedaAvl)
+
+	vpaAvl, err := autoDetect.VPAAvailability()
+	if err != nil {
+		return err
+	}
+	c.VPAAvailability = vpaAvl
+	logger.V(2).Info("determined VerticalPodAutoscaler availability", "availability", vpaAvl)
+
+	grafanaAvl, err := autoDetect.GrafanaDashboardAvailability()
+	if err != nil {
+		return err
+	}
+	c.GrafanaDashboardAvailability = grafanaAvl
+	logger.V(2).Info("determined GrafanaDashboard availability", "availability", grafanaAvl)
+
 	return nil
 }
@@ -27,11 +27,14 @@ import (
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/certmanager"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/collector"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/gatewayapi"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/grafana"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/keda"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/opampbridge"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/openshift"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/prometheus"
 	autoRBAC "github.com/open-telemetry/opentelemetry-operator/internal/autodetect/rbac"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/targetallocator"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/vpa"
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
 	"github.com/open-telemetry/opentelemetry-operator/internal/rbac"
 )
@@ -162,6 +165,305 @@ func TestDetectPlatformBasedOnAvailableAPIGroupsPrometheus(t *testing.T) {
 	}
 }
 
+func TestDetectKedaBasedOnAvailableAPIGroups(t *testing.T) {
+	for _, tt := range []struct {
+		apiGroupList *metav1.APIGroupList
+		resources    *metav1.APIResourceList
+		expected     keda.Availability
+	}{
+		{
+			&metav1.APIGroupList{},
+			&metav1.APIResourceList{},
+			keda.NotAvailable,
+		},
+		{
+			&metav1.APIGroupList{
+				Groups: []metav1.APIGroup{
+					{
+						Name:     "keda.sh",
+						Versions: []metav1.GroupVersionForDiscovery{{GroupVersion: "keda.sh/v1alpha1"}},
+					},
+				},
+			},
+			&metav1.APIResourceList{
+				APIResources: []metav1.APIResource{{Kind: "ScaledJob"}},
+			},
+			keda.NotAvailable,
+		},
+		{
+			&metav1.APIGroupList{
+				Groups: []metav1.APIGroup{
+					{
+						Name:     "keda.sh",
+						Versions: []metav1.GroupVersionForDiscovery{{GroupVersion: "keda.sh/v1alpha1"}},
+					},
+				},
+			},
+			&metav1.APIResourceList{
+				APIResources: []metav1.APIResource{{Kind: "ScaledJob"}, {Kind: "ScaledObject"}},
+			},
+			keda.Available,
+		},
+	} {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			var output []byte
+			var err error
+			if req.URL.Path == "/apis" {
+				output, err = json.Marshal(tt.apiGroupList)
+			} else {
+				output, err = json.Marshal(tt.resources)
+			}
+			require.NoError(t, err)
+
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			_, err = w.Write(output)
+			require.NoError(t, err)
+		}))
+		defer server.Close()
+
+		autoDetect, err := autodetect.New(&rest.Config{Host: server.URL}, nil)
+		require.NoError(t, err)
+
+		// test
+		availability, err := autoDetect.KedaAvailability()
+
+		// verify
+		assert.NoError(t, err)
+		assert.Equal(t, tt.expected, availability)
+	}
+}
+
+func TestDetectVPABasedOnAvailableAPIGroups(t *testing.T) {
+	for _, tt := range []struct {
+		apiGroupList *metav1.APIGroupList
+		resources    *metav1.APIResourceList
+		expected     vpa.Availability
+	}{
+		{
+			&metav1.APIGroupList{},
+			&metav1.APIResourceList{},
+			vpa.NotAvailable,
+		},
+		{
+			&metav1.APIGroupList{
+				Groups: []metav1.APIGroup{
+					{
+						Name:     "autoscaling.k8s.io",
+						Versions: []metav1.GroupVersionForDiscovery{{GroupVersion: "autoscaling.k8s.io/v1"}},
+					},
+				},
+			},
+			&metav1.APIResourceList{
+				APIResources: []metav1.APIResource{{Kind: "VerticalPodAutoscalerCheckpoint"}},
+			},
+			vpa.NotAvailable,
+		},
+		{
+			&metav1.APIGroupList{
+				Groups: []metav1.APIGroup{
+					{
+						Name:     "autoscaling.k8s.io",
+						Versions: []metav1.GroupVersionForDiscovery{{GroupVersion: "autoscaling.k8s.io/v1"}},
+					},
+				},
+			},
+			&metav1.APIResourceList{
+				APIResources: []metav1.APIResource{{Kind: "VerticalPodAutoscalerCheckpoint"}, {Kind: "VerticalPodAutoscaler"}},
+			},
+			vpa.Available,
+		},
+	} {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			var output []byte
+			var err error
+			if req.URL.Path == "/apis" {
+				output, err = json.Marshal(tt.apiGroupList)
+			} else {
+				output, err = json.Marshal(tt.resources)
+			}
+			require.NoError(t, err)
+
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			_, err = w.Write(output)
+			require.NoError(t, err)
+		}))
+		defer server.Close()
+
+		autoDetect, err := autodetect.New(&rest.Config{Host: server.URL}, nil)
+		require.NoError(t, err)
+
+		// test
+		availability, err := autoDetect.VPAAvailability()
+
+		// verify
+		assert.NoError(t, err)
+		assert.Equal(t, tt.expected, availability)
+	}
+}
+
+func TestDetectGrafanaDashboardBasedOnAvailableAPIGroups(t *testing.T) {
+	for _, tt := range []struct {
+		apiGroupList *metav1.APIGroupList
+		resources    *metav1.APIResourceList
+		expected     grafana.Availability
+	}{
+		{
+			&metav1.APIGroupList{},
+			&metav1.APIResourceList{},
+			grafana.NotAvailable,
+		},
+		{
+			&metav1.APIGroupList{
+				Groups: []metav1.APIGroup{
+					{
+						Name:     "grafana.integreatly.org",
+						Versions: []metav1.GroupVersionForDiscovery{{GroupVersion: "grafana.integreatly.org/v1beta1"}},
+					},
+				},
+			},
+			&metav1.APIResourceList{
+				APIResources: []metav1.APIResource{{Kind: "GrafanaDatasource"}},
+			},
+			grafana.NotAvailable,
+		},
+		{
+			&metav1.APIGroupList{
+				Groups: []metav1.APIGroup{
+					{
+						Name:     "grafana.integreatly.org",
+						Versions: []metav1.GroupVersionForDiscovery{{GroupVersion: "grafana.integreatly.org/v1beta1"}},
+					},
+				},
+			},
+			&metav1.APIResourceList{
+				APIResources: []metav1.APIResource{{Kind: "GrafanaDatasource"}, {Kind: "GrafanaDashboard"}},
+			},
+			grafana.Available,
+		},
+	} {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			var output []byte
+			var err error
+			if req.URL.Path == "/apis" {
+				output, err = json.Marshal(tt.apiGroupList)
+			} else {
+				output, err = json.Marshal(tt.resources)
+			}
+			require.NoError(t, err)
+
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			_, err = w.Write(output)
+			require.NoError(t, err)
+		}))
+		defer server.Close()
+
+		autoDetect, err := autodetect.New(&rest.Config{Host: server.URL}, nil)
+		require.NoError(t, err)
+
+		// test
+		availability, err := autoDetect.GrafanaDashboardAvailability()
+
+		// verify
+		assert.NoError(t, err)
+		assert.Equal(t, tt.expected, availability)
+	}
+}
+
+func TestDetectGatewayRoutesBasedOnAvailableAPIGroups(t *testing.T) {
+	for _, tt := range []struct {
+		name         string
+		apiGroupList *metav1.APIGroupList
+		resources    *metav1.APIResourceList
+		kind         string
+		expected     gatewayapi.ApiAvailability
+	}{
+		{
+			name:         "no gateway api",
+			apiGroupList: &metav1.APIGroupList{},
+			resources:    &metav1.APIResourceList{},
+			kind:         "GRPCRoute",
+			expected:     gatewayapi.ApiNotAvailable,
+		},
+		{
+			name: "standard channel",
+			apiGroupList: &metav1.APIGroupList{
+				Groups: []metav1.APIGroup{
+					{
+						Name:     "gateway.networking.k8s.io",
+						Versions: []metav1.GroupVersionForDiscovery{{GroupVersion: "gateway.networking.k8s.io/v1"}},
+					},
+				},
+			},
+			resources: &metav1.APIResourceList{
+				APIResources: []metav1.APIResource{{Kind: "Gateway"}, {Kind: "HTTPRoute"}, {Kind: "GRPCRoute"}},
+			},
+			kind:     "GRPCRoute",
+			expected: gatewayapi.ApiAvailable,
+		},
+		{
+			name: "route not installed",
+			apiGroupList: &metav1.APIGroupList{
+				Groups: []metav1.APIGroup{
+					{
+						Name:     "gateway.networking.k8s.io",
+						Versions: []metav1.GroupVersionForDiscovery{{GroupVersion: "gateway.networking.k8s.io/v1"}},
+					},
+				},
+			},
+			resources: &metav1.APIResourceList{
+				APIResources: []metav1.APIResource{{Kind: "Gateway"}, {Kind: "HTTPRoute"}, {Kind: "GRPCRoute"}},
+			},
+			kind:     "TLSRoute",
+			expected: gatewayapi.ApiNotAvailable,
+		},
+		{
+			name: "only served in v1alpha2",
+			apiGroupList: &metav1.APIGroupList{
+				Groups: []metav1.APIGroup{
+					{
+						Name:     "gateway.networking.k8s.io",
+						Versions: []metav1.GroupVersionForDiscovery{{GroupVersion: "gateway.networking.k8s.io/v1alpha2"}},
+					},
+				},
+			},
+			resources: &metav1.APIResourceList{
+				APIResources: []metav1.APIResource{{Kind: "TLSRoute"}},
+			},
+			kind:     "TLSRoute",
+			expected: gatewayapi.ApiNotAvailable,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				var output []byte
+				var err error
+				if req.URL.Path == "/apis" {
+					output, err = json.Marshal(tt.apiGroupList)
+				} else {
+					output, err = json.Marshal(tt.resources)
+				}
+				require.NoError(t, err)
+
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusOK)
+				_, err = w.Write(output)
+				require.NoError(t, err)
+			}))
+			defer server.Close()
+
+			autoDetect, err := autodetect.New(&rest.Config{Host: server.URL}, nil)
+			require.NoError(t, err)
+
+			availability, err := autoDetect.GatewayRouteAvailability(tt.kind)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, availability)
+		})
+	}
+}
+
 type fakeClientGenerator func() kubernetes.Interface
 
 const (
@@ -264,6 +566,51 @@ func TestDetectRBACPermissionsBasedOnAvailableClusterRoles(t *testing.T) {
 	}
 }
 
+func TestDetectRoleRBACPermissions(t *testing.T) {
+	for _, tt := range []struct {
+		description          string
+		expectedAvailability autoRBAC.Availability
+		shouldError          bool
+		clientGenerator      fakeClientGenerator
+	}{
+		{
+			description: "RBAC resources are NOT there",
+			shouldError: true,
+			clientGenerator: reactorFactory(v1.SubjectAccessReviewStatus{
+				Allowed: false,
+			}),
+			expectedAvailability: autoRBAC.NotAvailable,
+		},
+		{
+			description: "RBAC resources are there",
+			clientGenerator: reactorFactory(v1.SubjectAccessReviewStatus{
+				Allowed: true,
+			}),
+			expectedAvailability: autoRBAC.Available,
+		},
+	} {
+		t.Run(tt.description, func(t *testing.T) {
+			t.Setenv(autodetectutils.NAMESPACE_ENV_VAR, "default")
+			t.Setenv(autodetectutils.SA_ENV_VAR, "defaultSA")
+
+			server := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+			defer server.Close()
+
+			aD, err := autodetect.New(&rest.Config{Host: server.URL}, rbac.NewReviewer(tt.clientGenerator()))
+			require.NoError(t, err)
+
+			rAuto, err := aD.RoleRBACPermissions(context.Background())
+
+			assert.Equal(t, tt.expectedAvailability, rAuto)
+			if tt.shouldError {
+				require.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
 func TestCertManagerAvailability(t *testing.T) {
 	// test data
 	for _, tt := range []struct {
@@ -367,6 +714,9 @@ func TestConfigChangesOnAutoDetect(t *testing.T) {
 		RBACPermissionsFunc: func(context.Context) (autoRBAC.Availability, error) {
 			return autoRBAC.Available, nil
 		},
+		RoleRBACPermissionsFunc: func(context.Context) (autoRBAC.Availability, error) {
+			return autoRBAC.Available, nil
+		},
 		CertManagerAvailabilityFunc: func(context.Context) (certmanager.Availability, error) {
 			return certmanager.Available, nil
 		},
@@ -379,6 +729,15 @@ func TestConfigChangesOnAutoDetect(t *testing.T) {
 		NativeSidecarSupportFunc: func() (bool, error) {
 			return true, nil
 		},
+		KedaAvailabilityFunc: func() (keda.Availability, error) {
+			return keda.Available, nil
+		},
+		VPAAvailabilityFunc: func() (vpa.Availability, error) {
+			return vpa.Available, nil
+		},
+		GrafanaDashboardAvailabilityFunc: func() (grafana.Availability, error) {
+			return grafana.Available, nil
+		},
 	}
 	cfg := config.New()
 
@@ -386,10 +745,14 @@ func TestConfigChangesOnAutoDetect(t *testing.T) {
 	require.Equal(t, openshift.RoutesNotAvailable, cfg.OpenShiftRoutesAvailability)
 	require.Equal(t, prometheus.NotAvailable, cfg.PrometheusCRAvailability)
 	require.Equal(t, autoRBAC.NotAvailable, cfg.CreateRBACPermissions)
+	require.Equal(t, autoRBAC.NotAvailable, cfg.CreateRoleRBACPermissions)
 	require.Equal(t, certmanager.NotAvailable, cfg.CertManagerAvailability)
 	require.Equal(t, targetallocator.NotAvailable, cfg.TargetAllocatorAvailability)
 	require.Equal(t, opampbridge.NotAvailable, cfg.OpAmpBridgeAvailability)
 	require.Equal(t, false, cfg.Internal.NativeSidecarSupport)
+	require.Equal(t, keda.NotAvailable, cfg.KedaAvailability)
+	require.Equal(t, vpa.NotAvailable, cfg.VPAAvailability)
+	require.Equal(t, grafana.NotAvailable, cfg.GrafanaDashboardAvailability)
 
 	// test
 	err := autodetect.ApplyAutoDetect(mock, &cfg, ctrl.Log.WithName("test"))
@@ -399,24 +762,33 @@ func TestConfigChangesOnAutoDetect(t *testing.T) {
 	assert.Equal(t, openshift.RoutesAvailable, cfg.OpenShiftRoutesAvailability)
 	require.Equal(t, prometheus.Available, cfg.PrometheusCRAvailability)
 	require.Equal(t, autoRBAC.Available, cfg.CreateRBACPermissions)
+	require.Equal(t, autoRBAC.Available, cfg.CreateRoleRBACPermissions)
 	require.Equal(t, certmanager.Available, cfg.CertManagerAvailability)
 	require.Equal(t, targetallocator.Available, cfg.TargetAllocatorAvailability)
 	require.Equal(t, opampbridge.Available, cfg.OpAmpBridgeAvailability)
 	require.Equal(t, true, cfg.Internal.NativeSidecarSupport)
+	require.Equal(t, keda.Available, cfg.KedaAvailability)
+	require.Equal(t, vpa.Available, cfg.VPAAvailability)
+	require.Equal(t, grafana.Available, cfg.GrafanaDashboardAvailability)
 }
 
 var _ autodetect.AutoDetect = (*mockAutoDetect)(nil)
 
 type mockAutoDetect struct {
-	OpenShiftRoutesAvailabilityFunc func() (openshift.RoutesAvailability, error)
-	PrometheusCRsAvailabilityFunc   func() (prometheus.Availability, error)
-	RBACPermissionsFunc             func(ctx context.Context) (autoRBAC.Availability, error)
-	CertManagerAvailabilityFunc     func(ctx context.Context) (certmanager.Availability, error)
-	TargetAllocatorAvailabilityFunc func() (targetallocator.Availability, error)
-	CollectorAvailabilityFunc       func() (collector.Availability, error)
-	OpAmpBridgeAvailabilityFunc     func() (opampbridge.Availability, error)
-	NativeSidecarSupportFunc        func() (bool, error)
-	GatewayAPIsAvailabilityFunc     func() (gatewayapi.ApiAvailability, error)
+	OpenShiftRoutesAvailabilityFunc  func() (openshift.RoutesAvailability, error)
+	PrometheusCRsAvailabilityFunc    func() (prometheus.Availability, error)
+	RBACPermissionsFunc              func(ctx context.Context) (autoRBAC.Availability, error)
+	RoleRBACPermissionsFunc          func(ctx context.Context) (autoRBAC.Availability, error)
+	CertManagerAvailabilityFunc      func(ctx context.Context) (certmanager.Availability, error)
+	TargetAllocatorAvailabilityFunc  func() (targetallocator.Availability, error)
+	CollectorAvailabilityFunc        func() (collector.Availability, error)
+	OpAmpBridgeAvailabilityFunc      func() (opampbridge.Availability, error)
+	NativeSidecarSupportFunc         func() (bool, error)
+	GatewayAPIsAvailabilityFunc      func() (gatewayapi.ApiAvailability, error)
+	GatewayRouteAvailabilityFunc     func(kind string) (gatewayapi.ApiAvailability, error)
+	KedaAvailabilityFunc             func() (keda.Availability, error)
+	VPAAvailabilityFunc              func() (vpa.Availability, error)
+	GrafanaDashboardAvailabilityFunc func() (grafana.Availability, error)
 }
 
 func (m *mockAutoDetect) OpAmpBridgeAvailablity() (opampbridge.Availability, error) {
@@ -458,6 +830,13 @@ func (m *mockAutoDetect) RBACPermissions(ctx context.Context) (autoRBAC.Availabi
 	return autoRBAC.NotAvailable, nil
 }
 
+func (m *mockAutoDetect) RoleRBACPermissions(ctx context.Context) (autoRBAC.Availability, error) {
+	if m.RoleRBACPermissionsFunc != nil {
+		return m.RoleRBACPermissionsFunc(ctx)
+	}
+	return autoRBAC.NotAvailable, nil
+}
+
 func (m *mockAutoDetect) CertManagerAvailability(ctx context.Context) (certmanager.Availability, error) {
 	if m.CertManagerAvailabilityFunc != nil {
 		return m.CertManagerAvailabilityFunc(ctx)
@@ -485,3 +864,31 @@ func (m *mockAutoDetect) GatewayAPIsAvailability() (gatewayapi.ApiAvailability,
 	}
 	return gatewayapi.ApiNotAvailable, nil
 }
+
+func (m *mockAutoDetect) GatewayRouteAvailability(kind string) (gatewayapi.ApiAvailability, error) {
+	if m.GatewayRouteAvailabilityFunc != nil {
+		return m.GatewayRouteAvailabilityFunc(kind)
+	}
+	return gatewayapi.ApiNotAvailable, nil
+}
+
+func (m *mockAutoDetect) KedaAvailability() (keda.Availability, error) {
+	if m.KedaAvailabilityFunc != nil {
+		return m.KedaAvailabilityFunc()
+	}
+	return keda.NotAvailable, nil
+}
+
+func (m *mockAutoDetect) VPAAvailability() (vpa.Availability, error) {
+	if m.VPAAvailabilityFunc != nil {
+		return m.VPAAvailabilityFunc()
+	}
+	return vpa.NotAvailable, nil
+}
+
+func (m *mockAutoDetect) GrafanaDashboardAvailability() (grafana.Availability, error) {
+	if m.GrafanaDashboardAvailabilityFunc != nil {
+		return m.GrafanaDashboardAvailabilityFunc()
+	}
+	return grafana.NotAvailable, nil
+}
@@ -17,6 +17,16 @@ import (
 // CheckRBACPermissions checks if the operator has the needed permissions to create RBAC resources automatically.
 // If the RBAC is there, no errors nor warnings are returned.
 func CheckRBACPermissions(ctx context.Context, reviewer *rbac.Reviewer) (admission.Warnings, error) {
+	return checkPermissions(ctx, reviewer, "clusterrolebindings", "clusterroles")
+}
+
+// CheckRoleRBACPermissions checks if the operator has the needed permissions to create namespaced RBAC resources
+// automatically. If the RBAC is there, no errors nor warnings are returned.
+func CheckRoleRBACPermissions(ctx context.Context, reviewer *rbac.Reviewer) (admission.Warnings, error) {
+	return checkPermissions(ctx, reviewer, "rolebindings", "roles")
+}
+
+func checkPermissions(ctx context.Context, reviewer *rbac.Reviewer, resources ...string) (admission.Warnings, error) {
 	namespace, err := autodetectutils.GetOperatorNamespace()
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", "not possible to check RBAC rules", err)
@@ -30,7 +40,7 @@ func CheckRBACPermissions(ctx context.Context, reviewer *rbac.Reviewer) (admissi
 	rules := []*rbacv1.PolicyRule{
 		{
 			APIGroups: []string{"rbac.authorization.k8s.io"},
-			Resources: []string{"clusterrolebindings", "clusterroles"},
+			Resources: resources,
 			Verbs:     []string{"create", "delete", "get", "list", "patch", "update"},
 		},
 	}
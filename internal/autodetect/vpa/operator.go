@@ -0,0 +1,19 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package vpa
+
+// Availability represents whether the VerticalPodAutoscaler CRD is available.
+type Availability int
+
+const (
+	// NotAvailable represents the autoscaling.k8s.io VerticalPodAutoscaler CRD is not available.
+	NotAvailable Availability = iota
+
+	// Available represents the autoscaling.k8s.io VerticalPodAutoscaler CRD is available.
+	Available
+)
+
+func (p Availability) String() string {
+	return [...]string{"NotAvailable", "Available"}[p]
+}
@@ -24,12 +24,16 @@ type Settings[ComponentConfigType any] struct {
 	defaultRecAddr  string
 	portParser      PortParser[ComponentConfigType]
 	rbacGen         RBACRuleGenerator[ComponentConfigType]
+	roleGen         RoleRuleGenerator[ComponentConfigType]
+	egressGen       EgressGenerator[ComponentConfigType]
+	storageGen      StorageGenerator[ComponentConfigType]
 	livenessGen     ProbeGenerator[ComponentConfigType]
 	readinessGen    ProbeGenerator[ComponentConfigType]
 	startupGen      ProbeGenerator[ComponentConfigType]
 	defaultsApplier Defaulter[ComponentConfigType]
 	envVarGen       EnvVarGenerator[ComponentConfigType]
 	aliases         []string
+	servesTLS       bool
 }
 
 func NewEmptySettings[ComponentConfigType any]() *Settings[ComponentConfigType] {
@@ -124,6 +128,24 @@ func (b Builder[ComponentConfigType]) WithRbacGen(rbacGen RBACRuleGenerator[Comp
 	})
 }
 
+func (b Builder[ComponentConfigType]) WithRoleGen(roleGen RoleRuleGenerator[ComponentConfigType]) Builder[ComponentConfigType] {
+	return append(b, func(o *Settings[ComponentConfigType]) {
+		o.roleGen = roleGen
+	})
+}
+
+func (b Builder[ComponentConfigType]) WithEgressGen(egressGen EgressGenerator[ComponentConfigType]) Builder[ComponentConfigType] {
+	return append(b, func(o *Settings[ComponentConfigType]) {
+		o.egressGen = egressGen
+	})
+}
+
+func (b Builder[ComponentConfigType]) WithStorageGen(storageGen StorageGenerator[ComponentConfigType]) Builder[ComponentConfigType] {
+	return append(b, func(o *Settings[ComponentConfigType]) {
+		o.storageGen = storageGen
+	})
+}
+
 func (b Builder[ComponentConfigType]) WithLivenessGen(livenessGen ProbeGenerator[ComponentConfigType]) Builder[ComponentConfigType] {
 	return append(b, func(o *Settings[ComponentConfigType]) {
 		o.livenessGen = livenessGen
@@ -154,6 +176,14 @@ func (b Builder[ComponentConfigType]) WithDefaultsApplier(defaultsApplier Defaul
 	})
 }
 
+// WithTLSServer marks the component as serving TLS, so that the certificate set with WithTLSCertificate is injected
+// into its configuration.
+func (b Builder[ComponentConfigType]) WithTLSServer() Builder[ComponentConfigType] {
+	return append(b, func(o *Settings[ComponentConfigType]) {
+		o.servesTLS = true
+	})
+}
+
 func (b Builder[ComponentConfigType]) Build() (*GenericParser[ComponentConfigType], error) {
 	o := NewEmptySettings[ComponentConfigType]()
 	o.Apply(b...)
@@ -165,6 +195,9 @@ func (b Builder[ComponentConfigType]) Build() (*GenericParser[ComponentConfigTyp
 		aliases:         o.aliases,
 		portParser:      o.portParser,
 		rbacGen:         o.rbacGen,
+		roleGen:         o.roleGen,
+		egressGen:       o.egressGen,
+		storageGen:      o.storageGen,
 		envVarGen:       o.envVarGen,
 		livenessGen:     o.livenessGen,
 		readinessGen:    o.readinessGen,
@@ -34,6 +34,34 @@ type PortParser[ComponentConfigType any] func(logger logr.Logger, name string, d
 // It's expected that type Config is the configuration used by a parser.
 type RBACRuleGenerator[ComponentConfigType any] func(logger logr.Logger, config ComponentConfigType) ([]rbacv1.PolicyRule, error)
 
+// RoleRules are the RBAC rules a component needs within a single namespace. An empty Namespace stands for the
+// namespace of the collector.
+type RoleRules struct {
+	Namespace string
+	Rules     []rbacv1.PolicyRule
+}
+
+// RoleRuleGenerator is a function that generates the namespaced RBAC Rules given a configuration of type Config.
+// It's expected that type Config is the configuration used by a parser.
+type RoleRuleGenerator[ComponentConfigType any] func(logger logr.Logger, config ComponentConfigType) ([]RoleRules, error)
+
+// EgressGenerator is a function that returns the destinations a component connects to given a configuration of type Config.
+// It's expected that type Config is the configuration used by a parser.
+type EgressGenerator[ComponentConfigType any] func(logger logr.Logger, config ComponentConfigType) ([]EgressDestination, error)
+
+// StorageDirectory is a directory a component of the collector stores its data in.
+type StorageDirectory struct {
+	// Component is the name of the component, of the form "type" or "type/name".
+	Component string
+	// Path is the absolute path of the directory in the collector container.
+	Path string
+}
+
+// StorageGenerator is a function that returns the directories a component stores its data in given a configuration
+// of type Config.
+// It's expected that type Config is the configuration used by a parser.
+type StorageGenerator[ComponentConfigType any] func(logger logr.Logger, config ComponentConfigType) ([]string, error)
+
 // ProbeGenerator is a function that generates a valid probe for a container given Config
 // It's expected that type Config is the configuration used by a parser.
 type ProbeGenerator[ComponentConfigType any] func(logger logr.Logger, config ComponentConfigType) (*corev1.Probe, error)
@@ -46,6 +74,8 @@ type EnvVarGenerator[ComponentConfigType any] func(logger logr.Logger, config Co
 type DefaultConfig struct {
 	// TLSProfile provides TLS settings to inject into components with tls: blocks.
 	TLSProfile TLSProfile
+	// TLSCertificate is the certificate to inject into the components serving TLS.
+	TLSCertificate *TLSCertificate
 }
 
 // DefaultOption is a functional option for configuring defaults behavior.
@@ -60,6 +90,15 @@ func WithTLSProfile(tls TLSProfile) DefaultOption {
 	}
 }
 
+// WithTLSCertificate sets the certificate to use when applying defaults.
+// When set, the certificate files (cert_file, key_file, client_ca_file) are injected
+// into the tls: block of the components serving TLS, creating it when needed.
+func WithTLSCertificate(cert *TLSCertificate) DefaultOption {
+	return func(cfg *DefaultConfig) {
+		cfg.TLSCertificate = cert
+	}
+}
+
 // Defaulter is a function that applies given defaults to the passed Config.
 // It's expected that type Config is the configuration used by a parser.
 type Defaulter[ComponentConfigType any] func(logger logr.Logger, defaultCfg *DefaultConfig, defaultAddr string, defaultPort int32, config ComponentConfigType) (map[string]any, error)
@@ -115,6 +154,16 @@ type Parser interface {
 	// GetRBACRules returns the rbac rules for this component
 	GetRBACRules(logger logr.Logger, config any) ([]rbacv1.PolicyRule, error)
 
+	// GetRoleRules returns the rbac rules this component needs within specific namespaces
+	GetRoleRules(logger logr.Logger, config any) ([]RoleRules, error)
+
+	// GetEgressDestinations returns the network destinations this component connects to, AnyDestination when they
+	// are unknown
+	GetEgressDestinations(logger logr.Logger, config any) ([]EgressDestination, error)
+
+	// GetStorageDirectories returns the directories this component stores its data in
+	GetStorageDirectories(logger logr.Logger, config any) ([]string, error)
+
 	// GetLivenessProbe returns a liveness probe set for the collector
 	GetLivenessProbe(logger logr.Logger, config any) (*corev1.Probe, error)
 
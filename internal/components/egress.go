@@ -0,0 +1,139 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package components
+
+import (
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/go-logr/logr"
+)
+
+// EgressDestination is a network destination a component connects to. The egress rules of the collector's
+// NetworkPolicy are derived from the destinations of its components.
+type EgressDestination struct {
+	// Host is the host name or IP address the component connects to, empty when any address is allowed.
+	Host string
+	// Port is the port the component connects to, 0 when any port is allowed.
+	Port int32
+	// KubernetesAPI is set when the component connects to the Kubernetes API server.
+	KubernetesAPI bool
+	// Pods is set when the component connects to pods discovered at runtime, such as Prometheus scrape targets.
+	Pods bool
+}
+
+var (
+	// KubernetesAPIDestination is the destination of the components watching Kubernetes resources.
+	KubernetesAPIDestination = EgressDestination{KubernetesAPI: true}
+	// AnyDestination is the destination of the components whose destinations can't be derived from their configuration.
+	AnyDestination = EgressDestination{}
+)
+
+// envVarRegexp matches the environment variables the collector expands in its configuration, such as ${env:HOST}.
+var envVarRegexp = regexp.MustCompile(`\$\{[^}]*\}`)
+
+// EndpointDestination returns the destination of the given endpoint, which is either of the form host:port or a URL.
+// The default port is used when the endpoint has none, except for URLs, which default to the port of their scheme.
+// The host or port are left empty when they reference environment variables. It returns false when the endpoint
+// doesn't need network access, such as a unix socket or a loopback address.
+func EndpointDestination(endpoint string, defaultPort int32) (EgressDestination, bool) {
+	endpoint = strings.TrimSpace(endpoint)
+	if endpoint == "" {
+		return EgressDestination{}, false
+	}
+	if scheme, rest, ok := strings.Cut(endpoint, "://"); ok {
+		switch strings.ToLower(scheme) {
+		case "unix", "file":
+			return EgressDestination{}, false
+		case "https":
+			defaultPort = 443
+		case "http":
+			defaultPort = 80
+		}
+		endpoint, _, _ = strings.Cut(rest, "/")
+		if _, hostPort, hasUser := strings.Cut(endpoint, "@"); hasUser {
+			endpoint = hostPort
+		}
+	}
+
+	// hide the environment variables, as their content is only known to the collector
+	const placeholder = "\x00"
+	masked := envVarRegexp.ReplaceAllString(endpoint, placeholder)
+	host, port := masked, ""
+	if h, p, err := net.SplitHostPort(masked); err == nil {
+		host, port = h, p
+	}
+
+	destination := EgressDestination{Port: defaultPort}
+	if !strings.Contains(host, placeholder) {
+		destination.Host = host
+	}
+	if port != "" {
+		destination.Port = 0
+		if p, err := strconv.ParseInt(port, 10, 32); err == nil {
+			destination.Port = int32(p)
+		}
+	}
+	if destination.Host == "localhost" || isLoopback(destination.Host) {
+		return EgressDestination{}, false
+	}
+	return destination, true
+}
+
+func isLoopback(host string) bool {
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// EndpointsDestinations returns the destinations of the endpoints found under the given keys of a component's
+// configuration, which may hold a single endpoint or a list of endpoints. Components connecting to endpoints that
+// can't be found are allowed to connect to any destination.
+func EndpointsDestinations(config any, defaultPort int32, keys ...string) []EgressDestination {
+	cfg, ok := config.(map[string]any)
+	if !ok {
+		return []EgressDestination{AnyDestination}
+	}
+	var endpoints []string
+	for _, key := range keys {
+		switch value := cfg[key].(type) {
+		case string:
+			endpoints = append(endpoints, value)
+		case []any:
+			for _, item := range value {
+				switch item := item.(type) {
+				case string:
+					endpoints = append(endpoints, item)
+				case map[string]any:
+					if endpoint, isString := item["endpoint"].(string); isString {
+						endpoints = append(endpoints, endpoint)
+					}
+				}
+			}
+		}
+	}
+	if len(endpoints) == 0 {
+		return []EgressDestination{AnyDestination}
+	}
+
+	var destinations []EgressDestination
+	for _, endpoint := range endpoints {
+		if destination, needed := EndpointDestination(endpoint, defaultPort); needed {
+			destinations = append(destinations, destination)
+		}
+	}
+	return destinations
+}
+
+// GenerateNoEgress is the egress generator of the components that don't connect anywhere, such as the receivers
+// listening for telemetry. The components without an egress generator are allowed to connect to any destination.
+func GenerateNoEgress[ComponentConfigType any](logr.Logger, ComponentConfigType) ([]EgressDestination, error) {
+	return nil, nil
+}
+
+// GenerateKubernetesAPIEgress returns the Kubernetes API server as the only destination of a component.
+func GenerateKubernetesAPIEgress[ComponentConfigType any](logr.Logger, ComponentConfigType) ([]EgressDestination, error) {
+	return []EgressDestination{KubernetesAPIDestination}, nil
+}
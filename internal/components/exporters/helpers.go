@@ -4,19 +4,36 @@
 package exporters
 
 import (
+	"github.com/go-logr/logr"
+
 	"github.com/open-telemetry/opentelemetry-operator/internal/components"
 )
 
 // registry holds a record of all known receiver parsers.
 var registry = map[string]components.Parser{
-	"prometheus": components.NewSinglePortParserBuilder("prometheus", 8888).MustBuild(),
+	"prometheus":    components.NewSinglePortParserBuilder("prometheus", 8888).MustBuild(),
+	"loadbalancing": components.NewBuilder[loadBalancingConfig]().WithName("loadbalancing").WithEgressGen(generateLoadBalancingEgress).WithRoleGen(generateLoadBalancingRoleRules).MustBuild(),
+	// the exporters writing locally don't connect to any endpoint
+	"debug": components.NewBuilder[any]().WithName("debug").WithEgressGen(components.GenerateNoEgress[any]).MustBuild(),
+	"nop":   components.NewBuilder[any]().WithName("nop").WithEgressGen(components.GenerateNoEgress[any]).MustBuild(),
+	"file":  components.NewBuilder[any]().WithName("file").WithEgressGen(components.GenerateNoEgress[any]).MustBuild(),
 }
 
+// endpointKeys are the configuration keys the exporters hold the endpoints they send to under.
+var endpointKeys = []string{"endpoint", "endpoints", "traces_endpoint", "metrics_endpoint", "logs_endpoint", "brokers"}
+
 // ParserFor returns a parser builder for the given exporter name.
 func ParserFor(name string) components.Parser {
 	if parser, ok := registry[components.ComponentType(name)]; ok {
 		return parser
 	}
 	// We want the default for exporters to fail silently.
-	return components.NewBuilder[any]().WithName(name).MustBuild()
+	return components.NewBuilder[any]().WithName(name).WithEgressGen(generateEndpointsEgress).MustBuild()
+}
+
+// generateEndpointsEgress returns the destinations of the endpoints an exporter sends to. Exporters whose endpoints
+// can't be found in their configuration, such as the vendor exporters with a built-in endpoint, are allowed to
+// connect to any destination.
+func generateEndpointsEgress(_ logr.Logger, config any) ([]components.EgressDestination, error) {
+	return components.EndpointsDestinations(config, components.UnsetPort, endpointKeys...), nil
 }
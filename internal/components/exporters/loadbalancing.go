@@ -0,0 +1,95 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exporters
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/go-logr/logr"
+	rbacv1 "k8s.io/api/rbac/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/internal/components"
+)
+
+// defaultOTLPGrpcPort is the port the loadbalancing exporter sends to when the resolved backends have none.
+const defaultOTLPGrpcPort = 4317
+
+// loadBalancingConfig represents the loadbalancing exporter config relevant for its egress destinations.
+type loadBalancingConfig struct {
+	Resolver loadBalancingResolverConfig `mapstructure:"resolver"`
+}
+
+type loadBalancingResolverConfig struct {
+	Static *struct {
+		Hostnames []string `mapstructure:"hostnames"`
+	} `mapstructure:"static"`
+	DNS *struct {
+		Hostname string `mapstructure:"hostname"`
+		Port     string `mapstructure:"port"`
+	} `mapstructure:"dns"`
+	K8s *struct {
+		Service string `mapstructure:"service"`
+	} `mapstructure:"k8s"`
+}
+
+// generateLoadBalancingEgress returns the backends the loadbalancing exporter resolves. The backends discovered at
+// runtime through a cloud provider are only known to the collector, so any destination is allowed for them.
+func generateLoadBalancingEgress(_ logr.Logger, config loadBalancingConfig) ([]components.EgressDestination, error) {
+	resolver := config.Resolver
+	var destinations []components.EgressDestination
+	switch {
+	case resolver.Static != nil:
+		for _, hostname := range resolver.Static.Hostnames {
+			if destination, needed := components.EndpointDestination(hostname, defaultOTLPGrpcPort); needed {
+				destinations = append(destinations, destination)
+			}
+		}
+	case resolver.DNS != nil:
+		port := int32(defaultOTLPGrpcPort)
+		if resolver.DNS.Port != "" {
+			p, err := strconv.ParseInt(resolver.DNS.Port, 10, 32)
+			if err != nil {
+				return []components.EgressDestination{components.AnyDestination}, nil
+			}
+			port = int32(p)
+		}
+		destinations = append(destinations, components.EgressDestination{Host: resolver.DNS.Hostname, Port: port})
+	case resolver.K8s != nil:
+		// the endpoints of the service are watched from the Kubernetes API, and the service defaults to the
+		// namespace of the collector
+		destinations = append(destinations, components.KubernetesAPIDestination)
+		service := resolver.K8s.Service
+		if strings.Count(service, ".") == 1 {
+			// the service is referenced as name.namespace
+			service += ".svc"
+		}
+		destinations = append(destinations, components.EgressDestination{Host: service})
+	default:
+		destinations = append(destinations, components.AnyDestination)
+	}
+	return destinations, nil
+}
+
+// generateLoadBalancingRoleRules returns the rules the k8s resolver needs to watch the endpoints of the service, in
+// the namespace of the service.
+func generateLoadBalancingRoleRules(_ logr.Logger, config loadBalancingConfig) ([]components.RoleRules, error) {
+	if config.Resolver.K8s == nil || config.Resolver.K8s.Service == "" {
+		return nil, nil
+	}
+	var namespace string
+	if parts := strings.Split(config.Resolver.K8s.Service, "."); len(parts) > 1 {
+		namespace = parts[1]
+	}
+	return []components.RoleRules{{
+		Namespace: namespace,
+		Rules: []rbacv1.PolicyRule{
+			{
+				APIGroups: []string{"discovery.k8s.io"},
+				Resources: []string{"endpointslices"},
+				Verbs:     []string{"get", "list", "watch"},
+			},
+		},
+	}}, nil
+}
@@ -0,0 +1,116 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exporters
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	rbacv1 "k8s.io/api/rbac/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/internal/components"
+)
+
+func TestLoadBalancingEgressDestinations(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		resolver map[string]any
+		expected []components.EgressDestination
+	}{
+		{
+			name: "static",
+			resolver: map[string]any{
+				"static": map[string]any{"hostnames": []any{"backend-1:4317", "backend-2.example.com"}},
+			},
+			expected: []components.EgressDestination{
+				{Host: "backend-1", Port: 4317},
+				{Host: "backend-2.example.com", Port: 4317},
+			},
+		},
+		{
+			name: "dns",
+			resolver: map[string]any{
+				"dns": map[string]any{"hostname": "backends.observability.svc.cluster.local", "port": "5317"},
+			},
+			expected: []components.EgressDestination{
+				{Host: "backends.observability.svc.cluster.local", Port: 5317},
+			},
+		},
+		{
+			name: "k8s",
+			resolver: map[string]any{
+				"k8s": map[string]any{"service": "backends.observability"},
+			},
+			expected: []components.EgressDestination{
+				components.KubernetesAPIDestination,
+				{Host: "backends.observability.svc"},
+			},
+		},
+		{
+			name: "aws cloud map",
+			resolver: map[string]any{
+				"aws_cloud_map": map[string]any{"namespace": "backends"},
+			},
+			expected: []components.EgressDestination{components.AnyDestination},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			parser := ParserFor("loadbalancing")
+			destinations, err := parser.GetEgressDestinations(logr.Discard(), map[string]any{
+				"protocol": map[string]any{"otlp": map[string]any{}},
+				"resolver": tt.resolver,
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, destinations)
+		})
+	}
+}
+
+func TestLoadBalancingRoleRules(t *testing.T) {
+	endpointSlices := []rbacv1.PolicyRule{
+		{
+			APIGroups: []string{"discovery.k8s.io"},
+			Resources: []string{"endpointslices"},
+			Verbs:     []string{"get", "list", "watch"},
+		},
+	}
+	for _, tt := range []struct {
+		name     string
+		resolver map[string]any
+		expected []components.RoleRules
+	}{
+		{
+			name: "dns",
+			resolver: map[string]any{
+				"dns": map[string]any{"hostname": "backends.observability.svc.cluster.local"},
+			},
+		},
+		{
+			name: "k8s service in the collector namespace",
+			resolver: map[string]any{
+				"k8s": map[string]any{"service": "backends"},
+			},
+			expected: []components.RoleRules{{Rules: endpointSlices}},
+		},
+		{
+			name: "k8s service in another namespace",
+			resolver: map[string]any{
+				"k8s": map[string]any{"service": "backends.observability"},
+			},
+			expected: []components.RoleRules{{Namespace: "observability", Rules: endpointSlices}},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			parser := ParserFor("loadbalancing")
+			rules, err := parser.GetRoleRules(logr.Discard(), map[string]any{
+				"protocol": map[string]any{"otlp": map[string]any{}},
+				"resolver": tt.resolver,
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, rules)
+		})
+	}
+}
@@ -0,0 +1,35 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package extensions
+
+import (
+	"github.com/go-logr/logr"
+)
+
+// defaultFileStorageDirectory is the directory the file_storage extension stores its data in on Linux when none is
+// configured.
+const defaultFileStorageDirectory = "/var/lib/otelcol/file_storage"
+
+type fileStorageConfig struct {
+	Directory  string                      `mapstructure:"directory"`
+	Compaction fileStorageCompactionConfig `mapstructure:"compaction"`
+}
+
+type fileStorageCompactionConfig struct {
+	Directory string `mapstructure:"directory"`
+}
+
+// generateFileStorageDirectories returns the directory of the extension, and the directory of its compactions when it
+// differs.
+func generateFileStorageDirectories(_ logr.Logger, config fileStorageConfig) ([]string, error) {
+	directory := config.Directory
+	if directory == "" {
+		directory = defaultFileStorageDirectory
+	}
+	directories := []string{directory}
+	if config.Compaction.Directory != "" && config.Compaction.Directory != directory {
+		directories = append(directories, config.Compaction.Directory)
+	}
+	return directories, nil
+}
@@ -0,0 +1,59 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package extensions
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileStorageDirectories(t *testing.T) {
+	tests := []struct {
+		name   string
+		config any
+		want   []string
+	}{
+		{
+			name:   "default directory",
+			config: map[string]any{},
+			want:   []string{"/var/lib/otelcol/file_storage"},
+		},
+		{
+			name:   "nil config",
+			config: nil,
+			want:   []string{"/var/lib/otelcol/file_storage"},
+		},
+		{
+			name:   "configured directory",
+			config: map[string]any{"directory": "/data/queue"},
+			want:   []string{"/data/queue"},
+		},
+		{
+			name: "compaction in the same directory",
+			config: map[string]any{
+				"directory":  "/data/queue",
+				"compaction": map[string]any{"directory": "/data/queue", "on_start": true},
+			},
+			want: []string{"/data/queue"},
+		},
+		{
+			name: "compaction in another directory",
+			config: map[string]any{
+				"directory":  "/data/queue",
+				"compaction": map[string]any{"directory": "/tmp/compaction"},
+			},
+			want: []string{"/data/queue", "/tmp/compaction"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			directories, err := ParserFor("file_storage/queue").GetStorageDirectories(logr.Discard(), tt.config)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, directories)
+		})
+	}
+}
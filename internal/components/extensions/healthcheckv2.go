@@ -0,0 +1,102 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package extensions
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/go-logr/logr"
+	"github.com/mitchellh/mapstructure"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/internal/components"
+)
+
+const (
+	// HealthCheckV2Type is the type of the healthcheckv2 extension.
+	HealthCheckV2Type = "healthcheckv2"
+
+	defaultHealthcheckV2StatusPath = "/status"
+)
+
+// healthcheckV2Config is the configuration of the healthcheckv2 extension. Without use_v2, the extension runs in a
+// legacy mode configured like the health_check extension.
+type healthcheckV2Config struct {
+	healthcheckV1Config `mapstructure:",squash"`
+	UseV2               bool                     `mapstructure:"use_v2"`
+	HTTP                *healthcheckV2HTTPConfig `mapstructure:"http"`
+}
+
+type healthcheckV2HTTPConfig struct {
+	components.SingleEndpointConfig `mapstructure:",squash"`
+	Status                          healthcheckV2PathConfig `mapstructure:"status"`
+}
+
+type healthcheckV2PathConfig struct {
+	Enabled *bool  `mapstructure:"enabled"`
+	Path    string `mapstructure:"path"`
+}
+
+// httpEndpoint returns the endpoint of the HTTP server of the extension.
+func (c healthcheckV2Config) httpEndpoint() *components.SingleEndpointConfig {
+	if !c.UseV2 {
+		return &c.SingleEndpointConfig
+	}
+	if c.HTTP == nil {
+		return &components.SingleEndpointConfig{}
+	}
+	return &c.HTTP.SingleEndpointConfig
+}
+
+func healthCheckV2AddressDefaulter(logger logr.Logger, defaultConfig *components.DefaultConfig, defaultRecAddr string, port int32, config healthcheckV2Config) (map[string]any, error) {
+	if !config.UseV2 {
+		return healthCheckV1AddressDefaulter(logger, defaultConfig, defaultRecAddr, port, config.healthcheckV1Config)
+	}
+
+	// the HTTP server listens on localhost by default, which the operator can't reach to read the component status
+	httpConfig := config.HTTP
+	if httpConfig == nil {
+		httpConfig = &healthcheckV2HTTPConfig{}
+	}
+	if httpConfig.Endpoint == "" {
+		httpConfig.Endpoint = fmt.Sprintf("%s:%d", defaultRecAddr, port)
+	} else {
+		h, p, err := net.SplitHostPort(httpConfig.Endpoint)
+		if err == nil && h == "" && p != "" {
+			httpConfig.Endpoint = fmt.Sprintf("%s:%s", defaultRecAddr, p)
+		}
+	}
+	httpConfig.TLS.ApplyTLSProfileDefaults(defaultConfig.TLSProfile)
+
+	httpRes := make(map[string]any)
+	if err := mapstructure.Decode(httpConfig.SingleEndpointConfig, &httpRes); err != nil {
+		return nil, err
+	}
+	return map[string]any{"http": httpRes}, nil
+}
+
+func healthCheckV2Ports(logger logr.Logger, name string, defaultPort *corev1.ServicePort, config healthcheckV2Config) ([]corev1.ServicePort, error) {
+	return components.ParseSingleEndpointSilent(logger, name, defaultPort, config.httpEndpoint())
+}
+
+// HealthCheckV2StatusEndpoint returns the port and the path of the component status endpoint of a healthcheckv2
+// extension with the given configuration, and whether the extension serves it.
+func HealthCheckV2StatusEndpoint(logger logr.Logger, config any) (int32, string, bool, error) {
+	var parsed healthcheckV2Config
+	if err := mapstructure.Decode(config, &parsed); err != nil {
+		return 0, "", false, err
+	}
+	if !parsed.UseV2 {
+		return 0, "", false, nil
+	}
+	if parsed.HTTP != nil && parsed.HTTP.Status.Enabled != nil && !*parsed.HTTP.Status.Enabled {
+		return 0, "", false, nil
+	}
+	path := defaultHealthcheckV2StatusPath
+	if parsed.HTTP != nil && parsed.HTTP.Status.Path != "" {
+		path = parsed.HTTP.Status.Path
+	}
+	return parsed.httpEndpoint().GetPortNumOrDefault(logger, defaultHealthcheckV1Port), path, true, nil
+}
@@ -0,0 +1,103 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package extensions
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealthCheckV2Ports(t *testing.T) {
+	tests := []struct {
+		name   string
+		config any
+		want   int32
+	}{
+		{name: "default", config: map[string]any{"use_v2": true}, want: 13133},
+		{name: "http endpoint", config: map[string]any{"use_v2": true, "http": map[string]any{"endpoint": "0.0.0.0:8080"}}, want: 8080},
+		{name: "legacy endpoint", config: map[string]any{"endpoint": "0.0.0.0:9090"}, want: 9090},
+		{name: "legacy endpoint ignored in v2", config: map[string]any{"use_v2": true, "endpoint": "0.0.0.0:9090"}, want: 13133},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ports, err := ParserFor("healthcheckv2").Ports(logr.Discard(), "healthcheckv2", tt.config)
+			require.NoError(t, err)
+			require.Len(t, ports, 1)
+			assert.Equal(t, tt.want, ports[0].Port)
+		})
+	}
+}
+
+func TestHealthCheckV2Defaults(t *testing.T) {
+	tests := []struct {
+		name   string
+		config any
+		want   map[string]any
+	}{
+		{
+			name:   "v2 without http",
+			config: map[string]any{"use_v2": true},
+			want:   map[string]any{"http": map[string]any{"endpoint": "0.0.0.0:13133"}},
+		},
+		{
+			name:   "v2 with a port only",
+			config: map[string]any{"use_v2": true, "http": map[string]any{"endpoint": ":8080"}},
+			want:   map[string]any{"http": map[string]any{"endpoint": "0.0.0.0:8080"}},
+		},
+		{
+			name:   "v2 with localhost",
+			config: map[string]any{"use_v2": true, "http": map[string]any{"endpoint": "localhost:13133"}},
+			want:   map[string]any{"http": map[string]any{"endpoint": "localhost:13133"}},
+		},
+		{
+			name:   "legacy",
+			config: map[string]any{},
+			want:   map[string]any{"endpoint": "0.0.0.0:13133", "path": "/"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParserFor("healthcheckv2").GetDefaultConfig(logr.Discard(), tt.config)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestHealthCheckV2StatusEndpoint(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   any
+		wantPort int32
+		wantPath string
+		wantOk   bool
+	}{
+		{name: "legacy", config: map[string]any{}},
+		{name: "default", config: map[string]any{"use_v2": true}, wantPort: 13133, wantPath: "/status", wantOk: true},
+		{
+			name: "custom",
+			config: map[string]any{"use_v2": true, "http": map[string]any{
+				"endpoint": "0.0.0.0:8080",
+				"status":   map[string]any{"enabled": true, "path": "/health/status"},
+			}},
+			wantPort: 8080, wantPath: "/health/status", wantOk: true,
+		},
+		{
+			name:   "status disabled",
+			config: map[string]any{"use_v2": true, "http": map[string]any{"status": map[string]any{"enabled": false}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			port, path, ok, err := HealthCheckV2StatusEndpoint(logr.Discard(), tt.config)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantOk, ok)
+			assert.Equal(t, tt.wantPort, port)
+			assert.Equal(t, tt.wantPath, path)
+		})
+	}
+}
@@ -12,6 +12,11 @@ import (
 
 // registry holds a record of all known receiver parsers.
 var registry = map[string]components.Parser{
+	"file_storage": components.NewBuilder[fileStorageConfig]().
+		WithName("file_storage").
+		WithStorageGen(generateFileStorageDirectories).
+		WithEgressGen(components.GenerateNoEgress[fileStorageConfig]).
+		MustBuild(),
 	"health_check": components.NewBuilder[healthcheckV1Config]().
 		WithName("health_check").
 		WithPort(defaultHealthcheckV1Port).
@@ -23,17 +28,40 @@ var registry = map[string]components.Parser{
 		WithPortParser(func(logger logr.Logger, name string, defaultPort *corev1.ServicePort, config healthcheckV1Config) ([]corev1.ServicePort, error) {
 			return components.ParseSingleEndpointSilent(logger, name, defaultPort, &config.SingleEndpointConfig)
 		}).
+		WithEgressGen(components.GenerateNoEgress[healthcheckV1Config]).
+		MustBuild(),
+	HealthCheckV2Type: components.NewBuilder[healthcheckV2Config]().
+		WithName(HealthCheckV2Type).
+		WithPort(defaultHealthcheckV1Port).
+		WithDefaultsApplier(healthCheckV2AddressDefaulter).
+		WithDefaultRecAddress(components.DefaultRecAddress).
+		WithPortParser(healthCheckV2Ports).
+		WithEgressGen(components.GenerateNoEgress[healthcheckV2Config]).
 		MustBuild(),
 	"jaeger_query": NewJaegerQueryExtensionParserBuilder().
 		MustBuild(),
 	"k8s_leader_elector": components.NewBuilder[any]().
 		WithName("k8s_leader_elector").
 		WithRbacGen(generatek8sleaderelectorRbacRules).
+		WithEgressGen(components.GenerateKubernetesAPIEgress[any]).
 		MustBuild(),
 	"k8s_observer": components.NewBuilder[k8sobserverConfig]().
 		WithName("k8s_observer").
 		WithRbacGen(generatek8sobserverRbacRules).
+		WithRoleGen(generatek8sobserverRoleRules).
+		WithEgressGen(components.GenerateKubernetesAPIEgress[k8sobserverConfig]).
 		MustBuild(),
+	// the extensions serving locally don't connect anywhere, unlike the unknown extensions, such as oauth2client
+	"pprof":           localExtension("pprof"),
+	"zpages":          localExtension("zpages"),
+	"basicauth":       localExtension("basicauth"),
+	"bearertokenauth": localExtension("bearertokenauth"),
+	"headers_setter":  localExtension("headers_setter"),
+}
+
+// localExtension returns the parser of an extension that doesn't connect anywhere.
+func localExtension(name string) components.Parser {
+	return components.NewBuilder[any]().WithName(name).WithEgressGen(components.GenerateNoEgress[any]).MustBuild()
 }
 
 // ParserFor returns a parser builder for the given exporter name.
@@ -6,18 +6,22 @@ package extensions
 import (
 	"github.com/go-logr/logr"
 	rbacv1 "k8s.io/api/rbac/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/internal/components"
 )
 
 type k8sobserverConfig struct {
-	ObservePods     bool `mapstructure:"observe_pods"`
-	ObserveServices bool `mapstructure:"observe_services"`
-	ObserveNodes    bool `mapstructure:"observe_nodes"`
+	ObservePods      bool     `mapstructure:"observe_pods"`
+	ObserveServices  bool     `mapstructure:"observe_services"`
+	ObserveNodes     bool     `mapstructure:"observe_nodes"`
+	ObserveIngresses bool     `mapstructure:"observe_ingresses"`
+	Namespaces       []string `mapstructure:"namespaces"`
 }
 
-func generatek8sobserverRbacRules(_ logr.Logger, config k8sobserverConfig) ([]rbacv1.PolicyRule, error) {
-	prs := []rbacv1.PolicyRule{}
-
-	if config.ObservePods {
+// namespacedRules returns the rules of the namespaced resources the observer watches.
+func (c k8sobserverConfig) namespacedRules() []rbacv1.PolicyRule {
+	var prs []rbacv1.PolicyRule
+	if c.ObservePods {
 		prs = append(prs, rbacv1.PolicyRule{
 			APIGroups: []string{""},
 			Resources: []string{"pods"},
@@ -25,7 +29,7 @@ func generatek8sobserverRbacRules(_ logr.Logger, config k8sobserverConfig) ([]rb
 		})
 	}
 
-	if config.ObserveServices {
+	if c.ObserveServices {
 		prs = append(prs, rbacv1.PolicyRule{
 			APIGroups: []string{""},
 			Resources: []string{"services"},
@@ -33,6 +37,24 @@ func generatek8sobserverRbacRules(_ logr.Logger, config k8sobserverConfig) ([]rb
 		})
 	}
 
+	if c.ObserveIngresses {
+		prs = append(prs, rbacv1.PolicyRule{
+			APIGroups: []string{"networking.k8s.io"},
+			Resources: []string{"ingresses"},
+			Verbs:     []string{"list", "watch"},
+		})
+	}
+	return prs
+}
+
+func generatek8sobserverRbacRules(_ logr.Logger, config k8sobserverConfig) ([]rbacv1.PolicyRule, error) {
+	prs := []rbacv1.PolicyRule{}
+
+	// the namespaced resources are only watched cluster-wide when the observer isn't limited to some namespaces
+	if len(config.Namespaces) == 0 {
+		prs = append(prs, config.namespacedRules()...)
+	}
+
 	if config.ObserveNodes {
 		prs = append(prs, rbacv1.PolicyRule{
 			APIGroups: []string{""},
@@ -43,3 +65,15 @@ func generatek8sobserverRbacRules(_ logr.Logger, config k8sobserverConfig) ([]rb
 
 	return prs, nil
 }
+
+func generatek8sobserverRoleRules(_ logr.Logger, config k8sobserverConfig) ([]components.RoleRules, error) {
+	rules := config.namespacedRules()
+	if len(config.Namespaces) == 0 || len(rules) == 0 {
+		return nil, nil
+	}
+	var roleRules []components.RoleRules
+	for _, namespace := range config.Namespaces {
+		roleRules = append(roleRules, components.RoleRules{Namespace: namespace, Rules: rules})
+	}
+	return roleRules, nil
+}
@@ -9,6 +9,8 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/stretchr/testify/assert"
 	rbacv1 "k8s.io/api/rbac/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/internal/components"
 )
 
 func TestGeneratek8sobserverRbacRules(t *testing.T) {
@@ -92,6 +94,28 @@ func TestGeneratek8sobserverRbacRules(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:   "ingresses only",
+			config: k8sobserverConfig{ObserveIngresses: true},
+			want: []rbacv1.PolicyRule{
+				{
+					APIGroups: []string{"networking.k8s.io"},
+					Resources: []string{"ingresses"},
+					Verbs:     []string{"list", "watch"},
+				},
+			},
+		},
+		{
+			name:   "namespaced with nodes",
+			config: k8sobserverConfig{ObservePods: true, ObserveNodes: true, Namespaces: []string{"apps"}},
+			want: []rbacv1.PolicyRule{
+				{
+					APIGroups: []string{""},
+					Resources: []string{"nodes"},
+					Verbs:     []string{"list", "watch"},
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -102,3 +126,43 @@ func TestGeneratek8sobserverRbacRules(t *testing.T) {
 		})
 	}
 }
+
+func TestGeneratek8sobserverRoleRules(t *testing.T) {
+	podRules := []rbacv1.PolicyRule{
+		{
+			APIGroups: []string{""},
+			Resources: []string{"pods"},
+			Verbs:     []string{"list", "watch"},
+		},
+	}
+	tests := []struct {
+		name   string
+		config k8sobserverConfig
+		want   []components.RoleRules
+	}{
+		{
+			name:   "all namespaces",
+			config: k8sobserverConfig{ObservePods: true},
+		},
+		{
+			name:   "nodes only",
+			config: k8sobserverConfig{ObserveNodes: true, Namespaces: []string{"apps"}},
+		},
+		{
+			name:   "namespaces",
+			config: k8sobserverConfig{ObservePods: true, ObserveNodes: true, Namespaces: []string{"apps", "batch"}},
+			want: []components.RoleRules{
+				{Namespace: "apps", Rules: podRules},
+				{Namespace: "batch", Rules: podRules},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := generatek8sobserverRoleRules(logr.Logger{}, tt.config)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
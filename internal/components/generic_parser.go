@@ -22,6 +22,9 @@ type GenericParser[T any] struct {
 	settings        *Settings[T]
 	portParser      PortParser[T]
 	rbacGen         RBACRuleGenerator[T]
+	roleGen         RoleRuleGenerator[T]
+	egressGen       EgressGenerator[T]
+	storageGen      StorageGenerator[T]
 	envVarGen       EnvVarGenerator[T]
 	livenessGen     ProbeGenerator[T]
 	readinessGen    ProbeGenerator[T]
@@ -45,6 +48,9 @@ func (g *GenericParser[T]) GetDefaultConfig(logger logr.Logger, config any, opts
 			opt(defaultCfg)
 		}
 	}
+	if !g.settings.servesTLS {
+		defaultCfg.TLSCertificate = nil
+	}
 
 	var parsed T
 	if err := mapstructure.Decode(config, &parsed); err != nil {
@@ -97,6 +103,40 @@ func (g *GenericParser[T]) GetRBACRules(logger logr.Logger, config any) ([]rbacv
 	return g.rbacGen(logger, parsed)
 }
 
+func (g *GenericParser[T]) GetRoleRules(logger logr.Logger, config any) ([]RoleRules, error) {
+	if g.roleGen == nil {
+		return nil, nil
+	}
+	var parsed T
+	if err := mapstructure.Decode(config, &parsed); err != nil {
+		return nil, err
+	}
+	return g.roleGen(logger, parsed)
+}
+
+func (g *GenericParser[T]) GetEgressDestinations(logger logr.Logger, config any) ([]EgressDestination, error) {
+	if g.egressGen == nil {
+		// the destinations of the component are unknown
+		return []EgressDestination{AnyDestination}, nil
+	}
+	var parsed T
+	if err := mapstructure.Decode(config, &parsed); err != nil {
+		return nil, err
+	}
+	return g.egressGen(logger, parsed)
+}
+
+func (g *GenericParser[T]) GetStorageDirectories(logger logr.Logger, config any) ([]string, error) {
+	if g.storageGen == nil {
+		return nil, nil
+	}
+	var parsed T
+	if err := mapstructure.Decode(config, &parsed); err != nil {
+		return nil, err
+	}
+	return g.storageGen(logger, parsed)
+}
+
 func (g *GenericParser[T]) GetEnvironmentVariables(logger logr.Logger, config any) ([]corev1.EnvVar, error) {
 	if g.envVarGen == nil {
 		return nil, nil
@@ -33,6 +33,8 @@ type MultiPortReceiver struct {
 
 	addrMappings map[string]string
 	portMappings map[string]*corev1.ServicePort
+	tlsProtocols map[string]bool
+	egressGen    func(logger logr.Logger, config any) ([]EgressDestination, error)
 }
 
 func (m *MultiPortReceiver) Ports(logger logr.Logger, name string, config any) ([]corev1.ServicePort, error) {
@@ -95,7 +97,11 @@ func (m *MultiPortReceiver) GetDefaultConfig(logger logr.Logger, config any, opt
 		if defaultAddr, ok := m.addrMappings[protocol]; ok {
 			addr = defaultAddr
 		}
-		conf, err := AddressDefaulter(logger, defaultCfg, addr, port, ec)
+		protocolCfg := *defaultCfg
+		if !m.tlsProtocols[protocol] {
+			protocolCfg.TLSCertificate = nil
+		}
+		conf, err := AddressDefaulter(logger, &protocolCfg, addr, port, ec)
 		if err != nil {
 			return nil, err
 		}
@@ -118,6 +124,22 @@ func (*MultiPortReceiver) GetStartupProbe(logr.Logger, any) (*corev1.Probe, erro
 	return nil, nil
 }
 
+func (*MultiPortReceiver) GetRoleRules(logr.Logger, any) ([]RoleRules, error) {
+	return nil, nil
+}
+
+func (m *MultiPortReceiver) GetEgressDestinations(logger logr.Logger, config any) ([]EgressDestination, error) {
+	if m.egressGen == nil {
+		// the destinations of the receiver are unknown
+		return []EgressDestination{AnyDestination}, nil
+	}
+	return m.egressGen(logger, config)
+}
+
+func (*MultiPortReceiver) GetStorageDirectories(logr.Logger, any) ([]string, error) {
+	return nil, nil
+}
+
 func (*MultiPortReceiver) GetRBACRules(logr.Logger, any) ([]rbacv1.PolicyRule, error) {
 	return nil, nil
 }
@@ -140,6 +162,14 @@ func (mp MultiPortBuilder[ComponentConfigType]) AddPortMapping(builder Builder[C
 	return append(mp, builder)
 }
 
+// WithEgressGen sets the destinations the receiver connects to, which are otherwise unknown.
+func (mp MultiPortBuilder[ComponentConfigType]) WithEgressGen(egressGen EgressGenerator[ComponentConfigType]) MultiPortBuilder[ComponentConfigType] {
+	if len(mp) < 1 {
+		return mp
+	}
+	return append(MultiPortBuilder[ComponentConfigType]{mp[0].WithEgressGen(egressGen)}, mp[1:]...)
+}
+
 func (mp MultiPortBuilder[ComponentConfigType]) Build() (*MultiPortReceiver, error) {
 	if len(mp) < 1 {
 		return nil, errors.New("must provide at least one port mapping")
@@ -151,6 +181,10 @@ func (mp MultiPortBuilder[ComponentConfigType]) Build() (*MultiPortReceiver, err
 		defaultRecAddr: mb.settings.defaultRecAddr,
 		addrMappings:   map[string]string{},
 		portMappings:   map[string]*corev1.ServicePort{},
+		tlsProtocols:   map[string]bool{},
+	}
+	if mb.egressGen != nil {
+		multiReceiver.egressGen = mb.GetEgressDestinations
 	}
 	for _, bu := range mp[1:] {
 		built, err := bu.Build()
@@ -160,6 +194,7 @@ func (mp MultiPortBuilder[ComponentConfigType]) Build() (*MultiPortReceiver, err
 		if built.settings != nil {
 			multiReceiver.portMappings[built.name] = built.settings.GetServicePort()
 			multiReceiver.addrMappings[built.name] = built.settings.defaultRecAddr
+			multiReceiver.tlsProtocols[built.name] = built.settings.servesTLS
 		}
 	}
 	return multiReceiver, nil
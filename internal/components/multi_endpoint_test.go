@@ -9,6 +9,7 @@ import (
 
 	"github.com/go-logr/logr"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 
@@ -516,6 +517,39 @@ func TestMultiPortReceiver_GetDefaultConfig(t *testing.T) {
 	}
 }
 
+func TestMultiPortReceiver_GetDefaultConfigTLSCertificate(t *testing.T) {
+	m := components.NewMultiPortReceiverBuilder("receiver1").
+		AddPortMapping(components.NewProtocolBuilder("grpc", 90).
+			WithTargetPort(8080).
+			WithTLSServer()).
+		AddPortMapping(components.NewProtocolBuilder("udp", 91).
+			WithTargetPort(8081)).MustBuild()
+	cert := &components.TLSCertificate{CertFile: "/tls/tls.crt", KeyFile: "/tls/tls.key", ReloadInterval: "1h0m0s"}
+
+	got, err := m.GetDefaultConfig(logr.Discard(), map[string]any{
+		"protocols": map[string]any{
+			"grpc": nil,
+			"udp":  nil,
+		},
+	}, components.WithTLSCertificate(cert))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"protocols": map[string]any{
+			"grpc": map[string]any{
+				"endpoint": "0.0.0.0:90",
+				"tls": map[string]any{
+					"cert_file":       "/tls/tls.crt",
+					"key_file":        "/tls/tls.key",
+					"reload_interval": "1h0m0s",
+				},
+			},
+			"udp": map[string]any{
+				"endpoint": "0.0.0.0:91",
+			},
+		},
+	}, got)
+}
+
 func TestMultiMustBuildPanics(t *testing.T) {
 	b := components.MultiPortBuilder[*components.MultiProtocolEndpointConfig]{}
 	assert.Panics(t, func() {
@@ -24,16 +24,17 @@ func ProcessorFor(name string) components.Parser {
 	if parser, ok := registry[components.ComponentType(name)]; ok {
 		return parser
 	}
-	return components.NewBuilder[any]().WithName(name).MustBuild()
+	// the processors work on the telemetry in memory, the ones connecting anywhere are registered
+	return components.NewBuilder[any]().WithName(name).WithEgressGen(components.GenerateNoEgress[any]).MustBuild()
 }
 
 var componentParsers = []components.Parser{
 	// k8s_attributes, formerly k8sattributes
 	// (open-telemetry/opentelemetry-collector-contrib#45901, see #4922).
-	components.NewBuilder[K8sAttributeConfig]().WithName("k8s_attributes").WithRbacGen(GenerateK8SAttrRbacRules).WithAlias("k8sattributes").MustBuild(),
+	components.NewBuilder[K8sAttributeConfig]().WithName("k8s_attributes").WithRbacGen(GenerateK8SAttrRbacRules).WithEgressGen(components.GenerateKubernetesAPIEgress[K8sAttributeConfig]).WithAlias("k8sattributes").MustBuild(),
 	// resource_detection, formerly resourcedetection
 	// (open-telemetry/opentelemetry-collector-contrib#48525).
-	components.NewBuilder[ResourceDetectionConfig]().WithName("resource_detection").WithRbacGen(GenerateResourceDetectionRbacRules).WithAlias("resourcedetection").MustBuild(),
+	components.NewBuilder[ResourceDetectionConfig]().WithName("resource_detection").WithRbacGen(GenerateResourceDetectionRbacRules).WithEgressGen(GenerateResourceDetectionEgress).WithAlias("resourcedetection").MustBuild(),
 }
 
 func init() {
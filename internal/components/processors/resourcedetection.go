@@ -6,6 +6,8 @@ package processors
 import (
 	"github.com/go-logr/logr"
 	rbacv1 "k8s.io/api/rbac/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/internal/components"
 )
 
 // ResourceDetectionConfig is a minimal struct needed for parsing a valid resourcedetection processor configuration
@@ -37,3 +39,22 @@ func GenerateResourceDetectionRbacRules(_ logr.Logger, config ResourceDetectionC
 	}
 	return prs, nil
 }
+
+func GenerateResourceDetectionEgress(_ logr.Logger, config ResourceDetectionConfig) ([]components.EgressDestination, error) {
+	var destinations []components.EgressDestination
+	for _, detector := range config.Detectors {
+		switch detector {
+		case "k8snode", "openshift":
+			destinations = append(destinations, components.KubernetesAPIDestination)
+		case "ec2", "eks", "gcp", "azure", "aks":
+			// the link-local instance metadata endpoint of the cloud providers
+			destinations = append(destinations, components.EgressDestination{Host: "169.254.169.254", Port: 80})
+		case "ecs":
+			// the task metadata endpoint
+			destinations = append(destinations, components.EgressDestination{Host: "169.254.170.2", Port: 80})
+		case "consul":
+			destinations = append(destinations, components.AnyDestination)
+		}
+	}
+	return destinations, nil
+}
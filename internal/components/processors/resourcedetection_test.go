@@ -7,9 +7,12 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/go-logr/logr"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	rbacv1 "k8s.io/api/rbac/v1"
 
+	"github.com/open-telemetry/opentelemetry-operator/internal/components"
 	"github.com/open-telemetry/opentelemetry-operator/internal/components/processors"
 )
 
@@ -108,3 +111,15 @@ func TestGenerateResourceDetectionRbacRules(t *testing.T) {
 		})
 	}
 }
+
+func TestGenerateResourceDetectionEgress(t *testing.T) {
+	destinations, err := processors.GenerateResourceDetectionEgress(logr.Discard(), processors.ResourceDetectionConfig{
+		Detectors: []string{"env", "k8snode", "ec2", "ecs"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []components.EgressDestination{
+		components.KubernetesAPIDestination,
+		{Host: "169.254.169.254", Port: 80},
+		{Host: "169.254.170.2", Port: 80},
+	}, destinations)
+}
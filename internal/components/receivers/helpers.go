@@ -4,6 +4,7 @@
 package receivers
 
 import (
+	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
 
 	"github.com/open-telemetry/opentelemetry-operator/internal/components"
@@ -28,25 +29,40 @@ func ReceiverFor(name string) components.Parser {
 	if parser, ok := registry[components.ComponentType(name)]; ok {
 		return parser
 	}
+	// the unknown receivers, such as kafka, may connect to any destination
 	return components.NewSilentSinglePortParserBuilder(components.ComponentType(name), components.UnsetPort).MustBuild()
 }
 
-// NewScraperParser is an instance of a generic parser that returns nothing when called and never fails.
+// NewScraperParser is an instance of a generic parser that returns nothing when called and never fails, except for
+// the destinations of the endpoints the scraper connects to.
 // Additional aliases can be passed for components that accept more than one spelling.
 func NewScraperParser(name string, aliases ...string) *components.GenericParser[any] {
-	return components.NewBuilder[any]().WithName(name).WithPort(components.UnsetPort).WithAlias(aliases...).MustBuild()
+	return components.NewBuilder[any]().WithName(name).WithPort(components.UnsetPort).WithAlias(aliases...).
+		WithEgressGen(generateScraperEgress).
+		MustBuild()
 }
 
+// generateScraperEgress returns the destinations of the endpoints a scraper connects to.
+func generateScraperEgress(_ logr.Logger, config any) ([]components.EgressDestination, error) {
+	return components.EndpointsDestinations(config, components.UnsetPort, "endpoint", "endpoints", "targets"), nil
+}
+
+// componentParsers holds the known receivers. The receivers listening for telemetry don't connect anywhere, while
+// the other receivers without an egress generator are allowed to connect to any destination.
 var componentParsers = []components.Parser{
 	components.NewMultiPortReceiverBuilder("otlp").
+		WithEgressGen(components.GenerateNoEgress[*components.MultiProtocolEndpointConfig]).
 		AddPortMapping(components.NewProtocolBuilder("grpc", 4317).
 			WithAppProtocol(&components.GrpcProtocol).
-			WithTargetPort(4317)).
+			WithTargetPort(4317).
+			WithTLSServer()).
 		AddPortMapping(components.NewProtocolBuilder("http", 4318).
 			WithAppProtocol(&components.HttpProtocol).
-			WithTargetPort(4318)).
+			WithTargetPort(4318).
+			WithTLSServer()).
 		MustBuild(),
 	components.NewMultiPortReceiverBuilder("skywalking").
+		WithEgressGen(components.GenerateNoEgress[*components.MultiProtocolEndpointConfig]).
 		AddPortMapping(components.NewProtocolBuilder(components.GrpcProtocol, 11800).
 			WithTargetPort(11800).
 			WithAppProtocol(&components.GrpcProtocol)).
@@ -55,14 +71,17 @@ var componentParsers = []components.Parser{
 			WithAppProtocol(&components.HttpProtocol)).
 		MustBuild(),
 	components.NewMultiPortReceiverBuilder("jaeger").
+		WithEgressGen(components.GenerateNoEgress[*components.MultiProtocolEndpointConfig]).
 		AddPortMapping(components.NewProtocolBuilder(components.GrpcProtocol, 14250).
 			WithTargetPort(14250).
 			WithProtocol(corev1.ProtocolTCP).
-			WithAppProtocol(&components.GrpcProtocol)).
+			WithAppProtocol(&components.GrpcProtocol).
+			WithTLSServer()).
 		AddPortMapping(components.NewProtocolBuilder("thrift_http", 14268).
 			WithTargetPort(14268).
 			WithProtocol(corev1.ProtocolTCP).
-			WithAppProtocol(&components.HttpProtocol)).
+			WithAppProtocol(&components.HttpProtocol).
+			WithTLSServer()).
 		AddPortMapping(components.NewProtocolBuilder("thrift_compact", 6831).
 			WithTargetPort(6831).
 			WithProtocol(corev1.ProtocolUDP)).
@@ -71,6 +90,7 @@ var componentParsers = []components.Parser{
 			WithProtocol(corev1.ProtocolUDP)).
 		MustBuild(),
 	components.NewMultiPortReceiverBuilder("loki").
+		WithEgressGen(components.GenerateNoEgress[*components.MultiProtocolEndpointConfig]).
 		AddPortMapping(components.NewProtocolBuilder(components.GrpcProtocol, 9095).
 			WithTargetPort(9095).
 			WithAppProtocol(&components.GrpcProtocol)).
@@ -129,27 +149,36 @@ var componentParsers = []components.Parser{
 		WithAppProtocol(&components.HttpProtocol).
 		WithProtocol(corev1.ProtocolTCP).
 		WithTargetPort(3100).
+		WithTLSServer().
 		MustBuild(),
 	// kubelet_stats, formerly kubeletstats
 	// (open-telemetry/opentelemetry-collector-contrib#47957).
 	components.NewBuilder[kubeletStatsConfig]().WithName("kubelet_stats").
 		WithRbacGen(generateKubeletStatsRbacRules).
 		WithEnvVarGen(generateKubeletStatsEnvVars).
+		WithEgressGen(generateKubeletStatsEgress).
 		WithAlias("kubeletstats").
 		MustBuild(),
 	components.NewBuilder[k8seventsConfig]().WithName("k8s_events").
 		WithRbacGen(generatek8seventsRbacRules).
+		WithEgressGen(components.GenerateKubernetesAPIEgress[k8seventsConfig]).
 		MustBuild(),
 	components.NewBuilder[k8sclusterConfig]().WithName("k8s_cluster").
 		WithRbacGen(generatek8sclusterRbacRules).
+		WithEgressGen(components.GenerateKubernetesAPIEgress[k8sclusterConfig]).
 		MustBuild(),
 	// k8s_objects, formerly k8sobjects
 	// (open-telemetry/opentelemetry-collector-contrib#47440).
 	components.NewBuilder[k8sobjectsConfig]().WithName("k8s_objects").
 		WithRbacGen(generatek8sobjectsRbacRules).
+		WithEgressGen(components.GenerateKubernetesAPIEgress[k8sobjectsConfig]).
 		WithAlias("k8sobjects").
 		MustBuild(),
 	NewPrometheusParser(),
+	components.NewBuilder[receiverCreatorConfig]().WithName("receiver_creator").
+		WithRbacGen(generateReceiverCreatorRbacRules).
+		WithRoleGen(generateReceiverCreatorRoleRules).
+		MustBuild(),
 	// ssh_check, formerly sshcheck
 	// (open-telemetry/opentelemetry-collector-contrib#47515).
 	NewScraperParser("ssh_check", "sshcheck"),
@@ -186,7 +215,8 @@ var componentParsers = []components.Parser{
 	// (open-telemetry/opentelemetry-collector-contrib#47929).
 	NewScraperParser("flink_metrics", "flinkmetrics"),
 	NewScraperParser("couchdb"),
-	NewScraperParser("filelog"),
+	// filelog reads local files, so unlike the scrapers it doesn't connect to any endpoint
+	components.NewBuilder[any]().WithName("filelog").WithPort(components.UnsetPort).WithEgressGen(components.GenerateNoEgress[any]).MustBuild(),
 }
 
 func init() {
@@ -7,6 +7,8 @@ import (
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/internal/components"
 )
 
 type metricConfig struct {
@@ -27,6 +29,7 @@ type metrics struct {
 // KubeletStatsConfig is a minimal struct needed for parsing a valid kubeletstats receiver configuration
 // This only contains the fields necessary for parsing, other fields can be added in the future.
 type kubeletStatsConfig struct {
+	Endpoint            string   `mapstructure:"endpoint"`
 	ExtraMetadataLabels []string `mapstructure:"extra_metadata_labels"`
 	Metrics             metrics  `mapstructure:"metrics"`
 	AuthType            string   `mapstructure:"auth_type"`
@@ -82,3 +85,19 @@ func generateKubeletStatsRbacRules(_ logr.Logger, config kubeletStatsConfig) ([]
 	}
 	return prs, nil
 }
+
+// defaultKubeletPort is the port of the kubelet's authenticated endpoint, which the receiver scrapes by default.
+const defaultKubeletPort = 10250
+
+func generateKubeletStatsEgress(_ logr.Logger, config kubeletStatsConfig) ([]components.EgressDestination, error) {
+	// the default endpoint is the one of the node the collector runs on, which isn't known in advance
+	endpoint := config.Endpoint
+	if endpoint == "" {
+		endpoint = "${env:K8S_NODE_NAME}"
+	}
+	destination, needed := components.EndpointDestination(endpoint, defaultKubeletPort)
+	if !needed {
+		return nil, nil
+	}
+	return []components.EgressDestination{destination}, nil
+}
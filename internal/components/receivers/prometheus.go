@@ -4,8 +4,14 @@
 package receivers
 
 import (
+	"reflect"
+	"slices"
+	"strings"
+
 	"github.com/go-logr/logr"
+	"github.com/mitchellh/mapstructure"
 	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 
 	"github.com/open-telemetry/opentelemetry-operator/internal/components"
 	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
@@ -15,7 +21,17 @@ import (
 // The api_server section opens an inbound HTTP listener whose port must be exposed
 // in the Kubernetes Service and NetworkPolicy.
 type prometheusConfig struct {
-	ApiServer *apiServerConfig `mapstructure:"api_server,omitempty"`
+	ApiServer       *apiServerConfig       `mapstructure:"api_server,omitempty"`
+	Config          *promScrapeConfigs     `mapstructure:"config,omitempty"`
+	TargetAllocator *targetAllocatorConfig `mapstructure:"target_allocator,omitempty"`
+}
+
+type promScrapeConfigs struct {
+	ScrapeConfigs []map[string]any `mapstructure:"scrape_configs,omitempty"`
+}
+
+type targetAllocatorConfig struct {
+	Endpoint string `mapstructure:"endpoint,omitempty"`
 }
 
 type apiServerConfig struct {
@@ -27,6 +43,48 @@ type serverConfig struct {
 	Endpoint string `mapstructure:"endpoint,omitempty"`
 }
 
+// kubernetesSDConfig is the subset of a kubernetes_sd_config defining the permissions of the service discovery.
+type kubernetesSDConfig struct {
+	Role           string                     `mapstructure:"role"`
+	APIServer      string                     `mapstructure:"api_server"`
+	KubeConfigFile string                     `mapstructure:"kubeconfig_file"`
+	Namespaces     kubernetesSDNamespaces     `mapstructure:"namespaces"`
+	AttachMetadata kubernetesSDAttachMetadata `mapstructure:"attach_metadata"`
+}
+
+type kubernetesSDNamespaces struct {
+	OwnNamespace bool     `mapstructure:"own_namespace"`
+	Names        []string `mapstructure:"names"`
+}
+
+type kubernetesSDAttachMetadata struct {
+	Node      bool `mapstructure:"node"`
+	Namespace bool `mapstructure:"namespace"`
+}
+
+var kubernetesSDVerbs = []string{"get", "list", "watch"}
+
+// kubernetesSDRoleRules are the rules of the namespaced resources watched by each role of the Kubernetes service
+// discovery. The nodes are cluster-scoped, so their rules are always cluster-wide.
+var kubernetesSDRoleRules = map[string][]rbacv1.PolicyRule{
+	"pod": {
+		{APIGroups: []string{""}, Resources: []string{"pods"}, Verbs: kubernetesSDVerbs},
+	},
+	"service": {
+		{APIGroups: []string{""}, Resources: []string{"services"}, Verbs: kubernetesSDVerbs},
+	},
+	"endpoints": {
+		{APIGroups: []string{""}, Resources: []string{"endpoints", "pods", "services"}, Verbs: kubernetesSDVerbs},
+	},
+	"endpointslice": {
+		{APIGroups: []string{"discovery.k8s.io"}, Resources: []string{"endpointslices"}, Verbs: kubernetesSDVerbs},
+		{APIGroups: []string{""}, Resources: []string{"pods", "services"}, Verbs: kubernetesSDVerbs},
+	},
+	"ingress": {
+		{APIGroups: []string{"networking.k8s.io"}, Resources: []string{"ingresses"}, Verbs: kubernetesSDVerbs},
+	},
+}
+
 func (c *prometheusConfig) GetPortNum() (int32, error) {
 	if c.ApiServer == nil || !c.ApiServer.Enabled {
 		return components.UnsetPort, components.PortNotFoundErr
@@ -60,12 +118,134 @@ func parsePrometheusPort(logger logr.Logger, name string, defaultPort *corev1.Se
 	return []corev1.ServicePort{components.ConstructServicePort(svcPort, port)}, nil
 }
 
+// generatePrometheusEgress returns the destinations the prometheus receiver scrapes: the static targets, the pods
+// for the Kubernetes service discovery and the target allocator. The targets of the other service discoveries are
+// only known to the collector, so any destination is allowed for them.
+func generatePrometheusEgress(_ logr.Logger, cfg *prometheusConfig) ([]components.EgressDestination, error) {
+	if cfg == nil {
+		return nil, nil
+	}
+	var destinations []components.EgressDestination
+	if cfg.TargetAllocator != nil && cfg.TargetAllocator.Endpoint != "" {
+		if destination, needed := components.EndpointDestination(cfg.TargetAllocator.Endpoint, 80); needed {
+			destinations = append(destinations, destination)
+		}
+	}
+	if cfg.Config == nil {
+		return destinations, nil
+	}
+	for _, scrapeConfig := range cfg.Config.ScrapeConfigs {
+		for key, value := range scrapeConfig {
+			switch {
+			case key == "static_configs":
+				staticConfigs, _ := value.([]any)
+				for _, staticConfig := range staticConfigs {
+					destinations = append(destinations, components.EndpointsDestinations(staticConfig, components.UnsetPort, "targets")...)
+				}
+			case key == "kubernetes_sd_configs":
+				destinations = append(destinations, components.KubernetesAPIDestination, components.EgressDestination{Pods: true})
+			case strings.HasSuffix(key, "_sd_configs"):
+				destinations = append(destinations, components.AnyDestination)
+			}
+		}
+	}
+	return destinations, nil
+}
+
+// kubernetesSDRules returns the rules the kubernetes_sd_configs of the scrape configs need. The service discoveries
+// limited to explicit namespaces get namespaced rules, the other ones cluster-wide rules. The service discoveries
+// connecting to another API server or with their own credentials don't need any permission of the collector.
+func kubernetesSDRules(cfg *prometheusConfig) ([]rbacv1.PolicyRule, []components.RoleRules, error) {
+	if cfg == nil || cfg.Config == nil {
+		return nil, nil, nil
+	}
+	var clusterRules []rbacv1.PolicyRule
+	var roleRules []components.RoleRules
+	addRoleRules := func(namespace string, rules []rbacv1.PolicyRule) {
+		index := slices.IndexFunc(roleRules, func(r components.RoleRules) bool { return r.Namespace == namespace })
+		if index < 0 {
+			roleRules = append(roleRules, components.RoleRules{Namespace: namespace})
+			index = len(roleRules) - 1
+		}
+		roleRules[index].Rules = appendMissingRules(roleRules[index].Rules, rules...)
+	}
+
+	for _, scrapeConfig := range cfg.Config.ScrapeConfigs {
+		sdConfigs, _ := scrapeConfig["kubernetes_sd_configs"].([]any)
+		for _, sdConfig := range sdConfigs {
+			var parsed kubernetesSDConfig
+			if err := mapstructure.Decode(sdConfig, &parsed); err != nil {
+				return nil, nil, err
+			}
+			if parsed.APIServer != "" || parsed.KubeConfigFile != "" {
+				continue
+			}
+
+			// the node metadata can be attached to the targets of the pod, endpoints and endpointslice roles
+			attachesNode := parsed.AttachMetadata.Node && slices.Contains([]string{"pod", "endpoints", "endpointslice"}, parsed.Role)
+			if parsed.Role == "node" || attachesNode {
+				clusterRules = appendMissingRules(clusterRules, rbacv1.PolicyRule{
+					APIGroups: []string{""}, Resources: []string{"nodes"}, Verbs: kubernetesSDVerbs,
+				})
+			}
+			if parsed.AttachMetadata.Namespace {
+				clusterRules = appendMissingRules(clusterRules, rbacv1.PolicyRule{
+					APIGroups: []string{""}, Resources: []string{"namespaces"}, Verbs: kubernetesSDVerbs,
+				})
+			}
+
+			rules := kubernetesSDRoleRules[parsed.Role]
+			if len(rules) == 0 {
+				continue
+			}
+			namespaces := parsed.Namespaces.Names
+			if parsed.Namespaces.OwnNamespace {
+				namespaces = append(namespaces, "")
+			}
+			if len(namespaces) == 0 {
+				clusterRules = appendMissingRules(clusterRules, rules...)
+				continue
+			}
+			for _, namespace := range namespaces {
+				addRoleRules(namespace, rules)
+			}
+		}
+	}
+	return clusterRules, roleRules, nil
+}
+
+// appendMissingRules appends the rules that aren't part of the given rules yet.
+func appendMissingRules(rules []rbacv1.PolicyRule, added ...rbacv1.PolicyRule) []rbacv1.PolicyRule {
+	for _, rule := range added {
+		if !slices.ContainsFunc(rules, func(r rbacv1.PolicyRule) bool { return reflect.DeepEqual(r, rule) }) {
+			rules = append(rules, rule)
+		}
+	}
+	return rules
+}
+
+// generatePrometheusRbacRules returns the cluster-wide rules of the Kubernetes service discoveries.
+func generatePrometheusRbacRules(_ logr.Logger, cfg *prometheusConfig) ([]rbacv1.PolicyRule, error) {
+	rules, _, err := kubernetesSDRules(cfg)
+	return rules, err
+}
+
+// generatePrometheusRoleRules returns the rules of the Kubernetes service discoveries limited to explicit namespaces.
+func generatePrometheusRoleRules(_ logr.Logger, cfg *prometheusConfig) ([]components.RoleRules, error) {
+	_, rules, err := kubernetesSDRules(cfg)
+	return rules, err
+}
+
 // NewPrometheusParser returns a parser for the prometheus receiver that extracts
-// the api_server.server_config.endpoint port for Service and NetworkPolicy exposure.
+// the api_server.server_config.endpoint port for Service and NetworkPolicy exposure,
+// the scrape targets for the NetworkPolicy egress rules, and the permissions of the Kubernetes service discovery.
 func NewPrometheusParser() *components.GenericParser[*prometheusConfig] {
 	return components.NewBuilder[*prometheusConfig]().
 		WithName("prometheus").
 		WithPort(components.UnsetPort).
 		WithPortParser(parsePrometheusPort).
+		WithRbacGen(generatePrometheusRbacRules).
+		WithRoleGen(generatePrometheusRoleRules).
+		WithEgressGen(generatePrometheusEgress).
 		MustBuild()
 }
@@ -6,9 +6,12 @@ package receivers_test
 import (
 	"testing"
 
+	"github.com/go-logr/logr"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	rbacv1 "k8s.io/api/rbac/v1"
 
+	"github.com/open-telemetry/opentelemetry-operator/internal/components"
 	"github.com/open-telemetry/opentelemetry-operator/internal/components/receivers"
 )
 
@@ -116,3 +119,111 @@ func TestPrometheusParserPorts(t *testing.T) {
 		})
 	}
 }
+
+func TestPrometheusParserEgressDestinations(t *testing.T) {
+	parser := receivers.ReceiverFor("prometheus")
+	destinations, err := parser.GetEgressDestinations(logr.Discard(), map[string]any{
+		"config": map[string]any{
+			"scrape_configs": []any{
+				map[string]any{
+					"job_name": "static",
+					"static_configs": []any{
+						map[string]any{"targets": []any{"app.example.com:9090", "10.0.0.5:8080"}},
+					},
+				},
+				map[string]any{
+					"job_name":              "pods",
+					"kubernetes_sd_configs": []any{map[string]any{"role": "pod"}},
+				},
+			},
+		},
+		"target_allocator": map[string]any{
+			"endpoint": "http://collector-targetallocator",
+		},
+	})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []components.EgressDestination{
+		{Host: "collector-targetallocator", Port: 80},
+		{Host: "app.example.com", Port: 9090},
+		{Host: "10.0.0.5", Port: 8080},
+		components.KubernetesAPIDestination,
+		{Pods: true},
+	}, destinations)
+}
+
+func TestPrometheusParserEgressDestinationsOtherServiceDiscovery(t *testing.T) {
+	parser := receivers.ReceiverFor("prometheus")
+	destinations, err := parser.GetEgressDestinations(logr.Discard(), map[string]any{
+		"config": map[string]any{
+			"scrape_configs": []any{
+				map[string]any{
+					"job_name":          "consul",
+					"consul_sd_configs": []any{map[string]any{"server": "consul.example.com:8500"}},
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []components.EgressDestination{components.AnyDestination}, destinations)
+}
+
+func TestPrometheusParserKubernetesSDRules(t *testing.T) {
+	verbs := []string{"get", "list", "watch"}
+	parser := receivers.ReceiverFor("prometheus")
+	config := map[string]any{
+		"config": map[string]any{
+			"scrape_configs": []any{
+				map[string]any{
+					"job_name": "pods",
+					"kubernetes_sd_configs": []any{map[string]any{
+						"role":            "pod",
+						"attach_metadata": map[string]any{"node": true},
+					}},
+				},
+				map[string]any{
+					"job_name": "endpoints",
+					"kubernetes_sd_configs": []any{map[string]any{
+						"role":       "endpointslice",
+						"namespaces": map[string]any{"names": []any{"apps"}, "own_namespace": true},
+					}},
+				},
+				map[string]any{
+					"job_name": "ingresses",
+					"kubernetes_sd_configs": []any{map[string]any{
+						"role":       "ingress",
+						"namespaces": map[string]any{"names": []any{"apps"}},
+					}},
+				},
+				map[string]any{
+					"job_name": "remote",
+					"kubernetes_sd_configs": []any{map[string]any{
+						"role":       "service",
+						"api_server": "https://remote.example.com:6443",
+					}},
+				},
+			},
+		},
+	}
+
+	rules, err := parser.GetRBACRules(logr.Discard(), config)
+	require.NoError(t, err)
+	assert.Equal(t, []rbacv1.PolicyRule{
+		{APIGroups: []string{""}, Resources: []string{"nodes"}, Verbs: verbs},
+		{APIGroups: []string{""}, Resources: []string{"pods"}, Verbs: verbs},
+	}, rules)
+
+	endpointSliceRules := []rbacv1.PolicyRule{
+		{APIGroups: []string{"discovery.k8s.io"}, Resources: []string{"endpointslices"}, Verbs: verbs},
+		{APIGroups: []string{""}, Resources: []string{"pods", "services"}, Verbs: verbs},
+	}
+	roleRules, err := parser.GetRoleRules(logr.Discard(), config)
+	require.NoError(t, err)
+	assert.Equal(t, []components.RoleRules{
+		{
+			Namespace: "apps",
+			Rules: append(endpointSliceRules,
+				rbacv1.PolicyRule{APIGroups: []string{"networking.k8s.io"}, Resources: []string{"ingresses"}, Verbs: verbs}),
+		},
+		{Namespace: "", Rules: endpointSliceRules},
+	}, roleRules)
+}
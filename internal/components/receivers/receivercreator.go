@@ -0,0 +1,61 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package receivers
+
+import (
+	"errors"
+	"maps"
+	"slices"
+
+	"github.com/go-logr/logr"
+	rbacv1 "k8s.io/api/rbac/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/internal/components"
+)
+
+// receiverCreatorConfig is the subset of the receiver_creator config defining the receivers it starts.
+type receiverCreatorConfig struct {
+	Receivers map[string]receiverTemplateConfig `mapstructure:"receivers"`
+}
+
+type receiverTemplateConfig struct {
+	Config any `mapstructure:"config"`
+}
+
+// templateNames returns the names of the receiver templates in a stable order, so that the generated rules don't
+// change between reconciliations.
+func (c receiverCreatorConfig) templateNames() []string {
+	return slices.Sorted(maps.Keys(c.Receivers))
+}
+
+// generateReceiverCreatorRbacRules returns the cluster-wide rules of the receivers started by the receiver_creator.
+// The observers the receivers are discovered with are extensions, whose rules are generated separately.
+func generateReceiverCreatorRbacRules(logger logr.Logger, config receiverCreatorConfig) ([]rbacv1.PolicyRule, error) {
+	var rules []rbacv1.PolicyRule
+	var errs []error
+	for _, name := range config.templateNames() {
+		templateRules, err := ReceiverFor(name).GetRBACRules(logger, config.Receivers[name].Config)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		rules = appendMissingRules(rules, templateRules...)
+	}
+	return rules, errors.Join(errs...)
+}
+
+// generateReceiverCreatorRoleRules returns the namespaced rules of the receivers started by the receiver_creator.
+func generateReceiverCreatorRoleRules(logger logr.Logger, config receiverCreatorConfig) ([]components.RoleRules, error) {
+	var roleRules []components.RoleRules
+	var errs []error
+	for _, name := range config.templateNames() {
+		templateRules, err := ReceiverFor(name).GetRoleRules(logger, config.Receivers[name].Config)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		roleRules = append(roleRules, templateRules...)
+	}
+	return roleRules, errors.Join(errs...)
+}
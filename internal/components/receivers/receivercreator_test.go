@@ -0,0 +1,65 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package receivers_test
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	rbacv1 "k8s.io/api/rbac/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/internal/components"
+	"github.com/open-telemetry/opentelemetry-operator/internal/components/receivers"
+)
+
+func TestReceiverCreatorRules(t *testing.T) {
+	verbs := []string{"get", "list", "watch"}
+	parser := receivers.ReceiverFor("receiver_creator/pods")
+	config := map[string]any{
+		"watch_observers": []any{"k8s_observer"},
+		"receivers": map[string]any{
+			"prometheus/services": map[string]any{
+				"rule": `type == "pod"`,
+				"config": map[string]any{
+					"config": map[string]any{
+						"scrape_configs": []any{
+							map[string]any{
+								"job_name": "services",
+								"kubernetes_sd_configs": []any{map[string]any{
+									"role":       "service",
+									"namespaces": map[string]any{"names": []any{"apps"}},
+								}},
+							},
+						},
+					},
+				},
+			},
+			"k8s_events": map[string]any{
+				"rule": `type == "k8s.node"`,
+			},
+			"redis": map[string]any{
+				"rule":   `type == "port" && port == 6379`,
+				"config": map[string]any{"endpoint": "`endpoint`"},
+			},
+		},
+	}
+
+	rules, err := parser.GetRBACRules(logr.Discard(), config)
+	require.NoError(t, err)
+	expected, err := receivers.ReceiverFor("k8s_events").GetRBACRules(logr.Discard(), nil)
+	require.NoError(t, err)
+	require.NotEmpty(t, expected)
+	assert.Equal(t, expected, rules)
+
+	roleRules, err := parser.GetRoleRules(logr.Discard(), config)
+	require.NoError(t, err)
+	assert.Equal(t, []components.RoleRules{
+		{
+			Namespace: "apps",
+			Rules:     []rbacv1.PolicyRule{{APIGroups: []string{""}, Resources: []string{"services"}, Verbs: verbs}},
+		},
+	}, roleRules)
+}
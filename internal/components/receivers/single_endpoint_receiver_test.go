@@ -65,6 +65,15 @@ func TestScraperParserAliases(t *testing.T) {
 	}
 }
 
+func TestFilelogParserEgressDestinations(t *testing.T) {
+	parser := receivers.ReceiverFor("filelog")
+	destinations, err := parser.GetEgressDestinations(logger, map[string]any{
+		"include": []any{"/var/log/pods/*/*/*.log"},
+	})
+	assert.NoError(t, err)
+	assert.Empty(t, destinations)
+}
+
 func TestDownstreamParsers(t *testing.T) {
 	for _, tt := range []struct {
 		desc             string
@@ -73,8 +73,10 @@ func internalParseSingleEndpoint(logger logr.Logger, name string, failSilently b
 	return []corev1.ServicePort{ConstructServicePort(svcPort, port)}, nil
 }
 
+// NewSinglePortParserBuilder returns the builder of a component listening on a single port, which doesn't connect
+// anywhere.
 func NewSinglePortParserBuilder(name string, port int32) Builder[*SingleEndpointConfig] {
-	return NewBuilder[*SingleEndpointConfig]().WithPort(port).WithName(name).WithPortParser(ParseSingleEndpoint).WithDefaultsApplier(AddressDefaulter).WithDefaultRecAddress(DefaultRecAddress)
+	return NewBuilder[*SingleEndpointConfig]().WithPort(port).WithName(name).WithPortParser(ParseSingleEndpoint).WithDefaultsApplier(AddressDefaulter).WithDefaultRecAddress(DefaultRecAddress).WithEgressGen(GenerateNoEgress[*SingleEndpointConfig])
 }
 
 func NewSilentSinglePortParserBuilder(name string, port int32) Builder[*SingleEndpointConfig] {
@@ -96,6 +98,12 @@ func AddressDefaulter(_ logr.Logger, defaultCfg *DefaultConfig, defaultRecAddr s
 	}
 
 	res := make(map[string]any)
+	if defaultCfg.TLSCertificate != nil {
+		if config.TLS == nil {
+			config.TLS = &TLSConfig{}
+		}
+		config.TLS.ApplyTLSCertificateDefaults(defaultCfg.TLSCertificate)
+	}
 	config.TLS.ApplyTLSProfileDefaults(defaultCfg.TLSProfile)
 
 	err := mapstructure.Decode(config, &res)
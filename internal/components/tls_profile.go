@@ -19,8 +19,21 @@ type TLSProfileProvider interface {
 
 // TLSConfig holds OTel-format TLS settings that can be injected into component configs.
 type TLSConfig struct {
-	Ciphers    []string `mapstructure:"cipher_suites,omitempty"`
-	MinVersion string   `mapstructure:"min_version,omitempty"`
+	Ciphers        []string `mapstructure:"cipher_suites,omitempty"`
+	MinVersion     string   `mapstructure:"min_version,omitempty"`
+	CertFile       string   `mapstructure:"cert_file,omitempty"`
+	KeyFile        string   `mapstructure:"key_file,omitempty"`
+	ClientCAFile   string   `mapstructure:"client_ca_file,omitempty"`
+	ReloadInterval string   `mapstructure:"reload_interval,omitempty"`
+}
+
+// TLSCertificate holds the files of the certificate components serving TLS are configured with.
+type TLSCertificate struct {
+	CertFile     string
+	KeyFile      string
+	ClientCAFile string
+	// ReloadInterval is the interval at which the files are reloaded, so that renewed certificates are picked up.
+	ReloadInterval string
 }
 
 // ApplyTLSProfileDefaults sets MinVersion and Ciphers from the given TLS
@@ -37,6 +50,25 @@ func (t *TLSConfig) ApplyTLSProfileDefaults(profile TLSProfile) {
 	}
 }
 
+// ApplyTLSCertificateDefaults sets the certificate files from the given
+// certificate when no certificate files are already configured. A block
+// configuring any of them is left as it is, so that the client CA of the
+// operator isn't mixed with a certificate of the user.
+func (t *TLSConfig) ApplyTLSCertificateDefaults(cert *TLSCertificate) {
+	if t == nil || cert == nil {
+		return
+	}
+	if t.CertFile != "" || t.KeyFile != "" || t.ClientCAFile != "" {
+		return
+	}
+	t.CertFile = cert.CertFile
+	t.KeyFile = cert.KeyFile
+	t.ClientCAFile = cert.ClientCAFile
+	if t.ReloadInterval == "" {
+		t.ReloadInterval = cert.ReloadInterval
+	}
+}
+
 // TLSProfile holds the TLS configuration to inject into collector components.
 // These settings are derived from the cluster's TLS security profile.
 type TLSProfile interface {
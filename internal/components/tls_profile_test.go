@@ -26,3 +26,35 @@ func TestStaticProfileTLS13ReturnsNilCiphers(t *testing.T) {
 	assert.Nil(t, profile.CipherSuiteNames(), "TLS 1.3 should return nil for CipherSuiteNames")
 	assert.Equal(t, "1.3", profile.MinTLSVersionOTEL())
 }
+
+func TestApplyTLSCertificateDefaults(t *testing.T) {
+	cert := &TLSCertificate{
+		CertFile:       "/tls/tls.crt",
+		KeyFile:        "/tls/tls.key",
+		ClientCAFile:   "/tls/ca.crt",
+		ReloadInterval: "1h0m0s",
+	}
+
+	empty := &TLSConfig{}
+	empty.ApplyTLSCertificateDefaults(cert)
+	assert.Equal(t, &TLSConfig{CertFile: "/tls/tls.crt", KeyFile: "/tls/tls.key", ClientCAFile: "/tls/ca.crt", ReloadInterval: "1h0m0s"}, empty)
+
+	// a certificate configured by the user is kept, without the client CA of the operator
+	configured := &TLSConfig{CertFile: "/certs/my.crt", KeyFile: "/certs/my.key", MinVersion: "1.3"}
+	configured.ApplyTLSCertificateDefaults(cert)
+	assert.Equal(t, &TLSConfig{CertFile: "/certs/my.crt", KeyFile: "/certs/my.key", MinVersion: "1.3"}, configured)
+
+	// so is a client CA configured by the user
+	clientCA := &TLSConfig{ClientCAFile: "/certs/ca.crt"}
+	clientCA.ApplyTLSCertificateDefaults(cert)
+	assert.Equal(t, &TLSConfig{ClientCAFile: "/certs/ca.crt"}, clientCA)
+
+	// other settings don't prevent the certificate from being set
+	profile := &TLSConfig{MinVersion: "1.3"}
+	profile.ApplyTLSCertificateDefaults(cert)
+	assert.Equal(t, &TLSConfig{CertFile: "/tls/tls.crt", KeyFile: "/tls/tls.key", ClientCAFile: "/tls/ca.crt", ReloadInterval: "1h0m0s", MinVersion: "1.3"}, profile)
+
+	var unset *TLSConfig
+	unset.ApplyTLSCertificateDefaults(cert)
+	assert.Nil(t, unset)
+}
@@ -40,6 +40,8 @@ func CreateCLIParser(cfg Config) *pflag.FlagSet {
 	f.Bool("ignore-missing-collector-crds", cfg.IgnoreMissingCollectorCRDs, "Ignore missing OpenTelemetryCollector CRDs presence in the cluster")
 	f.String("collector-image", cfg.CollectorImage, "The default OpenTelemetry collector image. This image is used when no image is specified in the CustomResource.")
 	f.String("clusterobservability-collector-image", cfg.ClusterObservabilityCollectorImage, "The OpenTelemetry collector image used for collectors generated by the ClusterObservability reconciler. Defaults to the k8s distribution.")
+	f.String("sidecar-job-watcher-image", cfg.SidecarJobWatcherImage, "The image of the containers stopping the collector sidecar of Job pods once their containers exit, or draining its exporter queues before it is stopped.")
+	f.String("storage-init-image", cfg.StorageInitImage, "The image of the init container handing the hostPath volumes of the persistence of daemonset collectors over to the collector user.")
 	f.String("target-allocator-image", cfg.TargetAllocatorImage, "The default OpenTelemetry target allocator image. This image is used when no image is specified in the CustomResource.")
 	f.String("operator-opamp-bridge-image", cfg.OperatorOpAMPBridgeImage, "The default OpenTelemetry Operator OpAMP Bridge image. This image is used when no image is specified in the CustomResource.")
 	f.String("auto-instrumentation-java-image", cfg.AutoInstrumentationJavaImage, "The default OpenTelemetry Java instrumentation image. This image is used when no image is specified in the CustomResource.")
@@ -100,6 +102,10 @@ func ApplyCLI(cfg *Config) error {
 				cfg.CollectorImage, _ = f.GetString("collector-image")
 			case "clusterobservability-collector-image":
 				cfg.ClusterObservabilityCollectorImage, _ = f.GetString("clusterobservability-collector-image")
+			case "sidecar-job-watcher-image":
+				cfg.SidecarJobWatcherImage, _ = f.GetString("sidecar-job-watcher-image")
+			case "storage-init-image":
+				cfg.StorageInitImage, _ = f.GetString("storage-init-image")
 			case "target-allocator-image":
 				cfg.TargetAllocatorImage, _ = f.GetString("target-allocator-image")
 			case "operator-opamp-bridge-image":
@@ -14,11 +14,14 @@ import (
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/certmanager"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/collector"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/gatewayapi"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/grafana"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/keda"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/opampbridge"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/openshift"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/prometheus"
 	autoRBAC "github.com/open-telemetry/opentelemetry-operator/internal/autodetect/rbac"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/targetallocator"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/vpa"
 	"github.com/open-telemetry/opentelemetry-operator/internal/components"
 	"github.com/open-telemetry/opentelemetry-operator/internal/version"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/constants"
@@ -29,6 +32,8 @@ const (
 	defaultTargetAllocatorConfigMapEntry     = "targetallocator.yaml"
 	defaultOperatorOpAMPBridgeConfigMapEntry = "remoteconfiguration.yaml"
 	defaultOpenShiftWebhookReplicas          = 2
+	defaultSidecarJobWatcherImage            = "docker.io/library/busybox:1.37"
+	defaultStorageInitImage                  = "docker.io/library/busybox:1.37"
 )
 
 type ZapConfig struct {
@@ -52,10 +57,20 @@ type Config struct {
 	// cluster collectors generated by the ClusterObservability reconciler. It defaults to
 	// the k8s distribution at the same version as CollectorImage.
 	ClusterObservabilityCollectorImage string `yaml:"clusterobservability-collector-image"`
+	// SidecarJobWatcherImage is the image of the container stopping the collector sidecar of Job pods once their
+	// containers exit, when native sidecars are not available, and of the container draining the collector's exporter
+	// queues otherwise. It requires a shell with grep and wget.
+	SidecarJobWatcherImage string `yaml:"sidecar-job-watcher-image"`
+	// StorageInitImage is the image of the init container handing the hostPath volumes of the persistence over to the
+	// collector user in the daemonset mode. It requires chown.
+	StorageInitImage string `yaml:"storage-init-image"`
 	// CollectorConfigMapEntry represents the configuration file name for the collector. Immutable.
 	CollectorConfigMapEntry string `yaml:"collector-configmap-entry"`
 	// CreateRBACPermissions is true when the operator can create RBAC permissions for SAs running a collector instance. Immutable.
 	CreateRBACPermissions autoRBAC.Availability `yaml:"create-rbac-permissions"`
+	// CreateRoleRBACPermissions is true when the operator can also create namespaced RBAC permissions for SAs running a
+	// collector instance. Otherwise, the namespaced permissions are granted cluster-wide. Immutable.
+	CreateRoleRBACPermissions autoRBAC.Availability `yaml:"create-role-rbac-permissions"`
 	// EnableMultiInstrumentation is true when the operator supports multi instrumentation.
 	EnableMultiInstrumentation bool `yaml:"enable-multi-instrumentation"`
 	// EnableApacheHttpdAutoInstrumentation is true when the operator supports ApacheHttpd auto instrumentation.
@@ -94,6 +109,16 @@ type Config struct {
 	OpenShiftRoutesAvailability openshift.RoutesAvailability `yaml:"open-shift-routes-availability"`
 	// GatewayAPIsAvailability represents the availability of the Gateway APIs.
 	GatewayAPIsAvailability gatewayapi.ApiAvailability `yaml:"gateway-apis-availability"`
+	// GRPCRouteAvailability represents the availability of the Gateway API GRPCRoute.
+	GRPCRouteAvailability gatewayapi.ApiAvailability `yaml:"grpc-route-availability"`
+	// TLSRouteAvailability represents the availability of the Gateway API TLSRoute.
+	TLSRouteAvailability gatewayapi.ApiAvailability `yaml:"tls-route-availability"`
+	// KedaAvailability represents the availability of the KEDA ScaledObject CRD.
+	KedaAvailability keda.Availability `yaml:"keda-availability"`
+	// VPAAvailability represents the availability of the VerticalPodAutoscaler CRD.
+	VPAAvailability vpa.Availability `yaml:"vpa-availability"`
+	// GrafanaDashboardAvailability represents the availability of the GrafanaDashboard CRD of the Grafana operator.
+	GrafanaDashboardAvailability grafana.Availability `yaml:"grafana-dashboard-availability"`
 	// PrometheusCRAvailability represents the availability of the Prometheus Operator CRDs.
 	PrometheusCRAvailability prometheus.Availability `yaml:"prometheus-cr-availability"`
 	// CertManagerAvailability represents the availability of the Cert-Manager.
@@ -184,6 +209,8 @@ func New() Config {
 	return Config{
 		CollectorImage:                      fmt.Sprintf("ghcr.io/open-telemetry/opentelemetry-collector-releases/opentelemetry-collector:%s", v.OpenTelemetryCollector),
 		ClusterObservabilityCollectorImage:  fmt.Sprintf("ghcr.io/open-telemetry/opentelemetry-collector-releases/opentelemetry-collector-k8s:%s", v.OpenTelemetryCollector),
+		SidecarJobWatcherImage:              defaultSidecarJobWatcherImage,
+		StorageInitImage:                    defaultStorageInitImage,
 		CollectorConfigMapEntry:             defaultCollectorConfigMapEntry,
 		EnableMultiInstrumentation:          true,
 		EnableApacheHttpdInstrumentation:    true,
@@ -199,6 +226,9 @@ func New() Config {
 		OperatorOpAMPBridgeConfigMapEntry:   defaultOperatorOpAMPBridgeConfigMapEntry,
 		OpenShiftRoutesAvailability:         openshift.RoutesNotAvailable,
 		PrometheusCRAvailability:            prometheus.NotAvailable,
+		KedaAvailability:                    keda.NotAvailable,
+		VPAAvailability:                     vpa.NotAvailable,
+		GrafanaDashboardAvailability:        grafana.NotAvailable,
 		CertManagerAvailability:             certmanager.NotAvailable,
 		TargetAllocatorAvailability:         targetallocator.NotAvailable,
 		CollectorAvailability:               collector.NotAvailable,
@@ -213,6 +243,7 @@ func New() Config {
 		LabelsFilter:                        []string{},
 		AnnotationsFilter:                   []string{constants.KubernetesLastAppliedConfigurationAnnotation},
 		CreateRBACPermissions:               autoRBAC.NotAvailable,
+		CreateRoleRBACPermissions:           autoRBAC.NotAvailable,
 		OpAmpBridgeAvailability:             opampbridge.NotAvailable,
 		MetricsAddr:                         ":8443",
 		MetricsSecure:                       true,
@@ -33,6 +33,7 @@ func TestToStringMap(t *testing.T) {
 		"collector-configmap-entry":               "",
 		"collector-image":                         "myexample:1.0",
 		"create-rbac-permissions":                 "0",
+		"create-role-rbac-permissions":            "0",
 		"create-service-monitor-operator-metrics": "false",
 		"enable-apache-httpd-instrumentation":     "false",
 		"enable-cr-metrics":                       "false",
@@ -61,6 +62,8 @@ func TestToStringMap(t *testing.T) {
 		"pprof-addr":                              "",
 		"health-probe-addr":                       "",
 		"prometheus-cr-availability":              "0",
+		"sidecar-job-watcher-image":               "",
+		"storage-init-image":                      "",
 		"target-allocator-availability":           "0",
 		"target-allocator-configmap-entry":        "",
 		"targetallocator-image":                   "",
@@ -68,6 +71,11 @@ func TestToStringMap(t *testing.T) {
 		"webhook-port":                            "0",
 		"enable-webhooks":                         "false",
 		"gateway-apis-availability":               "0",
+		"grpc-route-availability":                 "0",
+		"tls-route-availability":                  "0",
+		"keda-availability":                       "0",
+		"vpa-availability":                        "0",
+		"grafana-dashboard-availability":          "0",
 	}, cfg.ToStringMap())
 }
 
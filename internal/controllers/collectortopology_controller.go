@@ -0,0 +1,164 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"maps"
+
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collectortopology"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
+	topologyStatus "github.com/open-telemetry/opentelemetry-operator/internal/status/collectortopology"
+)
+
+// CollectorTopologyReconciler reconciles a CollectorTopology object.
+type CollectorTopologyReconciler struct {
+	client.Client
+	recorder events.EventRecorder
+	scheme   *runtime.Scheme
+	log      logr.Logger
+	config   config.Config
+}
+
+// CollectorTopologyReconcilerParams is the set of options to build a new CollectorTopologyReconciler.
+type CollectorTopologyReconcilerParams struct {
+	client.Client
+	Recorder events.EventRecorder
+	Scheme   *runtime.Scheme
+	Log      logr.Logger
+	Config   config.Config
+}
+
+func (r *CollectorTopologyReconciler) getParams(instance v1alpha1.CollectorTopology) manifests.Params {
+	return manifests.Params{
+		Config:            r.config,
+		Client:            r.Client,
+		CollectorTopology: instance,
+		Log:               r.log,
+		Scheme:            r.scheme,
+		Recorder:          r.recorder,
+	}
+}
+
+func NewCollectorTopologyReconciler(params CollectorTopologyReconcilerParams) *CollectorTopologyReconciler {
+	reconciler := &CollectorTopologyReconciler{
+		Client:   params.Client,
+		scheme:   params.Scheme,
+		log:      params.Log,
+		recorder: params.Recorder,
+		config:   params.Config,
+	}
+	return reconciler
+}
+
+//+kubebuilder:rbac:groups=opentelemetry.io,resources=collectortopologies,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=opentelemetry.io,resources=collectortopologies/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=opentelemetry.io,resources=collectortopologies/finalizers,verbs=update
+//+kubebuilder:rbac:groups=opentelemetry.io,resources=opentelemetrycollectors,verbs=get;list;watch;create;update;patch;delete
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+func (r *CollectorTopologyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.log.WithValues("collectortopology", req.NamespacedName)
+
+	var instance v1alpha1.CollectorTopology
+	if err := r.Get(ctx, req.NamespacedName, &instance); err != nil {
+		if !apierrors.IsNotFound(err) {
+			log.Error(err, "unable to fetch CollectorTopology")
+		}
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	// We have a deletion, short circuit and let the deletion happen: the collectors are garbage collected with
+	// their owner
+	if deletionTimestamp := instance.GetDeletionTimestamp(); deletionTimestamp != nil {
+		return ctrl.Result{}, nil
+	}
+
+	params := r.getParams(instance)
+
+	desiredObjects, buildErr := collectortopology.Build(params)
+	if buildErr != nil {
+		return topologyStatus.HandleReconcileStatus(ctx, log, params, buildErr)
+	}
+	if ownerErr := r.checkOwnership(ctx, params, desiredObjects); ownerErr != nil {
+		return topologyStatus.HandleReconcileStatus(ctx, log, params, ownerErr)
+	}
+	ownedObjects, err := r.findOwnedObjects(ctx, params)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	err = reconcileDesiredObjects(ctx, r.Client, log, &params.CollectorTopology, params.Scheme, desiredObjects, ownedObjects)
+	return topologyStatus.HandleReconcileStatus(ctx, log, params, err)
+}
+
+// checkOwnership returns an error when a collector of the topology already exists without being controlled by it, so
+// that a collector created by a user under the same name isn't adopted and overwritten.
+func (r *CollectorTopologyReconciler) checkOwnership(ctx context.Context, params manifests.Params, desiredObjects []client.Object) error {
+	for _, desired := range desiredObjects {
+		existing := desired.DeepCopyObject().(client.Object)
+		if err := r.Get(ctx, client.ObjectKeyFromObject(desired), existing); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+		if !metav1.IsControlledBy(existing, &params.CollectorTopology) {
+			return fmt.Errorf("the OpenTelemetryCollector %s already exists and isn't managed by the topology", existing.GetName())
+		}
+	}
+	return nil
+}
+
+// findOwnedObjects returns the collectors the CollectorTopology owns, to prune the ones which are no longer desired.
+func (r *CollectorTopologyReconciler) findOwnedObjects(ctx context.Context, params manifests.Params) (map[types.UID]client.Object, error) {
+	ownedObjects := map[types.UID]client.Object{}
+	listOpts := []client.ListOption{
+		client.InNamespace(params.CollectorTopology.Namespace),
+		client.MatchingLabels(manifestutils.SelectorLabels(params.CollectorTopology.ObjectMeta, collectortopology.ComponentCollectorTopology)),
+	}
+	for _, objectType := range r.GetOwnedResourceTypes() {
+		objs, err := getList(ctx, r.Client, objectType, listOpts...)
+		if err != nil {
+			return nil, err
+		}
+		maps.DeleteFunc(objs, func(_ types.UID, obj client.Object) bool {
+			return !metav1.IsControlledBy(obj, &params.CollectorTopology)
+		})
+		maps.Copy(ownedObjects, objs)
+	}
+	return ownedObjects, nil
+}
+
+// GetOwnedResourceTypes returns the CRs created by CollectorTopology. The collector controller manages the workloads
+// of the tiers.
+func (*CollectorTopologyReconciler) GetOwnedResourceTypes() []client.Object {
+	return []client.Object{
+		&v1beta1.OpenTelemetryCollector{},
+	}
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *CollectorTopologyReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	builder := ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha1.CollectorTopology{})
+	// the status of the collectors is reflected in the status of their topology
+	for _, resource := range r.GetOwnedResourceTypes() {
+		builder.Owns(resource)
+	}
+	return builder.Complete(r)
+}
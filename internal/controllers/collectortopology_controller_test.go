@@ -0,0 +1,68 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+)
+
+func TestCollectorTopologyCheckOwnership(t *testing.T) {
+	topology := v1alpha1.CollectorTopology{
+		ObjectMeta: metav1.ObjectMeta{Name: "sampling", Namespace: "observability", UID: types.UID("topology-uid")},
+	}
+	desired := []client.Object{
+		&v1beta1.OpenTelemetryCollector{ObjectMeta: metav1.ObjectMeta{Name: "sampling-agent", Namespace: "observability"}},
+	}
+	owned := &v1beta1.OpenTelemetryCollector{ObjectMeta: metav1.ObjectMeta{Name: "sampling-agent", Namespace: "observability"}}
+	require.NoError(t, controllerutil.SetControllerReference(&topology, owned, reconcilerTestScheme))
+
+	tests := []struct {
+		name     string
+		existing []client.Object
+		wantErr  string
+	}{
+		{
+			name: "missing collector",
+		},
+		{
+			name:     "collector owned by the topology",
+			existing: []client.Object{owned},
+		},
+		{
+			name: "collector created by a user",
+			existing: []client.Object{
+				&v1beta1.OpenTelemetryCollector{ObjectMeta: metav1.ObjectMeta{Name: "sampling-agent", Namespace: "observability"}},
+			},
+			wantErr: "the OpenTelemetryCollector sampling-agent already exists and isn't managed by the topology",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewCollectorTopologyReconciler(CollectorTopologyReconcilerParams{
+				Client: fake.NewClientBuilder().WithScheme(reconcilerTestScheme).WithObjects(tt.existing...).Build(),
+				Scheme: reconcilerTestScheme,
+			})
+
+			err := r.checkOwnership(context.Background(), manifests.Params{CollectorTopology: topology}, desired)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -12,6 +12,7 @@ import (
 	rbacv1 "k8s.io/api/rbac/v1"
 	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/util/retry"
@@ -95,13 +96,20 @@ func getList[T client.Object](ctx context.Context, cl client.Client, l T, option
 		return nil, err
 	}
 	gvk.Kind = fmt.Sprintf("%sList", gvk.Kind)
-	list, err := cl.Scheme().New(gvk)
-	if err != nil {
-		return nil, fmt.Errorf("unable to list objects of type %s: %w", gvk.Kind, err)
+	var objList client.ObjectList
+	if _, isUnstructured := any(l).(*unstructured.Unstructured); isUnstructured {
+		// the kinds whose API isn't a dependency of the operator aren't registered in the scheme
+		unstructuredList := &unstructured.UnstructuredList{}
+		unstructuredList.SetGroupVersionKind(gvk)
+		objList = unstructuredList
+	} else {
+		list, newErr := cl.Scheme().New(gvk)
+		if newErr != nil {
+			return nil, fmt.Errorf("unable to list objects of type %s: %w", gvk.Kind, newErr)
+		}
+		objList = list.(client.ObjectList)
 	}
 
-	objList := list.(client.ObjectList)
-
 	err = cl.List(ctx, objList, options...)
 	if err != nil {
 		return ownedObjects, fmt.Errorf("error listing %T: %w", l, err)
@@ -128,7 +136,8 @@ func reconcileDesiredObjects(ctx context.Context, kubeClient client.Client, logg
 			"object_name", desired.GetName(),
 			"object_kind", desired.GetObjectKind(),
 		)
-		if isNamespaceScoped(desired) {
+		// the objects outside the namespace of their owner can't reference it, they're deleted by their labels instead
+		if isNamespaceScoped(desired) && (desired.GetNamespace() == "" || desired.GetNamespace() == owner.GetNamespace()) {
 			if setErr := ctrl.SetControllerReference(owner, desired, scheme); setErr != nil {
 				l.Error(setErr, "failed to set controller owner reference to desired")
 				errs = append(errs, setErr)
@@ -6,10 +6,14 @@ package controllers
 
 import (
 	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
 	"maps"
 	"slices"
 	"time"
 
+	cmv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	"github.com/go-logr/logr"
 	routev1 "github.com/openshift/api/route/v1"
 	securityv1 "github.com/openshift/api/security/v1"
@@ -21,42 +25,69 @@ import (
 	networkingv1 "k8s.io/api/networking/v1"
 	policyV1 "k8s.io/api/policy/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/events"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/cluster"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/certmanager"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/gatewayapi"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/grafana"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/keda"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/openshift"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/prometheus"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/rbac"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/vpa"
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/fips"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+	"github.com/open-telemetry/opentelemetry-operator/internal/otelconfig"
 	internalRbac "github.com/open-telemetry/opentelemetry-operator/internal/rbac"
 	collectorStatus "github.com/open-telemetry/opentelemetry-operator/internal/status/collector"
 	"github.com/open-telemetry/opentelemetry-operator/internal/version"
+	wh "github.com/open-telemetry/opentelemetry-operator/internal/webhook"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/collector/upgrade"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/constants"
 )
 
-const resourceOwnerKey = ".metadata.owner"
+const (
+	resourceOwnerKey = ".metadata.owner"
+	configFromKey    = ".spec.configFrom"
+)
+
+// errConfigFrom is wrapped by the errors resolving spec.configFrom.
+var errConfigFrom = errors.New("failed to resolve spec.configFrom")
 
 var ownedClusterObjectTypes = []client.Object{
 	&rbacv1.ClusterRole{},
 	&rbacv1.ClusterRoleBinding{},
 }
 
+// ownedNamespacedRBACObjectTypes are the RBAC objects created in the namespaces the components of the collector
+// access, which can't be owned by the collector outside its namespace.
+var ownedNamespacedRBACObjectTypes = []client.Object{
+	&rbacv1.Role{},
+	&rbacv1.RoleBinding{},
+}
+
 // OpenTelemetryCollectorReconciler reconciles a OpenTelemetryCollector object.
 type OpenTelemetryCollectorReconciler struct {
 	client.Client
@@ -66,6 +97,11 @@ type OpenTelemetryCollectorReconciler struct {
 	config   config.Config
 	reviewer *internalRbac.Reviewer
 	upgrade  *upgrade.VersionUpgrade
+	webhook  *wh.CollectorWebhook
+	// configFragmentSecrets holds the Secrets labeled as config fragments, can be nil.
+	configFragmentSecrets cache.Cache
+	// rbacObjects holds the Roles and RoleBindings managed by the operator, can be nil.
+	rbacObjects cache.Cache
 }
 
 // Params is the set of options to build a new OpenTelemetryCollectorReconciler.
@@ -77,6 +113,43 @@ type Params struct {
 	Config   config.Config
 	Reviewer *internalRbac.Reviewer
 	Version  version.Version
+	// FIPSCheck validates the collector components of configurations merged from spec.configFrom, can be nil.
+	FIPSCheck fips.FIPSCheck
+	// ConfigFragmentSecrets holds the Secrets labeled as config fragments, so that the other Secrets aren't cached
+	// to resolve spec.configFrom. When nil, the Secrets are read and watched through the manager.
+	ConfigFragmentSecrets cache.Cache
+	// RBACObjects holds the Roles and RoleBindings managed by the operator, so that the other ones aren't cached. When
+	// nil, they are read and watched through the manager.
+	RBACObjects cache.Cache
+}
+
+// rbacObjectsClient reads the Roles and RoleBindings from the cache holding the ones managed by the operator.
+type rbacObjectsClient struct {
+	client.Client
+	rbacObjects client.Reader
+}
+
+func (c rbacObjectsClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	if isNamespacedRBACObject(obj) {
+		return c.rbacObjects.Get(ctx, key, obj, opts...)
+	}
+	return c.Client.Get(ctx, key, obj, opts...)
+}
+
+func (c rbacObjectsClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	switch list.(type) {
+	case *rbacv1.RoleList, *rbacv1.RoleBindingList:
+		return c.rbacObjects.List(ctx, list, opts...)
+	}
+	return c.Client.List(ctx, list, opts...)
+}
+
+func isNamespacedRBACObject(obj client.Object) bool {
+	switch obj.(type) {
+	case *rbacv1.Role, *rbacv1.RoleBinding:
+		return true
+	}
+	return false
 }
 
 func (r *OpenTelemetryCollectorReconciler) findOtelOwnedObjects(ctx context.Context, params manifests.Params) (map[types.UID]client.Object, error) {
@@ -88,6 +161,10 @@ func (r *OpenTelemetryCollectorReconciler) findOtelOwnedObjects(ctx context.Cont
 		client.MatchingFields{resourceOwnerKey: params.OtelCol.Name},
 	}
 	for _, objectType := range ownedObjectTypes {
+		// the roles and bindings are found by their labels in every namespace, including the collector's, below
+		if isNamespacedRBACObject(objectType) {
+			continue
+		}
 		objs, err := getList(ctx, r, objectType, listOpts...)
 		if err != nil {
 			return nil, err
@@ -98,6 +175,10 @@ func (r *OpenTelemetryCollectorReconciler) findOtelOwnedObjects(ctx context.Cont
 		case *corev1.ConfigMap:
 			for _, object := range objs {
 				configMap := object.(*corev1.ConfigMap)
+				// the dashboard isn't a version of the collector configuration
+				if configMap.Name == naming.Dashboard(params.OtelCol.Name) {
+					continue
+				}
 				collectorConfigMaps = append(collectorConfigMaps, configMap)
 			}
 		default:
@@ -111,6 +192,22 @@ func (r *OpenTelemetryCollectorReconciler) findOtelOwnedObjects(ctx context.Cont
 	for _, configMap := range configMapsToKeep {
 		delete(ownedObjects, configMap.GetUID())
 	}
+	// the roles in other namespaces don't have owner references
+	if params.Config.CreateRBACPermissions == rbac.Available && params.Config.CreateRoleRBACPermissions == rbac.Available {
+		objs, err := r.findNamespacedRBACObjects(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+		maps.Copy(ownedObjects, objs)
+	}
+	// the pods keep running the stable configuration while a new one is analyzed or after it was rolled back
+	if rollout := params.OtelCol.Status.ConfigRollout; params.OtelCol.Spec.ConfigRollout != nil && rollout != nil && rollout.Stable != nil {
+		for _, configMap := range collectorConfigMaps {
+			if configMap.Name == rollout.Stable.ConfigMap {
+				delete(ownedObjects, configMap.GetUID())
+			}
+		}
+	}
 
 	return ownedObjects, nil
 }
@@ -134,6 +231,23 @@ func (r *OpenTelemetryCollectorReconciler) findClusterRoleObjects(ctx context.Co
 	return ownedObjects, nil
 }
 
+// findNamespacedRBACObjects finds the roles and bindings of the collector in all namespaces.
+func (r *OpenTelemetryCollectorReconciler) findNamespacedRBACObjects(ctx context.Context, params manifests.Params) (map[types.UID]client.Object, error) {
+	ownedObjects := map[types.UID]client.Object{}
+	listOps := &client.ListOptions{
+		LabelSelector: labels.SelectorFromSet(
+			manifestutils.SelectorLabels(params.OtelCol.ObjectMeta, collector.ComponentOpenTelemetryCollector)),
+	}
+	for _, objectType := range ownedNamespacedRBACObjectTypes {
+		objs, err := getList(ctx, r, objectType, listOps)
+		if err != nil {
+			return nil, err
+		}
+		maps.Copy(ownedObjects, objs)
+	}
+	return ownedObjects, nil
+}
+
 // getCollectorConfigMapsToKeep gets ConfigMaps the controller would normally delete, but which we want to keep around
 // anyway. This is part of a feature to keep around previous ConfigMap versions to make rollbacks easier.
 // Fundamentally, this just sorts by time created and picks configVersionsToKeep latest ones.
@@ -170,8 +284,21 @@ func (r *OpenTelemetryCollectorReconciler) GetParams(ctx context.Context, instan
 		Scheme:   r.scheme,
 		Recorder: r.recorder,
 		Reviewer: r.reviewer,
+
+		ConfigFragmentSecrets: r.configFragmentSecrets,
 	}
 
+	// the target allocator and the manifests are generated from the configuration including the fragments
+	if err := r.resolveConfigFrom(ctx, &p); err != nil {
+		return p, fmt.Errorf("%w: %w", errConfigFrom, err)
+	}
+	// the collector pods keep running the stable configuration while the instance's one is analyzed on canary pods
+	stableConfig, err := collector.StableConfig(ctx, p)
+	if err != nil {
+		return p, err
+	}
+	p.StableConfig = stableConfig
+
 	// generate the target allocator CR from the collector CR
 	targetAllocator, err := r.getTargetAllocator(ctx, p)
 	if err != nil {
@@ -181,6 +308,106 @@ func (r *OpenTelemetryCollectorReconciler) GetParams(ctx context.Context, instan
 	return p, nil
 }
 
+// resolveConfigFrom merges the configuration fragments referenced by spec.configFrom into the collector's configuration
+// and validates the result like the webhook validates spec.config. The fragments must not require RBAC permissions
+// the inline configuration doesn't, as the permissions of their authors can't be checked against the ones the
+// operator would grant. The fragments stored in Secrets are validated, but left out of the collector's configuration:
+// the collector reads them from the Secrets, so that their content isn't stored in its ConfigMap.
+func (r *OpenTelemetryCollectorReconciler) resolveConfigFrom(ctx context.Context, params *manifests.Params) error {
+	if len(params.OtelCol.Spec.ConfigFrom) == 0 {
+		return nil
+	}
+	fragments, err := collector.ConfigFragments(ctx, *params)
+	if err != nil {
+		return err
+	}
+	merged, err := collector.MergeConfigFragments(params.OtelCol.Spec.Config, fragments)
+	if err != nil {
+		return err
+	}
+	if _, err = otelconfig.ApplyDefaults(&merged, r.log); err != nil {
+		return err
+	}
+
+	if r.config.CreateRBACPermissions == rbac.Available {
+		if err = checkConfigFromRbacRules(params.OtelCol.Spec.Config, merged, r.log); err != nil {
+			return err
+		}
+	}
+	otelcol := params.OtelCol.DeepCopy()
+	otelcol.Spec.Config = merged
+	if _, err = r.webhook.Validate(ctx, otelcol); err != nil {
+		return fmt.Errorf("the configuration merged from spec.configFrom is invalid: %w", err)
+	}
+
+	var configMapFragments, secretFragments []collector.ConfigFragment
+	for _, fragment := range fragments {
+		if fragment.SecretKeyRef != nil {
+			secretFragments = append(secretFragments, fragment)
+		} else {
+			configMapFragments = append(configMapFragments, fragment)
+		}
+	}
+	if len(secretFragments) > 0 {
+		if merged, err = collector.MergeConfigFragments(params.OtelCol.Spec.Config, configMapFragments); err != nil {
+			return err
+		}
+		if _, err = otelconfig.ApplyDefaults(&merged, r.log); err != nil {
+			return err
+		}
+		if err = collector.CheckSecretFragments(merged, secretFragments); err != nil {
+			return err
+		}
+		if err = checkConfigFromPorts(merged, otelcol.Spec.Config, r.log); err != nil {
+			return err
+		}
+		for _, fragment := range secretFragments {
+			params.ConfigFromSecrets = append(params.ConfigFromSecrets, manifests.ConfigFromSecret{
+				Name: fragment.SecretKeyRef.Name,
+				Key:  fragment.SecretKeyRef.Key,
+				Hash: fmt.Sprintf("%x", sha256.Sum256([]byte(fragment.Yaml))),
+			})
+		}
+	}
+
+	params.OtelCol.Spec.Config = merged
+	return nil
+}
+
+// checkConfigFromPorts checks that the fragments stored in Secrets don't change the ports of the collector, as its
+// Services are derived from the configuration without them.
+func checkConfigFromPorts(withoutSecrets, merged v1beta1.Config, logger logr.Logger) error {
+	expected, err := otelconfig.GetAllPorts(&withoutSecrets, logger)
+	if err != nil {
+		return err
+	}
+	actual, err := otelconfig.GetAllPorts(&merged, logger)
+	if err != nil {
+		return err
+	}
+	if !equality.Semantic.DeepEqual(expected, actual) {
+		return errors.New("the config fragments stored in Secrets must not change the ports of the collector")
+	}
+	return nil
+}
+
+func checkConfigFromRbacRules(inline, merged v1beta1.Config, logger logr.Logger) error {
+	allowed, err := otelconfig.GetAllRbacRules(&inline, logger)
+	if err != nil {
+		return err
+	}
+	required, err := otelconfig.GetAllRbacRules(&merged, logger)
+	if err != nil {
+		return err
+	}
+	for _, rule := range required {
+		if !slices.ContainsFunc(allowed, func(r rbacv1.PolicyRule) bool { return equality.Semantic.DeepEqual(r, rule) }) {
+			return fmt.Errorf("the configuration merged from spec.configFrom requires RBAC permissions the spec.config doesn't: %s", rule.String())
+		}
+	}
+	return nil
+}
+
 // defaultFSGroupOnOpenShift sets podSecurityContext.fsGroup from the namespace's
 // supplemental-groups or UID range annotation when running on OpenShift and no
 // explicit fsGroup is configured.
@@ -252,6 +479,13 @@ func NewReconciler(p Params) *OpenTelemetryCollectorReconciler {
 		recorder: p.Recorder,
 		reviewer: p.Reviewer,
 		upgrade:  up,
+		webhook:  wh.NewCollectorWebhook(p.Log, p.Scheme, p.Config, p.Reviewer, nil, nil, nil, p.FIPSCheck),
+
+		configFragmentSecrets: p.ConfigFragmentSecrets,
+		rbacObjects:           p.RBACObjects,
+	}
+	if p.RBACObjects != nil {
+		r.Client = rbacObjectsClient{Client: p.Client, rbacObjects: p.RBACObjects}
 	}
 	return r
 }
@@ -266,10 +500,13 @@ func NewReconciler(p Params) *OpenTelemetryCollectorReconciler {
 // +kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=coordination.k8s.io,resources=leases,verbs=get;list;create;update
 // +kubebuilder:rbac:groups=discovery.k8s.io,resources=endpointslices,verbs=get;list
-// +kubebuilder:rbac:groups=monitoring.coreos.com,resources=servicemonitors;podmonitors,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=monitoring.coreos.com,resources=servicemonitors;podmonitors;prometheusrules,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=networking.k8s.io,resources=networkpolicies,verbs=get;list;watch;create;update;patch;delete
-// +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=httproutes,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=httproutes;grpcroutes;tlsroutes,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=keda.sh,resources=scaledobjects,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=autoscaling.k8s.io,resources=verticalpodautoscalers,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=grafana.integreatly.org,resources=grafanadashboards,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=apps,resources=deployments/finalizers,verbs=get;watch;update;patch
 // +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
 // +kubebuilder:rbac:groups=route.openshift.io,resources=routes;routes/custom-host,verbs=get;list;watch;create;update;patch;delete
@@ -297,8 +534,10 @@ func (r *OpenTelemetryCollectorReconciler) Reconcile(ctx context.Context, req ct
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
+	// the errors resolving spec.configFrom are reported in the status, once the collector isn't being deleted
 	params, err := r.GetParams(ctx, instance)
-	if err != nil {
+	configFromErr := err
+	if err != nil && !errors.Is(err, errConfigFrom) {
 		log.Error(err, "Failed to create manifest.Params")
 		return ctrl.Result{}, err
 	}
@@ -333,6 +572,10 @@ func (r *OpenTelemetryCollectorReconciler) Reconcile(ctx context.Context, req ct
 		}
 	}
 
+	if configFromErr != nil {
+		return collectorStatus.HandleReconcileStatus(ctx, log, params, instance, configFromErr)
+	}
+
 	desiredObjects, buildErr := BuildCollector(params)
 	if buildErr != nil {
 		return ctrl.Result{}, buildErr
@@ -359,16 +602,60 @@ func (r *OpenTelemetryCollectorReconciler) SetupWithManager(mgr ctrl.Manager) er
 		For(&v1beta1.OpenTelemetryCollector{})
 
 	for _, resource := range ownedResources {
+		// the roles and bindings are watched through their own cache, so that the manager's one doesn't hold every
+		// Role and RoleBinding of the cluster
+		if r.rbacObjects != nil && isNamespacedRBACObject(resource) {
+			builder.WatchesRawSource(source.Kind[client.Object](r.rbacObjects, resource,
+				handler.EnqueueRequestForOwner(mgr.GetScheme(), mgr.GetRESTMapper(), &v1beta1.OpenTelemetryCollector{}, handler.OnlyControllerOwner())))
+			continue
+		}
 		builder.Owns(resource)
 	}
 
+	// roll the collectors out again when the fragments of their configuration change, the Secrets are watched through
+	// their own cache, so that the manager's one doesn't hold every Secret
+	builder.Watches(&corev1.ConfigMap{}, handler.EnqueueRequestsFromMapFunc(r.findCollectorsReferencing("ConfigMap")))
+	if r.configFragmentSecrets != nil {
+		builder.WatchesRawSource(source.Kind[client.Object](r.configFragmentSecrets, &corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(r.findCollectorsReferencing("Secret"))))
+	} else {
+		builder.Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(r.findCollectorsReferencing("Secret")))
+	}
+
 	return builder.Complete(r)
 }
 
+// findCollectorsReferencing returns a function mapping ConfigMaps or Secrets to the collectors referencing them in
+// spec.configFrom.
+func (r *OpenTelemetryCollectorReconciler) findCollectorsReferencing(kind string) handler.MapFunc {
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		collectors := &v1beta1.OpenTelemetryCollectorList{}
+		if err := r.List(ctx, collectors,
+			client.InNamespace(obj.GetNamespace()),
+			client.MatchingFields{configFromKey: configFromIndexValue(kind, obj.GetName())},
+		); err != nil {
+			r.log.Error(err, "failed to list the collectors referencing a config fragment", "kind", kind, "name", obj.GetName(), "namespace", obj.GetNamespace())
+			return nil
+		}
+		requests := make([]reconcile.Request, 0, len(collectors.Items))
+		for _, otelcol := range collectors.Items {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&otelcol)})
+		}
+		return requests
+	}
+}
+
+func configFromIndexValue(kind, name string) string {
+	return kind + "/" + name
+}
+
 // SetupCaches sets up caching and indexing for our controller.
 func (r *OpenTelemetryCollectorReconciler) SetupCaches(cluster cluster.Cluster) error {
 	ownedResources := r.GetOwnedResourceTypes()
 	for _, resource := range ownedResources {
+		// the roles and bindings are found by their labels rather than their owner
+		if isNamespacedRBACObject(resource) {
+			continue
+		}
 		if err := cluster.GetCache().IndexField(context.Background(), resource, resourceOwnerKey, func(rawObj client.Object) []string {
 			owner := metav1.GetControllerOf(rawObj)
 			if owner == nil {
@@ -384,7 +671,23 @@ func (r *OpenTelemetryCollectorReconciler) SetupCaches(cluster cluster.Cluster)
 			return err
 		}
 	}
-	return nil
+
+	return cluster.GetCache().IndexField(context.Background(), &v1beta1.OpenTelemetryCollector{}, configFromKey, func(rawObj client.Object) []string {
+		otelcol, ok := rawObj.(*v1beta1.OpenTelemetryCollector)
+		if !ok {
+			return nil
+		}
+		var sources []string
+		for _, source := range otelcol.Spec.ConfigFrom {
+			if source.ConfigMapKeyRef != nil {
+				sources = append(sources, configFromIndexValue("ConfigMap", source.ConfigMapKeyRef.Name))
+			}
+			if source.SecretKeyRef != nil {
+				sources = append(sources, configFromIndexValue("Secret", source.SecretKeyRef.Name))
+			}
+		}
+		return sources
+	})
 }
 
 // GetOwnedResourceTypes returns all the resource types the controller can own. Even though this method returns an array
@@ -407,11 +710,15 @@ func (r *OpenTelemetryCollectorReconciler) GetOwnedResourceTypes() []client.Obje
 	if r.config.CreateRBACPermissions == rbac.Available {
 		ownedResources = append(ownedResources, &rbacv1.ClusterRole{})
 		ownedResources = append(ownedResources, &rbacv1.ClusterRoleBinding{})
+		if r.config.CreateRoleRBACPermissions == rbac.Available {
+			ownedResources = append(ownedResources, ownedNamespacedRBACObjectTypes...)
+		}
 	}
 
 	if r.config.PrometheusCRAvailability == prometheus.Available {
 		ownedResources = append(ownedResources, &monitoringv1.PodMonitor{})
 		ownedResources = append(ownedResources, &monitoringv1.ServiceMonitor{})
+		ownedResources = append(ownedResources, &monitoringv1.PrometheusRule{})
 	}
 
 	if r.config.OpenShiftRoutesAvailability == openshift.RoutesAvailable {
@@ -422,6 +729,37 @@ func (r *OpenTelemetryCollectorReconciler) GetOwnedResourceTypes() []client.Obje
 		ownedResources = append(ownedResources, &gatewayv1.HTTPRoute{})
 	}
 
+	if r.config.GRPCRouteAvailability == gatewayapi.ApiAvailable {
+		ownedResources = append(ownedResources, &gatewayv1.GRPCRoute{})
+	}
+
+	if r.config.TLSRouteAvailability == gatewayapi.ApiAvailable {
+		ownedResources = append(ownedResources, &gatewayv1.TLSRoute{})
+	}
+
+	if r.config.CertManagerAvailability == certmanager.Available {
+		ownedResources = append(ownedResources, &cmv1.Certificate{})
+		ownedResources = append(ownedResources, &cmv1.Issuer{})
+	}
+
+	if r.config.KedaAvailability == keda.Available {
+		scaledObject := &unstructured.Unstructured{}
+		scaledObject.SetGroupVersionKind(collector.ScaledObjectGVK)
+		ownedResources = append(ownedResources, scaledObject)
+	}
+
+	if r.config.VPAAvailability == vpa.Available {
+		verticalPodAutoscaler := &unstructured.Unstructured{}
+		verticalPodAutoscaler.SetGroupVersionKind(collector.VerticalPodAutoscalerGVK)
+		ownedResources = append(ownedResources, verticalPodAutoscaler)
+	}
+
+	if r.config.GrafanaDashboardAvailability == grafana.Available {
+		grafanaDashboard := &unstructured.Unstructured{}
+		grafanaDashboard.SetGroupVersionKind(collector.GrafanaDashboardGVK)
+		ownedResources = append(ownedResources, grafanaDashboard)
+	}
+
 	return ownedResources
 }
 
@@ -434,6 +772,14 @@ func (r *OpenTelemetryCollectorReconciler) finalizeCollector(ctx context.Context
 		if err != nil {
 			return err
 		}
+		// nor do the roles and bindings outside the collector's namespace
+		if params.Config.CreateRoleRBACPermissions == rbac.Available {
+			namespacedObjects, err := r.findNamespacedRBACObjects(ctx, params)
+			if err != nil {
+				return err
+			}
+			maps.Copy(objects, namespacedObjects)
+		}
 		return deleteObjects(ctx, r.Client, r.log, objects)
 	}
 	return nil
@@ -15,10 +15,13 @@ import (
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
@@ -268,3 +271,25 @@ func TestRemoveFinalizer(t *testing.T) {
 		})
 	}
 }
+
+func TestRBACObjectsClient(t *testing.T) {
+	managed := &rbacv1.Role{ObjectMeta: metav1.ObjectMeta{Name: "managed", Namespace: "observability"}}
+	unmanaged := &rbacv1.Role{ObjectMeta: metav1.ObjectMeta{Name: "unmanaged", Namespace: "observability"}}
+	configMap := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "config", Namespace: "observability"}}
+	cl := rbacObjectsClient{
+		Client:      fake.NewClientBuilder().WithScheme(reconcilerTestScheme).WithObjects(unmanaged, configMap).Build(),
+		rbacObjects: fake.NewClientBuilder().WithScheme(reconcilerTestScheme).WithObjects(managed).Build(),
+	}
+	ctx := context.Background()
+
+	// the roles and bindings are read from the cache of the managed ones
+	require.NoError(t, cl.Get(ctx, client.ObjectKeyFromObject(managed), &rbacv1.Role{}))
+	assert.True(t, apierrors.IsNotFound(cl.Get(ctx, client.ObjectKeyFromObject(unmanaged), &rbacv1.Role{})))
+	roles := &rbacv1.RoleList{}
+	require.NoError(t, cl.List(ctx, roles))
+	require.Len(t, roles.Items, 1)
+	assert.Equal(t, "managed", roles.Items[0].Name)
+
+	// the other objects are read from the manager
+	require.NoError(t, cl.Get(ctx, client.ObjectKeyFromObject(configMap), &corev1.ConfigMap{}))
+}
@@ -43,11 +43,14 @@ import (
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/certmanager"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/collector"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/gatewayapi"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/grafana"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/keda"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/opampbridge"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/openshift"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/prometheus"
 	autoRBAC "github.com/open-telemetry/opentelemetry-operator/internal/autodetect/rbac"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/targetallocator"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/vpa"
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector/testdata"
@@ -87,14 +90,19 @@ const (
 var _ autodetect.AutoDetect = (*mockAutoDetect)(nil)
 
 type mockAutoDetect struct {
-	OpenShiftRoutesAvailabilityFunc func() (openshift.RoutesAvailability, error)
-	PrometheusCRsAvailabilityFunc   func() (prometheus.Availability, error)
-	RBACPermissionsFunc             func(ctx context.Context) (autoRBAC.Availability, error)
-	CertManagerAvailabilityFunc     func(ctx context.Context) (certmanager.Availability, error)
-	TargetAllocatorAvailabilityFunc func() (targetallocator.Availability, error)
-	CollectorCRDAvailabilityFunc    func() (collector.Availability, error)
-	OpAmpBridgeAvailabilityFunc     func() (opampbridge.Availability, error)
-	GatewayAPIsAvailabilityFunc     func() (gatewayapi.ApiAvailability, error)
+	OpenShiftRoutesAvailabilityFunc  func() (openshift.RoutesAvailability, error)
+	PrometheusCRsAvailabilityFunc    func() (prometheus.Availability, error)
+	RBACPermissionsFunc              func(ctx context.Context) (autoRBAC.Availability, error)
+	RoleRBACPermissionsFunc          func(ctx context.Context) (autoRBAC.Availability, error)
+	CertManagerAvailabilityFunc      func(ctx context.Context) (certmanager.Availability, error)
+	TargetAllocatorAvailabilityFunc  func() (targetallocator.Availability, error)
+	CollectorCRDAvailabilityFunc     func() (collector.Availability, error)
+	OpAmpBridgeAvailabilityFunc      func() (opampbridge.Availability, error)
+	GatewayAPIsAvailabilityFunc      func() (gatewayapi.ApiAvailability, error)
+	GatewayRouteAvailabilityFunc     func(kind string) (gatewayapi.ApiAvailability, error)
+	KedaAvailabilityFunc             func() (keda.Availability, error)
+	VPAAvailabilityFunc              func() (vpa.Availability, error)
+	GrafanaDashboardAvailabilityFunc func() (grafana.Availability, error)
 }
 
 func (*mockAutoDetect) FIPSEnabled(context.Context) bool {
@@ -126,6 +134,13 @@ func (m *mockAutoDetect) RBACPermissions(ctx context.Context) (autoRBAC.Availabi
 	return autoRBAC.NotAvailable, nil
 }
 
+func (m *mockAutoDetect) RoleRBACPermissions(ctx context.Context) (autoRBAC.Availability, error) {
+	if m.RoleRBACPermissionsFunc != nil {
+		return m.RoleRBACPermissionsFunc(ctx)
+	}
+	return autoRBAC.NotAvailable, nil
+}
+
 func (m *mockAutoDetect) CertManagerAvailability(ctx context.Context) (certmanager.Availability, error) {
 	if m.CertManagerAvailabilityFunc != nil {
 		return m.CertManagerAvailabilityFunc(ctx)
@@ -161,6 +176,34 @@ func (m *mockAutoDetect) GatewayAPIsAvailability() (gatewayapi.ApiAvailability,
 	return gatewayapi.ApiNotAvailable, nil
 }
 
+func (m *mockAutoDetect) GatewayRouteAvailability(kind string) (gatewayapi.ApiAvailability, error) {
+	if m.GatewayRouteAvailabilityFunc != nil {
+		return m.GatewayRouteAvailabilityFunc(kind)
+	}
+	return gatewayapi.ApiNotAvailable, nil
+}
+
+func (m *mockAutoDetect) KedaAvailability() (keda.Availability, error) {
+	if m.KedaAvailabilityFunc != nil {
+		return m.KedaAvailabilityFunc()
+	}
+	return keda.NotAvailable, nil
+}
+
+func (m *mockAutoDetect) VPAAvailability() (vpa.Availability, error) {
+	if m.VPAAvailabilityFunc != nil {
+		return m.VPAAvailabilityFunc()
+	}
+	return vpa.NotAvailable, nil
+}
+
+func (m *mockAutoDetect) GrafanaDashboardAvailability() (grafana.Availability, error) {
+	if m.GrafanaDashboardAvailabilityFunc != nil {
+		return m.GrafanaDashboardAvailabilityFunc()
+	}
+	return grafana.NotAvailable, nil
+}
+
 func TestMain(m *testing.M) {
 	var err error
 	ctx, cancel = context.WithCancel(context.TODO())
@@ -179,7 +222,7 @@ func TestMain(m *testing.M) {
 
 	tenv, err := testenv.Start(&ctrlenvtest.Environment{
 		CRDDirectoryPaths: []string{filepath.Join("..", "..", "config", "crd", "bases")},
-		CRDs:              []*apiextensionsv1.CustomResourceDefinition{testdata.OpenShiftRouteCRD, testdata.ServiceMonitorCRD, testdata.PodMonitorCRD, testdata.HTTPRouteCRD},
+		CRDs:              []*apiextensionsv1.CustomResourceDefinition{testdata.OpenShiftRouteCRD, testdata.ServiceMonitorCRD, testdata.PodMonitorCRD, testdata.PrometheusRuleCRD, testdata.HTTPRouteCRD},
 		WebhookInstallOptions: ctrlenvtest.WebhookInstallOptions{
 			Paths:                   []string{filepath.Join("..", "..", "config", "webhook")},
 			IgnoreSchemeConvertible: true,
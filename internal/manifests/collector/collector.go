@@ -25,6 +25,7 @@ func Build(params manifests.Params) ([]client.Object, error) {
 	switch params.OtelCol.Spec.Mode {
 	case v1beta1.ModeDeployment:
 		manifestFactories = append(manifestFactories, manifests.Factory(Deployment))
+		manifestFactories = append(manifestFactories, manifests.Factory(CanaryDeployment))
 		manifestFactories = append(manifestFactories, manifests.Factory(PodDisruptionBudget))
 	case v1beta1.ModeStatefulSet:
 		manifestFactories = append(manifestFactories, manifests.Factory(StatefulSet))
@@ -45,12 +46,18 @@ func Build(params manifests.Params) ([]client.Object, error) {
 	if params.OtelCol.Spec.Mode != v1beta1.ModeSidecar {
 		manifestFactories = append(manifestFactories, []manifests.K8sManifestFactory[manifests.Params]{
 			manifests.Factory(HorizontalPodAutoscaler),
+			manifests.Factory(ScaledObject),
+			manifests.Factory(VerticalPodAutoscaler),
 			manifests.Factory(Service),
 			manifests.Factory(HeadlessService),
 			manifests.Factory(MonitoringService),
 			manifests.Factory(ExtensionService),
 			manifests.Factory(Ingress),
 			manifests.Factory(NetworkPolicy),
+			manifests.Factory(ReceiverSelfSignedIssuer),
+			manifests.Factory(ReceiverCACertificate),
+			manifests.Factory(ReceiverCAIssuer),
+			manifests.Factory(ReceiverCertificate),
 		}...)
 	}
 
@@ -64,6 +71,12 @@ func Build(params manifests.Params) ([]client.Object, error) {
 		}
 	}
 
+	manifestFactories = append(manifestFactories,
+		manifests.Factory(PrometheusRule),
+		manifests.Factory(DashboardConfigMap),
+		manifests.Factory(GrafanaDashboard),
+	)
+
 	if params.Config.CreateRBACPermissions == rbac.Available {
 		manifestFactories = append(manifestFactories,
 			manifests.Factory(ClusterRole),
@@ -102,6 +115,23 @@ func Build(params manifests.Params) ([]client.Object, error) {
 		resourceManifests = append(resourceManifests, route)
 	}
 
+	if params.Config.CreateRBACPermissions == rbac.Available {
+		roles, err := Roles(params)
+		if err != nil {
+			return nil, err
+		}
+		for _, role := range roles {
+			resourceManifests = append(resourceManifests, role)
+		}
+		roleBindings, err := RoleBindings(params)
+		if err != nil {
+			return nil, err
+		}
+		for _, roleBinding := range roleBindings {
+			resourceManifests = append(resourceManifests, roleBinding)
+		}
+	}
+
 	httpRoutes, err := HTTPRoutes(params)
 	if err != nil {
 		return nil, err
@@ -110,6 +140,22 @@ func Build(params manifests.Params) ([]client.Object, error) {
 		resourceManifests = append(resourceManifests, httpRoute)
 	}
 
+	grpcRoutes, err := GRPCRoutes(params)
+	if err != nil {
+		return nil, err
+	}
+	for _, grpcRoute := range grpcRoutes {
+		resourceManifests = append(resourceManifests, grpcRoute)
+	}
+
+	tlsRoutes, err := TLSRoutes(params)
+	if err != nil {
+		return nil, err
+	}
+	for _, tlsRoute := range tlsRoutes {
+		resourceManifests = append(resourceManifests, tlsRoute)
+	}
+
 	return resourceManifests, nil
 }
 
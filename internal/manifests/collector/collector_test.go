@@ -136,6 +136,10 @@ func (*mockReviewer) CheckPolicyRules(context.Context, string, string, ...*rbacv
 	return nil, errors.New("error checking policy rules")
 }
 
+func (*mockReviewer) CheckNamespacedPolicyRules(context.Context, string, string, string, ...*rbacv1.PolicyRule) ([]*v1.SubjectAccessReview, error) {
+	return nil, errors.New("error checking policy rules")
+}
+
 func (*mockReviewer) CanAccess(context.Context, string, string, *v1.ResourceAttributes, *v1.NonResourceAttributes) (*v1.SubjectAccessReview, error) {
 	return nil, nil
 }
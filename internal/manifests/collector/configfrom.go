@@ -0,0 +1,62 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package collector
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"path"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+)
+
+const (
+	configFromMountPath      = "/conf-from"
+	configFromFile           = "collector.yaml"
+	configFromHashAnnotation = "opentelemetry-operator-config-from/sha256"
+)
+
+// applyConfigFromSecrets mounts the fragments of the configuration stored in Secrets in the collector container and
+// passes them to the collector, which merges them into the configuration of its ConfigMap. The hash of the fragments
+// is set as a pod annotation, so that the pods are rolled out again when a fragment changes.
+func applyConfigFromSecrets(params manifests.Params, template *corev1.PodTemplateSpec) {
+	if len(params.ConfigFromSecrets) == 0 {
+		return
+	}
+	hash := sha256.New()
+	for i, secret := range params.ConfigFromSecrets {
+		volumeName := fmt.Sprintf("otc-config-from-%d", i)
+		mountPath := path.Join(configFromMountPath, strconv.Itoa(i))
+		template.Spec.Volumes = append(template.Spec.Volumes, corev1.Volume{
+			Name: volumeName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: secret.Name,
+					Items:      []corev1.KeyToPath{{Key: secret.Key, Path: configFromFile}},
+				},
+			},
+		})
+		for j := range template.Spec.Containers {
+			container := &template.Spec.Containers[j]
+			if container.Name != naming.Container() {
+				continue
+			}
+			container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+				Name:      volumeName,
+				MountPath: mountPath,
+				ReadOnly:  true,
+			})
+			container.Args = append(container.Args, fmt.Sprintf("--config=%s", path.Join(mountPath, configFromFile)))
+		}
+		hash.Write([]byte(secret.Hash))
+	}
+	if template.Annotations == nil {
+		template.Annotations = map[string]string{}
+	}
+	template.Annotations[configFromHashAnnotation] = fmt.Sprintf("%x", hash.Sum(nil))
+}
@@ -0,0 +1,62 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package collector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+)
+
+func TestApplyConfigFromSecrets(t *testing.T) {
+	params := manifests.Params{
+		Config: config.New(),
+		OtelCol: v1beta1.OpenTelemetryCollector{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-instance", Namespace: "my-namespace"},
+		},
+		Log: testLogger,
+		ConfigFromSecrets: []manifests.ConfigFromSecret{
+			{Name: "credentials", Key: "exporters.yaml", Hash: "first"},
+		},
+	}
+
+	d, err := Deployment(params)
+	require.NoError(t, err)
+
+	podSpec := d.Spec.Template.Spec
+	assert.Contains(t, podSpec.Volumes, corev1.Volume{
+		Name: "otc-config-from-0",
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: "credentials",
+				Items:      []corev1.KeyToPath{{Key: "exporters.yaml", Path: "collector.yaml"}},
+			},
+		},
+	})
+	container := podSpec.Containers[0]
+	assert.Contains(t, container.VolumeMounts, corev1.VolumeMount{Name: "otc-config-from-0", MountPath: "/conf-from/0", ReadOnly: true})
+	assert.Equal(t, []string{"--config=/conf/collector.yaml", "--config=/conf-from/0/collector.yaml"}, container.Args)
+
+	// the pods are rolled out again when a fragment changes
+	hash := d.Spec.Template.Annotations["opentelemetry-operator-config-from/sha256"]
+	assert.NotEmpty(t, hash)
+	params.ConfigFromSecrets[0].Hash = "second"
+	d, err = Deployment(params)
+	require.NoError(t, err)
+	assert.NotEqual(t, hash, d.Spec.Template.Annotations["opentelemetry-operator-config-from/sha256"])
+
+	// without fragments stored in Secrets, the pods are left untouched
+	params.ConfigFromSecrets = nil
+	d, err = Deployment(params)
+	require.NoError(t, err)
+	assert.NotContains(t, d.Spec.Template.Annotations, "opentelemetry-operator-config-from/sha256")
+	assert.Equal(t, []string{"--config=/conf/collector.yaml"}, d.Spec.Template.Spec.Containers[0].Args)
+}
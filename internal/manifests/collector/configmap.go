@@ -4,12 +4,21 @@
 package collector
 
 import (
+	"context"
+	"fmt"
 	"path/filepath"
 
+	go_yaml "github.com/goccy/go-yaml"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
 	"github.com/open-telemetry/opentelemetry-operator/internal/components"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
 	ta "github.com/open-telemetry/opentelemetry-operator/internal/manifests/targetallocator/adapters"
@@ -18,6 +27,19 @@ import (
 	"github.com/open-telemetry/opentelemetry-operator/pkg/constants"
 )
 
+// configDefaultOptions returns the defaults the operator applies to the configuration of the collector at
+// reconciliation time: the TLS settings of the cluster's TLS profile, and the receiver certificate.
+func configDefaultOptions(cfg config.Config, otelcol v1beta1.OpenTelemetryCollector) []components.DefaultOption {
+	var opts []components.DefaultOption
+	if cfg.Internal.OperandTLSProfile != nil {
+		opts = append(opts, components.WithTLSProfile(cfg.Internal.OperandTLSProfile))
+	}
+	if servesReceiverTLS(cfg, otelcol) {
+		opts = append(opts, components.WithTLSCertificate(receiverTLSCertificate(otelcol)))
+	}
+	return opts
+}
+
 func ConfigMap(params manifests.Params) (*corev1.ConfigMap, error) {
 	// Create a deep copy of the collector to avoid modifying the original
 	otelCol := params.OtelCol.DeepCopy()
@@ -25,14 +47,22 @@ func ConfigMap(params manifests.Params) (*corev1.ConfigMap, error) {
 	// Apply TLS defaults at reconciliation time if configured.
 	// This ensures collectors get updated TLS settings when the operator restarts
 	// after a cluster TLS profile change, without requiring CR updates.
-	if params.Config.Internal.OperandTLSProfile != nil {
-		_, err := otelconfig.ApplyDefaults(&otelCol.Spec.Config, params.Log, components.WithTLSProfile(params.Config.Internal.OperandTLSProfile))
+	if defaultOpts := configDefaultOptions(params.Config, *otelCol); len(defaultOpts) > 0 {
+		_, err := otelconfig.ApplyDefaults(&otelCol.Spec.Config, params.Log, defaultOpts...)
 		if err != nil {
 			params.Log.Error(err, "failed to apply TLS defaults to collector config")
 			return nil, err
 		}
 	}
 
+	// Tune the memory_limiter processor to the resources set by the VerticalPodAutoscaler.
+	if tunesMemoryLimiter(*otelCol) {
+		applyMemoryLimiter(&otelCol.Spec.Config, otelCol.Spec.VerticalAutoscaler.MemoryLimiter)
+	}
+	if syncsStorage(*otelCol) {
+		applyFsync(&otelCol.Spec.Config)
+	}
+
 	hash, err := manifestutils.GetConfigMapSHA(otelCol.Spec.Config)
 	if err != nil {
 		return nil, err
@@ -75,3 +105,178 @@ func ConfigMap(params manifests.Params) (*corev1.ConfigMap, error) {
 		},
 	}, nil
 }
+
+// ConfigFragment is a part of the collector configuration referenced by spec.configFrom.
+type ConfigFragment struct {
+	// Source describes where the fragment comes from, for error messages.
+	Source string
+	Yaml   string
+	// SecretKeyRef is set for the fragments stored in Secrets.
+	SecretKeyRef *corev1.SecretKeySelector
+}
+
+// ConfigFragments fetches the configuration fragments referenced by spec.configFrom, in order.
+// Fragments marked as optional are skipped when their ConfigMap, Secret or key doesn't exist. Secrets must have the
+// config fragment label, which restricts the Secrets the operator watches.
+func ConfigFragments(ctx context.Context, params manifests.Params) ([]ConfigFragment, error) {
+	var fragments []ConfigFragment
+	for _, source := range params.OtelCol.Spec.ConfigFrom {
+		var (
+			fragment ConfigFragment
+			found    bool
+			optional bool
+			err      error
+		)
+		switch {
+		case source.ConfigMapKeyRef != nil:
+			ref := source.ConfigMapKeyRef
+			optional = ptr.Deref(ref.Optional, false)
+			fragment.Source = fmt.Sprintf("ConfigMap %s/%s key %q", params.OtelCol.Namespace, ref.Name, ref.Key)
+			cm := corev1.ConfigMap{}
+			if err = getConfigSource(ctx, params, ref.Name, &cm); err == nil {
+				fragment.Yaml, found = cm.Data[ref.Key]
+			}
+		case source.SecretKeyRef != nil:
+			ref := source.SecretKeyRef
+			optional = ptr.Deref(ref.Optional, false)
+			fragment.Source = fmt.Sprintf("Secret %s/%s key %q", params.OtelCol.Namespace, ref.Name, ref.Key)
+			fragment.SecretKeyRef = ref
+			secret := corev1.Secret{}
+			if err = getConfigSource(ctx, params, ref.Name, &secret); err == nil {
+				if _, labeled := secret.Labels[constants.LabelConfigFragment]; !labeled {
+					return nil, fmt.Errorf("the Secret %s/%s must have the %s label to be used as a config fragment", params.OtelCol.Namespace, ref.Name, constants.LabelConfigFragment)
+				}
+				var data []byte
+				data, found = secret.Data[ref.Key]
+				fragment.Yaml = string(data)
+			}
+		default:
+			continue
+		}
+		if apierrors.IsNotFound(err) && optional {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the config fragment from %s: %w", fragment.Source, err)
+		}
+		if !found {
+			if optional {
+				continue
+			}
+			return nil, fmt.Errorf("the config fragment %s doesn't exist", fragment.Source)
+		}
+		fragments = append(fragments, fragment)
+	}
+	return fragments, nil
+}
+
+func getConfigSource(ctx context.Context, params manifests.Params, name string, obj client.Object) error {
+	var reader client.Reader = params.Client
+	if _, ok := obj.(*corev1.Secret); ok && params.ConfigFragmentSecrets != nil {
+		reader = params.ConfigFragmentSecrets
+	}
+	return reader.Get(ctx, types.NamespacedName{Namespace: params.OtelCol.Namespace, Name: name}, obj)
+}
+
+// fragmentMergeOptions combines the lists of the fragments and rejects their conflicting values.
+var fragmentMergeOptions = otelconfig.MergeOptions{Lists: otelconfig.ListUnion, Conflicts: otelconfig.ConflictError}
+
+// MergeConfigFragments merges the given fragments into the collector configuration, in order. Maps are merged
+// recursively and lists, such as the components of a pipeline, are combined without duplicates. A fragment setting
+// a different value for a key that is already set is rejected, as the owners of the fragments would otherwise
+// silently override each other.
+func MergeConfigFragments(cfg v1beta1.Config, fragments []ConfigFragment) (v1beta1.Config, error) {
+	if len(fragments) == 0 {
+		return cfg, nil
+	}
+	cfgYaml, err := cfg.Yaml()
+	if err != nil {
+		return cfg, err
+	}
+	merged := map[string]any{}
+	if err = go_yaml.Unmarshal([]byte(cfgYaml), &merged); err != nil {
+		return cfg, err
+	}
+
+	for _, fragment := range fragments {
+		fragmentMap := map[string]any{}
+		if err = go_yaml.Unmarshal([]byte(fragment.Yaml), &fragmentMap); err != nil {
+			return cfg, fmt.Errorf("the config fragment %s is not valid YAML: %w", fragment.Source, err)
+		}
+		if err = otelconfig.MergeMaps(merged, fragmentMap, fragmentMergeOptions); err != nil {
+			return cfg, fmt.Errorf("failed to merge the config fragment %s: %w", fragment.Source, err)
+		}
+	}
+
+	mergedYaml, err := go_yaml.Marshal(merged)
+	if err != nil {
+		return cfg, err
+	}
+	result := v1beta1.Config{}
+	if err = go_yaml.Unmarshal(mergedYaml, &result); err != nil {
+		return cfg, fmt.Errorf("the merged config is not a valid collector config: %w", err)
+	}
+	return result, nil
+}
+
+// CheckSecretFragments checks that the collector can merge the given fragments stored in Secrets into the given
+// configuration itself, with the same result as MergeConfigFragments. The collector merges maps, while lists replace
+// the existing ones, so a list set by a Secret fragment must not be set anywhere else. The fragments must not
+// configure receivers or connectors either, as the Services and the target allocator of the collector are derived
+// from the configuration without the content of the Secrets.
+func CheckSecretFragments(cfg v1beta1.Config, fragments []ConfigFragment) error {
+	cfgYaml, err := cfg.Yaml()
+	if err != nil {
+		return err
+	}
+	cfgMap := map[string]any{}
+	if err = go_yaml.Unmarshal([]byte(cfgYaml), &cfgMap); err != nil {
+		return err
+	}
+	fragmentMaps := make([]map[string]any, len(fragments))
+	for i, fragment := range fragments {
+		if err = go_yaml.Unmarshal([]byte(fragment.Yaml), &fragmentMaps[i]); err != nil {
+			return fmt.Errorf("the config fragment %s is not valid YAML: %w", fragment.Source, err)
+		}
+		for _, kind := range []string{"receivers", "connectors"} {
+			if _, ok := fragmentMaps[i][kind]; ok {
+				return fmt.Errorf("the config fragment %s must not configure %s, as it is stored in a Secret", fragment.Source, kind)
+			}
+		}
+	}
+	for i, fragment := range fragments {
+		others := []map[string]any{cfgMap}
+		others = append(others, fragmentMaps[:i]...)
+		others = append(others, fragmentMaps[i+1:]...)
+		if err = checkSecretLists(fragmentMaps[i], others, ""); err != nil {
+			return fmt.Errorf("the config fragment %s can't be merged, as it is stored in a Secret: %w", fragment.Source, err)
+		}
+	}
+	return nil
+}
+
+func checkSecretLists(fragment map[string]any, others []map[string]any, path string) error {
+	for k, v := range fragment {
+		keyPath := k
+		if path != "" {
+			keyPath = path + "." + k
+		}
+		var nested []map[string]any
+		for _, other := range others {
+			if existing, ok := other[k]; ok && existing != nil {
+				if _, isList := v.([]any); isList {
+					return fmt.Errorf("%s is a list that is set by another source of the configuration", keyPath)
+				}
+				if existingMap, isMap := existing.(map[string]any); isMap {
+					nested = append(nested, existingMap)
+				}
+			}
+		}
+		if value, isMap := v.(map[string]any); isMap {
+			if err := checkSecretLists(value, nested, keyPath); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -4,10 +4,16 @@
 package collector
 
 import (
+	"context"
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
@@ -15,6 +21,7 @@ import (
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/openshift"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/prometheus"
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
 	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
 )
@@ -284,4 +291,254 @@ service:
 		expectedLables["app.kubernetes.io/version"] = "0.47.0"
 		assert.NoError(t, err)
 	})
+
+	t.Run("should tune the memory_limiter to the vertical autoscaler", func(t *testing.T) {
+		param := deploymentParams()
+		param.OtelCol.Spec.VerticalAutoscaler = &v1beta1.VerticalAutoscalerSpec{Enabled: true}
+
+		actual, err := ConfigMap(param)
+		require.NoError(t, err)
+
+		assert.Equal(t, configMapName(param.Config, param.OtelCol), actual.Name)
+		hash, _ := manifestutils.GetConfigMapSHA(param.OtelCol.Spec.Config)
+		assert.NotEqual(t, naming.ConfigMap(param.OtelCol.Name, hash), actual.Name)
+		assert.Contains(t, actual.Data["collector.yaml"], "limit_percentage: 80")
+		assert.Contains(t, actual.Data["collector.yaml"], "- memory_limiter")
+	})
+}
+
+func TestMergeConfigFragments(t *testing.T) {
+	base := func() v1beta1.Config {
+		return v1beta1.Config{
+			Receivers: v1beta1.AnyConfig{Object: map[string]any{
+				"otlp": map[string]any{"protocols": map[string]any{"grpc": map[string]any{}}},
+			}},
+			Exporters: v1beta1.AnyConfig{Object: map[string]any{
+				"debug": map[string]any{"verbosity": "basic"},
+			}},
+			Service: v1beta1.Service{
+				Pipelines: map[string]*v1beta1.Pipeline{
+					"traces": {Receivers: []string{"otlp"}, Exporters: []string{"debug"}},
+				},
+			},
+		}
+	}
+
+	t.Run("merges the fragments", func(t *testing.T) {
+		merged, err := MergeConfigFragments(base(), []ConfigFragment{
+			{Source: "first", Yaml: "exporters: {otlp: {endpoint: 'backend:4317'}}\nservice: {pipelines: {traces: {exporters: [otlp]}}}"},
+			{Source: "second", Yaml: "exporters: {debug: {verbosity: basic}}\nservice: {pipelines: {traces: {exporters: [debug, otlp]}}}"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]any{"endpoint": "backend:4317"}, merged.Exporters.Object["otlp"])
+		assert.Equal(t, map[string]any{"verbosity": "basic"}, merged.Exporters.Object["debug"])
+		assert.Equal(t, []string{"debug", "otlp"}, merged.Service.Pipelines["traces"].Exporters)
+		assert.Equal(t, []string{"otlp"}, merged.Service.Pipelines["traces"].Receivers)
+	})
+
+	t.Run("no fragments", func(t *testing.T) {
+		merged, err := MergeConfigFragments(base(), nil)
+		require.NoError(t, err)
+		assert.Equal(t, base(), merged)
+	})
+
+	t.Run("conflicting value", func(t *testing.T) {
+		_, err := MergeConfigFragments(base(), []ConfigFragment{
+			{Source: "ConfigMap default/debug key \"collector.yaml\"", Yaml: "exporters: {debug: {verbosity: detailed}}"},
+		})
+		assert.ErrorContains(t, err, `failed to merge the config fragment ConfigMap default/debug key "collector.yaml": exporters.debug.verbosity is set to detailed, which conflicts with the existing value basic`)
+	})
+
+	t.Run("conflicting type", func(t *testing.T) {
+		_, err := MergeConfigFragments(base(), []ConfigFragment{
+			{Source: "fragment", Yaml: "service: {pipelines: {traces: {exporters: {otlp: {}}}}}"},
+		})
+		assert.ErrorContains(t, err, "service.pipelines.traces.exporters is set to a map, which conflicts with the existing value")
+	})
+
+	t.Run("invalid yaml", func(t *testing.T) {
+		_, err := MergeConfigFragments(base(), []ConfigFragment{{Source: "fragment", Yaml: "exporters: ["}})
+		assert.ErrorContains(t, err, "the config fragment fragment is not valid YAML")
+	})
+}
+
+func TestConfigFragments(t *testing.T) {
+	objects := []client.Object{
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "exporters", Namespace: "default"},
+			Data:       map[string]string{"collector.yaml": "exporters: {debug: {}}"},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "credentials",
+				Namespace: "default",
+				Labels:    map[string]string{"opentelemetry.io/config-fragment": "true"},
+			},
+			Data: map[string][]byte{"collector.yaml": []byte("extensions: {basicauth: {}}")},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "unlabeled", Namespace: "default"},
+			Data:       map[string][]byte{"collector.yaml": []byte("extensions: {basicauth: {}}")},
+		},
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "other"},
+			Data:       map[string]string{"collector.yaml": "exporters: {otlp: {}}"},
+		},
+	}
+	secretRef := func(name string) *corev1.SecretKeySelector {
+		return &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: name},
+			Key:                  "collector.yaml",
+		}
+	}
+	configMapRef := func(name, key string, optional bool) v1beta1.ConfigSource {
+		return v1beta1.ConfigSource{ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: name},
+			Key:                  key,
+			Optional:             &optional,
+		}}
+	}
+
+	for _, tt := range []struct {
+		name        string
+		configFrom  []v1beta1.ConfigSource
+		expected    []ConfigFragment
+		expectedErr string
+	}{
+		{
+			name: "configmap and secret",
+			configFrom: []v1beta1.ConfigSource{
+				configMapRef("exporters", "collector.yaml", false),
+				{SecretKeyRef: secretRef("credentials")},
+			},
+			expected: []ConfigFragment{
+				{Source: `ConfigMap default/exporters key "collector.yaml"`, Yaml: "exporters: {debug: {}}"},
+				{Source: `Secret default/credentials key "collector.yaml"`, Yaml: "extensions: {basicauth: {}}", SecretKeyRef: secretRef("credentials")},
+			},
+		},
+		{
+			name:        "secret without the config fragment label",
+			configFrom:  []v1beta1.ConfigSource{{SecretKeyRef: secretRef("unlabeled")}},
+			expectedErr: "the Secret default/unlabeled must have the opentelemetry.io/config-fragment label to be used as a config fragment",
+		},
+		{
+			name: "optional missing sources",
+			configFrom: []v1beta1.ConfigSource{
+				configMapRef("other", "collector.yaml", true),
+				configMapRef("exporters", "missing.yaml", true),
+			},
+		},
+		{
+			name:        "missing configmap",
+			configFrom:  []v1beta1.ConfigSource{configMapRef("other", "collector.yaml", false)},
+			expectedErr: `failed to get the config fragment from ConfigMap default/other key "collector.yaml"`,
+		},
+		{
+			name:        "missing key",
+			configFrom:  []v1beta1.ConfigSource{configMapRef("exporters", "missing.yaml", false)},
+			expectedErr: `the config fragment ConfigMap default/exporters key "missing.yaml" doesn't exist`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			params := manifests.Params{
+				Client: fake.NewClientBuilder().WithObjects(objects...).Build(),
+				OtelCol: v1beta1.OpenTelemetryCollector{
+					ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+					Spec:       v1beta1.OpenTelemetryCollectorSpec{ConfigFrom: tt.configFrom},
+				},
+			}
+			fragments, err := ConfigFragments(context.Background(), params)
+			if tt.expectedErr != "" {
+				assert.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, fragments)
+		})
+	}
+}
+
+func TestConfigFragmentsFromSecretReader(t *testing.T) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "credentials",
+			Namespace: "default",
+			Labels:    map[string]string{"opentelemetry.io/config-fragment": "true"},
+		},
+		Data: map[string][]byte{"collector.yaml": []byte("extensions: {basicauth: {}}")},
+	}
+	params := manifests.Params{
+		// the Secrets are only read from the config fragment reader
+		Client:                fake.NewClientBuilder().Build(),
+		ConfigFragmentSecrets: fake.NewClientBuilder().WithObjects(secret).Build(),
+		OtelCol: v1beta1.OpenTelemetryCollector{
+			ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+			Spec: v1beta1.OpenTelemetryCollectorSpec{ConfigFrom: []v1beta1.ConfigSource{{SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "credentials"},
+				Key:                  "collector.yaml",
+			}}}},
+		},
+	}
+
+	fragments, err := ConfigFragments(context.Background(), params)
+	require.NoError(t, err)
+	require.Len(t, fragments, 1)
+	assert.Equal(t, "extensions: {basicauth: {}}", fragments[0].Yaml)
+}
+
+func TestCheckSecretFragments(t *testing.T) {
+	cfg := v1beta1.Config{
+		Exporters: v1beta1.AnyConfig{Object: map[string]any{
+			"otlp/backend": map[string]any{"endpoint": "backend:4317"},
+		}},
+		Service: v1beta1.Service{
+			Extensions: []string{"health_check"},
+			Pipelines: map[string]*v1beta1.Pipeline{
+				"traces": {Receivers: []string{"otlp"}, Exporters: []string{"otlp/backend"}},
+			},
+		},
+	}
+
+	for _, tt := range []struct {
+		name        string
+		fragments   []string
+		expectedErr string
+	}{
+		{
+			name:      "exporter credentials",
+			fragments: []string{"exporters: {otlp/backend: {headers: {authorization: 'Bearer token'}}}"},
+		},
+		{
+			name:      "new list",
+			fragments: []string{"exporters: {otlp/other: {}}\nservice: {pipelines: {logs: {receivers: [otlp], exporters: [otlp/other]}}}"},
+		},
+		{
+			name:        "existing list",
+			fragments:   []string{"service: {pipelines: {traces: {exporters: [otlp/other]}}}"},
+			expectedErr: "service.pipelines.traces.exporters is a list that is set by another source of the configuration",
+		},
+		{
+			name:        "list set by another secret",
+			fragments:   []string{"service: {telemetry: {logs: {processors: []}}}", "service: {telemetry: {logs: {processors: []}}}"},
+			expectedErr: "service.telemetry.logs.processors is a list that is set by another source of the configuration",
+		},
+		{
+			name:        "receivers",
+			fragments:   []string{"receivers: {kafka: {}}"},
+			expectedErr: "must not configure receivers, as it is stored in a Secret",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var fragments []ConfigFragment
+			for i, fragment := range tt.fragments {
+				fragments = append(fragments, ConfigFragment{Source: fmt.Sprintf("secret-%d", i), Yaml: fragment})
+			}
+			err := CheckSecretFragments(cfg, fragments)
+			if tt.expectedErr != "" {
+				assert.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
 }
@@ -0,0 +1,186 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package collector
+
+import (
+	"context"
+	"fmt"
+	"maps"
+
+	go_yaml "github.com/goccy/go-yaml"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+)
+
+const (
+	// ConfigCanaryLabel marks the pods of the deployment running a new configuration while it's analyzed.
+	ConfigCanaryLabel = "opentelemetry.io/config-canary"
+	// ComponentOpenTelemetryCollectorCanary is the component of the canary pods, which keeps them out of the selector
+	// of the collector's deployment.
+	ComponentOpenTelemetryCollectorCanary = "opentelemetry-collector-canary"
+)
+
+// CanarySelectorLabels returns the labels selecting the pods of the canary deployment.
+func CanarySelectorLabels(instance metav1.ObjectMeta) map[string]string {
+	labels := manifestutils.SelectorLabels(instance, ComponentOpenTelemetryCollectorCanary)
+	labels[ConfigCanaryLabel] = "true"
+	return labels
+}
+
+// podSelectorLabels returns the labels selecting the collector pods in the Services and the network policy. When the
+// configuration rollouts run a canary deployment, the canary pods are selected as well, by their name rather than
+// their component.
+func podSelectorLabels(params manifests.Params) map[string]string {
+	selector := manifestutils.SelectorLabels(params.OtelCol.ObjectMeta, ComponentOpenTelemetryCollector)
+	if params.OtelCol.Spec.ConfigRollout == nil || params.OtelCol.Spec.Mode != v1beta1.ModeDeployment {
+		return selector
+	}
+	podLabels := manifestutils.Labels(params.OtelCol.ObjectMeta, naming.Collector(params.OtelCol.Name), params.OtelCol.Spec.Image, ComponentOpenTelemetryCollector, params.Config.LabelsFilter)
+	delete(selector, "app.kubernetes.io/component")
+	selector["app.kubernetes.io/name"] = podLabels["app.kubernetes.io/name"]
+	return selector
+}
+
+// CurrentConfigVersion returns the version of the instance's configuration.
+func CurrentConfigVersion(cfg config.Config, otelcol v1beta1.OpenTelemetryCollector) (v1beta1.ConfigVersion, error) {
+	hash, err := manifestutils.GetConfigMapSHA(otelcol.Spec.Config)
+	if err != nil {
+		return v1beta1.ConfigVersion{}, err
+	}
+	return v1beta1.ConfigVersion{ConfigMap: configMapName(cfg, otelcol), Hash: hash}, nil
+}
+
+// IsConfigCanary returns whether the instance's configuration is being analyzed on the canary pods.
+func IsConfigCanary(otelcol v1beta1.OpenTelemetryCollector, current v1beta1.ConfigVersion) bool {
+	rollout := otelcol.Status.ConfigRollout
+	return stableConfigVersion(otelcol, current) != nil && rollout.Canary != nil && rollout.Canary.Hash == current.Hash
+}
+
+// stableConfigVersion returns the configuration the collector pods keep running instead of the instance's one, while
+// the latter is analyzed on the canary pods or after it was rolled back. It returns nil when the instance's
+// configuration is rolled out to every pod.
+func stableConfigVersion(otelcol v1beta1.OpenTelemetryCollector, current v1beta1.ConfigVersion) *v1beta1.ConfigVersion {
+	rollout := otelcol.Status.ConfigRollout
+	if otelcol.Spec.ConfigRollout == nil || rollout == nil || rollout.Stable == nil || rollout.Stable.Hash == current.Hash {
+		return nil
+	}
+	return rollout.Stable
+}
+
+// StableConfig returns the configuration the collector pods keep running instead of the instance's one, read from the
+// ConfigMap of its version. It returns nil when the instance's configuration is rolled out to every pod, or when that
+// ConfigMap doesn't exist anymore.
+func StableConfig(ctx context.Context, params manifests.Params) (*v1beta1.Config, error) {
+	if params.OtelCol.Spec.ConfigRollout == nil {
+		return nil, nil
+	}
+	current, err := CurrentConfigVersion(params.Config, params.OtelCol)
+	if err != nil {
+		return nil, err
+	}
+	stable := stableConfigVersion(params.OtelCol, current)
+	if stable == nil {
+		return nil, nil
+	}
+	cm := &corev1.ConfigMap{}
+	err = params.Client.Get(ctx, types.NamespacedName{Namespace: params.OtelCol.Namespace, Name: stable.ConfigMap}, cm)
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	cfg := &v1beta1.Config{}
+	if err = go_yaml.Unmarshal([]byte(cm.Data["collector.yaml"]), cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse the stable configuration %s: %w", stable.ConfigMap, err)
+	}
+	return cfg, nil
+}
+
+// useConfigVersion points the pod template to the given configuration version.
+func useConfigVersion(template *corev1.PodTemplateSpec, version v1beta1.ConfigVersion) {
+	template.Annotations[manifestutils.ConfigHashAnnotation] = version.Hash
+	for i := range template.Spec.Volumes {
+		volume := &template.Spec.Volumes[i]
+		if volume.Name == naming.ConfigMapVolume() && volume.ConfigMap != nil {
+			volume.ConfigMap.Name = version.ConfigMap
+		}
+	}
+}
+
+// applyConfigRollout makes the collector pods run the stable configuration, while the instance's one is analyzed on
+// the canary pods or after it was rolled back. Their pod template is built by podTemplate from the stable
+// configuration, when it's known. While a StatefulSet's configuration is analyzed, only the pods with the highest
+// ordinals are updated.
+func applyConfigRollout(params manifests.Params, template *corev1.PodTemplateSpec, updateStrategy *appsv1.StatefulSetUpdateStrategy, podTemplate func(manifests.Params) (*corev1.PodTemplateSpec, error)) error {
+	current, err := CurrentConfigVersion(params.Config, params.OtelCol)
+	if err != nil {
+		return err
+	}
+	stable := stableConfigVersion(params.OtelCol, current)
+	if stable == nil {
+		return nil
+	}
+	if updateStrategy != nil && IsConfigCanary(params.OtelCol, current) {
+		replicas := ptr.Deref(manifestutils.GetDesiredReplicas(params.OtelCol), 1)
+		*updateStrategy = appsv1.StatefulSetUpdateStrategy{
+			Type: appsv1.RollingUpdateStatefulSetStrategyType,
+			RollingUpdate: &appsv1.RollingUpdateStatefulSetStrategy{
+				Partition: ptr.To(max(replicas-params.OtelCol.Spec.ConfigRollout.CanaryReplicas, 0)),
+			},
+		}
+		return nil
+	}
+	if params.StableConfig != nil {
+		stableParams := params
+		stableParams.OtelCol = *params.OtelCol.DeepCopy()
+		stableParams.OtelCol.Spec.Config = *params.StableConfig
+		stableParams.OtelCol.Spec.ConfigRollout = nil
+		stableParams.StableConfig = nil
+		stableTemplate, err := podTemplate(stableParams)
+		if err != nil {
+			return fmt.Errorf("failed to build the pods of the stable configuration: %w", err)
+		}
+		*template = *stableTemplate
+	}
+	useConfigVersion(template, *stable)
+	return nil
+}
+
+// CanaryDeployment builds the deployment running the instance's configuration while it's analyzed, in addition to the
+// collector's deployment.
+func CanaryDeployment(params manifests.Params) (*appsv1.Deployment, error) {
+	current, err := CurrentConfigVersion(params.Config, params.OtelCol)
+	if err != nil {
+		return nil, err
+	}
+	if !IsConfigCanary(params.OtelCol, current) {
+		return nil, nil
+	}
+
+	canary, err := deployment(params)
+	if err != nil {
+		return nil, err
+	}
+	canary.Name = naming.CollectorCanary(params.OtelCol.Name)
+	canary.Spec.Replicas = ptr.To(params.OtelCol.Spec.ConfigRollout.CanaryReplicas)
+	// the canary pods must not be selected by the collector's deployment, which would count them as its own
+	canaryLabels := CanarySelectorLabels(params.OtelCol.ObjectMeta)
+	canary.Labels = maps.Clone(canary.Labels)
+	maps.Copy(canary.Labels, canaryLabels)
+	canary.Spec.Selector.MatchLabels = canaryLabels
+	canary.Spec.Template.Labels = maps.Clone(canary.Spec.Template.Labels)
+	maps.Copy(canary.Spec.Template.Labels, canaryLabels)
+	return canary, nil
+}
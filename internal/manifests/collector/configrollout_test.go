@@ -0,0 +1,218 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package collector
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	go_yaml "github.com/goccy/go-yaml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+)
+
+var stableConfigVersionForTest = v1beta1.ConfigVersion{ConfigMap: "my-instance-collector-stable", Hash: "stable-hash"}
+
+func configRolloutParams(t *testing.T, mode v1beta1.Mode, rollout func(current v1beta1.ConfigVersion) *v1beta1.ConfigRolloutStatus) (manifests.Params, v1beta1.ConfigVersion) {
+	otelcol := v1beta1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-instance",
+			Namespace: "my-namespace",
+		},
+		Spec: v1beta1.OpenTelemetryCollectorSpec{
+			Mode: mode,
+			OpenTelemetryCommonFields: v1beta1.OpenTelemetryCommonFields{
+				Replicas: ptr.To(int32(3)),
+			},
+			ConfigRollout: &v1beta1.ConfigRolloutSpec{CanaryReplicas: 1},
+		},
+	}
+	cfg := config.New()
+	current, err := CurrentConfigVersion(cfg, otelcol)
+	require.NoError(t, err)
+	otelcol.Status.ConfigRollout = rollout(current)
+	return manifests.Params{Config: cfg, OtelCol: otelcol, Log: testLogger}, current
+}
+
+func configVolume(template corev1.PodTemplateSpec) string {
+	for _, volume := range template.Spec.Volumes {
+		if volume.Name == naming.ConfigMapVolume() {
+			return volume.ConfigMap.Name
+		}
+	}
+	return ""
+}
+
+func TestDeploymentConfigRollout(t *testing.T) {
+	for _, tt := range []struct {
+		name         string
+		rollout      func(current v1beta1.ConfigVersion) *v1beta1.ConfigRolloutStatus
+		expectStable bool
+		expectCanary bool
+	}{
+		{
+			name:    "no rollout status",
+			rollout: func(v1beta1.ConfigVersion) *v1beta1.ConfigRolloutStatus { return nil },
+		},
+		{
+			name: "rolled out",
+			rollout: func(current v1beta1.ConfigVersion) *v1beta1.ConfigRolloutStatus {
+				return &v1beta1.ConfigRolloutStatus{Stable: &current}
+			},
+		},
+		{
+			name: "canary",
+			rollout: func(current v1beta1.ConfigVersion) *v1beta1.ConfigRolloutStatus {
+				return &v1beta1.ConfigRolloutStatus{Stable: &stableConfigVersionForTest, Canary: &current}
+			},
+			expectStable: true,
+			expectCanary: true,
+		},
+		{
+			name: "rolled back",
+			rollout: func(current v1beta1.ConfigVersion) *v1beta1.ConfigRolloutStatus {
+				return &v1beta1.ConfigRolloutStatus{Stable: &stableConfigVersionForTest, Failed: &current}
+			},
+			expectStable: true,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			params, current := configRolloutParams(t, v1beta1.ModeDeployment, tt.rollout)
+
+			d, err := Deployment(params)
+			require.NoError(t, err)
+			expected := current
+			if tt.expectStable {
+				expected = stableConfigVersionForTest
+			}
+			assert.Equal(t, expected.ConfigMap, configVolume(d.Spec.Template))
+			assert.Equal(t, expected.Hash, d.Spec.Template.Annotations[manifestutils.ConfigHashAnnotation])
+
+			canary, err := CanaryDeployment(params)
+			require.NoError(t, err)
+			if !tt.expectCanary {
+				assert.Nil(t, canary)
+				return
+			}
+			assert.Equal(t, "my-instance-collector-canary", canary.Name)
+			assert.Equal(t, int32(1), *canary.Spec.Replicas)
+			assert.Equal(t, current.ConfigMap, configVolume(canary.Spec.Template))
+			assert.Equal(t, current.Hash, canary.Spec.Template.Annotations[manifestutils.ConfigHashAnnotation])
+			assert.Equal(t, "true", canary.Spec.Selector.MatchLabels[ConfigCanaryLabel])
+			assert.Equal(t, "true", canary.Spec.Template.Labels[ConfigCanaryLabel])
+			// the main deployment doesn't get the canary label
+			assert.NotContains(t, d.Spec.Template.Labels, ConfigCanaryLabel)
+
+			// the deployments don't select each other's pods, while the Services select both
+			selectsPods := func(selector map[string]string, template corev1.PodTemplateSpec) bool {
+				return labels.SelectorFromSet(selector).Matches(labels.Set(template.Labels))
+			}
+			assert.True(t, selectsPods(canary.Spec.Selector.MatchLabels, canary.Spec.Template))
+			assert.False(t, selectsPods(d.Spec.Selector.MatchLabels, canary.Spec.Template))
+			assert.False(t, selectsPods(canary.Spec.Selector.MatchLabels, d.Spec.Template))
+			svc, err := MonitoringService(params)
+			require.NoError(t, err)
+			assert.True(t, selectsPods(svc.Spec.Selector, d.Spec.Template))
+			assert.True(t, selectsPods(svc.Spec.Selector, canary.Spec.Template))
+		})
+	}
+}
+
+func TestStatefulSetConfigRollout(t *testing.T) {
+	t.Run("canary", func(t *testing.T) {
+		params, current := configRolloutParams(t, v1beta1.ModeStatefulSet, func(current v1beta1.ConfigVersion) *v1beta1.ConfigRolloutStatus {
+			return &v1beta1.ConfigRolloutStatus{Stable: &stableConfigVersionForTest, Canary: &current}
+		})
+
+		ss, err := StatefulSet(params)
+		require.NoError(t, err)
+		assert.Equal(t, current.ConfigMap, configVolume(ss.Spec.Template))
+		assert.Equal(t, appsv1.StatefulSetUpdateStrategy{
+			Type:          appsv1.RollingUpdateStatefulSetStrategyType,
+			RollingUpdate: &appsv1.RollingUpdateStatefulSetStrategy{Partition: ptr.To(int32(2))},
+		}, ss.Spec.UpdateStrategy)
+	})
+
+	t.Run("rolled back", func(t *testing.T) {
+		params, _ := configRolloutParams(t, v1beta1.ModeStatefulSet, func(current v1beta1.ConfigVersion) *v1beta1.ConfigRolloutStatus {
+			return &v1beta1.ConfigRolloutStatus{Stable: &stableConfigVersionForTest, Failed: &current}
+		})
+
+		ss, err := StatefulSet(params)
+		require.NoError(t, err)
+		assert.Equal(t, stableConfigVersionForTest.ConfigMap, configVolume(ss.Spec.Template))
+		assert.Equal(t, stableConfigVersionForTest.Hash, ss.Spec.Template.Annotations[manifestutils.ConfigHashAnnotation])
+		assert.Empty(t, ss.Spec.UpdateStrategy)
+	})
+}
+
+// otlpConfig returns a configuration receiving OTLP over gRPC on the given port.
+func otlpConfig(port int) v1beta1.Config {
+	return v1beta1.Config{
+		Receivers: v1beta1.AnyConfig{Object: map[string]any{
+			"otlp": map[string]any{"protocols": map[string]any{"grpc": map[string]any{"endpoint": fmt.Sprintf("0.0.0.0:%d", port)}}},
+		}},
+		Exporters: v1beta1.AnyConfig{Object: map[string]any{"debug": map[string]any{}}},
+		Service: v1beta1.Service{
+			Pipelines: map[string]*v1beta1.Pipeline{"traces": {Receivers: []string{"otlp"}, Exporters: []string{"debug"}}},
+		},
+	}
+}
+
+func containerPorts(template corev1.PodTemplateSpec) []int32 {
+	var ports []int32
+	for _, port := range template.Spec.Containers[0].Ports {
+		ports = append(ports, port.ContainerPort)
+	}
+	return ports
+}
+
+func TestConfigRolloutStableConfig(t *testing.T) {
+	params, current := configRolloutParams(t, v1beta1.ModeDeployment, func(v1beta1.ConfigVersion) *v1beta1.ConfigRolloutStatus { return nil })
+	params.OtelCol.Spec.Config = otlpConfig(14317)
+	current, err := CurrentConfigVersion(params.Config, params.OtelCol)
+	require.NoError(t, err)
+	params.OtelCol.Status.ConfigRollout = &v1beta1.ConfigRolloutStatus{Stable: &stableConfigVersionForTest, Canary: &current}
+
+	stableYaml, err := go_yaml.Marshal(otlpConfig(4317))
+	require.NoError(t, err)
+	params.Client = fake.NewClientBuilder().WithObjects(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: stableConfigVersionForTest.ConfigMap, Namespace: "my-namespace"},
+		Data:       map[string]string{"collector.yaml": string(stableYaml)},
+	}).Build()
+	params.StableConfig, err = StableConfig(context.Background(), params)
+	require.NoError(t, err)
+	require.NotNil(t, params.StableConfig)
+
+	// the stable pods are built from the stable configuration, and the canary pods from the instance's one
+	d, err := Deployment(params)
+	require.NoError(t, err)
+	assert.Contains(t, containerPorts(d.Spec.Template), int32(4317))
+	assert.NotContains(t, containerPorts(d.Spec.Template), int32(14317))
+	assert.Equal(t, stableConfigVersionForTest.ConfigMap, configVolume(d.Spec.Template))
+	assert.Equal(t, stableConfigVersionForTest.Hash, d.Spec.Template.Annotations[manifestutils.ConfigHashAnnotation])
+	canary, err := CanaryDeployment(params)
+	require.NoError(t, err)
+	assert.Contains(t, containerPorts(canary.Spec.Template), int32(14317))
+	assert.Equal(t, current.ConfigMap, configVolume(canary.Spec.Template))
+
+	// the configuration is only read while it isn't rolled out to every pod
+	params.OtelCol.Status.ConfigRollout = &v1beta1.ConfigRolloutStatus{Stable: &current}
+	stableConfig, err := StableConfig(context.Background(), params)
+	require.NoError(t, err)
+	assert.Nil(t, stableConfig)
+}
@@ -67,6 +67,12 @@ func Container(cfg config.Config, logger logr.Logger, otelcol v1beta1.OpenTeleme
 		volumeMounts = append(volumeMounts, clientMounts...)
 	}
 
+	if servesReceiverTLS(cfg, otelcol) {
+		volumeMounts = append(volumeMounts, receiverTLSVolumeMount())
+	}
+
+	volumeMounts = append(volumeMounts, storageVolumeMounts(otelcol)...)
+
 	// ensure that the v1alpha1.OpenTelemetryCollectorSpec.Args are ordered when moved to container.Args,
 	// where iterating over a map does not guarantee, so that reconcile will not be fooled by different
 	// ordering in args.
@@ -109,22 +115,30 @@ func Container(cfg config.Config, logger logr.Logger, otelcol v1beta1.OpenTeleme
 		defaultProbeSettings(startupProbe, otelcol.Spec.StartupProbe)
 	}
 
+	// the collectors reporting the status of their components report the configuration errors they fail to start
+	// with as well, from the end of their logs
+	var terminationMessagePolicy corev1.TerminationMessagePolicy
+	if _, _, ok, _ := otelconfig.ComponentStatusEndpoint(&otelcol.Spec.Config, logger); ok && otelcol.Spec.Mode != v1beta1.ModeSidecar {
+		terminationMessagePolicy = corev1.TerminationMessageFallbackToLogsOnError
+	}
+
 	return corev1.Container{
-		Name:            naming.Container(),
-		Image:           image,
-		ImagePullPolicy: otelcol.Spec.ImagePullPolicy,
-		Ports:           ports,
-		VolumeMounts:    volumeMounts,
-		Args:            args,
-		Command:         slices.Clone(otelcol.Spec.Command),
-		Env:             getContainerEnvVars(cfg, otelcol, logger),
-		EnvFrom:         otelcol.Spec.EnvFrom,
-		Resources:       otelcol.Spec.Resources,
-		SecurityContext: otelcol.Spec.SecurityContext,
-		LivenessProbe:   livenessProbe,
-		ReadinessProbe:  readinessProbe,
-		StartupProbe:    startupProbe,
-		Lifecycle:       otelcol.Spec.Lifecycle,
+		Name:                     naming.Container(),
+		Image:                    image,
+		ImagePullPolicy:          otelcol.Spec.ImagePullPolicy,
+		Ports:                    ports,
+		VolumeMounts:             volumeMounts,
+		Args:                     args,
+		Command:                  slices.Clone(otelcol.Spec.Command),
+		Env:                      getContainerEnvVars(cfg, otelcol, logger),
+		EnvFrom:                  otelcol.Spec.EnvFrom,
+		Resources:                otelcol.Spec.Resources,
+		SecurityContext:          otelcol.Spec.SecurityContext,
+		LivenessProbe:            livenessProbe,
+		ReadinessProbe:           readinessProbe,
+		StartupProbe:             startupProbe,
+		Lifecycle:                otelcol.Spec.Lifecycle,
+		TerminationMessagePolicy: terminationMessagePolicy,
 	}
 }
 
@@ -301,10 +315,9 @@ func getContainerEnvVars(cfg config.Config, otelcol v1beta1.OpenTelemetryCollect
 	inferredEnvVars := getInferredContainerEnvVars(cfg, otelcol, logger)
 
 	envVars := []corev1.EnvVar{}
-	envVars = append(envVars, otelcol.Spec.Env...)
-
 	userDefinedEnvVars := make(map[string]bool, len(otelcol.Spec.Env))
 	for _, env := range otelcol.Spec.Env {
+		envVars = append(envVars, env)
 		userDefinedEnvVars[env.Name] = true
 	}
 
@@ -332,17 +345,15 @@ func getInferredContainerEnvVars(cfg config.Config, otelcol v1beta1.OpenTelemetr
 		},
 	})
 
+	if tunesMemoryLimiter(otelcol) {
+		if env, ok := tunedGoMemLimitEnvVar(otelcol); ok {
+			envVars = append(envVars, env)
+		}
+	} else if featuregate.SetGolangFlags.IsEnabled() {
+		envVars = append(envVars, goMemLimitEnvVar())
+	}
 	if featuregate.SetGolangFlags.IsEnabled() {
 		envVars = append(envVars,
-			corev1.EnvVar{
-				Name: "GOMEMLIMIT",
-				ValueFrom: &corev1.EnvVarSource{
-					ResourceFieldRef: &corev1.ResourceFieldSelector{
-						Resource:      "limits.memory",
-						ContainerName: naming.Container(),
-					},
-				},
-			},
 			corev1.EnvVar{
 				Name: "GOMAXPROCS",
 				ValueFrom: &corev1.EnvVarSource{
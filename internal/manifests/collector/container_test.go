@@ -869,6 +869,56 @@ service:
 	assert.Equal(t, "", c.LivenessProbe.HTTPGet.Host)
 }
 
+func TestContainerTerminationMessagePolicy(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		mode     v1beta1.Mode
+		config   string
+		expected corev1.TerminationMessagePolicy
+	}{
+		{
+			name: "component status",
+			mode: v1beta1.ModeDeployment,
+			config: `extensions:
+  healthcheckv2:
+    use_v2: true
+service:
+  extensions: [healthcheckv2]`,
+			expected: corev1.TerminationMessageFallbackToLogsOnError,
+		},
+		{
+			name: "legacy healthcheckv2",
+			mode: v1beta1.ModeDeployment,
+			config: `extensions:
+  healthcheckv2:
+service:
+  extensions: [healthcheckv2]`,
+		},
+		{
+			name: "sidecar",
+			mode: v1beta1.ModeSidecar,
+			config: `extensions:
+  healthcheckv2:
+    use_v2: true
+service:
+  extensions: [healthcheckv2]`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			otelcol := v1beta1.OpenTelemetryCollector{
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					Mode:   tt.mode,
+					Config: mustUnmarshalToConfig(t, tt.config),
+				},
+			}
+
+			c := Container(config.New(), testLogger, otelcol, true, nil)
+
+			assert.Equal(t, tt.expected, c.TerminationMessagePolicy)
+		})
+	}
+}
+
 func TestContainerLifecycle(t *testing.T) {
 	// prepare
 	otelcol := v1beta1.OpenTelemetryCollector{
@@ -1098,6 +1148,117 @@ func TestGetEnvironmentVariables(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "with the memory_limiter tuned to the vertical autoscaler",
+			otelcol: v1beta1.OpenTelemetryCollector{
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					Mode: v1beta1.ModeDeployment,
+					VerticalAutoscaler: &v1beta1.VerticalAutoscalerSpec{
+						Enabled:       true,
+						MemoryLimiter: v1beta1.MemoryLimiterTuning{LimitPercentage: new(int32(75))},
+					},
+					OpenTelemetryCommonFields: v1beta1.OpenTelemetryCommonFields{
+						Resources: corev1.ResourceRequirements{
+							Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+						},
+					},
+				},
+			},
+			expectedEnvVars: []corev1.EnvVar{
+				{
+					Name: "POD_NAME",
+					ValueFrom: &corev1.EnvVarSource{
+						FieldRef: &corev1.ObjectFieldSelector{
+							FieldPath: "metadata.name",
+						},
+					},
+				},
+				{
+					Name:  "GOMEMLIMIT",
+					Value: "805306368",
+				},
+				{
+					Name: "GOMAXPROCS",
+					ValueFrom: &corev1.EnvVarSource{
+						ResourceFieldRef: &corev1.ResourceFieldSelector{
+							Resource:      "limits.cpu",
+							ContainerName: naming.Container(),
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "with the memory_limiter tuned to the vertical autoscaler and GOMEMLIMIT set",
+			otelcol: v1beta1.OpenTelemetryCollector{
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					Mode:               v1beta1.ModeDeployment,
+					VerticalAutoscaler: &v1beta1.VerticalAutoscalerSpec{Enabled: true},
+					OpenTelemetryCommonFields: v1beta1.OpenTelemetryCommonFields{
+						Env: []corev1.EnvVar{
+							{
+								Name:  "GOMEMLIMIT",
+								Value: "500MiB",
+							},
+						},
+						Resources: corev1.ResourceRequirements{
+							Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+						},
+					},
+				},
+			},
+			expectedEnvVars: []corev1.EnvVar{
+				{
+					Name:  "GOMEMLIMIT",
+					Value: "500MiB",
+				},
+				{
+					Name: "POD_NAME",
+					ValueFrom: &corev1.EnvVarSource{
+						FieldRef: &corev1.ObjectFieldSelector{
+							FieldPath: "metadata.name",
+						},
+					},
+				},
+				{
+					Name: "GOMAXPROCS",
+					ValueFrom: &corev1.EnvVarSource{
+						ResourceFieldRef: &corev1.ResourceFieldSelector{
+							Resource:      "limits.cpu",
+							ContainerName: naming.Container(),
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "with the memory_limiter tuned to the vertical autoscaler without memory limit",
+			otelcol: v1beta1.OpenTelemetryCollector{
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					Mode:               v1beta1.ModeDeployment,
+					VerticalAutoscaler: &v1beta1.VerticalAutoscalerSpec{Enabled: true},
+				},
+			},
+			expectedEnvVars: []corev1.EnvVar{
+				{
+					Name: "POD_NAME",
+					ValueFrom: &corev1.EnvVarSource{
+						FieldRef: &corev1.ObjectFieldSelector{
+							FieldPath: "metadata.name",
+						},
+					},
+				},
+				{
+					Name: "GOMAXPROCS",
+					ValueFrom: &corev1.EnvVarSource{
+						ResourceFieldRef: &corev1.ResourceFieldSelector{
+							Resource:      "limits.cpu",
+							ContainerName: naming.Container(),
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "proxy environment variables",
 			otelcol: v1beta1.OpenTelemetryCollector{
@@ -28,7 +28,7 @@ func DaemonSet(params manifests.Params) (*appsv1.DaemonSet, error) {
 		return nil, err
 	}
 
-	return &appsv1.DaemonSet{
+	daemonSet := &appsv1.DaemonSet{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        naming.Collector(params.OtelCol.Name),
 			Namespace:   params.OtelCol.Namespace,
@@ -46,7 +46,7 @@ func DaemonSet(params manifests.Params) (*appsv1.DaemonSet, error) {
 				},
 				Spec: corev1.PodSpec{
 					ServiceAccountName:            ServiceAccountName(params.OtelCol),
-					InitContainers:                params.OtelCol.Spec.InitContainers,
+					InitContainers:                append(storageInitContainers(params.Config, params.OtelCol), params.OtelCol.Spec.InitContainers...),
 					Containers:                    append([]corev1.Container{Container(params.Config, params.Log, params.OtelCol, true, params.TargetAllocator)}, params.OtelCol.Spec.AdditionalContainers...),
 					Volumes:                       Volumes(params.Config, params.OtelCol, params.TargetAllocator),
 					Tolerations:                   params.OtelCol.Spec.Tolerations,
@@ -58,7 +58,7 @@ func DaemonSet(params manifests.Params) (*appsv1.DaemonSet, error) {
 					ShareProcessNamespace:         &params.OtelCol.Spec.ShareProcessNamespace,
 					DNSPolicy:                     manifestutils.GetDNSPolicy(params.OtelCol.Spec.HostNetwork, params.OtelCol.Spec.PodDNSConfig, params.OtelCol.Spec.DNSPolicy),
 					DNSConfig:                     &params.OtelCol.Spec.PodDNSConfig,
-					SecurityContext:               params.OtelCol.Spec.PodSecurityContext,
+					SecurityContext:               podSecurityContext(params.OtelCol),
 					PriorityClassName:             params.OtelCol.Spec.PriorityClassName,
 					Affinity:                      params.OtelCol.Spec.Affinity,
 					TerminationGracePeriodSeconds: params.OtelCol.Spec.TerminationGracePeriodSeconds,
@@ -66,5 +66,7 @@ func DaemonSet(params manifests.Params) (*appsv1.DaemonSet, error) {
 			},
 			UpdateStrategy: params.OtelCol.Spec.DaemonSetUpdateStrategy,
 		},
-	}, nil
+	}
+	applyConfigFromSecrets(params, &daemonSet.Spec.Template)
+	return daemonSet, nil
 }
@@ -0,0 +1,275 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package collector
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"maps"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/grafana"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+)
+
+// GrafanaDashboardGVK is the kind of the GrafanaDashboard of the Grafana operator. Its API isn't a dependency of the
+// operator, so the GrafanaDashboard is built as an unstructured object.
+var GrafanaDashboardGVK = schema.GroupVersionKind{Group: "grafana.integreatly.org", Version: "v1beta1", Kind: "GrafanaDashboard"}
+
+// defaultDashboardLabels are the labels the Grafana dashboard sidecar of the Grafana Helm chart looks for.
+var defaultDashboardLabels = map[string]string{"grafana_dashboard": "1"}
+
+// grafanaDashboardSpec is the subset of the GrafanaDashboard spec the operator sets.
+type grafanaDashboardSpec struct {
+	InstanceSelector *metav1.LabelSelector `json:"instanceSelector"`
+	JSON             string                `json:"json"`
+}
+
+// dashboard is the subset of the Grafana dashboard model the operator sets.
+type dashboard struct {
+	UID           string             `json:"uid"`
+	Title         string             `json:"title"`
+	Description   string             `json:"description"`
+	Tags          []string           `json:"tags"`
+	Editable      bool               `json:"editable"`
+	SchemaVersion int                `json:"schemaVersion"`
+	Refresh       string             `json:"refresh"`
+	Time          dashboardTimeRange `json:"time"`
+	Templating    dashboardVariables `json:"templating"`
+	Panels        []dashboardPanel   `json:"panels"`
+}
+
+type dashboardTimeRange struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+type dashboardVariables struct {
+	List []dashboardVariable `json:"list"`
+}
+
+type dashboardVariable struct {
+	Name  string `json:"name"`
+	Label string `json:"label"`
+	Type  string `json:"type"`
+	Query string `json:"query"`
+}
+
+type dashboardPanel struct {
+	ID          int                  `json:"id"`
+	Title       string               `json:"title"`
+	Type        string               `json:"type"`
+	Datasource  dashboardDatasource  `json:"datasource"`
+	GridPos     dashboardGridPos     `json:"gridPos"`
+	FieldConfig dashboardFieldConfig `json:"fieldConfig"`
+	Targets     []dashboardTarget    `json:"targets"`
+}
+
+type dashboardDatasource struct {
+	Type string `json:"type"`
+	UID  string `json:"uid"`
+}
+
+type dashboardGridPos struct {
+	H int `json:"h"`
+	W int `json:"w"`
+	X int `json:"x"`
+	Y int `json:"y"`
+}
+
+type dashboardFieldConfig struct {
+	Defaults dashboardFieldDefaults `json:"defaults"`
+}
+
+type dashboardFieldDefaults struct {
+	Unit string `json:"unit,omitempty"`
+}
+
+type dashboardTarget struct {
+	RefID        string              `json:"refId"`
+	Datasource   dashboardDatasource `json:"datasource"`
+	Expr         string              `json:"expr"`
+	LegendFormat string              `json:"legendFormat"`
+}
+
+// dashboardQuery is a panel of the dashboard, with a single query.
+type dashboardQuery struct {
+	title  string
+	unit   string
+	expr   string
+	legend string
+}
+
+// shouldCreateDashboard returns whether the Grafana dashboard of the collector is enabled.
+func shouldCreateDashboard(params manifests.Params) bool {
+	spec := params.OtelCol.Spec.Monitoring.Dashboard
+	if spec == nil || !spec.Enabled {
+		return false
+	}
+	if params.OtelCol.Spec.Mode == v1beta1.ModeSidecar {
+		params.Log.V(2).Info("Using sidecar mode. The Grafana dashboard will not be created")
+		return false
+	}
+	return true
+}
+
+// DashboardConfigMap returns the ConfigMap of the Grafana dashboard of the collector, which the Grafana dashboard
+// sidecar imports, when the Grafana operator isn't installed.
+func DashboardConfigMap(params manifests.Params) (*corev1.ConfigMap, error) {
+	if !shouldCreateDashboard(params) || params.Config.GrafanaDashboardAvailability == grafana.Available {
+		return nil, nil
+	}
+
+	content, err := collectorDashboard(params)
+	if err != nil {
+		return nil, err
+	}
+	name := naming.Dashboard(params.OtelCol.Name)
+	labels := manifestutils.Labels(params.OtelCol.ObjectMeta, name, params.OtelCol.Spec.Image, ComponentOpenTelemetryCollector, params.Config.LabelsFilter)
+	dashboardLabels := params.OtelCol.Spec.Monitoring.Dashboard.Labels
+	if len(dashboardLabels) == 0 {
+		dashboardLabels = defaultDashboardLabels
+	}
+	maps.Copy(labels, dashboardLabels)
+
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: params.OtelCol.Namespace,
+			Labels:    labels,
+		},
+		Data: map[string]string{
+			// the sidecar writes the dashboards of every namespace to the same directory
+			fmt.Sprintf("otelcol-%s-%s.json", params.OtelCol.Namespace, params.OtelCol.Name): content,
+		},
+	}, nil
+}
+
+// GrafanaDashboard returns the GrafanaDashboard of the collector, when the Grafana operator is installed.
+func GrafanaDashboard(params manifests.Params) (*unstructured.Unstructured, error) {
+	if !shouldCreateDashboard(params) || params.Config.GrafanaDashboardAvailability != grafana.Available {
+		return nil, nil
+	}
+
+	content, err := collectorDashboard(params)
+	if err != nil {
+		return nil, err
+	}
+	instanceSelector := params.OtelCol.Spec.Monitoring.Dashboard.InstanceSelector
+	if instanceSelector == nil {
+		instanceSelector = &metav1.LabelSelector{}
+	}
+	specObject, err := toUnstructuredMap(grafanaDashboardSpec{InstanceSelector: instanceSelector, JSON: content})
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert the GrafanaDashboard spec: %w", err)
+	}
+
+	name := naming.Dashboard(params.OtelCol.Name)
+	grafanaDashboard := &unstructured.Unstructured{Object: map[string]any{"spec": specObject}}
+	grafanaDashboard.SetGroupVersionKind(GrafanaDashboardGVK)
+	grafanaDashboard.SetName(name)
+	grafanaDashboard.SetNamespace(params.OtelCol.Namespace)
+	grafanaDashboard.SetLabels(manifestutils.Labels(params.OtelCol.ObjectMeta, name, params.OtelCol.Spec.Image, ComponentOpenTelemetryCollector, params.Config.LabelsFilter))
+	return grafanaDashboard, nil
+}
+
+// collectorDashboard returns the JSON model of the Grafana dashboard of the collector's own metrics.
+func collectorDashboard(params manifests.Params) (string, error) {
+	selector := collectorLabelSelector(params)
+	queries := []dashboardQuery{
+		{
+			title:  "Accepted items by receiver",
+			unit:   "short",
+			expr:   fmt.Sprintf(`sum by (receiver) (rate({__name__=~"otelcol_receiver_accepted_(spans|metric_points|log_records)(_total)?",%s}[$__rate_interval]))`, selector),
+			legend: "{{receiver}}",
+		},
+		{
+			title:  "Refused items by receiver",
+			unit:   "short",
+			expr:   fmt.Sprintf(`sum by (receiver) (rate({__name__=~"otelcol_receiver_refused_(spans|metric_points|log_records)(_total)?",%s}[$__rate_interval]))`, selector),
+			legend: "{{receiver}}",
+		},
+		{
+			title:  "Sent items by exporter",
+			unit:   "short",
+			expr:   fmt.Sprintf(`sum by (exporter) (rate({__name__=~"otelcol_exporter_sent_(spans|metric_points|log_records)(_total)?",%s}[$__rate_interval]))`, selector),
+			legend: "{{exporter}}",
+		},
+		{
+			title:  "Failed items by exporter",
+			unit:   "short",
+			expr:   fmt.Sprintf(`sum by (exporter) (rate({__name__=~"otelcol_exporter_send_failed_(spans|metric_points|log_records)(_total)?",%s}[$__rate_interval]))`, selector),
+			legend: "{{exporter}}",
+		},
+		{
+			title:  "Sending queue usage by exporter",
+			unit:   "percentunit",
+			expr:   fmt.Sprintf(`max by (exporter) (otelcol_exporter_queue_size{%s} / otelcol_exporter_queue_capacity{%s})`, selector, selector),
+			legend: "{{exporter}}",
+		},
+		{
+			title:  "Items refused or dropped by the memory_limiter",
+			unit:   "short",
+			expr:   fmt.Sprintf(`sum by (processor) (rate({__name__=~"otelcol_processor_memory_limiter_(refused|dropped)_(spans|metric_points|log_records)(_total)?",%s}[$__rate_interval]))`, selector),
+			legend: "{{processor}}",
+		},
+		{
+			title:  "Memory by pod",
+			unit:   "bytes",
+			expr:   fmt.Sprintf(`max by (pod) ({__name__=~"otelcol_process_memory_rss(_bytes)?",%s})`, selector),
+			legend: "{{pod}}",
+		},
+		{
+			title:  "CPU by pod",
+			unit:   "short",
+			expr:   fmt.Sprintf(`sum by (pod) (rate({__name__=~"otelcol_process_cpu_seconds(_total)?",%s}[$__rate_interval]))`, selector),
+			legend: "{{pod}}",
+		},
+	}
+
+	datasource := dashboardDatasource{Type: "prometheus", UID: "${datasource}"}
+	panels := make([]dashboardPanel, 0, len(queries))
+	for i, query := range queries {
+		panels = append(panels, dashboardPanel{
+			ID:          i + 1,
+			Title:       query.title,
+			Type:        "timeseries",
+			Datasource:  datasource,
+			GridPos:     dashboardGridPos{H: 8, W: 12, X: (i % 2) * 12, Y: (i / 2) * 8},
+			FieldConfig: dashboardFieldConfig{Defaults: dashboardFieldDefaults{Unit: query.unit}},
+			Targets: []dashboardTarget{
+				{RefID: "A", Datasource: datasource, Expr: query.expr, LegendFormat: query.legend},
+			},
+		})
+	}
+
+	// the UID identifies the dashboard in Grafana, so it must be stable and unique across the collectors
+	uid := sha256.Sum256([]byte(fmt.Sprintf("%s/%s", params.OtelCol.Namespace, params.OtelCol.Name)))
+	content, err := json.Marshal(dashboard{
+		UID:           fmt.Sprintf("otelcol-%x", uid[:8]),
+		Title:         fmt.Sprintf("OpenTelemetry Collector / %s / %s", params.OtelCol.Namespace, params.OtelCol.Name),
+		Description:   fmt.Sprintf("The own metrics of the OpenTelemetry Collector %s in the namespace %s.", params.OtelCol.Name, params.OtelCol.Namespace),
+		Tags:          []string{"opentelemetry", "opentelemetry-collector"},
+		Editable:      false,
+		SchemaVersion: 39,
+		Refresh:       "30s",
+		Time:          dashboardTimeRange{From: "now-1h", To: "now"},
+		Templating: dashboardVariables{
+			List: []dashboardVariable{{Name: "datasource", Label: "Data source", Type: "datasource", Query: "prometheus"}},
+		},
+		Panels: panels,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to render the Grafana dashboard: %w", err)
+	}
+	return string(content), nil
+}
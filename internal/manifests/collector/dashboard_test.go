@@ -0,0 +1,107 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package collector
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/grafana"
+)
+
+func TestDashboardConfigMap(t *testing.T) {
+	params := deploymentParams()
+	params.OtelCol.Spec.Monitoring.Dashboard = &v1beta1.MetricsDashboardSpec{Enabled: true}
+
+	cm, err := DashboardConfigMap(params)
+	require.NoError(t, err)
+	require.NotNil(t, cm)
+	assert.Equal(t, "test-collector-dashboard", cm.Name)
+	assert.Equal(t, "1", cm.Labels["grafana_dashboard"])
+	require.Contains(t, cm.Data, "otelcol-default-test.json")
+
+	var model map[string]any
+	require.NoError(t, json.Unmarshal([]byte(cm.Data["otelcol-default-test.json"]), &model))
+	assert.Equal(t, "OpenTelemetry Collector / default / test", model["title"])
+	assert.Regexp(t, "^otelcol-[0-9a-f]{16}$", model["uid"])
+	panels := model["panels"].([]any)
+	assert.Len(t, panels, 8)
+	for _, panel := range panels {
+		target := panel.(map[string]any)["targets"].([]any)[0].(map[string]any)
+		assert.Contains(t, target["expr"], `namespace="default",app_kubernetes_io_instance="default.test"`)
+	}
+
+	// no GrafanaDashboard without the Grafana operator
+	grafanaDashboard, err := GrafanaDashboard(params)
+	require.NoError(t, err)
+	assert.Nil(t, grafanaDashboard)
+
+	// custom labels replace the default one
+	params.OtelCol.Spec.Monitoring.Dashboard.Labels = map[string]string{"dashboards": "otel"}
+	cm, err = DashboardConfigMap(params)
+	require.NoError(t, err)
+	assert.Equal(t, "otel", cm.Labels["dashboards"])
+	assert.NotContains(t, cm.Labels, "grafana_dashboard")
+}
+
+func TestGrafanaDashboard(t *testing.T) {
+	params := deploymentParams()
+	params.Config.GrafanaDashboardAvailability = grafana.Available
+	params.OtelCol.Spec.Monitoring.Dashboard = &v1beta1.MetricsDashboardSpec{
+		Enabled:          true,
+		InstanceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"dashboards": "grafana"}},
+	}
+
+	grafanaDashboard, err := GrafanaDashboard(params)
+	require.NoError(t, err)
+	require.NotNil(t, grafanaDashboard)
+	assert.Equal(t, GrafanaDashboardGVK, grafanaDashboard.GroupVersionKind())
+	assert.Equal(t, "test-collector-dashboard", grafanaDashboard.GetName())
+	assert.Equal(t, "default", grafanaDashboard.GetNamespace())
+
+	matchLabels, _, err := unstructured.NestedStringMap(grafanaDashboard.Object, "spec", "instanceSelector", "matchLabels")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"dashboards": "grafana"}, matchLabels)
+	content, _, err := unstructured.NestedString(grafanaDashboard.Object, "spec", "json")
+	require.NoError(t, err)
+	expected, err := collectorDashboard(params)
+	require.NoError(t, err)
+	assert.JSONEq(t, expected, content)
+
+	// no ConfigMap with the Grafana operator
+	cm, err := DashboardConfigMap(params)
+	require.NoError(t, err)
+	assert.Nil(t, cm)
+}
+
+func TestDashboardNotCreated(t *testing.T) {
+	for _, tt := range []struct {
+		name      string
+		mode      v1beta1.Mode
+		dashboard *v1beta1.MetricsDashboardSpec
+	}{
+		{name: "not set", mode: v1beta1.ModeDeployment},
+		{name: "disabled", mode: v1beta1.ModeDeployment, dashboard: &v1beta1.MetricsDashboardSpec{}},
+		{name: "sidecar", mode: v1beta1.ModeSidecar, dashboard: &v1beta1.MetricsDashboardSpec{Enabled: true}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			params := paramsWithMode(tt.mode)
+			params.OtelCol.Spec.Monitoring.Dashboard = tt.dashboard
+
+			cm, err := DashboardConfigMap(params)
+			require.NoError(t, err)
+			assert.Nil(t, cm)
+			params.Config.GrafanaDashboardAvailability = grafana.Available
+			grafanaDashboard, err := GrafanaDashboard(params)
+			require.NoError(t, err)
+			assert.Nil(t, grafanaDashboard)
+		})
+	}
+}
@@ -15,6 +15,27 @@ import (
 
 // Deployment builds the deployment for the given instance.
 func Deployment(params manifests.Params) (*appsv1.Deployment, error) {
+	d, err := deployment(params)
+	if err != nil {
+		return nil, err
+	}
+	if params.OtelCol.Spec.ConfigRollout != nil {
+		if err = applyConfigRollout(params, &d.Spec.Template, nil, deploymentPodTemplate); err != nil {
+			return nil, err
+		}
+	}
+	return d, nil
+}
+
+func deploymentPodTemplate(params manifests.Params) (*corev1.PodTemplateSpec, error) {
+	d, err := deployment(params)
+	if err != nil {
+		return nil, err
+	}
+	return &d.Spec.Template, nil
+}
+
+func deployment(params manifests.Params) (*appsv1.Deployment, error) {
 	name := naming.Collector(params.OtelCol.Name)
 	labels := manifestutils.Labels(params.OtelCol.ObjectMeta, name, params.OtelCol.Spec.Image, ComponentOpenTelemetryCollector, params.Config.LabelsFilter)
 	annotations, err := manifestutils.Annotations(params.OtelCol, params.Config.AnnotationsFilter)
@@ -27,7 +48,7 @@ func Deployment(params manifests.Params) (*appsv1.Deployment, error) {
 		return nil, err
 	}
 
-	return &appsv1.Deployment{
+	d := &appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        name,
 			Namespace:   params.OtelCol.Namespace,
@@ -59,7 +80,7 @@ func Deployment(params manifests.Params) (*appsv1.Deployment, error) {
 					ShareProcessNamespace:         &params.OtelCol.Spec.ShareProcessNamespace,
 					Tolerations:                   params.OtelCol.Spec.Tolerations,
 					NodeSelector:                  params.OtelCol.Spec.NodeSelector,
-					SecurityContext:               params.OtelCol.Spec.PodSecurityContext,
+					SecurityContext:               podSecurityContext(params.OtelCol),
 					PriorityClassName:             params.OtelCol.Spec.PriorityClassName,
 					Affinity:                      params.OtelCol.Spec.Affinity,
 					TerminationGracePeriodSeconds: params.OtelCol.Spec.TerminationGracePeriodSeconds,
@@ -67,5 +88,7 @@ func Deployment(params manifests.Params) (*appsv1.Deployment, error) {
 				},
 			},
 		},
-	}, nil
+	}
+	applyConfigFromSecrets(params, &d.Spec.Template)
+	return d, nil
 }
@@ -0,0 +1,96 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package collector
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/gatewayapi"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+)
+
+// GRPCRoutes returns a GRPCRoute for every port of the collector serving gRPC, which are the ports HTTPRoutes skip.
+// The routes match all the gRPC services, so each of them attaches to the listener of the Gateway on its port.
+func GRPCRoutes(params manifests.Params) ([]*gatewayv1.GRPCRoute, error) {
+	if !params.OtelCol.Spec.GRPCRoute.Enabled {
+		return nil, nil
+	}
+
+	if params.OtelCol.Spec.Mode == v1beta1.ModeSidecar {
+		params.Log.V(3).Info("GRPCRoute settings are not supported in sidecar mode")
+		return nil, nil
+	}
+
+	if params.Config.GRPCRouteAvailability != gatewayapi.ApiAvailable {
+		params.Log.V(1).Info("the Gateway API GRPCRoute is not available, skipping GRPCRoute")
+		return nil, nil
+	}
+
+	// Gateway name is required
+	if params.OtelCol.Spec.GRPCRoute.Gateway == "" {
+		params.Log.V(1).Info(
+			"GRPCRoute is enabled but gateway name is not specified, skipping GRPCRoute",
+			"instance.name", params.OtelCol.Name,
+			"instance.namespace", params.OtelCol.Namespace,
+		)
+		return nil, nil
+	}
+
+	ports, err := servicePortsFromCfg(params.Log, params.OtelCol)
+	if len(ports) == 0 || err != nil {
+		params.Log.V(1).Info(
+			"the instance's configuration didn't yield any ports to open, skipping GRPCRoute",
+			"instance.name", params.OtelCol.Name,
+			"instance.namespace", params.OtelCol.Namespace,
+		)
+		return nil, err
+	}
+
+	grpcRouteConfig := &params.OtelCol.Spec.GRPCRoute
+	var grpcRoutes []*gatewayv1.GRPCRoute
+
+	for _, port := range ports {
+		if !isGRPCPort(port) {
+			continue
+		}
+
+		name := naming.GRPCRoute(params.OtelCol.Name, port.Name)
+		labels := manifestutils.Labels(params.OtelCol.ObjectMeta, name, params.OtelCol.Spec.Image, ComponentOpenTelemetryCollector, params.Config.LabelsFilter)
+		portNumber := gatewayv1.PortNumber(port.Port)
+
+		grpcRoutes = append(grpcRoutes, &gatewayv1.GRPCRoute{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: params.OtelCol.Namespace,
+				Labels:    labels,
+			},
+			Spec: gatewayv1.GRPCRouteSpec{
+				CommonRouteSpec: gatewayv1.CommonRouteSpec{
+					ParentRefs: gatewayParentRefs(params, "GRPCRoute", grpcRouteConfig.Gateway, grpcRouteConfig.GatewayNamespace, &portNumber),
+				},
+				Hostnames: gatewayHostnames(grpcRouteConfig.Hostnames),
+				Rules: []gatewayv1.GRPCRouteRule{
+					{
+						BackendRefs: []gatewayv1.GRPCBackendRef{
+							{
+								BackendRef: gatewayv1.BackendRef{
+									BackendObjectReference: gatewayv1.BackendObjectReference{
+										Name: gatewayv1.ObjectName(naming.Service(params.OtelCol.Name)),
+										Port: &portNumber,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		})
+	}
+
+	return grpcRoutes, nil
+}
@@ -0,0 +1,139 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package collector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/gatewayapi"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+)
+
+func TestDesiredGRPCRoutes(t *testing.T) {
+	t.Run("should return nil when GRPCRoute is not enabled", func(t *testing.T) {
+		params := manifests.Params{
+			Config: config.Config{GRPCRouteAvailability: gatewayapi.ApiAvailable},
+			Log:    testLogger,
+			OtelCol: v1beta1.OpenTelemetryCollector{
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					GRPCRoute: v1beta1.GRPCRouteConfig{
+						Enabled: false,
+						Gateway: "example-gateway",
+					},
+				},
+			},
+		}
+
+		actual, err := GRPCRoutes(params)
+		assert.NoError(t, err)
+		assert.Nil(t, actual)
+	})
+
+	t.Run("should return nil for sidecar mode", func(t *testing.T) {
+		params := manifests.Params{
+			Config: config.Config{GRPCRouteAvailability: gatewayapi.ApiAvailable},
+			Log:    testLogger,
+			OtelCol: v1beta1.OpenTelemetryCollector{
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					Mode: v1beta1.ModeSidecar,
+					GRPCRoute: v1beta1.GRPCRouteConfig{
+						Enabled: true,
+						Gateway: "example-gateway",
+					},
+				},
+			},
+		}
+
+		actual, err := GRPCRoutes(params)
+		assert.NoError(t, err)
+		assert.Nil(t, actual)
+	})
+
+	t.Run("should return nil when GRPCRoute is not available", func(t *testing.T) {
+		params, err := newParams("something:tag", testFileIngress, nil)
+		require.NoError(t, err)
+		params.OtelCol.Spec.GRPCRoute = v1beta1.GRPCRouteConfig{
+			Enabled: true,
+			Gateway: "example-gateway",
+		}
+
+		actual, err := GRPCRoutes(params)
+		assert.NoError(t, err)
+		assert.Nil(t, actual)
+	})
+
+	t.Run("should create a GRPCRoute for every gRPC port", func(t *testing.T) {
+		params, err := newParams("something:tag", testFileIngress, nil)
+		require.NoError(t, err)
+		params.Config.GRPCRouteAvailability = gatewayapi.ApiAvailable
+		params.OtelCol.Namespace = "test"
+		params.OtelCol.Spec.GRPCRoute = v1beta1.GRPCRouteConfig{
+			Enabled:          true,
+			Gateway:          "example-gateway",
+			GatewayNamespace: "gateway-system",
+			Hostnames:        []string{"otel.example.com"},
+		}
+
+		grpcRoutes, err := GRPCRoutes(params)
+		require.NoError(t, err)
+		require.Len(t, grpcRoutes, 2)
+
+		names := []string{grpcRoutes[0].Name, grpcRoutes[1].Name}
+		assert.ElementsMatch(t, []string{
+			naming.GRPCRoute(params.OtelCol.Name, "otlp-grpc"),
+			naming.GRPCRoute(params.OtelCol.Name, "otlp-test-grpc"),
+		}, names)
+
+		for _, got := range grpcRoutes {
+			assert.Equal(t, "test", got.Namespace)
+
+			require.Len(t, got.Spec.ParentRefs, 1)
+			assert.Equal(t, gatewayv1.ObjectName("example-gateway"), got.Spec.ParentRefs[0].Name)
+			assert.Equal(t, gatewayv1.Namespace("gateway-system"), *got.Spec.ParentRefs[0].Namespace)
+			assert.Equal(t, []gatewayv1.Hostname{"otel.example.com"}, got.Spec.Hostnames)
+
+			require.Len(t, got.Spec.Rules, 1)
+			assert.Empty(t, got.Spec.Rules[0].Matches)
+			require.Len(t, got.Spec.Rules[0].BackendRefs, 1)
+			backendRef := got.Spec.Rules[0].BackendRefs[0]
+			assert.Equal(t, gatewayv1.ObjectName(naming.Service(params.OtelCol.Name)), backendRef.Name)
+			require.NotNil(t, backendRef.Port)
+			// the route attaches to the listener on the port it routes to
+			require.NotNil(t, got.Spec.ParentRefs[0].Port)
+			assert.Equal(t, *backendRef.Port, *got.Spec.ParentRefs[0].Port)
+		}
+
+		// the routes match all the gRPC services, so they must not attach to the same listener
+		assert.NotEqual(t, *grpcRoutes[0].Spec.ParentRefs[0].Port, *grpcRoutes[1].Spec.ParentRefs[0].Port)
+	})
+
+	t.Run("should skip the ports HTTPRoutes expose", func(t *testing.T) {
+		params, err := newParams("something:tag", testFileIngress, nil)
+		require.NoError(t, err)
+		params.Config.GRPCRouteAvailability = gatewayapi.ApiAvailable
+		params.OtelCol.Spec.Config.Receivers.Object = map[string]any{
+			"otlp": map[string]any{
+				"protocols": map[string]any{
+					"http": map[string]any{},
+				},
+			},
+		}
+		params.OtelCol.Spec.Config.Service.Pipelines["traces"].Receivers = []string{"otlp"}
+		params.OtelCol.Spec.GRPCRoute = v1beta1.GRPCRouteConfig{
+			Enabled: true,
+			Gateway: "example-gateway",
+		}
+
+		grpcRoutes, err := GRPCRoutes(params)
+		require.NoError(t, err)
+		assert.Empty(t, grpcRoutes)
+	})
+}
@@ -37,6 +37,11 @@ func HorizontalPodAutoscaler(params manifests.Params) (*autoscalingv2.Horizontal
 		return nil, nil
 	}
 
+	// the HorizontalPodAutoscaler of a KEDA ScaledObject is managed by KEDA
+	if params.OtelCol.Spec.Autoscaler.Type == v1beta1.AutoscalerTypeKeda {
+		return nil, nil
+	}
+
 	metrics := []autoscalingv2.MetricSpec{}
 
 	if params.OtelCol.Spec.Autoscaler.TargetMemoryUtilization != nil {
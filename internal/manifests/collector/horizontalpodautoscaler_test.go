@@ -99,3 +99,25 @@ func TestHPA(t *testing.T) {
 		}
 	}
 }
+
+func TestHPAKedaAutoscaler(t *testing.T) {
+	maxReplicas := int32(5)
+	params := manifests.Params{
+		Config: config.New(),
+		OtelCol: v1beta1.OpenTelemetryCollector{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "my-instance",
+			},
+			Spec: v1beta1.OpenTelemetryCollectorSpec{
+				Autoscaler: &v1beta1.AutoscalerSpec{
+					Type:        v1beta1.AutoscalerTypeKeda,
+					MaxReplicas: &maxReplicas,
+				},
+			},
+		},
+		Log: testLogger,
+	}
+	hpa, err := HorizontalPodAutoscaler(params)
+	require.NoError(t, err)
+	assert.Nil(t, hpa)
+}
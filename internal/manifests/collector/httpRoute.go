@@ -6,6 +6,7 @@ package collector
 import (
 	"fmt"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 
@@ -50,7 +51,7 @@ func HTTPRoutes(params manifests.Params) ([]*gatewayv1.HTTPRoute, error) {
 
 	for _, port := range ports {
 		// dont create HTTPRoute for gRPC ports
-		if port.AppProtocol != nil && (*port.AppProtocol == "grpc" || *port.AppProtocol == "h2c") {
+		if isGRPCPort(port) {
 			params.Log.V(2).Info(
 				"skipping gRPC port for HTTPRoute",
 				"instance.name", params.OtelCol.Name,
@@ -63,30 +64,8 @@ func HTTPRoutes(params manifests.Params) ([]*gatewayv1.HTTPRoute, error) {
 		name := naming.HTTPRoute(params.OtelCol.Name, port.Name)
 		labels := manifestutils.Labels(params.OtelCol.ObjectMeta, name, params.OtelCol.Spec.Image, ComponentOpenTelemetryCollector, params.Config.LabelsFilter)
 
-		gatewayNamespace := httpRouteConfig.GatewayNamespace
-		if gatewayNamespace == "" {
-			gatewayNamespace = params.OtelCol.Namespace
-			params.Log.V(1).Info("HTTPRoute GatewayNamespace not specified, using the same namespace as the OtelCol", "namespace", gatewayNamespace)
-		}
-
-		namespace := gatewayv1.Namespace(gatewayNamespace)
-		group := gatewayv1.Group("gateway.networking.k8s.io")
-		kind := gatewayv1.Kind("Gateway")
-
-		// auto-generate ParentRefs from gateway name and namespace
-		parentRefs := []gatewayv1.ParentReference{
-			{
-				Group:     &group,
-				Kind:      &kind,
-				Name:      gatewayv1.ObjectName(httpRouteConfig.Gateway),
-				Namespace: &namespace,
-			},
-		}
-
-		var hostnames []gatewayv1.Hostname
-		for _, hostname := range httpRouteConfig.Hostnames {
-			hostnames = append(hostnames, gatewayv1.Hostname(hostname))
-		}
+		parentRefs := gatewayParentRefs(params, "HTTPRoute", httpRouteConfig.Gateway, httpRouteConfig.GatewayNamespace, nil)
+		hostnames := gatewayHostnames(httpRouteConfig.Hostnames)
 
 		// use PathPrefix as match type so that multiple routes can be created without conflict
 		pathMatchType := gatewayv1.PathMatchPathPrefix
@@ -148,3 +127,38 @@ func HTTPRoutes(params manifests.Params) ([]*gatewayv1.HTTPRoute, error) {
 
 	return httpRoutes, nil
 }
+
+// isGRPCPort returns whether the given port serves gRPC, based on the app protocol set by the receiver's parser.
+func isGRPCPort(port corev1.ServicePort) bool {
+	return port.AppProtocol != nil && (*port.AppProtocol == "grpc" || *port.AppProtocol == "h2c")
+}
+
+// gatewayParentRefs auto-generates the ParentRefs of a route of the given kind from the gateway name and namespace.
+// When port is set, the route only attaches to the listeners of the Gateway on that port.
+func gatewayParentRefs(params manifests.Params, routeKind, gateway, gatewayNamespace string, port *gatewayv1.PortNumber) []gatewayv1.ParentReference {
+	if gatewayNamespace == "" {
+		gatewayNamespace = params.OtelCol.Namespace
+		params.Log.V(1).Info(routeKind+" GatewayNamespace not specified, using the same namespace as the OtelCol", "namespace", gatewayNamespace)
+	}
+
+	namespace := gatewayv1.Namespace(gatewayNamespace)
+	group := gatewayv1.Group("gateway.networking.k8s.io")
+	kind := gatewayv1.Kind("Gateway")
+	return []gatewayv1.ParentReference{
+		{
+			Group:     &group,
+			Kind:      &kind,
+			Name:      gatewayv1.ObjectName(gateway),
+			Namespace: &namespace,
+			Port:      port,
+		},
+	}
+}
+
+func gatewayHostnames(hostnames []string) []gatewayv1.Hostname {
+	var gatewayHostnames []gatewayv1.Hostname
+	for _, hostname := range hostnames {
+		gatewayHostnames = append(gatewayHostnames, gatewayv1.Hostname(hostname))
+	}
+	return gatewayHostnames
+}
@@ -0,0 +1,128 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package collector
+
+import (
+	"slices"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/components"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+)
+
+const (
+	memoryLimiterType = "memory_limiter"
+
+	goMemLimitEnvVarName = "GOMEMLIMIT"
+
+	defaultMemoryLimiterCheckInterval        = "1s"
+	defaultMemoryLimiterLimitPercentage      = int32(80)
+	defaultMemoryLimiterSpikeLimitPercentage = int32(20)
+)
+
+// tunesMemoryLimiter returns whether the operator manages the memory_limiter processor of the collector, which it does
+// when the resources of the collector container are managed by a VerticalPodAutoscaler.
+func tunesMemoryLimiter(otelcol v1beta1.OpenTelemetryCollector) bool {
+	vpa := otelcol.Spec.VerticalAutoscaler
+	return vpa != nil && vpa.Enabled && !vpa.MemoryLimiter.Disabled && supportsVerticalAutoscaler(otelcol.Spec.Mode)
+}
+
+// goMemLimitEnvVar returns the GOMEMLIMIT environment variable set to the memory limit of the collector container.
+func goMemLimitEnvVar() corev1.EnvVar {
+	return corev1.EnvVar{
+		Name: goMemLimitEnvVarName,
+		ValueFrom: &corev1.EnvVarSource{
+			ResourceFieldRef: &corev1.ResourceFieldSelector{
+				Resource:      "limits.memory",
+				ContainerName: naming.Container(),
+			},
+		},
+	}
+}
+
+// tunedGoMemLimitEnvVar returns the GOMEMLIMIT environment variable set to the limit_percentage of the tuned
+// memory_limiter processors of the memory limit of the collector container, so that the Go runtime collects garbage
+// more aggressively before the processors refuse data. The Downward API can't scale the limit, so the value is derived
+// from the limit in the spec, and doesn't follow the limits set by the VerticalPodAutoscaler on the pods. It's not set
+// when the container has no memory limit.
+func tunedGoMemLimitEnvVar(otelcol v1beta1.OpenTelemetryCollector) (corev1.EnvVar, bool) {
+	limit, ok := otelcol.Spec.Resources.Limits[corev1.ResourceMemory]
+	if !ok || limit.IsZero() {
+		return corev1.EnvVar{}, false
+	}
+	limitPercentage := defaultMemoryLimiterLimitPercentage
+	if tuned := otelcol.Spec.VerticalAutoscaler.MemoryLimiter.LimitPercentage; tuned != nil {
+		limitPercentage = *tuned
+	}
+	return corev1.EnvVar{
+		Name:  goMemLimitEnvVarName,
+		Value: strconv.FormatInt(limit.Value()*int64(limitPercentage)/100, 10),
+	}, true
+}
+
+// applyMemoryLimiter puts a memory_limiter processor at the head of every pipeline of the configuration. The limits
+// of the memory_limiter processors are set in percent of the memory available to the collector, which is the memory
+// limit of its container, so that they follow the limits set by the VerticalPodAutoscaler. Absolute limits set in the
+// configuration are replaced.
+func applyMemoryLimiter(cfg *v1beta1.Config, tuning v1beta1.MemoryLimiterTuning) {
+	if len(cfg.Service.Pipelines) == 0 {
+		return
+	}
+	if cfg.Processors == nil {
+		cfg.Processors = &v1beta1.AnyConfig{}
+	}
+	if cfg.Processors.Object == nil {
+		cfg.Processors.Object = map[string]any{}
+	}
+
+	limitPercentage := defaultMemoryLimiterLimitPercentage
+	if tuning.LimitPercentage != nil {
+		limitPercentage = *tuning.LimitPercentage
+	}
+	spikeLimitPercentage := defaultMemoryLimiterSpikeLimitPercentage
+	if tuning.SpikeLimitPercentage != nil {
+		spikeLimitPercentage = *tuning.SpikeLimitPercentage
+	}
+	tune := func(processor map[string]any) map[string]any {
+		if processor == nil {
+			processor = map[string]any{}
+		}
+		if _, ok := processor["check_interval"]; !ok {
+			processor["check_interval"] = defaultMemoryLimiterCheckInterval
+		}
+		delete(processor, "limit_mib")
+		delete(processor, "spike_limit_mib")
+		processor["limit_percentage"] = limitPercentage
+		processor["spike_limit_percentage"] = spikeLimitPercentage
+		return processor
+	}
+
+	for name, processor := range cfg.Processors.Object {
+		if components.ComponentType(name) != memoryLimiterType {
+			continue
+		}
+		processorMap, _ := processor.(map[string]any)
+		cfg.Processors.Object[name] = tune(processorMap)
+	}
+
+	for _, pipeline := range cfg.Service.Pipelines {
+		if pipeline == nil {
+			continue
+		}
+		index := slices.IndexFunc(pipeline.Processors, func(name string) bool {
+			return components.ComponentType(name) == memoryLimiterType
+		})
+		name := memoryLimiterType
+		if index >= 0 {
+			name = pipeline.Processors[index]
+			pipeline.Processors = slices.Delete(pipeline.Processors, index, index+1)
+		} else if _, ok := cfg.Processors.Object[name]; !ok {
+			cfg.Processors.Object[name] = tune(nil)
+		}
+		pipeline.Processors = slices.Insert(pipeline.Processors, 0, name)
+	}
+}
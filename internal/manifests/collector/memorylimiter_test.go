@@ -0,0 +1,145 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package collector
+
+import (
+	"testing"
+
+	go_yaml "github.com/goccy/go-yaml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+)
+
+func TestApplyMemoryLimiter(t *testing.T) {
+	limit := int32(75)
+	for _, tt := range []struct {
+		name     string
+		config   string
+		tuning   v1beta1.MemoryLimiterTuning
+		expected string
+	}{
+		{
+			name: "inject",
+			config: `receivers:
+  otlp: {}
+processors:
+  batch: {}
+exporters:
+  debug: {}
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      processors: [batch]
+      exporters: [debug]
+    metrics:
+      receivers: [otlp]
+      exporters: [debug]
+`,
+			expected: `receivers:
+  otlp: {}
+processors:
+  batch: {}
+  memory_limiter:
+    check_interval: 1s
+    limit_percentage: 80
+    spike_limit_percentage: 20
+exporters:
+  debug: {}
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      processors: [memory_limiter, batch]
+      exporters: [debug]
+    metrics:
+      receivers: [otlp]
+      processors: [memory_limiter]
+      exporters: [debug]
+`,
+		},
+		{
+			name: "adjust and move existing",
+			config: `receivers:
+  otlp: {}
+processors:
+  batch: {}
+  memory_limiter/gateway:
+    check_interval: 5s
+    limit_mib: 4000
+    spike_limit_mib: 800
+exporters:
+  debug: {}
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      processors: [batch, memory_limiter/gateway]
+      exporters: [debug]
+`,
+			tuning: v1beta1.MemoryLimiterTuning{LimitPercentage: &limit},
+			expected: `receivers:
+  otlp: {}
+processors:
+  batch: {}
+  memory_limiter/gateway:
+    check_interval: 5s
+    limit_percentage: 75
+    spike_limit_percentage: 20
+exporters:
+  debug: {}
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      processors: [memory_limiter/gateway, batch]
+      exporters: [debug]
+`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := v1beta1.Config{}
+			require.NoError(t, go_yaml.Unmarshal([]byte(tt.config), &cfg))
+			expected := v1beta1.Config{}
+			require.NoError(t, go_yaml.Unmarshal([]byte(tt.expected), &expected))
+
+			applyMemoryLimiter(&cfg, tt.tuning)
+
+			actualYaml, err := cfg.Yaml()
+			require.NoError(t, err)
+			expectedYaml, err := expected.Yaml()
+			require.NoError(t, err)
+			assert.YAMLEq(t, expectedYaml, actualYaml)
+		})
+	}
+}
+
+func TestTunesMemoryLimiter(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		mode     v1beta1.Mode
+		spec     *v1beta1.VerticalAutoscalerSpec
+		expected bool
+	}{
+		{name: "no vertical autoscaler", mode: v1beta1.ModeDeployment},
+		{name: "disabled", mode: v1beta1.ModeDeployment, spec: &v1beta1.VerticalAutoscalerSpec{}},
+		{name: "deployment", mode: v1beta1.ModeDeployment, spec: &v1beta1.VerticalAutoscalerSpec{Enabled: true}, expected: true},
+		{name: "daemonset", mode: v1beta1.ModeDaemonSet, spec: &v1beta1.VerticalAutoscalerSpec{Enabled: true}, expected: true},
+		{name: "statefulset", mode: v1beta1.ModeStatefulSet, spec: &v1beta1.VerticalAutoscalerSpec{Enabled: true}},
+		{
+			name: "memory limiter disabled",
+			mode: v1beta1.ModeDeployment,
+			spec: &v1beta1.VerticalAutoscalerSpec{Enabled: true, MemoryLimiter: v1beta1.MemoryLimiterTuning{Disabled: true}},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			otelcol := v1beta1.OpenTelemetryCollector{
+				Spec: v1beta1.OpenTelemetryCollectorSpec{Mode: tt.mode, VerticalAutoscaler: tt.spec},
+			}
+			assert.Equal(t, tt.expected, tunesMemoryLimiter(otelcol))
+		})
+	}
+}
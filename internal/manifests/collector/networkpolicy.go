@@ -4,14 +4,30 @@
 package collector
 
 import (
+	"cmp"
+	"net"
+	"slices"
+	"strings"
+
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/openshift"
+	"github.com/open-telemetry/opentelemetry-operator/internal/components"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
 	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+	"github.com/open-telemetry/opentelemetry-operator/internal/otelconfig"
+)
+
+const (
+	dnsPort = 53
+	// openShiftDNSPort is the port the DNS pods of OpenShift listen on, behind the port 53 of their service.
+	openShiftDNSPort = 5353
 )
 
 func NetworkPolicy(params manifests.Params) (*networkingv1.NetworkPolicy, error) {
@@ -42,7 +58,7 @@ func NetworkPolicy(params manifests.Params) (*networkingv1.NetworkPolicy, error)
 		},
 		Spec: networkingv1.NetworkPolicySpec{
 			PodSelector: metav1.LabelSelector{
-				MatchLabels: manifestutils.SelectorLabels(params.OtelCol.ObjectMeta, ComponentOpenTelemetryCollector),
+				MatchLabels: podSelectorLabels(params),
 			},
 			Ingress: []networkingv1.NetworkPolicyIngressRule{
 				{},
@@ -59,5 +75,177 @@ func NetworkPolicy(params manifests.Params) (*networkingv1.NetworkPolicy, error)
 		})
 	}
 
+	if !params.OtelCol.Spec.NetworkPolicy.DisableEgress {
+		np.Spec.Egress, err = egressRules(params)
+		if err != nil {
+			return nil, err
+		}
+		np.Spec.PolicyTypes = append(np.Spec.PolicyTypes, networkingv1.PolicyTypeEgress)
+	}
+
 	return np, nil
 }
+
+// egressRules returns the egress rules allowing the collector to reach the destinations of its components, the
+// endpoints of its own telemetry and the DNS servers.
+func egressRules(params manifests.Params) ([]networkingv1.NetworkPolicyEgressRule, error) {
+	destinations, err := otelconfig.GetAllEgressDestinations(&params.OtelCol.Spec.Config, params.Log)
+	if err != nil {
+		return nil, err
+	}
+	destinations = append(destinations, telemetryEgressDestinations(params.OtelCol.Spec.Config.Service.Telemetry)...)
+	if params.TargetAllocator != nil {
+		// the target allocator and the targets it assigns to the collector
+		destinations = append(destinations, components.EgressDestination{Pods: true})
+	}
+	slices.SortFunc(destinations, func(a, b components.EgressDestination) int {
+		return cmp.Or(
+			strings.Compare(a.Host, b.Host),
+			cmp.Compare(a.Port, b.Port),
+			compareBool(a.KubernetesAPI, b.KubernetesAPI),
+			compareBool(a.Pods, b.Pods),
+		)
+	})
+	destinations = slices.Compact(destinations)
+
+	rules := []networkingv1.NetworkPolicyEgressRule{dnsEgressRule(params)}
+	for _, destination := range destinations {
+		rule, restricted := egressRule(params, destination)
+		if !restricted {
+			params.Log.V(2).Info("allowing the collector to connect to any destination", "destination", destination)
+			return []networkingv1.NetworkPolicyEgressRule{{}}, nil
+		}
+		if !slices.ContainsFunc(rules, func(r networkingv1.NetworkPolicyEgressRule) bool { return equality.Semantic.DeepEqual(r, rule) }) {
+			rules = append(rules, rule)
+		}
+	}
+	return rules, nil
+}
+
+func compareBool(a, b bool) int {
+	switch {
+	case a == b:
+		return 0
+	case a:
+		return 1
+	default:
+		return -1
+	}
+}
+
+// dnsEgressRule allows the collector to resolve the hosts of its destinations.
+func dnsEgressRule(params manifests.Params) networkingv1.NetworkPolicyEgressRule {
+	udp, tcp := corev1.ProtocolUDP, corev1.ProtocolTCP
+	dnsPorts := []int32{dnsPort}
+	if params.Config.OpenShiftRoutesAvailability == openshift.RoutesAvailable {
+		dnsPorts = append(dnsPorts, openShiftDNSPort)
+	}
+	var rule networkingv1.NetworkPolicyEgressRule
+	for _, port := range dnsPorts {
+		rule.Ports = append(rule.Ports,
+			networkingv1.NetworkPolicyPort{Protocol: &udp, Port: ptrIntOrString(port)},
+			networkingv1.NetworkPolicyPort{Protocol: &tcp, Port: ptrIntOrString(port)},
+		)
+	}
+	return rule
+}
+
+// egressRule returns the egress rule allowing the collector to reach the given destination. It returns false when
+// the destination can't be restricted, in which case the collector must be allowed to connect anywhere.
+func egressRule(params manifests.Params, destination components.EgressDestination) (networkingv1.NetworkPolicyEgressRule, bool) {
+	tcp := corev1.ProtocolTCP
+	var rule networkingv1.NetworkPolicyEgressRule
+	switch {
+	case destination.KubernetesAPI:
+		if len(params.Config.Internal.KubeAPIServerIPs) == 0 || params.Config.Internal.KubeAPIServerPort == 0 {
+			return rule, false
+		}
+		for _, ip := range params.Config.Internal.KubeAPIServerIPs {
+			rule.To = append(rule.To, networkingv1.NetworkPolicyPeer{IPBlock: &networkingv1.IPBlock{CIDR: hostCIDR(ip)}})
+		}
+		rule.Ports = []networkingv1.NetworkPolicyPort{{Protocol: &tcp, Port: ptrIntOrString(params.Config.Internal.KubeAPIServerPort)}}
+		return rule, true
+	case destination.Pods:
+		rule.To = []networkingv1.NetworkPolicyPeer{{NamespaceSelector: &metav1.LabelSelector{}}}
+		return rule, true
+	}
+
+	if namespace, inCluster := serviceNamespace(destination.Host, params.OtelCol.Namespace); inCluster {
+		// the pods behind a service may listen on another port than the service's
+		rule.To = []networkingv1.NetworkPolicyPeer{{
+			NamespaceSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{corev1.LabelMetadataName: namespace},
+			},
+		}}
+		return rule, true
+	}
+	if net.ParseIP(destination.Host) != nil {
+		rule.To = []networkingv1.NetworkPolicyPeer{{IPBlock: &networkingv1.IPBlock{CIDR: hostCIDR(destination.Host)}}}
+	} else if destination.Port == 0 {
+		// a NetworkPolicy can't select a host name, and any port of the host is used
+		return rule, false
+	}
+	if destination.Port != 0 {
+		rule.Ports = []networkingv1.NetworkPolicyPort{{Protocol: &tcp, Port: ptrIntOrString(destination.Port)}}
+	}
+	return rule, true
+}
+
+// serviceNamespace returns the namespace of the service the given host resolves to, if it is the host name of a
+// service of the cluster: either the name of a service of the collector's namespace, or of the form
+// <service>.<namespace>.svc[.<cluster domain>].
+func serviceNamespace(host, collectorNamespace string) (string, bool) {
+	if host == "" || net.ParseIP(host) != nil {
+		return "", false
+	}
+	labels := strings.Split(host, ".")
+	switch {
+	case len(labels) == 1:
+		return collectorNamespace, true
+	case len(labels) >= 3 && labels[2] == "svc":
+		return labels[1], true
+	default:
+		return "", false
+	}
+}
+
+func hostCIDR(ip string) string {
+	if strings.Contains(ip, ":") {
+		return ip + "/128"
+	}
+	return ip + "/32"
+}
+
+func ptrIntOrString(port int32) *intstr.IntOrString {
+	value := intstr.FromInt32(port)
+	return &value
+}
+
+// telemetryEgressDestinations returns the destinations of the endpoints the collector exports its own telemetry to.
+func telemetryEgressDestinations(telemetry *v1beta1.AnyConfig) []components.EgressDestination {
+	if telemetry == nil {
+		return nil
+	}
+	var destinations []components.EgressDestination
+	var walk func(value any)
+	walk = func(value any) {
+		switch value := value.(type) {
+		case map[string]any:
+			for key, item := range value {
+				if endpoint, isString := item.(string); isString && key == "endpoint" {
+					if destination, needed := components.EndpointDestination(endpoint, components.UnsetPort); needed {
+						destinations = append(destinations, destination)
+					}
+					continue
+				}
+				walk(item)
+			}
+		case []any:
+			for _, item := range value {
+				walk(item)
+			}
+		}
+	}
+	walk(telemetry.Object)
+	return destinations
+}
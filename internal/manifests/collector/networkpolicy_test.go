@@ -9,12 +9,15 @@ import (
 
 	"github.com/goccy/go-yaml"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/openshift"
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
@@ -60,7 +63,7 @@ func TestNetworkPolicy(t *testing.T) {
 		assert.NoError(t, err)
 
 		params := manifests.Params{
-			Config: config.Config{},
+			Config: config.Config{OpenShiftRoutesAvailability: openshift.RoutesNotAvailable},
 			Log:    testLogger,
 			OtelCol: v1beta1.OpenTelemetryCollector{
 				ObjectMeta: metav1.ObjectMeta{
@@ -70,7 +73,8 @@ func TestNetworkPolicy(t *testing.T) {
 				Spec: v1beta1.OpenTelemetryCollectorSpec{
 					Config: cfg,
 					NetworkPolicy: v1beta1.NetworkPolicy{
-						Enabled: &trueValue,
+						Enabled:       &trueValue,
+						DisableEgress: true,
 					},
 				},
 			},
@@ -120,4 +124,374 @@ func TestNetworkPolicy(t *testing.T) {
 
 		assert.Equal(t, expected, actual)
 	})
+
+	t.Run("should return egress rules by default", func(t *testing.T) {
+		params := manifests.Params{
+			Config: config.Config{OpenShiftRoutesAvailability: openshift.RoutesNotAvailable},
+			Log:    testLogger,
+			OtelCol: v1beta1.OpenTelemetryCollector{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-collector",
+					Namespace: "default",
+				},
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					NetworkPolicy: v1beta1.NetworkPolicy{
+						Enabled: &trueValue,
+					},
+				},
+			},
+		}
+
+		actual, err := NetworkPolicy(params)
+		assert.NoError(t, err)
+		tcp, udp := corev1.ProtocolTCP, corev1.ProtocolUDP
+		assert.Equal(t, []networkingv1.NetworkPolicyEgressRule{
+			{
+				Ports: []networkingv1.NetworkPolicyPort{
+					{Protocol: &udp, Port: &intstr.IntOrString{Type: intstr.Int, IntVal: 53}},
+					{Protocol: &tcp, Port: &intstr.IntOrString{Type: intstr.Int, IntVal: 53}},
+				},
+			},
+		}, actual.Spec.Egress)
+		assert.Equal(t, []networkingv1.PolicyType{networkingv1.PolicyTypeIngress, networkingv1.PolicyTypeEgress}, actual.Spec.PolicyTypes)
+	})
+
+	t.Run("should not return egress rules when they are disabled", func(t *testing.T) {
+		params := manifests.Params{
+			Config: config.Config{OpenShiftRoutesAvailability: openshift.RoutesNotAvailable},
+			Log:    testLogger,
+			OtelCol: v1beta1.OpenTelemetryCollector{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-collector",
+					Namespace: "default",
+				},
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					NetworkPolicy: v1beta1.NetworkPolicy{
+						Enabled:       &trueValue,
+						DisableEgress: true,
+					},
+				},
+			},
+		}
+
+		actual, err := NetworkPolicy(params)
+		assert.NoError(t, err)
+		assert.Empty(t, actual.Spec.Egress)
+		assert.Equal(t, []networkingv1.PolicyType{networkingv1.PolicyTypeIngress}, actual.Spec.PolicyTypes)
+	})
+}
+
+func TestNetworkPolicyEgress(t *testing.T) {
+	tcp, udp := corev1.ProtocolTCP, corev1.ProtocolUDP
+	port := func(protocol corev1.Protocol, port int32) networkingv1.NetworkPolicyPort {
+		return networkingv1.NetworkPolicyPort{Protocol: &protocol, Port: &intstr.IntOrString{Type: intstr.Int, IntVal: port}}
+	}
+	dnsRule := networkingv1.NetworkPolicyEgressRule{
+		Ports: []networkingv1.NetworkPolicyPort{port(udp, 53), port(tcp, 53)},
+	}
+	apiServerRule := networkingv1.NetworkPolicyEgressRule{
+		To: []networkingv1.NetworkPolicyPeer{
+			{IPBlock: &networkingv1.IPBlock{CIDR: "10.0.0.1/32"}},
+		},
+		Ports: []networkingv1.NetworkPolicyPort{port(tcp, 6443)},
+	}
+	namespaceRule := func(namespace string) networkingv1.NetworkPolicyEgressRule {
+		return networkingv1.NetworkPolicyEgressRule{
+			To: []networkingv1.NetworkPolicyPeer{{
+				NamespaceSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{"kubernetes.io/metadata.name": namespace},
+				},
+			}},
+		}
+	}
+
+	for _, tt := range []struct {
+		name            string
+		config          string
+		internal        config.Internal
+		openShift       bool
+		targetAllocator bool
+		expected        []networkingv1.NetworkPolicyEgressRule
+	}{
+		{
+			name: "exporter endpoints",
+			config: `receivers:
+  otlp:
+    protocols:
+      grpc: {}
+exporters:
+  otlp:
+    endpoint: backend.example.com:4317
+  otlphttp:
+    endpoint: https://otlp.example.com/v1
+  otlp/gateway:
+    endpoint: gateway-collector.observability.svc.cluster.local:4317
+  otlp/local:
+    endpoint: gateway-collector:4317
+  otlp/ip:
+    endpoint: 192.168.1.10:4317
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      exporters: [otlp, otlphttp, otlp/gateway, otlp/local, otlp/ip]
+`,
+			expected: []networkingv1.NetworkPolicyEgressRule{
+				dnsRule,
+				{
+					To:    []networkingv1.NetworkPolicyPeer{{IPBlock: &networkingv1.IPBlock{CIDR: "192.168.1.10/32"}}},
+					Ports: []networkingv1.NetworkPolicyPort{port(tcp, 4317)},
+				},
+				{Ports: []networkingv1.NetworkPolicyPort{port(tcp, 4317)}},
+				namespaceRule("default"),
+				namespaceRule("observability"),
+				{Ports: []networkingv1.NetworkPolicyPort{port(tcp, 443)}},
+			},
+		},
+		{
+			name: "host name endpoints",
+			config: `receivers:
+  otlp:
+    protocols:
+      grpc: {}
+exporters:
+  otlp:
+    endpoint: backend.example.com:4317
+  otlp/other:
+    endpoint: other.example.com:4317
+  otlphttp:
+    endpoint: https://otlp.example.com
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      exporters: [otlp, otlp/other, otlphttp]
+`,
+			// a host name can't be selected, so the ports of the endpoints are allowed to any address
+			expected: []networkingv1.NetworkPolicyEgressRule{
+				dnsRule,
+				{Ports: []networkingv1.NetworkPolicyPort{port(tcp, 4317)}},
+				{Ports: []networkingv1.NetworkPolicyPort{port(tcp, 443)}},
+			},
+		},
+		{
+			name: "host name endpoint with an unknown port",
+			config: `receivers:
+  otlp:
+    protocols:
+      grpc: {}
+exporters:
+  otlp:
+    endpoint: backend.example.com:${env:BACKEND_PORT}
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      exporters: [otlp]
+`,
+			expected: []networkingv1.NetworkPolicyEgressRule{{}},
+		},
+		{
+			name: "kubernetes API",
+			config: `receivers:
+  k8s_cluster: {}
+processors:
+  k8sattributes: {}
+exporters:
+  debug: {}
+service:
+  pipelines:
+    metrics:
+      receivers: [k8s_cluster]
+      processors: [k8sattributes]
+      exporters: [debug]
+`,
+			internal: config.Internal{KubeAPIServerIPs: []string{"10.0.0.1"}, KubeAPIServerPort: 6443},
+			expected: []networkingv1.NetworkPolicyEgressRule{dnsRule, apiServerRule},
+		},
+		{
+			name: "kubernetes API without the discovered API server",
+			config: `receivers:
+  k8s_events: {}
+exporters:
+  debug: {}
+service:
+  pipelines:
+    logs:
+      receivers: [k8s_events]
+      exporters: [debug]
+`,
+			expected: []networkingv1.NetworkPolicyEgressRule{{}},
+		},
+		{
+			name: "exporter without an endpoint",
+			config: `receivers:
+  otlp:
+    protocols:
+      grpc: {}
+exporters:
+  datadog:
+    api:
+      key: secret
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      exporters: [datadog]
+`,
+			expected: []networkingv1.NetworkPolicyEgressRule{{}},
+		},
+		{
+			name: "receiver with unknown destinations",
+			config: `receivers:
+  kafka:
+    brokers: [kafka.example.com:9092]
+exporters:
+  debug: {}
+service:
+  pipelines:
+    logs:
+      receivers: [kafka]
+      exporters: [debug]
+`,
+			expected: []networkingv1.NetworkPolicyEgressRule{{}},
+		},
+		{
+			name: "extension with unknown destinations",
+			config: `receivers:
+  otlp:
+    protocols:
+      grpc: {}
+extensions:
+  oauth2client:
+    token_url: https://auth.example.com/oauth2/token
+  pprof: {}
+exporters:
+  otlp:
+    endpoint: 192.168.1.10:4317
+    auth:
+      authenticator: oauth2client
+service:
+  extensions: [oauth2client, pprof]
+  pipelines:
+    traces:
+      receivers: [otlp]
+      exporters: [otlp]
+`,
+			expected: []networkingv1.NetworkPolicyEgressRule{{}},
+		},
+		{
+			name: "local extensions and processors",
+			config: `receivers:
+  otlp:
+    protocols:
+      grpc: {}
+      http: {}
+  zipkin: {}
+extensions:
+  health_check: {}
+  pprof: {}
+processors:
+  batch: {}
+  memory_limiter: {}
+exporters:
+  otlp:
+    endpoint: 192.168.1.10:4317
+service:
+  extensions: [health_check, pprof]
+  pipelines:
+    traces:
+      receivers: [otlp, zipkin]
+      processors: [memory_limiter, batch]
+      exporters: [otlp]
+`,
+			expected: []networkingv1.NetworkPolicyEgressRule{
+				dnsRule,
+				{
+					To:    []networkingv1.NetworkPolicyPeer{{IPBlock: &networkingv1.IPBlock{CIDR: "192.168.1.10/32"}}},
+					Ports: []networkingv1.NetworkPolicyPort{port(tcp, 4317)},
+				},
+			},
+		},
+		{
+			name: "target allocator",
+			config: `receivers:
+  prometheus:
+    config:
+      scrape_configs: []
+exporters:
+  debug: {}
+service:
+  pipelines:
+    metrics:
+      receivers: [prometheus]
+      exporters: [debug]
+`,
+			targetAllocator: true,
+			expected: []networkingv1.NetworkPolicyEgressRule{
+				dnsRule,
+				{To: []networkingv1.NetworkPolicyPeer{{NamespaceSelector: &metav1.LabelSelector{}}}},
+			},
+		},
+		{
+			name: "own telemetry",
+			config: `receivers:
+  otlp:
+    protocols:
+      grpc: {}
+exporters:
+  debug: {}
+service:
+  telemetry:
+    logs:
+      processors:
+        - batch:
+            exporter:
+              otlp:
+                protocol: grpc
+                endpoint: https://telemetry.example.com:4317
+  pipelines:
+    traces:
+      receivers: [otlp]
+      exporters: [debug]
+`,
+			openShift: true,
+			expected: []networkingv1.NetworkPolicyEgressRule{
+				{Ports: []networkingv1.NetworkPolicyPort{port(udp, 53), port(tcp, 53), port(udp, 5353), port(tcp, 5353)}},
+				{Ports: []networkingv1.NetworkPolicyPort{port(tcp, 4317)}},
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := v1beta1.Config{}
+			require.NoError(t, yaml.Unmarshal([]byte(tt.config), &cfg))
+			enabled := true
+			routes := openshift.RoutesNotAvailable
+			if tt.openShift {
+				routes = openshift.RoutesAvailable
+			}
+			params := manifests.Params{
+				Config: config.Config{OpenShiftRoutesAvailability: routes, Internal: tt.internal},
+				Log:    testLogger,
+				OtelCol: v1beta1.OpenTelemetryCollector{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "test-collector",
+						Namespace: "default",
+					},
+					Spec: v1beta1.OpenTelemetryCollectorSpec{
+						Config:        cfg,
+						NetworkPolicy: v1beta1.NetworkPolicy{Enabled: &enabled},
+					},
+				},
+			}
+			if tt.targetAllocator {
+				params.TargetAllocator = &v1alpha1.TargetAllocator{}
+			}
+
+			actual, err := NetworkPolicy(params)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, actual.Spec.Egress)
+			assert.Equal(t, []networkingv1.PolicyType{networkingv1.PolicyTypeIngress, networkingv1.PolicyTypeEgress}, actual.Spec.PolicyTypes)
+		})
+	}
 }
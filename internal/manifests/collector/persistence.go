@@ -0,0 +1,266 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package collector
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/components"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+	"github.com/open-telemetry/opentelemetry-operator/internal/otelconfig"
+)
+
+const (
+	// collectorUserID is the user the collector images run as.
+	collectorUserID = 10001
+	// collectorGroupID is the group of the user the collector images run as.
+	collectorGroupID = 10001
+
+	storageInitContainerName = "otc-storage-init"
+
+	defaultPersistenceHostPath = "/var/lib/otelcol"
+
+	fileStorageExtension = "file_storage"
+)
+
+var defaultPersistenceSize = resource.MustParse("1Gi")
+
+// storageInitResources are the resources of the init container changing the owner of the hostPath directories.
+var storageInitResources = corev1.ResourceRequirements{
+	Requests: corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("10m"),
+		corev1.ResourceMemory: resource.MustParse("16Mi"),
+	},
+	Limits: corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("100m"),
+		corev1.ResourceMemory: resource.MustParse("32Mi"),
+	},
+}
+
+// storageVolume is a volume mounted at a directory of a component storing its data.
+type storageVolume struct {
+	name string
+	// key identifies the directory among the ones of the collector, e.g. file-storage-queue.
+	key  string
+	path string
+}
+
+// persists returns whether storage is provisioned for the storage directories of the collector.
+func persists(otelcol v1beta1.OpenTelemetryCollector) bool {
+	return otelcol.Spec.Persistence != nil && otelcol.Spec.Persistence.Enabled && otelcol.Spec.Mode != v1beta1.ModeSidecar
+}
+
+// syncsStorage returns whether the operator sets fsync in the file_storage extensions of the collector.
+func syncsStorage(otelcol v1beta1.OpenTelemetryCollector) bool {
+	return persists(otelcol) && otelcol.Spec.Persistence.Fsync
+}
+
+// applyFsync sets fsync in the file_storage extensions enabled in the service, unless they configure it.
+func applyFsync(cfg *v1beta1.Config) {
+	if cfg.Extensions == nil {
+		return
+	}
+	for _, name := range cfg.Service.Extensions {
+		if components.ComponentType(name) != fileStorageExtension {
+			continue
+		}
+		extension, _ := cfg.Extensions.Object[name].(map[string]any)
+		if extension == nil {
+			extension = map[string]any{}
+		}
+		if _, ok := extension["fsync"]; !ok {
+			extension["fsync"] = true
+		}
+		cfg.Extensions.Object[name] = extension
+	}
+}
+
+// storageVolumes returns the volumes to mount at the storage directories of the components of the collector. The
+// directories the user already mounts a volume at are left alone.
+func storageVolumes(otelcol v1beta1.OpenTelemetryCollector) []storageVolume {
+	if !persists(otelcol) {
+		return nil
+	}
+	directories, err := otelconfig.GetStorageDirectories(&otelcol.Spec.Config, logr.Discard())
+	if err != nil {
+		return nil
+	}
+	var volumes []storageVolume
+	seen := map[string]int{}
+	for _, directory := range directories {
+		if slices.ContainsFunc(otelcol.Spec.VolumeMounts, func(m corev1.VolumeMount) bool { return filepath.Clean(m.MountPath) == filepath.Clean(directory.Path) }) ||
+			slices.ContainsFunc(volumes, func(v storageVolume) bool { return v.path == directory.Path }) {
+			continue
+		}
+		// the second and next directories of a component are suffixed by their index
+		key := naming.DNSName(directory.Component)
+		if n := seen[directory.Component]; n > 0 {
+			key = fmt.Sprintf("%s-%d", key, n)
+		}
+		seen[directory.Component]++
+		volumes = append(volumes, storageVolume{name: naming.StorageVolume(key), key: key, path: directory.Path})
+	}
+	return volumes
+}
+
+// storagePodVolumes returns the pod volumes backing the storage directories. In the statefulset mode, the volumes come
+// from the volumeClaimTemplates instead.
+func storagePodVolumes(otelcol v1beta1.OpenTelemetryCollector) []corev1.Volume {
+	var volumes []corev1.Volume
+	for _, volume := range storageVolumes(otelcol) {
+		switch otelcol.Spec.Mode {
+		case v1beta1.ModeDeployment:
+			volumes = append(volumes, corev1.Volume{
+				Name: volume.name,
+				VolumeSource: corev1.VolumeSource{
+					EmptyDir: &corev1.EmptyDirVolumeSource{SizeLimit: otelcol.Spec.Persistence.Size},
+				},
+			})
+		case v1beta1.ModeDaemonSet:
+			hostPath := otelcol.Spec.Persistence.HostPath
+			if hostPath == "" {
+				hostPath = defaultPersistenceHostPath
+			}
+			volumes = append(volumes, corev1.Volume{
+				Name: volume.name,
+				VolumeSource: corev1.VolumeSource{
+					HostPath: &corev1.HostPathVolumeSource{
+						Path: filepath.Join(hostPath, otelcol.Namespace, otelcol.Name, volume.key),
+						Type: ptr.To(corev1.HostPathDirectoryOrCreate),
+					},
+				},
+			})
+		}
+	}
+	return volumes
+}
+
+// storageVolumeClaimTemplates returns the claims of the volumes backing the storage directories in the statefulset
+// mode.
+func storageVolumeClaimTemplates(otelcol v1beta1.OpenTelemetryCollector) []corev1.PersistentVolumeClaim {
+	if otelcol.Spec.Mode != v1beta1.ModeStatefulSet {
+		return nil
+	}
+	var claims []corev1.PersistentVolumeClaim
+	for _, volume := range storageVolumes(otelcol) {
+		size := defaultPersistenceSize
+		if otelcol.Spec.Persistence.Size != nil {
+			size = *otelcol.Spec.Persistence.Size
+		}
+		claims = append(claims, corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: volume.name},
+			Spec: corev1.PersistentVolumeClaimSpec{
+				AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+				Resources: corev1.VolumeResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceStorage: size},
+				},
+				StorageClassName: otelcol.Spec.Persistence.StorageClassName,
+			},
+		})
+	}
+	return claims
+}
+
+func storageVolumeMounts(otelcol v1beta1.OpenTelemetryCollector) []corev1.VolumeMount {
+	var mounts []corev1.VolumeMount
+	for _, volume := range storageVolumes(otelcol) {
+		mounts = append(mounts, corev1.VolumeMount{Name: volume.name, MountPath: volume.path})
+	}
+	return mounts
+}
+
+// storageInitContainers returns the init container handing the hostPath volumes over to the collector user in the
+// daemonset mode, when spec.persistence.chownHostPath is set. Kubernetes creates their directories owned by root and,
+// unlike the other volumes, doesn't apply the fsGroup to them.
+func storageInitContainers(cfg config.Config, otelcol v1beta1.OpenTelemetryCollector) []corev1.Container {
+	mounts := storageVolumeMounts(otelcol)
+	if otelcol.Spec.Mode != v1beta1.ModeDaemonSet || len(mounts) == 0 || !otelcol.Spec.Persistence.ChownHostPath {
+		return nil
+	}
+	user, group := collectorOwner(otelcol)
+	if user == 0 {
+		return nil
+	}
+	command := []string{"chown", fmt.Sprintf("%d:%d", user, group)}
+	for _, mount := range mounts {
+		command = append(command, mount.MountPath)
+	}
+	return []corev1.Container{{
+		Name:            storageInitContainerName,
+		Image:           cfg.StorageInitImage,
+		Command:         command,
+		VolumeMounts:    mounts,
+		Resources:       *storageInitResources.DeepCopy(),
+		SecurityContext: storageInitSecurityContext(otelcol),
+	}}
+}
+
+// storageInitSecurityContext returns the security context of the storage init container: the one of the collector
+// container, run as root to be allowed to change the owner of the directories.
+func storageInitSecurityContext(otelcol v1beta1.OpenTelemetryCollector) *corev1.SecurityContext {
+	securityContext := &corev1.SecurityContext{}
+	if otelcol.Spec.SecurityContext != nil {
+		securityContext = otelcol.Spec.SecurityContext.DeepCopy()
+	}
+	securityContext.RunAsUser = ptr.To[int64](0)
+	securityContext.RunAsNonRoot = ptr.To(false)
+	// the capabilities dropped for the collector, e.g. ALL, may include the one needed to change the owner
+	if securityContext.Capabilities != nil && !slices.Contains(securityContext.Capabilities.Add, "CHOWN") {
+		securityContext.Capabilities.Add = append(securityContext.Capabilities.Add, "CHOWN")
+	}
+	return securityContext
+}
+
+// collectorOwner returns the user and group the collector container runs as. Its security context takes precedence
+// over the pod's one.
+func collectorOwner(otelcol v1beta1.OpenTelemetryCollector) (int64, int64) {
+	user, group := int64(collectorUserID), int64(collectorGroupID)
+	if podSecurityContext := otelcol.Spec.PodSecurityContext; podSecurityContext != nil {
+		if podSecurityContext.RunAsUser != nil {
+			user = *podSecurityContext.RunAsUser
+		}
+		if podSecurityContext.RunAsGroup != nil {
+			group = *podSecurityContext.RunAsGroup
+		}
+	}
+	if securityContext := otelcol.Spec.SecurityContext; securityContext != nil {
+		if securityContext.RunAsUser != nil {
+			user = *securityContext.RunAsUser
+		}
+		if securityContext.RunAsGroup != nil {
+			group = *securityContext.RunAsGroup
+		}
+	}
+	return user, group
+}
+
+// podSecurityContext returns the security context of the collector pods. When storage is provisioned, the volumes
+// are owned by the group of the collector user, unless the user sets another group.
+func podSecurityContext(otelcol v1beta1.OpenTelemetryCollector) *corev1.PodSecurityContext {
+	if len(storageVolumes(otelcol)) == 0 {
+		return otelcol.Spec.PodSecurityContext
+	}
+	securityContext := &corev1.PodSecurityContext{}
+	if otelcol.Spec.PodSecurityContext != nil {
+		securityContext = otelcol.Spec.PodSecurityContext.DeepCopy()
+	}
+	if securityContext.FSGroup == nil {
+		securityContext.FSGroup = ptr.To[int64](collectorGroupID)
+	}
+	if securityContext.FSGroupChangePolicy == nil {
+		// the ownership of the volumes is only changed once, rather than on every start of the pods
+		securityContext.FSGroupChangePolicy = ptr.To(corev1.FSGroupChangeOnRootMismatch)
+	}
+	return securityContext
+}
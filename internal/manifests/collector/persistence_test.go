@@ -0,0 +1,268 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package collector
+
+import (
+	"testing"
+
+	go_yaml "github.com/goccy/go-yaml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+)
+
+func persistenceParams(t *testing.T, mode v1beta1.Mode, persistence *v1beta1.PersistenceSpec) manifests.Params {
+	collectorCfg := v1beta1.Config{}
+	require.NoError(t, go_yaml.Unmarshal([]byte(`receivers:
+  otlp:
+    protocols:
+      grpc: {}
+exporters:
+  otlp:
+    endpoint: backend:4317
+    sending_queue:
+      storage: file_storage/queue
+extensions:
+  file_storage/queue:
+    directory: /var/lib/otelcol/queue
+    compaction:
+      directory: /tmp/compaction
+service:
+  extensions: [file_storage/queue]
+  pipelines:
+    traces:
+      receivers: [otlp]
+      exporters: [otlp]
+`), &collectorCfg))
+	return manifests.Params{
+		Config: config.New(),
+		OtelCol: v1beta1.OpenTelemetryCollector{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-instance", Namespace: "observability"},
+			Spec: v1beta1.OpenTelemetryCollectorSpec{
+				Mode:        mode,
+				Config:      collectorCfg,
+				Persistence: persistence,
+			},
+		},
+		Log: testLogger,
+	}
+}
+
+func TestPersistenceDeployment(t *testing.T) {
+	size := resource.MustParse("512Mi")
+	params := persistenceParams(t, v1beta1.ModeDeployment, &v1beta1.PersistenceSpec{Enabled: true, Size: &size})
+
+	d, err := Deployment(params)
+	require.NoError(t, err)
+
+	volumes := d.Spec.Template.Spec.Volumes
+	require.Len(t, volumes, 3)
+	assert.Equal(t, corev1.Volume{
+		Name:         "otc-storage-file-storage-queue",
+		VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{SizeLimit: &size}},
+	}, volumes[1])
+	assert.Equal(t, "otc-storage-file-storage-queue-1", volumes[2].Name)
+
+	assert.Subset(t, d.Spec.Template.Spec.Containers[0].VolumeMounts, []corev1.VolumeMount{
+		{Name: "otc-storage-file-storage-queue", MountPath: "/var/lib/otelcol/queue"},
+		{Name: "otc-storage-file-storage-queue-1", MountPath: "/tmp/compaction"},
+	})
+	assert.Equal(t, &corev1.PodSecurityContext{
+		FSGroup:             ptr.To[int64](10001),
+		FSGroupChangePolicy: ptr.To(corev1.FSGroupChangeOnRootMismatch),
+	}, d.Spec.Template.Spec.SecurityContext)
+}
+
+func TestPersistenceStatefulSet(t *testing.T) {
+	params := persistenceParams(t, v1beta1.ModeStatefulSet, &v1beta1.PersistenceSpec{Enabled: true, StorageClassName: ptr.To("fast")})
+	params.OtelCol.Spec.PodSecurityContext = &corev1.PodSecurityContext{FSGroup: ptr.To[int64](2000)}
+
+	ss, err := StatefulSet(params)
+	require.NoError(t, err)
+
+	for _, volume := range ss.Spec.Template.Spec.Volumes {
+		assert.NotContains(t, volume.Name, "otc-storage", "the volumes come from the volumeClaimTemplates")
+	}
+	claims := ss.Spec.VolumeClaimTemplates
+	require.Len(t, claims, 2)
+	assert.Equal(t, "otc-storage-file-storage-queue", claims[0].Name)
+	assert.Equal(t, []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce}, claims[0].Spec.AccessModes)
+	assert.Equal(t, resource.MustParse("1Gi"), claims[0].Spec.Resources.Requests[corev1.ResourceStorage])
+	assert.Equal(t, ptr.To("fast"), claims[0].Spec.StorageClassName)
+	assert.Contains(t, ss.Spec.Template.Spec.Containers[0].VolumeMounts,
+		corev1.VolumeMount{Name: "otc-storage-file-storage-queue", MountPath: "/var/lib/otelcol/queue"})
+
+	// the group set by the user is kept
+	assert.Equal(t, ptr.To[int64](2000), ss.Spec.Template.Spec.SecurityContext.FSGroup)
+	assert.Equal(t, ptr.To(corev1.FSGroupChangeOnRootMismatch), ss.Spec.Template.Spec.SecurityContext.FSGroupChangePolicy)
+	assert.Nil(t, params.OtelCol.Spec.PodSecurityContext.FSGroupChangePolicy, "the spec must not be modified")
+}
+
+func TestPersistenceDaemonSet(t *testing.T) {
+	params := persistenceParams(t, v1beta1.ModeDaemonSet, &v1beta1.PersistenceSpec{Enabled: true, HostPath: "/data/otelcol", ChownHostPath: true})
+	// the user already provides the compaction directory
+	params.OtelCol.Spec.VolumeMounts = []corev1.VolumeMount{{Name: "scratch", MountPath: "/tmp/compaction/"}}
+
+	ds, err := DaemonSet(params)
+	require.NoError(t, err)
+
+	var storageVolumes []corev1.Volume
+	for _, volume := range ds.Spec.Template.Spec.Volumes {
+		if volume.Name != "otc-internal" {
+			storageVolumes = append(storageVolumes, volume)
+		}
+	}
+	assert.Equal(t, []corev1.Volume{{
+		Name: "otc-storage-file-storage-queue",
+		VolumeSource: corev1.VolumeSource{
+			HostPath: &corev1.HostPathVolumeSource{
+				Path: "/data/otelcol/observability/my-instance/file-storage-queue",
+				Type: ptr.To(corev1.HostPathDirectoryOrCreate),
+			},
+		},
+	}}, storageVolumes)
+
+	// the directories created owned by root are handed over to the collector user
+	assert.Equal(t, []corev1.Container{{
+		Name:         "otc-storage-init",
+		Image:        "docker.io/library/busybox:1.37",
+		Command:      []string{"chown", "10001:10001", "/var/lib/otelcol/queue"},
+		VolumeMounts: []corev1.VolumeMount{{Name: "otc-storage-file-storage-queue", MountPath: "/var/lib/otelcol/queue"}},
+		Resources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("10m"), corev1.ResourceMemory: resource.MustParse("16Mi")},
+			Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m"), corev1.ResourceMemory: resource.MustParse("32Mi")},
+		},
+		SecurityContext: &corev1.SecurityContext{
+			RunAsUser:    ptr.To[int64](0),
+			RunAsNonRoot: ptr.To(false),
+		},
+	}}, ds.Spec.Template.Spec.InitContainers)
+}
+
+func TestPersistenceDaemonSetOwner(t *testing.T) {
+	for _, tt := range []struct {
+		name               string
+		podSecurityContext *corev1.PodSecurityContext
+		chownHostPath      *bool
+		securityContext    *corev1.SecurityContext
+		initContainers     []corev1.Container
+		expectedCommand    []string
+	}{
+		{
+			name:          "not enabled",
+			chownHostPath: ptr.To(false),
+		},
+		{
+			name:               "pod user",
+			podSecurityContext: &corev1.PodSecurityContext{RunAsUser: ptr.To[int64](1000), RunAsGroup: ptr.To[int64](2000)},
+			expectedCommand:    []string{"chown", "1000:2000", "/var/lib/otelcol/queue", "/tmp/compaction"},
+		},
+		{
+			name:               "container user",
+			podSecurityContext: &corev1.PodSecurityContext{RunAsUser: ptr.To[int64](1000), RunAsGroup: ptr.To[int64](2000)},
+			securityContext:    &corev1.SecurityContext{RunAsUser: ptr.To[int64](3000)},
+			expectedCommand:    []string{"chown", "3000:2000", "/var/lib/otelcol/queue", "/tmp/compaction"},
+		},
+		{
+			name:            "root",
+			securityContext: &corev1.SecurityContext{RunAsUser: ptr.To[int64](0)},
+		},
+		{
+			name:            "user init containers",
+			initContainers:  []corev1.Container{{Name: "init"}},
+			expectedCommand: []string{"chown", "10001:10001", "/var/lib/otelcol/queue", "/tmp/compaction"},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			params := persistenceParams(t, v1beta1.ModeDaemonSet, &v1beta1.PersistenceSpec{Enabled: true, ChownHostPath: ptr.Deref(tt.chownHostPath, true)})
+			params.OtelCol.Spec.PodSecurityContext = tt.podSecurityContext
+			params.OtelCol.Spec.SecurityContext = tt.securityContext
+			params.OtelCol.Spec.InitContainers = tt.initContainers
+
+			ds, err := DaemonSet(params)
+			require.NoError(t, err)
+
+			if tt.expectedCommand == nil {
+				assert.Empty(t, ds.Spec.Template.Spec.InitContainers)
+				return
+			}
+			require.Len(t, ds.Spec.Template.Spec.InitContainers, len(tt.initContainers)+1)
+			assert.Equal(t, tt.expectedCommand, ds.Spec.Template.Spec.InitContainers[0].Command)
+		})
+	}
+}
+
+func TestPersistenceDaemonSetSecurityContext(t *testing.T) {
+	params := persistenceParams(t, v1beta1.ModeDaemonSet, &v1beta1.PersistenceSpec{Enabled: true, ChownHostPath: true})
+	params.OtelCol.Spec.SecurityContext = &corev1.SecurityContext{
+		RunAsUser:                ptr.To[int64](1000),
+		AllowPrivilegeEscalation: ptr.To(false),
+		Capabilities:             &corev1.Capabilities{Drop: []corev1.Capability{"ALL"}},
+		SeccompProfile:           &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+	}
+
+	ds, err := DaemonSet(params)
+	require.NoError(t, err)
+
+	// the init container follows the collector's security context, but runs as root and may change the owner
+	require.Len(t, ds.Spec.Template.Spec.InitContainers, 1)
+	assert.Equal(t, &corev1.SecurityContext{
+		RunAsUser:                ptr.To[int64](0),
+		RunAsNonRoot:             ptr.To(false),
+		AllowPrivilegeEscalation: ptr.To(false),
+		Capabilities:             &corev1.Capabilities{Add: []corev1.Capability{"CHOWN"}, Drop: []corev1.Capability{"ALL"}},
+		SeccompProfile:           &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+	}, ds.Spec.Template.Spec.InitContainers[0].SecurityContext)
+	assert.Equal(t, ptr.To[int64](1000), params.OtelCol.Spec.SecurityContext.RunAsUser, "the spec must not be modified")
+	assert.Empty(t, params.OtelCol.Spec.SecurityContext.Capabilities.Add, "the spec must not be modified")
+}
+
+func TestPersistenceDisabled(t *testing.T) {
+	for _, tt := range []struct {
+		name        string
+		persistence *v1beta1.PersistenceSpec
+	}{
+		{name: "not set"},
+		{name: "disabled", persistence: &v1beta1.PersistenceSpec{}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			params := persistenceParams(t, v1beta1.ModeStatefulSet, tt.persistence)
+
+			ss, err := StatefulSet(params)
+			require.NoError(t, err)
+			assert.Empty(t, ss.Spec.VolumeClaimTemplates)
+			assert.Nil(t, ss.Spec.Template.Spec.SecurityContext)
+			for _, mount := range ss.Spec.Template.Spec.Containers[0].VolumeMounts {
+				assert.NotContains(t, mount.Name, "otc-storage")
+			}
+		})
+	}
+}
+
+func TestPersistenceFsync(t *testing.T) {
+	params := persistenceParams(t, v1beta1.ModeStatefulSet, &v1beta1.PersistenceSpec{Enabled: true, Fsync: true})
+	params.OtelCol.Spec.Config.Extensions.Object["file_storage/scratch"] = map[string]any{"directory": "/tmp/scratch", "fsync": false}
+	params.OtelCol.Spec.Config.Service.Extensions = append(params.OtelCol.Spec.Config.Service.Extensions, "file_storage/scratch")
+
+	configMap, err := ConfigMap(params)
+	require.NoError(t, err)
+	collectorCfg := map[string]any{}
+	require.NoError(t, go_yaml.Unmarshal([]byte(configMap.Data["collector.yaml"]), &collectorCfg))
+	extensions := collectorCfg["extensions"].(map[string]any)
+	assert.Equal(t, true, extensions["file_storage/queue"].(map[string]any)["fsync"])
+	// the setting of the user is kept
+	assert.Equal(t, false, extensions["file_storage/scratch"].(map[string]any)["fsync"])
+
+	// the pods reference the ConfigMap holding the injected setting
+	volumes := Volumes(params.Config, params.OtelCol, nil)
+	assert.Equal(t, configMap.Name, volumes[0].ConfigMap.Name)
+	assert.NotContains(t, params.OtelCol.Spec.Config.Extensions.Object["file_storage/queue"], "fsync", "the spec must not be modified")
+}
@@ -0,0 +1,153 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package collector
+
+import (
+	"fmt"
+
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/prometheus"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+)
+
+const (
+	// queueNearCapacityRatio is the fill ratio of an exporter's sending queue above which it's near capacity.
+	queueNearCapacityRatio = 0.8
+
+	alertRateWindow = "5m"
+)
+
+// PrometheusRule returns the PrometheusRule alerting on the collector's own metrics, when alerts are enabled.
+func PrometheusRule(params manifests.Params) (*monitoringv1.PrometheusRule, error) {
+	if !shouldCreatePrometheusRule(params) {
+		return nil, nil
+	}
+
+	name := naming.PrometheusRule(params.OtelCol.Name)
+	labels := manifestutils.Labels(params.OtelCol.ObjectMeta, name, params.OtelCol.Spec.Image, ComponentOpenTelemetryCollector, []string{})
+	// the extra labels let the rule be selected by the Prometheus instance scraping the ServiceMonitor
+	manifestutils.AddExtraLabels(&params.Log, labels, params.OtelCol.Spec.Observability.Metrics.ExtraLabels)
+
+	return &monitoringv1.PrometheusRule{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: params.OtelCol.Namespace,
+			Name:      name,
+			Labels:    labels,
+		},
+		Spec: monitoringv1.PrometheusRuleSpec{
+			Groups: []monitoringv1.RuleGroup{
+				{
+					Name:  "opentelemetry-collector",
+					Rules: collectorAlerts(params),
+				},
+			},
+		},
+	}, nil
+}
+
+func shouldCreatePrometheusRule(params manifests.Params) bool {
+	l := params.Log.WithValues(
+		"params.OtelCol.name", params.OtelCol.Name,
+		"params.OtelCol.namespace", params.OtelCol.Namespace,
+	)
+
+	switch {
+	case !params.OtelCol.Spec.Monitoring.EnableAlerts:
+		return false
+	case params.Config.PrometheusCRAvailability == prometheus.NotAvailable:
+		l.V(2).Info("Cannot enable PrometheusRule when prometheus CRDs are unavailable")
+		return false
+	case params.OtelCol.Spec.Mode == v1beta1.ModeSidecar:
+		l.V(2).Info("Using sidecar mode. PrometheusRule will not be created")
+		return false
+	}
+	return true
+}
+
+// collectorPodTargetLabels are the labels of the collector pods the ServiceMonitor copies to the scraped metrics when
+// the alerts or the dashboard are enabled.
+var collectorPodTargetLabels = []string{"app.kubernetes.io/name", "app.kubernetes.io/instance", "app.kubernetes.io/managed-by"}
+
+// monitoringEnabled returns whether the alerts or the dashboard of the collector are enabled.
+func monitoringEnabled(params manifests.Params) bool {
+	monitoring := params.OtelCol.Spec.Monitoring
+	return monitoring.EnableAlerts || (monitoring.Dashboard != nil && monitoring.Dashboard.Enabled)
+}
+
+// collectorLabelSelector returns the label matchers selecting the collector's own metrics by the instance label its
+// ServiceMonitor and PodMonitor copy from the collector pods, so that they don't match the pods of other workloads
+// sharing the collector's name prefix.
+func collectorLabelSelector(params manifests.Params) string {
+	instance := manifestutils.SelectorLabels(params.OtelCol.ObjectMeta, ComponentOpenTelemetryCollector)["app.kubernetes.io/instance"]
+	return fmt.Sprintf(`namespace=%q,app_kubernetes_io_instance=%q`, params.OtelCol.Namespace, instance)
+}
+
+// collectorAlerts returns the alerts on the metrics of the collector, labelled with the name of the collector they fire
+// for.
+func collectorAlerts(params manifests.Params) []monitoringv1.Rule {
+	selector := collectorLabelSelector(params)
+	alertLabels := map[string]string{
+		"severity":               "warning",
+		"opentelemetrycollector": params.OtelCol.Name,
+	}
+	collector := fmt.Sprintf("%s/%s", params.OtelCol.Namespace, params.OtelCol.Name)
+
+	return []monitoringv1.Rule{
+		{
+			Alert: "OpenTelemetryCollectorRefusedData",
+			Expr: intstr.FromString(fmt.Sprintf(
+				`sum by (namespace, pod, receiver) (rate({__name__=~"otelcol_receiver_refused_(spans|metric_points|log_records)(_total)?",%s}[%s])) > 0`,
+				selector, alertRateWindow)),
+			For:    ptr.To(monitoringv1.Duration("5m")),
+			Labels: alertLabels,
+			Annotations: map[string]string{
+				"summary":     fmt.Sprintf("The collector %s refuses data.", collector),
+				"description": "The receiver {{ $labels.receiver }} of the pod {{ $labels.pod }} refuses {{ $value | humanize }} items per second, which its clients have to retry or drop.",
+			},
+		},
+		{
+			Alert: "OpenTelemetryCollectorExportFailed",
+			Expr: intstr.FromString(fmt.Sprintf(
+				`sum by (namespace, pod, exporter) (rate({__name__=~"otelcol_exporter_send_failed_(spans|metric_points|log_records)(_total)?",%s}[%s])) > 0`,
+				selector, alertRateWindow)),
+			For:    ptr.To(monitoringv1.Duration("5m")),
+			Labels: alertLabels,
+			Annotations: map[string]string{
+				"summary":     fmt.Sprintf("The collector %s fails to export data.", collector),
+				"description": "The exporter {{ $labels.exporter }} of the pod {{ $labels.pod }} fails to send {{ $value | humanize }} items per second.",
+			},
+		},
+		{
+			Alert: "OpenTelemetryCollectorQueueNearCapacity",
+			Expr: intstr.FromString(fmt.Sprintf(
+				`max by (namespace, pod, exporter) (otelcol_exporter_queue_size{%s} / otelcol_exporter_queue_capacity{%s}) > %g`,
+				selector, selector, queueNearCapacityRatio)),
+			For:    ptr.To(monitoringv1.Duration("10m")),
+			Labels: alertLabels,
+			Annotations: map[string]string{
+				"summary":     fmt.Sprintf("The sending queue of an exporter of the collector %s is near capacity.", collector),
+				"description": "The sending queue of the exporter {{ $labels.exporter }} of the pod {{ $labels.pod }} is {{ $value | humanizePercentage }} full. The data is dropped once it's full.",
+			},
+		},
+		{
+			Alert: "OpenTelemetryCollectorMemoryLimiterDroppingData",
+			Expr: intstr.FromString(fmt.Sprintf(
+				`sum by (namespace, pod, processor) (rate({__name__=~"otelcol_processor_memory_limiter_(refused|dropped)_(spans|metric_points|log_records)(_total)?",%s}[%s])) > 0`,
+				selector, alertRateWindow)),
+			For:    ptr.To(monitoringv1.Duration("5m")),
+			Labels: alertLabels,
+			Annotations: map[string]string{
+				"summary":     fmt.Sprintf("The memory_limiter processor of the collector %s drops data.", collector),
+				"description": "The processor {{ $labels.processor }} of the pod {{ $labels.pod }} drops {{ $value | humanize }} items per second because the collector is running out of memory.",
+			},
+		},
+	}
+}
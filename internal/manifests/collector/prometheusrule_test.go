@@ -0,0 +1,70 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package collector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/prometheus"
+)
+
+func TestDesiredPrometheusRule(t *testing.T) {
+	params := deploymentParams()
+	params.OtelCol.Spec.Monitoring.EnableAlerts = true
+	params.OtelCol.Spec.Observability.Metrics.ExtraLabels = map[string]string{"release": "prometheus"}
+
+	actual, err := PrometheusRule(params)
+	require.NoError(t, err)
+	require.NotNil(t, actual)
+	assert.Equal(t, "test-collector", actual.Name)
+	assert.Equal(t, "default", actual.Namespace)
+	assert.Equal(t, "prometheus", actual.Labels["release"])
+	assert.Equal(t, "opentelemetry-collector", actual.Labels["app.kubernetes.io/component"])
+
+	require.Len(t, actual.Spec.Groups, 1)
+	rules := actual.Spec.Groups[0].Rules
+	var alerts []string
+	for _, rule := range rules {
+		alerts = append(alerts, rule.Alert)
+		assert.Contains(t, rule.Expr.String(), `namespace="default",app_kubernetes_io_instance="default.test"`, rule.Alert)
+		assert.Equal(t, "test", rule.Labels["opentelemetrycollector"])
+		assert.Equal(t, "warning", rule.Labels["severity"])
+	}
+	assert.Equal(t, []string{
+		"OpenTelemetryCollectorRefusedData",
+		"OpenTelemetryCollectorExportFailed",
+		"OpenTelemetryCollectorQueueNearCapacity",
+		"OpenTelemetryCollectorMemoryLimiterDroppingData",
+	}, alerts)
+	assert.Equal(t,
+		`max by (namespace, pod, exporter) (otelcol_exporter_queue_size{namespace="default",app_kubernetes_io_instance="default.test"} / otelcol_exporter_queue_capacity{namespace="default",app_kubernetes_io_instance="default.test"}) > 0.8`,
+		rules[2].Expr.String())
+}
+
+func TestPrometheusRuleNotCreated(t *testing.T) {
+	for _, tt := range []struct {
+		name         string
+		enableAlerts bool
+		mode         v1beta1.Mode
+		availability prometheus.Availability
+	}{
+		{name: "alerts disabled", mode: v1beta1.ModeDeployment, availability: prometheus.Available},
+		{name: "prometheus CRDs not available", enableAlerts: true, mode: v1beta1.ModeDeployment, availability: prometheus.NotAvailable},
+		{name: "sidecar", enableAlerts: true, mode: v1beta1.ModeSidecar, availability: prometheus.Available},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			params := paramsWithMode(tt.mode)
+			params.Config.PrometheusCRAvailability = tt.availability
+			params.OtelCol.Spec.Monitoring.EnableAlerts = tt.enableAlerts
+
+			actual, err := PrometheusRule(params)
+			require.NoError(t, err)
+			assert.Nil(t, actual)
+		})
+	}
+}
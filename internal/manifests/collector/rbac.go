@@ -6,10 +6,15 @@ package collector
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 
 	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	autoRBAC "github.com/open-telemetry/opentelemetry-operator/internal/autodetect/rbac"
+	"github.com/open-telemetry/opentelemetry-operator/internal/components"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
 	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
@@ -17,8 +22,54 @@ import (
 	"github.com/open-telemetry/opentelemetry-operator/internal/rbac"
 )
 
-func ClusterRole(params manifests.Params) (*rbacv1.ClusterRole, error) {
+// clusterRoleRules returns the rules of the collector's ClusterRole. When the operator can't create namespaced RBAC
+// resources, the rules the components need within some namespaces are granted cluster-wide.
+func clusterRoleRules(params manifests.Params) ([]rbacv1.PolicyRule, error) {
 	rules, err := otelconfig.GetAllRbacRules(&params.OtelCol.Spec.Config, params.Log)
+	if err != nil || params.Config.CreateRoleRBACPermissions == autoRBAC.Available {
+		return rules, err
+	}
+	roleRules, err := roleRules(params)
+	if err != nil {
+		return nil, err
+	}
+	for _, namespaceRules := range roleRules {
+		for _, rule := range namespaceRules.Rules {
+			// the same rules are often needed in several namespaces
+			if !slices.ContainsFunc(rules, func(r rbacv1.PolicyRule) bool { return equality.Semantic.DeepEqual(r, rule) }) {
+				rules = append(rules, rule)
+			}
+		}
+	}
+	return rules, nil
+}
+
+// roleRules returns the rules the components need within some namespaces, with the namespace of the collector set
+// where the components refer to it.
+func roleRules(params manifests.Params) ([]components.RoleRules, error) {
+	roleRules, err := otelconfig.GetAllRoleRules(&params.OtelCol.Spec.Config, params.Log)
+	if err != nil {
+		return nil, err
+	}
+	var resolved []components.RoleRules
+	for _, namespaceRules := range roleRules {
+		namespace := namespaceRules.Namespace
+		if namespace == "" {
+			namespace = params.OtelCol.Namespace
+		}
+		index := slices.IndexFunc(resolved, func(r components.RoleRules) bool { return r.Namespace == namespace })
+		if index < 0 {
+			resolved = append(resolved, components.RoleRules{Namespace: namespace})
+			index = len(resolved) - 1
+		}
+		resolved[index].Rules = append(resolved[index].Rules, namespaceRules.Rules...)
+	}
+	slices.SortFunc(resolved, func(a, b components.RoleRules) int { return strings.Compare(a.Namespace, b.Namespace) })
+	return resolved, nil
+}
+
+func ClusterRole(params manifests.Params) (*rbacv1.ClusterRole, error) {
+	rules, err := clusterRoleRules(params)
 	if err != nil {
 		return nil, err
 	} else if len(rules) == 0 {
@@ -44,7 +95,7 @@ func ClusterRole(params manifests.Params) (*rbacv1.ClusterRole, error) {
 }
 
 func ClusterRoleBinding(params manifests.Params) (*rbacv1.ClusterRoleBinding, error) {
-	rules, err := otelconfig.GetAllRbacRules(&params.OtelCol.Spec.Config, params.Log)
+	rules, err := clusterRoleRules(params)
 	if err != nil {
 		return nil, err
 	} else if len(rules) == 0 {
@@ -80,6 +131,84 @@ func ClusterRoleBinding(params manifests.Params) (*rbacv1.ClusterRoleBinding, er
 	}, nil
 }
 
+// Roles returns the Roles granting the collector the permissions its components need within some namespaces, one per
+// namespace. The Roles outside the namespace of the collector can't be owned by it, they're deleted by their labels.
+func Roles(params manifests.Params) ([]*rbacv1.Role, error) {
+	if params.Config.CreateRoleRBACPermissions != autoRBAC.Available {
+		return nil, nil
+	}
+	roleRules, err := roleRules(params)
+	if err != nil || len(roleRules) == 0 {
+		return nil, err
+	}
+
+	name := naming.Role(params.OtelCol.Name, params.OtelCol.Namespace)
+	labels := manifestutils.Labels(params.OtelCol.ObjectMeta, name, params.OtelCol.Spec.Image, ComponentOpenTelemetryCollector, params.Config.LabelsFilter)
+
+	annotations, err := manifestutils.Annotations(params.OtelCol, params.Config.AnnotationsFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	var roles []*rbacv1.Role
+	for _, namespaceRules := range roleRules {
+		roles = append(roles, &rbacv1.Role{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Namespace:   namespaceRules.Namespace,
+				Annotations: annotations,
+				Labels:      labels,
+			},
+			Rules: namespaceRules.Rules,
+		})
+	}
+	return roles, nil
+}
+
+// RoleBindings returns the RoleBindings binding the Roles of the collector to its service account.
+func RoleBindings(params manifests.Params) ([]*rbacv1.RoleBinding, error) {
+	if params.Config.CreateRoleRBACPermissions != autoRBAC.Available {
+		return nil, nil
+	}
+	roleRules, err := roleRules(params)
+	if err != nil || len(roleRules) == 0 {
+		return nil, err
+	}
+
+	name := naming.RoleBinding(params.OtelCol.Name, params.OtelCol.Namespace)
+	labels := manifestutils.Labels(params.OtelCol.ObjectMeta, name, params.OtelCol.Spec.Image, ComponentOpenTelemetryCollector, params.Config.LabelsFilter)
+
+	annotations, err := manifestutils.Annotations(params.OtelCol, params.Config.AnnotationsFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	var roleBindings []*rbacv1.RoleBinding
+	for _, namespaceRules := range roleRules {
+		roleBindings = append(roleBindings, &rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Namespace:   namespaceRules.Namespace,
+				Annotations: annotations,
+				Labels:      labels,
+			},
+			Subjects: []rbacv1.Subject{
+				{
+					Kind:      "ServiceAccount",
+					Name:      ServiceAccountName(params.OtelCol),
+					Namespace: params.OtelCol.Namespace,
+				},
+			},
+			RoleRef: rbacv1.RoleRef{
+				Kind:     "Role",
+				Name:     naming.Role(params.OtelCol.Name, params.OtelCol.Namespace),
+				APIGroup: "rbac.authorization.k8s.io",
+			},
+		})
+	}
+	return roleBindings, nil
+}
+
 func CheckRbacRules(params manifests.Params, saName string) ([]string, error) {
 	ctx := context.Background()
 
@@ -87,6 +216,10 @@ func CheckRbacRules(params manifests.Params, saName string) ([]string, error) {
 	if err != nil {
 		return nil, err
 	}
+	roleRules, err := roleRules(params)
+	if err != nil {
+		return nil, err
+	}
 
 	r := []*rbacv1.PolicyRule{}
 
@@ -94,9 +227,22 @@ func CheckRbacRules(params manifests.Params, saName string) ([]string, error) {
 		r = append(r, &rule)
 	}
 
-	if subjectAccessReviews, err := params.Reviewer.CheckPolicyRules(ctx, saName, params.OtelCol.Namespace, r...); err != nil {
+	subjectAccessReviews, err := params.Reviewer.CheckPolicyRules(ctx, saName, params.OtelCol.Namespace, r...)
+	if err != nil {
 		return nil, fmt.Errorf("%s: %w", "unable to check rbac rules", err)
-	} else if allowed, deniedReviews := rbac.AllSubjectAccessReviewsAllowed(subjectAccessReviews); !allowed {
+	}
+	for _, namespaceRules := range roleRules {
+		r = []*rbacv1.PolicyRule{}
+		for _, rule := range namespaceRules.Rules {
+			r = append(r, &rule)
+		}
+		namespaceReviews, err := params.Reviewer.CheckNamespacedPolicyRules(ctx, saName, params.OtelCol.Namespace, namespaceRules.Namespace, r...)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", "unable to check rbac rules", err)
+		}
+		subjectAccessReviews = append(subjectAccessReviews, namespaceReviews...)
+	}
+	if allowed, deniedReviews := rbac.AllSubjectAccessReviewsAllowed(subjectAccessReviews); !allowed {
 		return rbac.WarningsGroupedByResource(deniedReviews), nil
 	}
 	return nil, nil
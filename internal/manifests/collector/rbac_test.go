@@ -9,6 +9,9 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	rbacv1 "k8s.io/api/rbac/v1"
+
+	autoRBAC "github.com/open-telemetry/opentelemetry-operator/internal/autodetect/rbac"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
 )
 
 func TestDesiredClusterRoles(t *testing.T) {
@@ -155,6 +158,22 @@ func TestDesiredClusterRoles(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc:       "prometheus receiver - kubernetes_sd without namespaced permissions",
+			configPath: "testdata/rbac_prometheus_kubernetes_sd.yaml",
+			expectedRules: []rbacv1.PolicyRule{
+				{
+					APIGroups: []string{""},
+					Resources: []string{"nodes"},
+					Verbs:     []string{"get", "list", "watch"},
+				},
+				{
+					APIGroups: []string{""},
+					Resources: []string{"pods"},
+					Verbs:     []string{"get", "list", "watch"},
+				},
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -184,3 +203,56 @@ func TestDesiredClusterRolBinding(t *testing.T) {
 	require.NoError(t, err)
 	assert.NotNil(t, crb)
 }
+
+func TestDesiredRoles(t *testing.T) {
+	cfg := config.New()
+	cfg.CreateRBACPermissions = autoRBAC.Available
+	cfg.CreateRoleRBACPermissions = autoRBAC.Available
+	params, err := newParams("", "testdata/rbac_prometheus_kubernetes_sd.yaml", &cfg)
+	require.NoError(t, err)
+
+	cr, err := ClusterRole(params)
+	require.NoError(t, err)
+	assert.Equal(t, []rbacv1.PolicyRule{
+		{
+			APIGroups: []string{""},
+			Resources: []string{"nodes"},
+			Verbs:     []string{"get", "list", "watch"},
+		},
+	}, cr.Rules)
+
+	roles, err := Roles(params)
+	require.NoError(t, err)
+	require.Len(t, roles, 2)
+	for i, namespace := range []string{"apps", "default"} {
+		assert.Equal(t, "test-default-role", roles[i].Name)
+		assert.Equal(t, namespace, roles[i].Namespace)
+		assert.Equal(t, []rbacv1.PolicyRule{
+			{
+				APIGroups: []string{""},
+				Resources: []string{"pods"},
+				Verbs:     []string{"get", "list", "watch"},
+			},
+		}, roles[i].Rules)
+	}
+
+	roleBindings, err := RoleBindings(params)
+	require.NoError(t, err)
+	require.Len(t, roleBindings, 2)
+	for i, namespace := range []string{"apps", "default"} {
+		assert.Equal(t, "test-default-collector", roleBindings[i].Name)
+		assert.Equal(t, namespace, roleBindings[i].Namespace)
+		assert.Equal(t, rbacv1.RoleRef{Kind: "Role", Name: "test-default-role", APIGroup: "rbac.authorization.k8s.io"}, roleBindings[i].RoleRef)
+		assert.Equal(t, []rbacv1.Subject{{Kind: "ServiceAccount", Name: "test-collector", Namespace: "default"}}, roleBindings[i].Subjects)
+	}
+
+	// the roles are only created when the operator can create them
+	cfg.CreateRoleRBACPermissions = autoRBAC.NotAvailable
+	params.Config = cfg
+	roles, err = Roles(params)
+	require.NoError(t, err)
+	assert.Empty(t, roles)
+	roleBindings, err = RoleBindings(params)
+	require.NoError(t, err)
+	assert.Empty(t, roleBindings)
+}
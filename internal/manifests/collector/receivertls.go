@@ -0,0 +1,217 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package collector
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	cmv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/certmanager"
+	"github.com/open-telemetry/opentelemetry-operator/internal/components"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/constants"
+)
+
+const (
+	// receiverCertDuration is the validity period of the receiver certificate (90 days), which cert-manager renews
+	// at 2/3 of its duration.
+	receiverCertDuration = time.Hour * 24 * 90
+
+	// receiverCACertDuration and receiverCACertRenewBefore follow the durations of the target allocator CA, so that the
+	// CA always outlives the receiver certificates it signs.
+	receiverCACertDuration    = receiverCertDuration * 8
+	receiverCACertRenewBefore = receiverCertDuration*2 + 24*time.Hour
+
+	defaultReceiverTLSReloadInterval = time.Hour
+)
+
+// servesReceiverTLS returns whether the receivers of the collector are configured with a certificate issued by
+// cert-manager.
+func servesReceiverTLS(cfg config.Config, otelcol v1beta1.OpenTelemetryCollector) bool {
+	return otelcol.Spec.TLS != nil && otelcol.Spec.TLS.Enabled &&
+		otelcol.Spec.Mode != v1beta1.ModeSidecar &&
+		cfg.CertManagerAvailability == certmanager.Available
+}
+
+// usesReceiverCAIssuer returns whether the operator creates the CA signing the receiver certificate.
+func usesReceiverCAIssuer(cfg config.Config, otelcol v1beta1.OpenTelemetryCollector) bool {
+	return servesReceiverTLS(cfg, otelcol) && otelcol.Spec.TLS.IssuerRef == nil
+}
+
+// receiverTLSCertificate returns the certificate files the receivers serving TLS are configured with.
+func receiverTLSCertificate(otelcol v1beta1.OpenTelemetryCollector) *components.TLSCertificate {
+	reloadInterval := defaultReceiverTLSReloadInterval
+	if otelcol.Spec.TLS.ReloadInterval != nil {
+		reloadInterval = otelcol.Spec.TLS.ReloadInterval.Duration
+	}
+	cert := &components.TLSCertificate{
+		CertFile:       filepath.Join(constants.CollectorReceiverTLSDirPath, corev1.TLSCertKey),
+		KeyFile:        filepath.Join(constants.CollectorReceiverTLSDirPath, corev1.TLSPrivateKeyKey),
+		ReloadInterval: reloadInterval.String(),
+	}
+	if otelcol.Spec.TLS.ClientAuth {
+		cert.ClientCAFile = filepath.Join(constants.CollectorReceiverTLSDirPath, cmmeta.TLSCAKey)
+	}
+	return cert
+}
+
+// receiverTLSVolume returns the volume holding the receiver certificate. The whole Secret is mounted, rather than
+// single keys, so that the renewed certificates show up in the container.
+func receiverTLSVolume(otelcol v1beta1.OpenTelemetryCollector) corev1.Volume {
+	return corev1.Volume{
+		Name: naming.ReceiverTLSVolume(),
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{SecretName: naming.ReceiverCertificate(otelcol.Name)},
+		},
+	}
+}
+
+func receiverTLSVolumeMount() corev1.VolumeMount {
+	return corev1.VolumeMount{
+		Name:      naming.ReceiverTLSVolume(),
+		MountPath: constants.CollectorReceiverTLSDirPath,
+		ReadOnly:  true,
+	}
+}
+
+// ReceiverCertificate returns the Certificate of the receivers of the collector, covering the DNS names of the
+// collector Service.
+func ReceiverCertificate(params manifests.Params) (*cmv1.Certificate, error) {
+	if !servesReceiverTLS(params.Config, params.OtelCol) {
+		return nil, nil
+	}
+	name := naming.ReceiverCertificate(params.OtelCol.Name)
+	labels := manifestutils.Labels(params.OtelCol.ObjectMeta, name, params.OtelCol.Spec.Image, ComponentOpenTelemetryCollector, params.Config.LabelsFilter)
+
+	issuerRef := cmmeta.IssuerReference{
+		Kind: string(v1beta1.TLSIssuerKindIssuer),
+		Name: naming.CollectorCAIssuer(params.OtelCol.Name),
+	}
+	if ref := params.OtelCol.Spec.TLS.IssuerRef; ref != nil {
+		issuerRef.Name = ref.Name
+		if ref.Kind != "" {
+			issuerRef.Kind = string(ref.Kind)
+		}
+	}
+
+	// the names don't include the cluster domain, which isn't always cluster.local: the fully qualified names are
+	// added with spec.tls.dnsNames
+	var dnsNames []string
+	for _, service := range []string{naming.Service(params.OtelCol.Name), naming.HeadlessService(params.OtelCol.Name)} {
+		dnsNames = append(dnsNames,
+			fmt.Sprintf("%s.%s", service, params.OtelCol.Namespace),
+			fmt.Sprintf("%s.%s.svc", service, params.OtelCol.Namespace),
+		)
+	}
+	dnsNames = append(dnsNames, params.OtelCol.Spec.TLS.DNSNames...)
+
+	return &cmv1.Certificate{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: params.OtelCol.Namespace,
+			Name:      name,
+			Labels:    labels,
+		},
+		Spec: cmv1.CertificateSpec{
+			Duration:  &metav1.Duration{Duration: receiverCertDuration},
+			DNSNames:  dnsNames,
+			IssuerRef: issuerRef,
+			Usages: []cmv1.KeyUsage{
+				cmv1.UsageServerAuth,
+			},
+			SecretName: name,
+			Subject: &cmv1.X509Subject{
+				OrganizationalUnits: []string{"opentelemetry-operator"},
+			},
+		},
+	}, nil
+}
+
+// ReceiverCACertificate returns the self-signed CA Certificate signing the receiver certificate, when no issuer is
+// referenced.
+func ReceiverCACertificate(params manifests.Params) (*cmv1.Certificate, error) {
+	if !usesReceiverCAIssuer(params.Config, params.OtelCol) {
+		return nil, nil
+	}
+	name := naming.CollectorCACertificate(params.OtelCol.Name)
+	labels := manifestutils.Labels(params.OtelCol.ObjectMeta, name, params.OtelCol.Spec.Image, ComponentOpenTelemetryCollector, params.Config.LabelsFilter)
+
+	return &cmv1.Certificate{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: params.OtelCol.Namespace,
+			Name:      name,
+			Labels:    labels,
+		},
+		Spec: cmv1.CertificateSpec{
+			IsCA:        true,
+			CommonName:  name,
+			Duration:    &metav1.Duration{Duration: receiverCACertDuration},
+			RenewBefore: &metav1.Duration{Duration: receiverCACertRenewBefore},
+			Subject: &cmv1.X509Subject{
+				OrganizationalUnits: []string{"opentelemetry-operator"},
+			},
+			SecretName: name,
+			IssuerRef: cmmeta.IssuerReference{
+				Name: naming.CollectorSelfSignedIssuer(params.OtelCol.Name),
+				Kind: string(v1beta1.TLSIssuerKindIssuer),
+			},
+		},
+	}, nil
+}
+
+// ReceiverSelfSignedIssuer returns the Issuer signing the CA of the receivers, when no issuer is referenced.
+func ReceiverSelfSignedIssuer(params manifests.Params) (*cmv1.Issuer, error) {
+	if !usesReceiverCAIssuer(params.Config, params.OtelCol) {
+		return nil, nil
+	}
+	name := naming.CollectorSelfSignedIssuer(params.OtelCol.Name)
+	labels := manifestutils.Labels(params.OtelCol.ObjectMeta, name, params.OtelCol.Spec.Image, ComponentOpenTelemetryCollector, params.Config.LabelsFilter)
+
+	return &cmv1.Issuer{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: params.OtelCol.Namespace,
+			Labels:    labels,
+		},
+		Spec: cmv1.IssuerSpec{
+			IssuerConfig: cmv1.IssuerConfig{
+				SelfSigned: &cmv1.SelfSignedIssuer{},
+			},
+		},
+	}, nil
+}
+
+// ReceiverCAIssuer returns the Issuer signing the receiver certificate with the CA of the receivers, when no issuer
+// is referenced.
+func ReceiverCAIssuer(params manifests.Params) (*cmv1.Issuer, error) {
+	if !usesReceiverCAIssuer(params.Config, params.OtelCol) {
+		return nil, nil
+	}
+	name := naming.CollectorCAIssuer(params.OtelCol.Name)
+	labels := manifestutils.Labels(params.OtelCol.ObjectMeta, name, params.OtelCol.Spec.Image, ComponentOpenTelemetryCollector, params.Config.LabelsFilter)
+
+	return &cmv1.Issuer{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: params.OtelCol.Namespace,
+			Labels:    labels,
+		},
+		Spec: cmv1.IssuerSpec{
+			IssuerConfig: cmv1.IssuerConfig{
+				CA: &cmv1.CAIssuer{
+					SecretName: naming.CollectorCACertificate(params.OtelCol.Name),
+				},
+			},
+		},
+	}, nil
+}
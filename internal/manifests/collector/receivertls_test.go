@@ -0,0 +1,199 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package collector
+
+import (
+	"testing"
+	"time"
+
+	cmv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	go_yaml "github.com/goccy/go-yaml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/certmanager"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+)
+
+func receiverTLSParams(t *testing.T, tls *v1beta1.ReceiverTLSSpec) manifests.Params {
+	cfg := config.New()
+	cfg.CertManagerAvailability = certmanager.Available
+	collectorCfg := v1beta1.Config{}
+	require.NoError(t, go_yaml.Unmarshal([]byte(`receivers:
+  otlp:
+    protocols:
+      grpc: {}
+      http: {}
+  jaeger:
+    protocols:
+      grpc: {}
+      thrift_compact: {}
+  zipkin:
+    tls:
+      cert_file: /certs/zipkin.crt
+      key_file: /certs/zipkin.key
+exporters:
+  debug: {}
+service:
+  pipelines:
+    traces:
+      receivers: [otlp, jaeger, zipkin]
+      exporters: [debug]
+`), &collectorCfg))
+	return manifests.Params{
+		Config: cfg,
+		OtelCol: v1beta1.OpenTelemetryCollector{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-instance", Namespace: "observability"},
+			Spec: v1beta1.OpenTelemetryCollectorSpec{
+				Mode:   v1beta1.ModeDeployment,
+				Config: collectorCfg,
+				TLS:    tls,
+			},
+		},
+		Log: testLogger,
+	}
+}
+
+func TestReceiverCertificate(t *testing.T) {
+	params := receiverTLSParams(t, &v1beta1.ReceiverTLSSpec{Enabled: true, DNSNames: []string{"otel.example.com"}})
+
+	selfSignedIssuer, err := ReceiverSelfSignedIssuer(params)
+	require.NoError(t, err)
+	require.NotNil(t, selfSignedIssuer)
+	assert.Equal(t, "my-instance-collector-self-signed-issuer", selfSignedIssuer.Name)
+	assert.NotNil(t, selfSignedIssuer.Spec.SelfSigned)
+
+	caCertificate, err := ReceiverCACertificate(params)
+	require.NoError(t, err)
+	require.NotNil(t, caCertificate)
+	assert.True(t, caCertificate.Spec.IsCA)
+	assert.Equal(t, "my-instance-collector-ca-cert", caCertificate.Spec.SecretName)
+	assert.Equal(t, "my-instance-collector-self-signed-issuer", caCertificate.Spec.IssuerRef.Name)
+
+	caIssuer, err := ReceiverCAIssuer(params)
+	require.NoError(t, err)
+	require.NotNil(t, caIssuer)
+	assert.Equal(t, "my-instance-collector-ca-issuer", caIssuer.Name)
+	assert.Equal(t, "my-instance-collector-ca-cert", caIssuer.Spec.CA.SecretName)
+
+	certificate, err := ReceiverCertificate(params)
+	require.NoError(t, err)
+	require.NotNil(t, certificate)
+	assert.Equal(t, "my-instance-collector-receiver-cert", certificate.Name)
+	assert.Equal(t, "observability", certificate.Namespace)
+	assert.Equal(t, "my-instance-collector-receiver-cert", certificate.Spec.SecretName)
+	assert.Equal(t, "Issuer", certificate.Spec.IssuerRef.Kind)
+	assert.Equal(t, "my-instance-collector-ca-issuer", certificate.Spec.IssuerRef.Name)
+	assert.Equal(t, []cmv1.KeyUsage{cmv1.UsageServerAuth}, certificate.Spec.Usages)
+	assert.Equal(t, []string{
+		"my-instance-collector.observability",
+		"my-instance-collector.observability.svc",
+		"my-instance-collector-headless.observability",
+		"my-instance-collector-headless.observability.svc",
+		"otel.example.com",
+	}, certificate.Spec.DNSNames)
+}
+
+func TestReceiverCertificateIssuerRef(t *testing.T) {
+	params := receiverTLSParams(t, &v1beta1.ReceiverTLSSpec{
+		Enabled:   true,
+		IssuerRef: &v1beta1.TLSIssuerReference{Name: "corporate-ca", Kind: v1beta1.TLSIssuerKindClusterIssuer},
+	})
+
+	certificate, err := ReceiverCertificate(params)
+	require.NoError(t, err)
+	require.NotNil(t, certificate)
+	assert.Equal(t, "ClusterIssuer", certificate.Spec.IssuerRef.Kind)
+	assert.Equal(t, "corporate-ca", certificate.Spec.IssuerRef.Name)
+
+	objects, err := Build(params)
+	require.NoError(t, err)
+	for _, object := range objects {
+		_, isIssuer := object.(*cmv1.Issuer)
+		assert.False(t, isIssuer, "no issuer is expected when an issuer is referenced")
+		if c, ok := object.(*cmv1.Certificate); ok {
+			assert.False(t, c.Spec.IsCA, "no CA certificate is expected when an issuer is referenced")
+		}
+	}
+}
+
+func TestReceiverTLSSkipped(t *testing.T) {
+	for _, tt := range []struct {
+		name         string
+		mode         v1beta1.Mode
+		tls          *v1beta1.ReceiverTLSSpec
+		availability certmanager.Availability
+	}{
+		{name: "not set", mode: v1beta1.ModeDeployment, availability: certmanager.Available},
+		{name: "disabled", mode: v1beta1.ModeDeployment, tls: &v1beta1.ReceiverTLSSpec{}, availability: certmanager.Available},
+		{name: "sidecar", mode: v1beta1.ModeSidecar, tls: &v1beta1.ReceiverTLSSpec{Enabled: true}, availability: certmanager.Available},
+		{name: "not available", mode: v1beta1.ModeDeployment, tls: &v1beta1.ReceiverTLSSpec{Enabled: true}, availability: certmanager.NotAvailable},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			params := receiverTLSParams(t, tt.tls)
+			params.OtelCol.Spec.Mode = tt.mode
+			params.Config.CertManagerAvailability = tt.availability
+
+			certificate, err := ReceiverCertificate(params)
+			require.NoError(t, err)
+			assert.Nil(t, certificate)
+			issuer, err := ReceiverCAIssuer(params)
+			require.NoError(t, err)
+			assert.Nil(t, issuer)
+
+			for _, volume := range Volumes(params.Config, params.OtelCol, nil) {
+				assert.NotEqual(t, "otc-receiver-tls", volume.Name)
+			}
+		})
+	}
+}
+
+func TestReceiverTLSConfig(t *testing.T) {
+	params := receiverTLSParams(t, &v1beta1.ReceiverTLSSpec{
+		Enabled:        true,
+		ClientAuth:     true,
+		ReloadInterval: &metav1.Duration{Duration: 10 * time.Minute},
+	})
+
+	configMap, err := ConfigMap(params)
+	require.NoError(t, err)
+	collectorCfg := map[string]any{}
+	require.NoError(t, go_yaml.Unmarshal([]byte(configMap.Data["collector.yaml"]), &collectorCfg))
+	receivers := collectorCfg["receivers"].(map[string]any)
+
+	expectedTLS := map[string]any{
+		"cert_file":       "/receiver-tls/tls.crt",
+		"key_file":        "/receiver-tls/tls.key",
+		"client_ca_file":  "/receiver-tls/ca.crt",
+		"reload_interval": "10m0s",
+	}
+	tlsOf := func(protocols map[string]any, protocol string) any {
+		return protocols[protocol].(map[string]any)["tls"]
+	}
+	otlpProtocols := receivers["otlp"].(map[string]any)["protocols"].(map[string]any)
+	assert.Equal(t, expectedTLS, tlsOf(otlpProtocols, "grpc"))
+	assert.Equal(t, expectedTLS, tlsOf(otlpProtocols, "http"))
+	jaegerProtocols := receivers["jaeger"].(map[string]any)["protocols"].(map[string]any)
+	assert.Equal(t, expectedTLS, tlsOf(jaegerProtocols, "grpc"))
+	assert.Nil(t, tlsOf(jaegerProtocols, "thrift_compact"), "UDP protocols don't serve TLS")
+	// the certificate configured by the user is kept as it is
+	assert.Equal(t, map[string]any{
+		"cert_file": "/certs/zipkin.crt",
+		"key_file":  "/certs/zipkin.key",
+	}, receivers["zipkin"].(map[string]any)["tls"])
+
+	// the pods reference the ConfigMap holding the injected certificate
+	volumes := Volumes(params.Config, params.OtelCol, nil)
+	assert.Equal(t, configMap.Name, volumes[0].ConfigMap.Name)
+	require.Len(t, volumes, 2)
+	assert.Equal(t, "otc-receiver-tls", volumes[1].Name)
+	assert.Equal(t, "my-instance-collector-receiver-cert", volumes[1].Secret.SecretName)
+
+	container := Container(params.Config, testLogger, params.OtelCol, true, nil)
+	assert.Contains(t, container.VolumeMounts, receiverTLSVolumeMount())
+	assert.Empty(t, receiverTLSVolumeMount().SubPath, "the certificate is mounted as a directory to pick up renewals")
+}
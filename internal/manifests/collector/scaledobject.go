@@ -0,0 +1,184 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/keda"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+)
+
+// ScaledObjectGVK is the kind of the KEDA ScaledObject. KEDA's API isn't a dependency of the operator, so the
+// ScaledObject is built as an unstructured object.
+var ScaledObjectGVK = schema.GroupVersionKind{Group: "keda.sh", Version: "v1alpha1", Kind: "ScaledObject"}
+
+// refusedSpansRateWindow is the window the rate of the refused spans is computed over.
+const refusedSpansRateWindow = "2m"
+
+// scaledObjectSpec is the subset of the KEDA ScaledObject spec the operator sets.
+type scaledObjectSpec struct {
+	ScaleTargetRef  autoscalingv2.CrossVersionObjectReference `json:"scaleTargetRef"`
+	PollingInterval *int32                                    `json:"pollingInterval,omitempty"`
+	MinReplicaCount *int32                                    `json:"minReplicaCount,omitempty"`
+	MaxReplicaCount *int32                                    `json:"maxReplicaCount,omitempty"`
+	Advanced        *scaledObjectAdvanced                     `json:"advanced,omitempty"`
+	Triggers        []v1beta1.KedaTrigger                     `json:"triggers"`
+}
+
+type scaledObjectAdvanced struct {
+	HorizontalPodAutoscalerConfig scaledObjectHPAConfig `json:"horizontalPodAutoscalerConfig"`
+}
+
+type scaledObjectHPAConfig struct {
+	Behavior *autoscalingv2.HorizontalPodAutoscalerBehavior `json:"behavior,omitempty"`
+}
+
+// ScaledObject returns the KEDA ScaledObject scaling the collector, when its autoscaler is of type keda. Like the
+// HorizontalPodAutoscaler, it scales the OpenTelemetryCollector through its scale subresource.
+func ScaledObject(params manifests.Params) (*unstructured.Unstructured, error) {
+	autoscaler := params.OtelCol.Spec.Autoscaler
+	if autoscaler == nil || autoscaler.Type != v1beta1.AutoscalerTypeKeda {
+		return nil, nil
+	}
+	if params.Config.KedaAvailability != keda.Available {
+		params.Log.V(1).Info("KEDA is not available, skipping the ScaledObject creation")
+		return nil, nil
+	}
+
+	name := naming.ScaledObject(params.OtelCol.Name)
+	labels := manifestutils.Labels(params.OtelCol.ObjectMeta, name, params.OtelCol.Spec.Image, ComponentOpenTelemetryCollector, params.Config.LabelsFilter)
+	annotations, err := manifestutils.Annotations(params.OtelCol, params.Config.AnnotationsFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	spec := scaledObjectSpec{
+		ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
+			APIVersion: v1beta1.GroupVersion.String(),
+			Kind:       "OpenTelemetryCollector",
+			Name:       naming.OpenTelemetryCollector(params.OtelCol.Name),
+		},
+		MinReplicaCount: autoscaler.MinReplicas,
+		MaxReplicaCount: autoscaler.MaxReplicas,
+		Triggers:        kedaTriggers(params),
+	}
+	if autoscaler.Keda != nil {
+		spec.PollingInterval = autoscaler.Keda.PollingInterval
+	}
+	if autoscaler.Behavior != nil {
+		spec.Advanced = &scaledObjectAdvanced{
+			HorizontalPodAutoscalerConfig: scaledObjectHPAConfig{Behavior: autoscaler.Behavior},
+		}
+	}
+	specObject, err := toUnstructuredMap(spec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert the ScaledObject spec: %w", err)
+	}
+
+	scaledObject := &unstructured.Unstructured{Object: map[string]any{"spec": specObject}}
+	scaledObject.SetGroupVersionKind(ScaledObjectGVK)
+	scaledObject.SetName(name)
+	scaledObject.SetNamespace(params.OtelCol.Namespace)
+	scaledObject.SetLabels(labels)
+	scaledObject.SetAnnotations(annotations)
+	return scaledObject, nil
+}
+
+// kedaTriggers returns the triggers of the ScaledObject: the resource utilization targets of the autoscaler, the
+// triggers on the collector's own metrics and the additional triggers.
+func kedaTriggers(params manifests.Params) []v1beta1.KedaTrigger {
+	autoscaler := params.OtelCol.Spec.Autoscaler
+	var triggers []v1beta1.KedaTrigger
+	if autoscaler.TargetMemoryUtilization != nil {
+		triggers = append(triggers, v1beta1.KedaTrigger{
+			Type:       "memory",
+			MetricType: autoscalingv2.UtilizationMetricType,
+			Metadata:   map[string]string{"value": strconv.Itoa(int(*autoscaler.TargetMemoryUtilization))},
+		})
+	}
+	if autoscaler.TargetCPUUtilization != nil {
+		triggers = append(triggers, v1beta1.KedaTrigger{
+			Type:       "cpu",
+			MetricType: autoscalingv2.UtilizationMetricType,
+			Metadata:   map[string]string{"value": strconv.Itoa(int(*autoscaler.TargetCPUUtilization))},
+		})
+	}
+
+	spec := autoscaler.Keda
+	if spec == nil {
+		return triggers
+	}
+	selector := collectorMetricsSelector(params)
+	if spec.ExporterQueueSize != nil && spec.Prometheus != nil {
+		query := fmt.Sprintf("sum(otelcol_exporter_queue_size{%s})", selector)
+		triggers = append(triggers, prometheusTrigger("exporter-queue-size", spec.Prometheus, query, spec.ExporterQueueSize.TargetValue))
+	}
+	if spec.RefusedSpans != nil && spec.Prometheus != nil {
+		// the counters are exported with or without the _total suffix depending on the collector's telemetry settings
+		query := fmt.Sprintf(`sum(rate({__name__=~"otelcol_receiver_refused_spans(_total)?",%s}[%s]))`, selector, refusedSpansRateWindow)
+		triggers = append(triggers, prometheusTrigger("refused-spans", spec.Prometheus, query, spec.RefusedSpans.TargetValue))
+	}
+	if lag := spec.KafkaLag; lag != nil {
+		metadata := map[string]string{
+			"bootstrapServers": lag.BootstrapServers,
+			"consumerGroup":    lag.ConsumerGroup,
+			"lagThreshold":     strconv.FormatInt(lag.LagThreshold, 10),
+		}
+		if lag.Topic != "" {
+			metadata["topic"] = lag.Topic
+		}
+		triggers = append(triggers, v1beta1.KedaTrigger{
+			Type:              "kafka",
+			Name:              "kafka-lag",
+			MetricType:        autoscalingv2.AverageValueMetricType,
+			Metadata:          metadata,
+			AuthenticationRef: lag.AuthenticationRef,
+		})
+	}
+	return append(triggers, spec.Triggers...)
+}
+
+// collectorMetricsSelector returns the label matchers selecting the collector's own metrics by the namespace and the
+// monitoring service its ServiceMonitor scrapes them through, so that they don't match the pods of other workloads
+// sharing the collector's name prefix.
+func collectorMetricsSelector(params manifests.Params) string {
+	return fmt.Sprintf(`namespace=%q,service=%q`, params.OtelCol.Namespace, naming.MonitoringService(params.OtelCol.Name))
+}
+
+func prometheusTrigger(name string, prometheus *v1beta1.KedaPrometheusSpec, query string, target resource.Quantity) v1beta1.KedaTrigger {
+	return v1beta1.KedaTrigger{
+		Type:       "prometheus",
+		Name:       name,
+		MetricType: autoscalingv2.AverageValueMetricType,
+		Metadata: map[string]string{
+			"serverAddress": prometheus.ServerAddress,
+			"query":         query,
+			"threshold":     strconv.FormatFloat(target.AsApproximateFloat64(), 'f', -1, 64),
+		},
+		AuthenticationRef: prometheus.AuthenticationRef,
+	}
+}
+
+func toUnstructuredMap(value any) (map[string]any, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var object map[string]any
+	if err := json.Unmarshal(data, &object); err != nil {
+		return nil, err
+	}
+	return object, nil
+}
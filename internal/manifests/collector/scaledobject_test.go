@@ -0,0 +1,142 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package collector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/keda"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+)
+
+func TestScaledObject(t *testing.T) {
+	minReplicas := int32(2)
+	maxReplicas := int32(10)
+	cpuUtilization := int32(80)
+	pollingInterval := int32(15)
+
+	otelcol := v1beta1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-instance",
+			Namespace: "observability",
+		},
+		Spec: v1beta1.OpenTelemetryCollectorSpec{
+			Autoscaler: &v1beta1.AutoscalerSpec{
+				Type:                 v1beta1.AutoscalerTypeKeda,
+				MinReplicas:          &minReplicas,
+				MaxReplicas:          &maxReplicas,
+				TargetCPUUtilization: &cpuUtilization,
+				Keda: &v1beta1.KedaAutoscalerSpec{
+					PollingInterval: &pollingInterval,
+					Prometheus: &v1beta1.KedaPrometheusSpec{
+						ServerAddress: "http://prometheus.monitoring:9090",
+					},
+					ExporterQueueSize: &v1beta1.KedaMetricTarget{TargetValue: resource.MustParse("500")},
+					RefusedSpans:      &v1beta1.KedaMetricTarget{TargetValue: resource.MustParse("0.5")},
+					KafkaLag: &v1beta1.KedaKafkaLagTrigger{
+						BootstrapServers: "kafka:9092",
+						ConsumerGroup:    "otel",
+						Topic:            "otlp_spans",
+						LagThreshold:     100,
+					},
+				},
+			},
+		},
+	}
+	cfg := config.New()
+	cfg.KedaAvailability = keda.Available
+	params := manifests.Params{
+		Config:  cfg,
+		OtelCol: otelcol,
+		Log:     testLogger,
+	}
+
+	scaledObject, err := ScaledObject(params)
+	require.NoError(t, err)
+	require.NotNil(t, scaledObject)
+
+	assert.Equal(t, ScaledObjectGVK, scaledObject.GroupVersionKind())
+	assert.Equal(t, "my-instance-collector", scaledObject.GetName())
+	assert.Equal(t, "observability", scaledObject.GetNamespace())
+
+	targetName, _, _ := unstructured.NestedString(scaledObject.Object, "spec", "scaleTargetRef", "name")
+	assert.Equal(t, "my-instance", targetName)
+	targetKind, _, _ := unstructured.NestedString(scaledObject.Object, "spec", "scaleTargetRef", "kind")
+	assert.Equal(t, "OpenTelemetryCollector", targetKind)
+	minCount, _, _ := unstructured.NestedFloat64(scaledObject.Object, "spec", "minReplicaCount")
+	assert.EqualValues(t, 2, minCount)
+	maxCount, _, _ := unstructured.NestedFloat64(scaledObject.Object, "spec", "maxReplicaCount")
+	assert.EqualValues(t, 10, maxCount)
+	interval, _, _ := unstructured.NestedFloat64(scaledObject.Object, "spec", "pollingInterval")
+	assert.EqualValues(t, 15, interval)
+
+	triggers := kedaTriggers(params)
+	require.Len(t, triggers, 4)
+	assert.Equal(t, "cpu", triggers[0].Type)
+	assert.Equal(t, "80", triggers[0].Metadata["value"])
+	assert.Equal(t, "exporter-queue-size", triggers[1].Name)
+	assert.Equal(t, `sum(otelcol_exporter_queue_size{namespace="observability",service="my-instance-collector-monitoring"})`, triggers[1].Metadata["query"])
+	assert.Equal(t, "500", triggers[1].Metadata["threshold"])
+	assert.Equal(t, "refused-spans", triggers[2].Name)
+	assert.Equal(t, `sum(rate({__name__=~"otelcol_receiver_refused_spans(_total)?",namespace="observability",service="my-instance-collector-monitoring"}[2m]))`, triggers[2].Metadata["query"])
+	assert.Equal(t, "0.5", triggers[2].Metadata["threshold"])
+	assert.Equal(t, "kafka", triggers[3].Type)
+	assert.Equal(t, map[string]string{
+		"bootstrapServers": "kafka:9092",
+		"consumerGroup":    "otel",
+		"topic":            "otlp_spans",
+		"lagThreshold":     "100",
+	}, triggers[3].Metadata)
+
+	specTriggers, _, _ := unstructured.NestedSlice(scaledObject.Object, "spec", "triggers")
+	assert.Len(t, specTriggers, 4)
+}
+
+func TestScaledObjectSkipped(t *testing.T) {
+	maxReplicas := int32(5)
+	for _, tt := range []struct {
+		name         string
+		autoscaler   *v1beta1.AutoscalerSpec
+		availability keda.Availability
+	}{
+		{
+			name:         "no autoscaler",
+			availability: keda.Available,
+		},
+		{
+			name:         "hpa autoscaler",
+			autoscaler:   &v1beta1.AutoscalerSpec{MaxReplicas: &maxReplicas},
+			availability: keda.Available,
+		},
+		{
+			name:         "keda not available",
+			autoscaler:   &v1beta1.AutoscalerSpec{Type: v1beta1.AutoscalerTypeKeda, MaxReplicas: &maxReplicas},
+			availability: keda.NotAvailable,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.New()
+			cfg.KedaAvailability = tt.availability
+			params := manifests.Params{
+				Config: cfg,
+				OtelCol: v1beta1.OpenTelemetryCollector{
+					ObjectMeta: metav1.ObjectMeta{Name: "my-instance"},
+					Spec:       v1beta1.OpenTelemetryCollectorSpec{Autoscaler: tt.autoscaler},
+				},
+				Log: testLogger,
+			}
+			scaledObject, err := ScaledObject(params)
+			require.NoError(t, err)
+			assert.Nil(t, scaledObject)
+		})
+	}
+}
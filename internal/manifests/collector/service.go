@@ -85,7 +85,7 @@ func MonitoringService(params manifests.Params) (*corev1.Service, error) {
 			Annotations: annotations,
 		},
 		Spec: corev1.ServiceSpec{
-			Selector:  manifestutils.SelectorLabels(params.OtelCol.ObjectMeta, ComponentOpenTelemetryCollector),
+			Selector:  podSelectorLabels(params),
 			ClusterIP: "",
 			Ports: []corev1.ServicePort{{
 				Name: "monitoring",
@@ -128,7 +128,7 @@ func ExtensionService(params manifests.Params) (*corev1.Service, error) {
 		},
 		Spec: corev1.ServiceSpec{
 			Ports:                 ports,
-			Selector:              manifestutils.SelectorLabels(params.OtelCol.ObjectMeta, ComponentOpenTelemetryCollector),
+			Selector:              podSelectorLabels(params),
 			TrafficDistribution:   params.OtelCol.Spec.TrafficDistribution,
 			SessionAffinity:       sessionAffinity(params),
 			SessionAffinityConfig: params.OtelCol.Spec.SessionAffinityConfig,
@@ -199,7 +199,7 @@ func Service(params manifests.Params) (*corev1.Service, error) {
 		},
 		Spec: corev1.ServiceSpec{
 			InternalTrafficPolicy: &trafficPolicy,
-			Selector:              manifestutils.SelectorLabels(params.OtelCol.ObjectMeta, ComponentOpenTelemetryCollector),
+			Selector:              podSelectorLabels(params),
 			ClusterIP:             "",
 			Ports:                 ports,
 			IPFamilies:            params.OtelCol.Spec.IpFamilies,
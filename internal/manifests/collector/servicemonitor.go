@@ -73,6 +73,11 @@ func createServiceMonitor(name string, params manifests.Params, serviceType Serv
 		},
 	}
 
+	// The alerts and the dashboard select the collector metrics by the labels of its pods
+	if monitoringEnabled(params) {
+		sm.Spec.PodTargetLabels = collectorPodTargetLabels
+	}
+
 	return &sm, nil
 }
 
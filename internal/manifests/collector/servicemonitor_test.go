@@ -9,6 +9,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/openshift"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/prometheus"
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
@@ -156,3 +157,26 @@ func TestDesiredServiceMonitorsWithExtraLabels(t *testing.T) {
 	}
 	assert.Equal(t, expectedSelectorLabels, actual.Spec.Selector.MatchLabels)
 }
+
+func TestDesiredServiceMonitorsPodTargetLabels(t *testing.T) {
+	params := deploymentParams()
+	params.OtelCol.Spec.Observability.Metrics.EnableMetrics = true
+
+	// the pod labels are only copied to the metrics when the alerts or the dashboard select the metrics by them
+	actual, err := ServiceMonitorMonitoring(params)
+	assert.NoError(t, err)
+	assert.NotNil(t, actual)
+	assert.Empty(t, actual.Spec.PodTargetLabels)
+
+	params.OtelCol.Spec.Monitoring.EnableAlerts = true
+	actual, err = ServiceMonitorMonitoring(params)
+	assert.NoError(t, err)
+	assert.NotNil(t, actual)
+	assert.Equal(t, []string{"app.kubernetes.io/name", "app.kubernetes.io/instance", "app.kubernetes.io/managed-by"}, actual.Spec.PodTargetLabels)
+
+	params.OtelCol.Spec.Monitoring = v1beta1.MonitoringSpec{Dashboard: &v1beta1.MetricsDashboardSpec{Enabled: true}}
+	actual, err = ServiceMonitorMonitoring(params)
+	assert.NoError(t, err)
+	assert.NotNil(t, actual)
+	assert.Equal(t, []string{"app.kubernetes.io/name", "app.kubernetes.io/instance", "app.kubernetes.io/managed-by"}, actual.Spec.PodTargetLabels)
+}
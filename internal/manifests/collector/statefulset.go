@@ -13,6 +13,14 @@ import (
 	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
 )
 
+func statefulSetPodTemplate(params manifests.Params) (*corev1.PodTemplateSpec, error) {
+	ss, err := StatefulSet(params)
+	if err != nil {
+		return nil, err
+	}
+	return &ss.Spec.Template, nil
+}
+
 // StatefulSet builds the statefulset for the given instance.
 func StatefulSet(params manifests.Params) (*appsv1.StatefulSet, error) {
 	name := naming.Collector(params.OtelCol.Name)
@@ -38,7 +46,7 @@ func StatefulSet(params manifests.Params) (*appsv1.StatefulSet, error) {
 		podManagementPolicy = appsv1.ParallelPodManagement
 	}
 
-	return &appsv1.StatefulSet{
+	statefulSet := &appsv1.StatefulSet{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        name,
 			Namespace:   params.OtelCol.Namespace,
@@ -69,7 +77,7 @@ func StatefulSet(params manifests.Params) (*appsv1.StatefulSet, error) {
 					ShareProcessNamespace:         &params.OtelCol.Spec.ShareProcessNamespace,
 					Tolerations:                   params.OtelCol.Spec.Tolerations,
 					NodeSelector:                  params.OtelCol.Spec.NodeSelector,
-					SecurityContext:               params.OtelCol.Spec.PodSecurityContext,
+					SecurityContext:               podSecurityContext(params.OtelCol),
 					PriorityClassName:             params.OtelCol.Spec.PriorityClassName,
 					Affinity:                      params.OtelCol.Spec.Affinity,
 					TopologySpreadConstraints:     params.OtelCol.Spec.TopologySpreadConstraints,
@@ -81,5 +89,13 @@ func StatefulSet(params manifests.Params) (*appsv1.StatefulSet, error) {
 			VolumeClaimTemplates:                 VolumeClaimTemplates(params.OtelCol),
 			PersistentVolumeClaimRetentionPolicy: params.OtelCol.Spec.PersistentVolumeClaimRetentionPolicy,
 		},
-	}, nil
+	}
+
+	applyConfigFromSecrets(params, &statefulSet.Spec.Template)
+	if params.OtelCol.Spec.ConfigRollout != nil {
+		if err = applyConfigRollout(params, &statefulSet.Spec.Template, &statefulSet.Spec.UpdateStrategy, statefulSetPodTemplate); err != nil {
+			return nil, err
+		}
+	}
+	return statefulSet, nil
 }
@@ -0,0 +1,96 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package collector
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/gatewayapi"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+)
+
+// TLSRoutes returns a TLSRoute for every TCP port of the collector. The TLS connections are passed through to the
+// receivers, so a TLSRoute can't tell the ports apart by their content: each TLSRoute attaches to the listeners of the
+// Gateway on the port of the collector it routes to.
+func TLSRoutes(params manifests.Params) ([]*gatewayv1.TLSRoute, error) {
+	if !params.OtelCol.Spec.TLSRoute.Enabled {
+		return nil, nil
+	}
+
+	if params.OtelCol.Spec.Mode == v1beta1.ModeSidecar {
+		params.Log.V(3).Info("TLSRoute settings are not supported in sidecar mode")
+		return nil, nil
+	}
+
+	if params.Config.TLSRouteAvailability != gatewayapi.ApiAvailable {
+		params.Log.V(1).Info("the Gateway API TLSRoute is not available, skipping TLSRoute")
+		return nil, nil
+	}
+
+	tlsRouteConfig := &params.OtelCol.Spec.TLSRoute
+	// Gateway name and SNI hostnames are required
+	if tlsRouteConfig.Gateway == "" || len(tlsRouteConfig.Hostnames) == 0 {
+		params.Log.V(1).Info(
+			"TLSRoute is enabled but gateway name or hostnames are not specified, skipping TLSRoute",
+			"instance.name", params.OtelCol.Name,
+			"instance.namespace", params.OtelCol.Namespace,
+		)
+		return nil, nil
+	}
+
+	ports, err := servicePortsFromCfg(params.Log, params.OtelCol)
+	if len(ports) == 0 || err != nil {
+		params.Log.V(1).Info(
+			"the instance's configuration didn't yield any ports to open, skipping TLSRoute",
+			"instance.name", params.OtelCol.Name,
+			"instance.namespace", params.OtelCol.Namespace,
+		)
+		return nil, err
+	}
+
+	var tlsRoutes []*gatewayv1.TLSRoute
+
+	for _, port := range ports {
+		if port.Protocol != "" && port.Protocol != corev1.ProtocolTCP {
+			continue
+		}
+
+		name := naming.TLSRoute(params.OtelCol.Name, port.Name)
+		labels := manifestutils.Labels(params.OtelCol.ObjectMeta, name, params.OtelCol.Spec.Image, ComponentOpenTelemetryCollector, params.Config.LabelsFilter)
+		portNumber := gatewayv1.PortNumber(port.Port)
+
+		tlsRoutes = append(tlsRoutes, &gatewayv1.TLSRoute{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: params.OtelCol.Namespace,
+				Labels:    labels,
+			},
+			Spec: gatewayv1.TLSRouteSpec{
+				CommonRouteSpec: gatewayv1.CommonRouteSpec{
+					ParentRefs: gatewayParentRefs(params, "TLSRoute", tlsRouteConfig.Gateway, tlsRouteConfig.GatewayNamespace, &portNumber),
+				},
+				Hostnames: gatewayHostnames(tlsRouteConfig.Hostnames),
+				Rules: []gatewayv1.TLSRouteRule{
+					{
+						BackendRefs: []gatewayv1.BackendRef{
+							{
+								BackendObjectReference: gatewayv1.BackendObjectReference{
+									Name: gatewayv1.ObjectName(naming.Service(params.OtelCol.Name)),
+									Port: &portNumber,
+								},
+							},
+						},
+					},
+				},
+			},
+		})
+	}
+
+	return tlsRoutes, nil
+}
@@ -0,0 +1,97 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package collector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/gatewayapi"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+)
+
+func TestDesiredTLSRoutes(t *testing.T) {
+	t.Run("should return nil without hostnames", func(t *testing.T) {
+		params, err := newParams("something:tag", testFileIngress, nil)
+		require.NoError(t, err)
+		params.Config.TLSRouteAvailability = gatewayapi.ApiAvailable
+		params.OtelCol.Spec.TLSRoute = v1beta1.TLSRouteConfig{
+			Enabled: true,
+			Gateway: "example-gateway",
+		}
+
+		actual, err := TLSRoutes(params)
+		assert.NoError(t, err)
+		assert.Nil(t, actual)
+	})
+
+	t.Run("should return nil when TLSRoute is not available", func(t *testing.T) {
+		params, err := newParams("something:tag", testFileIngress, nil)
+		require.NoError(t, err)
+		params.OtelCol.Spec.TLSRoute = v1beta1.TLSRouteConfig{
+			Enabled:   true,
+			Gateway:   "example-gateway",
+			Hostnames: []string{"otel.example.com"},
+		}
+
+		actual, err := TLSRoutes(params)
+		assert.NoError(t, err)
+		assert.Nil(t, actual)
+	})
+
+	t.Run("should create a TLSRoute for every TCP port", func(t *testing.T) {
+		params, err := newParams("something:tag", testFileIngress, nil)
+		require.NoError(t, err)
+		params.Config.TLSRouteAvailability = gatewayapi.ApiAvailable
+		params.OtelCol.Spec.Config.Receivers.Object = map[string]any{
+			"otlp": map[string]any{
+				"protocols": map[string]any{
+					"grpc": map[string]any{},
+					"http": map[string]any{},
+				},
+			},
+			"jaeger": map[string]any{
+				"protocols": map[string]any{
+					"thrift_compact": map[string]any{},
+				},
+			},
+		}
+		params.OtelCol.Spec.Config.Service.Pipelines["traces"].Receivers = []string{"otlp", "jaeger"}
+		params.OtelCol.Spec.TLSRoute = v1beta1.TLSRouteConfig{
+			Enabled:   true,
+			Gateway:   "example-gateway",
+			Hostnames: []string{"otel.example.com"},
+		}
+
+		tlsRoutes, err := TLSRoutes(params)
+		require.NoError(t, err)
+		require.Len(t, tlsRoutes, 3, "the UDP port of jaeger can't be routed")
+
+		ports := map[string]gatewayv1.PortNumber{}
+		for _, got := range tlsRoutes {
+			require.Len(t, got.Spec.ParentRefs, 1)
+			require.NotNil(t, got.Spec.ParentRefs[0].Port)
+			assert.Equal(t, gatewayv1.ObjectName("example-gateway"), got.Spec.ParentRefs[0].Name)
+			assert.Equal(t, gatewayv1.Namespace(params.OtelCol.Namespace), *got.Spec.ParentRefs[0].Namespace)
+			assert.Equal(t, []gatewayv1.Hostname{"otel.example.com"}, got.Spec.Hostnames)
+
+			require.Len(t, got.Spec.Rules, 1)
+			require.Len(t, got.Spec.Rules[0].BackendRefs, 1)
+			backendRef := got.Spec.Rules[0].BackendRefs[0]
+			assert.Equal(t, gatewayv1.ObjectName(naming.Service(params.OtelCol.Name)), backendRef.Name)
+			// the route attaches to the listener on the port it routes to
+			assert.Equal(t, *got.Spec.ParentRefs[0].Port, *backendRef.Port)
+			ports[got.Name] = *backendRef.Port
+		}
+		assert.Equal(t, map[string]gatewayv1.PortNumber{
+			naming.TLSRoute(params.OtelCol.Name, "web"):       80,
+			naming.TLSRoute(params.OtelCol.Name, "otlp-grpc"): 4317,
+			naming.TLSRoute(params.OtelCol.Name, "otlp-http"): 4318,
+		}, ports)
+	})
+}
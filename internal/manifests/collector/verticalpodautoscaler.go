@@ -0,0 +1,119 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package collector
+
+import (
+	"fmt"
+
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/vpa"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+)
+
+// VerticalPodAutoscalerGVK is the kind of the VerticalPodAutoscaler. Its API isn't a dependency of the operator, so
+// the VerticalPodAutoscaler is built as an unstructured object.
+var VerticalPodAutoscalerGVK = schema.GroupVersionKind{Group: "autoscaling.k8s.io", Version: "v1", Kind: "VerticalPodAutoscaler"}
+
+// verticalPodAutoscalerSpec is the subset of the VerticalPodAutoscaler spec the operator sets.
+type verticalPodAutoscalerSpec struct {
+	TargetRef      autoscalingv1.CrossVersionObjectReference `json:"targetRef"`
+	UpdatePolicy   *vpaUpdatePolicy                          `json:"updatePolicy,omitempty"`
+	ResourcePolicy vpaResourcePolicy                         `json:"resourcePolicy"`
+}
+
+type vpaUpdatePolicy struct {
+	UpdateMode v1beta1.VerticalAutoscalerUpdateMode `json:"updateMode"`
+}
+
+type vpaResourcePolicy struct {
+	ContainerPolicies []vpaContainerPolicy `json:"containerPolicies"`
+}
+
+type vpaContainerPolicy struct {
+	ContainerName       string                `json:"containerName"`
+	Mode                string                `json:"mode,omitempty"`
+	MinAllowed          corev1.ResourceList   `json:"minAllowed,omitempty"`
+	MaxAllowed          corev1.ResourceList   `json:"maxAllowed,omitempty"`
+	ControlledResources []corev1.ResourceName `json:"controlledResources,omitempty"`
+	ControlledValues    string                `json:"controlledValues,omitempty"`
+}
+
+// supportsVerticalAutoscaler returns whether the collector's pods can be managed by a VerticalPodAutoscaler in the
+// given mode.
+func supportsVerticalAutoscaler(mode v1beta1.Mode) bool {
+	return mode == v1beta1.ModeDeployment || mode == v1beta1.ModeDaemonSet
+}
+
+// VerticalPodAutoscaler returns the VerticalPodAutoscaler adjusting the resources of the collector container, when
+// it's enabled. Both the requests and the limits of the container are adjusted, so that the memory_limiter processor
+// tuned by the operator follows the recommendations.
+func VerticalPodAutoscaler(params manifests.Params) (*unstructured.Unstructured, error) {
+	spec := params.OtelCol.Spec.VerticalAutoscaler
+	if spec == nil || !spec.Enabled || !supportsVerticalAutoscaler(params.OtelCol.Spec.Mode) {
+		return nil, nil
+	}
+	if params.Config.VPAAvailability != vpa.Available {
+		params.Log.V(1).Info("the VerticalPodAutoscaler is not available, skipping its creation")
+		return nil, nil
+	}
+
+	name := naming.VerticalPodAutoscaler(params.OtelCol.Name)
+	labels := manifestutils.Labels(params.OtelCol.ObjectMeta, name, params.OtelCol.Spec.Image, ComponentOpenTelemetryCollector, params.Config.LabelsFilter)
+	annotations, err := manifestutils.Annotations(params.OtelCol, params.Config.AnnotationsFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	kind := "Deployment"
+	if params.OtelCol.Spec.Mode == v1beta1.ModeDaemonSet {
+		kind = "DaemonSet"
+	}
+	updateMode := spec.UpdateMode
+	if updateMode == "" {
+		updateMode = v1beta1.VerticalAutoscalerUpdateModeAuto
+	}
+	vpaSpec := verticalPodAutoscalerSpec{
+		TargetRef: autoscalingv1.CrossVersionObjectReference{
+			APIVersion: "apps/v1",
+			Kind:       kind,
+			Name:       naming.Collector(params.OtelCol.Name),
+		},
+		UpdatePolicy: &vpaUpdatePolicy{UpdateMode: updateMode},
+		ResourcePolicy: vpaResourcePolicy{
+			ContainerPolicies: []vpaContainerPolicy{
+				{
+					ContainerName:       naming.Container(),
+					MinAllowed:          spec.MinAllowed,
+					MaxAllowed:          spec.MaxAllowed,
+					ControlledResources: spec.ControlledResources,
+					ControlledValues:    "RequestsAndLimits",
+				},
+				{
+					// the additional containers of the pods are left as they are
+					ContainerName: "*",
+					Mode:          "Off",
+				},
+			},
+		},
+	}
+	specObject, err := toUnstructuredMap(vpaSpec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert the VerticalPodAutoscaler spec: %w", err)
+	}
+
+	verticalPodAutoscaler := &unstructured.Unstructured{Object: map[string]any{"spec": specObject}}
+	verticalPodAutoscaler.SetGroupVersionKind(VerticalPodAutoscalerGVK)
+	verticalPodAutoscaler.SetName(name)
+	verticalPodAutoscaler.SetNamespace(params.OtelCol.Namespace)
+	verticalPodAutoscaler.SetLabels(labels)
+	verticalPodAutoscaler.SetAnnotations(annotations)
+	return verticalPodAutoscaler, nil
+}
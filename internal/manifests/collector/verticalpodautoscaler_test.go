@@ -0,0 +1,103 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package collector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/vpa"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+)
+
+func TestVerticalPodAutoscaler(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		mode v1beta1.Mode
+		kind string
+	}{
+		{name: "deployment", mode: v1beta1.ModeDeployment, kind: "Deployment"},
+		{name: "daemonset", mode: v1beta1.ModeDaemonSet, kind: "DaemonSet"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.New()
+			cfg.VPAAvailability = vpa.Available
+			params := manifests.Params{
+				Config: cfg,
+				OtelCol: v1beta1.OpenTelemetryCollector{
+					ObjectMeta: metav1.ObjectMeta{Name: "my-instance", Namespace: "observability"},
+					Spec: v1beta1.OpenTelemetryCollectorSpec{
+						Mode: tt.mode,
+						VerticalAutoscaler: &v1beta1.VerticalAutoscalerSpec{
+							Enabled:    true,
+							UpdateMode: v1beta1.VerticalAutoscalerUpdateModeInitial,
+							MaxAllowed: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("2Gi")},
+						},
+					},
+				},
+				Log: testLogger,
+			}
+
+			verticalPodAutoscaler, err := VerticalPodAutoscaler(params)
+			require.NoError(t, err)
+			require.NotNil(t, verticalPodAutoscaler)
+
+			assert.Equal(t, VerticalPodAutoscalerGVK, verticalPodAutoscaler.GroupVersionKind())
+			assert.Equal(t, "my-instance-collector", verticalPodAutoscaler.GetName())
+			assert.Equal(t, "observability", verticalPodAutoscaler.GetNamespace())
+
+			targetRef, _, _ := unstructured.NestedStringMap(verticalPodAutoscaler.Object, "spec", "targetRef")
+			assert.Equal(t, map[string]string{"apiVersion": "apps/v1", "kind": tt.kind, "name": "my-instance-collector"}, targetRef)
+			updateMode, _, _ := unstructured.NestedString(verticalPodAutoscaler.Object, "spec", "updatePolicy", "updateMode")
+			assert.Equal(t, "Initial", updateMode)
+
+			policies, _, _ := unstructured.NestedSlice(verticalPodAutoscaler.Object, "spec", "resourcePolicy", "containerPolicies")
+			require.Len(t, policies, 2)
+			assert.Equal(t, map[string]any{
+				"containerName":    "otc-container",
+				"controlledValues": "RequestsAndLimits",
+				"maxAllowed":       map[string]any{"memory": "2Gi"},
+			}, policies[0])
+			assert.Equal(t, map[string]any{"containerName": "*", "mode": "Off"}, policies[1])
+		})
+	}
+}
+
+func TestVerticalPodAutoscalerSkipped(t *testing.T) {
+	for _, tt := range []struct {
+		name         string
+		mode         v1beta1.Mode
+		spec         *v1beta1.VerticalAutoscalerSpec
+		availability vpa.Availability
+	}{
+		{name: "not set", mode: v1beta1.ModeDeployment, availability: vpa.Available},
+		{name: "disabled", mode: v1beta1.ModeDeployment, spec: &v1beta1.VerticalAutoscalerSpec{}, availability: vpa.Available},
+		{name: "statefulset", mode: v1beta1.ModeStatefulSet, spec: &v1beta1.VerticalAutoscalerSpec{Enabled: true}, availability: vpa.Available},
+		{name: "not available", mode: v1beta1.ModeDeployment, spec: &v1beta1.VerticalAutoscalerSpec{Enabled: true}, availability: vpa.NotAvailable},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.New()
+			cfg.VPAAvailability = tt.availability
+			params := manifests.Params{
+				Config: cfg,
+				OtelCol: v1beta1.OpenTelemetryCollector{
+					ObjectMeta: metav1.ObjectMeta{Name: "my-instance"},
+					Spec:       v1beta1.OpenTelemetryCollectorSpec{Mode: tt.mode, VerticalAutoscaler: tt.spec},
+				},
+				Log: testLogger,
+			}
+			verticalPodAutoscaler, err := VerticalPodAutoscaler(params)
+			require.NoError(t, err)
+			assert.Nil(t, verticalPodAutoscaler)
+		})
+	}
+}
@@ -10,7 +10,6 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
-	"github.com/open-telemetry/opentelemetry-operator/internal/components"
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
 	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
@@ -19,12 +18,7 @@ import (
 
 // Volumes builds the volumes for the given instance, including the config map volume.
 func Volumes(cfg config.Config, otelcol v1beta1.OpenTelemetryCollector, ta *v1alpha1.TargetAllocator) []corev1.Volume {
-	collectorCfg := otelcol.Spec.Config.DeepCopy()
-	if cfg.Internal.OperandTLSProfile != nil {
-		_, _ = otelconfig.ApplyDefaults(collectorCfg, logr.Discard(), components.WithTLSProfile(cfg.Internal.OperandTLSProfile))
-	}
-	hash, _ := manifestutils.GetConfigMapSHA(*collectorCfg)
-	configMapName := naming.ConfigMap(otelcol.Name, hash)
+	configMapName := configMapName(cfg, otelcol)
 	volumes := []corev1.Volume{{
 		Name: naming.ConfigMapVolume(),
 		VolumeSource: corev1.VolumeSource{
@@ -43,6 +37,12 @@ func Volumes(cfg config.Config, otelcol v1beta1.OpenTelemetryCollector, ta *v1al
 		volumes = append(volumes, clientVolumes...)
 	}
 
+	if servesReceiverTLS(cfg, otelcol) {
+		volumes = append(volumes, receiverTLSVolume(otelcol))
+	}
+
+	volumes = append(volumes, storagePodVolumes(otelcol)...)
+
 	if len(otelcol.Spec.Volumes) > 0 {
 		volumes = append(volumes, otelcol.Spec.Volumes...)
 	}
@@ -64,3 +64,20 @@ func Volumes(cfg config.Config, otelcol v1beta1.OpenTelemetryCollector, ta *v1al
 
 	return volumes
 }
+
+// configMapName returns the name of the ConfigMap holding the given instance's configuration, which is derived from the
+// configuration the ConfigMap holds.
+func configMapName(cfg config.Config, otelcol v1beta1.OpenTelemetryCollector) string {
+	collectorCfg := otelcol.Spec.Config.DeepCopy()
+	if defaultOpts := configDefaultOptions(cfg, otelcol); len(defaultOpts) > 0 {
+		_, _ = otelconfig.ApplyDefaults(collectorCfg, logr.Discard(), defaultOpts...)
+	}
+	if tunesMemoryLimiter(otelcol) {
+		applyMemoryLimiter(collectorCfg, otelcol.Spec.VerticalAutoscaler.MemoryLimiter)
+	}
+	if syncsStorage(otelcol) {
+		applyFsync(collectorCfg)
+	}
+	hash, _ := manifestutils.GetConfigMapSHA(*collectorCfg)
+	return naming.ConfigMap(otelcol.Name, hash)
+}
@@ -5,18 +5,24 @@
 package collector
 
 import (
+	"slices"
+
 	corev1 "k8s.io/api/core/v1"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
 )
 
 // VolumeClaimTemplates builds the volumeClaimTemplates for the given instance,
-// including the config map volume mount.
+// including the claims of the storage directories of its components.
 func VolumeClaimTemplates(otelcol v1beta1.OpenTelemetryCollector) []corev1.PersistentVolumeClaim {
 	if otelcol.Spec.Mode != "statefulset" {
 		return []corev1.PersistentVolumeClaim{}
 	}
 
 	// Add all user specified claims.
-	return otelcol.Spec.VolumeClaimTemplates
+	claims := otelcol.Spec.VolumeClaimTemplates
+	if storageClaims := storageVolumeClaimTemplates(otelcol); len(storageClaims) > 0 {
+		claims = append(slices.Clone(claims), storageClaims...)
+	}
+	return claims
 }
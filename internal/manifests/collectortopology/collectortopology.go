@@ -0,0 +1,237 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package collectortopology
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+	"github.com/open-telemetry/opentelemetry-operator/internal/otelconfig"
+	"github.com/open-telemetry/opentelemetry-operator/internal/webhook"
+)
+
+const (
+	ComponentCollectorTopology = "collector-topology"
+
+	// Collector name suffixes.
+	AgentCollectorSuffix   = "agent"
+	GatewayCollectorSuffix = "gateway"
+
+	defaultLoadBalancingPort = 4317
+
+	tailSamplingProcessor = "tail_sampling"
+)
+
+// loadBalancingExporters are the exporters added to the agent pipelines of each signal.
+var loadBalancingExporters = map[string]string{
+	"traces":  "loadbalancing/traces",
+	"metrics": "loadbalancing/metrics",
+	"logs":    "loadbalancing/logs",
+}
+
+// AgentCollector returns the name of the OpenTelemetryCollector of the agent tier.
+func AgentCollector(topology string) string {
+	return naming.DNSName(naming.Truncate("%s-%s", 63, topology, AgentCollectorSuffix))
+}
+
+// GatewayCollector returns the name of the OpenTelemetryCollector of the gateway tier.
+func GatewayCollector(topology string) string {
+	return naming.DNSName(naming.Truncate("%s-%s", 63, topology, GatewayCollectorSuffix))
+}
+
+// Build creates the manifest for the CollectorTopology resource.
+func Build(params manifests.Params) ([]client.Object, error) {
+	gateway, err := buildGatewayCollector(params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build gateway collector: %w", err)
+	}
+	agent, err := buildAgentCollector(params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build agent collector: %w", err)
+	}
+	return []client.Object{agent, gateway}, nil
+}
+
+// buildGatewayCollector creates the OpenTelemetryCollector of the gateway tier. The headless Service the collector
+// controller creates for it is the one the agents resolve the gateway collectors with.
+func buildGatewayCollector(params manifests.Params) (*v1beta1.OpenTelemetryCollector, error) {
+	topology := params.CollectorTopology
+	tier := topology.Spec.Gateway
+	cfg := *tier.Config.DeepCopy()
+
+	port := loadBalancingPort(topology.Spec.LoadBalancing)
+	ports, err := otelconfig.GetReceiverPorts(&cfg, params.Log)
+	if err != nil {
+		return nil, err
+	}
+	if !slices.ContainsFunc(ports, func(p corev1.ServicePort) bool { return p.Port == port }) {
+		return nil, fmt.Errorf("no receiver of the gateway listens on the port %d the agents export to", port)
+	}
+
+	if tracesRoutingKey(topology.Spec.LoadBalancing) != v1alpha1.TracesRoutingKeyTraceID && tailSamples(cfg) {
+		return nil, fmt.Errorf("the gateway tail samples the traces, which requires the agents to route the spans by %s", v1alpha1.TracesRoutingKeyTraceID)
+	}
+
+	return tierCollector(params, GatewayCollector(topology.Name), v1beta1.ModeStatefulSet, tier, cfg)
+}
+
+// buildAgentCollector creates the OpenTelemetryCollector of the agent tier, exporting the telemetry of every
+// pipeline to the gateway tier.
+func buildAgentCollector(params manifests.Params) (*v1beta1.OpenTelemetryCollector, error) {
+	topology := params.CollectorTopology
+	tier := topology.Spec.Agent
+	cfg := *tier.Config.DeepCopy()
+
+	if cfg.Exporters.Object == nil {
+		cfg.Exporters.Object = map[string]any{}
+	}
+	for pipelineName, pipeline := range cfg.Service.Pipelines {
+		if pipeline == nil {
+			continue
+		}
+		signal, _, _ := strings.Cut(pipelineName, "/")
+		exporterName, ok := loadBalancingExporters[signal]
+		if !ok {
+			continue
+		}
+		cfg.Exporters.Object[exporterName] = loadBalancingExporter(topology, signal)
+		if !slices.Contains(pipeline.Exporters, exporterName) {
+			pipeline.Exporters = append(pipeline.Exporters, exporterName)
+		}
+	}
+
+	return tierCollector(params, AgentCollector(topology.Name), v1beta1.ModeDaemonSet, tier, cfg)
+}
+
+// loadBalancingExporter returns the configuration of the loadbalancing exporter of the given signal.
+func loadBalancingExporter(topology v1alpha1.CollectorTopology, signal string) map[string]any {
+	spec := topology.Spec.LoadBalancing
+	port := loadBalancingPort(spec)
+	service := naming.HeadlessService(GatewayCollector(topology.Name))
+
+	protocol := map[string]any{"tls": map[string]any{"insecure": true}}
+	if spec.Protocol != nil && len(spec.Protocol.Object) > 0 {
+		protocol = spec.Protocol.DeepCopy().Object
+	}
+
+	var resolver map[string]any
+	switch spec.Resolver {
+	case v1alpha1.LoadBalancingResolverK8s:
+		resolver = map[string]any{"k8s": map[string]any{
+			"service": fmt.Sprintf("%s.%s", service, topology.Namespace),
+			"ports":   []any{port},
+		}}
+	default:
+		resolver = map[string]any{"dns": map[string]any{
+			"hostname": fmt.Sprintf("%s.%s.svc", service, topology.Namespace),
+			"port":     strconv.Itoa(int(port)),
+		}}
+	}
+
+	exporter := map[string]any{
+		"protocol": map[string]any{"otlp": protocol},
+		"resolver": resolver,
+	}
+	switch signal {
+	case "traces":
+		exporter["routing_key"] = string(tracesRoutingKey(spec))
+	case "metrics":
+		exporter["routing_key"] = string(metricsRoutingKey(spec))
+	}
+	return exporter
+}
+
+// tierCollector returns the OpenTelemetryCollector of a tier. The collector is defaulted the way the webhook defaults
+// it, so that it compares equal to the one read back from the API server and isn't updated on every reconciliation.
+func tierCollector(params manifests.Params, name string, mode v1beta1.Mode, tier v1alpha1.CollectorTierSpec, cfg v1beta1.Config) (*v1beta1.OpenTelemetryCollector, error) {
+	topology := params.CollectorTopology
+	labels := manifestutils.Labels(topology.ObjectMeta, name, tier.Image, ComponentCollectorTopology, params.Config.LabelsFilter)
+	labels["app.kubernetes.io/managed-by"] = "opentelemetry-operator"
+	labels["app.kubernetes.io/component"] = ComponentCollectorTopology
+
+	otelcol := &v1beta1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: topology.Namespace,
+			Labels:    labels,
+		},
+		Spec: v1beta1.OpenTelemetryCollectorSpec{
+			OpenTelemetryCommonFields: *tier.OpenTelemetryCommonFields.DeepCopy(),
+			Mode:                      mode,
+			Config:                    cfg,
+		},
+	}
+	defaulter := webhook.NewCollectorWebhook(params.Log, params.Scheme, params.Config, nil, nil, nil, nil, nil)
+	if err := defaulter.Default(context.Background(), otelcol); err != nil {
+		return nil, err
+	}
+	// the config is compared with the one read back from the API server, whose numbers are all decoded as float64
+	normalized, err := normalizeConfig(otelcol.Spec.Config)
+	if err != nil {
+		return nil, err
+	}
+	otelcol.Spec.Config = normalized
+	return otelcol, nil
+}
+
+// tailSamples returns whether a traces pipeline of the configuration runs the tail_sampling processor.
+func tailSamples(cfg v1beta1.Config) bool {
+	for pipelineName, pipeline := range cfg.Service.Pipelines {
+		if signal, _, _ := strings.Cut(pipelineName, "/"); pipeline == nil || signal != "traces" {
+			continue
+		}
+		for _, processor := range pipeline.Processors {
+			if processor == tailSamplingProcessor || strings.HasPrefix(processor, tailSamplingProcessor+"/") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func normalizeConfig(cfg v1beta1.Config) (v1beta1.Config, error) {
+	b, err := json.Marshal(&cfg)
+	if err != nil {
+		return v1beta1.Config{}, err
+	}
+	normalized := v1beta1.Config{}
+	if err := json.Unmarshal(b, &normalized); err != nil {
+		return v1beta1.Config{}, err
+	}
+	return normalized, nil
+}
+
+func loadBalancingPort(spec v1alpha1.LoadBalancingSpec) int32 {
+	if spec.Port == 0 {
+		return defaultLoadBalancingPort
+	}
+	return spec.Port
+}
+
+func tracesRoutingKey(spec v1alpha1.LoadBalancingSpec) v1alpha1.TracesRoutingKey {
+	if spec.TracesRoutingKey == "" {
+		return v1alpha1.TracesRoutingKeyTraceID
+	}
+	return spec.TracesRoutingKey
+}
+
+func metricsRoutingKey(spec v1alpha1.LoadBalancingSpec) v1alpha1.MetricsRoutingKey {
+	if spec.MetricsRoutingKey == "" {
+		return v1alpha1.MetricsRoutingKeyService
+	}
+	return spec.MetricsRoutingKey
+}
@@ -0,0 +1,168 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package collectortopology
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	go_yaml "github.com/goccy/go-yaml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+	"github.com/open-telemetry/opentelemetry-operator/internal/webhook"
+)
+
+const (
+	agentConfig = `receivers:
+  otlp:
+    protocols:
+      grpc: {}
+exporters:
+  debug: {}
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      exporters: [debug]
+    metrics/app:
+      receivers: [otlp]
+      exporters: []
+`
+	gatewayConfig = `receivers:
+  otlp:
+    protocols:
+      grpc: {}
+processors:
+  tail_sampling: {}
+exporters:
+  debug: {}
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      processors: [tail_sampling]
+      exporters: [debug]
+`
+)
+
+func topologyParams(t *testing.T, loadBalancing v1alpha1.LoadBalancingSpec) manifests.Params {
+	var agent, gateway v1beta1.Config
+	require.NoError(t, go_yaml.Unmarshal([]byte(agentConfig), &agent))
+	require.NoError(t, go_yaml.Unmarshal([]byte(gatewayConfig), &gateway))
+	replicas := int32(3)
+	return manifests.Params{
+		Config: config.New(),
+		Log:    logr.Discard(),
+		CollectorTopology: v1alpha1.CollectorTopology{
+			ObjectMeta: metav1.ObjectMeta{Name: "sampling", Namespace: "observability"},
+			Spec: v1alpha1.CollectorTopologySpec{
+				Agent: v1alpha1.CollectorTierSpec{Config: agent},
+				Gateway: v1alpha1.CollectorTierSpec{
+					OpenTelemetryCommonFields: v1beta1.OpenTelemetryCommonFields{Replicas: &replicas},
+					Config:                    gateway,
+				},
+				LoadBalancing: loadBalancing,
+			},
+		},
+	}
+}
+
+func TestBuild(t *testing.T) {
+	params := topologyParams(t, v1alpha1.LoadBalancingSpec{})
+
+	objects, err := Build(params)
+	require.NoError(t, err)
+	require.Len(t, objects, 2)
+
+	agent := objects[0].(*v1beta1.OpenTelemetryCollector)
+	assert.Equal(t, "sampling-agent", agent.Name)
+	assert.Equal(t, "observability", agent.Namespace)
+	assert.Equal(t, v1beta1.ModeDaemonSet, agent.Spec.Mode)
+	assert.Equal(t, ComponentCollectorTopology, agent.Labels["app.kubernetes.io/component"])
+
+	gateway := objects[1].(*v1beta1.OpenTelemetryCollector)
+	assert.Equal(t, "sampling-gateway", gateway.Name)
+	assert.Equal(t, v1beta1.ModeStatefulSet, gateway.Spec.Mode)
+	assert.Equal(t, int32(3), *gateway.Spec.Replicas)
+	assert.Equal(t, params.CollectorTopology.Spec.Gateway.Config.Service.Pipelines, gateway.Spec.Config.Service.Pipelines)
+	assert.Equal(t, params.CollectorTopology.Spec.Gateway.Config.Exporters, gateway.Spec.Config.Exporters)
+
+	// every pipeline of the agent exports to the gateway with the exporter of its signal
+	assert.Equal(t, []string{"debug", "loadbalancing/traces"}, agent.Spec.Config.Service.Pipelines["traces"].Exporters)
+	assert.Equal(t, []string{"loadbalancing/metrics"}, agent.Spec.Config.Service.Pipelines["metrics/app"].Exporters)
+	assert.NotContains(t, agent.Spec.Config.Exporters.Object, "loadbalancing/logs")
+
+	assert.Equal(t, map[string]any{
+		"protocol": map[string]any{"otlp": map[string]any{"tls": map[string]any{"insecure": true}}},
+		"resolver": map[string]any{"dns": map[string]any{
+			"hostname": "sampling-gateway-collector-headless.observability.svc",
+			"port":     "4317",
+		}},
+		"routing_key": "traceID",
+	}, agent.Spec.Config.Exporters.Object["loadbalancing/traces"])
+	assert.Equal(t, "service", agent.Spec.Config.Exporters.Object["loadbalancing/metrics"].(map[string]any)["routing_key"])
+}
+
+func TestBuildDefaultsCollectors(t *testing.T) {
+	params := topologyParams(t, v1alpha1.LoadBalancingSpec{})
+
+	objects, err := Build(params)
+	require.NoError(t, err)
+	for _, object := range objects {
+		otelcol := object.(*v1beta1.OpenTelemetryCollector)
+		assert.Equal(t, v1beta1.ManagementStateManaged, otelcol.Spec.ManagementState)
+		assert.Equal(t, v1beta1.UpgradeStrategyAutomatic, otelcol.Spec.UpgradeStrategy)
+		receiver := otelcol.Spec.Config.Receivers.Object["otlp"].(map[string]any)["protocols"].(map[string]any)["grpc"]
+		assert.Equal(t, map[string]any{"endpoint": "0.0.0.0:4317"}, receiver)
+
+		// the webhook defaulting the stored collector again doesn't change it, so it isn't updated on every
+		// reconciliation
+		stored := otelcol.DeepCopy()
+		require.NoError(t, webhook.NewCollectorWebhook(logr.Discard(), nil, params.Config, nil, nil, nil, nil, nil).Default(context.Background(), stored))
+		stored.Spec.Config, err = normalizeConfig(stored.Spec.Config)
+		require.NoError(t, err)
+		assert.Equal(t, otelcol, stored)
+	}
+}
+
+func TestBuildK8sResolver(t *testing.T) {
+	params := topologyParams(t, v1alpha1.LoadBalancingSpec{
+		Resolver:          v1alpha1.LoadBalancingResolverK8s,
+		MetricsRoutingKey: v1alpha1.MetricsRoutingKeyStreamID,
+		Protocol: &v1beta1.AnyConfig{Object: map[string]any{
+			"compression": "zstd",
+		}},
+	})
+
+	agent, err := buildAgentCollector(params)
+	require.NoError(t, err)
+	exporter := agent.Spec.Config.Exporters.Object["loadbalancing/metrics"].(map[string]any)
+	assert.Equal(t, map[string]any{"k8s": map[string]any{
+		"service": "sampling-gateway-collector-headless.observability",
+		"ports":   []any{float64(4317)},
+	}}, exporter["resolver"])
+	assert.Equal(t, "streamID", exporter["routing_key"])
+	assert.Equal(t, map[string]any{"otlp": map[string]any{"compression": "zstd"}}, exporter["protocol"])
+}
+
+func TestBuildInvalidTopology(t *testing.T) {
+	t.Run("tail sampling routed by service", func(t *testing.T) {
+		params := topologyParams(t, v1alpha1.LoadBalancingSpec{TracesRoutingKey: v1alpha1.TracesRoutingKeyService})
+		_, err := Build(params)
+		assert.ErrorContains(t, err, "the gateway tail samples the traces, which requires the agents to route the spans by traceID")
+	})
+
+	t.Run("gateway not listening on the load balancing port", func(t *testing.T) {
+		params := topologyParams(t, v1alpha1.LoadBalancingSpec{Port: 5317})
+		_, err := Build(params)
+		assert.ErrorContains(t, err, "no receiver of the gateway listens on the port 5317")
+	})
+}
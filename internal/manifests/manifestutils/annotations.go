@@ -19,6 +19,10 @@ import (
 // avoids clobbering prometheus.io/* annotations that the user set out of band.
 const PrometheusAnnotationsAddedKey = "operator.opentelemetry.io/prometheus-annotations-added"
 
+// ConfigHashAnnotation is the pod-template annotation holding the hash of the collector configuration, so that the
+// pods are rolled out when the configuration changes.
+const ConfigHashAnnotation = "opentelemetry-operator-config/sha256"
+
 // Annotations return the annotations for OpenTelemetryCollector resources.
 func Annotations(instance v1beta1.OpenTelemetryCollector, filterAnnotations []string) (map[string]string, error) {
 	// new map every time, so that we don't touch the instance's annotations
@@ -91,7 +95,7 @@ func PodAnnotations(instance v1beta1.OpenTelemetryCollector, filterAnnotations [
 	}
 
 	// Adding the ConfigMap Hash only to PodAnnotations
-	podAnnotations["opentelemetry-operator-config/sha256"] = hash
+	podAnnotations[ConfigHashAnnotation] = hash
 
 	return podAnnotations, nil
 }
@@ -18,11 +18,13 @@ import (
 	policyV1 "k8s.io/api/policy/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
 )
 
@@ -56,6 +58,7 @@ var ImmutableChangeErr *ImmutableFieldChangeErr
 // - Secret
 // - TargetAllocator
 // - HTTPRoute
+// - Unstructured objects, such as the KEDA ScaledObject
 // In order for the operator to reconcile other types, they must be added here.
 // The function returned takes no arguments but instead uses the existing and desired inputs here. Existing is expected
 // to be set by the controller-runtime package through a client get call.
@@ -152,6 +155,16 @@ func MutateFuncFor(existing, desired client.Object) controllerutil.MutateFn {
 			wantHTTPRoute := desired.(*gatewayv1.HTTPRoute)
 			mutateHTTPRoute(httpRoute, wantHTTPRoute)
 
+		case *gatewayv1.GRPCRoute:
+			grpcRoute := existing
+			wantGRPCRoute := desired.(*gatewayv1.GRPCRoute)
+			mutateGRPCRoute(grpcRoute, wantGRPCRoute)
+
+		case *gatewayv1.TLSRoute:
+			tlsRoute := existing
+			wantTLSRoute := desired.(*gatewayv1.TLSRoute)
+			mutateTLSRoute(tlsRoute, wantTLSRoute)
+
 		case *networkingv1.NetworkPolicy:
 			ds := existing
 			wantDs := desired.(*networkingv1.NetworkPolicy)
@@ -192,6 +205,18 @@ func MutateFuncFor(existing, desired client.Object) controllerutil.MutateFn {
 			wantTa := desired.(*v1alpha1.TargetAllocator)
 			mutateTargetAllocator(ta, wantTa)
 
+		case *v1beta1.OpenTelemetryCollector:
+			otelcol := existing
+			wantOtelCol := desired.(*v1beta1.OpenTelemetryCollector)
+			mutateOpenTelemetryCollector(otelcol, wantOtelCol)
+
+		case *unstructured.Unstructured:
+			obj := existing
+			wantObj := desired.(*unstructured.Unstructured)
+			if err := mutateUnstructured(obj, wantObj); err != nil {
+				return err
+			}
+
 		default:
 			t := reflect.TypeOf(existing).String()
 			return fmt.Errorf("missing mutate implementation for resource type: %s", t)
@@ -269,6 +294,37 @@ func mutateHTTPRoute(existing, desired *gatewayv1.HTTPRoute) {
 	existing.Spec.Rules = desired.Spec.Rules
 }
 
+func mutateGRPCRoute(existing, desired *gatewayv1.GRPCRoute) {
+	existing.Spec.Hostnames = desired.Spec.Hostnames
+	existing.Spec.ParentRefs = desired.Spec.ParentRefs
+	existing.Spec.Rules = desired.Spec.Rules
+}
+
+func mutateTLSRoute(existing, desired *gatewayv1.TLSRoute) {
+	existing.Spec.Hostnames = desired.Spec.Hostnames
+	existing.Spec.ParentRefs = desired.Spec.ParentRefs
+	existing.Spec.Rules = desired.Spec.Rules
+}
+
+// mutateUnstructured sets the spec of the objects whose API isn't a dependency of the operator. Their labels and
+// annotations are merged, so the ones set by their controllers, such as KEDA, or by users are kept.
+func mutateUnstructured(existing, desired *unstructured.Unstructured) error {
+	labels := existing.GetLabels()
+	if err := mergeWithOverride(&labels, desired.GetLabels()); err != nil {
+		return err
+	}
+	existing.SetLabels(labels)
+
+	annotations := existing.GetAnnotations()
+	if err := mergeWithOverride(&annotations, desired.GetAnnotations()); err != nil {
+		return err
+	}
+	existing.SetAnnotations(annotations)
+
+	existing.Object["spec"] = desired.Object["spec"]
+	return nil
+}
+
 func mutateNetworkPolicy(existing, desired *networkingv1.NetworkPolicy) {
 	existing.Annotations = desired.Annotations
 	existing.Labels = desired.Labels
@@ -299,6 +355,12 @@ func mutateTargetAllocator(existing, desired *v1alpha1.TargetAllocator) {
 	existing.Spec = desired.Spec
 }
 
+func mutateOpenTelemetryCollector(existing, desired *v1beta1.OpenTelemetryCollector) {
+	existing.Annotations = desired.Annotations
+	existing.Labels = desired.Labels
+	existing.Spec = desired.Spec
+}
+
 func mutateService(existing, desired *corev1.Service) {
 	// ClusterIP and ClusterIPs are immutable once assigned by the API server.
 	clusterIP := existing.Spec.ClusterIP
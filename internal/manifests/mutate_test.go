@@ -12,6 +12,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
 )
@@ -2665,6 +2666,34 @@ func TestGetMutateFunc_MutateNetworkPolicy(t *testing.T) {
 	require.Exactly(t, got.Spec, want.Spec)
 }
 
+func TestGetMutateFunc_MutateUnstructured(t *testing.T) {
+	got := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "keda.sh/v1alpha1",
+		"kind":       "ScaledObject",
+		"metadata": map[string]any{
+			"labels":      map[string]any{"test": "test", "scaledobject.keda.sh/name": "test"},
+			"annotations": map[string]any{"test": "test", "autoscaling.keda.sh/paused": "true"},
+		},
+		"spec": map[string]any{"minReplicaCount": int64(1)},
+	}}
+
+	want := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "keda.sh/v1alpha1",
+		"kind":       "ScaledObject",
+		"spec":       map[string]any{"minReplicaCount": int64(2)},
+	}}
+	want.SetLabels(map[string]string{"test": "changed", "other": "label"})
+	want.SetAnnotations(map[string]string{"other": "annotation"})
+
+	f := MutateFuncFor(got, want)
+	err := f()
+	require.NoError(t, err)
+
+	require.Exactly(t, map[string]string{"test": "changed", "other": "label", "scaledobject.keda.sh/name": "test"}, got.GetLabels())
+	require.Exactly(t, map[string]string{"test": "test", "other": "annotation", "autoscaling.keda.sh/paused": "true"}, got.GetAnnotations())
+	require.Exactly(t, want.Object["spec"], got.Object["spec"])
+}
+
 // TestMutatePodTemplateStripsOperatorStampedPrometheusAnnotations exercises the
 // marker-gated strip introduced for the design pivot agreed in
 // https://github.com/open-telemetry/opentelemetry-operator/pull/5069 (Refs #5043).
@@ -47,6 +47,49 @@ func ConfigMap(params manifests.Params) (*corev1.ConfigMap, error) {
 		config["componentsAllowed"] = params.OpAMPBridge.Spec.ComponentsAllowed
 	}
 
+	if params.OpAMPBridge.Spec.ResourcesAllowed != nil {
+		config["resourcesAllowed"] = params.OpAMPBridge.Spec.ResourcesAllowed
+	}
+
+	if params.OpAMPBridge.Spec.AtomicRemoteConfig {
+		config["atomicRemoteConfig"] = true
+	}
+
+	if len(params.OpAMPBridge.Spec.Packages) > 0 {
+		config["packages"] = params.OpAMPBridge.Spec.Packages
+	}
+
+	if params.OpAMPBridge.Spec.Audit != nil {
+		config["audit"] = params.OpAMPBridge.Spec.Audit
+	}
+
+	if params.OpAMPBridge.Spec.SignatureVerification != nil {
+		config["signatureVerification"] = params.OpAMPBridge.Spec.SignatureVerification
+	}
+
+	if params.OpAMPBridge.Spec.DriftDetection != nil {
+		config["driftDetection"] = params.OpAMPBridge.Spec.DriftDetection
+	}
+
+	if params.OpAMPBridge.Spec.LeaderElection != nil {
+		config["leaderElection"] = params.OpAMPBridge.Spec.LeaderElection
+	}
+
+	if params.OpAMPBridge.Spec.TelemetryForwarding != nil {
+		config["telemetryForwarding"] = params.OpAMPBridge.Spec.TelemetryForwarding
+	}
+
+	if multiplexing := params.OpAMPBridge.Spec.Multiplexing; multiplexing != nil {
+		multiplexingConfig := map[string]any{}
+		if multiplexing.MaxConnections > 0 {
+			multiplexingConfig["maxConnections"] = multiplexing.MaxConnections
+		}
+		if multiplexing.SyncInterval != nil {
+			multiplexingConfig["syncInterval"] = multiplexing.SyncInterval.Duration.String()
+		}
+		config["multiplexing"] = multiplexingConfig
+	}
+
 	if params.OpAMPBridge.Spec.Description != nil {
 		config["description"] = map[string]any{
 			"non_identifying_attributes": params.OpAMPBridge.Spec.Description.NonIdentifyingAttributes,
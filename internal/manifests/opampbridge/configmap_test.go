@@ -5,6 +5,7 @@ package opampbridge
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -28,7 +29,11 @@ func expectedLabels() map[string]string {
 
 func TestDesiredConfigMap(t *testing.T) {
 	data := map[string]string{
-		"remoteconfiguration.yaml": `capabilities:
+		"remoteconfiguration.yaml": `atomicRemoteConfig: true
+audit:
+  storage: configmap
+  maxRecords: 50
+capabilities:
   AcceptsOpAMPConnectionSettings: true
   AcceptsOtherConnectionSettings: true
   AcceptsRemoteConfig: true
@@ -50,13 +55,38 @@ componentsAllowed:
 description:
   non_identifying_attributes:
     hello: world
+driftDetection:
+  policy: enforce
 endpoint: ws://opamp-server:4320/v1/opamp
 headers:
   authorization: access-12345-token
+leaderElection:
+  leaseName: bridge-lease
+multiplexing:
+  maxConnections: 20
+  syncInterval: 1m0s
+packages:
+  collector:
+    image: ghcr.io/open-telemetry/opentelemetry-collector-releases/opentelemetry-collector-k8s
+    digestsAllowed:
+    - sha256:00738c3a6bca8f143995c9c89fd0c1976784d9785ea394fcdfe580fb18754e1e
 proxy:
   url: http://proxy.example.com:8080
   headers:
     Proxy-Authorization: proxy-token
+resourcesAllowed:
+  Instrumentation:
+  - sampler
+  - exporter
+signatureVerification:
+  trustRoots:
+  - |
+    -----BEGIN PUBLIC KEY-----
+    MCowBQYDK2VwAyEAGb9ECWmEzf6FQbrBZ9w7lshQhqowtrbLDFw4rXAxZuE=
+    -----END PUBLIC KEY-----
+telemetryForwarding:
+  signals:
+  - metrics
 `,
 	}
 	tests := []struct {
@@ -116,7 +146,35 @@ proxy:
 						v1alpha1.OpAMPBridgeCapabilityReportsHealth:                  true,
 						v1alpha1.OpAMPBridgeCapabilityReportsRemoteConfig:            true,
 					},
-					ComponentsAllowed: map[string][]string{"receivers": {"otlp"}, "processors": {"memory_limiter"}, "exporters": {"debug"}},
+					ComponentsAllowed:  map[string][]string{"receivers": {"otlp"}, "processors": {"memory_limiter"}, "exporters": {"debug"}},
+					ResourcesAllowed:   map[string][]string{"Instrumentation": {"sampler", "exporter"}},
+					AtomicRemoteConfig: true,
+					SignatureVerification: &v1alpha1.OpAMPBridgeSignatureVerification{
+						TrustRoots: []string{"-----BEGIN PUBLIC KEY-----\nMCowBQYDK2VwAyEAGb9ECWmEzf6FQbrBZ9w7lshQhqowtrbLDFw4rXAxZuE=\n-----END PUBLIC KEY-----\n"},
+					},
+					Audit: &v1alpha1.OpAMPBridgeAudit{
+						Storage:    "configmap",
+						MaxRecords: 50,
+					},
+					DriftDetection: &v1alpha1.OpAMPBridgeDriftDetection{
+						Policy: "enforce",
+					},
+					LeaderElection: &v1alpha1.OpAMPBridgeLeaderElection{
+						LeaseName: "bridge-lease",
+					},
+					TelemetryForwarding: &v1alpha1.OpAMPBridgeTelemetryForwarding{
+						Signals: []v1alpha1.OpAMPBridgeTelemetrySignal{"metrics"},
+					},
+					Multiplexing: &v1alpha1.OpAMPBridgeMultiplexing{
+						MaxConnections: 20,
+						SyncInterval:   &metav1.Duration{Duration: time.Minute},
+					},
+					Packages: map[string]v1alpha1.OpAMPBridgePackage{
+						"collector": {
+							Image:          "ghcr.io/open-telemetry/opentelemetry-collector-releases/opentelemetry-collector-k8s",
+							DigestsAllowed: []string{"sha256:00738c3a6bca8f143995c9c89fd0c1976784d9785ea394fcdfe580fb18754e1e"},
+						},
+					},
 				},
 			}
 
@@ -54,6 +54,18 @@ func Container(cfg config.Config, _ logr.Logger, opampBridge v1alpha1.OpAMPBridg
 		})
 	}
 
+	if opampBridge.Spec.LeaderElection != nil {
+		// the leader labels its pod, so that the Service selects it
+		envVars = append(envVars, corev1.EnvVar{
+			Name: "POD_NAME",
+			ValueFrom: &corev1.EnvVarSource{
+				FieldRef: &corev1.ObjectFieldSelector{
+					FieldPath: "metadata.name",
+				},
+			},
+		})
+	}
+
 	if featuregate.SetGolangFlags.IsEnabled() {
 		envVars = append(envVars, corev1.EnvVar{
 			Name: "GOMEMLIMIT",
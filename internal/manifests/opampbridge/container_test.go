@@ -120,3 +120,20 @@ func TestContainerEnvAliasing(t *testing.T) {
 			"container Env shares backing array with spec")
 	}
 }
+
+func TestContainerLeaderElectionPodName(t *testing.T) {
+	podName := corev1.EnvVar{
+		Name: "POD_NAME",
+		ValueFrom: &corev1.EnvVarSource{
+			FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.name"},
+		},
+	}
+
+	c := Container(config.New(), logger, v1alpha1.OpAMPBridge{})
+	assert.NotContains(t, c.Env, podName)
+
+	c = Container(config.New(), logger, v1alpha1.OpAMPBridge{
+		Spec: v1alpha1.OpAMPBridgeSpec{LeaderElection: &v1alpha1.OpAMPBridgeLeaderElection{}},
+	})
+	assert.Contains(t, c.Env, podName)
+}
@@ -11,6 +11,9 @@ import (
 
 const (
 	ComponentOpAMPBridge = "opentelemetry-opamp-bridge"
+	// LeaderLabelKey labels the pod of the leader of the bridge replicas, with leader election. It must match the
+	// label set by the bridge.
+	LeaderLabelKey = "opentelemetry.io/opamp-bridge-leader"
 )
 
 // Build creates the manifest for the OpAMPBridge resource.
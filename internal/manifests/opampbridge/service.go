@@ -17,6 +17,10 @@ func Service(params manifests.Params) *corev1.Service {
 	name := naming.OpAMPBridgeService(params.OpAMPBridge.Name)
 	labels := manifestutils.Labels(params.OpAMPBridge.ObjectMeta, name, params.OpAMPBridge.Spec.Image, ComponentOpAMPBridge, []string{})
 	selector := manifestutils.SelectorLabels(params.OpAMPBridge.ObjectMeta, ComponentOpAMPBridge)
+	if params.OpAMPBridge.Spec.LeaderElection != nil {
+		// only the leader runs the OpAMP proxy the collectors connect to
+		selector[LeaderLabelKey] = "true"
+	}
 
 	ports := []corev1.ServicePort{{
 		Name:       "opamp-bridge",
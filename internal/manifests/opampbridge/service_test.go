@@ -0,0 +1,30 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package opampbridge
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
+)
+
+func TestServiceSelectsLeader(t *testing.T) {
+	opampBridge := v1alpha1.OpAMPBridge{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-bridge", Namespace: "default"},
+	}
+	selector := manifestutils.SelectorLabels(opampBridge.ObjectMeta, ComponentOpAMPBridge)
+
+	service := Service(manifests.Params{OpAMPBridge: opampBridge, Log: logger})
+	assert.Equal(t, selector, service.Spec.Selector)
+
+	opampBridge.Spec.LeaderElection = &v1alpha1.OpAMPBridgeLeaderElection{}
+	service = Service(manifests.Params{OpAMPBridge: opampBridge, Log: logger})
+	selector[LeaderLabelKey] = "true"
+	assert.Equal(t, selector, service.Spec.Selector, "only the leader runs the OpAMP proxy")
+}
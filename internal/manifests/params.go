@@ -25,7 +25,26 @@ type Params struct {
 	TargetAllocator      *v1alpha1.TargetAllocator
 	OpAMPBridge          v1alpha1.OpAMPBridge
 	ClusterObservability v1alpha1.ClusterObservability
+	CollectorTopology    v1alpha1.CollectorTopology
 	Config               config.Config
 	Reviewer             rbac.SAReviewer
 	ErrorAsWarning       bool
+	// ConfigFromSecrets are the fragments of the collector configuration stored in Secrets. They are left out of
+	// OtelCol.Spec.Config, the collector reads them from the mounted Secrets, so that their content isn't stored in
+	// the collector's ConfigMap.
+	ConfigFromSecrets []ConfigFromSecret
+	// ConfigFragmentSecrets reads the Secrets labeled as config fragments. When nil, they are read with Client.
+	ConfigFragmentSecrets client.Reader
+	// StableConfig is the configuration the collector pods keep running while the instance's one is analyzed on the
+	// canary pods or after it was rolled back, as read from its ConfigMap. The pod template of the collector pods is
+	// built from it, so that their ports, probes and arguments match the configuration they run.
+	StableConfig *v1beta1.Config
+}
+
+// ConfigFromSecret is a fragment of the collector configuration stored in a Secret.
+type ConfigFromSecret struct {
+	Name string
+	Key  string
+	// Hash is the hash of the fragment, rolling the collector out again when it changes.
+	Hash string
 }
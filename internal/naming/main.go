@@ -25,6 +25,16 @@ func ConfigMapVolume() string {
 	return "otc-internal"
 }
 
+// ReceiverTLSVolume returns the name of the volume holding the certificate of the collector's receivers.
+func ReceiverTLSVolume() string {
+	return "otc-receiver-tls"
+}
+
+// StorageVolume returns the name of the volume mounted at a storage directory of the collector's components.
+func StorageVolume(directory string) string {
+	return DNSName(Truncate("otc-storage-%s", 63, directory))
+}
+
 // ConfigMapExtra returns the prefix to use for the extras mounted configmaps in the pod.
 func ConfigMapExtra(extraConfigMapName string) string {
 	return DNSName(Truncate("configmap-%s", 63, extraConfigMapName))
@@ -60,11 +70,26 @@ func Collector(otelcol string) string {
 	return DNSName(Truncate("%s-collector", 63, otelcol))
 }
 
+// CollectorCanary builds the name of the deployment running a new configuration while it's analyzed.
+func CollectorCanary(otelcol string) string {
+	return DNSName(Truncate("%s-collector-canary", 63, otelcol))
+}
+
 // HorizontalPodAutoscaler builds the autoscaler name based on the instance.
 func HorizontalPodAutoscaler(otelcol string) string {
 	return DNSName(Truncate("%s-collector", 63, otelcol))
 }
 
+// ScaledObject builds the KEDA ScaledObject name based on the instance.
+func ScaledObject(otelcol string) string {
+	return DNSName(Truncate("%s-collector", 63, otelcol))
+}
+
+// VerticalPodAutoscaler builds the VerticalPodAutoscaler name based on the instance.
+func VerticalPodAutoscaler(otelcol string) string {
+	return DNSName(Truncate("%s-collector", 63, otelcol))
+}
+
 // PodDisruptionBudget builds the pdb name based on the instance.
 func PodDisruptionBudget(otelcol string) string {
 	return DNSName(Truncate("%s-collector", 63, otelcol))
@@ -140,6 +165,16 @@ func HTTPRoute(otelcol, prefix string) string {
 	return DNSName(Truncate("%s-%s-httproute", 63, otelcol, prefix))
 }
 
+// GRPCRoute builds the GRPCRoute name based on the instance.
+func GRPCRoute(otelcol, prefix string) string {
+	return DNSName(Truncate("%s-%s-grpcroute", 63, otelcol, prefix))
+}
+
+// TLSRoute builds the TLSRoute name based on the instance.
+func TLSRoute(otelcol, prefix string) string {
+	return DNSName(Truncate("%s-%s-tlsroute", 63, otelcol, prefix))
+}
+
 // ClusterRole builds the cluster role name based on the instance.
 func ClusterRole(otelcol, namespace string) string {
 	return DNSName(Truncate("%s-%s-cluster-role", 63, otelcol, namespace))
@@ -150,6 +185,16 @@ func ClusterRoleBinding(otelcol, namespace string) string {
 	return DNSName(Truncate("%s-%s-collector", 63, otelcol, namespace))
 }
 
+// Role builds the name of the roles of the instance in the namespaces its components access.
+func Role(otelcol, namespace string) string {
+	return DNSName(Truncate("%s-%s-role", 63, otelcol, namespace))
+}
+
+// RoleBinding builds the name of the role bindings of the instance in the namespaces its components access.
+func RoleBinding(otelcol, namespace string) string {
+	return DNSName(Truncate("%s-%s-collector", 63, otelcol, namespace))
+}
+
 // TAService returns the name to use for the TargetAllocator service.
 func TAService(taName string) string {
 	return DNSName(Truncate("%s-targetallocator", 63, taName))
@@ -175,6 +220,16 @@ func PodMonitor(otelcol string) string {
 	return DNSName(Truncate("%s-collector", 63, otelcol))
 }
 
+// PrometheusRule builds the PrometheusRule name based on the instance.
+func PrometheusRule(otelcol string) string {
+	return DNSName(Truncate("%s-collector", 63, otelcol))
+}
+
+// Dashboard builds the name of the Grafana dashboard, a ConfigMap or a GrafanaDashboard, based on the instance.
+func Dashboard(otelcol string) string {
+	return DNSName(Truncate("%s-collector-dashboard", 63, otelcol))
+}
+
 // TargetAllocatorServiceAccount returns the TargetAllocator service account resource name.
 func TargetAllocatorServiceAccount(otelcol string) string {
 	return DNSName(Truncate("%s-targetallocator", 63, otelcol))
@@ -205,6 +260,26 @@ func CACertificate(otelcol string) string {
 	return DNSName(Truncate("%s-ca-cert", 63, otelcol))
 }
 
+// CollectorSelfSignedIssuer returns the SelfSigned Issuer name of the collector's receiver CA based on the instance.
+func CollectorSelfSignedIssuer(otelcol string) string {
+	return DNSName(Truncate("%s-collector-self-signed-issuer", 63, otelcol))
+}
+
+// CollectorCAIssuer returns the CA Issuer name of the collector's receiver CA based on the instance.
+func CollectorCAIssuer(otelcol string) string {
+	return DNSName(Truncate("%s-collector-ca-issuer", 63, otelcol))
+}
+
+// CollectorCACertificate returns the CA Certificate and Secret name of the collector's receivers based on the instance.
+func CollectorCACertificate(otelcol string) string {
+	return DNSName(Truncate("%s-collector-ca-cert", 63, otelcol))
+}
+
+// ReceiverCertificate returns the Certificate and Secret name of the collector's receivers based on the instance.
+func ReceiverCertificate(otelcol string) string {
+	return DNSName(Truncate("%s-collector-receiver-cert", 63, otelcol))
+}
+
 // TAServerCertificate returns the Certificate name based on the instance.
 func TAServerCertificate(otelcol string) string {
 	return DNSName(Truncate("%s-ta-server-cert", 63, otelcol))
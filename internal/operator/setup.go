@@ -17,7 +17,10 @@ import (
 	configv1 "github.com/openshift/api/config/v1"
 	openshifttls "github.com/openshift/controller-runtime-common/pkg/tls"
 	"go.uber.org/zap/zapcore"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/selection"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -32,8 +35,10 @@ import (
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect"
 	"github.com/open-telemetry/opentelemetry-operator/internal/components"
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector"
 	"github.com/open-telemetry/opentelemetry-operator/internal/rbac"
 	"github.com/open-telemetry/opentelemetry-operator/internal/version"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/constants"
 )
 
 var setupLog = ctrl.Log.WithName("setup")
@@ -98,6 +103,10 @@ func SetupManager(cfg *config.Config, configFile string, opts zap.Options, schem
 		}),
 		Cache: cache.Options{
 			DefaultNamespaces: namespaces,
+			ByObject: map[client.Object]cache.ByObject{
+				// the pods are only listed to analyze the collectors, so the other ones aren't cached
+				&corev1.Pod{}: {Label: collectorPodSelector()},
+			},
 		},
 	}
 
@@ -302,3 +311,63 @@ func validateFilterPatterns(cfg config.Config) {
 		}
 	}
 }
+
+// NewConfigFragmentCache returns a cache of the Secrets labeled as config fragments of the collectors, added to the
+// manager. The Secrets referenced by spec.configFrom are read and watched through it, so that the manager's cache
+// doesn't hold every Secret of the watched namespaces.
+func NewConfigFragmentCache(mgr ctrl.Manager, cfg config.Config) (cache.Cache, error) {
+	fragmentCache, err := cache.New(mgr.GetConfig(), cache.Options{
+		Scheme:               mgr.GetScheme(),
+		Mapper:               mgr.GetRESTMapper(),
+		DefaultNamespaces:    parseWatchNamespaces(cfg.WatchNamespace),
+		DefaultLabelSelector: configFragmentSelector(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err = mgr.Add(fragmentCache); err != nil {
+		return nil, err
+	}
+	return fragmentCache, nil
+}
+
+// NewRBACCache returns a cache of the Roles and RoleBindings managed by the operator, added to the manager. The roles
+// and bindings the collectors get in the namespaces their components access are read and watched through it, so that
+// the manager's cache doesn't hold every Role and RoleBinding of the cluster.
+func NewRBACCache(mgr ctrl.Manager, cfg config.Config) (cache.Cache, error) {
+	rbacCache, err := cache.New(mgr.GetConfig(), cache.Options{
+		Scheme:               mgr.GetScheme(),
+		Mapper:               mgr.GetRESTMapper(),
+		DefaultNamespaces:    parseWatchNamespaces(cfg.WatchNamespace),
+		DefaultLabelSelector: managedSelector(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err = mgr.Add(rbacCache); err != nil {
+		return nil, err
+	}
+	return rbacCache, nil
+}
+
+// managedSelector selects the objects managed by the operator.
+func managedSelector() labels.Selector {
+	requirement, _ := labels.NewRequirement("app.kubernetes.io/managed-by", selection.Equals, []string{"opentelemetry-operator"})
+	return labels.NewSelector().Add(*requirement)
+}
+
+// configFragmentSelector selects the Secrets labeled as config fragments of the collectors.
+func configFragmentSelector() labels.Selector {
+	requirement, _ := labels.NewRequirement(constants.LabelConfigFragment, selection.Exists, nil)
+	return labels.NewSelector().Add(*requirement)
+}
+
+// collectorPodSelector selects the pods of the collectors' workloads, including their canary pods.
+func collectorPodSelector() labels.Selector {
+	managedBy, _ := labels.NewRequirement("app.kubernetes.io/managed-by", selection.Equals, []string{"opentelemetry-operator"})
+	component, _ := labels.NewRequirement("app.kubernetes.io/component", selection.In, []string{
+		collector.ComponentOpenTelemetryCollector,
+		collector.ComponentOpenTelemetryCollectorCanary,
+	})
+	return labels.NewSelector().Add(*managedBy, *component)
+}
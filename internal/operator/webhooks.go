@@ -50,16 +50,19 @@ func SetupWebhooks(ctx context.Context, mgr ctrl.Manager, cfg config.Config, rev
 		}
 	}
 
+	// the sidecars' config overrides are validated the same way as the collectors, except for the RBAC review, as the
+	// sidecars run with the pods' service accounts
+	var sidecarValidator sidecar.CollectorValidator
 	if cfg.CollectorAvailability == collector.Available {
-		var fipsCheck fips.FIPSCheck
-		if autodetector.FIPSEnabled(ctx) {
+		fipsCheck := NewFIPSCheck(ctx, cfg, autodetector)
+		if fipsCheck != nil {
 			receivers, exporters, processors, extensions := fips.ParseFipsFlag(cfg.FipsDisabledComponents)
 			logger.Info("Fips disabled components", "receivers", receivers, "exporters", exporters, "processors", processors, "extensions", extensions)
-			fipsCheck = fips.NewFipsCheck(receivers, exporters, processors, extensions)
 		}
 		if err := wh.SetupCollectorWebhook(mgr, cfg, reviewer, crdMetrics, bv, fipsCheck); err != nil {
 			return err
 		}
+		sidecarValidator = wh.NewCollectorWebhook(mgr.GetLogger().WithValues("handler", "SidecarConfigOverride"), mgr.GetScheme(), cfg, nil, mgr.GetEventRecorder("opentelemetry-operator"), nil, nil, fipsCheck)
 	}
 
 	if cfg.TargetAllocatorAvailability == targetallocator.Available {
@@ -76,7 +79,7 @@ func SetupWebhooks(ctx context.Context, mgr ctrl.Manager, cfg config.Config, rev
 	mgr.GetWebhookServer().Register("/mutate-v1-pod", &webhook.Admission{
 		Handler: podmutation.NewWebhookHandler(cfg, ctrl.Log.WithName("pod-webhook"), decoder, mgr.GetClient(),
 			[]podmutation.PodMutator{
-				sidecar.NewMutator(logger, cfg, mgr.GetClient()),
+				sidecar.NewMutator(logger, cfg, mgr.GetClient(), sidecarValidator),
 				instrumentation.NewMutator(logger, mgr.GetClient(), mgr.GetEventRecorder("opentelemetry-operator"), cfg),
 			}),
 	})
@@ -90,6 +93,16 @@ func SetupWebhooks(ctx context.Context, mgr ctrl.Manager, cfg config.Config, rev
 	return nil
 }
 
+// NewFIPSCheck returns the check of the collector components disabled on FIPS enabled platforms, or nil when the
+// platform is not FIPS enabled.
+func NewFIPSCheck(ctx context.Context, cfg config.Config, autodetector autodetect.AutoDetect) fips.FIPSCheck {
+	if !autodetector.FIPSEnabled(ctx) {
+		return nil
+	}
+	receivers, exporters, processors, extensions := fips.ParseFipsFlag(cfg.FipsDisabledComponents)
+	return fips.NewFipsCheck(receivers, exporters, processors, extensions)
+}
+
 // NewStandaloneBuildValidator creates a BuildValidator that works without the collector
 // reconciler. It constructs manifests.Params directly from the manager and config.
 func NewStandaloneBuildValidator(mgr ctrl.Manager, cfg config.Config, reviewer rbac.SAReviewer) wh.BuildValidator {
@@ -32,6 +32,7 @@ type networkPolicy struct {
 	metricsPort                int32
 	apiServerPodSelector       *metav1.LabelSelector
 	apiServerNamespaceSelector *metav1.LabelSelector
+	collectorPodSelector       *metav1.LabelSelector
 }
 
 var (
@@ -102,6 +103,15 @@ func WithAPISererNamespaceLabelSelector(selector *metav1.LabelSelector) Option {
 	}
 }
 
+// WithCollectorPodLabelSelector sets the label selector for the collector pods, whose own telemetry the operator
+// scrapes, and enables the egress to them in every namespace. All the TCP ports are allowed, since the telemetry port
+// is set by the configuration of each collector.
+func WithCollectorPodLabelSelector(selector *metav1.LabelSelector) Option {
+	return func(s *networkPolicy) {
+		s.collectorPodSelector = selector
+	}
+}
+
 func (n *networkPolicy) Start(ctx context.Context) error {
 	tcp := corev1.ProtocolTCP
 	apiServerPort := intstr.FromInt32(n.apiServerPort)
@@ -160,6 +170,16 @@ func (n *networkPolicy) Start(ctx context.Context) error {
 		})
 	}
 
+	if n.collectorPodSelector != nil {
+		np.Spec.Egress = append(np.Spec.Egress, networkingv1.NetworkPolicyEgressRule{
+			Ports: []networkingv1.NetworkPolicyPort{{Protocol: &tcp}},
+			To: []networkingv1.NetworkPolicyPeer{{
+				PodSelector:       n.collectorPodSelector,
+				NamespaceSelector: &metav1.LabelSelector{},
+			}},
+		})
+	}
+
 	if n.webhookPort != 0 {
 		webhookPort := intstr.FromInt32(n.webhookPort)
 		np.Spec.Ingress[0].Ports = append(np.Spec.Ingress[0].Ports, networkingv1.NetworkPolicyPort{
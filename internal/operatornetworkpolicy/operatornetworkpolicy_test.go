@@ -272,6 +272,36 @@ func TestStart_WithIngressPorts(t *testing.T) {
 	assert.Equal(t, expected, np)
 }
 
+func TestStart_WithCollectorPodEgress(t *testing.T) {
+	const namespace = "test-ns"
+	clientset := fake.NewClientset(operatorDeployment(namespace))
+
+	collectorSelector := &metav1.LabelSelector{
+		MatchLabels: map[string]string{"app.kubernetes.io/managed-by": "opentelemetry-operator"},
+	}
+	np := startAndCapture(t, clientset, newTestScheme(),
+		WithOperatorNamespace(namespace),
+		WithAPIServerPort(6443),
+		WithAPIServerIPs([]string{"10.0.0.1"}),
+		WithCollectorPodLabelSelector(collectorSelector),
+	)
+
+	tcp := corev1.ProtocolTCP
+	apiServerPort := intstr.FromInt32(6443)
+	assert.Equal(t, []networkingv1.NetworkPolicyEgressRule{
+		{
+			Ports: []networkingv1.NetworkPolicyPort{{Protocol: &tcp, Port: &apiServerPort}},
+			To:    []networkingv1.NetworkPolicyPeer{{IPBlock: &networkingv1.IPBlock{CIDR: "10.0.0.1/32"}}},
+		},
+		{
+			Ports: []networkingv1.NetworkPolicyPort{{Protocol: &tcp}},
+			To: []networkingv1.NetworkPolicyPeer{
+				{PodSelector: collectorSelector, NamespaceSelector: &metav1.LabelSelector{}},
+			},
+		},
+	}, np.Spec.Egress)
+}
+
 func TestStart_FullOpenShiftConfig(t *testing.T) {
 	const namespace = "openshift-opentelemetry-operator"
 	clientset := fake.NewClientset(operatorDeployment(namespace))
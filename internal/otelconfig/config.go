@@ -6,6 +6,7 @@ package otelconfig
 import (
 	"encoding/json"
 	"fmt"
+	"maps"
 	"math"
 	"slices"
 	"strings"
@@ -142,6 +143,147 @@ func getRbacRulesForComponentKinds(c *v1beta1.Config, logger logr.Logger, compon
 	return rules, nil
 }
 
+// getRoleRulesForComponentKinds gets the namespaced RBAC Rules for the given ComponentKind(s), grouped by namespace.
+func getRoleRulesForComponentKinds(c *v1beta1.Config, logger logr.Logger, componentKinds ...v1beta1.ComponentKind) ([]components.RoleRules, error) {
+	rulesByNamespace := map[string][]rbacv1.PolicyRule{}
+	enabledComponents := GetEnabledComponents(c)
+	for _, componentKind := range componentKinds {
+		var retriever components.ParserRetriever
+		var cfg v1beta1.AnyConfig
+		switch componentKind {
+		case v1beta1.KindReceiver:
+			retriever = receivers.ReceiverFor
+			cfg = c.Receivers
+		case v1beta1.KindExporter:
+			retriever = exporters.ParserFor
+			cfg = c.Exporters
+		case v1beta1.KindProcessor:
+			retriever = processors.ProcessorFor
+			if c.Processors == nil {
+				cfg = v1beta1.AnyConfig{}
+			} else {
+				cfg = *c.Processors
+			}
+		case v1beta1.KindExtension:
+			retriever = extensions.ParserFor
+			if c.Extensions == nil {
+				cfg = v1beta1.AnyConfig{}
+			} else {
+				cfg = *c.Extensions
+			}
+		default:
+			logger.V(1).Info("unknown component kind", "kind", componentKind)
+			continue
+		}
+		for componentName := range enabledComponents[componentKind] {
+			parser := retriever(componentName)
+			parsedRules, err := parser.GetRoleRules(logger, cfg.Object[componentName])
+			if err != nil {
+				return nil, err
+			}
+			for _, roleRules := range parsedRules {
+				rulesByNamespace[roleRules.Namespace] = append(rulesByNamespace[roleRules.Namespace], roleRules.Rules...)
+			}
+		}
+	}
+	var rules []components.RoleRules
+	for _, namespace := range slices.Sorted(maps.Keys(rulesByNamespace)) {
+		rules = append(rules, components.RoleRules{Namespace: namespace, Rules: rulesByNamespace[namespace]})
+	}
+	return rules, nil
+}
+
+// getEgressDestinationsForComponentKinds gets the egress destinations for the given ComponentKind(s).
+func getEgressDestinationsForComponentKinds(c *v1beta1.Config, logger logr.Logger, componentKinds ...v1beta1.ComponentKind) ([]components.EgressDestination, error) {
+	var destinations []components.EgressDestination
+	enabledComponents := GetEnabledComponents(c)
+	for _, componentKind := range componentKinds {
+		var retriever components.ParserRetriever
+		var cfg v1beta1.AnyConfig
+		switch componentKind {
+		case v1beta1.KindReceiver:
+			retriever = receivers.ReceiverFor
+			cfg = c.Receivers
+		case v1beta1.KindExporter:
+			retriever = exporters.ParserFor
+			cfg = c.Exporters
+		case v1beta1.KindProcessor:
+			retriever = processors.ProcessorFor
+			if c.Processors == nil {
+				cfg = v1beta1.AnyConfig{}
+			} else {
+				cfg = *c.Processors
+			}
+		case v1beta1.KindExtension:
+			retriever = extensions.ParserFor
+			if c.Extensions == nil {
+				cfg = v1beta1.AnyConfig{}
+			} else {
+				cfg = *c.Extensions
+			}
+		default:
+			logger.V(1).Info("unknown component kind", "kind", componentKind)
+			continue
+		}
+		for componentName := range enabledComponents[componentKind] {
+			parser := retriever(componentName)
+			parsedDestinations, err := parser.GetEgressDestinations(logger, cfg.Object[componentName])
+			if err != nil {
+				return nil, err
+			}
+			destinations = append(destinations, parsedDestinations...)
+		}
+	}
+	return destinations, nil
+}
+
+// getStorageDirectoriesForComponentKinds gets the storage directories for the given ComponentKind(s), sorted by
+// component.
+func getStorageDirectoriesForComponentKinds(c *v1beta1.Config, logger logr.Logger, componentKinds ...v1beta1.ComponentKind) ([]components.StorageDirectory, error) {
+	var directories []components.StorageDirectory
+	enabledComponents := GetEnabledComponents(c)
+	for _, componentKind := range componentKinds {
+		var retriever components.ParserRetriever
+		var cfg v1beta1.AnyConfig
+		switch componentKind {
+		case v1beta1.KindReceiver:
+			retriever = receivers.ReceiverFor
+			cfg = c.Receivers
+		case v1beta1.KindExporter:
+			retriever = exporters.ParserFor
+			cfg = c.Exporters
+		case v1beta1.KindProcessor:
+			retriever = processors.ProcessorFor
+			if c.Processors == nil {
+				cfg = v1beta1.AnyConfig{}
+			} else {
+				cfg = *c.Processors
+			}
+		case v1beta1.KindExtension:
+			retriever = extensions.ParserFor
+			if c.Extensions == nil {
+				cfg = v1beta1.AnyConfig{}
+			} else {
+				cfg = *c.Extensions
+			}
+		default:
+			logger.V(1).Info("unknown component kind", "kind", componentKind)
+			continue
+		}
+		for _, componentName := range slices.Sorted(maps.Keys(enabledComponents[componentKind])) {
+			parser := retriever(componentName)
+			paths, err := parser.GetStorageDirectories(logger, cfg.Object[componentName])
+			if err != nil {
+				return nil, err
+			}
+			for _, path := range paths {
+				directories = append(directories, components.StorageDirectory{Component: componentName, Path: path})
+			}
+		}
+	}
+	return directories, nil
+}
+
 // getPortsForComponentKinds gets the ports for the given ComponentKind(s).
 func getPortsForComponentKinds(c *v1beta1.Config, logger logr.Logger, componentKinds ...v1beta1.ComponentKind) ([]corev1.ServicePort, error) {
 	var ports []corev1.ServicePort
@@ -307,6 +449,22 @@ func GetAllRbacRules(c *v1beta1.Config, logger logr.Logger) ([]rbacv1.PolicyRule
 	return getRbacRulesForComponentKinds(c, logger, v1beta1.KindReceiver, v1beta1.KindExporter, v1beta1.KindProcessor, v1beta1.KindExtension)
 }
 
+// GetAllRoleRules gets the namespaced RBAC rules for all component kinds, grouped by namespace. An empty namespace
+// stands for the namespace of the collector.
+func GetAllRoleRules(c *v1beta1.Config, logger logr.Logger) ([]components.RoleRules, error) {
+	return getRoleRulesForComponentKinds(c, logger, v1beta1.KindReceiver, v1beta1.KindExporter, v1beta1.KindProcessor, v1beta1.KindExtension)
+}
+
+// GetAllEgressDestinations gets the egress destinations for all component kinds.
+func GetAllEgressDestinations(c *v1beta1.Config, logger logr.Logger) ([]components.EgressDestination, error) {
+	return getEgressDestinationsForComponentKinds(c, logger, v1beta1.KindReceiver, v1beta1.KindExporter, v1beta1.KindProcessor, v1beta1.KindExtension)
+}
+
+// GetStorageDirectories gets the directories the enabled components store their data in.
+func GetStorageDirectories(c *v1beta1.Config, logger logr.Logger) ([]components.StorageDirectory, error) {
+	return getStorageDirectoriesForComponentKinds(c, logger, v1beta1.KindReceiver, v1beta1.KindExporter, v1beta1.KindProcessor, v1beta1.KindExtension)
+}
+
 // ApplyDefaults applies default configuration values to the collector config.
 // Optional DefaultsOption arguments can be provided to customize behavior.
 func ApplyDefaults(c *v1beta1.Config, logger logr.Logger, opts ...components.DefaultOption) ([]v1beta1.EventInfo, error) {
@@ -399,6 +557,25 @@ func NullObjects(c *v1beta1.Config) []string {
 	return nullKeys
 }
 
+// ComponentStatusEndpoint returns the port and the path of the endpoint reporting the status of the components of the
+// collector, served by an enabled healthcheckv2 extension, and whether such an extension is enabled.
+func ComponentStatusEndpoint(c *v1beta1.Config, logger logr.Logger) (port int32, path string, ok bool, err error) {
+	if c.Extensions == nil {
+		return 0, "", false, nil
+	}
+	enabledComponents := GetEnabledComponents(c)
+	for _, componentName := range slices.Sorted(maps.Keys(enabledComponents[v1beta1.KindExtension])) {
+		if components.ComponentType(componentName) != extensions.HealthCheckV2Type {
+			continue
+		}
+		port, path, ok, err = extensions.HealthCheckV2StatusEndpoint(logger, c.Extensions.Object[componentName])
+		if err != nil || ok {
+			return port, path, ok, err
+		}
+	}
+	return 0, "", false, nil
+}
+
 // MetricsEndpoint attempts gets the host and port number from the host address without doing any validation regarding the
 // address itself.
 // It works even before env var expansion happens, when a simple `net.SplitHostPort` would fail because of the extra colon
@@ -16,9 +16,11 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	v1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/components"
 )
 
 func TestConfigFiles(t *testing.T) {
@@ -797,6 +799,139 @@ func TestConfig_getEnvironmentVariablesForComponentKinds(t *testing.T) {
 	}
 }
 
+func TestConfig_GetAllRoleRules(t *testing.T) {
+	config := &v1beta1.Config{
+		Receivers: v1beta1.AnyConfig{
+			Object: map[string]any{
+				"prometheus": map[string]any{
+					"config": map[string]any{
+						"scrape_configs": []any{
+							map[string]any{
+								"job_name": "pods",
+								"kubernetes_sd_configs": []any{map[string]any{
+									"role":       "pod",
+									"namespaces": map[string]any{"names": []any{"web", "apps"}},
+								}},
+							},
+						},
+					},
+				},
+			},
+		},
+		Extensions: &v1beta1.AnyConfig{
+			Object: map[string]any{
+				"k8s_observer": map[string]any{
+					"observe_services": true,
+					"namespaces":       []any{"apps"},
+				},
+			},
+		},
+		Service: v1beta1.Service{
+			Extensions: []string{"k8s_observer"},
+			Pipelines: map[string]*v1beta1.Pipeline{
+				"metrics": {
+					Receivers: []string{"prometheus"},
+				},
+			},
+		},
+	}
+
+	rules, err := GetAllRoleRules(config, logr.Discard())
+	require.NoError(t, err)
+	require.Len(t, rules, 2)
+	assert.Equal(t, "apps", rules[0].Namespace)
+	assert.ElementsMatch(t, []rbacv1.PolicyRule{
+		{APIGroups: []string{""}, Resources: []string{"pods"}, Verbs: []string{"get", "list", "watch"}},
+		{APIGroups: []string{""}, Resources: []string{"services"}, Verbs: []string{"list", "watch"}},
+	}, rules[0].Rules)
+	assert.Equal(t, "web", rules[1].Namespace)
+	assert.Equal(t, []rbacv1.PolicyRule{
+		{APIGroups: []string{""}, Resources: []string{"pods"}, Verbs: []string{"get", "list", "watch"}},
+	}, rules[1].Rules)
+}
+
+func TestConfig_GetStorageDirectories(t *testing.T) {
+	config := &v1beta1.Config{
+		Exporters: v1beta1.AnyConfig{
+			Object: map[string]any{
+				"otlp": map[string]any{
+					"sending_queue": map[string]any{"storage": "file_storage/queue"},
+				},
+			},
+		},
+		Extensions: &v1beta1.AnyConfig{
+			Object: map[string]any{
+				"file_storage/queue": map[string]any{
+					"directory":  "/var/lib/otelcol/queue",
+					"compaction": map[string]any{"directory": "/tmp/compaction"},
+				},
+				"file_storage": map[string]any{},
+				// not enabled in the service
+				"file_storage/unused": map[string]any{"directory": "/unused"},
+			},
+		},
+		Service: v1beta1.Service{
+			Extensions: []string{"file_storage/queue", "file_storage"},
+			Pipelines: map[string]*v1beta1.Pipeline{
+				"traces": {
+					Exporters: []string{"otlp"},
+				},
+			},
+		},
+	}
+
+	directories, err := GetStorageDirectories(config, logr.Discard())
+	require.NoError(t, err)
+	assert.Equal(t, []components.StorageDirectory{
+		{Component: "file_storage", Path: "/var/lib/otelcol/file_storage"},
+		{Component: "file_storage/queue", Path: "/var/lib/otelcol/queue"},
+		{Component: "file_storage/queue", Path: "/tmp/compaction"},
+	}, directories)
+}
+
+func TestConfig_ComponentStatusEndpoint(t *testing.T) {
+	config := &v1beta1.Config{
+		Extensions: &v1beta1.AnyConfig{
+			Object: map[string]any{
+				"healthcheckv2": map[string]any{
+					"use_v2": true,
+					"http": map[string]any{
+						"endpoint": "localhost:8080",
+						"status":   map[string]any{"path": "/health/status"},
+					},
+					"component_health": map[string]any{"include_recoverable_errors": true},
+				},
+			},
+		},
+		Service: v1beta1.Service{
+			Extensions: []string{"healthcheckv2"},
+		},
+	}
+
+	port, path, ok, err := ComponentStatusEndpoint(config, logr.Discard())
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, int32(8080), port)
+	assert.Equal(t, "/health/status", path)
+
+	// the defaults keep the settings of the extension
+	_, err = ApplyDefaults(config, logr.Discard())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"use_v2": true,
+		"http": map[string]any{
+			"endpoint": "localhost:8080",
+			"status":   map[string]any{"path": "/health/status"},
+		},
+		"component_health": map[string]any{"include_recoverable_errors": true},
+	}, config.Extensions.Object["healthcheckv2"])
+
+	config.Service.Extensions = nil
+	_, _, ok, err = ComponentStatusEndpoint(config, logr.Discard())
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
 func TestConfig_GetReceiverPorts(t *testing.T) {
 	tests := []struct {
 		name    string
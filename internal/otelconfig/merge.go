@@ -0,0 +1,107 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelconfig
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+)
+
+// ListMode defines how MergeMaps merges a list set in both maps.
+type ListMode int
+
+const (
+	// ListReplace handles the lists like scalars, following the ConflictMode.
+	ListReplace ListMode = iota
+	// ListUnion appends the items of the source list missing from the destination list.
+	ListUnion
+)
+
+// ConflictMode defines how MergeMaps handles a key set to different values in both maps.
+type ConflictMode int
+
+const (
+	// ConflictOverride replaces the destination value with the source value, and removes the keys set to null in the
+	// source.
+	ConflictOverride ConflictMode = iota
+	// ConflictError rejects the conflicting values. A key set to null in the source keeps the destination value.
+	ConflictError
+)
+
+// MergeOptions defines how MergeMaps merges the values set in both maps. Maps are always merged recursively.
+type MergeOptions struct {
+	Lists     ListMode
+	Conflicts ConflictMode
+}
+
+// MergeMaps deep-merges the source map, such as a partial collector configuration, into the destination map.
+func MergeMaps(dst, src map[string]any, opts MergeOptions) error {
+	return mergeMaps(dst, src, opts, "")
+}
+
+func mergeMaps(dst, src map[string]any, opts MergeOptions, path string) error {
+	for k, v := range src {
+		keyPath := k
+		if path != "" {
+			keyPath = path + "." + k
+		}
+		existing, ok := dst[k]
+		if v == nil {
+			if opts.Conflicts == ConflictOverride {
+				delete(dst, k)
+			} else if !ok {
+				// a component without configuration, e.g. `health_check:`
+				dst[k] = nil
+			}
+			continue
+		}
+		if !ok || existing == nil {
+			dst[k] = v
+			continue
+		}
+
+		var conflict error
+		switch value := v.(type) {
+		case map[string]any:
+			existingMap, isMap := existing.(map[string]any)
+			if isMap {
+				if err := mergeMaps(existingMap, value, opts, keyPath); err != nil {
+					return err
+				}
+				continue
+			}
+			conflict = fmt.Errorf("%s is set to a map, which conflicts with the existing value", keyPath)
+		case []any:
+			existingList, isList := existing.([]any)
+			switch {
+			case isList && opts.Lists == ListUnion:
+				for _, item := range value {
+					if !slices.ContainsFunc(existingList, func(e any) bool { return reflect.DeepEqual(e, item) }) {
+						existingList = append(existingList, item)
+					}
+				}
+				dst[k] = existingList
+				continue
+			case !isList:
+				conflict = fmt.Errorf("%s is set to a list, which conflicts with the existing value", keyPath)
+			case !reflect.DeepEqual(existing, v):
+				conflict = fmt.Errorf("%s is set to %v, which conflicts with the existing value %v", keyPath, v, existing)
+			}
+		default:
+			if !reflect.DeepEqual(existing, v) {
+				conflict = fmt.Errorf("%s is set to %v, which conflicts with the existing value %v", keyPath, v, existing)
+			}
+		}
+
+		if conflict == nil {
+			continue
+		}
+		if opts.Conflicts == ConflictError {
+			return conflict
+		}
+		dst[k] = v
+	}
+	return nil
+}
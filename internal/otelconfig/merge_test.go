@@ -0,0 +1,119 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelconfig
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeMaps(t *testing.T) {
+	base := func() map[string]any {
+		return map[string]any{
+			"exporters": map[string]any{
+				"otlp":  map[string]any{"endpoint": "backend:4317"},
+				"debug": map[string]any{},
+			},
+			"service": map[string]any{
+				"pipelines": map[string]any{
+					"traces": map[string]any{"exporters": []any{"otlp"}},
+				},
+			},
+		}
+	}
+	tests := []struct {
+		name          string
+		src           map[string]any
+		opts          MergeOptions
+		expected      map[string]any
+		expectedError string
+	}{
+		{
+			name: "override replaces lists and values",
+			src: map[string]any{
+				"exporters": map[string]any{"otlp": map[string]any{"endpoint": "other:4317"}},
+				"service": map[string]any{
+					"pipelines": map[string]any{"traces": map[string]any{"exporters": []any{"debug"}}},
+				},
+			},
+			opts: MergeOptions{Lists: ListReplace, Conflicts: ConflictOverride},
+			expected: map[string]any{
+				"exporters": map[string]any{
+					"otlp":  map[string]any{"endpoint": "other:4317"},
+					"debug": map[string]any{},
+				},
+				"service": map[string]any{
+					"pipelines": map[string]any{"traces": map[string]any{"exporters": []any{"debug"}}},
+				},
+			},
+		},
+		{
+			name: "override removes null keys",
+			src:  map[string]any{"exporters": map[string]any{"debug": nil}},
+			opts: MergeOptions{Lists: ListReplace, Conflicts: ConflictOverride},
+			expected: map[string]any{
+				"exporters": map[string]any{"otlp": map[string]any{"endpoint": "backend:4317"}},
+				"service": map[string]any{
+					"pipelines": map[string]any{"traces": map[string]any{"exporters": []any{"otlp"}}},
+				},
+			},
+		},
+		{
+			name: "union combines lists and keeps null keys",
+			src: map[string]any{
+				"extensions": map[string]any{"health_check": nil},
+				"exporters":  map[string]any{"debug": nil},
+				"service": map[string]any{
+					"pipelines": map[string]any{"traces": map[string]any{"exporters": []any{"debug", "otlp"}}},
+				},
+			},
+			opts: MergeOptions{Lists: ListUnion, Conflicts: ConflictError},
+			expected: map[string]any{
+				"extensions": map[string]any{"health_check": nil},
+				"exporters": map[string]any{
+					"otlp":  map[string]any{"endpoint": "backend:4317"},
+					"debug": map[string]any{},
+				},
+				"service": map[string]any{
+					"pipelines": map[string]any{"traces": map[string]any{"exporters": []any{"otlp", "debug"}}},
+				},
+			},
+		},
+		{
+			name:          "conflicting value",
+			src:           map[string]any{"exporters": map[string]any{"otlp": map[string]any{"endpoint": "other:4317"}}},
+			opts:          MergeOptions{Lists: ListUnion, Conflicts: ConflictError},
+			expectedError: "exporters.otlp.endpoint is set to other:4317, which conflicts with the existing value backend:4317",
+		},
+		{
+			name: "conflicting list",
+			src: map[string]any{
+				"service": map[string]any{
+					"pipelines": map[string]any{"traces": map[string]any{"exporters": []any{"debug"}}},
+				},
+			},
+			opts:          MergeOptions{Lists: ListReplace, Conflicts: ConflictError},
+			expectedError: "service.pipelines.traces.exporters is set to [debug], which conflicts with the existing value [otlp]",
+		},
+		{
+			name:          "conflicting type",
+			src:           map[string]any{"exporters": []any{"otlp"}},
+			opts:          MergeOptions{Lists: ListUnion, Conflicts: ConflictError},
+			expectedError: "exporters is set to a list, which conflicts with the existing value",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dst := base()
+			err := MergeMaps(dst, tt.src, tt.opts)
+			if tt.expectedError != "" {
+				require.EqualError(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, dst)
+		})
+	}
+}
@@ -20,6 +20,7 @@ const (
 
 type SAReviewer interface {
 	CheckPolicyRules(ctx context.Context, serviceAccount, serviceAccountNamespace string, rules ...*rbacv1.PolicyRule) ([]*v1.SubjectAccessReview, error)
+	CheckNamespacedPolicyRules(ctx context.Context, serviceAccount, serviceAccountNamespace, namespace string, rules ...*rbacv1.PolicyRule) ([]*v1.SubjectAccessReview, error)
 	CanAccess(ctx context.Context, serviceAccount, serviceAccountNamespace string, res *v1.ResourceAttributes, nonResourceAttributes *v1.NonResourceAttributes) (*v1.SubjectAccessReview, error)
 }
 
@@ -51,6 +52,12 @@ func AllSubjectAccessReviewsAllowed(subjectAccessReviews []*v1.SubjectAccessRevi
 
 // CheckPolicyRules is a convenience function that lets the caller check access for a set of PolicyRules.
 func (r *Reviewer) CheckPolicyRules(ctx context.Context, serviceAccount, serviceAccountNamespace string, rules ...*rbacv1.PolicyRule) ([]*v1.SubjectAccessReview, error) {
+	return r.CheckNamespacedPolicyRules(ctx, serviceAccount, serviceAccountNamespace, "", rules...)
+}
+
+// CheckNamespacedPolicyRules checks access for a set of PolicyRules within the given namespace, which is how the
+// rules of a Role are granted. An empty namespace checks the access across all namespaces.
+func (r *Reviewer) CheckNamespacedPolicyRules(ctx context.Context, serviceAccount, serviceAccountNamespace, namespace string, rules ...*rbacv1.PolicyRule) ([]*v1.SubjectAccessReview, error) {
 	var subjectAccessReviews []*v1.SubjectAccessReview
 	var errs []error
 	for _, rule := range rules {
@@ -60,6 +67,7 @@ func (r *Reviewer) CheckPolicyRules(ctx context.Context, serviceAccount, service
 		resourceAttributes := policyRuleToResourceAttributes(rule)
 		nonResourceAttributes := policyRuleToNonResourceAttributes(rule)
 		for _, res := range resourceAttributes {
+			res.Namespace = namespace
 			sar, err := r.CanAccess(ctx, serviceAccount, serviceAccountNamespace, res, nil)
 			subjectAccessReviews = append(subjectAccessReviews, sar)
 			errs = append(errs, err)
@@ -246,6 +246,21 @@ func TestReviewer_CheckPolicyRules(t *testing.T) {
 	}
 }
 
+func TestReviewer_CheckNamespacedPolicyRules(t *testing.T) {
+	r := NewReviewer(reactorFactory(v1.SubjectAccessReviewStatus{Allowed: true}, nil)())
+	got, err := r.CheckNamespacedPolicyRules(context.Background(), "test", "default", "apps", &rbacv1.PolicyRule{
+		Verbs:     []string{"list", "watch"},
+		APIGroups: []string{""},
+		Resources: []string{"pods"},
+	})
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	for _, sar := range got {
+		assert.Equal(t, "apps", sar.Spec.ResourceAttributes.Namespace)
+		assert.Equal(t, "system:serviceaccount:default:test", sar.Spec.User)
+	}
+}
+
 func TestReviewer_CanAccessAsUser(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -0,0 +1,392 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package collector
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+	"github.com/open-telemetry/opentelemetry-operator/internal/otelconfig"
+)
+
+const (
+	conditionTypeComponentsHealthy     = "ComponentsHealthy"
+	conditionTypeExporterDegraded      = "ExporterDegraded"
+	conditionTypeReceiverFailedToStart = "ReceiverFailedToStart"
+	conditionTypeConfigInvalid         = "ConfigInvalid"
+
+	reasonComponentsHealthy     = "Healthy"
+	reasonComponentsUnhealthy   = "ComponentError"
+	reasonComponentsUnreachable = "Unreachable"
+	reasonExporterDegraded      = "ExporterError"
+	reasonReceiverFailed        = "ReceiverError"
+	reasonConfigRejected        = "ConfigRejected"
+	reasonNoIssue               = "NoIssue"
+
+	// componentHealthInterval is how often the status of the components is read from the collector pods.
+	componentHealthInterval = 30 * time.Second
+
+	// componentHealthConcurrency is the number of collector pods whose component status is read at once.
+	componentHealthConcurrency = 10
+
+	// maxConditionIssues is the number of issues listed in the message of a condition.
+	maxConditionIssues = 5
+	// maxConfigErrorLen is the length the configuration errors reported by the collectors are truncated to.
+	maxConfigErrorLen = 256
+
+	statusRecoverableError = "StatusRecoverableError"
+	statusPermanentError   = "StatusPermanentError"
+	statusFatalError       = "StatusFatalError"
+)
+
+// componentHealthConditions are the conditions reflecting the status the collector pods report for their components.
+var componentHealthConditions = []string{
+	conditionTypeComponentsHealthy,
+	conditionTypeExporterDegraded,
+	conditionTypeReceiverFailedToStart,
+	conditionTypeConfigInvalid,
+}
+
+// configErrors are the messages the collector fails to start with when its configuration is invalid.
+var configErrors = []string{"invalid configuration", "failed to get config"}
+
+// healthClient reads the component status of the collectors.
+var healthClient = &http.Client{Timeout: 5 * time.Second}
+
+// componentStatus is the status of a component, or of a group of components, reported by the healthcheckv2
+// extension in its verbose form.
+type componentStatus struct {
+	Healthy    bool                        `json:"healthy"`
+	Status     string                      `json:"status"`
+	Error      string                      `json:"error,omitempty"`
+	Components map[string]*componentStatus `json:"components,omitempty"`
+}
+
+// componentIssue is a component of a pipeline reporting an error.
+type componentIssue struct {
+	pod       string
+	pipeline  string
+	component string
+	status    string
+	err       string
+}
+
+func (i componentIssue) String() string {
+	msg := fmt.Sprintf("%s in the pipeline %s of the pod %s: %s", i.component, i.pipeline, i.pod, i.status)
+	if i.err != "" {
+		msg += ": " + i.err
+	}
+	return msg
+}
+
+var (
+	_ manager.Runnable               = (*ComponentHealthChecker)(nil)
+	_ manager.LeaderElectionRunnable = (*ComponentHealthChecker)(nil)
+)
+
+// ComponentHealthChecker reads the status the collector pods report for their components every
+// componentHealthInterval, and reflects it in the conditions of the collectors. It runs next to the collector
+// controller, rather than in its reconciliations, so that polling the pods neither delays nor requeues them, and it
+// only patches the status of the collectors whose conditions change.
+type ComponentHealthChecker struct {
+	client client.Client
+	log    logr.Logger
+}
+
+func NewComponentHealthChecker(c client.Client, log logr.Logger) *ComponentHealthChecker {
+	return &ComponentHealthChecker{client: c, log: log}
+}
+
+// NeedLeaderElection makes only the leader poll the collector pods.
+func (*ComponentHealthChecker) NeedLeaderElection() bool {
+	return true
+}
+
+// Start updates the component health of the collectors until the context is done.
+func (h *ComponentHealthChecker) Start(ctx context.Context) error {
+	ticker := time.NewTicker(componentHealthInterval)
+	defer ticker.Stop()
+	for {
+		h.checkCollectors(ctx)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (h *ComponentHealthChecker) checkCollectors(ctx context.Context) {
+	otelcols := &v1beta1.OpenTelemetryCollectorList{}
+	if err := h.client.List(ctx, otelcols); err != nil {
+		h.log.Error(err, "failed to list the collectors")
+		return
+	}
+	for _, otelcol := range otelcols.Items {
+		if err := h.checkCollector(ctx, otelcol); err != nil {
+			h.log.Error(err, "failed to update the component health of the collector", "name", otelcol.Name, "namespace", otelcol.Namespace)
+		}
+	}
+}
+
+// checkCollector patches the component health conditions of the given collector when they change.
+func (h *ComponentHealthChecker) checkCollector(ctx context.Context, otelcol v1beta1.OpenTelemetryCollector) error {
+	if otelcol.Spec.ManagementState == v1beta1.ManagementStateUnmanaged || otelcol.DeletionTimestamp != nil {
+		return nil
+	}
+	changed := otelcol.DeepCopy()
+	if err := updateComponentHealth(ctx, h.client, h.log, changed); err != nil {
+		return err
+	}
+	if equality.Semantic.DeepEqual(changed.Status.Conditions, otelcol.Status.Conditions) {
+		return nil
+	}
+	// rather than overwriting the conditions the collector controller set in the meantime, the patch fails and the
+	// health is read again on the next tick
+	return h.client.Status().Patch(ctx, changed, client.MergeFromWithOptions(&otelcol, client.MergeFromWithOptimisticLock{}))
+}
+
+// updateComponentHealth reflects the status the collector pods report for their components in the conditions of the
+// collector, when a healthcheckv2 extension serves it.
+func updateComponentHealth(ctx context.Context, c client.Client, log logr.Logger, changed *v1beta1.OpenTelemetryCollector) error {
+	port, path, ok, err := otelconfig.ComponentStatusEndpoint(&changed.Spec.Config, log)
+	if err != nil {
+		return err
+	}
+	if !ok || changed.Spec.Mode == v1beta1.ModeSidecar {
+		for _, conditionType := range componentHealthConditions {
+			meta.RemoveStatusCondition(&changed.Status.Conditions, conditionType)
+		}
+		return nil
+	}
+
+	pods := &corev1.PodList{}
+	if err := c.List(ctx, pods,
+		client.InNamespace(changed.Namespace),
+		client.MatchingLabels(manifestutils.SelectorLabels(changed.ObjectMeta, collector.ComponentOpenTelemetryCollector)),
+	); err != nil {
+		return fmt.Errorf("failed to list the collector pods: %w", err)
+	}
+
+	var configIssues []string
+	var runningPods []corev1.Pod
+	for _, pod := range pods.Items {
+		if pod.DeletionTimestamp != nil {
+			continue
+		}
+		if configErr := configError(pod); configErr != "" {
+			configIssues = append(configIssues, fmt.Sprintf("the collector of the pod %s rejected its configuration: %s", pod.Name, configErr))
+		}
+		if pod.Status.Phase == corev1.PodRunning && pod.Status.PodIP != "" {
+			runningPods = append(runningPods, pod)
+		}
+	}
+
+	statuses := readComponentStatuses(ctx, log, runningPods, port, path)
+	var exporterIssues, receiverIssues, otherIssues []componentIssue
+	for _, pod := range runningPods {
+		status, found := statuses[pod.Name]
+		if !found {
+			continue
+		}
+		for _, issue := range componentIssues(pod.Name, status) {
+			kind, _, _ := strings.Cut(issue.component, ":")
+			switch {
+			case kind == "exporter":
+				exporterIssues = append(exporterIssues, issue)
+			case kind == "receiver" && issue.status != statusRecoverableError:
+				receiverIssues = append(receiverIssues, issue)
+			default:
+				otherIssues = append(otherIssues, issue)
+			}
+		}
+	}
+
+	setIssueCondition(changed, conditionTypeConfigInvalid, reasonConfigRejected, configIssues)
+	setIssueCondition(changed, conditionTypeExporterDegraded, reasonExporterDegraded, issueMessages(exporterIssues))
+	setIssueCondition(changed, conditionTypeReceiverFailedToStart, reasonReceiverFailed, issueMessages(receiverIssues))
+
+	allIssues := slices.Concat(configIssues, issueMessages(receiverIssues), issueMessages(exporterIssues), issueMessages(otherIssues))
+	switch {
+	case len(allIssues) > 0:
+		setComponentHealthCondition(changed, conditionTypeComponentsHealthy, metav1.ConditionFalse, reasonComponentsUnhealthy, summarize(allIssues))
+	case len(statuses) == 0:
+		setComponentHealthCondition(changed, conditionTypeComponentsHealthy, metav1.ConditionUnknown, reasonComponentsUnreachable,
+			fmt.Sprintf("the status of the components couldn't be read from any of the %d running collector pods", len(runningPods)))
+	default:
+		setComponentHealthCondition(changed, conditionTypeComponentsHealthy, metav1.ConditionTrue, reasonComponentsHealthy,
+			fmt.Sprintf("the components of the %d collector pods report no error", len(statuses)))
+	}
+	return nil
+}
+
+// readComponentStatuses reads the status of the components of the given pods, by pod name. The pods whose status
+// can't be read are left out.
+func readComponentStatuses(ctx context.Context, log logr.Logger, pods []corev1.Pod, port int32, path string) map[string]*componentStatus {
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	statuses := map[string]*componentStatus{}
+	sem := make(chan struct{}, componentHealthConcurrency)
+	for _, pod := range pods {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			status, err := readComponentStatus(ctx, pod, port, path)
+			if err != nil {
+				log.V(2).Info("failed to read the component status of a collector pod", "pod", pod.Name, "err", err)
+				return
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			statuses[pod.Name] = status
+		}()
+	}
+	wg.Wait()
+	return statuses
+}
+
+// readComponentStatus reads the verbose status of the components of the given pod from its healthcheckv2 extension.
+func readComponentStatus(ctx context.Context, pod corev1.Pod, port int32, path string) (*componentStatus, error) {
+	url := fmt.Sprintf("http://%s%s?verbose", net.JoinHostPort(pod.Status.PodIP, strconv.Itoa(int(port))), path)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := healthClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	// the extension answers with 503 when the collector is unhealthy, along with the status of the components
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusServiceUnavailable {
+		return nil, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	status := &componentStatus{}
+	if err := json.NewDecoder(resp.Body).Decode(status); err != nil {
+		return nil, err
+	}
+	return status, nil
+}
+
+// componentIssues returns the components of the pipelines of the given status reporting an error, sorted by pipeline
+// and component.
+func componentIssues(pod string, status *componentStatus) []componentIssue {
+	var issues []componentIssue
+	for _, pipelineKey := range slices.Sorted(maps.Keys(status.Components)) {
+		pipeline, isPipeline := strings.CutPrefix(pipelineKey, "pipeline:")
+		if !isPipeline || status.Components[pipelineKey] == nil {
+			continue
+		}
+		pipelineStatus := status.Components[pipelineKey]
+		for _, component := range slices.Sorted(maps.Keys(pipelineStatus.Components)) {
+			componentStatus := pipelineStatus.Components[component]
+			if componentStatus == nil || !isErrorStatus(componentStatus.Status) {
+				continue
+			}
+			issues = append(issues, componentIssue{
+				pod:       pod,
+				pipeline:  pipeline,
+				component: component,
+				status:    componentStatus.Status,
+				err:       componentStatus.Error,
+			})
+		}
+	}
+	return issues
+}
+
+func isErrorStatus(status string) bool {
+	return status == statusRecoverableError || status == statusPermanentError || status == statusFatalError
+}
+
+// configError returns the configuration error the collector container of the given pod last exited with, while it
+// isn't running again. The container reports the end of its logs as termination message when it fails.
+func configError(pod corev1.Pod) string {
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name != naming.Container() || status.State.Running != nil {
+			continue
+		}
+		terminated := status.State.Terminated
+		if terminated == nil {
+			terminated = status.LastTerminationState.Terminated
+		}
+		if terminated == nil || terminated.ExitCode == 0 {
+			continue
+		}
+		for _, line := range strings.Split(terminated.Message, "\n") {
+			for _, configErr := range configErrors {
+				if idx := strings.Index(line, configErr); idx >= 0 {
+					return truncate(strings.TrimSpace(line[idx:]), maxConfigErrorLen)
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// setIssueCondition sets a condition which is true when there are issues.
+func setIssueCondition(changed *v1beta1.OpenTelemetryCollector, conditionType, reason string, issues []string) {
+	if len(issues) == 0 {
+		setComponentHealthCondition(changed, conditionType, metav1.ConditionFalse, reasonNoIssue, "")
+		return
+	}
+	setComponentHealthCondition(changed, conditionType, metav1.ConditionTrue, reason, summarize(issues))
+}
+
+func setComponentHealthCondition(changed *v1beta1.OpenTelemetryCollector, conditionType string, status metav1.ConditionStatus, reason, msg string) {
+	meta.SetStatusCondition(&changed.Status.Conditions, metav1.Condition{
+		Type:               conditionType,
+		Status:             status,
+		ObservedGeneration: changed.Generation,
+		Reason:             reason,
+		Message:            msg,
+		LastTransitionTime: metav1.Now(),
+	})
+}
+
+func issueMessages(issues []componentIssue) []string {
+	messages := make([]string, 0, len(issues))
+	for _, issue := range issues {
+		messages = append(messages, issue.String())
+	}
+	return messages
+}
+
+// summarize joins the first issues, and counts the other ones.
+func summarize(issues []string) string {
+	if len(issues) <= maxConditionIssues {
+		return strings.Join(issues, "; ")
+	}
+	return fmt.Sprintf("%s; and %d more", strings.Join(issues[:maxConditionIssues], "; "), len(issues)-maxConditionIssues)
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n] + "..."
+}
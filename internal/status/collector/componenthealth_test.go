@@ -0,0 +1,268 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package collector
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+)
+
+const healthyStatus = `{
+  "healthy": true,
+  "status": "StatusOK",
+  "components": {
+    "extensions": {"healthy": true, "status": "StatusOK", "components": {"extension:healthcheckv2": {"healthy": true, "status": "StatusOK"}}},
+    "pipeline:traces": {
+      "healthy": true,
+      "status": "StatusOK",
+      "components": {
+        "receiver:otlp": {"healthy": true, "status": "StatusOK"},
+        "processor:batch": {"healthy": true, "status": "StatusOK"},
+        "exporter:otlp": {"healthy": true, "status": "StatusOK"}
+      }
+    }
+  }
+}`
+
+const degradedStatus = `{
+  "healthy": false,
+  "status": "StatusPermanentError",
+  "components": {
+    "pipeline:traces": {
+      "healthy": false,
+      "status": "StatusRecoverableError",
+      "components": {
+        "receiver:otlp": {"healthy": true, "status": "StatusOK"},
+        "exporter:otlp": {"healthy": false, "status": "StatusRecoverableError", "error": "rpc error: code = Unavailable"}
+      }
+    },
+    "pipeline:metrics": {
+      "healthy": false,
+      "status": "StatusPermanentError",
+      "components": {
+        "receiver:prometheus": {"healthy": false, "status": "StatusPermanentError", "error": "listen tcp :8888: bind: address already in use"},
+        "exporter:otlp": {"healthy": true, "status": "StatusOK"}
+      }
+    }
+  }
+}`
+
+// componentStatusServer serves the given component status and returns the port it listens on.
+func componentStatusServer(t *testing.T, code int, body string) int32 {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/status" || !r.URL.Query().Has("verbose") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(code)
+		_, _ = fmt.Fprint(w, body)
+	}))
+	t.Cleanup(server.Close)
+	_, port, err := net.SplitHostPort(server.Listener.Addr().String())
+	require.NoError(t, err)
+	p, err := strconv.Atoi(port)
+	require.NoError(t, err)
+	return int32(p)
+}
+
+func collectorPod(otelcol v1beta1.OpenTelemetryCollector, name string, containerStatus corev1.ContainerStatus) *corev1.Pod {
+	containerStatus.Name = naming.Container()
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: otelcol.Namespace,
+			Labels:    manifestutils.SelectorLabels(otelcol.ObjectMeta, collector.ComponentOpenTelemetryCollector),
+		},
+		Status: corev1.PodStatus{
+			Phase:             corev1.PodRunning,
+			PodIP:             "127.0.0.1",
+			ContainerStatuses: []corev1.ContainerStatus{containerStatus},
+		},
+	}
+}
+
+func healthCheckedCollector(port int32) v1beta1.OpenTelemetryCollector {
+	return v1beta1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+		Spec: v1beta1.OpenTelemetryCollectorSpec{
+			Mode: v1beta1.ModeDeployment,
+			Config: v1beta1.Config{
+				Extensions: &v1beta1.AnyConfig{Object: map[string]any{
+					"healthcheckv2": map[string]any{
+						"use_v2": true,
+						"http":   map[string]any{"endpoint": fmt.Sprintf("0.0.0.0:%d", port)},
+					},
+				}},
+				Service: v1beta1.Service{Extensions: []string{"healthcheckv2"}},
+			},
+		},
+	}
+}
+
+func TestUpdateComponentHealth(t *testing.T) {
+	running := corev1.ContainerStatus{State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}}
+	crashing := corev1.ContainerStatus{
+		State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+		LastTerminationState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+			ExitCode: 1,
+			Message:  "2024-01-01T00:00:00.000Z\tinfo\tservice starting\nError: invalid configuration: exporters::otlp: requires a non-empty \"endpoint\"\n",
+		}},
+	}
+
+	for _, tt := range []struct {
+		name     string
+		code     int
+		body     string
+		pods     func(otelcol v1beta1.OpenTelemetryCollector) []client.Object
+		expected map[string]metav1.ConditionStatus
+		messages map[string]string
+	}{
+		{
+			name: "healthy",
+			code: http.StatusOK,
+			body: healthyStatus,
+			pods: func(otelcol v1beta1.OpenTelemetryCollector) []client.Object {
+				return []client.Object{collectorPod(otelcol, "test-collector-0", running)}
+			},
+			expected: map[string]metav1.ConditionStatus{
+				conditionTypeComponentsHealthy:     metav1.ConditionTrue,
+				conditionTypeExporterDegraded:      metav1.ConditionFalse,
+				conditionTypeReceiverFailedToStart: metav1.ConditionFalse,
+				conditionTypeConfigInvalid:         metav1.ConditionFalse,
+			},
+		},
+		{
+			name: "degraded",
+			code: http.StatusServiceUnavailable,
+			body: degradedStatus,
+			pods: func(otelcol v1beta1.OpenTelemetryCollector) []client.Object {
+				return []client.Object{collectorPod(otelcol, "test-collector-0", running)}
+			},
+			expected: map[string]metav1.ConditionStatus{
+				conditionTypeComponentsHealthy:     metav1.ConditionFalse,
+				conditionTypeExporterDegraded:      metav1.ConditionTrue,
+				conditionTypeReceiverFailedToStart: metav1.ConditionTrue,
+				conditionTypeConfigInvalid:         metav1.ConditionFalse,
+			},
+			messages: map[string]string{
+				conditionTypeExporterDegraded:      "exporter:otlp in the pipeline traces of the pod test-collector-0: StatusRecoverableError: rpc error: code = Unavailable",
+				conditionTypeReceiverFailedToStart: "receiver:prometheus in the pipeline metrics of the pod test-collector-0: StatusPermanentError: listen tcp :8888: bind: address already in use",
+			},
+		},
+		{
+			name: "invalid configuration",
+			code: http.StatusOK,
+			body: healthyStatus,
+			pods: func(otelcol v1beta1.OpenTelemetryCollector) []client.Object {
+				return []client.Object{
+					collectorPod(otelcol, "test-collector-0", running),
+					collectorPod(otelcol, "test-collector-1", crashing),
+				}
+			},
+			expected: map[string]metav1.ConditionStatus{
+				conditionTypeComponentsHealthy:     metav1.ConditionFalse,
+				conditionTypeExporterDegraded:      metav1.ConditionFalse,
+				conditionTypeReceiverFailedToStart: metav1.ConditionFalse,
+				conditionTypeConfigInvalid:         metav1.ConditionTrue,
+			},
+			messages: map[string]string{
+				conditionTypeConfigInvalid: "the collector of the pod test-collector-1 rejected its configuration: invalid configuration: exporters::otlp: requires a non-empty \"endpoint\"",
+			},
+		},
+		{
+			name: "unreachable",
+			code: http.StatusNotFound,
+			pods: func(otelcol v1beta1.OpenTelemetryCollector) []client.Object {
+				return []client.Object{collectorPod(otelcol, "test-collector-0", running)}
+			},
+			expected: map[string]metav1.ConditionStatus{
+				conditionTypeComponentsHealthy:     metav1.ConditionUnknown,
+				conditionTypeExporterDegraded:      metav1.ConditionFalse,
+				conditionTypeReceiverFailedToStart: metav1.ConditionFalse,
+				conditionTypeConfigInvalid:         metav1.ConditionFalse,
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			otelcol := healthCheckedCollector(componentStatusServer(t, tt.code, tt.body))
+			c := fake.NewClientBuilder().WithObjects(tt.pods(otelcol)...).Build()
+
+			changed := otelcol.DeepCopy()
+			require.NoError(t, updateComponentHealth(context.Background(), c, logr.Discard(), changed))
+
+			for conditionType, status := range tt.expected {
+				condition := meta.FindStatusCondition(changed.Status.Conditions, conditionType)
+				require.NotNil(t, condition, conditionType)
+				assert.Equal(t, status, condition.Status, conditionType)
+				if msg, ok := tt.messages[conditionType]; ok {
+					assert.Equal(t, msg, condition.Message)
+				}
+			}
+		})
+	}
+}
+
+func TestUpdateComponentHealthDisabled(t *testing.T) {
+	otelcol := healthCheckedCollector(13133)
+	otelcol.Spec.Config.Extensions.Object["healthcheckv2"] = map[string]any{}
+	otelcol.Status.Conditions = []metav1.Condition{
+		{Type: conditionTypeComponentsHealthy, Status: metav1.ConditionTrue},
+		{Type: conditionTypeExporterDegraded, Status: metav1.ConditionFalse},
+	}
+
+	changed := otelcol.DeepCopy()
+	require.NoError(t, updateComponentHealth(context.Background(), nil, logr.Discard(), changed))
+	assert.Empty(t, changed.Status.Conditions)
+}
+
+func TestComponentHealthCheckerPatchesStatus(t *testing.T) {
+	s := runtime.NewScheme()
+	require.NoError(t, scheme.AddToScheme(s))
+	require.NoError(t, v1beta1.AddToScheme(s))
+
+	otelcol := healthCheckedCollector(componentStatusServer(t, http.StatusServiceUnavailable, degradedStatus))
+	running := corev1.ContainerStatus{State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}}
+	c := fake.NewClientBuilder().
+		WithScheme(s).
+		WithObjects(&otelcol, collectorPod(otelcol, "test-collector-0", running)).
+		WithStatusSubresource(&v1beta1.OpenTelemetryCollector{}).
+		Build()
+	checker := NewComponentHealthChecker(c, logr.Discard())
+
+	checker.checkCollectors(context.Background())
+	stored := &v1beta1.OpenTelemetryCollector{}
+	require.NoError(t, c.Get(context.Background(), client.ObjectKeyFromObject(&otelcol), stored))
+	condition := meta.FindStatusCondition(stored.Status.Conditions, conditionTypeExporterDegraded)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionTrue, condition.Status)
+	// only the status is patched
+	assert.Equal(t, otelcol.Spec, stored.Spec)
+
+	// the status isn't patched again while the conditions don't change
+	resourceVersion := stored.ResourceVersion
+	checker.checkCollectors(context.Background())
+	require.NoError(t, c.Get(context.Background(), client.ObjectKeyFromObject(&otelcol), stored))
+	assert.Equal(t, resourceVersion, stored.ResourceVersion)
+}
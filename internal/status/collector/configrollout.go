@@ -0,0 +1,247 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package collector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/common/expfmt"
+	"github.com/prometheus/common/model"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+	"github.com/open-telemetry/opentelemetry-operator/internal/otelconfig"
+)
+
+const (
+	conditionTypeConfigRollout = "ConfigRollout"
+
+	reasonConfigPromoted    = "Promoted"
+	reasonConfigProgressing = "Progressing"
+	reasonConfigRolledBack  = "RolledBack"
+
+	// canaryAnalysisInterval is how often the canary pods are checked while a configuration is analyzed.
+	canaryAnalysisInterval = 15 * time.Second
+
+	sentMetricsPrefix       = "otelcol_exporter_sent_"
+	sendFailedMetricsPrefix = "otelcol_exporter_send_failed_"
+)
+
+var errUnhealthyCanary = errors.New("the canary pods are unhealthy")
+
+// metricsClient scrapes the collectors' own telemetry.
+var metricsClient = &http.Client{Timeout: 5 * time.Second}
+
+// updateConfigRollout progresses the rollout of the collector's configuration: a new configuration is analyzed on the
+// canary pods, then promoted to every pod once they stayed healthy for the analysis duration, or rolled back as soon
+// as they are unhealthy. It returns how long to wait before checking the canary pods again, if a configuration is
+// being analyzed.
+func updateConfigRollout(ctx context.Context, params manifests.Params, changed *v1beta1.OpenTelemetryCollector) (time.Duration, error) {
+	spec := changed.Spec.ConfigRollout
+	if spec == nil || (changed.Spec.Mode != v1beta1.ModeDeployment && changed.Spec.Mode != v1beta1.ModeStatefulSet) {
+		changed.Status.ConfigRollout = nil
+		meta.RemoveStatusCondition(&changed.Status.Conditions, conditionTypeConfigRollout)
+		return 0, nil
+	}
+
+	// the configuration the pods run is the one of the params, which includes the fragments referenced by configFrom
+	current, err := collector.CurrentConfigVersion(params.Config, params.OtelCol)
+	if err != nil {
+		return 0, err
+	}
+	if changed.Status.ConfigRollout == nil {
+		changed.Status.ConfigRollout = &v1beta1.ConfigRolloutStatus{}
+	}
+	rollout := changed.Status.ConfigRollout
+
+	switch {
+	case rollout.Stable == nil || rollout.Stable.Hash == current.Hash:
+		// the first configuration is trusted, and a configuration reverted to the stable one is already rolled out
+		rollout.Stable = &current
+		rollout.Canary = nil
+		rollout.CanaryStartTime = nil
+		setConfigRolloutCondition(changed, metav1.ConditionTrue, reasonConfigPromoted, fmt.Sprintf("the configuration %s is rolled out", current.ConfigMap))
+		return 0, nil
+	case rollout.Failed != nil && rollout.Failed.Hash == current.Hash:
+		return 0, nil
+	case rollout.Canary == nil || rollout.Canary.Hash != current.Hash:
+		rollout.Canary = &current
+		rollout.CanaryStartTime = ptr.To(metav1.Now())
+		msg := fmt.Sprintf("the configuration %s is analyzed on %d canary pods", current.ConfigMap, spec.CanaryReplicas)
+		setConfigRolloutCondition(changed, metav1.ConditionFalse, reasonConfigProgressing, msg)
+		params.Recorder.Eventf(changed, nil, corev1.EventTypeNormal, reasonConfigProgressing, reasonConfigProgressing, msg)
+		return canaryAnalysisInterval, nil
+	}
+
+	elapsed := time.Since(rollout.CanaryStartTime.Time)
+	ready, err := analyzeCanary(ctx, params, changed, current, elapsed)
+	if errors.Is(err, errUnhealthyCanary) {
+		msg := fmt.Sprintf("the configuration %s was rolled back to %s: %s", current.ConfigMap, rollout.Stable.ConfigMap, err)
+		rollout.Failed = rollout.Canary
+		rollout.Canary = nil
+		rollout.CanaryStartTime = nil
+		setConfigRolloutCondition(changed, metav1.ConditionFalse, reasonConfigRolledBack, msg)
+		params.Recorder.Eventf(changed, nil, corev1.EventTypeWarning, reasonConfigRolledBack, reasonConfigRolledBack, msg)
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	if !ready || elapsed < spec.AnalysisDuration.Duration {
+		return min(canaryAnalysisInterval, max(spec.AnalysisDuration.Duration-elapsed, time.Second)), nil
+	}
+
+	msg := fmt.Sprintf("the configuration %s is rolled out", current.ConfigMap)
+	rollout.Stable = rollout.Canary
+	rollout.Canary = nil
+	rollout.CanaryStartTime = nil
+	rollout.Failed = nil
+	setConfigRolloutCondition(changed, metav1.ConditionTrue, reasonConfigPromoted, msg)
+	params.Recorder.Eventf(changed, nil, corev1.EventTypeNormal, reasonConfigPromoted, reasonConfigPromoted, msg)
+	return 0, nil
+}
+
+func setConfigRolloutCondition(changed *v1beta1.OpenTelemetryCollector, status metav1.ConditionStatus, reason, msg string) {
+	meta.SetStatusCondition(&changed.Status.Conditions, metav1.Condition{
+		Type:               conditionTypeConfigRollout,
+		Status:             status,
+		ObservedGeneration: changed.Generation,
+		Reason:             reason,
+		Message:            msg,
+		LastTransitionTime: metav1.Now(),
+	})
+}
+
+// analyzeCanary returns whether all the canary pods are ready and their exporter metrics were scraped, or an error
+// wrapping errUnhealthyCanary if they crashed, aren't ready or can't be scraped by the end of the analysis, or their
+// exporters fail to send too much telemetry.
+func analyzeCanary(ctx context.Context, params manifests.Params, changed *v1beta1.OpenTelemetryCollector, current v1beta1.ConfigVersion, elapsed time.Duration) (bool, error) {
+	spec := changed.Spec.ConfigRollout
+	selector := manifestutils.SelectorLabels(changed.ObjectMeta, collector.ComponentOpenTelemetryCollector)
+	if changed.Spec.Mode == v1beta1.ModeDeployment {
+		selector = collector.CanarySelectorLabels(changed.ObjectMeta)
+	}
+	pods := &corev1.PodList{}
+	if err := params.Client.List(ctx, pods, client.InNamespace(changed.Namespace), client.MatchingLabels(selector)); err != nil {
+		return false, fmt.Errorf("failed to list the canary pods: %w", err)
+	}
+
+	var readyPods []corev1.Pod
+	for _, pod := range pods.Items {
+		if pod.Annotations[manifestutils.ConfigHashAnnotation] != current.Hash || pod.DeletionTimestamp != nil {
+			continue
+		}
+		for _, status := range pod.Status.ContainerStatuses {
+			if status.Name != naming.Container() {
+				continue
+			}
+			if status.RestartCount > 0 {
+				return false, fmt.Errorf("%w: the collector of the pod %s restarted %d times", errUnhealthyCanary, pod.Name, status.RestartCount)
+			}
+			if status.State.Terminated != nil {
+				return false, fmt.Errorf("%w: the collector of the pod %s exited: %s", errUnhealthyCanary, pod.Name, status.State.Terminated.Reason)
+			}
+		}
+		if isPodReady(pod) {
+			readyPods = append(readyPods, pod)
+		}
+	}
+	ready := int32(len(readyPods)) >= spec.CanaryReplicas
+	if !ready && elapsed >= spec.AnalysisDuration.Duration {
+		return false, fmt.Errorf("%w: %d of the %d canary pods are ready after %s", errUnhealthyCanary, len(readyPods), spec.CanaryReplicas, spec.AnalysisDuration.Duration)
+	}
+
+	// a configuration whose canary pods can't be analyzed is held, then rolled back, rather than promoted blindly
+	_, port, err := otelconfig.MetricsEndpoint(&params.OtelCol.Spec.Config.Service, params.Log)
+	if err != nil {
+		if elapsed >= spec.AnalysisDuration.Duration {
+			return false, fmt.Errorf("%w: the exporter metrics can't be analyzed: %w", errUnhealthyCanary, err)
+		}
+		params.Log.V(2).Info("the exporter metrics of the canary pods can't be analyzed", "err", err)
+		return false, nil
+	}
+	var sent, failed float64
+	var scrapeErrs []error
+	for _, pod := range readyPods {
+		podSent, podFailed, scrapeErr := scrapeExporterMetrics(ctx, pod, port)
+		if scrapeErr != nil {
+			params.Log.V(2).Info("failed to scrape the exporter metrics of a canary pod", "pod", pod.Name, "err", scrapeErr)
+			scrapeErrs = append(scrapeErrs, fmt.Errorf("the exporter metrics of the pod %s can't be scraped: %w", pod.Name, scrapeErr))
+			continue
+		}
+		sent += podSent
+		failed += podFailed
+	}
+	if failed > 0 && failed*100 > float64(spec.MaxExportFailurePercent)*(sent+failed) {
+		return false, fmt.Errorf("%w: the exporters failed to send %.1f%% of the telemetry", errUnhealthyCanary, failed*100/(sent+failed))
+	}
+	if len(scrapeErrs) > 0 {
+		if elapsed >= spec.AnalysisDuration.Duration {
+			return false, fmt.Errorf("%w: %w", errUnhealthyCanary, errors.Join(scrapeErrs...))
+		}
+		return false, nil
+	}
+	return ready, nil
+}
+
+func isPodReady(pod corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// scrapeExporterMetrics returns the number of telemetry items the exporters of the given pod sent and failed to send,
+// from the collector's own metrics.
+func scrapeExporterMetrics(ctx context.Context, pod corev1.Pod, port int32) (sent, failed float64, err error) {
+	url := fmt.Sprintf("http://%s/metrics", net.JoinHostPort(pod.Status.PodIP, strconv.Itoa(int(port))))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, http.NoBody)
+	if err != nil {
+		return 0, 0, err
+	}
+	resp, err := metricsClient.Do(req)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return 0, 0, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	parser := expfmt.NewTextParser(model.UTF8Validation)
+	families, err := parser.TextToMetricFamilies(resp.Body)
+	if err != nil {
+		return 0, 0, err
+	}
+	for name, family := range families {
+		var total float64
+		for _, metric := range family.GetMetric() {
+			total += metric.GetCounter().GetValue()
+		}
+		switch {
+		case strings.HasPrefix(name, sentMetricsPrefix):
+			sent += total
+		case strings.HasPrefix(name, sendFailedMetricsPrefix):
+			failed += total
+		}
+	}
+	return sent, failed, nil
+}
@@ -0,0 +1,323 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package collector
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/events"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+)
+
+var stableConfigVersion = v1beta1.ConfigVersion{ConfigMap: "test-collector-stable", Hash: "stable-hash"}
+
+// exporterMetricsServer serves the given exporter metrics and returns the port it listens on.
+func exporterMetricsServer(t *testing.T, sent, failed int) int32 {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = fmt.Fprintf(w, "# TYPE otelcol_exporter_sent_spans_total counter\notelcol_exporter_sent_spans_total{exporter=\"otlp\"} %d\n", sent)
+		_, _ = fmt.Fprintf(w, "# TYPE otelcol_exporter_send_failed_spans_total counter\notelcol_exporter_send_failed_spans_total{exporter=\"otlp\"} %d\n", failed)
+	}))
+	t.Cleanup(server.Close)
+	_, port, err := net.SplitHostPort(server.Listener.Addr().String())
+	require.NoError(t, err)
+	p, err := strconv.Atoi(port)
+	require.NoError(t, err)
+	return int32(p)
+}
+
+// closedPort returns a port nothing listens on.
+func closedPort(t *testing.T) int32 {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	port := listener.Addr().(*net.TCPAddr).Port
+	require.NoError(t, listener.Close())
+	return int32(port)
+}
+
+func canaryPod(otelcol v1beta1.OpenTelemetryCollector, hash string, ready bool, restarts int32) *corev1.Pod {
+	readyStatus := corev1.ConditionFalse
+	if ready {
+		readyStatus = corev1.ConditionTrue
+	}
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "test-collector-canary-0",
+			Namespace:   otelcol.Namespace,
+			Labels:      collector.CanarySelectorLabels(otelcol.ObjectMeta),
+			Annotations: map[string]string{manifestutils.ConfigHashAnnotation: hash},
+		},
+		Status: corev1.PodStatus{
+			PodIP:             "127.0.0.1",
+			Conditions:        []corev1.PodCondition{{Type: corev1.PodReady, Status: readyStatus}},
+			ContainerStatuses: []corev1.ContainerStatus{{Name: naming.Container(), RestartCount: restarts}},
+		},
+	}
+}
+
+func TestUpdateConfigRollout(t *testing.T) {
+	for _, tt := range []struct {
+		name            string
+		rollout         func(current v1beta1.ConfigVersion) *v1beta1.ConfigRolloutStatus
+		pods            func(otelcol v1beta1.OpenTelemetryCollector, current v1beta1.ConfigVersion) []client.Object
+		sent, failed    int
+		unreachable     bool
+		metricsAddress  string
+		expected        func(current v1beta1.ConfigVersion) *v1beta1.ConfigRolloutStatus
+		expectedReason  string
+		expectedRequeue bool
+	}{
+		{
+			name:    "first configuration",
+			rollout: func(v1beta1.ConfigVersion) *v1beta1.ConfigRolloutStatus { return nil },
+			expected: func(current v1beta1.ConfigVersion) *v1beta1.ConfigRolloutStatus {
+				return &v1beta1.ConfigRolloutStatus{Stable: &current}
+			},
+			expectedReason: reasonConfigPromoted,
+		},
+		{
+			name: "new configuration",
+			rollout: func(v1beta1.ConfigVersion) *v1beta1.ConfigRolloutStatus {
+				return &v1beta1.ConfigRolloutStatus{Stable: &stableConfigVersion}
+			},
+			expected: func(current v1beta1.ConfigVersion) *v1beta1.ConfigRolloutStatus {
+				return &v1beta1.ConfigRolloutStatus{Stable: &stableConfigVersion, Canary: &current}
+			},
+			expectedReason:  reasonConfigProgressing,
+			expectedRequeue: true,
+		},
+		{
+			name: "canary analyzed",
+			rollout: func(current v1beta1.ConfigVersion) *v1beta1.ConfigRolloutStatus {
+				return &v1beta1.ConfigRolloutStatus{Stable: &stableConfigVersion, Canary: &current, CanaryStartTime: ptr.To(metav1.Now())}
+			},
+			pods: func(otelcol v1beta1.OpenTelemetryCollector, current v1beta1.ConfigVersion) []client.Object {
+				return []client.Object{canaryPod(otelcol, current.Hash, true, 0)}
+			},
+			sent: 100,
+			expected: func(current v1beta1.ConfigVersion) *v1beta1.ConfigRolloutStatus {
+				return &v1beta1.ConfigRolloutStatus{Stable: &stableConfigVersion, Canary: &current}
+			},
+			expectedRequeue: true,
+		},
+		{
+			name: "canary promoted",
+			rollout: func(current v1beta1.ConfigVersion) *v1beta1.ConfigRolloutStatus {
+				return &v1beta1.ConfigRolloutStatus{Stable: &stableConfigVersion, Canary: &current, CanaryStartTime: ptr.To(metav1.NewTime(time.Now().Add(-time.Hour)))}
+			},
+			pods: func(otelcol v1beta1.OpenTelemetryCollector, current v1beta1.ConfigVersion) []client.Object {
+				return []client.Object{canaryPod(otelcol, current.Hash, true, 0)}
+			},
+			sent:   100,
+			failed: 1,
+			expected: func(current v1beta1.ConfigVersion) *v1beta1.ConfigRolloutStatus {
+				return &v1beta1.ConfigRolloutStatus{Stable: &current}
+			},
+			expectedReason: reasonConfigPromoted,
+		},
+		{
+			name: "canary restarted",
+			rollout: func(current v1beta1.ConfigVersion) *v1beta1.ConfigRolloutStatus {
+				return &v1beta1.ConfigRolloutStatus{Stable: &stableConfigVersion, Canary: &current, CanaryStartTime: ptr.To(metav1.Now())}
+			},
+			pods: func(otelcol v1beta1.OpenTelemetryCollector, current v1beta1.ConfigVersion) []client.Object {
+				return []client.Object{canaryPod(otelcol, current.Hash, false, 2)}
+			},
+			expected: func(current v1beta1.ConfigVersion) *v1beta1.ConfigRolloutStatus {
+				return &v1beta1.ConfigRolloutStatus{Stable: &stableConfigVersion, Failed: &current}
+			},
+			expectedReason: reasonConfigRolledBack,
+		},
+		{
+			name: "canary not ready",
+			rollout: func(current v1beta1.ConfigVersion) *v1beta1.ConfigRolloutStatus {
+				return &v1beta1.ConfigRolloutStatus{Stable: &stableConfigVersion, Canary: &current, CanaryStartTime: ptr.To(metav1.NewTime(time.Now().Add(-time.Hour)))}
+			},
+			pods: func(otelcol v1beta1.OpenTelemetryCollector, current v1beta1.ConfigVersion) []client.Object {
+				return []client.Object{canaryPod(otelcol, current.Hash, false, 0)}
+			},
+			expected: func(current v1beta1.ConfigVersion) *v1beta1.ConfigRolloutStatus {
+				return &v1beta1.ConfigRolloutStatus{Stable: &stableConfigVersion, Failed: &current}
+			},
+			expectedReason: reasonConfigRolledBack,
+		},
+		{
+			name: "canary export failures",
+			rollout: func(current v1beta1.ConfigVersion) *v1beta1.ConfigRolloutStatus {
+				return &v1beta1.ConfigRolloutStatus{Stable: &stableConfigVersion, Canary: &current, CanaryStartTime: ptr.To(metav1.Now())}
+			},
+			pods: func(otelcol v1beta1.OpenTelemetryCollector, current v1beta1.ConfigVersion) []client.Object {
+				return []client.Object{canaryPod(otelcol, current.Hash, true, 0)}
+			},
+			sent:   50,
+			failed: 50,
+			expected: func(current v1beta1.ConfigVersion) *v1beta1.ConfigRolloutStatus {
+				return &v1beta1.ConfigRolloutStatus{Stable: &stableConfigVersion, Failed: &current}
+			},
+			expectedReason: reasonConfigRolledBack,
+		},
+		{
+			name: "canary metrics unavailable",
+			rollout: func(current v1beta1.ConfigVersion) *v1beta1.ConfigRolloutStatus {
+				return &v1beta1.ConfigRolloutStatus{Stable: &stableConfigVersion, Canary: &current, CanaryStartTime: ptr.To(metav1.Now())}
+			},
+			pods: func(otelcol v1beta1.OpenTelemetryCollector, current v1beta1.ConfigVersion) []client.Object {
+				return []client.Object{canaryPod(otelcol, current.Hash, true, 0)}
+			},
+			unreachable: true,
+			expected: func(current v1beta1.ConfigVersion) *v1beta1.ConfigRolloutStatus {
+				return &v1beta1.ConfigRolloutStatus{Stable: &stableConfigVersion, Canary: &current}
+			},
+			expectedRequeue: true,
+		},
+		{
+			name: "canary metrics unavailable after the analysis",
+			rollout: func(current v1beta1.ConfigVersion) *v1beta1.ConfigRolloutStatus {
+				return &v1beta1.ConfigRolloutStatus{Stable: &stableConfigVersion, Canary: &current, CanaryStartTime: ptr.To(metav1.NewTime(time.Now().Add(-time.Hour)))}
+			},
+			pods: func(otelcol v1beta1.OpenTelemetryCollector, current v1beta1.ConfigVersion) []client.Object {
+				return []client.Object{canaryPod(otelcol, current.Hash, true, 0)}
+			},
+			unreachable: true,
+			expected: func(current v1beta1.ConfigVersion) *v1beta1.ConfigRolloutStatus {
+				return &v1beta1.ConfigRolloutStatus{Stable: &stableConfigVersion, Failed: &current}
+			},
+			expectedReason: reasonConfigRolledBack,
+		},
+		{
+			name: "canary metrics endpoint invalid",
+			rollout: func(current v1beta1.ConfigVersion) *v1beta1.ConfigRolloutStatus {
+				return &v1beta1.ConfigRolloutStatus{Stable: &stableConfigVersion, Canary: &current, CanaryStartTime: ptr.To(metav1.Now())}
+			},
+			pods: func(otelcol v1beta1.OpenTelemetryCollector, current v1beta1.ConfigVersion) []client.Object {
+				return []client.Object{canaryPod(otelcol, current.Hash, true, 0)}
+			},
+			metricsAddress: "0.0.0.0:metrics",
+			expected: func(current v1beta1.ConfigVersion) *v1beta1.ConfigRolloutStatus {
+				return &v1beta1.ConfigRolloutStatus{Stable: &stableConfigVersion, Canary: &current}
+			},
+			expectedRequeue: true,
+		},
+		{
+			name: "canary metrics endpoint invalid after the analysis",
+			rollout: func(current v1beta1.ConfigVersion) *v1beta1.ConfigRolloutStatus {
+				return &v1beta1.ConfigRolloutStatus{Stable: &stableConfigVersion, Canary: &current, CanaryStartTime: ptr.To(metav1.NewTime(time.Now().Add(-time.Hour)))}
+			},
+			pods: func(otelcol v1beta1.OpenTelemetryCollector, current v1beta1.ConfigVersion) []client.Object {
+				return []client.Object{canaryPod(otelcol, current.Hash, true, 0)}
+			},
+			metricsAddress: "0.0.0.0:metrics",
+			expected: func(current v1beta1.ConfigVersion) *v1beta1.ConfigRolloutStatus {
+				return &v1beta1.ConfigRolloutStatus{Stable: &stableConfigVersion, Failed: &current}
+			},
+			expectedReason: reasonConfigRolledBack,
+		},
+		{
+			name: "rolled back configuration",
+			rollout: func(current v1beta1.ConfigVersion) *v1beta1.ConfigRolloutStatus {
+				return &v1beta1.ConfigRolloutStatus{Stable: &stableConfigVersion, Failed: &current}
+			},
+			expected: func(current v1beta1.ConfigVersion) *v1beta1.ConfigRolloutStatus {
+				return &v1beta1.ConfigRolloutStatus{Stable: &stableConfigVersion, Failed: &current}
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			port := exporterMetricsServer(t, tt.sent, tt.failed)
+			if tt.unreachable {
+				port = closedPort(t)
+			}
+			metricsAddress := fmt.Sprintf("0.0.0.0:%d", port)
+			if tt.metricsAddress != "" {
+				metricsAddress = tt.metricsAddress
+			}
+			otelcol := v1beta1.OpenTelemetryCollector{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					Mode: v1beta1.ModeDeployment,
+					Config: v1beta1.Config{
+						Service: v1beta1.Service{
+							Telemetry: &v1beta1.AnyConfig{Object: map[string]any{
+								"metrics": map[string]any{"address": metricsAddress},
+							}},
+						},
+					},
+					ConfigRollout: &v1beta1.ConfigRolloutSpec{
+						CanaryReplicas:          1,
+						AnalysisDuration:        metav1.Duration{Duration: 5 * time.Minute},
+						MaxExportFailurePercent: 5,
+					},
+				},
+			}
+			cfg := config.New()
+			current, err := collector.CurrentConfigVersion(cfg, otelcol)
+			require.NoError(t, err)
+			otelcol.Status.ConfigRollout = tt.rollout(current)
+
+			builder := fake.NewClientBuilder()
+			if tt.pods != nil {
+				builder = builder.WithObjects(tt.pods(otelcol, current)...)
+			}
+			params := manifests.Params{
+				Config:   cfg,
+				Client:   builder.Build(),
+				OtelCol:  otelcol,
+				Log:      logr.Discard(),
+				Recorder: events.NewFakeRecorder(10),
+			}
+
+			changed := otelcol.DeepCopy()
+			requeueAfter, err := updateConfigRollout(context.Background(), params, changed)
+			require.NoError(t, err)
+
+			expected := tt.expected(current)
+			assert.Equal(t, expected.Stable, changed.Status.ConfigRollout.Stable)
+			assert.Equal(t, expected.Canary, changed.Status.ConfigRollout.Canary)
+			assert.Equal(t, expected.Failed, changed.Status.ConfigRollout.Failed)
+			assert.Equal(t, tt.expectedRequeue, requeueAfter > 0)
+			if tt.expectedReason != "" {
+				condition := meta.FindStatusCondition(changed.Status.Conditions, conditionTypeConfigRollout)
+				require.NotNil(t, condition)
+				assert.Equal(t, tt.expectedReason, condition.Reason)
+			}
+		})
+	}
+}
+
+func TestUpdateConfigRolloutDisabled(t *testing.T) {
+	changed := &v1beta1.OpenTelemetryCollector{
+		Spec: v1beta1.OpenTelemetryCollectorSpec{Mode: v1beta1.ModeDeployment},
+		Status: v1beta1.OpenTelemetryCollectorStatus{
+			ConfigRollout: &v1beta1.ConfigRolloutStatus{Stable: &stableConfigVersion},
+			Conditions:    []metav1.Condition{{Type: conditionTypeConfigRollout, Status: metav1.ConditionTrue}},
+		},
+	}
+
+	requeueAfter, err := updateConfigRollout(context.Background(), manifests.Params{}, changed)
+	require.NoError(t, err)
+	assert.Zero(t, requeueAfter)
+	assert.Nil(t, changed.Status.ConfigRollout)
+	assert.Empty(t, changed.Status.Conditions)
+}
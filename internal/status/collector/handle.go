@@ -6,6 +6,7 @@ package collector
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
@@ -63,6 +64,10 @@ func HandleReconcileStatus(ctx context.Context, log logr.Logger, params manifest
 	}
 
 	statusErr := updateCollectorStatus(ctx, params.Client, changed)
+	var requeueAfter time.Duration
+	if statusErr == nil {
+		requeueAfter, statusErr = updateConfigRollout(ctx, params, changed)
+	}
 
 	if statusErr != nil {
 		// if status update fails, still update the condition to reflect the error
@@ -91,5 +96,5 @@ func HandleReconcileStatus(ctx context.Context, log logr.Logger, params manifest
 		return ctrl.Result{}, fmt.Errorf("failed to apply status changes to the OpenTelemetry CR: %w", err)
 	}
 	params.Recorder.Eventf(changed, nil, corev1.EventTypeNormal, reasonInfo, reasonInfo, "applied status changes")
-	return ctrl.Result{}, statusErr
+	return ctrl.Result{RequeueAfter: requeueAfter}, statusErr
 }
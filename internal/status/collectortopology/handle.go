@@ -0,0 +1,138 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package collectortopology
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collectortopology"
+)
+
+const (
+	reasonError          = "Error"
+	reasonInfo           = "Info"
+	reasonReconcileError = "ReconcileError"
+	reasonTiersReady     = "TiersReady"
+	reasonTiersNotReady  = "TiersNotReady"
+)
+
+// HandleReconcileStatus handles updating the status of the CollectorTopology CRD. The topology is ready once the pods
+// of both of its tiers are.
+func HandleReconcileStatus(ctx context.Context, log logr.Logger, params manifests.Params, reconcileErr error) (ctrl.Result, error) {
+	log.V(2).Info("updating collector topology status")
+
+	topology := params.CollectorTopology
+	changed := topology.DeepCopy()
+	changed.Status.ObservedGeneration = changed.Generation
+
+	if reconcileErr != nil {
+		meta.SetStatusCondition(&changed.Status.Conditions, metav1.Condition{
+			Type:               v1alpha1.CollectorTopologyConditionReady,
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: changed.Generation,
+			Reason:             reasonReconcileError,
+			Message:            reconcileErr.Error(),
+		})
+		statusPatch := client.MergeFrom(&topology)
+		if err := params.Client.Status().Patch(ctx, changed, statusPatch); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to apply status changes to the CollectorTopology CR: %w", err)
+		}
+		params.Recorder.Eventf(&topology, nil, corev1.EventTypeWarning, reasonError, reasonError, reconcileErr.Error())
+		return ctrl.Result{}, reconcileErr
+	}
+
+	var err error
+	changed.Status.Agent, err = tierStatus(ctx, params.Client, changed.Namespace, collectortopology.AgentCollector(changed.Name))
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	changed.Status.Gateway, err = tierStatus(ctx, params.Client, changed.Namespace, collectortopology.GatewayCollector(changed.Name))
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	meta.SetStatusCondition(&changed.Status.Conditions, readyCondition(changed))
+
+	statusPatch := client.MergeFrom(&topology)
+	if err := params.Client.Status().Patch(ctx, changed, statusPatch); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to apply status changes to the CollectorTopology CR: %w", err)
+	}
+	params.Recorder.Eventf(changed, nil, corev1.EventTypeNormal, reasonInfo, reasonInfo, "applied status changes")
+	return ctrl.Result{}, nil
+}
+
+// tierStatus returns the status of a tier from the scale status of its OpenTelemetryCollector.
+func tierStatus(ctx context.Context, cli client.Client, namespace, name string) (v1alpha1.CollectorTierStatus, error) {
+	status := v1alpha1.CollectorTierStatus{Collector: name}
+	otelcol := &v1beta1.OpenTelemetryCollector{}
+	if err := cli.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, otelcol); err != nil {
+		if apierrors.IsNotFound(err) {
+			return status, nil
+		}
+		return status, fmt.Errorf("failed to get the OpenTelemetryCollector %s: %w", name, err)
+	}
+	status.StatusReplicas = otelcol.Status.Scale.StatusReplicas
+	status.Ready = replicasReady(status.StatusReplicas)
+	return status, nil
+}
+
+// replicasReady returns whether all the pods of a "ready/total" status are ready.
+func replicasReady(statusReplicas string) bool {
+	readyPart, totalPart, found := strings.Cut(statusReplicas, "/")
+	if !found {
+		return false
+	}
+	ready, err := strconv.Atoi(readyPart)
+	if err != nil {
+		return false
+	}
+	total, err := strconv.Atoi(totalPart)
+	if err != nil {
+		return false
+	}
+	return total > 0 && ready == total
+}
+
+func readyCondition(topology *v1alpha1.CollectorTopology) metav1.Condition {
+	condition := metav1.Condition{
+		Type:               v1alpha1.CollectorTopologyConditionReady,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: topology.Generation,
+		Reason:             reasonTiersReady,
+		Message:            "The agent and gateway tiers are ready",
+	}
+	var notReady []string
+	if !topology.Status.Agent.Ready {
+		notReady = append(notReady, fmt.Sprintf("agent (%s)", replicasOrPending(topology.Status.Agent)))
+	}
+	if !topology.Status.Gateway.Ready {
+		notReady = append(notReady, fmt.Sprintf("gateway (%s)", replicasOrPending(topology.Status.Gateway)))
+	}
+	if len(notReady) > 0 {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = reasonTiersNotReady
+		condition.Message = "Tiers not ready: " + strings.Join(notReady, ", ")
+	}
+	return condition
+}
+
+func replicasOrPending(status v1alpha1.CollectorTierStatus) string {
+	if status.StatusReplicas == "" {
+		return "pending"
+	}
+	return status.StatusReplicas + " pods ready"
+}
@@ -0,0 +1,66 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package collectortopology
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+)
+
+func TestTierStatus(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1beta1.AddToScheme(scheme))
+	cli := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&v1beta1.OpenTelemetryCollector{
+			ObjectMeta: metav1.ObjectMeta{Name: "sampling-agent", Namespace: "observability"},
+			Status: v1beta1.OpenTelemetryCollectorStatus{
+				Scale: v1beta1.ScaleSubresourceStatus{StatusReplicas: "2/2"},
+			},
+		},
+		&v1beta1.OpenTelemetryCollector{
+			ObjectMeta: metav1.ObjectMeta{Name: "sampling-gateway", Namespace: "observability"},
+			Status: v1beta1.OpenTelemetryCollectorStatus{
+				Scale: v1beta1.ScaleSubresourceStatus{StatusReplicas: "1/3"},
+			},
+		},
+	).Build()
+
+	topology := &v1alpha1.CollectorTopology{ObjectMeta: metav1.ObjectMeta{Name: "sampling", Namespace: "observability"}}
+	var err error
+	topology.Status.Agent, err = tierStatus(context.Background(), cli, "observability", "sampling-agent")
+	require.NoError(t, err)
+	assert.Equal(t, v1alpha1.CollectorTierStatus{Collector: "sampling-agent", StatusReplicas: "2/2", Ready: true}, topology.Status.Agent)
+	topology.Status.Gateway, err = tierStatus(context.Background(), cli, "observability", "sampling-gateway")
+	require.NoError(t, err)
+	assert.False(t, topology.Status.Gateway.Ready)
+
+	condition := readyCondition(topology)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, "Tiers not ready: gateway (1/3 pods ready)", condition.Message)
+
+	missing, err := tierStatus(context.Background(), cli, "observability", "other-gateway")
+	require.NoError(t, err)
+	assert.Equal(t, v1alpha1.CollectorTierStatus{Collector: "other-gateway"}, missing)
+}
+
+func TestReplicasReady(t *testing.T) {
+	for statusReplicas, expected := range map[string]bool{
+		"3/3": true,
+		"2/3": false,
+		"0/0": false,
+		"":    false,
+		"a/b": false,
+	} {
+		assert.Equal(t, expected, replicasReady(statusReplicas), statusReplicas)
+	}
+}
@@ -7,10 +7,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/go-logr/logr"
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/validation"
@@ -22,7 +25,10 @@ import (
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/certmanager"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/gatewayapi"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/keda"
 	autoRBAC "github.com/open-telemetry/opentelemetry-operator/internal/autodetect/rbac"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/vpa"
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
 	"github.com/open-telemetry/opentelemetry-operator/internal/fips"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
@@ -86,12 +92,23 @@ func (c CollectorWebhook) Default(_ context.Context, otelcol *v1beta1.OpenTeleme
 			otelcol.Spec.Autoscaler.MinReplicas = otelcol.Spec.Replicas
 		}
 
-		if otelcol.Spec.Autoscaler.TargetMemoryUtilization == nil && otelcol.Spec.Autoscaler.TargetCPUUtilization == nil {
+		if otelcol.Spec.Autoscaler.TargetMemoryUtilization == nil && otelcol.Spec.Autoscaler.TargetCPUUtilization == nil && !hasKedaTriggers(otelcol.Spec.Autoscaler) {
 			defaultCPUTarget := int32(90)
 			otelcol.Spec.Autoscaler.TargetCPUUtilization = &defaultCPUTarget
 		}
 	}
 
+	if vertical := otelcol.Spec.VerticalAutoscaler; vertical != nil && vertical.Enabled && !vertical.MemoryLimiter.Disabled {
+		if vertical.MemoryLimiter.LimitPercentage == nil {
+			defaultLimitPercentage := int32(80)
+			vertical.MemoryLimiter.LimitPercentage = &defaultLimitPercentage
+		}
+		if vertical.MemoryLimiter.SpikeLimitPercentage == nil {
+			defaultSpikeLimitPercentage := int32(20)
+			vertical.MemoryLimiter.SpikeLimitPercentage = &defaultSpikeLimitPercentage
+		}
+	}
+
 	if otelcol.Spec.Ingress.Type == v1beta1.IngressTypeRoute && otelcol.Spec.Ingress.Route.Termination == "" {
 		otelcol.Spec.Ingress.Route.Termination = v1beta1.TLSRouteTerminationTypeEdge
 	}
@@ -189,6 +206,25 @@ func (c CollectorWebhook) Validate(ctx context.Context, r *v1beta1.OpenTelemetry
 		return warnings, fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'persistentVolumeClaimRetentionPolicy'", r.Spec.Mode)
 	}
 
+	// validate podMetadataAttributes
+	if r.Spec.Mode != v1beta1.ModeSidecar && r.Spec.PodMetadataAttributes != nil {
+		return warnings, fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'podMetadataAttributes'", r.Spec.Mode)
+	}
+	if r.Spec.PodMetadataAttributes != nil {
+		for _, keys := range []map[string]string{r.Spec.PodMetadataAttributes.Labels, r.Spec.PodMetadataAttributes.Annotations} {
+			for key, attribute := range keys {
+				if key == "" || attribute == "" {
+					return warnings, fmt.Errorf("the OpenTelemetry Spec podMetadataAttributes configuration is incorrect, empty key or attribute name in mapping %q: %q", key, attribute)
+				}
+			}
+		}
+	}
+
+	// validate configFrom, the sidecar's configuration is built by the pod webhook, which doesn't resolve fragments
+	if r.Spec.Mode == v1beta1.ModeSidecar && len(r.Spec.ConfigFrom) > 0 {
+		return warnings, fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'configFrom'", r.Spec.Mode)
+	}
+
 	// validate tolerations
 	// NOTE: this validation is also implemented in CRDs using CEL (Common Expression Language)
 	if r.Spec.Mode == v1beta1.ModeSidecar && len(r.Spec.Tolerations) > 0 {
@@ -212,6 +248,45 @@ func (c CollectorWebhook) Validate(ctx context.Context, r *v1beta1.OpenTelemetry
 		return warnings, fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'AdditionalContainers'", r.Spec.Mode)
 	}
 
+	// validate verticalAutoscaler
+	if r.Spec.VerticalAutoscaler != nil && r.Spec.VerticalAutoscaler.Enabled {
+		vpaWarnings, err := c.validateVerticalAutoscaler(r)
+		warnings = append(warnings, vpaWarnings...)
+		if err != nil {
+			return warnings, err
+		}
+	}
+
+	// validate gateway api routes
+	if err := c.validateGatewayRoutes(r); err != nil {
+		return warnings, err
+	}
+
+	// validate receiver tls
+	if r.Spec.TLS != nil && r.Spec.TLS.Enabled {
+		tlsWarnings, err := c.validateReceiverTLS(r)
+		warnings = append(warnings, tlsWarnings...)
+		if err != nil {
+			return warnings, err
+		}
+	}
+
+	// validate persistence
+	if r.Spec.Persistence != nil && r.Spec.Persistence.Enabled {
+		persistenceWarnings, err := validatePersistence(r)
+		warnings = append(warnings, persistenceWarnings...)
+		if err != nil {
+			return warnings, err
+		}
+	}
+
+	// validate the alerts and the dashboard of the collector metrics
+	monitoringWarnings, err := validateMonitoring(r)
+	warnings = append(warnings, monitoringWarnings...)
+	if err != nil {
+		return warnings, err
+	}
+
 	// validate target allocator configs
 	if r.Spec.TargetAllocator.Enabled {
 		taWarnings, err := c.validateTargetAllocatorConfig(ctx, r)
@@ -261,6 +336,15 @@ func (c CollectorWebhook) Validate(ctx context.Context, r *v1beta1.OpenTelemetry
 		minReplicas = r.Spec.Replicas
 	}
 
+	if r.Spec.Autoscaler != nil && r.Spec.Autoscaler.Type == v1beta1.AutoscalerTypeKeda {
+		if maxReplicas == nil {
+			return warnings, errors.New("the OpenTelemetry Spec autoscale configuration is incorrect, maxReplicas must be set when the autoscaler type is keda")
+		}
+		if c.cfg.KedaAvailability != keda.Available {
+			return warnings, errors.New("the OpenTelemetry Spec autoscale configuration is incorrect, the autoscaler type keda requires KEDA to be installed in the cluster")
+		}
+	}
+
 	// validate autoscale with horizontal pod autoscaler
 	if maxReplicas != nil {
 		if r.Spec.Replicas != nil && *r.Spec.Replicas > *maxReplicas {
@@ -301,6 +385,11 @@ func (c CollectorWebhook) Validate(ctx context.Context, r *v1beta1.OpenTelemetry
 		return warnings, fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'deploymentUpdateStrategy'", r.Spec.Mode)
 	}
 
+	// validate configRollout, canary pods are only supported by Deployments and StatefulSets
+	if r.Spec.Mode != v1beta1.ModeDeployment && r.Spec.Mode != v1beta1.ModeStatefulSet && r.Spec.ConfigRollout != nil {
+		return warnings, fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'configRollout'", r.Spec.Mode)
+	}
+
 	if c.fips != nil {
 		components := otelconfig.GetEnabledComponents(&r.Spec.Config)
 		if notAllowedComponents := c.fips.DisabledComponents(components[v1beta1.KindReceiver], components[v1beta1.KindExporter], components[v1beta1.KindProcessor], components[v1beta1.KindExtension]); notAllowedComponents != nil {
@@ -378,7 +467,7 @@ func (c CollectorWebhook) validateTargetAllocatorConfig(ctx context.Context, r *
 // escalation path equivalent to the one Kubernetes guards against with the "escalate" verb.
 //
 // The check uses a delta approach to avoid false rejections:
-//  1. Determine what RBAC rules the collector config requires.
+//  1. Determine what RBAC rules the collector config requires, cluster-wide and in the namespaces of its Roles.
 //  2. Check what the collector's ServiceAccount already holds.
 //  3. Compute the delta — rules the SA does not yet hold and that reconciliation would grant.
 //  4. Check the requesting user against the delta only.
@@ -399,7 +488,18 @@ func (c CollectorWebhook) validateRBACPrivilegeEscalation(ctx context.Context, r
 	if err != nil {
 		return fmt.Errorf("unable to determine RBAC rules for collector config: %w", err)
 	}
-	if len(rules) == 0 {
+	roleRules, err := otelconfig.GetAllRoleRules(&r.Spec.Config, c.logger)
+	if err != nil {
+		return fmt.Errorf("unable to determine RBAC rules for collector config: %w", err)
+	}
+	if c.cfg.CreateRoleRBACPermissions != autoRBAC.Available {
+		// the namespaced rules are granted cluster-wide
+		for _, namespaceRules := range roleRules {
+			rules = append(rules, namespaceRules.Rules...)
+		}
+		roleRules = nil
+	}
+	if len(rules) == 0 && len(roleRules) == 0 {
 		return nil
 	}
 
@@ -421,11 +521,26 @@ func (c CollectorWebhook) validateRBACPrivilegeEscalation(ctx context.Context, r
 		saName = naming.ServiceAccount(r.Name)
 	}
 
-	// Step 2: check what the SA already holds.
+	// Step 2: check what the SA already holds, cluster-wide and in the namespaces of the roles.
 	saSARs, err := c.reviewer.CheckPolicyRules(ctx, saName, r.Namespace, rulePtrs...)
 	if err != nil {
 		return fmt.Errorf("unable to check existing SA RBAC permissions: %w", err)
 	}
+	for _, namespaceRules := range roleRules {
+		namespace := namespaceRules.Namespace
+		if namespace == "" {
+			namespace = r.Namespace
+		}
+		namespaceRulePtrs := make([]*rbacv1.PolicyRule, len(namespaceRules.Rules))
+		for i := range namespaceRules.Rules {
+			namespaceRulePtrs[i] = &namespaceRules.Rules[i]
+		}
+		namespaceSARs, err := c.reviewer.CheckNamespacedPolicyRules(ctx, saName, r.Namespace, namespace, namespaceRulePtrs...)
+		if err != nil {
+			return fmt.Errorf("unable to check existing SA RBAC permissions: %w", err)
+		}
+		saSARs = append(saSARs, namespaceSARs...)
+	}
 
 	// Step 3: compute the delta — permissions the SA does not yet hold.
 	_, delta := rbac.AllSubjectAccessReviewsAllowed(saSARs)
@@ -462,7 +577,148 @@ func ValidatePorts(ports []v1beta1.PortsSpec) error {
 	return nil
 }
 
+func (c CollectorWebhook) validateVerticalAutoscaler(r *v1beta1.OpenTelemetryCollector) (admission.Warnings, error) {
+	if r.Spec.Mode != v1beta1.ModeDeployment && r.Spec.Mode != v1beta1.ModeDaemonSet {
+		return nil, fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'verticalAutoscaler'", r.Spec.Mode)
+	}
+	if c.cfg.VPAAvailability != vpa.Available {
+		return nil, errors.New("the OpenTelemetry Spec verticalAutoscaler configuration is incorrect, the VerticalPodAutoscaler is not installed in the cluster")
+	}
+
+	var warnings admission.Warnings
+	spec := r.Spec.VerticalAutoscaler
+	controls := func(resource corev1.ResourceName) bool {
+		return len(spec.ControlledResources) == 0 || slices.Contains(spec.ControlledResources, resource)
+	}
+	if as := r.Spec.Autoscaler; as != nil && as.MaxReplicas != nil &&
+		((as.TargetCPUUtilization != nil && controls(corev1.ResourceCPU)) || (as.TargetMemoryUtilization != nil && controls(corev1.ResourceMemory))) {
+		warnings = append(warnings, "the collector is scaled horizontally and vertically on the same resources, which makes the autoscalers compete with each other. Consider scaling horizontally on other metrics.")
+	}
+
+	if spec.MemoryLimiter.Disabled {
+		return warnings, nil
+	}
+	limit, spikeLimit := spec.MemoryLimiter.LimitPercentage, spec.MemoryLimiter.SpikeLimitPercentage
+	if limit != nil && spikeLimit != nil && *spikeLimit >= *limit {
+		return warnings, errors.New("the OpenTelemetry Spec verticalAutoscaler configuration is incorrect, memoryLimiter.spikeLimitPercentage must be lower than memoryLimiter.limitPercentage")
+	}
+	if _, ok := r.Spec.Resources.Limits[corev1.ResourceMemory]; !ok {
+		warnings = append(warnings, "the collector container has no memory limit, the limits of the memory_limiter processor are relative to the memory of the node")
+	}
+	if slices.ContainsFunc(r.Spec.Env, func(env corev1.EnvVar) bool { return env.Name == "GOMEMLIMIT" }) {
+		warnings = append(warnings, "GOMEMLIMIT is set in the env, it's kept as is and doesn't follow the memory limit set by the VerticalPodAutoscaler, unlike the limits of the memory_limiter processor")
+	}
+	return warnings, nil
+}
+
+func (c CollectorWebhook) validateGatewayRoutes(r *v1beta1.OpenTelemetryCollector) error {
+	routes := []struct {
+		attribute    string
+		kind         string
+		enabled      bool
+		gateway      string
+		hostnames    []string
+		needsHost    bool
+		availability gatewayapi.ApiAvailability
+	}{
+		{"grpcRoute", "GRPCRoute", r.Spec.GRPCRoute.Enabled, r.Spec.GRPCRoute.Gateway, r.Spec.GRPCRoute.Hostnames, false, c.cfg.GRPCRouteAvailability},
+		{"tlsRoute", "TLSRoute", r.Spec.TLSRoute.Enabled, r.Spec.TLSRoute.Gateway, r.Spec.TLSRoute.Hostnames, true, c.cfg.TLSRouteAvailability},
+	}
+	for _, route := range routes {
+		if !route.enabled {
+			continue
+		}
+		if r.Spec.Mode == v1beta1.ModeSidecar {
+			return fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute '%s'", r.Spec.Mode, route.attribute)
+		}
+		if route.availability != gatewayapi.ApiAvailable {
+			return fmt.Errorf("the OpenTelemetry Spec %s configuration is incorrect, the Gateway API %s is not installed in the cluster", route.attribute, route.kind)
+		}
+		if route.gateway == "" {
+			return fmt.Errorf("the OpenTelemetry Spec %s configuration is incorrect, gateway is required", route.attribute)
+		}
+		if route.needsHost && len(route.hostnames) == 0 {
+			return fmt.Errorf("the OpenTelemetry Spec %s configuration is incorrect, at least one hostname is required", route.attribute)
+		}
+	}
+	return nil
+}
+
+func (c CollectorWebhook) validateReceiverTLS(r *v1beta1.OpenTelemetryCollector) (admission.Warnings, error) {
+	if r.Spec.Mode == v1beta1.ModeSidecar {
+		return nil, fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'tls'", r.Spec.Mode)
+	}
+	if c.cfg.CertManagerAvailability != certmanager.Available {
+		return nil, errors.New("the OpenTelemetry Spec tls configuration is incorrect, cert-manager is not installed in the cluster or the operator lacks the permissions to manage certificates")
+	}
+	if ri := r.Spec.TLS.ReloadInterval; ri != nil && ri.Duration <= 0 {
+		return nil, errors.New("the OpenTelemetry Spec tls configuration is incorrect, reloadInterval must be positive")
+	}
+	if ref := r.Spec.TLS.IssuerRef; ref != nil && r.Spec.TLS.ClientAuth {
+		return admission.Warnings{fmt.Sprintf("the receivers verify client certificates with the CA in the certificate Secret, make sure the issuer %s provides it", ref.Name)}, nil
+	}
+	return nil, nil
+}
+
+func validatePersistence(r *v1beta1.OpenTelemetryCollector) (admission.Warnings, error) {
+	if r.Spec.Mode == v1beta1.ModeSidecar {
+		return nil, fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'persistence'", r.Spec.Mode)
+	}
+	if hostPath := r.Spec.Persistence.HostPath; hostPath != "" && !filepath.IsAbs(hostPath) {
+		return nil, errors.New("the OpenTelemetry Spec persistence configuration is incorrect, hostPath must be an absolute path")
+	}
+	directories, err := otelconfig.GetStorageDirectories(&r.Spec.Config, logr.Discard())
+	if err != nil {
+		return nil, err
+	}
+	if len(directories) == 0 {
+		return admission.Warnings{"persistence is enabled, but no file_storage extension is enabled in the service"}, nil
+	}
+	if r.Spec.Mode == v1beta1.ModeDaemonSet && !r.Spec.Persistence.ChownHostPath && !runsAsRoot(r) {
+		return admission.Warnings{"the hostPath directories of the persistence are created owned by root, and Kubernetes doesn't change their ownership: the collector can only write to them when it runs as a user allowed to, when they are prepared on the nodes beforehand, or when persistence.chownHostPath is set"}, nil
+	}
+	return nil, nil
+}
+
+// runsAsRoot returns whether the collector container is set to run as root.
+func runsAsRoot(r *v1beta1.OpenTelemetryCollector) bool {
+	if r.Spec.SecurityContext != nil && r.Spec.SecurityContext.RunAsUser != nil {
+		return *r.Spec.SecurityContext.RunAsUser == 0
+	}
+	return r.Spec.PodSecurityContext != nil && r.Spec.PodSecurityContext.RunAsUser != nil && *r.Spec.PodSecurityContext.RunAsUser == 0
+}
+
+func validateMonitoring(r *v1beta1.OpenTelemetryCollector) (admission.Warnings, error) {
+	monitoring := r.Spec.Monitoring
+	var features []string
+	if monitoring.EnableAlerts {
+		features = append(features, "enableAlerts")
+	}
+	if monitoring.Dashboard != nil && monitoring.Dashboard.Enabled {
+		features = append(features, "dashboard")
+	}
+	if len(features) == 0 {
+		return nil, nil
+	}
+	if r.Spec.Mode == v1beta1.ModeSidecar {
+		return nil, fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'monitoring'", r.Spec.Mode)
+	}
+	if !r.Spec.Observability.Metrics.EnableMetrics {
+		return admission.Warnings{fmt.Sprintf("monitoring %s rely on the collector metrics, but observability.metrics.enableMetrics is not set: the metrics must be scraped by other means", strings.Join(features, " and "))}, nil
+	}
+	return nil, nil
+}
+
 func checkAutoscalerSpec(autoscaler *v1beta1.AutoscalerSpec) error {
+	if autoscaler.Keda != nil && autoscaler.Type != v1beta1.AutoscalerTypeKeda {
+		return errors.New("the OpenTelemetry Spec autoscale configuration is incorrect, keda can only be set when the autoscaler type is keda")
+	}
+	if autoscaler.Type == v1beta1.AutoscalerTypeKeda {
+		if err := checkKedaAutoscalerSpec(autoscaler); err != nil {
+			return err
+		}
+	}
+
 	if autoscaler.Behavior != nil {
 		if autoscaler.Behavior.ScaleDown != nil && autoscaler.Behavior.ScaleDown.StabilizationWindowSeconds != nil &&
 			(*autoscaler.Behavior.ScaleDown.StabilizationWindowSeconds < int32(0) || *autoscaler.Behavior.ScaleDown.StabilizationWindowSeconds > 3600) {
@@ -498,6 +754,47 @@ func checkAutoscalerSpec(autoscaler *v1beta1.AutoscalerSpec) error {
 	return nil
 }
 
+func checkKedaAutoscalerSpec(autoscaler *v1beta1.AutoscalerSpec) error {
+	if len(autoscaler.Metrics) > 0 {
+		return errors.New("the OpenTelemetry Spec autoscale configuration is incorrect, metrics are not supported with the autoscaler type keda, use keda triggers instead")
+	}
+	spec := autoscaler.Keda
+	if spec == nil {
+		return nil
+	}
+	if (spec.ExporterQueueSize != nil || spec.RefusedSpans != nil) && (spec.Prometheus == nil || spec.Prometheus.ServerAddress == "") {
+		return errors.New("the OpenTelemetry Spec autoscale configuration is incorrect, keda.prometheus.serverAddress must be set for the exporterQueueSize and refusedSpans triggers")
+	}
+	for _, target := range []*v1beta1.KedaMetricTarget{spec.ExporterQueueSize, spec.RefusedSpans} {
+		if target != nil && target.TargetValue.Sign() <= 0 {
+			return errors.New("the OpenTelemetry Spec autoscale configuration is incorrect, keda target value should be greater than 0")
+		}
+	}
+	if spec.KafkaLag != nil {
+		if spec.KafkaLag.BootstrapServers == "" || spec.KafkaLag.ConsumerGroup == "" {
+			return errors.New("the OpenTelemetry Spec autoscale configuration is incorrect, keda.kafkaLag requires bootstrapServers and consumerGroup")
+		}
+		if spec.KafkaLag.LagThreshold < 1 {
+			return errors.New("the OpenTelemetry Spec autoscale configuration is incorrect, keda.kafkaLag.lagThreshold should be greater than 0")
+		}
+	}
+	for _, trigger := range spec.Triggers {
+		if trigger.Type == "" {
+			return errors.New("the OpenTelemetry Spec autoscale configuration is incorrect, keda trigger type must be set")
+		}
+	}
+	return nil
+}
+
+// hasKedaTriggers returns whether the autoscaler scales on KEDA triggers other than cpu and memory.
+func hasKedaTriggers(autoscaler *v1beta1.AutoscalerSpec) bool {
+	if autoscaler.Type != v1beta1.AutoscalerTypeKeda || autoscaler.Keda == nil {
+		return false
+	}
+	k := autoscaler.Keda
+	return k.ExporterQueueSize != nil || k.RefusedSpans != nil || k.KafkaLag != nil || len(k.Triggers) > 0
+}
+
 // BuildValidator enables running the manifest generators for the collector reconciler
 // +kubebuilder:object:generate=false
 type BuildValidator func(ctx context.Context, c v1beta1.OpenTelemetryCollector) admission.Warnings
@@ -11,6 +11,7 @@ import (
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/go-logr/logr"
 	go_yaml "github.com/goccy/go-yaml"
@@ -34,7 +35,10 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/certmanager"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/gatewayapi"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/keda"
 	autoRBAC "github.com/open-telemetry/opentelemetry-operator/internal/autodetect/rbac"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/vpa"
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
 	collectorManifests "github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector"
@@ -311,6 +315,37 @@ func TestCollectorDefaultingWebhook(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Setting VerticalAutoscaler memory limiter defaults",
+			otelcol: v1beta1.OpenTelemetryCollector{
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					VerticalAutoscaler: &v1beta1.VerticalAutoscalerSpec{
+						Enabled: true,
+					},
+				},
+			},
+			expected: v1beta1.OpenTelemetryCollector{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{},
+				},
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					Mode:            v1beta1.ModeDeployment,
+					UpgradeStrategy: v1beta1.UpgradeStrategyAutomatic,
+					NetworkPolicy:   v1beta1.NetworkPolicy{Enabled: new(true)},
+					OpenTelemetryCommonFields: v1beta1.OpenTelemetryCommonFields{
+						Replicas:        &one,
+						ManagementState: v1beta1.ManagementStateManaged,
+					},
+					VerticalAutoscaler: &v1beta1.VerticalAutoscalerSpec{
+						Enabled: true,
+						MemoryLimiter: v1beta1.MemoryLimiterTuning{
+							LimitPercentage:      new(int32(80)),
+							SpikeLimitPercentage: new(int32(20)),
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "Missing route termination",
 			otelcol: v1beta1.OpenTelemetryCollector{
@@ -1137,6 +1172,61 @@ func TestOTELColValidatingWebhook(t *testing.T) {
 			},
 			expectedErr: "the OpenTelemetry Spec autoscale configuration is incorrect, average value should be greater than 0",
 		},
+		{
+			name: "invalid mode with verticalAutoscaler",
+			otelcol: v1beta1.OpenTelemetryCollector{
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					Mode:               v1beta1.ModeStatefulSet,
+					VerticalAutoscaler: &v1beta1.VerticalAutoscalerSpec{Enabled: true},
+				},
+			},
+			expectedErr: "does not support the attribute 'verticalAutoscaler'",
+		},
+		{
+			name: "verticalAutoscaler without the VerticalPodAutoscaler installed",
+			otelcol: v1beta1.OpenTelemetryCollector{
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					Mode:               v1beta1.ModeDeployment,
+					VerticalAutoscaler: &v1beta1.VerticalAutoscalerSpec{Enabled: true},
+				},
+			},
+			expectedErr: "the VerticalPodAutoscaler is not installed in the cluster",
+		},
+		{
+			name: "keda autoscaler without maxReplicas",
+			otelcol: v1beta1.OpenTelemetryCollector{
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					Autoscaler: &v1beta1.AutoscalerSpec{
+						Type: v1beta1.AutoscalerTypeKeda,
+					},
+				},
+			},
+			expectedErr: "maxReplicas must be set when the autoscaler type is keda",
+		},
+		{
+			name: "keda autoscaler without KEDA installed",
+			otelcol: v1beta1.OpenTelemetryCollector{
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					Autoscaler: &v1beta1.AutoscalerSpec{
+						Type:        v1beta1.AutoscalerTypeKeda,
+						MaxReplicas: &three,
+					},
+				},
+			},
+			expectedErr: "the autoscaler type keda requires KEDA to be installed in the cluster",
+		},
+		{
+			name: "keda settings with hpa autoscaler",
+			otelcol: v1beta1.OpenTelemetryCollector{
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					Autoscaler: &v1beta1.AutoscalerSpec{
+						MaxReplicas: &three,
+						Keda:        &v1beta1.KedaAutoscalerSpec{},
+					},
+				},
+			},
+			expectedErr: "keda can only be set when the autoscaler type is keda",
+		},
 		{
 			name: "utilization target is not valid with pod metrics",
 			otelcol: v1beta1.OpenTelemetryCollector{
@@ -1230,6 +1320,55 @@ func TestOTELColValidatingWebhook(t *testing.T) {
 			},
 			expectedErr: "the OpenTelemetry Collector mode is set to sidecar, which does not support the attribute 'AdditionalContainers'",
 		},
+		{
+			name: "invalid mode with podMetadataAttributes",
+			otelcol: v1beta1.OpenTelemetryCollector{
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					Mode: v1beta1.ModeDeployment,
+					PodMetadataAttributes: &v1beta1.PodMetadataAttributes{
+						Labels: map[string]string{"app.kubernetes.io/version": "service.version"},
+					},
+				},
+			},
+			expectedErr: "the OpenTelemetry Collector mode is set to deployment, which does not support the attribute 'podMetadataAttributes'",
+		},
+		{
+			name: "invalid mode with configFrom",
+			otelcol: v1beta1.OpenTelemetryCollector{
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					Mode: v1beta1.ModeSidecar,
+					ConfigFrom: []v1beta1.ConfigSource{{
+						ConfigMapKeyRef: &v1.ConfigMapKeySelector{
+							LocalObjectReference: v1.LocalObjectReference{Name: "exporters"},
+							Key:                  "collector.yaml",
+						},
+					}},
+				},
+			},
+			expectedErr: "the OpenTelemetry Collector mode is set to sidecar, which does not support the attribute 'configFrom'",
+		},
+		{
+			name: "invalid mode with configRollout",
+			otelcol: v1beta1.OpenTelemetryCollector{
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					Mode:          v1beta1.ModeDaemonSet,
+					ConfigRollout: &v1beta1.ConfigRolloutSpec{CanaryReplicas: 1},
+				},
+			},
+			expectedErr: "the OpenTelemetry Collector mode is set to daemonset, which does not support the attribute 'configRollout'",
+		},
+		{
+			name: "invalid podMetadataAttributes with empty attribute name",
+			otelcol: v1beta1.OpenTelemetryCollector{
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					Mode: v1beta1.ModeSidecar,
+					PodMetadataAttributes: &v1beta1.PodMetadataAttributes{
+						Annotations: map[string]string{"example.com/owner": ""},
+					},
+				},
+			},
+			expectedErr: "empty key or attribute name",
+		},
 		{
 			name: "missing ingress hostname for subdomain ruleType",
 			otelcol: v1beta1.OpenTelemetryCollector{
@@ -1473,6 +1612,248 @@ func TestOTELColValidatingWebhook(t *testing.T) {
 	}
 }
 
+func TestKedaAutoscalerValidation(t *testing.T) {
+	three := int32(3)
+	tests := []struct {
+		name        string
+		autoscaler  v1beta1.AutoscalerSpec
+		expectedErr string
+	}{
+		{
+			name: "valid keda autoscaler",
+			autoscaler: v1beta1.AutoscalerSpec{
+				Type:        v1beta1.AutoscalerTypeKeda,
+				MaxReplicas: &three,
+				Keda: &v1beta1.KedaAutoscalerSpec{
+					Prometheus: &v1beta1.KedaPrometheusSpec{
+						ServerAddress: "http://prometheus.monitoring:9090",
+					},
+					ExporterQueueSize: &v1beta1.KedaMetricTarget{
+						TargetValue: resource.MustParse("100"),
+					},
+				},
+			},
+		},
+		{
+			name: "pods metrics",
+			autoscaler: v1beta1.AutoscalerSpec{
+				Type:        v1beta1.AutoscalerTypeKeda,
+				MaxReplicas: &three,
+				Metrics: []v1beta1.MetricSpec{
+					{
+						Type: autoscalingv2.PodsMetricSourceType,
+					},
+				},
+			},
+			expectedErr: "metrics are not supported with the autoscaler type keda",
+		},
+		{
+			name: "queue size without prometheus",
+			autoscaler: v1beta1.AutoscalerSpec{
+				Type:        v1beta1.AutoscalerTypeKeda,
+				MaxReplicas: &three,
+				Keda: &v1beta1.KedaAutoscalerSpec{
+					ExporterQueueSize: &v1beta1.KedaMetricTarget{
+						TargetValue: resource.MustParse("100"),
+					},
+				},
+			},
+			expectedErr: "keda.prometheus.serverAddress must be set",
+		},
+		{
+			name: "zero refused spans target",
+			autoscaler: v1beta1.AutoscalerSpec{
+				Type:        v1beta1.AutoscalerTypeKeda,
+				MaxReplicas: &three,
+				Keda: &v1beta1.KedaAutoscalerSpec{
+					Prometheus: &v1beta1.KedaPrometheusSpec{
+						ServerAddress: "http://prometheus.monitoring:9090",
+					},
+					RefusedSpans: &v1beta1.KedaMetricTarget{},
+				},
+			},
+			expectedErr: "keda target value should be greater than 0",
+		},
+		{
+			name: "kafka lag without consumer group",
+			autoscaler: v1beta1.AutoscalerSpec{
+				Type:        v1beta1.AutoscalerTypeKeda,
+				MaxReplicas: &three,
+				Keda: &v1beta1.KedaAutoscalerSpec{
+					KafkaLag: &v1beta1.KedaKafkaLagTrigger{
+						BootstrapServers: "kafka:9092",
+						LagThreshold:     10,
+					},
+				},
+			},
+			expectedErr: "keda.kafkaLag requires bootstrapServers and consumerGroup",
+		},
+		{
+			name: "trigger without type",
+			autoscaler: v1beta1.AutoscalerSpec{
+				Type:        v1beta1.AutoscalerTypeKeda,
+				MaxReplicas: &three,
+				Keda: &v1beta1.KedaAutoscalerSpec{
+					Triggers: []v1beta1.KedaTrigger{{Name: "custom"}},
+				},
+			},
+			expectedErr: "keda trigger type must be set",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cfg := config.Config{
+				CollectorImage:       "default-collector",
+				TargetAllocatorImage: "default-ta-allocator",
+				KedaAvailability:     keda.Available,
+			}
+			cvw := webhook.NewCollectorWebhook(
+				logr.Discard(),
+				testScheme,
+				cfg,
+				getReviewer(false),
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+			otelcol := v1beta1.OpenTelemetryCollector{
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					Autoscaler: &test.autoscaler,
+				},
+			}
+			_, err := cvw.ValidateCreate(context.Background(), &otelcol)
+			if test.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, test.expectedErr)
+			}
+		})
+	}
+}
+
+func TestVerticalAutoscalerValidation(t *testing.T) {
+	five := int32(5)
+	memoryLimits := v1beta1.OpenTelemetryCommonFields{
+		Resources: v1.ResourceRequirements{
+			Limits: v1.ResourceList{v1.ResourceMemory: resource.MustParse("1Gi")},
+		},
+	}
+	tests := []struct {
+		name             string
+		spec             v1beta1.OpenTelemetryCollectorSpec
+		expectedErr      string
+		expectedWarnings []string
+	}{
+		{
+			name: "valid vertical autoscaler",
+			spec: v1beta1.OpenTelemetryCollectorSpec{
+				OpenTelemetryCommonFields: memoryLimits,
+				Mode:                      v1beta1.ModeDaemonSet,
+				VerticalAutoscaler:        &v1beta1.VerticalAutoscalerSpec{Enabled: true},
+			},
+		},
+		{
+			name: "no memory limit",
+			spec: v1beta1.OpenTelemetryCollectorSpec{
+				Mode:               v1beta1.ModeDeployment,
+				VerticalAutoscaler: &v1beta1.VerticalAutoscalerSpec{Enabled: true},
+			},
+			expectedWarnings: []string{
+				"the collector container has no memory limit, the limits of the memory_limiter processor are relative to the memory of the node",
+			},
+		},
+		{
+			name: "GOMEMLIMIT set",
+			spec: v1beta1.OpenTelemetryCollectorSpec{
+				OpenTelemetryCommonFields: v1beta1.OpenTelemetryCommonFields{
+					Resources: memoryLimits.Resources,
+					Env:       []v1.EnvVar{{Name: "GOMEMLIMIT", Value: "500MiB"}},
+				},
+				Mode:               v1beta1.ModeDeployment,
+				VerticalAutoscaler: &v1beta1.VerticalAutoscalerSpec{Enabled: true},
+			},
+			expectedWarnings: []string{
+				"GOMEMLIMIT is set in the env, it's kept as is and doesn't follow the memory limit set by the VerticalPodAutoscaler, unlike the limits of the memory_limiter processor",
+			},
+		},
+		{
+			name: "horizontal autoscaler on the same resource",
+			spec: v1beta1.OpenTelemetryCollectorSpec{
+				OpenTelemetryCommonFields: memoryLimits,
+				Mode:                      v1beta1.ModeDeployment,
+				Autoscaler: &v1beta1.AutoscalerSpec{
+					MaxReplicas:          &five,
+					TargetCPUUtilization: new(int32(80)),
+				},
+				VerticalAutoscaler: &v1beta1.VerticalAutoscalerSpec{Enabled: true},
+			},
+			expectedWarnings: []string{
+				"the collector is scaled horizontally and vertically on the same resources, which makes the autoscalers compete with each other. Consider scaling horizontally on other metrics.",
+			},
+		},
+		{
+			name: "horizontal autoscaler on another resource",
+			spec: v1beta1.OpenTelemetryCollectorSpec{
+				OpenTelemetryCommonFields: memoryLimits,
+				Mode:                      v1beta1.ModeDeployment,
+				Autoscaler: &v1beta1.AutoscalerSpec{
+					MaxReplicas:          &five,
+					TargetCPUUtilization: new(int32(80)),
+				},
+				VerticalAutoscaler: &v1beta1.VerticalAutoscalerSpec{
+					Enabled:             true,
+					ControlledResources: []v1.ResourceName{v1.ResourceMemory},
+				},
+			},
+		},
+		{
+			name: "spike limit above the limit",
+			spec: v1beta1.OpenTelemetryCollectorSpec{
+				OpenTelemetryCommonFields: memoryLimits,
+				Mode:                      v1beta1.ModeDeployment,
+				VerticalAutoscaler: &v1beta1.VerticalAutoscalerSpec{
+					Enabled: true,
+					MemoryLimiter: v1beta1.MemoryLimiterTuning{
+						LimitPercentage:      new(int32(50)),
+						SpikeLimitPercentage: new(int32(50)),
+					},
+				},
+			},
+			expectedErr: "memoryLimiter.spikeLimitPercentage must be lower than memoryLimiter.limitPercentage",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cfg := config.Config{
+				CollectorImage:       "default-collector",
+				TargetAllocatorImage: "default-ta-allocator",
+				VPAAvailability:      vpa.Available,
+			}
+			cvw := webhook.NewCollectorWebhook(
+				logr.Discard(),
+				testScheme,
+				cfg,
+				getReviewer(false),
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+			otelcol := v1beta1.OpenTelemetryCollector{Spec: test.spec}
+			warnings, err := cvw.ValidateCreate(context.Background(), &otelcol)
+			if test.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, test.expectedErr)
+			}
+			assert.ElementsMatch(t, test.expectedWarnings, warnings)
+		})
+	}
+}
+
 func TestCollectorMTLSValidation(t *testing.T) {
 	cfg := v1beta1.Config{}
 	err := go_yaml.Unmarshal([]byte(cfgYaml), &cfg)
@@ -2100,3 +2481,452 @@ func TestValidateRBACPrivilegeEscalation(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateRBACPrivilegeEscalationRoles(t *testing.T) {
+	const cfgYAML = `
+receivers:
+  prometheus:
+    config:
+      scrape_configs:
+        - job_name: pods
+          kubernetes_sd_configs:
+            - role: pod
+              namespaces:
+                names: [apps]
+exporters:
+  debug: {}
+service:
+  pipelines:
+    metrics:
+      receivers: [prometheus]
+      exporters: [debug]
+`
+	var colCfg v1beta1.Config
+	require.NoError(t, go_yaml.Unmarshal([]byte(cfgYAML), &colCfg))
+	col := v1beta1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-col", Namespace: "default"},
+		Spec:       v1beta1.OpenTelemetryCollectorSpec{Config: colCfg},
+	}
+
+	// the service account holds nothing, the user only holds the permissions in userNamespace
+	reviewerFor := func(userNamespace string) *rbac.Reviewer {
+		c := fake.NewClientset()
+		c.PrependReactor("create", "subjectaccessreviews", func(action kubeTesting.Action) (handled bool, ret runtime.Object, err error) {
+			sar, ok := action.(kubeTesting.CreateAction).GetObject().DeepCopyObject().(*authv1.SubjectAccessReview)
+			if !ok || sar == nil {
+				return false, nil, errors.New("bad object")
+			}
+			allowed := !strings.HasPrefix(sar.Spec.User, "system:serviceaccount:") &&
+				sar.Spec.ResourceAttributes != nil && sar.Spec.ResourceAttributes.Namespace == userNamespace
+			sar.Status = authv1.SubjectAccessReviewStatus{Allowed: allowed, Denied: !allowed}
+			return true, sar, nil
+		})
+		return rbac.NewReviewer(c)
+	}
+
+	tests := []struct {
+		name          string
+		rolePerms     autoRBAC.Availability
+		userNamespace string
+		expectedErr   string
+	}{
+		{
+			name:          "user holds the permissions in the namespace of the role",
+			rolePerms:     autoRBAC.Available,
+			userNamespace: "apps",
+		},
+		{
+			name:          "user holds the permissions in another namespace",
+			rolePerms:     autoRBAC.Available,
+			userNamespace: "other",
+			expectedErr:   `user "alice" is not allowed to create a collector whose config would grant permissions they do not hold`,
+		},
+		{
+			name:          "namespaced rules granted cluster-wide",
+			rolePerms:     autoRBAC.NotAvailable,
+			userNamespace: "apps",
+			expectedErr:   `user "alice" is not allowed to create a collector whose config would grant permissions they do not hold`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.Config{
+				CollectorImage:            "default-collector",
+				TargetAllocatorImage:      "default-ta-allocator",
+				CreateRBACPermissions:     autoRBAC.Available,
+				CreateRoleRBACPermissions: tt.rolePerms,
+			}
+			cvw := webhook.NewCollectorWebhook(logr.Discard(), testScheme, cfg, reviewerFor(tt.userNamespace), nil, nil, nil, nil)
+
+			_, err := cvw.ValidateCreate(requestContext(context.Background(), "alice", nil), col.DeepCopy())
+			if tt.expectedErr != "" {
+				require.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestReceiverTLSValidation(t *testing.T) {
+	tests := []struct {
+		name             string
+		spec             v1beta1.OpenTelemetryCollectorSpec
+		availability     certmanager.Availability
+		expectedErr      string
+		expectedWarnings []string
+	}{
+		{
+			name: "valid receiver tls",
+			spec: v1beta1.OpenTelemetryCollectorSpec{
+				Mode: v1beta1.ModeDeployment,
+				TLS:  &v1beta1.ReceiverTLSSpec{Enabled: true, ClientAuth: true},
+			},
+			availability: certmanager.Available,
+		},
+		{
+			name: "cert-manager not available",
+			spec: v1beta1.OpenTelemetryCollectorSpec{
+				Mode: v1beta1.ModeDeployment,
+				TLS:  &v1beta1.ReceiverTLSSpec{Enabled: true},
+			},
+			availability: certmanager.NotAvailable,
+			expectedErr:  "cert-manager is not installed in the cluster",
+		},
+		{
+			name: "sidecar",
+			spec: v1beta1.OpenTelemetryCollectorSpec{
+				Mode: v1beta1.ModeSidecar,
+				TLS:  &v1beta1.ReceiverTLSSpec{Enabled: true},
+			},
+			availability: certmanager.Available,
+			expectedErr:  "does not support the attribute 'tls'",
+		},
+		{
+			name: "negative reload interval",
+			spec: v1beta1.OpenTelemetryCollectorSpec{
+				Mode: v1beta1.ModeDeployment,
+				TLS:  &v1beta1.ReceiverTLSSpec{Enabled: true, ReloadInterval: &metav1.Duration{Duration: -time.Minute}},
+			},
+			availability: certmanager.Available,
+			expectedErr:  "reloadInterval must be positive",
+		},
+		{
+			name: "client auth with an issuer",
+			spec: v1beta1.OpenTelemetryCollectorSpec{
+				Mode: v1beta1.ModeStatefulSet,
+				TLS: &v1beta1.ReceiverTLSSpec{
+					Enabled:    true,
+					ClientAuth: true,
+					IssuerRef:  &v1beta1.TLSIssuerReference{Name: "corporate-ca", Kind: v1beta1.TLSIssuerKindClusterIssuer},
+				},
+			},
+			availability: certmanager.Available,
+			expectedWarnings: []string{
+				"the receivers verify client certificates with the CA in the certificate Secret, make sure the issuer corporate-ca provides it",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cfg := config.Config{
+				CollectorImage:          "default-collector",
+				TargetAllocatorImage:    "default-ta-allocator",
+				CertManagerAvailability: test.availability,
+			}
+			cvw := webhook.NewCollectorWebhook(
+				logr.Discard(),
+				testScheme,
+				cfg,
+				getReviewer(false),
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+			otelcol := v1beta1.OpenTelemetryCollector{Spec: test.spec}
+			warnings, err := cvw.ValidateCreate(context.Background(), &otelcol)
+			if test.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, test.expectedErr)
+			}
+			assert.ElementsMatch(t, test.expectedWarnings, warnings)
+		})
+	}
+}
+
+func TestGatewayRoutesValidation(t *testing.T) {
+	tests := []struct {
+		name        string
+		spec        v1beta1.OpenTelemetryCollectorSpec
+		available   bool
+		expectedErr string
+	}{
+		{
+			name: "valid routes",
+			spec: v1beta1.OpenTelemetryCollectorSpec{
+				Mode:      v1beta1.ModeDeployment,
+				GRPCRoute: v1beta1.GRPCRouteConfig{Enabled: true, Gateway: "example-gateway"},
+				TLSRoute:  v1beta1.TLSRouteConfig{Enabled: true, Gateway: "example-gateway", Hostnames: []string{"otel.example.com"}},
+			},
+			available: true,
+		},
+		{
+			name: "disabled routes are not validated",
+			spec: v1beta1.OpenTelemetryCollectorSpec{
+				Mode:     v1beta1.ModeSidecar,
+				TLSRoute: v1beta1.TLSRouteConfig{Gateway: "example-gateway"},
+			},
+		},
+		{
+			name: "sidecar",
+			spec: v1beta1.OpenTelemetryCollectorSpec{
+				Mode:      v1beta1.ModeSidecar,
+				GRPCRoute: v1beta1.GRPCRouteConfig{Enabled: true, Gateway: "example-gateway"},
+			},
+			available:   true,
+			expectedErr: "does not support the attribute 'grpcRoute'",
+		},
+		{
+			name: "GRPCRoute not available",
+			spec: v1beta1.OpenTelemetryCollectorSpec{
+				Mode:      v1beta1.ModeDeployment,
+				GRPCRoute: v1beta1.GRPCRouteConfig{Enabled: true, Gateway: "example-gateway"},
+			},
+			expectedErr: "the Gateway API GRPCRoute is not installed in the cluster",
+		},
+		{
+			name: "missing gateway",
+			spec: v1beta1.OpenTelemetryCollectorSpec{
+				Mode:     v1beta1.ModeDeployment,
+				TLSRoute: v1beta1.TLSRouteConfig{Enabled: true, Hostnames: []string{"otel.example.com"}},
+			},
+			available:   true,
+			expectedErr: "gateway is required",
+		},
+		{
+			name: "TLSRoute without hostnames",
+			spec: v1beta1.OpenTelemetryCollectorSpec{
+				Mode:     v1beta1.ModeDeployment,
+				TLSRoute: v1beta1.TLSRouteConfig{Enabled: true, Gateway: "example-gateway"},
+			},
+			available:   true,
+			expectedErr: "at least one hostname is required",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cfg := config.Config{
+				CollectorImage:        "default-collector",
+				TargetAllocatorImage:  "default-ta-allocator",
+				GRPCRouteAvailability: gatewayapi.ApiNotAvailable,
+				TLSRouteAvailability:  gatewayapi.ApiNotAvailable,
+			}
+			if test.available {
+				cfg.GRPCRouteAvailability = gatewayapi.ApiAvailable
+				cfg.TLSRouteAvailability = gatewayapi.ApiAvailable
+			}
+			cvw := webhook.NewCollectorWebhook(
+				logr.Discard(),
+				testScheme,
+				cfg,
+				getReviewer(false),
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+			otelcol := v1beta1.OpenTelemetryCollector{Spec: test.spec}
+			_, err := cvw.ValidateCreate(context.Background(), &otelcol)
+			if test.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, test.expectedErr)
+			}
+		})
+	}
+}
+
+func TestPersistenceValidation(t *testing.T) {
+	fileStorageConfig := v1beta1.Config{
+		Extensions: &v1beta1.AnyConfig{Object: map[string]any{
+			"file_storage": map[string]any{"directory": "/var/lib/otelcol/queue"},
+		}},
+		Service: v1beta1.Service{Extensions: []string{"file_storage"}},
+	}
+	tests := []struct {
+		name             string
+		spec             v1beta1.OpenTelemetryCollectorSpec
+		expectedErr      string
+		expectedWarnings []string
+	}{
+		{
+			name: "valid persistence",
+			spec: v1beta1.OpenTelemetryCollectorSpec{
+				Mode:        v1beta1.ModeStatefulSet,
+				Config:      fileStorageConfig,
+				Persistence: &v1beta1.PersistenceSpec{Enabled: true, Fsync: true},
+			},
+		},
+		{
+			name: "host path written by a collector running as root",
+			spec: v1beta1.OpenTelemetryCollectorSpec{
+				Mode:   v1beta1.ModeDaemonSet,
+				Config: fileStorageConfig,
+				OpenTelemetryCommonFields: v1beta1.OpenTelemetryCommonFields{
+					SecurityContext: &v1.SecurityContext{RunAsUser: new(int64(0))},
+				},
+				Persistence: &v1beta1.PersistenceSpec{Enabled: true, HostPath: "/data/otelcol"},
+			},
+		},
+		{
+			name: "host path written by the collector user",
+			spec: v1beta1.OpenTelemetryCollectorSpec{
+				Mode:        v1beta1.ModeDaemonSet,
+				Config:      fileStorageConfig,
+				Persistence: &v1beta1.PersistenceSpec{Enabled: true, HostPath: "/data/otelcol"},
+			},
+			expectedWarnings: []string{"the hostPath directories of the persistence are created owned by root, and Kubernetes doesn't change their ownership: the collector can only write to them when it runs as a user allowed to, when they are prepared on the nodes beforehand, or when persistence.chownHostPath is set"},
+		},
+		{
+			name: "host path handed over to the collector user",
+			spec: v1beta1.OpenTelemetryCollectorSpec{
+				Mode:        v1beta1.ModeDaemonSet,
+				Config:      fileStorageConfig,
+				Persistence: &v1beta1.PersistenceSpec{Enabled: true, HostPath: "/data/otelcol", ChownHostPath: true},
+			},
+		},
+		{
+			name: "sidecar",
+			spec: v1beta1.OpenTelemetryCollectorSpec{
+				Mode:        v1beta1.ModeSidecar,
+				Config:      fileStorageConfig,
+				Persistence: &v1beta1.PersistenceSpec{Enabled: true},
+			},
+			expectedErr: "does not support the attribute 'persistence'",
+		},
+		{
+			name: "relative host path",
+			spec: v1beta1.OpenTelemetryCollectorSpec{
+				Mode:        v1beta1.ModeDaemonSet,
+				Config:      fileStorageConfig,
+				Persistence: &v1beta1.PersistenceSpec{Enabled: true, HostPath: "otelcol"},
+			},
+			expectedErr: "hostPath must be an absolute path",
+		},
+		{
+			name: "no file_storage extension",
+			spec: v1beta1.OpenTelemetryCollectorSpec{
+				Mode:        v1beta1.ModeStatefulSet,
+				Persistence: &v1beta1.PersistenceSpec{Enabled: true},
+			},
+			expectedWarnings: []string{"persistence is enabled, but no file_storage extension is enabled in the service"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cfg := config.Config{
+				CollectorImage:       "default-collector",
+				TargetAllocatorImage: "default-ta-allocator",
+			}
+			cvw := webhook.NewCollectorWebhook(
+				logr.Discard(),
+				testScheme,
+				cfg,
+				getReviewer(false),
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+			otelcol := v1beta1.OpenTelemetryCollector{Spec: test.spec}
+			warnings, err := cvw.ValidateCreate(context.Background(), &otelcol)
+			if test.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, test.expectedErr)
+			}
+			assert.ElementsMatch(t, test.expectedWarnings, warnings)
+		})
+	}
+}
+
+func TestMonitoringValidation(t *testing.T) {
+	tests := []struct {
+		name             string
+		mode             v1beta1.Mode
+		enableMetrics    bool
+		monitoring       v1beta1.MonitoringSpec
+		expectedErr      string
+		expectedWarnings []string
+	}{
+		{
+			name:          "alerts and dashboard",
+			mode:          v1beta1.ModeDeployment,
+			enableMetrics: true,
+			monitoring:    v1beta1.MonitoringSpec{EnableAlerts: true, Dashboard: &v1beta1.MetricsDashboardSpec{Enabled: true}},
+		},
+		{
+			name:       "disabled dashboard",
+			mode:       v1beta1.ModeDeployment,
+			monitoring: v1beta1.MonitoringSpec{Dashboard: &v1beta1.MetricsDashboardSpec{}},
+		},
+		{
+			name:             "metrics not scraped",
+			mode:             v1beta1.ModeStatefulSet,
+			monitoring:       v1beta1.MonitoringSpec{EnableAlerts: true, Dashboard: &v1beta1.MetricsDashboardSpec{Enabled: true}},
+			expectedWarnings: []string{"monitoring enableAlerts and dashboard rely on the collector metrics, but observability.metrics.enableMetrics is not set: the metrics must be scraped by other means"},
+		},
+		{
+			name:          "sidecar",
+			mode:          v1beta1.ModeSidecar,
+			enableMetrics: true,
+			monitoring:    v1beta1.MonitoringSpec{EnableAlerts: true},
+			expectedErr:   "the OpenTelemetry Collector mode is set to sidecar, which does not support the attribute 'monitoring'",
+		},
+		{
+			name:          "sidecar without alerts and dashboard",
+			mode:          v1beta1.ModeSidecar,
+			enableMetrics: true,
+			monitoring:    v1beta1.MonitoringSpec{Dashboard: &v1beta1.MetricsDashboardSpec{}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cfg := config.Config{
+				CollectorImage:       "default-collector",
+				TargetAllocatorImage: "default-ta-allocator",
+			}
+			cvw := webhook.NewCollectorWebhook(
+				logr.Discard(),
+				testScheme,
+				cfg,
+				getReviewer(false),
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+			otelcol := v1beta1.OpenTelemetryCollector{
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					Mode:          test.mode,
+					Observability: v1beta1.ObservabilitySpec{Metrics: v1beta1.MetricsConfigSpec{EnableMetrics: test.enableMetrics}},
+					Monitoring:    test.monitoring,
+				},
+			}
+			warnings, err := cvw.ValidateCreate(context.Background(), &otelcol)
+			if test.expectedErr != "" {
+				assert.ErrorContains(t, err, test.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.ElementsMatch(t, test.expectedWarnings, warnings)
+		})
+	}
+}
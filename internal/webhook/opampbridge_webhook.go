@@ -103,8 +103,8 @@ func (*OpAMPBridgeWebhook) validate(r *v1alpha1.OpAMPBridge) (admission.Warnings
 	}
 
 	// check for maximum replica count
-	if r.Spec.Replicas != nil && *r.Spec.Replicas > 1 {
-		return warnings, errors.New("replica count must not be greater than 1")
+	if r.Spec.Replicas != nil && *r.Spec.Replicas > 1 && r.Spec.LeaderElection == nil {
+		return warnings, errors.New("replica count must not be greater than 1 without leaderElection")
 	}
 	return warnings, nil
 }
@@ -207,6 +207,33 @@ func TestOpAMPBridgeValidatingWebhook(t *testing.T) {
 			},
 			expectedErr: "replica count must not be greater than 1",
 		},
+		{
+			name: "replica count greater than 1 with leaderElection should not return error",
+			opampBridge: v1alpha1.OpAMPBridge{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test",
+					Namespace: "default",
+				},
+				Spec: v1alpha1.OpAMPBridgeSpec{
+					Replicas:       &two,
+					LeaderElection: &v1alpha1.OpAMPBridgeLeaderElection{},
+					Endpoint:       "ws://opamp-server:4320/v1/opamp",
+					Capabilities: map[v1alpha1.OpAMPBridgeCapability]bool{
+						v1alpha1.OpAMPBridgeCapabilityReportsStatus:                  true,
+						v1alpha1.OpAMPBridgeCapabilityAcceptsRemoteConfig:            true,
+						v1alpha1.OpAMPBridgeCapabilityReportsEffectiveConfig:         true,
+						v1alpha1.OpAMPBridgeCapabilityReportsOwnTraces:               true,
+						v1alpha1.OpAMPBridgeCapabilityReportsOwnMetrics:              true,
+						v1alpha1.OpAMPBridgeCapabilityReportsOwnLogs:                 true,
+						v1alpha1.OpAMPBridgeCapabilityAcceptsOpAMPConnectionSettings: true,
+						v1alpha1.OpAMPBridgeCapabilityAcceptsOtherConnectionSettings: true,
+						v1alpha1.OpAMPBridgeCapabilityAcceptsRestartCommand:          true,
+						v1alpha1.OpAMPBridgeCapabilityReportsHealth:                  true,
+						v1alpha1.OpAMPBridgeCapabilityReportsRemoteConfig:            true,
+					},
+				},
+			},
+		},
 		{
 			name: "invalid port name",
 			opampBridge: v1alpha1.OpAMPBridge{
@@ -7,6 +7,7 @@ package podmutation
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 
 	"github.com/go-logr/logr"
@@ -41,6 +42,10 @@ type podMutationWebhook struct {
 	config      config.Config
 }
 
+// ErrPodDenied is wrapped by the errors of the PodMutators that refuse the pod. The pod is then denied, while it is
+// admitted unchanged on any other error.
+var ErrPodDenied = errors.New("the pod is denied")
+
 // PodMutator mutates a pod.
 type PodMutator interface {
 	Mutate(ctx context.Context, ns corev1.Namespace, pod corev1.Pod) (corev1.Pod, error)
@@ -80,6 +85,9 @@ func (p *podMutationWebhook) Handle(ctx context.Context, req admission.Request)
 
 	for _, m := range p.podMutators {
 		pod, err = m.Mutate(ctx, ns, pod)
+		if errors.Is(err, ErrPodDenied) {
+			return admission.Denied(err.Error())
+		}
 		if err != nil {
 			res := admission.Errored(http.StatusInternalServerError, err)
 			res.Allowed = true
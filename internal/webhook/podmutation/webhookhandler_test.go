@@ -140,7 +140,7 @@ func TestShouldInjectSidecar(t *testing.T) {
 			// the webhook handler
 			cfg := config.New()
 			decoder := admission.NewDecoder(scheme.Scheme)
-			injector := NewWebhookHandler(cfg, logger, decoder, k8sClient, []PodMutator{sidecar.NewMutator(logger, cfg, k8sClient)})
+			injector := NewWebhookHandler(cfg, logger, decoder, k8sClient, []PodMutator{sidecar.NewMutator(logger, cfg, k8sClient, nil)})
 
 			// test
 			res := injector.Handle(context.Background(), req)
@@ -358,7 +358,7 @@ func TestPodShouldNotBeChanged(t *testing.T) {
 			// the webhook handler
 			cfg := config.New()
 			decoder := admission.NewDecoder(scheme.Scheme)
-			injector := NewWebhookHandler(cfg, logger, decoder, k8sClient, []PodMutator{sidecar.NewMutator(logger, cfg, k8sClient)})
+			injector := NewWebhookHandler(cfg, logger, decoder, k8sClient, []PodMutator{sidecar.NewMutator(logger, cfg, k8sClient, nil)})
 			require.NoError(t, err)
 
 			// test
@@ -416,7 +416,7 @@ func TestFailOnInvalidRequest(t *testing.T) {
 			// prepare
 			cfg := config.New()
 			decoder := admission.NewDecoder(scheme.Scheme)
-			injector := NewWebhookHandler(cfg, logger, decoder, k8sClient, []PodMutator{sidecar.NewMutator(logger, cfg, k8sClient)})
+			injector := NewWebhookHandler(cfg, logger, decoder, k8sClient, []PodMutator{sidecar.NewMutator(logger, cfg, k8sClient, nil)})
 
 			// test
 			res := injector.Handle(context.Background(), tt.req)
@@ -25,6 +25,7 @@ const (
 	AnnotationDefaultAutoInstrumentationNginx       = InstrumentationPrefix + "default-auto-instrumentation-nginx-image"
 
 	LabelTargetAllocator                         = "opentelemetry.io/target-allocator"
+	LabelConfigFragment                          = "opentelemetry.io/config-fragment"
 	ResourceAttributeAnnotationPrefix            = "resource.opentelemetry.io/"
 	KubernetesLastAppliedConfigurationAnnotation = "^kubectl\\.kubernetes\\.io/last-applied-configuration$"
 
@@ -38,6 +39,8 @@ const (
 	TACollectorCAFileName      = "ca.crt"
 	TACollectorTLSKeyFileName  = "tls.key"
 	TACollectorTLSCertFileName = "tls.crt"
+
+	CollectorReceiverTLSDirPath = "/receiver-tls"
 )
 
 // InstrumentationLanguage represents a language for auto-instrumentation.
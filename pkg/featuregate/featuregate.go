@@ -53,6 +53,22 @@ var (
 		featuregate.WithRegisterDescription("enables the ClusterObservability controller for managed observability deployment"),
 		featuregate.WithRegisterFromVersion("v0.134.0"),
 	)
+	// EnableCollectorTopology is the feature gate that enables the CollectorTopology controller.
+	EnableCollectorTopology = featuregate.GlobalRegistry().MustRegister(
+		"operator.collectortopology",
+		featuregate.StageAlpha,
+		featuregate.WithRegisterDescription("enables the CollectorTopology controller deploying load balanced agent and gateway collectors"),
+		featuregate.WithRegisterFromVersion("v0.159.0"),
+	)
+	// EnableSidecarJobWatcher is the feature gate that enables stopping the collector sidecar of Job pods once their
+	// containers exit, on clusters without native sidecars, and draining its exporter queues before it's stopped
+	// otherwise.
+	EnableSidecarJobWatcher = featuregate.GlobalRegistry().MustRegister(
+		"operator.sidecar.jobwatcher",
+		featuregate.StageAlpha,
+		featuregate.WithRegisterDescription("enables a watcher container stopping the collector sidecar of Job pods once their containers exit when native sidecars are not available, and a container draining its exporter queues before it's stopped otherwise"),
+		featuregate.WithRegisterFromVersion("v0.159.0"),
+	)
 	// UseCollectorDefaultTelemetryShape, when enabled (stable, always on), makes
 	// the operator-injected Prometheus telemetry reader use collector defaults
 	// for without_type_suffix, without_units, and without_scope_info — metric
@@ -12,6 +12,10 @@ import (
 const (
 	// Annotation contains the annotation name that pods contain, indicating whether a sidecar is desired.
 	Annotation = "sidecar.opentelemetry.io/inject"
+
+	// ConfigOverrideAnnotation contains the annotation name that pods contain, referencing a ConfigMap in the pod's
+	// namespace with a partial collector configuration to merge into the sidecar's configuration.
+	ConfigOverrideAnnotation = "sidecar.opentelemetry.io/config-override"
 )
 
 // annotationValue returns the effective annotation value, based on the annotations from the pod and namespace.
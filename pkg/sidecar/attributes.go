@@ -12,6 +12,7 @@ import (
 	"go.opentelemetry.io/otel/attribute"
 	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 
 	"github.com/open-telemetry/opentelemetry-operator/pkg/constants"
@@ -22,6 +23,7 @@ const resourceAttributesEnvName = "OTEL_RESOURCE_ATTRIBUTES"
 type podReferences struct {
 	replicaset *appsv1.ReplicaSet
 	deployment *appsv1.Deployment
+	job        *batchv1.Job
 }
 
 // getResourceAttributesEnv returns a list of environment variables. The list contains OTEL_RESOURCE_ATTRIBUTES and additional environment variables that use Kubernetes downward API to read pod specification.
@@ -46,6 +48,17 @@ func getResourceAttributesEnv(ns corev1.Namespace, podReferences podReferences)
 		attributes[semconv.K8SReplicaSetNameKey] = string(podReferences.replicaset.Name)
 	}
 
+	if podReferences.job != nil {
+		attributes[semconv.K8SJobUIDKey] = string(podReferences.job.UID)
+		attributes[semconv.K8SJobNameKey] = podReferences.job.Name
+		for _, owner := range podReferences.job.OwnerReferences {
+			if owner.Kind == "CronJob" {
+				attributes[semconv.K8SCronJobUIDKey] = string(owner.UID)
+				attributes[semconv.K8SCronJobNameKey] = owner.Name
+			}
+		}
+	}
+
 	envvars = append(envvars, corev1.EnvVar{
 		Name: constants.EnvPodName,
 		ValueFrom: &corev1.EnvVarSource{
@@ -0,0 +1,156 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sidecar
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	go_yaml "github.com/goccy/go-yaml"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/otelconfig"
+	"github.com/open-telemetry/opentelemetry-operator/internal/webhook/podmutation"
+)
+
+var errInvalidConfigOverride = fmt.Errorf("%w: the pod's config override can't be applied", podmutation.ErrPodDenied)
+
+// CollectorValidator validates an OpenTelemetryCollector the way the collector webhook does.
+type CollectorValidator interface {
+	Validate(ctx context.Context, otelcol *v1beta1.OpenTelemetryCollector) (admission.Warnings, error)
+}
+
+// applyConfigOverride returns a copy of the given OpenTelemetryCollector with the pod's config override merged into its
+// configuration. The merged instance never goes through the collector webhook, so it is validated with the mutator's
+// CollectorValidator.
+func (p *sidecarPodMutator) applyConfigOverride(ctx context.Context, ns corev1.Namespace, pod corev1.Pod, otelcol v1beta1.OpenTelemetryCollector) (v1beta1.OpenTelemetryCollector, error) {
+	override, err := p.getConfigOverride(ctx, ns, pod)
+	if err != nil {
+		return otelcol, err
+	}
+	merged, err := mergeConfig(otelcol.Spec.Config, override)
+	if err == nil {
+		err = validateReferences(&merged)
+	}
+	if err != nil {
+		return otelcol, fmt.Errorf("%w: the config override %s/%s is invalid: %w", errInvalidConfigOverride, ns.Name, pod.Annotations[ConfigOverrideAnnotation], err)
+	}
+
+	// the instance comes from the client's cache, make sure we don't modify it
+	changed := *otelcol.DeepCopy()
+	changed.Spec.Config = merged
+	if p.validator != nil {
+		if _, err = p.validator.Validate(ctx, &changed); err != nil {
+			return otelcol, fmt.Errorf("%w: the config override %s/%s is invalid: %w", errInvalidConfigOverride, ns.Name, pod.Annotations[ConfigOverrideAnnotation], err)
+		}
+	}
+	return changed, nil
+}
+
+// getConfigOverride returns the partial collector configuration referenced by the pod's ConfigOverrideAnnotation.
+// The ConfigMap has to live in the pod's namespace and hold the configuration under the collector ConfigMap entry.
+func (p *sidecarPodMutator) getConfigOverride(ctx context.Context, ns corev1.Namespace, pod corev1.Pod) (string, error) {
+	name := pod.Annotations[ConfigOverrideAnnotation]
+	cm := corev1.ConfigMap{}
+	if err := p.client.Get(ctx, types.NamespacedName{Name: name, Namespace: ns.Name}, &cm); err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", fmt.Errorf("%w: the config override ConfigMap %s/%s doesn't exist", errInvalidConfigOverride, ns.Name, name)
+		}
+		return "", fmt.Errorf("failed to get the config override ConfigMap %s/%s: %w", ns.Name, name, err)
+	}
+	override, ok := cm.Data[p.config.CollectorConfigMapEntry]
+	if !ok {
+		return "", fmt.Errorf("%w: the config override ConfigMap %s/%s has no %q entry", errInvalidConfigOverride, ns.Name, name, p.config.CollectorConfigMapEntry)
+	}
+	return override, nil
+}
+
+// overrideMergeOptions lets the override replace the lists and values of the base configuration.
+var overrideMergeOptions = otelconfig.MergeOptions{Lists: otelconfig.ListReplace, Conflicts: otelconfig.ConflictOverride}
+
+// mergeConfig deep-merges the given partial configuration into the base configuration. Maps are merged recursively,
+// while scalars and lists, such as the components of a pipeline, replace the base value. A null value removes the key.
+func mergeConfig(base v1beta1.Config, override string) (v1beta1.Config, error) {
+	overrideMap := map[string]any{}
+	if err := go_yaml.Unmarshal([]byte(override), &overrideMap); err != nil {
+		return v1beta1.Config{}, fmt.Errorf("failed to parse the config override: %w", err)
+	}
+	if len(overrideMap) == 0 {
+		return v1beta1.Config{}, errors.New("the config override is empty")
+	}
+
+	baseYaml, err := base.Yaml()
+	if err != nil {
+		return v1beta1.Config{}, err
+	}
+	baseMap := map[string]any{}
+	if err = go_yaml.Unmarshal([]byte(baseYaml), &baseMap); err != nil {
+		return v1beta1.Config{}, err
+	}
+
+	if err = otelconfig.MergeMaps(baseMap, overrideMap, overrideMergeOptions); err != nil {
+		return v1beta1.Config{}, err
+	}
+	merged, err := go_yaml.Marshal(baseMap)
+	if err != nil {
+		return v1beta1.Config{}, err
+	}
+	cfg := v1beta1.Config{}
+	if err = go_yaml.Unmarshal(merged, &cfg); err != nil {
+		return v1beta1.Config{}, fmt.Errorf("the merged config is not a valid collector config: %w", err)
+	}
+	return cfg, nil
+}
+
+// validateReferences checks that every component referenced by the service is configured. The collector webhook
+// leaves this to the collector itself, which would only fail once the sidecar starts.
+func validateReferences(cfg *v1beta1.Config) error {
+	connectors := componentIDs(cfg.Connectors)
+	for name, pipeline := range cfg.Service.Pipelines {
+		if pipeline == nil {
+			continue
+		}
+		if err := checkReferences(name, v1beta1.KindReceiver, pipeline.Receivers, append(componentIDs(&cfg.Receivers), connectors...)); err != nil {
+			return err
+		}
+		if err := checkReferences(name, v1beta1.KindProcessor, pipeline.Processors, componentIDs(cfg.Processors)); err != nil {
+			return err
+		}
+		if err := checkReferences(name, v1beta1.KindExporter, pipeline.Exporters, append(componentIDs(&cfg.Exporters), connectors...)); err != nil {
+			return err
+		}
+	}
+	for _, id := range cfg.Service.Extensions {
+		if !slices.Contains(componentIDs(cfg.Extensions), id) {
+			return fmt.Errorf("the service references the %s %q, which is not configured", v1beta1.KindExtension, id)
+		}
+	}
+	return nil
+}
+
+func checkReferences(pipeline string, kind v1beta1.ComponentKind, referenced, configured []string) error {
+	for _, id := range referenced {
+		if !slices.Contains(configured, id) {
+			return fmt.Errorf("the pipeline %q references the %s %q, which is not configured", pipeline, kind, id)
+		}
+	}
+	return nil
+}
+
+func componentIDs(c *v1beta1.AnyConfig) []string {
+	if c == nil {
+		return nil
+	}
+	ids := make([]string, 0, len(c.Object))
+	for id := range c.Object {
+		ids = append(ids, id)
+	}
+	return ids
+}
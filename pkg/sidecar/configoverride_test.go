@@ -0,0 +1,244 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sidecar
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/webhook"
+	"github.com/open-telemetry/opentelemetry-operator/internal/webhook/podmutation"
+)
+
+func baseOverrideConfig() v1beta1.Config {
+	return v1beta1.Config{
+		Receivers: v1beta1.AnyConfig{Object: map[string]any{
+			"otlp": map[string]any{"protocols": map[string]any{"grpc": map[string]any{}}},
+		}},
+		Processors: &v1beta1.AnyConfig{Object: map[string]any{
+			"batch": map[string]any{},
+		}},
+		Exporters: v1beta1.AnyConfig{Object: map[string]any{
+			"otlp": map[string]any{
+				"endpoint": "collector:4317",
+				"headers":  map[string]any{"tenant": "default"},
+			},
+		}},
+		Service: v1beta1.Service{
+			Pipelines: map[string]*v1beta1.Pipeline{
+				"traces": {
+					Receivers:  []string{"otlp"},
+					Processors: []string{"batch"},
+					Exporters:  []string{"otlp"},
+				},
+			},
+		},
+	}
+}
+
+func TestMergeConfig(t *testing.T) {
+	override := `
+processors:
+  attributes:
+    actions:
+      - key: team
+        value: checkout
+        action: upsert
+exporters:
+  otlp:
+    headers:
+      tenant: checkout
+service:
+  pipelines:
+    traces:
+      processors: [attributes, batch]
+`
+	merged, err := mergeConfig(baseOverrideConfig(), override)
+	require.NoError(t, err)
+
+	assert.Contains(t, merged.Processors.Object, "attributes")
+	assert.Contains(t, merged.Processors.Object, "batch")
+	assert.Equal(t, map[string]any{
+		"endpoint": "collector:4317",
+		"headers":  map[string]any{"tenant": "checkout"},
+	}, merged.Exporters.Object["otlp"])
+	assert.Equal(t, &v1beta1.Pipeline{
+		Receivers:  []string{"otlp"},
+		Processors: []string{"attributes", "batch"},
+		Exporters:  []string{"otlp"},
+	}, merged.Service.Pipelines["traces"])
+}
+
+func TestMergeConfigRemovesNullValues(t *testing.T) {
+	override := `
+exporters:
+  otlp:
+    headers: null
+`
+	merged, err := mergeConfig(baseOverrideConfig(), override)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"endpoint": "collector:4317"}, merged.Exporters.Object["otlp"])
+}
+
+func TestMergeConfigInvalid(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		override string
+	}{
+		{
+			name:     "empty",
+			override: "",
+		},
+		{
+			name:     "not yaml",
+			override: "receivers: [",
+		},
+		{
+			name:     "wrong type",
+			override: "service: {pipelines: {traces: {receivers: otlp}}}",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := mergeConfig(baseOverrideConfig(), tt.override)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestValidateReferences(t *testing.T) {
+	for _, tt := range []struct {
+		name        string
+		mutate      func(cfg *v1beta1.Config)
+		expectedErr string
+	}{
+		{
+			name:   "valid",
+			mutate: func(*v1beta1.Config) {},
+		},
+		{
+			name: "missing processor",
+			mutate: func(cfg *v1beta1.Config) {
+				cfg.Service.Pipelines["traces"].Processors = append(cfg.Service.Pipelines["traces"].Processors, "attributes")
+			},
+			expectedErr: `the pipeline "traces" references the processor "attributes", which is not configured`,
+		},
+		{
+			name: "connector",
+			mutate: func(cfg *v1beta1.Config) {
+				cfg.Connectors = &v1beta1.AnyConfig{Object: map[string]any{"spanmetrics": map[string]any{}}}
+				cfg.Service.Pipelines["traces"].Exporters = append(cfg.Service.Pipelines["traces"].Exporters, "spanmetrics")
+				cfg.Service.Pipelines["metrics"] = &v1beta1.Pipeline{
+					Receivers: []string{"spanmetrics"},
+					Exporters: []string{"otlp"},
+				}
+			},
+		},
+		{
+			name: "missing extension",
+			mutate: func(cfg *v1beta1.Config) {
+				cfg.Service.Extensions = []string{"health_check"}
+			},
+			expectedErr: `the service references the extension "health_check", which is not configured`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := baseOverrideConfig()
+			tt.mutate(&cfg)
+			err := validateReferences(&cfg)
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.expectedErr)
+		})
+	}
+}
+
+func TestApplyConfigOverride(t *testing.T) {
+	ns := corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "my-ns"}}
+	pod := corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+		Annotations: map[string]string{ConfigOverrideAnnotation: "my-override"},
+	}}
+	otelcol := v1beta1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{Name: "otelcol", Namespace: "my-ns"},
+		Spec:       v1beta1.OpenTelemetryCollectorSpec{Config: baseOverrideConfig()},
+	}
+	cfg := config.New()
+
+	for _, tt := range []struct {
+		name        string
+		configMaps  []corev1.ConfigMap
+		expectedErr string
+	}{
+		{
+			name: "valid override",
+			configMaps: []corev1.ConfigMap{{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-override", Namespace: "my-ns"},
+				Data:       map[string]string{"collector.yaml": "exporters: {otlp: {endpoint: 'other:4317'}}"},
+			}},
+		},
+		{
+			name: "configmap in another namespace",
+			configMaps: []corev1.ConfigMap{{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-override", Namespace: "other-ns"},
+				Data:       map[string]string{"collector.yaml": "exporters: {otlp: {endpoint: 'other:4317'}}"},
+			}},
+			expectedErr: "the config override ConfigMap my-ns/my-override doesn't exist",
+		},
+		{
+			name: "missing entry",
+			configMaps: []corev1.ConfigMap{{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-override", Namespace: "my-ns"},
+				Data:       map[string]string{"config.yaml": "exporters: {otlp: {endpoint: 'other:4317'}}"},
+			}},
+			expectedErr: `the config override ConfigMap my-ns/my-override has no "collector.yaml" entry`,
+		},
+		{
+			name: "invalid merged config",
+			configMaps: []corev1.ConfigMap{{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-override", Namespace: "my-ns"},
+				Data:       map[string]string{"collector.yaml": "service: {pipelines: {traces: {exporters: [debug]}}}"},
+			}},
+			expectedErr: "the config override my-ns/my-override is invalid",
+		},
+		{
+			name: "invalid ports",
+			configMaps: []corev1.ConfigMap{{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-override", Namespace: "my-ns"},
+				Data:       map[string]string{"collector.yaml": "receivers: {otlp: {protocols: {grpc: {endpoint: '0.0.0.0:99999'}}}}"},
+			}},
+			expectedErr: "num '99999' errors",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := fake.NewClientBuilder()
+			for i := range tt.configMaps {
+				builder = builder.WithObjects(&tt.configMaps[i])
+			}
+			validator := webhook.NewCollectorWebhook(logr.Discard(), nil, cfg, nil, nil, nil, nil, nil)
+			mutator := NewMutator(logr.Discard(), cfg, builder.Build(), validator)
+
+			changed, err := mutator.applyConfigOverride(context.Background(), ns, pod, otelcol)
+			if tt.expectedErr != "" {
+				assert.ErrorIs(t, err, errInvalidConfigOverride)
+				assert.ErrorIs(t, err, podmutation.ErrPodDenied)
+				assert.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "other:4317", changed.Spec.Config.Exporters.Object["otlp"].(map[string]any)["endpoint"])
+			// the given instance is left untouched
+			assert.Equal(t, "collector:4317", otelcol.Spec.Config.Exporters.Object["otlp"].(map[string]any)["endpoint"])
+		})
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sidecar
+
+import (
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+)
+
+const (
+	jobWatcherContainerName = "otc-job-watcher"
+	queueDrainContainerName = "otc-queue-drain"
+
+	// defaultTerminationGracePeriodSeconds is the grace period Kubernetes uses when the pod doesn't set one.
+	defaultTerminationGracePeriodSeconds = int64(30)
+)
+
+// jobWatcherScript waits for the other containers of the pod to exit and stops the collector. The watcher is the last
+// container of the pod, so the kubelet has already started the other ones when it runs. It relies on the process
+// namespace being shared: the pause process is PID 1, the collector is recognized by the config argument set by the
+// operator and the watcher by its name, passed as $0. The bracket in the collector pattern prevents grep from matching
+// its own command line.
+const jobWatcherScript = `collector() {
+  for p in /proc/[0-9]*; do
+    grep -q 'env:OTEL_CONFI[G]' "$p/cmdline" 2>/dev/null && echo "${p#/proc/}"
+  done
+}
+workload() {
+  for p in /proc/[0-9]*; do
+    [ "${p#/proc/}" = 1 ] && continue
+    grep -q -e 'env:OTEL_CONFI[G]' -e "$0" "$p/cmdline" 2>/dev/null && continue
+    grep -q . "$p/cmdline" 2>/dev/null && return 0
+  done
+  return 1
+}
+while workload; do sleep 1; done
+for pid in $(collector); do kill -TERM "$pid"; done
+while [ -n "$(collector)" ]; do sleep 1; done
+`
+
+// queueDrainScript waits for the sending queues of the collector's exporters to be empty, according to the
+// otelcol_exporter_queue_size metric the collector exposes on the port passed as $1. It returns right away when the
+// metrics can't be scraped, the collector then only flushes its queues once stopped.
+const queueDrainScript = `queued() {
+  wget -q -T 2 -O - "http://localhost:$1/metrics" 2>/dev/null | grep '^otelcol_exporter_queue_size[{ ]' | grep -qv ' 0$'
+}
+while queued "$1"; do sleep 1; done
+`
+
+// isJobPod returns whether the pod is run by a Job, including those created by a CronJob.
+func isJobPod(pod corev1.Pod) bool {
+	return findOwnerReferenceKind(pod.OwnerReferences, "Job") != ""
+}
+
+// jobWatcherContainer returns the container stopping the collector once the other containers of the pod exit, so that
+// Jobs can complete when native sidecars are not available. The collector flushes its exporters when stopped.
+func jobWatcherContainer(cfg config.Config, collector corev1.Container) corev1.Container {
+	return corev1.Container{
+		Name:            jobWatcherContainerName,
+		Image:           cfg.SidecarJobWatcherImage,
+		Command:         []string{"sh", "-c", jobWatcherScript, jobWatcherContainerName},
+		SecurityContext: helperSecurityContext(collector),
+	}
+}
+
+// queueDrainContainer returns the native sidecar delaying the termination of the collector of Job pods until the
+// sending queues of its exporters are drained. Native sidecars are stopped in the reverse order of their declaration,
+// so the preStop hook of this container, declared after the collector, runs while the collector still exports.
+func queueDrainContainer(cfg config.Config, collector corev1.Container, metricsPort int32) corev1.Container {
+	return corev1.Container{
+		Name:          queueDrainContainerName,
+		Image:         cfg.SidecarJobWatcherImage,
+		RestartPolicy: ptr.To(corev1.ContainerRestartPolicyAlways),
+		Command:       []string{"sh", "-c", "trap 'exit 0' TERM; while true; do sleep 1; done"},
+		Lifecycle: &corev1.Lifecycle{
+			PreStop: &corev1.LifecycleHandler{
+				Exec: &corev1.ExecAction{
+					Command: []string{"sh", "-c", queueDrainScript, queueDrainContainerName, strconv.Itoa(int(metricsPort))},
+				},
+			},
+		},
+		SecurityContext: helperSecurityContext(collector),
+	}
+}
+
+// helperSecurityContext returns the security context of the containers helping with the collector's shutdown: the
+// collector's one, so that they run as the same user, inheriting the pod's security context otherwise, like the
+// collector. Signals can only be sent to processes of the same user, unless the helper runs as root.
+func helperSecurityContext(collector corev1.Container) *corev1.SecurityContext {
+	if collector.SecurityContext == nil {
+		return nil
+	}
+	return collector.SecurityContext.DeepCopy()
+}
+
+// jobTerminationGracePeriod returns the grace period the Job pod needs so that the collector has the time to flush
+// its exporters when it's stopped after the other containers exit, or nil if the pod's one is long enough.
+func jobTerminationGracePeriod(otelcol v1beta1.OpenTelemetryCollector, pod corev1.Pod) *int64 {
+	if otelcol.Spec.TerminationGracePeriodSeconds == nil {
+		return nil
+	}
+	current := defaultTerminationGracePeriodSeconds
+	if pod.Spec.TerminationGracePeriodSeconds != nil {
+		current = *pod.Spec.TerminationGracePeriodSeconds
+	}
+	if current >= *otelcol.Spec.TerminationGracePeriodSeconds {
+		return nil
+	}
+	return ptr.To(*otelcol.Spec.TerminationGracePeriodSeconds)
+}
+
+func isJobWatcherContainer(c corev1.Container) bool { return c.Name == jobWatcherContainerName }
+
+func isQueueDrainContainer(c corev1.Container) bool { return c.Name == queueDrainContainerName }
@@ -0,0 +1,191 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sidecar
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	colfg "go.opentelemetry.io/collector/featuregate"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
+)
+
+func TestJobWatcherContainer(t *testing.T) {
+	cfg := config.Config{SidecarJobWatcherImage: "busybox:latest"}
+
+	container := jobWatcherContainer(cfg, corev1.Container{Name: naming.Container()})
+	assert.Equal(t, "otc-job-watcher", container.Name)
+	assert.Equal(t, "busybox:latest", container.Image)
+	assert.Equal(t, []string{"sh", "-c", jobWatcherScript, "otc-job-watcher"}, container.Command)
+	assert.Nil(t, container.SecurityContext, "the watcher inherits the pod's security context, like the collector")
+
+	// the watcher runs as the same user as the collector, to be able to stop it
+	collectorSecurityContext := &corev1.SecurityContext{RunAsUser: ptr.To(int64(2000)), RunAsNonRoot: ptr.To(true)}
+	container = jobWatcherContainer(cfg, corev1.Container{Name: naming.Container(), SecurityContext: collectorSecurityContext})
+	assert.Equal(t, collectorSecurityContext, container.SecurityContext)
+	assert.NotSame(t, collectorSecurityContext, container.SecurityContext)
+}
+
+func TestQueueDrainContainer(t *testing.T) {
+	cfg := config.Config{SidecarJobWatcherImage: "busybox:latest"}
+	collectorSecurityContext := &corev1.SecurityContext{RunAsUser: ptr.To(int64(2000))}
+
+	container := queueDrainContainer(cfg, corev1.Container{Name: naming.Container(), SecurityContext: collectorSecurityContext}, 9090)
+	assert.Equal(t, "otc-queue-drain", container.Name)
+	assert.Equal(t, "busybox:latest", container.Image)
+	assert.Equal(t, ptr.To(corev1.ContainerRestartPolicyAlways), container.RestartPolicy)
+	require.NotNil(t, container.Lifecycle)
+	require.NotNil(t, container.Lifecycle.PreStop)
+	assert.Equal(t, []string{"sh", "-c", queueDrainScript, "otc-queue-drain", "9090"}, container.Lifecycle.PreStop.Exec.Command)
+	assert.Equal(t, collectorSecurityContext, container.SecurityContext)
+}
+
+func TestJobTerminationGracePeriod(t *testing.T) {
+	for _, tt := range []struct {
+		name      string
+		collector *int64
+		pod       *int64
+		expected  *int64
+	}{
+		{
+			name: "not set",
+		},
+		{
+			name:      "longer than the default",
+			collector: ptr.To(int64(60)),
+			expected:  ptr.To(int64(60)),
+		},
+		{
+			name:      "shorter than the default",
+			collector: ptr.To(int64(10)),
+		},
+		{
+			name:      "longer than the pod's",
+			collector: ptr.To(int64(60)),
+			pod:       ptr.To(int64(5)),
+			expected:  ptr.To(int64(60)),
+		},
+		{
+			name:      "shorter than the pod's",
+			collector: ptr.To(int64(60)),
+			pod:       ptr.To(int64(120)),
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			otelcol := v1beta1.OpenTelemetryCollector{}
+			otelcol.Spec.TerminationGracePeriodSeconds = tt.collector
+			pod := corev1.Pod{Spec: corev1.PodSpec{TerminationGracePeriodSeconds: tt.pod}}
+			assert.Equal(t, tt.expected, jobTerminationGracePeriod(otelcol, pod))
+		})
+	}
+}
+
+func TestAddSidecarToJobPod(t *testing.T) {
+	otelcol := v1beta1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "otelcol-sample",
+			Namespace: "some-app",
+		},
+		Spec: v1beta1.OpenTelemetryCollectorSpec{
+			Mode: v1beta1.ModeSidecar,
+		},
+	}
+	otelcol.Spec.TerminationGracePeriodSeconds = ptr.To(int64(60))
+	jobOwner := []metav1.OwnerReference{{Kind: "Job", Name: "my-job"}}
+
+	for _, tt := range []struct {
+		name                   string
+		owners                 []metav1.OwnerReference
+		nativeSidecar          bool
+		watcherEnabled         bool
+		expectedContainers     []string
+		expectedInitContainers []string
+		expectedShareProcess   *bool
+		expectedGracePeriod    *int64
+	}{
+		{
+			name:                   "native sidecar",
+			owners:                 jobOwner,
+			nativeSidecar:          true,
+			watcherEnabled:         true,
+			expectedContainers:     []string{"my-app"},
+			expectedInitContainers: []string{naming.Container(), "otc-queue-drain"},
+			expectedGracePeriod:    ptr.To(int64(60)),
+		},
+		{
+			name:                   "native sidecar without drain",
+			owners:                 jobOwner,
+			nativeSidecar:          true,
+			expectedContainers:     []string{"my-app"},
+			expectedInitContainers: []string{naming.Container()},
+			expectedGracePeriod:    ptr.To(int64(60)),
+		},
+		{
+			name:                 "watcher",
+			owners:               jobOwner,
+			watcherEnabled:       true,
+			expectedContainers:   []string{"my-app", naming.Container(), "otc-job-watcher"},
+			expectedShareProcess: ptr.To(true),
+			expectedGracePeriod:  ptr.To(int64(60)),
+		},
+		{
+			name:                "watcher disabled",
+			owners:              jobOwner,
+			expectedContainers:  []string{"my-app", naming.Container()},
+			expectedGracePeriod: ptr.To(int64(60)),
+		},
+		{
+			name:               "not a job",
+			owners:             []metav1.OwnerReference{{Kind: "ReplicaSet", Name: "my-rs"}},
+			watcherEnabled:     true,
+			expectedContainers: []string{"my-app", naming.Container()},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			require.NoError(t, colfg.GlobalRegistry().Set(featuregate.EnableSidecarJobWatcher.ID(), tt.watcherEnabled))
+			t.Cleanup(func() {
+				require.NoError(t, colfg.GlobalRegistry().Set(featuregate.EnableSidecarJobWatcher.ID(), false))
+			})
+			cfg := config.Config{
+				CollectorImage:         "some-default-image",
+				SidecarJobWatcherImage: "busybox:latest",
+				Internal:               config.Internal{NativeSidecarSupport: tt.nativeSidecar},
+			}
+			pod := corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{OwnerReferences: tt.owners},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "my-app"}},
+				},
+			}
+
+			changed, err := add(cfg, logger, otelcol, pod, nil)
+			require.NoError(t, err)
+
+			var containers, initContainers []string
+			for _, c := range changed.Spec.Containers {
+				containers = append(containers, c.Name)
+			}
+			for _, c := range changed.Spec.InitContainers {
+				initContainers = append(initContainers, c.Name)
+			}
+			assert.Equal(t, tt.expectedContainers, containers)
+			assert.Equal(t, tt.expectedInitContainers, initContainers)
+			assert.Equal(t, tt.expectedShareProcess, changed.Spec.ShareProcessNamespace)
+			assert.Equal(t, tt.expectedGracePeriod, changed.Spec.TerminationGracePeriodSeconds)
+
+			// removing the sidecar removes the watcher and the drain container as well
+			removed := remove(tt.nativeSidecar, changed)
+			assert.Equal(t, []corev1.Container{{Name: "my-app"}}, removed.Spec.Containers)
+			assert.Empty(t, removed.Spec.InitContainers)
+		})
+	}
+}
@@ -10,11 +10,14 @@ import (
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector"
 	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+	"github.com/open-telemetry/opentelemetry-operator/internal/otelconfig"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
 )
 
 const (
@@ -24,6 +27,16 @@ const (
 
 // add a new sidecar container to the given pod, based on the given OpenTelemetryCollector.
 func add(cfg config.Config, logger logr.Logger, otelcol v1beta1.OpenTelemetryCollector, pod corev1.Pod, attributes []corev1.EnvVar) (corev1.Pod, error) {
+	var podMetadata []podMetadataItem
+	if otelcol.Spec.PodMetadataAttributes != nil {
+		podMetadata = podMetadataItems(otelcol.Spec.PodMetadataAttributes, pod.ObjectMeta)
+	}
+	if len(podMetadata) > 0 {
+		// the config is shared with the caller, make sure we don't modify it
+		otelcol = *otelcol.DeepCopy()
+		addPodMetadataProcessor(&otelcol.Spec.Config, podMetadata)
+	}
+
 	otelColCfg, err := collector.ReplaceConfig(otelcol, nil)
 	if err != nil {
 		return pod, err
@@ -31,6 +44,15 @@ func add(cfg config.Config, logger logr.Logger, otelcol v1beta1.OpenTelemetryCol
 
 	container := collector.Container(cfg, logger, otelcol, false, nil)
 	container.Args = append(container.Args, fmt.Sprintf("--config=env:%s", confEnvVar))
+	if len(podMetadata) > 0 {
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+			Name:      podMetadataVolumeName,
+			MountPath: podMetadataMountPath,
+			ReadOnly:  true,
+		})
+		pod.Spec.Volumes = append(pod.Spec.Volumes, podMetadataVolume(podMetadata))
+		container.Env = append(container.Env, corev1.EnvVar{Name: podMetadataStringEnvVar})
+	}
 
 	container.Env = append(container.Env, corev1.EnvVar{Name: confEnvVar, Value: otelColCfg})
 	if !hasResourceAttributeEnvVar(container.Env) {
@@ -50,6 +72,26 @@ func add(cfg config.Config, logger logr.Logger, otelcol v1beta1.OpenTelemetryCol
 	} else {
 		pod.Spec.Containers = append(pod.Spec.Containers, container)
 	}
+
+	if isJobPod(pod) {
+		// the kubelet stops native sidecars once the other containers exit, the collector needs the time to drain
+		// the sending queues of its exporters. Without native sidecars, a watcher stops it so that the Job can complete.
+		if gracePeriod := jobTerminationGracePeriod(otelcol, pod); gracePeriod != nil {
+			pod.Spec.TerminationGracePeriodSeconds = gracePeriod
+		}
+		if featuregate.EnableSidecarJobWatcher.IsEnabled() {
+			if cfg.Internal.NativeSidecarSupport {
+				if _, metricsPort, metricsErr := otelconfig.MetricsEndpoint(&otelcol.Spec.Config.Service, logger); metricsErr != nil {
+					logger.Info("the collector's metrics port is unknown, its exporter queues won't be drained before it's stopped", "error", metricsErr.Error())
+				} else {
+					pod.Spec.InitContainers = append(pod.Spec.InitContainers, queueDrainContainer(cfg, container, metricsPort))
+				}
+			} else {
+				pod.Spec.ShareProcessNamespace = ptr.To(true)
+				pod.Spec.Containers = append(pod.Spec.Containers, jobWatcherContainer(cfg, container))
+			}
+		}
+	}
 	pod.Spec.Volumes = append(pod.Spec.Volumes, otelcol.Spec.Volumes...)
 
 	for _, cm := range otelcol.Spec.ConfigMaps {
@@ -85,11 +127,16 @@ func remove(useNativeSidecars bool, pod corev1.Pod) corev1.Pod {
 	}
 
 	pod.Spec.Containers = slices.DeleteFunc(pod.Spec.Containers, isOtelColContainer)
+	pod.Spec.Containers = slices.DeleteFunc(pod.Spec.Containers, isJobWatcherContainer)
+	pod.Spec.Volumes = slices.DeleteFunc(pod.Spec.Volumes, func(volume corev1.Volume) bool {
+		return volume.Name == podMetadataVolumeName
+	})
 
 	if useNativeSidecars {
 		// NOTE: we also remove init containers (native sidecars) since k8s 1.28.
 		// This should have no side effects.
 		pod.Spec.InitContainers = slices.DeleteFunc(pod.Spec.InitContainers, isOtelColContainer)
+		pod.Spec.InitContainers = slices.DeleteFunc(pod.Spec.InitContainers, isQueueDrainContainer)
 	}
 	return pod
 }
@@ -283,6 +283,7 @@ func TestRemoveSidecar(t *testing.T) {
 				{Name: "my-app"},
 				{Name: naming.Container()},
 				{Name: naming.Container()}, // two sidecars! should remove both
+				{Name: "otc-job-watcher"},
 			},
 			InitContainers: []corev1.Container{
 				{Name: "something"},
@@ -299,6 +300,25 @@ func TestRemoveSidecar(t *testing.T) {
 	assert.Len(t, changed.Spec.Containers, 1)
 }
 
+func TestRemoveSidecarPodMetadataVolume(t *testing.T) {
+	pod := corev1.Pod{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{Name: "my-app"},
+				{Name: naming.Container()},
+			},
+			Volumes: []corev1.Volume{
+				{Name: "my-volume"},
+				{Name: podMetadataVolumeName},
+			},
+		},
+	}
+
+	changed := remove(false, pod)
+
+	assert.Equal(t, []corev1.Volume{{Name: "my-volume"}}, changed.Spec.Volumes)
+}
+
 func TestRemoveNonExistingSidecar(t *testing.T) {
 	// prepare
 	pod := corev1.Pod{
@@ -401,3 +421,67 @@ func TestAddSidecarWithAditionalEnv(t *testing.T) {
 	assert.Len(t, changed.Spec.Containers, 2)
 	assert.Contains(t, changed.Spec.Containers[1].Env, extraEnv)
 }
+
+func TestAddSidecarWithPodMetadataAttributes(t *testing.T) {
+	// prepare
+	pod := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: map[string]string{"app.kubernetes.io/version": "1.0"},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{Name: "my-app"},
+			},
+		},
+	}
+	otelcol := v1beta1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "otelcol-sample",
+			Namespace: "some-app",
+		},
+		Spec: v1beta1.OpenTelemetryCollectorSpec{
+			Mode: v1beta1.ModeSidecar,
+			Config: v1beta1.Config{
+				Receivers: v1beta1.AnyConfig{Object: map[string]any{"otlp": map[string]any{}}},
+				Exporters: v1beta1.AnyConfig{Object: map[string]any{"debug": map[string]any{}}},
+				Service: v1beta1.Service{
+					Pipelines: map[string]*v1beta1.Pipeline{
+						"traces": {Receivers: []string{"otlp"}, Exporters: []string{"debug"}},
+					},
+				},
+			},
+			PodMetadataAttributes: &v1beta1.PodMetadataAttributes{
+				Labels: map[string]string{"app.kubernetes.io/version": "service.version"},
+			},
+		},
+	}
+	cfg := config.Config{
+		CollectorImage: "some-default-image",
+	}
+
+	// test
+	changed, err := add(cfg, logger, otelcol, pod, nil)
+
+	// verify
+	require.NoError(t, err)
+	require.Len(t, changed.Spec.Containers, 2)
+	require.Len(t, changed.Spec.Volumes, 1)
+	assert.Equal(t, "otc-pod-metadata", changed.Spec.Volumes[0].Name)
+	assert.Contains(t, changed.Spec.Containers[1].VolumeMounts, corev1.VolumeMount{
+		Name:      "otc-pod-metadata",
+		MountPath: "/etc/otelcol/pod-metadata",
+		ReadOnly:  true,
+	})
+	var otelConfig string
+	for _, env := range changed.Spec.Containers[1].Env {
+		if env.Name == confEnvVar {
+			otelConfig = env.Value
+		}
+	}
+	assert.Contains(t, otelConfig, "${file:/etc/otelcol/pod-metadata/labels/app.kubernetes.io%2Fversion}${env:OTEL_POD_METADATA_STRING}")
+	assert.Contains(t, changed.Spec.Containers[1].Env, corev1.EnvVar{Name: "OTEL_POD_METADATA_STRING"})
+	assert.Contains(t, otelConfig, "resource/pod-metadata")
+	// the original collector must not be modified
+	assert.Nil(t, otelcol.Spec.Config.Processors)
+	assert.Empty(t, otelcol.Spec.Config.Service.Pipelines["traces"].Processors)
+}
@@ -0,0 +1,133 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sidecar
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+)
+
+const (
+	podMetadataVolumeName    = "otc-pod-metadata"
+	podMetadataMountPath     = "/etc/otelcol/pod-metadata"
+	podMetadataProcessorName = "resource/pod-metadata"
+	memoryLimiterProcessor   = "memory_limiter"
+	// podMetadataStringEnvVar is set to an empty string in the sidecar container. Appending it to the file
+	// references turns them into string interpolations, which the collector resolves to the text of the files rather
+	// than parsing it as YAML, so that values like `1.0`, `true` or `0755` don't become numbers or booleans.
+	podMetadataStringEnvVar = "OTEL_POD_METADATA_STRING"
+)
+
+// podMetadataItem is a single label or annotation projected into the sidecar.
+type podMetadataItem struct {
+	fieldPath string
+	file      string
+	attribute string
+}
+
+// podMetadataItems returns the labels and annotations to project, sorted to make the generated volume and config predictable.
+// Keys the pod doesn't have are skipped, as their files would be empty and the attributes would have no value.
+func podMetadataItems(spec *v1beta1.PodMetadataAttributes, podMeta metav1.ObjectMeta) []podMetadataItem {
+	var items []podMetadataItem
+	for _, source := range []struct {
+		field string
+		keys  map[string]string
+		pod   map[string]string
+	}{
+		{field: "labels", keys: spec.Labels, pod: podMeta.Labels},
+		{field: "annotations", keys: spec.Annotations, pod: podMeta.Annotations},
+	} {
+		keys := make([]string, 0, len(source.keys))
+		for k := range source.keys {
+			if _, ok := source.pod[k]; ok {
+				keys = append(keys, k)
+			}
+		}
+		slices.Sort(keys)
+		for _, k := range keys {
+			items = append(items, podMetadataItem{
+				fieldPath: fmt.Sprintf("metadata.%s['%s']", source.field, k),
+				// label and annotation keys may contain a prefix separated by a slash, which would otherwise
+				// turn into a nested directory in the volume. Escaping keeps distinct keys in distinct files.
+				file:      path.Join(source.field, url.PathEscape(k)),
+				attribute: source.keys[k],
+			})
+		}
+	}
+	return items
+}
+
+// podMetadataVolume returns the Downward API volume exposing the configured pod labels and annotations as files.
+// The kubelet keeps the files up to date when the pod metadata changes, but the collector only reads them when it
+// loads its configuration, so a running collector sees the changes only after its container restarts.
+func podMetadataVolume(items []podMetadataItem) corev1.Volume {
+	files := make([]corev1.DownwardAPIVolumeFile, 0, len(items))
+	for _, item := range items {
+		files = append(files, corev1.DownwardAPIVolumeFile{
+			Path: item.file,
+			FieldRef: &corev1.ObjectFieldSelector{
+				FieldPath: item.fieldPath,
+			},
+		})
+	}
+	return corev1.Volume{
+		Name: podMetadataVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			DownwardAPI: &corev1.DownwardAPIVolumeSource{
+				Items: files,
+			},
+		},
+	}
+}
+
+// addPodMetadataProcessor adds a resource processor reading the projected pod metadata files to the given config and
+// inserts it into every pipeline, right after the memory_limiter processor when the pipeline starts with one. A
+// processor of the same name in the config is kept, with the pod metadata attributes appended to its own.
+func addPodMetadataProcessor(cfg *v1beta1.Config, items []podMetadataItem) {
+	attributes := make([]any, 0, len(items))
+	for _, item := range items {
+		attributes = append(attributes, map[string]any{
+			"key":    item.attribute,
+			"value":  fmt.Sprintf("${file:%s}${env:%s}", path.Join(podMetadataMountPath, item.file), podMetadataStringEnvVar),
+			"action": "upsert",
+		})
+	}
+
+	if cfg.Processors == nil {
+		cfg.Processors = &v1beta1.AnyConfig{}
+	}
+	if cfg.Processors.Object == nil {
+		cfg.Processors.Object = map[string]any{}
+	}
+	processor, ok := cfg.Processors.Object[podMetadataProcessorName].(map[string]any)
+	if !ok {
+		processor = map[string]any{}
+	}
+	existing, _ := processor["attributes"].([]any)
+	processor["attributes"] = append(slices.Clone(existing), attributes...)
+	cfg.Processors.Object[podMetadataProcessorName] = processor
+
+	for _, pipeline := range cfg.Service.Pipelines {
+		if pipeline == nil || slices.Contains(pipeline.Processors, podMetadataProcessorName) {
+			continue
+		}
+		idx := 0
+		if len(pipeline.Processors) > 0 && isMemoryLimiter(pipeline.Processors[0]) {
+			idx = 1
+		}
+		pipeline.Processors = slices.Insert(pipeline.Processors, idx, podMetadataProcessorName)
+	}
+}
+
+func isMemoryLimiter(id string) bool {
+	return id == memoryLimiterProcessor || strings.HasPrefix(id, memoryLimiterProcessor+"/")
+}
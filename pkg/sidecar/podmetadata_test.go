@@ -0,0 +1,164 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sidecar
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+)
+
+func TestPodMetadataVolume(t *testing.T) {
+	items := podMetadataItems(&v1beta1.PodMetadataAttributes{
+		Labels: map[string]string{
+			"team":                      "team.name",
+			"app.kubernetes.io/version": "service.version",
+		},
+		Annotations: map[string]string{
+			"example.com/owner": "owner",
+		},
+	}, metav1.ObjectMeta{
+		Labels:      map[string]string{"team": "a", "app.kubernetes.io/version": "1.0"},
+		Annotations: map[string]string{"example.com/owner": "b"},
+	})
+
+	volume := podMetadataVolume(items)
+
+	assert.Equal(t, podMetadataVolumeName, volume.Name)
+	require.NotNil(t, volume.DownwardAPI)
+	assert.Equal(t, []corev1.DownwardAPIVolumeFile{
+		{
+			Path:     "labels/app.kubernetes.io%2Fversion",
+			FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.labels['app.kubernetes.io/version']"},
+		},
+		{
+			Path:     "labels/team",
+			FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.labels['team']"},
+		},
+		{
+			Path:     "annotations/example.com%2Fowner",
+			FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.annotations['example.com/owner']"},
+		},
+	}, volume.DownwardAPI.Items)
+}
+
+func TestPodMetadataItemsDistinctFiles(t *testing.T) {
+	items := podMetadataItems(&v1beta1.PodMetadataAttributes{
+		Labels: map[string]string{
+			"example.com/team": "team.name",
+			"example.com_team": "team.alias",
+		},
+	}, metav1.ObjectMeta{
+		Labels: map[string]string{"example.com/team": "a", "example.com_team": "a"},
+	})
+
+	require.Len(t, items, 2)
+	assert.NotEqual(t, items[0].file, items[1].file)
+}
+
+func TestPodMetadataItemsSkipsMissingKeys(t *testing.T) {
+	items := podMetadataItems(&v1beta1.PodMetadataAttributes{
+		Labels:      map[string]string{"team": "team.name", "app.kubernetes.io/version": "service.version"},
+		Annotations: map[string]string{"example.com/owner": "owner"},
+	}, metav1.ObjectMeta{
+		Labels:      map[string]string{"app.kubernetes.io/version": ""},
+		Annotations: map[string]string{"team": "a"},
+	})
+
+	require.Len(t, items, 1)
+	assert.Equal(t, "service.version", items[0].attribute)
+}
+
+func TestAddPodMetadataProcessor(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		pipeline []string
+		expected []string
+	}{
+		{
+			name:     "no processors",
+			pipeline: nil,
+			expected: []string{"resource/pod-metadata"},
+		},
+		{
+			name:     "existing processors",
+			pipeline: []string{"batch"},
+			expected: []string{"resource/pod-metadata", "batch"},
+		},
+		{
+			name:     "after memory limiter",
+			pipeline: []string{"memory_limiter/custom", "batch"},
+			expected: []string{"memory_limiter/custom", "resource/pod-metadata", "batch"},
+		},
+		{
+			name:     "already present",
+			pipeline: []string{"batch", "resource/pod-metadata"},
+			expected: []string{"batch", "resource/pod-metadata"},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := v1beta1.Config{
+				Service: v1beta1.Service{
+					Pipelines: map[string]*v1beta1.Pipeline{
+						"traces": {Processors: tt.pipeline},
+					},
+				},
+			}
+
+			addPodMetadataProcessor(&cfg, podMetadataItems(&v1beta1.PodMetadataAttributes{
+				Labels: map[string]string{"app.kubernetes.io/version": "service.version"},
+			}, metav1.ObjectMeta{Labels: map[string]string{"app.kubernetes.io/version": "1.0"}}))
+
+			assert.Equal(t, tt.expected, cfg.Service.Pipelines["traces"].Processors)
+			require.NotNil(t, cfg.Processors)
+			assert.Equal(t, map[string]any{
+				"attributes": []any{
+					map[string]any{
+						"key":    "service.version",
+						"value":  "${file:/etc/otelcol/pod-metadata/labels/app.kubernetes.io%2Fversion}${env:OTEL_POD_METADATA_STRING}",
+						"action": "upsert",
+					},
+				},
+			}, cfg.Processors.Object["resource/pod-metadata"])
+		})
+	}
+}
+
+func TestAddPodMetadataProcessorMergesExisting(t *testing.T) {
+	cfg := v1beta1.Config{
+		Processors: &v1beta1.AnyConfig{Object: map[string]any{
+			"resource/pod-metadata": map[string]any{
+				"attributes": []any{
+					map[string]any{"key": "deployment.environment", "value": "prod", "action": "insert"},
+				},
+			},
+		}},
+		Service: v1beta1.Service{
+			Pipelines: map[string]*v1beta1.Pipeline{
+				"traces": {Processors: []string{"resource/pod-metadata", "batch"}},
+			},
+		},
+	}
+
+	addPodMetadataProcessor(&cfg, podMetadataItems(&v1beta1.PodMetadataAttributes{
+		Annotations: map[string]string{"example.com/owner": "team.name"},
+	}, metav1.ObjectMeta{Annotations: map[string]string{"example.com/owner": "team-a"}}))
+
+	assert.Equal(t, []string{"resource/pod-metadata", "batch"}, cfg.Service.Pipelines["traces"].Processors)
+	assert.Equal(t, map[string]any{
+		"attributes": []any{
+			map[string]any{"key": "deployment.environment", "value": "prod", "action": "insert"},
+			map[string]any{
+				"key":    "team.name",
+				"value":  "${file:/etc/otelcol/pod-metadata/annotations/example.com%2Fowner}${env:OTEL_POD_METADATA_STRING}",
+				"action": "upsert",
+			},
+		},
+	}, cfg.Processors.Object["resource/pod-metadata"])
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/go-logr/logr"
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -27,18 +28,22 @@ var (
 )
 
 type sidecarPodMutator struct {
-	client client.Client
-	logger logr.Logger
-	config config.Config
+	client    client.Client
+	logger    logr.Logger
+	validator CollectorValidator
+	config    config.Config
 }
 
 var _ podmutation.PodMutator = (*sidecarPodMutator)(nil)
 
-func NewMutator(logger logr.Logger, config config.Config, client client.Client) *sidecarPodMutator {
+// NewMutator returns the sidecar PodMutator. The validator, when not nil, validates the instances resulting from the
+// pods' config overrides.
+func NewMutator(logger logr.Logger, config config.Config, client client.Client, validator CollectorValidator) *sidecarPodMutator {
 	return &sidecarPodMutator{
-		config: config,
-		logger: logger,
-		client: client,
+		config:    config,
+		logger:    logger,
+		client:    client,
+		validator: validator,
 	}
 }
 
@@ -78,6 +83,16 @@ func (p *sidecarPodMutator) Mutate(ctx context.Context, ns corev1.Namespace, pod
 		return pod, err
 	}
 
+	// does the pod want a config different from the instance's one?
+	if pod.Annotations[ConfigOverrideAnnotation] != "" {
+		otelcol, err = p.applyConfigOverride(ctx, ns, pod, otelcol)
+		if err != nil {
+			// an invalid override denies the pod, rather than creating it without the sidecar it asked for
+			logger.Error(err, "failed to apply the config override for this pod's sidecar")
+			return pod, err
+		}
+	}
+
 	// getting pod references, if any
 	references := p.podReferences(ctx, pod.OwnerReferences, ns)
 	attributes := getResourceAttributesEnv(ns, references)
@@ -151,6 +166,10 @@ func (p *sidecarPodMutator) podReferences(ctx context.Context, ownerReferences [
 			references.deployment = deployment
 		}
 	}
+	job := p.getJobReference(ctx, ownerReferences, ns)
+	if job != nil {
+		references.job = job
+	}
 	return *references
 }
 
@@ -178,6 +197,18 @@ func (p *sidecarPodMutator) getDeploymentReference(ctx context.Context, replicaS
 	return nil
 }
 
+func (p *sidecarPodMutator) getJobReference(ctx context.Context, ownerReferences []metav1.OwnerReference, ns corev1.Namespace) *batchv1.Job {
+	jobName := findOwnerReferenceKind(ownerReferences, "Job")
+	if jobName != "" {
+		job := &batchv1.Job{}
+		err := p.client.Get(ctx, types.NamespacedName{Name: jobName, Namespace: ns.Name}, job)
+		if err == nil {
+			return job
+		}
+	}
+	return nil
+}
+
 func findOwnerReferenceKind(references []metav1.OwnerReference, kind string) string {
 	for _, reference := range references {
 		if reference.Kind == kind {